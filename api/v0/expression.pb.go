@@ -6,7 +6,7 @@ package capsule8_api_v0
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
-import google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
+import google_protobuf1 "github.com/golang/protobuf/ptypes/timestamp"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -164,7 +164,7 @@ type Value_DoubleValue struct {
 	DoubleValue float64 `protobuf:"fixed64,14,opt,name=double_value,json=doubleValue,oneof"`
 }
 type Value_TimestampValue struct {
-	TimestampValue *google_protobuf.Timestamp `protobuf:"bytes,15,opt,name=timestamp_value,json=timestampValue,oneof"`
+	TimestampValue *google_protobuf1.Timestamp `protobuf:"bytes,15,opt,name=timestamp_value,json=timestampValue,oneof"`
 }
 
 func (*Value_SignedValue) isValue_Value()    {}
@@ -223,7 +223,7 @@ func (m *Value) GetDoubleValue() float64 {
 	return 0
 }
 
-func (m *Value) GetTimestampValue() *google_protobuf.Timestamp {
+func (m *Value) GetTimestampValue() *google_protobuf1.Timestamp {
 	if x, ok := m.GetValue().(*Value_TimestampValue); ok {
 		return x.TimestampValue
 	}
@@ -319,7 +319,7 @@ func _Value_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer)
 		if wire != proto.WireBytes {
 			return true, proto.ErrInternalBadWireType
 		}
-		msg := new(google_protobuf.Timestamp)
+		msg := new(google_protobuf1.Timestamp)
 		err := b.DecodeMessage(msg)
 		m.Value = &Value_TimestampValue{msg}
 		return true, err
@@ -582,47 +582,46 @@ func init() {
 func init() { proto.RegisterFile("capsule8/api/v0/expression.proto", fileDescriptor4) }
 
 var fileDescriptor4 = []byte{
-	// 659 bytes of a gzipped FileDescriptorProto
+	// 655 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xdf, 0x6e, 0xda, 0x4a,
-	0x10, 0xc6, 0x6d, 0xc2, 0x3f, 0x8f, 0x03, 0xac, 0x56, 0x27, 0x39, 0x84, 0x1c, 0x25, 0x16, 0xe7,
-	0xa2, 0x56, 0xa4, 0x9a, 0xd4, 0x89, 0x22, 0xae, 0x2a, 0x41, 0xd8, 0xc6, 0x56, 0x1d, 0x9b, 0xda,
-	0x26, 0x6d, 0xaf, 0x10, 0x34, 0x0e, 0x58, 0x22, 0xd8, 0xb2, 0x71, 0xd4, 0x3c, 0x58, 0xef, 0xfb,
-	0x12, 0x7d, 0x91, 0x3e, 0x41, 0xb5, 0xbb, 0x98, 0x26, 0x4d, 0xd4, 0xf6, 0x6a, 0xc6, 0xdf, 0xfe,
-	0x66, 0x96, 0xf9, 0x86, 0x05, 0xe5, 0xd3, 0x24, 0x4e, 0xb3, 0x45, 0xd0, 0xed, 0x4c, 0xe2, 0xb0,
-	0x73, 0x77, 0xdc, 0x09, 0x3e, 0xc7, 0x49, 0x90, 0xa6, 0x61, 0xb4, 0xd4, 0xe2, 0x24, 0x5a, 0x45,
-	0xb8, 0x91, 0x13, 0xda, 0x24, 0x0e, 0xb5, 0xbb, 0xe3, 0xd6, 0xe1, 0x2c, 0x8a, 0x66, 0x8b, 0xa0,
-	0xc3, 0x8e, 0xa7, 0xd9, 0x4d, 0x67, 0x15, 0xde, 0x06, 0xe9, 0x6a, 0x72, 0x1b, 0xf3, 0x8a, 0xf6,
-	0xd7, 0x02, 0x94, 0xae, 0x26, 0x8b, 0x2c, 0xc0, 0x1a, 0x14, 0x57, 0xf7, 0x71, 0xd0, 0x14, 0x15,
-	0x51, 0xad, 0xeb, 0x2d, 0xed, 0x97, 0x56, 0x1a, 0xa3, 0xfc, 0xfb, 0x38, 0x70, 0x19, 0x87, 0xff,
-	0x87, 0xed, 0x34, 0x9c, 0x2d, 0x83, 0xeb, 0xf1, 0x1d, 0x3d, 0x69, 0x82, 0x22, 0xaa, 0xd8, 0x10,
-	0x5c, 0x99, 0xab, 0xbc, 0xe9, 0x0b, 0xa8, 0x67, 0xcb, 0x47, 0x98, 0xac, 0x88, 0x6a, 0xd1, 0x10,
-	0xdc, 0x5a, 0xae, 0x73, 0x90, 0x76, 0x5b, 0x25, 0xe1, 0x72, 0xb6, 0xc6, 0xb6, 0x15, 0x51, 0x95,
-	0x58, 0x37, 0xa6, 0x72, 0xe8, 0x10, 0x60, 0x1a, 0x45, 0x8b, 0x35, 0x52, 0x53, 0x44, 0xb5, 0x6a,
-	0x08, 0xae, 0x44, 0xb5, 0x4d, 0x97, 0xeb, 0x28, 0x9b, 0x2e, 0x82, 0x35, 0x52, 0x57, 0x44, 0x55,
-	0xa4, 0x5d, 0xb8, 0xca, 0x21, 0x02, 0x8d, 0x8d, 0x0b, 0x6b, 0xae, 0xa1, 0x88, 0xaa, 0xac, 0xb7,
-	0x34, 0xee, 0x96, 0x96, 0xbb, 0xa5, 0xf9, 0x39, 0x67, 0x08, 0x6e, 0x7d, 0x53, 0xc4, 0xda, 0xf4,
-	0x2b, 0x50, 0x62, 0xc5, 0xed, 0x39, 0x54, 0xfb, 0xe1, 0x72, 0x92, 0xdc, 0x3b, 0x31, 0x7e, 0x09,
-	0x5b, 0x8b, 0x79, 0xca, 0x3c, 0x94, 0xf5, 0xfd, 0x27, 0x1e, 0x92, 0xcd, 0xc2, 0x5c, 0xca, 0x51,
-	0x3c, 0x99, 0xa7, 0xcd, 0xc2, 0x5f, 0xe0, 0xc9, 0x3c, 0x6d, 0x7f, 0xdf, 0x02, 0xf8, 0xa9, 0xe1,
-	0xd7, 0x8f, 0x36, 0x76, 0xf4, 0x9b, 0xf2, 0x07, 0xe9, 0x83, 0x0d, 0x2a, 0x00, 0xe1, 0x75, 0xb0,
-	0x5c, 0x85, 0x37, 0x61, 0x90, 0xb0, 0xfd, 0x51, 0xc7, 0x1f, 0x68, 0x58, 0x5b, 0xcf, 0xc8, 0xb6,
-	0x26, 0xeb, 0xbb, 0xcf, 0xff, 0x29, 0x0c, 0xc1, 0xe5, 0x18, 0xee, 0x82, 0x34, 0x65, 0x56, 0x8c,
-	0xa3, 0x98, 0xad, 0x50, 0xd6, 0xf7, 0x9e, 0xd4, 0xe4, 0x66, 0x19, 0x82, 0x5b, 0x9d, 0xe6, 0xc6,
-	0x75, 0xa1, 0x9a, 0xe5, 0x85, 0xb5, 0x3f, 0xda, 0x61, 0x08, 0x6e, 0x25, 0xe3, 0x95, 0xed, 0x6f,
-	0x22, 0xd4, 0x1f, 0x8f, 0x87, 0x0f, 0xa0, 0x45, 0x3e, 0x0c, 0x5d, 0xe2, 0x79, 0xa6, 0x63, 0xfb,
-	0x1f, 0x87, 0x64, 0x3c, 0xb2, 0xbd, 0x21, 0x39, 0x37, 0xdf, 0x98, 0x64, 0x80, 0x04, 0x5c, 0x07,
-	0x30, 0x07, 0xc4, 0xf6, 0xe9, 0xb7, 0x8b, 0x44, 0x2c, 0x41, 0xe9, 0xaa, 0x67, 0x8d, 0x08, 0x2a,
-	0xe0, 0x06, 0xc8, 0x96, 0x73, 0x61, 0x9e, 0xf7, 0xac, 0x71, 0xcf, 0x1e, 0x20, 0xa0, 0x6c, 0x2e,
-	0x38, 0x2e, 0x92, 0x71, 0x19, 0x0a, 0xe4, 0x1d, 0xfa, 0x87, 0x46, 0x9b, 0xa0, 0x1d, 0x1a, 0x2d,
-	0x1f, 0xed, 0xb2, 0x48, 0xd0, 0xbf, 0x34, 0x5e, 0xf8, 0xa8, 0xc9, 0x22, 0x41, 0x7b, 0xb8, 0x0a,
-	0x45, 0xcb, 0x7c, 0x4b, 0x50, 0x0b, 0xcb, 0x50, 0x31, 0xbd, 0xb1, 0x3d, 0xb2, 0x2c, 0xb4, 0x4f,
-	0xef, 0xa1, 0x1f, 0x8e, 0xcf, 0x85, 0xff, 0xa8, 0xd0, 0x37, 0xfd, 0xf7, 0xa6, 0x47, 0xd8, 0xc5,
-	0x07, 0xfd, 0x32, 0x14, 0xe9, 0xfb, 0x3e, 0xfa, 0x22, 0x82, 0xb4, 0x79, 0x7b, 0x78, 0x0f, 0x76,
-	0xd8, 0x4f, 0x7d, 0x66, 0x2a, 0x80, 0xb2, 0xe7, 0xbb, 0xa6, 0x7d, 0xc1, 0x27, 0xf2, 0x4c, 0xdb,
-	0xef, 0xa2, 0x02, 0x93, 0x4d, 0xdb, 0x7f, 0x75, 0x86, 0xb6, 0xf2, 0xfc, 0x44, 0x47, 0xc5, 0x3c,
-	0x3f, 0x3b, 0x45, 0x25, 0x8a, 0x8f, 0x18, 0x5e, 0xa6, 0xf2, 0x88, 0xe3, 0x95, 0x3c, 0x3f, 0xd1,
-	0x51, 0x35, 0xcf, 0xcf, 0x4e, 0x91, 0x44, 0x67, 0xea, 0x3b, 0x8e, 0x85, 0x80, 0xaa, 0x03, 0x67,
-	0xd4, 0xb7, 0x08, 0x92, 0x71, 0x0d, 0x24, 0xdf, 0xbc, 0x24, 0x9e, 0xdf, 0xbb, 0x1c, 0xa2, 0xed,
-	0x69, 0x99, 0xbd, 0xa2, 0x93, 0x1f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xab, 0x9f, 0x14, 0x60, 0xb6,
-	0x04, 0x00, 0x00,
+	0x10, 0xc6, 0x6d, 0xc2, 0x3f, 0x8f, 0x03, 0xac, 0x56, 0x27, 0x39, 0x84, 0x54, 0x89, 0x45, 0x2f,
+	0x6a, 0x45, 0xaa, 0x49, 0x9d, 0x28, 0xe2, 0xaa, 0x12, 0x84, 0x6d, 0x6c, 0xd5, 0xb1, 0xa9, 0x6d,
+	0xd2, 0xf6, 0x0a, 0x41, 0xe3, 0x80, 0x25, 0x62, 0x5b, 0x36, 0x8e, 0x9a, 0x07, 0xeb, 0x7d, 0x5f,
+	0xa2, 0x2f, 0xd2, 0x27, 0xa8, 0x76, 0x8d, 0x09, 0x69, 0xa2, 0xb6, 0x57, 0x33, 0xfe, 0xf6, 0x37,
+	0xb3, 0xcc, 0x37, 0x2c, 0x48, 0x5f, 0x26, 0x51, 0x92, 0x2e, 0xbc, 0x6e, 0x67, 0x12, 0xf9, 0x9d,
+	0xbb, 0xe3, 0x8e, 0xf7, 0x35, 0x8a, 0xbd, 0x24, 0xf1, 0xc3, 0x40, 0x89, 0xe2, 0x70, 0x19, 0xe2,
+	0x46, 0x4e, 0x28, 0x93, 0xc8, 0x57, 0xee, 0x8e, 0x5b, 0x87, 0xb3, 0x30, 0x9c, 0x2d, 0xbc, 0x0e,
+	0x3b, 0x9e, 0xa6, 0x37, 0x9d, 0xa5, 0x7f, 0xeb, 0x25, 0xcb, 0xc9, 0x6d, 0x94, 0x55, 0xb4, 0xbf,
+	0x17, 0xa0, 0x74, 0x35, 0x59, 0xa4, 0x1e, 0x56, 0xa0, 0xb8, 0xbc, 0x8f, 0xbc, 0x26, 0x2f, 0xf1,
+	0x72, 0x5d, 0x6d, 0x29, 0xbf, 0xb5, 0x52, 0x18, 0xe5, 0xde, 0x47, 0x9e, 0xcd, 0x38, 0xfc, 0x12,
+	0xb6, 0x13, 0x7f, 0x16, 0x78, 0xd7, 0xe3, 0x3b, 0x7a, 0xd2, 0x04, 0x89, 0x97, 0xb1, 0xc6, 0xd9,
+	0x62, 0xa6, 0x66, 0x4d, 0x5f, 0x41, 0x3d, 0x0d, 0x1e, 0x61, 0xa2, 0xc4, 0xcb, 0x45, 0x8d, 0xb3,
+	0x6b, 0x69, 0xb0, 0x09, 0xd2, 0x6e, 0xcb, 0xd8, 0x0f, 0x66, 0x2b, 0x6c, 0x5b, 0xe2, 0x65, 0x81,
+	0x75, 0x63, 0x6a, 0x06, 0x1d, 0x02, 0x4c, 0xc3, 0x70, 0xb1, 0x42, 0x6a, 0x12, 0x2f, 0x57, 0x35,
+	0xce, 0x16, 0xa8, 0xb6, 0xee, 0x72, 0x1d, 0xa6, 0xd3, 0x85, 0xb7, 0x42, 0xea, 0x12, 0x2f, 0xf3,
+	0xb4, 0x4b, 0xa6, 0x66, 0x10, 0x81, 0xc6, 0xda, 0x85, 0x15, 0xd7, 0x90, 0x78, 0x59, 0x54, 0x5b,
+	0x4a, 0xe6, 0x96, 0x92, 0xbb, 0xa5, 0xb8, 0x39, 0xa7, 0x71, 0x76, 0x7d, 0x5d, 0xc4, 0xda, 0xf4,
+	0x2b, 0x50, 0x62, 0xc5, 0xed, 0x39, 0x54, 0xfb, 0x7e, 0x30, 0x89, 0xef, 0xad, 0x08, 0xbf, 0x86,
+	0xad, 0xc5, 0x3c, 0x61, 0x1e, 0x8a, 0xea, 0xfe, 0x13, 0x0f, 0xc9, 0x7a, 0x61, 0x36, 0xe5, 0x28,
+	0x1e, 0xcf, 0x93, 0x66, 0xe1, 0x1f, 0xf0, 0x78, 0x9e, 0xb4, 0x7f, 0x6e, 0x01, 0x3c, 0x68, 0xf8,
+	0xed, 0xa3, 0x8d, 0x1d, 0xfd, 0xa1, 0x7c, 0x23, 0xdd, 0xd8, 0xa0, 0x04, 0xe0, 0x5f, 0x7b, 0xc1,
+	0xd2, 0xbf, 0xf1, 0xbd, 0xb8, 0x09, 0x2b, 0xc7, 0x37, 0x34, 0xac, 0x40, 0xe9, 0x61, 0x6b, 0xa2,
+	0xba, 0xfb, 0xfc, 0x9f, 0x42, 0xe3, 0xec, 0x0c, 0xc3, 0x5d, 0x10, 0xa6, 0xcc, 0x8a, 0x71, 0x18,
+	0xb1, 0x15, 0x8a, 0xea, 0xde, 0x93, 0x9a, 0xdc, 0x2c, 0x8d, 0xb3, 0xab, 0xd3, 0x55, 0x8e, 0xbb,
+	0x50, 0x4d, 0xf3, 0xc2, 0xda, 0x5f, 0xed, 0xd0, 0x38, 0xbb, 0x92, 0x66, 0x95, 0xed, 0x1f, 0x3c,
+	0xd4, 0x1f, 0x8f, 0x87, 0x0f, 0xa0, 0x45, 0x3e, 0x0d, 0x6d, 0xe2, 0x38, 0xba, 0x65, 0xba, 0x9f,
+	0x87, 0x64, 0x3c, 0x32, 0x9d, 0x21, 0x39, 0xd7, 0xdf, 0xe9, 0x64, 0x80, 0x38, 0x5c, 0x07, 0xd0,
+	0x07, 0xc4, 0x74, 0xe9, 0xb7, 0x8d, 0x78, 0x2c, 0x40, 0xe9, 0xaa, 0x67, 0x8c, 0x08, 0x2a, 0xe0,
+	0x06, 0x88, 0x86, 0x75, 0xa1, 0x9f, 0xf7, 0x8c, 0x71, 0xcf, 0x1c, 0x20, 0xa0, 0x6c, 0x2e, 0x58,
+	0x36, 0x12, 0x71, 0x19, 0x0a, 0xe4, 0x03, 0xfa, 0x8f, 0x46, 0x93, 0xa0, 0x1d, 0x1a, 0x0d, 0x17,
+	0xed, 0xb2, 0x48, 0xd0, 0xff, 0x34, 0x5e, 0xb8, 0xa8, 0xc9, 0x22, 0x41, 0x7b, 0xb8, 0x0a, 0x45,
+	0x43, 0x7f, 0x4f, 0x50, 0x0b, 0x8b, 0x50, 0xd1, 0x9d, 0xb1, 0x39, 0x32, 0x0c, 0xb4, 0x4f, 0xef,
+	0xa1, 0x1f, 0x96, 0x9b, 0x09, 0x2f, 0xa8, 0xd0, 0xd7, 0xdd, 0x8f, 0xba, 0x43, 0xd8, 0xc5, 0x07,
+	0xfd, 0x32, 0x14, 0xe9, 0xfb, 0x3e, 0xfa, 0xc6, 0x83, 0xb0, 0x7e, 0x7b, 0x78, 0x0f, 0x76, 0xd8,
+	0x4f, 0x7d, 0x66, 0x2a, 0x80, 0xb2, 0xe3, 0xda, 0xba, 0x79, 0x91, 0x4d, 0xe4, 0xe8, 0xa6, 0xdb,
+	0x45, 0x05, 0x26, 0xeb, 0xa6, 0xfb, 0xe6, 0x0c, 0x6d, 0xe5, 0xf9, 0x89, 0x8a, 0x8a, 0x79, 0x7e,
+	0x76, 0x8a, 0x4a, 0x14, 0x1f, 0x31, 0xbc, 0x4c, 0xe5, 0x51, 0x86, 0x57, 0xf2, 0xfc, 0x44, 0x45,
+	0xd5, 0x3c, 0x3f, 0x3b, 0x45, 0x02, 0x9d, 0xa9, 0x6f, 0x59, 0x06, 0x02, 0xaa, 0x0e, 0xac, 0x51,
+	0xdf, 0x20, 0x48, 0xc4, 0x35, 0x10, 0x5c, 0xfd, 0x92, 0x38, 0x6e, 0xef, 0x72, 0x88, 0xb6, 0xa7,
+	0x65, 0xf6, 0x8a, 0x4e, 0x7e, 0x0d, 0x00, 0xab, 0x9f, 0x14, 0x60, 0xb6, 0x04, 0x00, 0x00,
 }
@@ -6,13 +6,251 @@ package capsule8_api_v0
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
-import google_protobuf1 "github.com/golang/protobuf/ptypes/wrappers"
+import google_protobuf2 "github.com/golang/protobuf/ptypes/wrappers"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
+// Optional fields of the perf sample records collected for kernel events
+type SampleField int32
+
+const (
+	SampleField_SAMPLE_FIELD_UNKNOWN SampleField = 0
+	// The CPU on which the event occurred (PERF_SAMPLE_CPU)
+	SampleField_SAMPLE_FIELD_CPU SampleField = 1
+	// The pid and tid of the task associated with the event
+	// (PERF_SAMPLE_TID). Used to identify the task for events that
+	// don't otherwise include it, such as performance events.
+	SampleField_SAMPLE_FIELD_TID SampleField = 2
+	// The instruction pointer when the event occurred (PERF_SAMPLE_IP)
+	SampleField_SAMPLE_FIELD_IP SampleField = 3
+	// The call chain when the event occurred (PERF_SAMPLE_CALLCHAIN)
+	SampleField_SAMPLE_FIELD_CALLCHAIN SampleField = 4
+	// The data source of the memory access that caused the event
+	// (PERF_SAMPLE_DATA_SRC). Only collected for performance events
+	// that count HARDWARE or HARDWARE_CACHE events, and only reported
+	// by the kernel for precise memory access events (e.g. Intel PEBS
+	// load latency events).
+	SampleField_SAMPLE_FIELD_DATA_SRC SampleField = 5
+)
+
+var SampleField_name = map[int32]string{
+	0: "SAMPLE_FIELD_UNKNOWN",
+	1: "SAMPLE_FIELD_CPU",
+	2: "SAMPLE_FIELD_TID",
+	3: "SAMPLE_FIELD_IP",
+	4: "SAMPLE_FIELD_CALLCHAIN",
+	5: "SAMPLE_FIELD_DATA_SRC",
+}
+var SampleField_value = map[string]int32{
+	"SAMPLE_FIELD_UNKNOWN":   0,
+	"SAMPLE_FIELD_CPU":       1,
+	"SAMPLE_FIELD_TID":       2,
+	"SAMPLE_FIELD_IP":        3,
+	"SAMPLE_FIELD_CALLCHAIN": 4,
+	"SAMPLE_FIELD_DATA_SRC":  5,
+}
+
+func (x SampleField) String() string {
+	return proto.EnumName(SampleField_name, int32(x))
+}
+func (SampleField) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{0} }
+
+// The SubscriptionPriority determines which subscriptions lose events first
+// when the Sensor cannot keep up with the rate of events. High priority
+// subscriptions are buffered more deeply, and low priority subscriptions stop
+// receiving events while the Sensor has a backlog of events to dispatch.
+type SubscriptionPriority int32
+
+const (
+	SubscriptionPriority_SUBSCRIPTION_PRIORITY_NORMAL SubscriptionPriority = 0
+	SubscriptionPriority_SUBSCRIPTION_PRIORITY_LOW    SubscriptionPriority = 1
+	SubscriptionPriority_SUBSCRIPTION_PRIORITY_HIGH   SubscriptionPriority = 2
+)
+
+var SubscriptionPriority_name = map[int32]string{
+	0: "SUBSCRIPTION_PRIORITY_NORMAL",
+	1: "SUBSCRIPTION_PRIORITY_LOW",
+	2: "SUBSCRIPTION_PRIORITY_HIGH",
+}
+var SubscriptionPriority_value = map[string]int32{
+	"SUBSCRIPTION_PRIORITY_NORMAL": 0,
+	"SUBSCRIPTION_PRIORITY_LOW":    1,
+	"SUBSCRIPTION_PRIORITY_HIGH":   2,
+}
+
+func (x SubscriptionPriority) String() string {
+	return proto.EnumName(SubscriptionPriority_name, int32(x))
+}
+func (SubscriptionPriority) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{1} }
+
+// The clocks that a Subscription's events may be timestamped with
+type PerfClock int32
+
+const (
+	// The Sensor's default clock. Events only report
+	// sensor_monotime_nanos.
+	PerfClock_PERF_CLOCK_DEFAULT PerfClock = 0
+	// CLOCK_MONOTONIC, which is adjusted by NTP and does not advance
+	// while the system is suspended
+	PerfClock_PERF_CLOCK_MONOTONIC PerfClock = 1
+	// CLOCK_BOOTTIME, which is like CLOCK_MONOTONIC but includes the
+	// time that the system was suspended
+	PerfClock_PERF_CLOCK_BOOTTIME PerfClock = 2
+	// CLOCK_REALTIME, the wall clock time in nanoseconds since the
+	// Unix epoch. It may jump when the system time is set.
+	PerfClock_PERF_CLOCK_REALTIME PerfClock = 3
+	// CLOCK_MONOTONIC_RAW, which is not adjusted by NTP
+	PerfClock_PERF_CLOCK_MONOTONIC_RAW PerfClock = 4
+)
+
+var PerfClock_name = map[int32]string{
+	0: "PERF_CLOCK_DEFAULT",
+	1: "PERF_CLOCK_MONOTONIC",
+	2: "PERF_CLOCK_BOOTTIME",
+	3: "PERF_CLOCK_REALTIME",
+	4: "PERF_CLOCK_MONOTONIC_RAW",
+}
+var PerfClock_value = map[string]int32{
+	"PERF_CLOCK_DEFAULT":       0,
+	"PERF_CLOCK_MONOTONIC":     1,
+	"PERF_CLOCK_BOOTTIME":      2,
+	"PERF_CLOCK_REALTIME":      3,
+	"PERF_CLOCK_MONOTONIC_RAW": 4,
+}
+
+func (x PerfClock) String() string {
+	return proto.EnumName(PerfClock_name, int32(x))
+}
+func (PerfClock) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{2} }
+
+// Where a Subscription's kernel events are decoded. Isolating a
+// subscription's decoding keeps a backlog of other subscriptions' events,
+// such as those whose paths are resolved, from delaying its events, and
+// keeps its own from delaying theirs, at the cost of a goroutine per
+// isolated subscription. Decoders share the Sensor's process information,
+// so they take turns one event at a time: it is the queueing of events that
+// is isolated. Events are still filtered and delivered by the Sensor's
+// shared dispatch goroutines. Process events are delivered to an isolated
+// subscription in order with its other events, but its events are no longer
+// ordered with the events of other subscriptions, and are dropped with a
+// DATA_LOSS status if its goroutine falls too far behind, rather than
+// slowing the shared decoding goroutine.
+type DecodeIsolation int32
+
+const (
+	// Decoded in time order with the events of all other shared
+	// subscriptions on the Sensor's shared decoding goroutine
+	DecodeIsolation_DECODE_ISOLATION_SHARED DecodeIsolation = 0
+	// Decoded on a goroutine of the subscription's own
+	DecodeIsolation_DECODE_ISOLATION_ISOLATED DecodeIsolation = 1
+)
+
+var DecodeIsolation_name = map[int32]string{
+	0: "DECODE_ISOLATION_SHARED",
+	1: "DECODE_ISOLATION_ISOLATED",
+}
+var DecodeIsolation_value = map[string]int32{
+	"DECODE_ISOLATION_SHARED":   0,
+	"DECODE_ISOLATION_ISOLATED": 1,
+}
+
+func (x DecodeIsolation) String() string {
+	return proto.EnumName(DecodeIsolation_name, int32(x))
+}
+func (DecodeIsolation) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{3} }
+
+// How a Subscription's filters that refer to unsupported fields are handled
+type UnsupportedFieldPolicy int32
+
+const (
+	// The subscription is rejected with an error naming the
+	// unsupported fields.
+	UnsupportedFieldPolicy_UNSUPPORTED_FIELD_POLICY_STRICT UnsupportedFieldPolicy = 0
+	// Predicates that refer to unsupported fields are dropped from
+	// the filter, and a FAILED_PRECONDITION status names the fields and
+	// the dropped predicates. The operands of a logical and are dropped
+	// individually, while any other expression that refers to an
+	// unsupported field, including a logical or or not, is dropped as a
+	// whole. Dropped predicates are treated as true, so the filter
+	// matches at least the events that it would have matched on a
+	// kernel that supports the fields.
+	UnsupportedFieldPolicy_UNSUPPORTED_FIELD_POLICY_LENIENT UnsupportedFieldPolicy = 1
+)
+
+var UnsupportedFieldPolicy_name = map[int32]string{
+	0: "UNSUPPORTED_FIELD_POLICY_STRICT",
+	1: "UNSUPPORTED_FIELD_POLICY_LENIENT",
+}
+var UnsupportedFieldPolicy_value = map[string]int32{
+	"UNSUPPORTED_FIELD_POLICY_STRICT":  0,
+	"UNSUPPORTED_FIELD_POLICY_LENIENT": 1,
+}
+
+func (x UnsupportedFieldPolicy) String() string {
+	return proto.EnumName(UnsupportedFieldPolicy_name, int32(x))
+}
+func (UnsupportedFieldPolicy) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{4} }
+
+// The events returned by a FilelessExecutionFilter
+type FilelessExecutionOutput int32
+
+const (
+	// A FilelessExecutionEvent for each execution of a memory file
+	FilelessExecutionOutput_FILELESS_EXECUTION_OUTPUT_DERIVED FilelessExecutionOutput = 0
+	// The system call events that the detection is derived from: a
+	// complete event for each memfd_create call, and the enter event
+	// of each execve or execveat call that executes a memory file
+	FilelessExecutionOutput_FILELESS_EXECUTION_OUTPUT_RAW FilelessExecutionOutput = 1
+	// Both the derived and the system call events
+	FilelessExecutionOutput_FILELESS_EXECUTION_OUTPUT_BOTH FilelessExecutionOutput = 2
+)
+
+var FilelessExecutionOutput_name = map[int32]string{
+	0: "FILELESS_EXECUTION_OUTPUT_DERIVED",
+	1: "FILELESS_EXECUTION_OUTPUT_RAW",
+	2: "FILELESS_EXECUTION_OUTPUT_BOTH",
+}
+var FilelessExecutionOutput_value = map[string]int32{
+	"FILELESS_EXECUTION_OUTPUT_DERIVED": 0,
+	"FILELESS_EXECUTION_OUTPUT_RAW":     1,
+	"FILELESS_EXECUTION_OUTPUT_BOTH":    2,
+}
+
+func (x FilelessExecutionOutput) String() string {
+	return proto.EnumName(FilelessExecutionOutput_name, int32(x))
+}
+func (FilelessExecutionOutput) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{5} }
+
+// The action to take for SYSCALL_EVENT_TYPE_COMPLETE filters when the enter of
+// a system call is observed with no exit before a timeout, or the exit of a
+// system call is observed without its enter. Unmatched exits are only
+// reported when the filter does not reference any system call arguments.
+type SyscallOrphanAction int32
+
+const (
+	// Drop the orphaned enter or exit
+	SyscallOrphanAction_SYSCALL_ORPHAN_ACTION_DROP SyscallOrphanAction = 0
+	// Emit a partial complete event for the orphaned enter or exit
+	SyscallOrphanAction_SYSCALL_ORPHAN_ACTION_EMIT_PARTIAL SyscallOrphanAction = 1
+)
+
+var SyscallOrphanAction_name = map[int32]string{
+	0: "SYSCALL_ORPHAN_ACTION_DROP",
+	1: "SYSCALL_ORPHAN_ACTION_EMIT_PARTIAL",
+}
+var SyscallOrphanAction_value = map[string]int32{
+	"SYSCALL_ORPHAN_ACTION_DROP":         0,
+	"SYSCALL_ORPHAN_ACTION_EMIT_PARTIAL": 1,
+}
+
+func (x SyscallOrphanAction) String() string {
+	return proto.EnumName(SyscallOrphanAction_name, int32(x))
+}
+func (SyscallOrphanAction) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{6} }
+
 // SampleRateType describes the type of sample rate to use, either by the # of
 // generated events (SAMPLE_RATE_TYPE_PERIOD) or by time
 // (SAMPLE_RATE_TYPE_FREQUENCY), which is expressed in units of kernel timer
@@ -39,7 +277,7 @@ var SampleRateType_value = map[string]int32{
 func (x SampleRateType) String() string {
 	return proto.EnumName(SampleRateType_name, int32(x))
 }
-func (SampleRateType) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{0} }
+func (SampleRateType) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{7} }
 
 // The ContainerEventView specifies the level of detail to include for
 // ContainerEvents.
@@ -65,7 +303,84 @@ var ContainerEventView_value = map[string]int32{
 func (x ContainerEventView) String() string {
 	return proto.EnumName(ContainerEventView_name, int32(x))
 }
-func (ContainerEventView) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{1} }
+func (ContainerEventView) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{8} }
+
+// Sampling modes
+type ContainerSampling_Mode int32
+
+const (
+	// Each container's events are sampled to at most
+	// events_per_container per interval, regardless of how
+	// busy the other containers are.
+	ContainerSampling_EQUAL ContainerSampling_Mode = 0
+	// All containers are sampled at the same rate, chosen so
+	// that the total is events_per_container times the number
+	// of active containers per interval. Every container still
+	// returns at least about one event per interval.
+	ContainerSampling_PROPORTIONAL ContainerSampling_Mode = 1
+)
+
+var ContainerSampling_Mode_name = map[int32]string{
+	0: "EQUAL",
+	1: "PROPORTIONAL",
+}
+var ContainerSampling_Mode_value = map[string]int32{
+	"EQUAL":        0,
+	"PROPORTIONAL": 1,
+}
+
+func (x ContainerSampling_Mode) String() string {
+	return proto.EnumName(ContainerSampling_Mode_name, int32(x))
+}
+func (ContainerSampling_Mode) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{4, 0} }
+
+// What events are keyed by
+type QuietPeriod_Key int32
+
+const (
+	// The process of the event (process_id)
+	QuietPeriod_PROCESS QuietPeriod_Key = 0
+	// The container of the event (container_id)
+	QuietPeriod_CONTAINER QuietPeriod_Key = 1
+)
+
+var QuietPeriod_Key_name = map[int32]string{
+	0: "PROCESS",
+	1: "CONTAINER",
+}
+var QuietPeriod_Key_value = map[string]int32{
+	"PROCESS":   0,
+	"CONTAINER": 1,
+}
+
+func (x QuietPeriod_Key) String() string {
+	return proto.EnumName(QuietPeriod_Key_name, int32(x))
+}
+func (QuietPeriod_Key) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{6, 0} }
+
+// What failures are counted by
+type FailureThreshold_Key int32
+
+const (
+	// The process of the event (process_id)
+	FailureThreshold_PROCESS FailureThreshold_Key = 0
+	// The container of the event (container_id)
+	FailureThreshold_CONTAINER FailureThreshold_Key = 1
+)
+
+var FailureThreshold_Key_name = map[int32]string{
+	0: "PROCESS",
+	1: "CONTAINER",
+}
+var FailureThreshold_Key_value = map[string]int32{
+	"PROCESS":   0,
+	"CONTAINER": 1,
+}
+
+func (x FailureThreshold_Key) String() string {
+	return proto.EnumName(FailureThreshold_Key_name, int32(x))
+}
+func (FailureThreshold_Key) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{8, 0} }
 
 // Possible interval types
 type ThrottleModifier_IntervalType int32
@@ -98,7 +413,7 @@ func (x ThrottleModifier_IntervalType) String() string {
 	return proto.EnumName(ThrottleModifier_IntervalType_name, int32(x))
 }
 func (ThrottleModifier_IntervalType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor3, []int{14, 0}
+	return fileDescriptor3, []int{26, 0}
 }
 
 //
@@ -113,18 +428,82 @@ type Subscription struct {
 	// If not empty, then only return events from containers matched
 	// by one or more of the specified container filters.
 	ContainerFilter *ContainerFilter `protobuf:"bytes,2,opt,name=container_filter,json=containerFilter" json:"container_filter,omitempty"`
+	// Optional; the priority of the subscription relative to others
+	// when the Sensor is under load.
+	Priority SubscriptionPriority `protobuf:"varint,3,opt,name=priority,enum=capsule8.api.v0.SubscriptionPriority" json:"priority,omitempty"`
+	// Optional; if true, the Sensor only enables the kernel events for
+	// the subscription while at least one container matched by
+	// `container_filter` is running, rather than for the lifetime of the
+	// subscription. Events that occur early in a container's lifetime,
+	// before the Sensor learns that it is running, may be missed.
+	// Requires `container_filter`.
+	Lazy bool `protobuf:"varint,4,opt,name=lazy" json:"lazy,omitempty"`
+	// Optional; the sample fields to collect for the subscription's
+	// kernel events. If empty, the Sensor's defaults are used. Fields
+	// that the Sensor requires for filtering and enrichment are always
+	// collected. Collecting fewer fields reduces per-event overhead.
+	SampleFields []SampleField `protobuf:"varint,5,rep,packed,name=sample_fields,json=sampleFields,enum=capsule8.api.v0.SampleField" json:"sample_fields,omitempty"`
+	// Optional; client metadata for the subscription, such as a rule
+	// name or tenant. The Sensor echoes the tags on the first response
+	// of the GetEvents stream and on every event it returns.
+	Tags map[string]string `protobuf:"bytes,6,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// If not empty, then only return events from the processes
+	// indicated.
+	PidFilter *PidFilter `protobuf:"bytes,7,opt,name=pid_filter,json=pidFilter" json:"pid_filter,omitempty"`
+	// Optional; if set, the Sensor periodically sends a
+	// SubscriptionStatsEvent with an estimate of the number of distinct
+	// processes whose events matched the subscription.
+	PidCardinality *PidCardinality `protobuf:"bytes,8,opt,name=pid_cardinality,json=pidCardinality" json:"pid_cardinality,omitempty"`
+	// Optional; if set, only return events at which the specified
+	// predicate changes value for the event's process.
+	EdgeTrigger *EdgeTrigger `protobuf:"bytes,9,opt,name=edge_trigger,json=edgeTrigger" json:"edge_trigger,omitempty"`
+	// Optional; if set, the Sensor samples the subscription's events
+	// per container so that busy containers do not crowd quieter ones
+	// out of the stream.
+	ContainerSampling *ContainerSampling `protobuf:"bytes,12,opt,name=container_sampling,json=containerSampling" json:"container_sampling,omitempty"`
+	// Optional; if true, events that the Sensor derives from other
+	// events, such as complete syscall events and EdgeTrigger
+	// transitions, carry the sensor_sequence_number of each event that
+	// contributed to them in caused_by.
+	IncludeCausedBy bool `protobuf:"varint,13,opt,name=include_caused_by,json=includeCausedBy" json:"include_caused_by,omitempty"`
+	// Optional; if set, the Sensor throttles the subscription's events
+	// when the client is slow to acknowledge them.
+	AckThrottle *AckThrottle `protobuf:"bytes,14,opt,name=ack_throttle,json=ackThrottle" json:"ack_throttle,omitempty"`
+	// Optional; if set, the Sensor alerts when the subscription's
+	// events stop for a process or container.
+	QuietPeriod *QuietPeriod `protobuf:"bytes,15,opt,name=quiet_period,json=quietPeriod" json:"quiet_period,omitempty"`
+	// Optional; the clock that the kernel timestamps the
+	// subscription's events with. Each event then reports its time by
+	// that clock in TelemetryEvent.perf_clock_nanos, which can be
+	// correlated with other sources that use the same clock. If the
+	// kernel does not support selecting the clock, the subscription
+	// uses the Sensor's default clock and reports an UNIMPLEMENTED
+	// status.
+	PerfClock PerfClock `protobuf:"varint,16,opt,name=perf_clock,json=perfClock,enum=capsule8.api.v0.PerfClock" json:"perf_clock,omitempty"`
+	// How filters that refer to fields the Sensor does not support
+	// are handled. The fields of kernel events are discovered from the
+	// running kernel, so a field may be available on some kernels and
+	// not others.
+	UnsupportedFieldPolicy UnsupportedFieldPolicy `protobuf:"varint,17,opt,name=unsupported_field_policy,json=unsupportedFieldPolicy,enum=capsule8.api.v0.UnsupportedFieldPolicy" json:"unsupported_field_policy,omitempty"`
+	// Optional; whether the subscription's kernel events are decoded
+	// on the Sensor's shared decoding goroutine or on one of their own.
+	DecodeIsolation DecodeIsolation `protobuf:"varint,18,opt,name=decode_isolation,json=decodeIsolation,enum=capsule8.api.v0.DecodeIsolation" json:"decode_isolation,omitempty"`
+	// Optional; if set, only return an event when the consecutive
+	// failures of a process or other key reach a threshold within a
+	// window, such as repeated failed opens or connects.
+	FailureThreshold *FailureThreshold `protobuf:"bytes,19,opt,name=failure_threshold,json=failureThreshold" json:"failure_threshold,omitempty"`
 	// If not empty, then only return events that occurred after
 	// the specified relative duration subtracted from the current
 	// time (recorder time). If the resulting time is in the past, then the
 	// subscription will search for historic events before streaming
 	// live ones. Sensors do not honor this field.
-	SinceDuration *google_protobuf1.Int64Value `protobuf:"bytes,10,opt,name=since_duration,json=sinceDuration" json:"since_duration,omitempty"`
+	SinceDuration *google_protobuf2.Int64Value `protobuf:"bytes,10,opt,name=since_duration,json=sinceDuration" json:"since_duration,omitempty"`
 	// If not empty, then only return events that occurred before
 	// the specified relative duration added to `since_duration`.
 	// If `since_duration` is not supplied, return events from now and until
 	// the specified relative duration is hit. Sensors do not honor this
 	// field.
-	ForDuration *google_protobuf1.Int64Value `protobuf:"bytes,11,opt,name=for_duration,json=forDuration" json:"for_duration,omitempty"`
+	ForDuration *google_protobuf2.Int64Value `protobuf:"bytes,11,opt,name=for_duration,json=forDuration" json:"for_duration,omitempty"`
 	// If not empty, apply the specified modifier to the subscription.
 	Modifier *Modifier `protobuf:"bytes,20,opt,name=modifier" json:"modifier,omitempty"`
 }
@@ -148,14 +527,119 @@ func (m *Subscription) GetContainerFilter() *ContainerFilter {
 	return nil
 }
 
-func (m *Subscription) GetSinceDuration() *google_protobuf1.Int64Value {
+func (m *Subscription) GetPriority() SubscriptionPriority {
+	if m != nil {
+		return m.Priority
+	}
+	return SubscriptionPriority_SUBSCRIPTION_PRIORITY_NORMAL
+}
+
+func (m *Subscription) GetLazy() bool {
+	if m != nil {
+		return m.Lazy
+	}
+	return false
+}
+
+func (m *Subscription) GetSampleFields() []SampleField {
+	if m != nil {
+		return m.SampleFields
+	}
+	return nil
+}
+
+func (m *Subscription) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *Subscription) GetPidFilter() *PidFilter {
+	if m != nil {
+		return m.PidFilter
+	}
+	return nil
+}
+
+func (m *Subscription) GetPidCardinality() *PidCardinality {
+	if m != nil {
+		return m.PidCardinality
+	}
+	return nil
+}
+
+func (m *Subscription) GetEdgeTrigger() *EdgeTrigger {
+	if m != nil {
+		return m.EdgeTrigger
+	}
+	return nil
+}
+
+func (m *Subscription) GetContainerSampling() *ContainerSampling {
+	if m != nil {
+		return m.ContainerSampling
+	}
+	return nil
+}
+
+func (m *Subscription) GetIncludeCausedBy() bool {
+	if m != nil {
+		return m.IncludeCausedBy
+	}
+	return false
+}
+
+func (m *Subscription) GetAckThrottle() *AckThrottle {
+	if m != nil {
+		return m.AckThrottle
+	}
+	return nil
+}
+
+func (m *Subscription) GetQuietPeriod() *QuietPeriod {
+	if m != nil {
+		return m.QuietPeriod
+	}
+	return nil
+}
+
+func (m *Subscription) GetPerfClock() PerfClock {
+	if m != nil {
+		return m.PerfClock
+	}
+	return PerfClock_PERF_CLOCK_DEFAULT
+}
+
+func (m *Subscription) GetUnsupportedFieldPolicy() UnsupportedFieldPolicy {
+	if m != nil {
+		return m.UnsupportedFieldPolicy
+	}
+	return UnsupportedFieldPolicy_UNSUPPORTED_FIELD_POLICY_STRICT
+}
+
+func (m *Subscription) GetDecodeIsolation() DecodeIsolation {
+	if m != nil {
+		return m.DecodeIsolation
+	}
+	return DecodeIsolation_DECODE_ISOLATION_SHARED
+}
+
+func (m *Subscription) GetFailureThreshold() *FailureThreshold {
+	if m != nil {
+		return m.FailureThreshold
+	}
+	return nil
+}
+
+func (m *Subscription) GetSinceDuration() *google_protobuf2.Int64Value {
 	if m != nil {
 		return m.SinceDuration
 	}
 	return nil
 }
 
-func (m *Subscription) GetForDuration() *google_protobuf1.Int64Value {
+func (m *Subscription) GetForDuration() *google_protobuf2.Int64Value {
 	if m != nil {
 		return m.ForDuration
 	}
@@ -221,6 +705,349 @@ func (m *ContainerFilter) GetImageNames() []string {
 	return nil
 }
 
+// The PidFilter restricts events in the Subscription to a set of processes.
+// The set is checked against a Bloom filter before exact membership, which
+// makes rejecting events from other processes cheap even for large sets.
+type PidFilter struct {
+	// The process IDs (thread group IDs) of the processes to include.
+	// Processes are removed from the set when they exit.
+	Pids []int32 `protobuf:"varint,1,rep,packed,name=pids" json:"pids,omitempty"`
+	// Optional; if true, processes created by processes in the set are
+	// added to the set as well.
+	FollowChildren bool `protobuf:"varint,2,opt,name=follow_children,json=followChildren" json:"follow_children,omitempty"`
+	// Optional; the target false positive rate of the Bloom filter,
+	// between 0 and 1. Lower rates use more memory, while higher rates
+	// send more events to the exact membership check. Defaults to 0.01.
+	FalsePositiveRate float64 `protobuf:"fixed64,3,opt,name=false_positive_rate,json=falsePositiveRate" json:"false_positive_rate,omitempty"`
+}
+
+func (m *PidFilter) Reset()                    { *m = PidFilter{} }
+func (m *PidFilter) String() string            { return proto.CompactTextString(m) }
+func (*PidFilter) ProtoMessage()               {}
+func (*PidFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{2} }
+
+func (m *PidFilter) GetPids() []int32 {
+	if m != nil {
+		return m.Pids
+	}
+	return nil
+}
+
+func (m *PidFilter) GetFollowChildren() bool {
+	if m != nil {
+		return m.FollowChildren
+	}
+	return false
+}
+
+func (m *PidFilter) GetFalsePositiveRate() float64 {
+	if m != nil {
+		return m.FalsePositiveRate
+	}
+	return 0
+}
+
+// The PidCardinality configures the periodic estimate of the number of
+// distinct processes whose events match a Subscription. Memory use is bounded
+// regardless of the number of processes.
+type PidCardinality struct {
+	// Optional; how often to send the estimate. Defaults to 60 seconds.
+	IntervalSeconds uint32 `protobuf:"varint,1,opt,name=interval_seconds,json=intervalSeconds" json:"interval_seconds,omitempty"`
+	// Optional; the length of the sliding window that each estimate
+	// covers. It is rounded up to a multiple of interval_seconds.
+	// Defaults to interval_seconds.
+	WindowSeconds uint32 `protobuf:"varint,2,opt,name=window_seconds,json=windowSeconds" json:"window_seconds,omitempty"`
+}
+
+func (m *PidCardinality) Reset()                    { *m = PidCardinality{} }
+func (m *PidCardinality) String() string            { return proto.CompactTextString(m) }
+func (*PidCardinality) ProtoMessage()               {}
+func (*PidCardinality) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{3} }
+
+func (m *PidCardinality) GetIntervalSeconds() uint32 {
+	if m != nil {
+		return m.IntervalSeconds
+	}
+	return 0
+}
+
+func (m *PidCardinality) GetWindowSeconds() uint32 {
+	if m != nil {
+		return m.WindowSeconds
+	}
+	return 0
+}
+
+// The ContainerSampling configures sampling of a Subscription's events by
+// container. The Sensor counts the events of each container, including the
+// host (an empty container ID), and adjusts each container's sampling rate
+// at the end of every interval to meet a target event budget. The effective
+// sampling rates are periodically sent in a SubscriptionStatsEvent.
+type ContainerSampling struct {
+	// Optional; the sampling mode. Defaults to EQUAL.
+	Mode ContainerSampling_Mode `protobuf:"varint,1,opt,name=mode,enum=capsule8.api.v0.ContainerSampling_Mode" json:"mode,omitempty"`
+	// Required; the target number of events per container per interval
+	EventsPerContainer uint64 `protobuf:"varint,2,opt,name=events_per_container,json=eventsPerContainer" json:"events_per_container,omitempty"`
+	// Optional; how often to adjust sampling rates and send them.
+	// Defaults to 10 seconds.
+	IntervalSeconds uint32 `protobuf:"varint,3,opt,name=interval_seconds,json=intervalSeconds" json:"interval_seconds,omitempty"`
+}
+
+func (m *ContainerSampling) Reset()                    { *m = ContainerSampling{} }
+func (m *ContainerSampling) String() string            { return proto.CompactTextString(m) }
+func (*ContainerSampling) ProtoMessage()               {}
+func (*ContainerSampling) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{4} }
+
+func (m *ContainerSampling) GetMode() ContainerSampling_Mode {
+	if m != nil {
+		return m.Mode
+	}
+	return ContainerSampling_EQUAL
+}
+
+func (m *ContainerSampling) GetEventsPerContainer() uint64 {
+	if m != nil {
+		return m.EventsPerContainer
+	}
+	return 0
+}
+
+func (m *ContainerSampling) GetIntervalSeconds() uint32 {
+	if m != nil {
+		return m.IntervalSeconds
+	}
+	return 0
+}
+
+// AckThrottle adapts the rate of a Subscription's events to the rate at which
+// the client acknowledges them. The Sensor measures the latency of each
+// event's delivery as the time that the stream takes to accept it, which
+// grows when the client stops acknowledging data and gRPC flow control
+// applies back pressure. While the average latency is above the high
+// threshold, events are only returned at the rate that the client has been
+// accepting them, until the average falls below the low threshold. Events
+// that are not returned are counted as dropped.
+type AckThrottle struct {
+	// Required; the average delivery latency in milliseconds above
+	// which events are throttled
+	HighLatencyMillis int64 `protobuf:"varint,1,opt,name=high_latency_millis,json=highLatencyMillis" json:"high_latency_millis,omitempty"`
+	// Optional; the average delivery latency in milliseconds below
+	// which throttling stops. Defaults to half of
+	// high_latency_millis.
+	LowLatencyMillis int64 `protobuf:"varint,2,opt,name=low_latency_millis,json=lowLatencyMillis" json:"low_latency_millis,omitempty"`
+}
+
+func (m *AckThrottle) Reset()                    { *m = AckThrottle{} }
+func (m *AckThrottle) String() string            { return proto.CompactTextString(m) }
+func (*AckThrottle) ProtoMessage()               {}
+func (*AckThrottle) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{5} }
+
+func (m *AckThrottle) GetHighLatencyMillis() int64 {
+	if m != nil {
+		return m.HighLatencyMillis
+	}
+	return 0
+}
+
+func (m *AckThrottle) GetLowLatencyMillis() int64 {
+	if m != nil {
+		return m.LowLatencyMillis
+	}
+	return 0
+}
+
+// QuietPeriod detects the absence of expected activity, such as a heartbeat
+// process that stops making system calls because it has hung or been killed.
+// The Subscription's filters select the events that count as activity. The
+// Sensor tracks the most recent event for each key, and when a key has had no
+// events for the silence threshold, it returns a QuietPeriodEvent for it. A
+// key is alerted on once per silence; its next event starts tracking it
+// again.
+type QuietPeriod struct {
+	// Optional; what events are keyed by. Events without a key are
+	// ignored.
+	Key QuietPeriod_Key `protobuf:"varint,1,opt,name=key,enum=capsule8.api.v0.QuietPeriod_Key" json:"key,omitempty"`
+	// Required; the number of seconds without events after which a key
+	// is alerted on
+	SilenceSeconds uint32 `protobuf:"varint,2,opt,name=silence_seconds,json=silenceSeconds" json:"silence_seconds,omitempty"`
+	// Optional; the maximum number of keys tracked. The least
+	// recently active keys are forgotten first, and are not alerted
+	// on. Defaults to 4096.
+	MaxKeys uint32 `protobuf:"varint,3,opt,name=max_keys,json=maxKeys" json:"max_keys,omitempty"`
+	// Optional; if true, only QuietPeriodEvents are returned, and not
+	// the events that count as activity.
+	AlertsOnly bool `protobuf:"varint,4,opt,name=alerts_only,json=alertsOnly" json:"alerts_only,omitempty"`
+}
+
+func (m *QuietPeriod) Reset()                    { *m = QuietPeriod{} }
+func (m *QuietPeriod) String() string            { return proto.CompactTextString(m) }
+func (*QuietPeriod) ProtoMessage()               {}
+func (*QuietPeriod) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{6} }
+
+func (m *QuietPeriod) GetKey() QuietPeriod_Key {
+	if m != nil {
+		return m.Key
+	}
+	return QuietPeriod_PROCESS
+}
+
+func (m *QuietPeriod) GetSilenceSeconds() uint32 {
+	if m != nil {
+		return m.SilenceSeconds
+	}
+	return 0
+}
+
+func (m *QuietPeriod) GetMaxKeys() uint32 {
+	if m != nil {
+		return m.MaxKeys
+	}
+	return 0
+}
+
+func (m *QuietPeriod) GetAlertsOnly() bool {
+	if m != nil {
+		return m.AlertsOnly
+	}
+	return false
+}
+
+// The EdgeTrigger turns a condition on a process into events that report
+// when it changes. The predicate is evaluated for each event that matches
+// the Subscription's filters, and the event is only returned if the value
+// differs from the value for the previous event of the same process. A
+// process is assumed not to satisfy the predicate until an event shows
+// otherwise. Events that are not associated with a process are not
+// returned. The predicate may refer to any field that a filter for the
+// event may refer to; events for which it refers to unknown fields are not
+// returned, and the Subscription's status says so.
+type EdgeTrigger struct {
+	// Required; the condition to track for each process
+	Predicate *Expression `protobuf:"bytes,1,opt,name=predicate" json:"predicate,omitempty"`
+	// Optional; return events at which the predicate becomes true
+	// (rising) and/or false (falling). If neither is set, both are
+	// returned.
+	Rising  bool `protobuf:"varint,2,opt,name=rising" json:"rising,omitempty"`
+	Falling bool `protobuf:"varint,3,opt,name=falling" json:"falling,omitempty"`
+	// Optional; the maximum number of processes whose predicate values
+	// are remembered. The least recently seen processes are forgotten
+	// first. Defaults to 4096.
+	MaxProcesses uint32 `protobuf:"varint,4,opt,name=max_processes,json=maxProcesses" json:"max_processes,omitempty"`
+}
+
+func (m *EdgeTrigger) Reset()                    { *m = EdgeTrigger{} }
+func (m *EdgeTrigger) String() string            { return proto.CompactTextString(m) }
+func (*EdgeTrigger) ProtoMessage()               {}
+func (*EdgeTrigger) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{7} }
+
+func (m *EdgeTrigger) GetPredicate() *Expression {
+	if m != nil {
+		return m.Predicate
+	}
+	return nil
+}
+
+func (m *EdgeTrigger) GetRising() bool {
+	if m != nil {
+		return m.Rising
+	}
+	return false
+}
+
+func (m *EdgeTrigger) GetFalling() bool {
+	if m != nil {
+		return m.Falling
+	}
+	return false
+}
+
+func (m *EdgeTrigger) GetMaxProcesses() uint32 {
+	if m != nil {
+		return m.MaxProcesses
+	}
+	return 0
+}
+
+// The FailureThreshold detects brute-force patterns. The failure predicate
+// is evaluated for each event that matches the Subscription's filters, and
+// the consecutive failures of each key are counted. The event at which the
+// count reaches the threshold is returned once, with failure_count set; no
+// other events are returned. An event that is not a failure resets the
+// count of its key, and a failure more than the window after the first
+// failure counted starts a new count. Events without a key are not
+// returned. The predicate and key fields may refer to any field that a
+// filter for the event may refer to; events for which they refer to unknown
+// fields are not returned, and the Subscription's status says so.
+type FailureThreshold struct {
+	// Optional; what failures are counted by. Events without a key are
+	// ignored.
+	Key FailureThreshold_Key `protobuf:"varint,1,opt,name=key,enum=capsule8.api.v0.FailureThreshold_Key" json:"key,omitempty"`
+	// Optional; fields of the event whose values are added to the key,
+	// e.g. "filename" to count the failures to open each file
+	// separately.
+	KeyFields []string `protobuf:"bytes,2,rep,name=key_fields,json=keyFields" json:"key_fields,omitempty"`
+	// Optional; the condition that makes an event a failure. Defaults
+	// to ret < 0.
+	Failure *Expression `protobuf:"bytes,3,opt,name=failure" json:"failure,omitempty"`
+	// Required; the number of consecutive failures at which the event
+	// is returned
+	Threshold uint32 `protobuf:"varint,4,opt,name=threshold" json:"threshold,omitempty"`
+	// Required; the number of seconds from a key's first failure within
+	// which the threshold must be reached
+	WindowSeconds uint32 `protobuf:"varint,5,opt,name=window_seconds,json=windowSeconds" json:"window_seconds,omitempty"`
+	// Optional; the maximum number of keys whose failures are counted.
+	// The least recently failing keys are forgotten first. Defaults to
+	// 4096.
+	MaxKeys uint32 `protobuf:"varint,6,opt,name=max_keys,json=maxKeys" json:"max_keys,omitempty"`
+}
+
+func (m *FailureThreshold) Reset()                    { *m = FailureThreshold{} }
+func (m *FailureThreshold) String() string            { return proto.CompactTextString(m) }
+func (*FailureThreshold) ProtoMessage()               {}
+func (*FailureThreshold) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{8} }
+
+func (m *FailureThreshold) GetKey() FailureThreshold_Key {
+	if m != nil {
+		return m.Key
+	}
+	return FailureThreshold_PROCESS
+}
+
+func (m *FailureThreshold) GetKeyFields() []string {
+	if m != nil {
+		return m.KeyFields
+	}
+	return nil
+}
+
+func (m *FailureThreshold) GetFailure() *Expression {
+	if m != nil {
+		return m.Failure
+	}
+	return nil
+}
+
+func (m *FailureThreshold) GetThreshold() uint32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *FailureThreshold) GetWindowSeconds() uint32 {
+	if m != nil {
+		return m.WindowSeconds
+	}
+	return 0
+}
+
+func (m *FailureThreshold) GetMaxKeys() uint32 {
+	if m != nil {
+		return m.MaxKeys
+	}
+	return 0
+}
+
 // The EventFilter specifies events to include. All of the specified
 // fields are effectively "ORed" together to create the list of events
 // included in the Subscription.
@@ -237,6 +1064,11 @@ type EventFilter struct {
 	NetworkEvents []*NetworkEventFilter `protobuf:"bytes,5,rep,name=network_events,json=networkEvents" json:"network_events,omitempty"`
 	// Zero or more performance events to include
 	PerformanceEvents []*PerformanceEventFilter `protobuf:"bytes,6,rep,name=performance_events,json=performanceEvents" json:"performance_events,omitempty"`
+	// Zero or more signal events to include
+	SignalEvents []*SignalEventFilter `protobuf:"bytes,7,rep,name=signal_events,json=signalEvents" json:"signal_events,omitempty"`
+	// Zero or more filters selecting the detection of fileless
+	// execution. If more than one is given, their outputs are combined.
+	FilelessExecutionEvents []*FilelessExecutionFilter `protobuf:"bytes,8,rep,name=fileless_execution_events,json=filelessExecutionEvents" json:"fileless_execution_events,omitempty"`
 	// Zero or more container events to include
 	ContainerEvents []*ContainerEventFilter `protobuf:"bytes,10,rep,name=container_events,json=containerEvents" json:"container_events,omitempty"`
 	// Zero or more character generators to configure and return events from
@@ -245,12 +1077,15 @@ type EventFilter struct {
 	// Zero or more ticker generators to configure and return events from
 	// (for debugging)
 	TickerEvents []*TickerEventFilter `protobuf:"bytes,101,rep,name=ticker_events,json=tickerEvents" json:"ticker_events,omitempty"`
+	// Zero or more generators of synthetic events to configure and
+	// return events from (for client integration testing)
+	SyntheticEvents []*SyntheticEventFilter `protobuf:"bytes,102,rep,name=synthetic_events,json=syntheticEvents" json:"synthetic_events,omitempty"`
 }
 
 func (m *EventFilter) Reset()                    { *m = EventFilter{} }
 func (m *EventFilter) String() string            { return proto.CompactTextString(m) }
 func (*EventFilter) ProtoMessage()               {}
-func (*EventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{2} }
+func (*EventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{9} }
 
 func (m *EventFilter) GetSyscallEvents() []*SyscallEventFilter {
 	if m != nil {
@@ -294,6 +1129,20 @@ func (m *EventFilter) GetPerformanceEvents() []*PerformanceEventFilter {
 	return nil
 }
 
+func (m *EventFilter) GetSignalEvents() []*SignalEventFilter {
+	if m != nil {
+		return m.SignalEvents
+	}
+	return nil
+}
+
+func (m *EventFilter) GetFilelessExecutionEvents() []*FilelessExecutionFilter {
+	if m != nil {
+		return m.FilelessExecutionEvents
+	}
+	return nil
+}
+
 func (m *EventFilter) GetContainerEvents() []*ContainerEventFilter {
 	if m != nil {
 		return m.ContainerEvents
@@ -315,31 +1164,97 @@ func (m *EventFilter) GetTickerEvents() []*TickerEventFilter {
 	return nil
 }
 
+func (m *EventFilter) GetSyntheticEvents() []*SyntheticEventFilter {
+	if m != nil {
+		return m.SyntheticEvents
+	}
+	return nil
+}
+
+// FilelessExecutionFilter selects the detection of fileless execution: the
+// execution of an anonymous memory file created with memfd_create. The Sensor
+// records the memory files that each process creates and correlates them with
+// later calls to execve of "/proc/self/fd/N", "/proc/[pid]/fd/N", or
+// "/dev/fd/N", to execveat of the file descriptor itself, and to execve of
+// "/memfd:" paths. Memory files created by a process are also found when its
+// children execute them. The detection covers all processes selected by the
+// Subscription's container and pid filters.
+type FilelessExecutionFilter struct {
+	// Optional; the events to return. The default is the derived
+	// FilelessExecutionEvent only.
+	Output FilelessExecutionOutput `protobuf:"varint,1,opt,name=output,enum=capsule8.api.v0.FilelessExecutionOutput" json:"output,omitempty"`
+}
+
+func (m *FilelessExecutionFilter) Reset()                    { *m = FilelessExecutionFilter{} }
+func (m *FilelessExecutionFilter) String() string            { return proto.CompactTextString(m) }
+func (*FilelessExecutionFilter) ProtoMessage()               {}
+func (*FilelessExecutionFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{10} }
+
+func (m *FilelessExecutionFilter) GetOutput() FilelessExecutionOutput {
+	if m != nil {
+		return m.Output
+	}
+	return FilelessExecutionOutput_FILELESS_EXECUTION_OUTPUT_DERIVED
+}
+
 // The SyscallEventFilter specifies which system call events to
 // include in the Subscription. The specified fields are effectively
 // "ANDed" to specify a matching event.
 type SyscallEventFilter struct {
 	// Required; type of system call event (entry or exit)
-	Type             SyscallEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.SyscallEventType" json:"type,omitempty"`
-	FilterExpression *Expression      `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+	Type SyscallEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.SyscallEventType" json:"type,omitempty"`
+	// Optional; a filter to apply to events. For
+	// SYSCALL_EVENT_TYPE_COMPLETE filters, predicates on ret and bytes
+	// are evaluated when the system call exits, and must be combined
+	// with the rest of the expression by logical and.
+	FilterExpression *Expression `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+	// Optional; bitmask of the system call arguments to capture for
+	// entry events. Bit 0 selects arg0, bit 1 selects arg1, and so
+	// on through bit 5 for arg5. Arguments referenced by
+	// filter_expression are always captured in addition to those
+	// selected here. If zero, all arguments are captured.
+	ArgMask uint32 `protobuf:"varint,101,opt,name=arg_mask,json=argMask" json:"arg_mask,omitempty"`
+	// Optional; the action to take when only one of the enter or exit
+	// of a system call is observed for a SYSCALL_EVENT_TYPE_COMPLETE
+	// filter.
+	OrphanAction SyscallOrphanAction `protobuf:"varint,102,opt,name=orphan_action,json=orphanAction,enum=capsule8.api.v0.SyscallOrphanAction" json:"orphan_action,omitempty"`
+	// Required for SYSCALL_EVENT_TYPE_ARG_DISTRIBUTION filters; the
+	// system call argument to summarize and how to summarize it.
+	ArgDistribution *SyscallArgDistribution `protobuf:"bytes,103,opt,name=arg_distribution,json=argDistribution" json:"arg_distribution,omitempty"`
+	// Optional; for enter and complete filters, capture the paths
+	// passed to the system calls in filter_expression that modify files
+	// by path (chmod, chown, lchown, unlink, rename, link, and their
+	// *at variants), and of openat, mkdirat, mknodat, symlinkat, execve,
+	// and execveat, reporting them in SyscallEvent.path and new_path.
+	// Paths are also captured if filter_expression refers to path,
+	// new_path, path_relative, or new_path_relative. The names passed
+	// to memfd_create are always captured and reported in
+	// SyscallEvent.memfd_name. Because capturing strings is expensive,
+	// the enter events of these system calls (and of perf_event_open,
+	// whose struct perf_event_attr is captured) are reported by kprobes
+	// on their own entry points, on Linux 4.17 and later. The enter
+	// events of all other system calls are reported by a single kprobe
+	// that captures only integer arguments. On older kernels, the
+	// strings are captured by that kprobe for every system call.
+	CapturePaths bool `protobuf:"varint,104,opt,name=capture_paths,json=capturePaths" json:"capture_paths,omitempty"`
 	// Required; system call number from
 	// arch/x86/entry/syscalls/syscall_64.tbl
-	Id *google_protobuf1.Int64Value `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
+	Id *google_protobuf2.Int64Value `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
 	// Optional; precise value of a particular system call argument
-	Arg0 *google_protobuf1.UInt64Value `protobuf:"bytes,10,opt,name=arg0" json:"arg0,omitempty"`
-	Arg1 *google_protobuf1.UInt64Value `protobuf:"bytes,11,opt,name=arg1" json:"arg1,omitempty"`
-	Arg2 *google_protobuf1.UInt64Value `protobuf:"bytes,12,opt,name=arg2" json:"arg2,omitempty"`
-	Arg3 *google_protobuf1.UInt64Value `protobuf:"bytes,13,opt,name=arg3" json:"arg3,omitempty"`
-	Arg4 *google_protobuf1.UInt64Value `protobuf:"bytes,14,opt,name=arg4" json:"arg4,omitempty"`
-	Arg5 *google_protobuf1.UInt64Value `protobuf:"bytes,15,opt,name=arg5" json:"arg5,omitempty"`
+	Arg0 *google_protobuf2.UInt64Value `protobuf:"bytes,10,opt,name=arg0" json:"arg0,omitempty"`
+	Arg1 *google_protobuf2.UInt64Value `protobuf:"bytes,11,opt,name=arg1" json:"arg1,omitempty"`
+	Arg2 *google_protobuf2.UInt64Value `protobuf:"bytes,12,opt,name=arg2" json:"arg2,omitempty"`
+	Arg3 *google_protobuf2.UInt64Value `protobuf:"bytes,13,opt,name=arg3" json:"arg3,omitempty"`
+	Arg4 *google_protobuf2.UInt64Value `protobuf:"bytes,14,opt,name=arg4" json:"arg4,omitempty"`
+	Arg5 *google_protobuf2.UInt64Value `protobuf:"bytes,15,opt,name=arg5" json:"arg5,omitempty"`
 	// Optional; return value of the system call (if type indicates exit).
-	Ret *google_protobuf1.Int64Value `protobuf:"bytes,20,opt,name=ret" json:"ret,omitempty"`
+	Ret *google_protobuf2.Int64Value `protobuf:"bytes,20,opt,name=ret" json:"ret,omitempty"`
 }
 
 func (m *SyscallEventFilter) Reset()                    { *m = SyscallEventFilter{} }
 func (m *SyscallEventFilter) String() string            { return proto.CompactTextString(m) }
 func (*SyscallEventFilter) ProtoMessage()               {}
-func (*SyscallEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{3} }
+func (*SyscallEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{11} }
 
 func (m *SyscallEventFilter) GetType() SyscallEventType {
 	if m != nil {
@@ -355,81 +1270,163 @@ func (m *SyscallEventFilter) GetFilterExpression() *Expression {
 	return nil
 }
 
-func (m *SyscallEventFilter) GetId() *google_protobuf1.Int64Value {
+func (m *SyscallEventFilter) GetArgMask() uint32 {
+	if m != nil {
+		return m.ArgMask
+	}
+	return 0
+}
+
+func (m *SyscallEventFilter) GetOrphanAction() SyscallOrphanAction {
+	if m != nil {
+		return m.OrphanAction
+	}
+	return SyscallOrphanAction_SYSCALL_ORPHAN_ACTION_DROP
+}
+
+func (m *SyscallEventFilter) GetArgDistribution() *SyscallArgDistribution {
+	if m != nil {
+		return m.ArgDistribution
+	}
+	return nil
+}
+
+func (m *SyscallEventFilter) GetCapturePaths() bool {
+	if m != nil {
+		return m.CapturePaths
+	}
+	return false
+}
+
+func (m *SyscallEventFilter) GetId() *google_protobuf2.Int64Value {
 	if m != nil {
 		return m.Id
 	}
 	return nil
 }
 
-func (m *SyscallEventFilter) GetArg0() *google_protobuf1.UInt64Value {
+func (m *SyscallEventFilter) GetArg0() *google_protobuf2.UInt64Value {
 	if m != nil {
 		return m.Arg0
 	}
 	return nil
 }
 
-func (m *SyscallEventFilter) GetArg1() *google_protobuf1.UInt64Value {
+func (m *SyscallEventFilter) GetArg1() *google_protobuf2.UInt64Value {
 	if m != nil {
 		return m.Arg1
 	}
 	return nil
 }
 
-func (m *SyscallEventFilter) GetArg2() *google_protobuf1.UInt64Value {
+func (m *SyscallEventFilter) GetArg2() *google_protobuf2.UInt64Value {
 	if m != nil {
 		return m.Arg2
 	}
 	return nil
 }
 
-func (m *SyscallEventFilter) GetArg3() *google_protobuf1.UInt64Value {
+func (m *SyscallEventFilter) GetArg3() *google_protobuf2.UInt64Value {
 	if m != nil {
 		return m.Arg3
 	}
 	return nil
 }
 
-func (m *SyscallEventFilter) GetArg4() *google_protobuf1.UInt64Value {
+func (m *SyscallEventFilter) GetArg4() *google_protobuf2.UInt64Value {
 	if m != nil {
 		return m.Arg4
 	}
 	return nil
 }
 
-func (m *SyscallEventFilter) GetArg5() *google_protobuf1.UInt64Value {
+func (m *SyscallEventFilter) GetArg5() *google_protobuf2.UInt64Value {
 	if m != nil {
 		return m.Arg5
 	}
 	return nil
 }
 
-func (m *SyscallEventFilter) GetRet() *google_protobuf1.Int64Value {
+func (m *SyscallEventFilter) GetRet() *google_protobuf2.Int64Value {
 	if m != nil {
 		return m.Ret
 	}
 	return nil
 }
 
+// SyscallArgDistribution configures a SYSCALL_EVENT_TYPE_ARG_DISTRIBUTION
+// filter. Instead of an event for each matching system call, an event with
+// the most frequently seen values of the argument is emitted at the end of
+// each interval for each system call number that was seen.
+type SyscallArgDistribution struct {
+	// Required; index of the argument to summarize, from 0 to 5
+	ArgIndex uint32 `protobuf:"varint,1,opt,name=arg_index,json=argIndex" json:"arg_index,omitempty"`
+	// Optional; maximum number of values to include in each summary.
+	// Defaults to 10.
+	TopK uint32 `protobuf:"varint,2,opt,name=top_k,json=topK" json:"top_k,omitempty"`
+	// Optional; length of the summary interval in seconds. Defaults
+	// to 60.
+	IntervalSeconds uint32 `protobuf:"varint,3,opt,name=interval_seconds,json=intervalSeconds" json:"interval_seconds,omitempty"`
+}
+
+func (m *SyscallArgDistribution) Reset()                    { *m = SyscallArgDistribution{} }
+func (m *SyscallArgDistribution) String() string            { return proto.CompactTextString(m) }
+func (*SyscallArgDistribution) ProtoMessage()               {}
+func (*SyscallArgDistribution) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{12} }
+
+func (m *SyscallArgDistribution) GetArgIndex() uint32 {
+	if m != nil {
+		return m.ArgIndex
+	}
+	return 0
+}
+
+func (m *SyscallArgDistribution) GetTopK() uint32 {
+	if m != nil {
+		return m.TopK
+	}
+	return 0
+}
+
+func (m *SyscallArgDistribution) GetIntervalSeconds() uint32 {
+	if m != nil {
+		return m.IntervalSeconds
+	}
+	return 0
+}
+
 // The ProcessEventFilter specifies which process events to include in
 // the Subscription. The specified fields are effectively "ANDed" to
 // specify a matching event.
 type ProcessEventFilter struct {
 	// Required; the process event type to match
-	Type             ProcessEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.ProcessEventType" json:"type,omitempty"`
-	FilterExpression *Expression      `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+	Type ProcessEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.ProcessEventType" json:"type,omitempty"`
+	// Optional; for exec events, capture the file descriptors that the
+	// process has open after it executes, revealing descriptors that
+	// it inherited. Reading them is expensive, so they are only
+	// captured if requested. If any exec filter of a subscription
+	// requests them, they are captured for all of its exec events.
+	ExecFdSnapshot *ExecFdSnapshot `protobuf:"bytes,2,opt,name=exec_fd_snapshot,json=execFdSnapshot" json:"exec_fd_snapshot,omitempty"`
+	// Optional; for exit events, read the CPU time and page faults of
+	// the exiting task from /proc. Reading them is expensive, so they
+	// are only read if requested, and they are missed for tasks that
+	// are reaped before the Sensor decodes their exit. While any
+	// subscription requests them, they are included in the exit
+	// events of all subscriptions.
+	ExitResourceUsage bool        `protobuf:"varint,3,opt,name=exit_resource_usage,json=exitResourceUsage" json:"exit_resource_usage,omitempty"`
+	FilterExpression  *Expression `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
 	// Optional; require exact match on the filename passed to execve(2)
-	ExecFilename *google_protobuf1.StringValue `protobuf:"bytes,12,opt,name=exec_filename,json=execFilename" json:"exec_filename,omitempty"`
+	ExecFilename *google_protobuf2.StringValue `protobuf:"bytes,12,opt,name=exec_filename,json=execFilename" json:"exec_filename,omitempty"`
 	// Optional; require pattern match on the filename passed to execve(2)
-	ExecFilenamePattern *google_protobuf1.StringValue `protobuf:"bytes,13,opt,name=exec_filename_pattern,json=execFilenamePattern" json:"exec_filename_pattern,omitempty"`
+	ExecFilenamePattern *google_protobuf2.StringValue `protobuf:"bytes,13,opt,name=exec_filename_pattern,json=execFilenamePattern" json:"exec_filename_pattern,omitempty"`
 	// Optional; require exact match on exit code
-	ExitCode *google_protobuf1.Int32Value `protobuf:"bytes,14,opt,name=exit_code,json=exitCode" json:"exit_code,omitempty"`
+	ExitCode *google_protobuf2.Int32Value `protobuf:"bytes,14,opt,name=exit_code,json=exitCode" json:"exit_code,omitempty"`
 }
 
 func (m *ProcessEventFilter) Reset()                    { *m = ProcessEventFilter{} }
 func (m *ProcessEventFilter) String() string            { return proto.CompactTextString(m) }
 func (*ProcessEventFilter) ProtoMessage()               {}
-func (*ProcessEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{4} }
+func (*ProcessEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{13} }
 
 func (m *ProcessEventFilter) GetType() ProcessEventType {
 	if m != nil {
@@ -438,6 +1435,20 @@ func (m *ProcessEventFilter) GetType() ProcessEventType {
 	return ProcessEventType_PROCESS_EVENT_TYPE_UNKNOWN
 }
 
+func (m *ProcessEventFilter) GetExecFdSnapshot() *ExecFdSnapshot {
+	if m != nil {
+		return m.ExecFdSnapshot
+	}
+	return nil
+}
+
+func (m *ProcessEventFilter) GetExitResourceUsage() bool {
+	if m != nil {
+		return m.ExitResourceUsage
+	}
+	return false
+}
+
 func (m *ProcessEventFilter) GetFilterExpression() *Expression {
 	if m != nil {
 		return m.FilterExpression
@@ -445,27 +1456,48 @@ func (m *ProcessEventFilter) GetFilterExpression() *Expression {
 	return nil
 }
 
-func (m *ProcessEventFilter) GetExecFilename() *google_protobuf1.StringValue {
+func (m *ProcessEventFilter) GetExecFilename() *google_protobuf2.StringValue {
 	if m != nil {
 		return m.ExecFilename
 	}
 	return nil
 }
 
-func (m *ProcessEventFilter) GetExecFilenamePattern() *google_protobuf1.StringValue {
+func (m *ProcessEventFilter) GetExecFilenamePattern() *google_protobuf2.StringValue {
 	if m != nil {
 		return m.ExecFilenamePattern
 	}
 	return nil
 }
 
-func (m *ProcessEventFilter) GetExitCode() *google_protobuf1.Int32Value {
+func (m *ProcessEventFilter) GetExitCode() *google_protobuf2.Int32Value {
 	if m != nil {
 		return m.ExitCode
 	}
 	return nil
 }
 
+// ExecFdSnapshot configures the capture of the open file descriptors of
+// processes when they execute
+type ExecFdSnapshot struct {
+	// Optional; the maximum number of file descriptors to capture for
+	// each event. The lowest numbered descriptors are captured. If
+	// zero or greater than the Sensor's limit, the limit is used.
+	MaxFds uint32 `protobuf:"varint,1,opt,name=max_fds,json=maxFds" json:"max_fds,omitempty"`
+}
+
+func (m *ExecFdSnapshot) Reset()                    { *m = ExecFdSnapshot{} }
+func (m *ExecFdSnapshot) String() string            { return proto.CompactTextString(m) }
+func (*ExecFdSnapshot) ProtoMessage()               {}
+func (*ExecFdSnapshot) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{14} }
+
+func (m *ExecFdSnapshot) GetMaxFds() uint32 {
+	if m != nil {
+		return m.MaxFds
+	}
+	return 0
+}
+
 // The FileEventFilter specifies which file events to include in the
 // Subscription. The specified fields are effectively "ANDed" to
 // specify a matching event.
@@ -474,21 +1506,21 @@ type FileEventFilter struct {
 	Type             FileEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.FileEventType" json:"type,omitempty"`
 	FilterExpression *Expression   `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
 	// Optional; require exact match on the filename being acted upon
-	Filename *google_protobuf1.StringValue `protobuf:"bytes,10,opt,name=filename" json:"filename,omitempty"`
+	Filename *google_protobuf2.StringValue `protobuf:"bytes,10,opt,name=filename" json:"filename,omitempty"`
 	// Optional; require pattern match on the filename being acted upon
-	FilenamePattern *google_protobuf1.StringValue `protobuf:"bytes,11,opt,name=filename_pattern,json=filenamePattern" json:"filename_pattern,omitempty"`
+	FilenamePattern *google_protobuf2.StringValue `protobuf:"bytes,11,opt,name=filename_pattern,json=filenamePattern" json:"filename_pattern,omitempty"`
 	// Optional; for file open events, require a match of the bits set
 	// for the open(2) flags argument
-	OpenFlagsMask *google_protobuf1.Int32Value `protobuf:"bytes,12,opt,name=open_flags_mask,json=openFlagsMask" json:"open_flags_mask,omitempty"`
+	OpenFlagsMask *google_protobuf2.Int32Value `protobuf:"bytes,12,opt,name=open_flags_mask,json=openFlagsMask" json:"open_flags_mask,omitempty"`
 	// Optional; for file open events, require a match of the bits set
 	// for the open(2) or creat(2) mode argument
-	CreateModeMask *google_protobuf1.Int32Value `protobuf:"bytes,13,opt,name=create_mode_mask,json=createModeMask" json:"create_mode_mask,omitempty"`
+	CreateModeMask *google_protobuf2.Int32Value `protobuf:"bytes,13,opt,name=create_mode_mask,json=createModeMask" json:"create_mode_mask,omitempty"`
 }
 
 func (m *FileEventFilter) Reset()                    { *m = FileEventFilter{} }
 func (m *FileEventFilter) String() string            { return proto.CompactTextString(m) }
 func (*FileEventFilter) ProtoMessage()               {}
-func (*FileEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{5} }
+func (*FileEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{15} }
 
 func (m *FileEventFilter) GetType() FileEventType {
 	if m != nil {
@@ -504,34 +1536,65 @@ func (m *FileEventFilter) GetFilterExpression() *Expression {
 	return nil
 }
 
-func (m *FileEventFilter) GetFilename() *google_protobuf1.StringValue {
+func (m *FileEventFilter) GetFilename() *google_protobuf2.StringValue {
 	if m != nil {
 		return m.Filename
 	}
 	return nil
 }
 
-func (m *FileEventFilter) GetFilenamePattern() *google_protobuf1.StringValue {
+func (m *FileEventFilter) GetFilenamePattern() *google_protobuf2.StringValue {
 	if m != nil {
 		return m.FilenamePattern
 	}
 	return nil
 }
 
-func (m *FileEventFilter) GetOpenFlagsMask() *google_protobuf1.Int32Value {
+func (m *FileEventFilter) GetOpenFlagsMask() *google_protobuf2.Int32Value {
 	if m != nil {
 		return m.OpenFlagsMask
 	}
 	return nil
 }
 
-func (m *FileEventFilter) GetCreateModeMask() *google_protobuf1.Int32Value {
+func (m *FileEventFilter) GetCreateModeMask() *google_protobuf2.Int32Value {
 	if m != nil {
 		return m.CreateModeMask
 	}
 	return nil
 }
 
+// The SignalEventFilter specifies which signal events to include in the
+// Subscription. Generate events may be filtered on sig, signal_name, pid
+// (the target), errno, code, group, and result. Deliver events may be
+// filtered on sig, signal_name, errno, code, sa_handler, and sa_flags.
+// Filters that refer to signal_name are evaluated by the Sensor rather than
+// the kernel.
+type SignalEventFilter struct {
+	// Required; the signal event type to match
+	Type             SignalEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.SignalEventType" json:"type,omitempty"`
+	FilterExpression *Expression     `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+}
+
+func (m *SignalEventFilter) Reset()                    { *m = SignalEventFilter{} }
+func (m *SignalEventFilter) String() string            { return proto.CompactTextString(m) }
+func (*SignalEventFilter) ProtoMessage()               {}
+func (*SignalEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{16} }
+
+func (m *SignalEventFilter) GetType() SignalEventType {
+	if m != nil {
+		return m.Type
+	}
+	return SignalEventType_SIGNAL_EVENT_TYPE_UNKNOWN
+}
+
+func (m *SignalEventFilter) GetFilterExpression() *Expression {
+	if m != nil {
+		return m.FilterExpression
+	}
+	return nil
+}
+
 // The KernelFunctionCallFilter specifies which kernel function call
 // events to include in the Subscription. The arguments map defines
 // values that will be fetched at each call and returned along with
@@ -551,7 +1614,11 @@ type KernelFunctionCallFilter struct {
 	// register containing the desired data and a suffix indicating the
 	// type of the data (e.g., "s32", "string", "u64", etc.). This map is
 	// used to construct the "fetchargs" passed to the kernel when creating
-	// the kernel probe.
+	// the kernel probe. Supported type suffixes are u8, u16, u32, u64,
+	// s8, s16, s32, s64, x8, x16, x32, x64, string, ustring, symbol,
+	// bitfields (b<width>@<offset>/<container>), and arrays of the
+	// numeric types (e.g., "u8[16]"). Arguments with unsupported types
+	// cause the filter to be rejected.
 	Arguments map[string]string `protobuf:"bytes,11,rep,name=arguments" json:"arguments,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// Optional; a filter to apply to kernel probe.
 	FilterExpression *Expression `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
@@ -560,7 +1627,7 @@ type KernelFunctionCallFilter struct {
 func (m *KernelFunctionCallFilter) Reset()                    { *m = KernelFunctionCallFilter{} }
 func (m *KernelFunctionCallFilter) String() string            { return proto.CompactTextString(m) }
 func (*KernelFunctionCallFilter) ProtoMessage()               {}
-func (*KernelFunctionCallFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{6} }
+func (*KernelFunctionCallFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{17} }
 
 func (m *KernelFunctionCallFilter) GetType() KernelFunctionCallEventType {
 	if m != nil {
@@ -604,7 +1671,7 @@ type NetworkEventFilter struct {
 func (m *NetworkEventFilter) Reset()                    { *m = NetworkEventFilter{} }
 func (m *NetworkEventFilter) String() string            { return proto.CompactTextString(m) }
 func (*NetworkEventFilter) ProtoMessage()               {}
-func (*NetworkEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{7} }
+func (*NetworkEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{18} }
 
 func (m *NetworkEventFilter) GetType() NetworkEventType {
 	if m != nil {
@@ -635,7 +1702,7 @@ type PerformanceEventCounter struct {
 func (m *PerformanceEventCounter) Reset()                    { *m = PerformanceEventCounter{} }
 func (m *PerformanceEventCounter) String() string            { return proto.CompactTextString(m) }
 func (*PerformanceEventCounter) ProtoMessage()               {}
-func (*PerformanceEventCounter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{8} }
+func (*PerformanceEventCounter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{19} }
 
 func (m *PerformanceEventCounter) GetType() PerformanceEventType {
 	if m != nil {
@@ -671,7 +1738,7 @@ type PerformanceEventFilter struct {
 func (m *PerformanceEventFilter) Reset()                    { *m = PerformanceEventFilter{} }
 func (m *PerformanceEventFilter) String() string            { return proto.CompactTextString(m) }
 func (*PerformanceEventFilter) ProtoMessage()               {}
-func (*PerformanceEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{9} }
+func (*PerformanceEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{20} }
 
 type isPerformanceEventFilter_SampleRate interface {
 	isPerformanceEventFilter_SampleRate()
@@ -802,7 +1869,7 @@ type ContainerEventFilter struct {
 func (m *ContainerEventFilter) Reset()                    { *m = ContainerEventFilter{} }
 func (m *ContainerEventFilter) String() string            { return proto.CompactTextString(m) }
 func (*ContainerEventFilter) ProtoMessage()               {}
-func (*ContainerEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{10} }
+func (*ContainerEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{21} }
 
 func (m *ContainerEventFilter) GetType() ContainerEventType {
 	if m != nil {
@@ -835,7 +1902,7 @@ type ChargenEventFilter struct {
 func (m *ChargenEventFilter) Reset()                    { *m = ChargenEventFilter{} }
 func (m *ChargenEventFilter) String() string            { return proto.CompactTextString(m) }
 func (*ChargenEventFilter) ProtoMessage()               {}
-func (*ChargenEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{11} }
+func (*ChargenEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{22} }
 
 func (m *ChargenEventFilter) GetLength() uint64 {
 	if m != nil {
@@ -854,7 +1921,7 @@ type TickerEventFilter struct {
 func (m *TickerEventFilter) Reset()                    { *m = TickerEventFilter{} }
 func (m *TickerEventFilter) String() string            { return proto.CompactTextString(m) }
 func (*TickerEventFilter) ProtoMessage()               {}
-func (*TickerEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{12} }
+func (*TickerEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{23} }
 
 func (m *TickerEventFilter) GetInterval() int64 {
 	if m != nil {
@@ -863,6 +1930,53 @@ func (m *TickerEventFilter) GetInterval() int64 {
 	return 0
 }
 
+// The SyntheticEventFilter configures a generator of copies of a template
+// event and includes them in the Subscription, so that clients can exercise
+// their handling of events without causing real ones. Synthetic events are
+// delivered like the Subscription's other events, so its container and pid
+// filters, sampling, throttling, and limits apply to them, but they are not
+// subject to the Sensor's per-syscall rate limit. Each has synthetic set,
+// and they are excluded from the Sensor's counts of observed events.
+type SyntheticEventFilter struct {
+	// Required; the event to generate, which must have a payload. Its
+	// fields are copied into each generated event, except for id,
+	// sensor_id, sensor_sequence_number, and sensor_monotime_nanos,
+	// which the Sensor sets.
+	Template *TelemetryEvent `protobuf:"bytes,1,opt,name=template" json:"template,omitempty"`
+	// Required; the number of events to generate per second, at most
+	// 100000
+	EventsPerSecond float64 `protobuf:"fixed64,2,opt,name=events_per_second,json=eventsPerSecond" json:"events_per_second,omitempty"`
+	// Optional; the number of events to generate. If zero, events are
+	// generated until the Subscription ends.
+	Count uint64 `protobuf:"varint,3,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *SyntheticEventFilter) Reset()                    { *m = SyntheticEventFilter{} }
+func (m *SyntheticEventFilter) String() string            { return proto.CompactTextString(m) }
+func (*SyntheticEventFilter) ProtoMessage()               {}
+func (*SyntheticEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{24} }
+
+func (m *SyntheticEventFilter) GetTemplate() *TelemetryEvent {
+	if m != nil {
+		return m.Template
+	}
+	return nil
+}
+
+func (m *SyntheticEventFilter) GetEventsPerSecond() float64 {
+	if m != nil {
+		return m.EventsPerSecond
+	}
+	return 0
+}
+
+func (m *SyntheticEventFilter) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
 // Modifier specifies which stream modifiers to apply if any. For a given
 // stream, a modifier can apply a throttle or limit etc. Modifiers can be
 // used together.
@@ -874,7 +1988,7 @@ type Modifier struct {
 func (m *Modifier) Reset()                    { *m = Modifier{} }
 func (m *Modifier) String() string            { return proto.CompactTextString(m) }
 func (*Modifier) ProtoMessage()               {}
-func (*Modifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{13} }
+func (*Modifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{25} }
 
 func (m *Modifier) GetThrottle() *ThrottleModifier {
 	if m != nil {
@@ -902,7 +2016,7 @@ type ThrottleModifier struct {
 func (m *ThrottleModifier) Reset()                    { *m = ThrottleModifier{} }
 func (m *ThrottleModifier) String() string            { return proto.CompactTextString(m) }
 func (*ThrottleModifier) ProtoMessage()               {}
-func (*ThrottleModifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{14} }
+func (*ThrottleModifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{26} }
 
 func (m *ThrottleModifier) GetInterval() int64 {
 	if m != nil {
@@ -929,7 +2043,7 @@ type LimitModifier struct {
 func (m *LimitModifier) Reset()                    { *m = LimitModifier{} }
 func (m *LimitModifier) String() string            { return proto.CompactTextString(m) }
 func (*LimitModifier) ProtoMessage()               {}
-func (*LimitModifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{15} }
+func (*LimitModifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{27} }
 
 func (m *LimitModifier) GetLimit() int64 {
 	if m != nil {
@@ -941,10 +2055,21 @@ func (m *LimitModifier) GetLimit() int64 {
 func init() {
 	proto.RegisterType((*Subscription)(nil), "capsule8.api.v0.Subscription")
 	proto.RegisterType((*ContainerFilter)(nil), "capsule8.api.v0.ContainerFilter")
+	proto.RegisterType((*PidFilter)(nil), "capsule8.api.v0.PidFilter")
+	proto.RegisterType((*PidCardinality)(nil), "capsule8.api.v0.PidCardinality")
+	proto.RegisterType((*ContainerSampling)(nil), "capsule8.api.v0.ContainerSampling")
+	proto.RegisterType((*AckThrottle)(nil), "capsule8.api.v0.AckThrottle")
+	proto.RegisterType((*QuietPeriod)(nil), "capsule8.api.v0.QuietPeriod")
+	proto.RegisterType((*EdgeTrigger)(nil), "capsule8.api.v0.EdgeTrigger")
+	proto.RegisterType((*FailureThreshold)(nil), "capsule8.api.v0.FailureThreshold")
 	proto.RegisterType((*EventFilter)(nil), "capsule8.api.v0.EventFilter")
+	proto.RegisterType((*FilelessExecutionFilter)(nil), "capsule8.api.v0.FilelessExecutionFilter")
 	proto.RegisterType((*SyscallEventFilter)(nil), "capsule8.api.v0.SyscallEventFilter")
+	proto.RegisterType((*SyscallArgDistribution)(nil), "capsule8.api.v0.SyscallArgDistribution")
 	proto.RegisterType((*ProcessEventFilter)(nil), "capsule8.api.v0.ProcessEventFilter")
+	proto.RegisterType((*ExecFdSnapshot)(nil), "capsule8.api.v0.ExecFdSnapshot")
 	proto.RegisterType((*FileEventFilter)(nil), "capsule8.api.v0.FileEventFilter")
+	proto.RegisterType((*SignalEventFilter)(nil), "capsule8.api.v0.SignalEventFilter")
 	proto.RegisterType((*KernelFunctionCallFilter)(nil), "capsule8.api.v0.KernelFunctionCallFilter")
 	proto.RegisterType((*NetworkEventFilter)(nil), "capsule8.api.v0.NetworkEventFilter")
 	proto.RegisterType((*PerformanceEventCounter)(nil), "capsule8.api.v0.PerformanceEventCounter")
@@ -952,104 +2077,215 @@ func init() {
 	proto.RegisterType((*ContainerEventFilter)(nil), "capsule8.api.v0.ContainerEventFilter")
 	proto.RegisterType((*ChargenEventFilter)(nil), "capsule8.api.v0.ChargenEventFilter")
 	proto.RegisterType((*TickerEventFilter)(nil), "capsule8.api.v0.TickerEventFilter")
+	proto.RegisterType((*SyntheticEventFilter)(nil), "capsule8.api.v0.SyntheticEventFilter")
 	proto.RegisterType((*Modifier)(nil), "capsule8.api.v0.Modifier")
 	proto.RegisterType((*ThrottleModifier)(nil), "capsule8.api.v0.ThrottleModifier")
 	proto.RegisterType((*LimitModifier)(nil), "capsule8.api.v0.LimitModifier")
+	proto.RegisterEnum("capsule8.api.v0.SampleField", SampleField_name, SampleField_value)
+	proto.RegisterEnum("capsule8.api.v0.SubscriptionPriority", SubscriptionPriority_name, SubscriptionPriority_value)
+	proto.RegisterEnum("capsule8.api.v0.PerfClock", PerfClock_name, PerfClock_value)
+	proto.RegisterEnum("capsule8.api.v0.DecodeIsolation", DecodeIsolation_name, DecodeIsolation_value)
+	proto.RegisterEnum("capsule8.api.v0.UnsupportedFieldPolicy", UnsupportedFieldPolicy_name, UnsupportedFieldPolicy_value)
+	proto.RegisterEnum("capsule8.api.v0.FilelessExecutionOutput", FilelessExecutionOutput_name, FilelessExecutionOutput_value)
+	proto.RegisterEnum("capsule8.api.v0.SyscallOrphanAction", SyscallOrphanAction_name, SyscallOrphanAction_value)
 	proto.RegisterEnum("capsule8.api.v0.SampleRateType", SampleRateType_name, SampleRateType_value)
 	proto.RegisterEnum("capsule8.api.v0.ContainerEventView", ContainerEventView_name, ContainerEventView_value)
+	proto.RegisterEnum("capsule8.api.v0.ContainerSampling_Mode", ContainerSampling_Mode_name, ContainerSampling_Mode_value)
+	proto.RegisterEnum("capsule8.api.v0.QuietPeriod_Key", QuietPeriod_Key_name, QuietPeriod_Key_value)
+	proto.RegisterEnum("capsule8.api.v0.FailureThreshold_Key", FailureThreshold_Key_name, FailureThreshold_Key_value)
 	proto.RegisterEnum("capsule8.api.v0.ThrottleModifier_IntervalType", ThrottleModifier_IntervalType_name, ThrottleModifier_IntervalType_value)
 }
 
 func init() { proto.RegisterFile("capsule8/api/v0/subscription.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 1403 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xdb, 0x72, 0xda, 0x46,
-	0x18, 0x36, 0x07, 0x7b, 0xe0, 0xe7, 0xa4, 0x6c, 0xd3, 0x84, 0x3a, 0x19, 0xc7, 0x55, 0xc6, 0xd3,
-	0x24, 0x4d, 0xb1, 0x83, 0xed, 0xc6, 0xed, 0xf4, 0x10, 0x42, 0x20, 0xa1, 0xb1, 0x31, 0x15, 0xb6,
-	0x3b, 0xb9, 0xd2, 0xc8, 0x62, 0x21, 0x1a, 0x84, 0xa4, 0xee, 0x0a, 0x3b, 0x5c, 0xf5, 0x29, 0x7a,
-	0xd9, 0xbe, 0x4c, 0x67, 0xfa, 0x00, 0x9d, 0xce, 0xf4, 0x05, 0x7a, 0xdd, 0x67, 0xe8, 0xec, 0x01,
-	0x90, 0x50, 0x30, 0x5c, 0xc4, 0x77, 0xda, 0x7f, 0xbf, 0xef, 0xe3, 0x3f, 0xed, 0xbf, 0x0b, 0xa8,
-	0xa6, 0xe1, 0xd1, 0xa1, 0x8d, 0x0f, 0xb6, 0x0d, 0xcf, 0xda, 0xbe, 0xd8, 0xd9, 0xa6, 0xc3, 0x73,
-	0x6a, 0x12, 0xcb, 0xf3, 0x2d, 0xd7, 0x29, 0x79, 0xc4, 0xf5, 0x5d, 0x54, 0x18, 0x63, 0x4a, 0x86,
-	0x67, 0x95, 0x2e, 0x76, 0xd6, 0xb7, 0x66, 0x49, 0x3e, 0xb6, 0xf1, 0x00, 0xfb, 0x64, 0xa4, 0xe3,
-	0x0b, 0xec, 0xf8, 0x82, 0xb7, 0xbe, 0x39, 0x0b, 0xc3, 0xef, 0x3c, 0x82, 0x29, 0x9d, 0x28, 0xaf,
-	0x6f, 0xf4, 0x5c, 0xb7, 0x67, 0xe3, 0x6d, 0xbe, 0x3a, 0x1f, 0x76, 0xb7, 0x2f, 0x89, 0xe1, 0x79,
-	0x98, 0x50, 0xb1, 0xaf, 0xfe, 0x13, 0x87, 0x6c, 0x3b, 0xe0, 0x10, 0xfa, 0x1e, 0xb2, 0xfc, 0x17,
-	0xf4, 0xae, 0x65, 0xfb, 0x98, 0x14, 0x63, 0x9b, 0xb1, 0x07, 0x99, 0xf2, 0xdd, 0xd2, 0x8c, 0x87,
-	0xa5, 0x1a, 0x03, 0xd5, 0x39, 0x46, 0xcb, 0xe0, 0xe9, 0x02, 0xbd, 0x06, 0xc5, 0x74, 0x1d, 0xdf,
-	0xb0, 0x1c, 0x4c, 0xc6, 0x22, 0x71, 0x2e, 0xb2, 0x19, 0x11, 0xa9, 0x8e, 0x81, 0x52, 0xa8, 0x60,
-	0x86, 0x0d, 0xe8, 0x39, 0xe4, 0xa9, 0xe5, 0x98, 0x58, 0xef, 0x0c, 0x89, 0xc1, 0xfc, 0x2b, 0x02,
-	0x97, 0xba, 0x53, 0x12, 0x71, 0x95, 0xc6, 0x71, 0x95, 0x1a, 0x8e, 0xff, 0xe5, 0xde, 0x99, 0x61,
-	0x0f, 0xb1, 0x96, 0xe3, 0x94, 0x17, 0x92, 0x81, 0xbe, 0x83, 0x6c, 0xd7, 0x25, 0x53, 0x85, 0xcc,
-	0x62, 0x85, 0x4c, 0xd7, 0x25, 0x13, 0xfe, 0x3e, 0xa4, 0x06, 0x6e, 0xc7, 0xea, 0x5a, 0x98, 0x14,
-	0x6f, 0x72, 0xee, 0x27, 0x91, 0x40, 0x8e, 0x24, 0x40, 0x9b, 0x40, 0xd5, 0x4b, 0x28, 0xcc, 0x84,
-	0x87, 0x14, 0x48, 0x58, 0x1d, 0x5a, 0x8c, 0x6d, 0x26, 0x1e, 0xa4, 0x35, 0xf6, 0x89, 0x6e, 0xc2,
-	0xaa, 0x63, 0x0c, 0x30, 0x2d, 0xc6, 0xb9, 0x4d, 0x2c, 0xd0, 0x1d, 0x48, 0x5b, 0x03, 0xa3, 0x87,
-	0x75, 0x86, 0x4e, 0xf0, 0x9d, 0x14, 0x37, 0x34, 0x3a, 0x14, 0xdd, 0x83, 0x8c, 0xd8, 0x14, 0xc4,
-	0x24, 0xdf, 0x06, 0x6e, 0x6a, 0x32, 0x8b, 0xfa, 0xc7, 0x2a, 0x64, 0x02, 0xd5, 0x41, 0x3f, 0x40,
-	0x9e, 0x8e, 0xa8, 0x69, 0xd8, 0xb6, 0xe8, 0x1d, 0xe1, 0x40, 0xa6, 0x7c, 0x3f, 0x12, 0x45, 0x5b,
-	0xc0, 0x82, 0xa5, 0xcd, 0xd1, 0x80, 0x8d, 0x32, 0x2d, 0x8f, 0xb8, 0x26, 0xa6, 0x74, 0xac, 0x15,
-	0x9f, 0xa3, 0xd5, 0x12, 0xb0, 0x90, 0x96, 0x17, 0xb0, 0x51, 0x54, 0x81, 0x4c, 0xd7, 0xb2, 0xf1,
-	0x58, 0x28, 0xc1, 0x85, 0xa2, 0x3d, 0x52, 0xb7, 0x6c, 0x1c, 0x54, 0x81, 0xee, 0xd8, 0x40, 0x51,
-	0x13, 0x72, 0x7d, 0x4c, 0x1c, 0x3c, 0x89, 0x2c, 0xc9, 0x45, 0x1e, 0x46, 0x44, 0x5e, 0x73, 0x54,
-	0x7d, 0xe8, 0x98, 0xac, 0xa4, 0x55, 0xc3, 0xb6, 0xa5, 0x5a, 0x56, 0xf0, 0xa7, 0xe1, 0x39, 0xd8,
-	0xbf, 0x74, 0x49, 0x7f, 0x2c, 0xb8, 0x3a, 0x27, 0xbc, 0xa6, 0x80, 0x85, 0xc2, 0x73, 0x02, 0x36,
-	0x8a, 0xce, 0x00, 0x79, 0x98, 0x74, 0x5d, 0x32, 0x30, 0x58, 0x03, 0x4b, 0xbd, 0x35, 0xae, 0xf7,
-	0x59, 0x34, 0x5d, 0x53, 0x68, 0x50, 0xf3, 0x86, 0x37, 0x63, 0xa7, 0xa8, 0x15, 0x3c, 0x5f, 0x52,
-	0x15, 0xb8, 0xea, 0xd6, 0xfc, 0xf3, 0x15, 0xd4, 0x9c, 0x1e, 0xb2, 0x69, 0xd4, 0xe6, 0x5b, 0x83,
-	0xf4, 0xb0, 0x33, 0xd6, 0xeb, 0xcc, 0x89, 0xba, 0x2a, 0x60, 0xa1, 0xa8, 0xcd, 0x80, 0x8d, 0xa2,
-	0x97, 0x90, 0xf3, 0x2d, 0xb3, 0x3f, 0x75, 0x0d, 0x73, 0x29, 0x35, 0x22, 0x75, 0xc2, 0x51, 0x41,
-	0xa5, 0xac, 0x3f, 0x35, 0x51, 0xf5, 0xb7, 0x24, 0xa0, 0x68, 0x3f, 0xa2, 0x7d, 0x48, 0xfa, 0x23,
-	0x0f, 0xf3, 0xb1, 0x94, 0x2f, 0x7f, 0x7a, 0x65, 0x0b, 0x9f, 0x8c, 0x3c, 0xac, 0x71, 0x38, 0x7a,
-	0x05, 0x37, 0xc4, 0x28, 0xd2, 0xa7, 0x13, 0xb2, 0xd8, 0x91, 0x83, 0x20, 0x32, 0xda, 0x26, 0x10,
-	0x4d, 0x11, 0xac, 0xa9, 0x05, 0x7d, 0x0e, 0x71, 0xab, 0x23, 0x07, 0xda, 0x95, 0x33, 0x24, 0x6e,
-	0x75, 0xd0, 0x0e, 0x24, 0x0d, 0xd2, 0xdb, 0x91, 0x43, 0xeb, 0x6e, 0x04, 0x7e, 0x1a, 0xc0, 0x73,
-	0xa4, 0x64, 0x3c, 0x91, 0x43, 0x6a, 0x31, 0xe3, 0x89, 0x64, 0x94, 0x8b, 0xd9, 0x25, 0x19, 0x65,
-	0xc9, 0xd8, 0x2d, 0xe6, 0x96, 0x64, 0xec, 0x4a, 0xc6, 0x5e, 0x31, 0xbf, 0x24, 0x63, 0x4f, 0x32,
-	0xf6, 0x8b, 0x85, 0x25, 0x19, 0xfb, 0xe8, 0x0b, 0x48, 0x10, 0xec, 0xcb, 0x09, 0x7b, 0x65, 0x66,
-	0x19, 0x4e, 0xfd, 0x37, 0x0e, 0x28, 0x3a, 0x63, 0x16, 0xf6, 0x47, 0x90, 0x72, 0x2d, 0xfd, 0x51,
-	0x81, 0x1c, 0x7e, 0x87, 0x4d, 0x76, 0xf3, 0x61, 0x36, 0xa1, 0xe7, 0xd6, 0xa5, 0xed, 0x13, 0xcb,
-	0xe9, 0x89, 0x88, 0xb2, 0x8c, 0x52, 0x97, 0x0c, 0xd4, 0x82, 0x8f, 0x43, 0x12, 0xba, 0x67, 0xf8,
-	0x3e, 0x26, 0xce, 0xdc, 0x82, 0x05, 0xa5, 0x3e, 0x0a, 0x4a, 0xb5, 0x04, 0x11, 0x1d, 0x40, 0x1a,
-	0xbf, 0xb3, 0x7c, 0xdd, 0x74, 0x3b, 0x58, 0x16, 0xf1, 0xbd, 0x19, 0xde, 0x2d, 0x0b, 0x91, 0x14,
-	0x43, 0x57, 0xdd, 0x0e, 0x56, 0x7f, 0x4f, 0x40, 0x61, 0x66, 0x02, 0xa3, 0x72, 0x28, 0xc7, 0x1b,
-	0xf3, 0x27, 0xf6, 0xb5, 0x24, 0xf8, 0x00, 0x52, 0x93, 0xdc, 0xc2, 0x12, 0x09, 0x99, 0xa0, 0xd1,
-	0x4b, 0x50, 0x22, 0x29, 0xcd, 0x2c, 0xa1, 0x50, 0xe8, 0xce, 0xa4, 0xb3, 0x0a, 0x05, 0xd7, 0xc3,
-	0x8e, 0xde, 0xb5, 0x8d, 0x1e, 0xd5, 0x07, 0x06, 0xed, 0xcb, 0x2a, 0x5f, 0x99, 0xd4, 0x1c, 0xe3,
-	0xd4, 0x19, 0xe5, 0xc8, 0xa0, 0x7d, 0x54, 0x03, 0xc5, 0x24, 0xd8, 0xf0, 0xb1, 0x3e, 0x70, 0x3b,
-	0x58, 0xa8, 0xe4, 0x16, 0xab, 0xe4, 0x05, 0xe9, 0xc8, 0xed, 0x60, 0x26, 0xa3, 0xfe, 0x1d, 0x87,
-	0xe2, 0xbc, 0xdb, 0x0d, 0x3d, 0x0b, 0x55, 0xea, 0xf1, 0x12, 0xd7, 0xe2, 0x6c, 0xdd, 0x6e, 0xc1,
-	0x1a, 0x1d, 0x0d, 0xce, 0x5d, 0x9b, 0xe7, 0x3a, 0xad, 0xc9, 0x15, 0x3a, 0x83, 0xb4, 0x41, 0x7a,
-	0xc3, 0x01, 0x9f, 0xf1, 0x19, 0x3e, 0xe3, 0x0f, 0x96, 0xbe, 0x75, 0x4b, 0x95, 0x31, 0xb5, 0xe6,
-	0xf8, 0x64, 0xa4, 0x4d, 0xa5, 0x3e, 0x5c, 0x9f, 0xac, 0x7f, 0x03, 0xf9, 0xf0, 0xcf, 0xb0, 0xe7,
-	0x57, 0x1f, 0x8f, 0x78, 0x32, 0xd2, 0x1a, 0xfb, 0x64, 0xcf, 0xaf, 0x0b, 0x96, 0x55, 0x3e, 0xcf,
-	0xd3, 0x9a, 0x58, 0x7c, 0x1d, 0x3f, 0x88, 0xa9, 0xbf, 0xc6, 0x00, 0x45, 0xef, 0xf8, 0x85, 0xe3,
-	0x25, 0x48, 0xb9, 0x8e, 0xee, 0x57, 0x6d, 0xb8, 0x3d, 0xfb, 0x54, 0xa8, 0xba, 0x43, 0x87, 0xf9,
-	0xf6, 0x55, 0xc8, 0xb7, 0xad, 0x85, 0x4f, 0x8c, 0x70, 0x95, 0x4d, 0xd7, 0xe9, 0x5a, 0x3d, 0x9e,
-	0x88, 0xa4, 0x26, 0x57, 0xea, 0x7f, 0x31, 0xb8, 0xf5, 0xfe, 0x97, 0x09, 0x7a, 0x06, 0x6b, 0xa1,
-	0xc7, 0xc7, 0x83, 0x85, 0xbf, 0x27, 0xfd, 0xd4, 0x24, 0x0f, 0x35, 0x40, 0xa1, 0xc6, 0xc0, 0xb3,
-	0xb1, 0x4e, 0xd8, 0x29, 0xe0, 0xbe, 0x67, 0xb8, 0xef, 0xf7, 0xa2, 0xd7, 0x3a, 0x07, 0x6a, 0x86,
-	0x8f, 0xb9, 0xd7, 0x79, 0x1a, 0x5a, 0xa3, 0x22, 0xac, 0x79, 0x98, 0x58, 0x6e, 0x87, 0x9f, 0xc3,
-	0xe4, 0xab, 0x15, 0x4d, 0xae, 0xd1, 0x06, 0xa4, 0xbb, 0x04, 0xff, 0x3c, 0xc4, 0x8e, 0x39, 0xe2,
-	0xc7, 0x8b, 0x6d, 0x4e, 0x4d, 0xcf, 0x73, 0x90, 0x09, 0x38, 0xa1, 0xfe, 0x15, 0x83, 0x9b, 0xef,
-	0x7b, 0x34, 0xa1, 0xa7, 0xa1, 0xe4, 0xde, 0x5f, 0xf0, 0xd2, 0x0a, 0xa4, 0xf6, 0x29, 0x24, 0x2f,
-	0x2c, 0x7c, 0xc9, 0x13, 0xbb, 0x98, 0x78, 0x66, 0xe1, 0x4b, 0x8d, 0x13, 0x3e, 0x60, 0xcf, 0x3c,
-	0x06, 0x14, 0x7d, 0xb8, 0xb1, 0x9a, 0xdb, 0xd8, 0xe9, 0xf9, 0x6f, 0x79, 0x4c, 0x49, 0x4d, 0xae,
-	0xd4, 0x6d, 0xb8, 0x11, 0x79, 0x9b, 0xa1, 0x75, 0x48, 0x59, 0xac, 0x78, 0x17, 0x86, 0xcd, 0xe1,
-	0x09, 0x6d, 0xb2, 0x56, 0x7f, 0x81, 0xd4, 0xf8, 0xef, 0x0f, 0xfa, 0x16, 0x52, 0xfe, 0x5b, 0xe2,
-	0xfa, 0xbe, 0x8d, 0xe5, 0x3f, 0xc7, 0xe8, 0x19, 0x39, 0x91, 0x80, 0xe9, 0x7f, 0xa6, 0x31, 0x05,
-	0xed, 0xc1, 0xaa, 0x6d, 0x0d, 0x2c, 0x5f, 0xbe, 0xaf, 0xa2, 0x57, 0xcb, 0x21, 0xdb, 0x9d, 0x10,
-	0x05, 0x58, 0xfd, 0x33, 0x06, 0xca, 0xac, 0xe8, 0x55, 0x1e, 0xa3, 0x36, 0xe4, 0xc6, 0xdf, 0xa2,
-	0xed, 0x44, 0x71, 0x4a, 0x0b, 0x5d, 0x65, 0x83, 0x98, 0xd3, 0x78, 0x81, 0xb3, 0x56, 0x60, 0xa5,
-	0x56, 0x20, 0x1b, 0xdc, 0x45, 0x05, 0xc8, 0x1c, 0x35, 0x0e, 0x0f, 0x1b, 0xed, 0x5a, 0xf5, 0xb8,
-	0xf9, 0x42, 0x59, 0x41, 0x00, 0x6b, 0xf2, 0x3b, 0xc6, 0xbe, 0x8f, 0x1a, 0xcd, 0xd3, 0x93, 0x9a,
-	0x12, 0x47, 0x29, 0x48, 0xbe, 0x3a, 0x3e, 0xd5, 0x94, 0x84, 0xba, 0x05, 0xb9, 0x50, 0x80, 0x6c,
-	0x3e, 0x89, 0x7c, 0x88, 0x08, 0xc4, 0xe2, 0x51, 0x1f, 0xf2, 0xe1, 0xf3, 0x80, 0xee, 0x42, 0xb1,
-	0x5d, 0x39, 0x6a, 0x1d, 0xd6, 0x74, 0xad, 0x72, 0x52, 0xd3, 0x4f, 0xde, 0xb4, 0x6a, 0xfa, 0x69,
-	0xf3, 0x75, 0xf3, 0xf8, 0xa7, 0xa6, 0xb2, 0x82, 0xee, 0xc0, 0xed, 0xc8, 0x6e, 0xab, 0xa6, 0x35,
-	0x8e, 0x99, 0x27, 0x1b, 0xb0, 0x1e, 0xd9, 0xac, 0x6b, 0xb5, 0x1f, 0x4f, 0x6b, 0xcd, 0xea, 0x1b,
-	0x25, 0xfe, 0xe8, 0x21, 0xa0, 0x68, 0x8b, 0xa2, 0x34, 0xac, 0x3e, 0xaf, 0xb4, 0x1b, 0x55, 0x65,
-	0x85, 0xb9, 0x5f, 0x3f, 0x3d, 0x3c, 0x54, 0x62, 0xe7, 0x6b, 0xfc, 0xbe, 0xda, 0xfd, 0x3f, 0x00,
-	0x00, 0xff, 0xff, 0x90, 0x64, 0xb5, 0x26, 0xf2, 0x10, 0x00, 0x00,
+	// 3004 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0xcd, 0x73, 0xdb, 0xc8,
+	0xb1, 0x17, 0x48, 0x4a, 0x26, 0x9b, 0x5f, 0xd0, 0x58, 0x2b, 0xc1, 0xb2, 0xd7, 0x96, 0xe9, 0xd5,
+	0x5b, 0x59, 0x6f, 0x9f, 0xec, 0x95, 0xed, 0x67, 0x6f, 0x36, 0x1f, 0x4b, 0x93, 0xd0, 0x0a, 0x11,
+	0x45, 0xc2, 0x43, 0xd2, 0x1b, 0x57, 0x2a, 0x85, 0x82, 0x81, 0x21, 0x85, 0x22, 0x08, 0x60, 0x01,
+	0x50, 0x12, 0x73, 0xc9, 0x31, 0xa7, 0x9c, 0x52, 0xb9, 0x26, 0x97, 0xfc, 0x29, 0xa9, 0x54, 0xe5,
+	0x9a, 0xca, 0x3d, 0xe7, 0x5c, 0xf2, 0x17, 0xe4, 0x90, 0x9a, 0x01, 0x40, 0x02, 0xfc, 0x30, 0xb5,
+	0x55, 0xbb, 0x37, 0x4c, 0xf7, 0xef, 0xd7, 0x98, 0xe9, 0x9e, 0xe9, 0xe9, 0x99, 0x81, 0x8a, 0xa6,
+	0x3a, 0xde, 0xc8, 0x24, 0xaf, 0x9e, 0xa8, 0x8e, 0xf1, 0xe4, 0xf2, 0xe9, 0x13, 0x6f, 0xf4, 0xde,
+	0xd3, 0x5c, 0xc3, 0xf1, 0x0d, 0xdb, 0x3a, 0x72, 0x5c, 0xdb, 0xb7, 0x51, 0x39, 0xc2, 0x1c, 0xa9,
+	0x8e, 0x71, 0x74, 0xf9, 0x74, 0x77, 0x7f, 0x96, 0xe4, 0x13, 0x93, 0x0c, 0x89, 0xef, 0x8e, 0x15,
+	0x72, 0x49, 0x2c, 0x3f, 0xe0, 0xed, 0xee, 0xcd, 0xc2, 0xc8, 0xb5, 0xe3, 0x12, 0xcf, 0x9b, 0x58,
+	0xde, 0xbd, 0xdf, 0xb7, 0xed, 0xbe, 0x49, 0x9e, 0xb0, 0xd6, 0xfb, 0x51, 0xef, 0xc9, 0x95, 0xab,
+	0x3a, 0x0e, 0x71, 0xbd, 0x40, 0x5f, 0xf9, 0x17, 0x40, 0xa1, 0x1d, 0xeb, 0x10, 0xfa, 0x19, 0x14,
+	0xd8, 0x1f, 0x94, 0x9e, 0x61, 0xfa, 0xc4, 0x15, 0xb8, 0x3d, 0xee, 0x20, 0x7f, 0x7c, 0xef, 0x68,
+	0xa6, 0x87, 0x47, 0x22, 0x05, 0x9d, 0x30, 0x0c, 0xce, 0x93, 0x69, 0x03, 0x9d, 0x01, 0xaf, 0xd9,
+	0x96, 0xaf, 0x1a, 0x16, 0x71, 0x23, 0x23, 0x29, 0x66, 0x64, 0x6f, 0xce, 0x48, 0x2d, 0x02, 0x86,
+	0x86, 0xca, 0x5a, 0x52, 0x80, 0xaa, 0x90, 0x75, 0x5c, 0xc3, 0x76, 0x0d, 0x7f, 0x2c, 0xa4, 0xf7,
+	0xb8, 0x83, 0xd2, 0xf1, 0xfe, 0x9c, 0x91, 0x78, 0xf7, 0xe5, 0x10, 0x8c, 0x27, 0x34, 0x84, 0x20,
+	0x63, 0xaa, 0xbf, 0x1e, 0x0b, 0x99, 0x3d, 0xee, 0x20, 0x8b, 0xd9, 0x37, 0xaa, 0x42, 0xd1, 0x53,
+	0x87, 0x8e, 0x49, 0x94, 0x9e, 0x41, 0x4c, 0xdd, 0x13, 0xd6, 0xf7, 0xd2, 0x07, 0xa5, 0x05, 0xa3,
+	0x6c, 0x33, 0xd4, 0x09, 0x05, 0xe1, 0x82, 0x37, 0x6d, 0x78, 0xe8, 0x4b, 0xc8, 0xf8, 0x6a, 0xdf,
+	0x13, 0x36, 0xf6, 0xd2, 0x07, 0xf9, 0xe3, 0x4f, 0x3f, 0xd8, 0xab, 0xa3, 0x8e, 0xda, 0xf7, 0x44,
+	0xcb, 0x77, 0xc7, 0x98, 0x91, 0xd0, 0x17, 0x00, 0x8e, 0xa1, 0x47, 0xde, 0xb9, 0xc5, 0xbc, 0xb3,
+	0x3b, 0x67, 0x42, 0x36, 0xf4, 0xd0, 0x2f, 0x39, 0x27, 0xfa, 0x44, 0xa7, 0x50, 0xa6, 0x54, 0x4d,
+	0x75, 0x75, 0xc3, 0x52, 0x4d, 0xea, 0x98, 0x2c, 0xe3, 0x3f, 0x58, 0xc4, 0xaf, 0x4d, 0x61, 0xb8,
+	0xe4, 0x24, 0xda, 0x2c, 0xd2, 0x7a, 0x9f, 0x28, 0xbe, 0x6b, 0xf4, 0xfb, 0xc4, 0x15, 0x72, 0xcb,
+	0x22, 0xad, 0xf7, 0x49, 0x27, 0xc0, 0xe0, 0x3c, 0x99, 0x36, 0xd0, 0x1b, 0x40, 0xd3, 0x48, 0x33,
+	0xe7, 0x18, 0x56, 0x5f, 0x28, 0x30, 0x33, 0x95, 0xe5, 0xb1, 0x6e, 0x87, 0x48, 0xbc, 0xa9, 0xcd,
+	0x8a, 0xd0, 0x21, 0x6c, 0x1a, 0x96, 0x66, 0x8e, 0x74, 0xa2, 0x68, 0xea, 0xc8, 0x23, 0xba, 0xf2,
+	0x7e, 0x2c, 0x14, 0x59, 0xe4, 0xca, 0xa1, 0xa2, 0xc6, 0xe4, 0xaf, 0x59, 0xff, 0x55, 0x6d, 0xa0,
+	0xf8, 0x17, 0xae, 0xed, 0xfb, 0x26, 0x11, 0x4a, 0x4b, 0xfa, 0x5f, 0xd5, 0x06, 0x9d, 0x10, 0x83,
+	0xf3, 0xea, 0xb4, 0x41, 0x0d, 0x7c, 0x3b, 0x32, 0x88, 0xaf, 0x38, 0xc4, 0x35, 0x6c, 0x5d, 0x28,
+	0x2f, 0x31, 0xf0, 0x86, 0x82, 0x64, 0x86, 0xc1, 0xf9, 0x6f, 0xa7, 0x0d, 0x16, 0x46, 0xe2, 0xf6,
+	0x14, 0xcd, 0xb4, 0xb5, 0x81, 0xc0, 0xb3, 0xf9, 0xb9, 0x20, 0x8c, 0xc4, 0xed, 0xd5, 0x28, 0x02,
+	0xe7, 0x9c, 0xe8, 0x13, 0xa9, 0x20, 0x8c, 0x2c, 0x6f, 0xe4, 0x38, 0xb6, 0xeb, 0x13, 0x3d, 0x98,
+	0x86, 0x8a, 0x63, 0x9b, 0x86, 0x36, 0x16, 0x36, 0x99, 0xa1, 0xf9, 0x29, 0xd5, 0x9d, 0x12, 0xd8,
+	0x24, 0x94, 0x19, 0x1c, 0x6f, 0x8f, 0x16, 0xca, 0xe9, 0x42, 0xd4, 0x89, 0x66, 0xeb, 0x44, 0x31,
+	0x3c, 0xdb, 0x54, 0xe9, 0x44, 0x14, 0x10, 0x33, 0x3d, 0xbf, 0x10, 0xeb, 0x0c, 0x28, 0x45, 0x38,
+	0x5c, 0xd6, 0x93, 0x02, 0xd4, 0x84, 0xcd, 0x9e, 0x6a, 0x98, 0x23, 0x97, 0x50, 0x87, 0x13, 0xef,
+	0xc2, 0x36, 0x75, 0xe1, 0x36, 0x73, 0xd8, 0xc3, 0x39, 0x6b, 0x27, 0x01, 0xb2, 0x13, 0x01, 0x31,
+	0xdf, 0x9b, 0x91, 0xa0, 0xd7, 0x50, 0xf2, 0x0c, 0x4b, 0x23, 0x8a, 0x3e, 0x72, 0x83, 0xae, 0x01,
+	0x33, 0x76, 0xf7, 0x28, 0x48, 0x58, 0x47, 0x51, 0xc2, 0x3a, 0x92, 0x2c, 0xff, 0xff, 0x9f, 0xbf,
+	0x55, 0xcd, 0x11, 0xc1, 0x45, 0x46, 0xa9, 0x87, 0x0c, 0xf4, 0x53, 0x28, 0xf4, 0x6c, 0x77, 0x6a,
+	0x21, 0xbf, 0xda, 0x42, 0xbe, 0x67, 0xbb, 0x13, 0xfe, 0x0b, 0xc8, 0x0e, 0x6d, 0xdd, 0xe8, 0x19,
+	0xc4, 0x15, 0xb6, 0x18, 0xf7, 0xce, 0xdc, 0x50, 0xce, 0x43, 0x00, 0x9e, 0x40, 0x77, 0x5f, 0x42,
+	0x6e, 0xb2, 0x9e, 0x11, 0x0f, 0xe9, 0x01, 0x19, 0xb3, 0x2c, 0x99, 0xc3, 0xf4, 0x13, 0x6d, 0xc1,
+	0xfa, 0x25, 0xfd, 0x17, 0x4b, 0x7a, 0x39, 0x1c, 0x34, 0x7e, 0x94, 0x7a, 0xc5, 0x55, 0xae, 0xa0,
+	0x3c, 0x93, 0xf0, 0x28, 0xdd, 0xd0, 0x3d, 0x81, 0xdb, 0x4b, 0x53, 0xba, 0xa1, 0x7b, 0x94, 0x6e,
+	0xa9, 0x43, 0xe2, 0x09, 0x29, 0x26, 0x0b, 0x1a, 0xe8, 0x2e, 0xe4, 0x8c, 0xa1, 0xda, 0x27, 0x0a,
+	0x45, 0xa7, 0x99, 0x26, 0xcb, 0x04, 0x92, 0xee, 0xa1, 0x07, 0x90, 0x0f, 0x94, 0x01, 0x31, 0xc3,
+	0xd4, 0xc0, 0x44, 0x4d, 0x2a, 0xa9, 0x5c, 0x43, 0x6e, 0x92, 0x4b, 0x68, 0x3e, 0x74, 0xa2, 0x7f,
+	0xae, 0x63, 0xf6, 0x8d, 0x3e, 0x85, 0x72, 0xcf, 0x36, 0x4d, 0xfb, 0x4a, 0xd1, 0x2e, 0x0c, 0x53,
+	0x77, 0x89, 0xc5, 0x7a, 0x9f, 0xc5, 0xa5, 0x40, 0x5c, 0x0b, 0xa5, 0xe8, 0x08, 0x6e, 0xf7, 0x54,
+	0xd3, 0x23, 0x8a, 0x63, 0x7b, 0x86, 0x6f, 0x5c, 0x12, 0xc5, 0x55, 0x7d, 0xc2, 0x52, 0x33, 0x87,
+	0x37, 0x99, 0x4a, 0x0e, 0x35, 0x58, 0xf5, 0x49, 0xe5, 0x3d, 0x94, 0x92, 0x59, 0x08, 0x3d, 0x06,
+	0xde, 0xb0, 0x7c, 0xe2, 0x5e, 0xaa, 0xa6, 0xe2, 0x11, 0xcd, 0xb6, 0x58, 0x57, 0xb8, 0x83, 0x22,
+	0x2e, 0x47, 0xf2, 0x76, 0x20, 0x46, 0xfb, 0x50, 0xba, 0x32, 0x2c, 0xdd, 0xbe, 0x9a, 0x00, 0x53,
+	0x0c, 0x58, 0x0c, 0xa4, 0x21, 0xac, 0xf2, 0x77, 0x0e, 0x36, 0xe7, 0x92, 0x0b, 0xcd, 0xcf, 0x43,
+	0x5b, 0x27, 0x02, 0xb7, 0x64, 0x31, 0xcd, 0x31, 0x68, 0xa8, 0x09, 0x66, 0x24, 0xf4, 0x14, 0xb6,
+	0xd8, 0x96, 0xe6, 0xd1, 0xd4, 0xa0, 0x4c, 0xd2, 0x14, 0xfb, 0x7f, 0x06, 0xa3, 0x40, 0x27, 0x13,
+	0x77, 0x62, 0x64, 0xe1, 0xb0, 0xd2, 0x0b, 0x87, 0x55, 0x79, 0x04, 0x19, 0xfa, 0x2b, 0x94, 0x83,
+	0x75, 0xf1, 0x4d, 0xb7, 0xda, 0xe0, 0xd7, 0x10, 0x0f, 0x05, 0x19, 0xb7, 0xe4, 0x16, 0xee, 0x48,
+	0xad, 0x66, 0xb5, 0xc1, 0x73, 0x95, 0x01, 0xe4, 0x63, 0x79, 0x8b, 0xfa, 0xfd, 0xc2, 0xe8, 0x5f,
+	0x28, 0xa6, 0xea, 0x13, 0x4b, 0x1b, 0x2b, 0x43, 0xc3, 0x34, 0x8d, 0xc0, 0x71, 0x69, 0xbc, 0x49,
+	0x55, 0x8d, 0x40, 0x73, 0xce, 0x14, 0xe8, 0x33, 0x40, 0x34, 0x9a, 0x33, 0xf0, 0x14, 0x83, 0xf3,
+	0xa6, 0x7d, 0x95, 0x40, 0x57, 0xfe, 0xc6, 0x41, 0x3e, 0x96, 0xe4, 0xd0, 0xf1, 0x74, 0x52, 0x2f,
+	0x4a, 0x16, 0x31, 0xe8, 0xd1, 0x19, 0x19, 0x07, 0xd3, 0xfe, 0x53, 0x28, 0x7b, 0x86, 0x49, 0xe8,
+	0x92, 0x4e, 0x46, 0xab, 0x14, 0x8a, 0xa3, 0xa8, 0xde, 0x81, 0xec, 0x50, 0xbd, 0x56, 0x06, 0x64,
+	0x1c, 0x79, 0xe8, 0xd6, 0x50, 0xbd, 0x3e, 0x23, 0x63, 0x36, 0x91, 0x55, 0x93, 0xb8, 0xbe, 0xa7,
+	0xd8, 0x96, 0x19, 0xed, 0xd8, 0x10, 0x88, 0x5a, 0x96, 0x39, 0xae, 0x3c, 0x84, 0xf4, 0x19, 0x19,
+	0xa3, 0x3c, 0xdc, 0x92, 0x71, 0xab, 0x26, 0xb6, 0xdb, 0xfc, 0x1a, 0x2a, 0x42, 0xae, 0xd6, 0x6a,
+	0x76, 0xaa, 0x52, 0x53, 0xc4, 0x3c, 0x57, 0xf9, 0x13, 0x07, 0xf9, 0xd8, 0x8e, 0x85, 0xbe, 0x80,
+	0x9c, 0xe3, 0x12, 0xdd, 0xd0, 0xe8, 0x3c, 0xe5, 0xc2, 0x0c, 0x31, 0xb7, 0xc5, 0x4d, 0xca, 0x26,
+	0x3c, 0x45, 0xa3, 0x6d, 0xd8, 0x70, 0x0d, 0x8f, 0xee, 0x69, 0xc1, 0x62, 0x08, 0x5b, 0x48, 0x80,
+	0x5b, 0x3d, 0xd5, 0x64, 0x9b, 0x5d, 0x9a, 0x29, 0xa2, 0x26, 0x7a, 0x04, 0x45, 0x3a, 0x36, 0xc7,
+	0xb5, 0x35, 0xe2, 0x79, 0x6c, 0x2d, 0xd2, 0x01, 0x16, 0x86, 0xea, 0xb5, 0x1c, 0xc9, 0x2a, 0x7f,
+	0x4e, 0x01, 0x3f, 0x9b, 0x21, 0xd1, 0xcb, 0xb8, 0xcb, 0xf7, 0x57, 0x66, 0xd4, 0xa9, 0xdf, 0x3f,
+	0x06, 0x18, 0x90, 0x71, 0x54, 0xc7, 0x04, 0x49, 0x23, 0x37, 0x20, 0xe3, 0xb0, 0x4c, 0x79, 0x41,
+	0xfb, 0xca, 0xb8, 0x42, 0x7a, 0xf5, 0xe0, 0x23, 0x2c, 0xba, 0x07, 0xb9, 0x69, 0x9a, 0x0f, 0x06,
+	0x31, 0x15, 0x2c, 0x58, 0x98, 0xeb, 0x0b, 0x16, 0x66, 0x22, 0xd2, 0x1b, 0x89, 0x48, 0xdf, 0x24,
+	0x90, 0x7f, 0xb9, 0x05, 0xf9, 0x58, 0x91, 0x89, 0x7e, 0x0e, 0x25, 0x6f, 0xec, 0x69, 0xaa, 0x69,
+	0x06, 0x25, 0x70, 0x90, 0xc1, 0xf2, 0xc7, 0x8f, 0xe6, 0x4b, 0xaf, 0x00, 0x16, 0x23, 0xe3, 0xa2,
+	0x17, 0x93, 0x79, 0xd4, 0x56, 0x18, 0xa3, 0xc8, 0x56, 0x6a, 0x89, 0xad, 0x30, 0x6c, 0x09, 0x5b,
+	0x4e, 0x4c, 0xe6, 0xa1, 0x2a, 0xe4, 0x7b, 0x86, 0x49, 0x22, 0x43, 0x69, 0x66, 0x68, 0x7e, 0xd1,
+	0x9c, 0x18, 0x26, 0x89, 0x5b, 0x81, 0x5e, 0x24, 0xf0, 0x50, 0x13, 0x8a, 0x03, 0xe2, 0x5a, 0x64,
+	0x32, 0xb2, 0x0c, 0x33, 0xf2, 0x78, 0xce, 0xc8, 0x19, 0x43, 0x9d, 0x8c, 0x2c, 0x8d, 0x6e, 0x60,
+	0x35, 0xd5, 0x34, 0x43, 0x6b, 0x85, 0x80, 0x3f, 0x1d, 0x9e, 0x45, 0xfc, 0x2b, 0xdb, 0x1d, 0x44,
+	0x06, 0xd7, 0x97, 0x0c, 0xaf, 0x19, 0xc0, 0x12, 0xc3, 0xb3, 0x62, 0x32, 0x0f, 0xbd, 0x05, 0x44,
+	0xab, 0x16, 0xdb, 0x1d, 0xaa, 0x74, 0x6d, 0x87, 0xf6, 0x96, 0x55, 0xbd, 0xf2, 0x14, 0x1a, 0xb7,
+	0xb9, 0xe9, 0xcc, 0xc8, 0x3d, 0xf4, 0x35, 0x14, 0x3d, 0xa3, 0x6f, 0xa9, 0x93, 0x31, 0xdf, 0xda,
+	0x4b, 0x2f, 0xac, 0x1b, 0xdb, 0x0c, 0x15, 0xb7, 0x56, 0xf0, 0xa6, 0x22, 0x0f, 0xe9, 0x70, 0x87,
+	0xba, 0xd2, 0x64, 0xc1, 0xbc, 0x26, 0xda, 0x88, 0xba, 0x26, 0x32, 0x9a, 0x65, 0x46, 0x0f, 0x16,
+	0x46, 0x83, 0x32, 0xc4, 0x88, 0x10, 0x9a, 0xde, 0xe9, 0xcd, 0x2a, 0xc2, 0xbf, 0xc8, 0xf1, 0x53,
+	0x4d, 0x68, 0x1c, 0x98, 0xf1, 0xfd, 0xe5, 0x5b, 0x4b, 0xbc, 0xd3, 0x65, 0x2d, 0x21, 0x65, 0x41,
+	0xd2, 0x2e, 0x54, 0xb7, 0x4f, 0x26, 0x9d, 0xd5, 0x97, 0x04, 0xa9, 0x16, 0xc0, 0x12, 0x41, 0xd2,
+	0x62, 0x32, 0xe6, 0x4c, 0xdf, 0xd0, 0x06, 0xd3, 0xae, 0x91, 0x25, 0xce, 0xec, 0x30, 0x54, 0xc2,
+	0x99, 0xfe, 0x54, 0xc4, 0x86, 0xe9, 0x8d, 0x2d, 0xff, 0x82, 0xf8, 0x86, 0x16, 0xd9, 0xea, 0x2d,
+	0x19, 0x66, 0x3b, 0x02, 0x26, 0x86, 0xe9, 0x25, 0xa4, 0x5e, 0xe5, 0x97, 0xb0, 0xb3, 0xc4, 0xd9,
+	0xe8, 0x2b, 0xd8, 0xb0, 0x47, 0xbe, 0x33, 0xf2, 0xc3, 0xb4, 0x77, 0x83, 0x30, 0xb5, 0x18, 0x1e,
+	0x87, 0xbc, 0xca, 0x7f, 0xd6, 0x01, 0xcd, 0xaf, 0x76, 0xf4, 0x02, 0x32, 0xfe, 0xd8, 0x89, 0xf6,
+	0xfe, 0x87, 0x1f, 0x4c, 0x10, 0x9d, 0xb1, 0x43, 0x30, 0x83, 0xa3, 0x53, 0xd8, 0x0c, 0x4e, 0x64,
+	0xca, 0xf4, 0x18, 0x2d, 0xe8, 0xab, 0xb3, 0x26, 0x1f, 0xb0, 0xa6, 0x12, 0x9a, 0xf9, 0x54, 0xb7,
+	0xaf, 0x0c, 0x55, 0x6f, 0x20, 0x90, 0x20, 0xf3, 0xa9, 0x6e, 0xff, 0x5c, 0xf5, 0x06, 0x48, 0x82,
+	0xa2, 0xed, 0x3a, 0x17, 0xaa, 0xa5, 0xa8, 0x6c, 0x11, 0x0b, 0x3d, 0xd6, 0xc9, 0x4f, 0x96, 0x75,
+	0xb2, 0xc5, 0xc0, 0x55, 0x86, 0xc5, 0x05, 0x3b, 0xd6, 0x42, 0x18, 0x78, 0xfa, 0x17, 0xdd, 0xf0,
+	0x7c, 0xd7, 0x78, 0xcf, 0xfc, 0x23, 0xf4, 0xf7, 0xb8, 0x85, 0x0b, 0x33, 0xb4, 0x56, 0x75, 0xfb,
+	0xf5, 0x18, 0x1c, 0x97, 0xd5, 0xa4, 0x80, 0xee, 0x60, 0x9a, 0xea, 0xf8, 0xb4, 0xce, 0x77, 0x54,
+	0xff, 0xc2, 0x13, 0x2e, 0xd8, 0x0e, 0x57, 0x08, 0x85, 0x32, 0x95, 0xa1, 0xff, 0x85, 0x94, 0xa1,
+	0x0b, 0xa9, 0xd5, 0xe5, 0x76, 0xca, 0xd0, 0xd1, 0x53, 0xc8, 0xa8, 0x6e, 0xff, 0x69, 0x58, 0xdf,
+	0xdf, 0x9b, 0x83, 0x77, 0x63, 0x78, 0x86, 0x0c, 0x19, 0x9f, 0x0b, 0xf9, 0x1b, 0x32, 0x3e, 0x0f,
+	0x19, 0xc7, 0x42, 0xe1, 0x86, 0x8c, 0xe3, 0x90, 0xf1, 0x4c, 0x28, 0xde, 0x90, 0xf1, 0x2c, 0x64,
+	0x3c, 0x17, 0x4a, 0x37, 0x64, 0x3c, 0x0f, 0x19, 0x2f, 0x84, 0xf2, 0x0d, 0x19, 0x2f, 0xd0, 0xff,
+	0x41, 0xda, 0x25, 0xbe, 0xb0, 0xb5, 0xda, 0xb3, 0x14, 0x57, 0x19, 0xc1, 0xf6, 0xe2, 0xb8, 0xd2,
+	0xf3, 0x02, 0x9d, 0x1a, 0x86, 0xa5, 0x93, 0xeb, 0xb0, 0xbc, 0xa6, 0x33, 0x52, 0xa2, 0x6d, 0x74,
+	0x1b, 0xd6, 0x7d, 0xdb, 0x51, 0x06, 0x61, 0x81, 0x96, 0xf1, 0x6d, 0xe7, 0xec, 0xbb, 0x14, 0xb0,
+	0xff, 0x4c, 0x03, 0x9a, 0xdf, 0x17, 0x57, 0xae, 0xba, 0x38, 0x25, 0xb6, 0xea, 0x24, 0xe0, 0x69,
+	0xda, 0x56, 0x7a, 0xba, 0xe2, 0x59, 0xaa, 0xe3, 0x5d, 0xd8, 0xbe, 0x90, 0x5a, 0x72, 0xa3, 0x41,
+	0xf3, 0xc0, 0x89, 0xde, 0x0e, 0x61, 0xb8, 0x44, 0x12, 0x6d, 0x5a, 0x25, 0x93, 0x6b, 0xc3, 0x57,
+	0x5c, 0xe2, 0xd9, 0x23, 0x57, 0x23, 0xca, 0xc8, 0x53, 0xfb, 0x24, 0x2c, 0xd2, 0x36, 0xa9, 0x0a,
+	0x87, 0x9a, 0x2e, 0x55, 0x7c, 0x8f, 0x0b, 0xbe, 0x0a, 0xc5, 0x60, 0x10, 0xb4, 0xd6, 0x55, 0x87,
+	0x64, 0xe9, 0x4c, 0x6c, 0xfb, 0xae, 0x61, 0xf5, 0x83, 0x18, 0x16, 0x58, 0xf7, 0x43, 0x06, 0x92,
+	0xe1, 0xa3, 0x84, 0x09, 0xba, 0xfe, 0x7c, 0xe2, 0x5a, 0x42, 0xf1, 0x06, 0xa6, 0x6e, 0xc7, 0x4d,
+	0xc9, 0x01, 0x11, 0xbd, 0x82, 0x1c, 0x73, 0x07, 0x3d, 0xc9, 0x0b, 0xa5, 0xe5, 0x73, 0xea, 0xd9,
+	0x71, 0x60, 0x24, 0x4b, 0xd1, 0x35, 0x5b, 0x27, 0x95, 0xc7, 0x50, 0x4a, 0xba, 0x1a, 0xed, 0x00,
+	0xad, 0xdd, 0x94, 0xde, 0xe4, 0xb4, 0xb6, 0x31, 0x54, 0xaf, 0x4f, 0x74, 0xaf, 0xf2, 0xc7, 0x34,
+	0x94, 0x67, 0x6a, 0x1b, 0x74, 0x9c, 0x98, 0x09, 0xf7, 0x97, 0xd7, 0x42, 0x3f, 0x48, 0xf2, 0x7d,
+	0x05, 0xd9, 0x49, 0x18, 0xe0, 0x06, 0xbe, 0x9b, 0xa0, 0xd1, 0xd7, 0xc0, 0xcf, 0x79, 0x3f, 0x7f,
+	0x03, 0x0b, 0xe5, 0xde, 0x8c, 0xe7, 0x6b, 0x50, 0xb6, 0x1d, 0x62, 0x29, 0x3d, 0x53, 0xed, 0x7b,
+	0xc1, 0x36, 0x50, 0x58, 0xed, 0xff, 0x22, 0xe5, 0x9c, 0x50, 0x0a, 0xdb, 0x29, 0x44, 0xe0, 0x35,
+	0x97, 0xa8, 0x3e, 0x51, 0xe8, 0x99, 0x34, 0xb0, 0x52, 0x5c, 0x6d, 0xa5, 0x14, 0x90, 0xe8, 0x11,
+	0x93, 0x9a, 0xa9, 0xfc, 0x9e, 0x83, 0xcd, 0xb9, 0x1a, 0x0a, 0x3d, 0x4f, 0x84, 0x68, 0xef, 0x43,
+	0x55, 0xd7, 0x0f, 0x11, 0xa4, 0xca, 0x3f, 0x52, 0x20, 0x2c, 0xab, 0x66, 0xd1, 0x57, 0x89, 0xce,
+	0x7d, 0x76, 0x83, 0x32, 0x78, 0xb6, 0xa3, 0xdb, 0xb0, 0xe1, 0x8d, 0x87, 0xef, 0x6d, 0x93, 0xcd,
+	0x80, 0x1c, 0x0e, 0x5b, 0xe8, 0x2d, 0xcb, 0x8b, 0xa3, 0x21, 0x2b, 0x6c, 0xf2, 0xac, 0xb0, 0x79,
+	0x75, 0xe3, 0x2a, 0xfb, 0xa8, 0x1a, 0x51, 0x83, 0xbb, 0xdc, 0xa9, 0xa9, 0xef, 0xcf, 0x31, 0xbb,
+	0x3f, 0x86, 0x52, 0xf2, 0x37, 0xdf, 0xe9, 0x8a, 0xe9, 0x0f, 0x1c, 0xa0, 0xf9, 0x9a, 0x7e, 0x65,
+	0x6a, 0x8e, 0x53, 0x7e, 0x90, 0x70, 0x9b, 0xb0, 0x33, 0x7b, 0x34, 0xa8, 0xd9, 0x23, 0xba, 0xaf,
+	0xa0, 0x2f, 0x12, 0x7d, 0xdb, 0x5f, 0x79, 0xa4, 0x48, 0x46, 0x59, 0xb3, 0xad, 0x9e, 0xd1, 0x0f,
+	0x2f, 0x66, 0xc2, 0x56, 0xe5, 0xdf, 0x1c, 0x6c, 0x2f, 0x3e, 0x89, 0xd0, 0x9a, 0x33, 0x51, 0xbd,
+	0x1f, 0xac, 0xfc, 0x5f, 0xd8, 0x4f, 0x1c, 0xf2, 0xe8, 0x7e, 0x15, 0xbe, 0x1d, 0xb8, 0x74, 0x6d,
+	0xb2, 0xbe, 0xe7, 0x59, 0xdf, 0x1f, 0x2c, 0x79, 0x3e, 0xc0, 0xaa, 0x4f, 0x58, 0xaf, 0x4b, 0x5e,
+	0xa2, 0x8d, 0x04, 0xd8, 0x08, 0xaf, 0x9e, 0x69, 0x76, 0xc8, 0x9c, 0xae, 0xe1, 0xb0, 0x8d, 0xee,
+	0x43, 0xae, 0xe7, 0x92, 0x6f, 0x47, 0xf4, 0x92, 0x46, 0x28, 0x86, 0xca, 0xa9, 0xe8, 0x75, 0x11,
+	0xf2, 0xb1, 0x4e, 0xd0, 0x2b, 0xb0, 0xad, 0x45, 0xa7, 0x0e, 0xf4, 0x32, 0xe1, 0xdc, 0x47, 0x2b,
+	0x8e, 0x2a, 0x31, 0xd7, 0xbe, 0x84, 0xcc, 0xa5, 0x41, 0xae, 0x84, 0xd4, 0x8d, 0x88, 0x6f, 0x0d,
+	0x72, 0x85, 0x19, 0xe1, 0x7b, 0x9c, 0x33, 0x9f, 0x01, 0x9a, 0x3f, 0xf9, 0xd0, 0x98, 0x9b, 0xc4,
+	0xea, 0xfb, 0x17, 0x6c, 0x4c, 0x19, 0x1c, 0xb6, 0x2a, 0x4f, 0x60, 0x73, 0xee, 0x70, 0x83, 0x76,
+	0x21, 0x1b, 0x15, 0x2f, 0xe1, 0x5d, 0xd9, 0xa4, 0x4d, 0x97, 0xca, 0xd6, 0xa2, 0x23, 0x0c, 0xfa,
+	0x12, 0xb2, 0x3e, 0x19, 0x3a, 0xe6, 0xf4, 0xc2, 0x68, 0x3e, 0xb0, 0x9d, 0xe8, 0x39, 0x8e, 0x11,
+	0xf1, 0x84, 0x40, 0x1f, 0x30, 0x62, 0x37, 0x87, 0x41, 0x21, 0xc5, 0x9c, 0xc8, 0xe1, 0xf2, 0xe4,
+	0xda, 0x30, 0x28, 0xa4, 0xe8, 0x32, 0xd6, 0xe8, 0xe4, 0x62, 0x05, 0x4a, 0x06, 0x07, 0x8d, 0xca,
+	0x6f, 0x20, 0x1b, 0x5d, 0x3a, 0xa3, 0x9f, 0x40, 0x76, 0xf2, 0xbc, 0xc1, 0x2d, 0xb9, 0x6c, 0x8f,
+	0xee, 0x08, 0xa7, 0x37, 0xd5, 0x11, 0x05, 0x3d, 0x87, 0x75, 0xd3, 0x18, 0x1a, 0x51, 0x3d, 0x35,
+	0xbf, 0x11, 0x37, 0xa8, 0x76, 0x42, 0x0c, 0xc0, 0x95, 0xbf, 0x72, 0xc0, 0xcf, 0x1a, 0xfd, 0x90,
+	0x27, 0x51, 0x1b, 0x8a, 0xd1, 0x77, 0xb0, 0x1c, 0x82, 0x49, 0x73, 0xb4, 0xb2, 0xab, 0x47, 0x52,
+	0x48, 0x63, 0x13, 0xaf, 0x60, 0xc4, 0x5a, 0x95, 0x2a, 0x14, 0xe2, 0x5a, 0x54, 0x86, 0xfc, 0xb9,
+	0xd4, 0x68, 0x48, 0x6d, 0xb1, 0xd6, 0x6a, 0xd6, 0xf9, 0x35, 0x04, 0xb0, 0x11, 0x7e, 0x73, 0xf4,
+	0xfb, 0x5c, 0x6a, 0x76, 0x3b, 0x22, 0x9f, 0x42, 0x59, 0xc8, 0x9c, 0xb6, 0xba, 0x98, 0x4f, 0x57,
+	0xf6, 0xa1, 0x98, 0x18, 0x20, 0x75, 0x78, 0xe0, 0x8f, 0x60, 0x04, 0x41, 0xe3, 0x90, 0xde, 0x18,
+	0xc6, 0xde, 0xf9, 0x90, 0x00, 0x5b, 0xed, 0xea, 0xb9, 0xdc, 0x10, 0x95, 0x13, 0x49, 0x6c, 0xd4,
+	0x95, 0x6e, 0xf3, 0xac, 0xd9, 0xfa, 0xa6, 0xc9, 0xaf, 0xa1, 0x2d, 0xe0, 0x13, 0x9a, 0x9a, 0xdc,
+	0xe5, 0xb9, 0x39, 0x69, 0x47, 0xaa, 0xf3, 0x29, 0x74, 0x1b, 0xca, 0x09, 0xa9, 0x24, 0xf3, 0x69,
+	0xb4, 0x0b, 0xdb, 0x49, 0x03, 0xd5, 0x46, 0xa3, 0x76, 0x5a, 0x95, 0x9a, 0x7c, 0x06, 0xdd, 0x81,
+	0x8f, 0x12, 0xba, 0x7a, 0xb5, 0x53, 0x55, 0xda, 0xb8, 0xc6, 0xaf, 0x1f, 0x5e, 0xc1, 0xd6, 0xa2,
+	0x47, 0x4e, 0xb4, 0x07, 0xf7, 0xda, 0xdd, 0xd7, 0xed, 0x1a, 0x96, 0x64, 0x7a, 0x71, 0xac, 0xc8,
+	0x58, 0x6a, 0x61, 0xa9, 0xf3, 0x4e, 0x69, 0xb6, 0xf0, 0x39, 0xbb, 0x58, 0xfe, 0x18, 0xee, 0x2c,
+	0x46, 0x34, 0x5a, 0xdf, 0xf0, 0x1c, 0xba, 0x0f, 0xbb, 0x8b, 0xd5, 0xa7, 0xd2, 0xd7, 0xa7, 0x7c,
+	0xea, 0xf0, 0x77, 0x1c, 0xe4, 0x26, 0xcf, 0x57, 0x68, 0x1b, 0x90, 0x2c, 0xe2, 0x13, 0xa5, 0xd6,
+	0x68, 0xd5, 0xce, 0x94, 0xba, 0x78, 0x52, 0xed, 0x36, 0x3a, 0xfc, 0x1a, 0x75, 0x58, 0x4c, 0x7e,
+	0xde, 0x6a, 0xb6, 0x3a, 0xad, 0xa6, 0x54, 0xe3, 0x39, 0xb4, 0x03, 0xb7, 0x63, 0x9a, 0xd7, 0xad,
+	0x56, 0xa7, 0x23, 0x9d, 0xd3, 0x20, 0x25, 0x15, 0x58, 0xac, 0x36, 0x98, 0x22, 0x8d, 0xee, 0x81,
+	0xb0, 0xc8, 0x96, 0x82, 0xab, 0xdf, 0xf0, 0x99, 0xc3, 0x73, 0x28, 0xcf, 0xbc, 0x54, 0xa1, 0xbb,
+	0xb0, 0x53, 0x17, 0x6b, 0xad, 0xba, 0xa8, 0x48, 0xed, 0x56, 0xa3, 0xca, 0x86, 0xd1, 0x3e, 0xad,
+	0x62, 0xb1, 0x1e, 0x0c, 0x7f, 0x4e, 0x19, 0x7c, 0x89, 0x75, 0x9e, 0x3b, 0xd4, 0x60, 0x7b, 0xf1,
+	0x9b, 0x1a, 0x7a, 0x04, 0x0f, 0xba, 0xcd, 0x76, 0x57, 0xa6, 0x57, 0xf2, 0x62, 0x3d, 0x8c, 0x88,
+	0xdc, 0x6a, 0x48, 0xb5, 0x77, 0x4a, 0xbb, 0x83, 0xa5, 0x1a, 0x1d, 0xf7, 0x27, 0xb0, 0xb7, 0x14,
+	0xd4, 0x10, 0x9b, 0x92, 0xd8, 0xec, 0xf0, 0xdc, 0xe1, 0x6f, 0x39, 0xd8, 0x59, 0x72, 0x8f, 0x81,
+	0xf6, 0xe1, 0xe1, 0x89, 0xd4, 0x10, 0x1b, 0x62, 0xbb, 0xad, 0x88, 0xbf, 0x10, 0x6b, 0x5d, 0xd6,
+	0xc3, 0x56, 0xb7, 0x23, 0x77, 0x3b, 0x4a, 0x5d, 0xc4, 0xd2, 0x5b, 0x36, 0x8c, 0x87, 0xf0, 0xf1,
+	0x72, 0x18, 0xf5, 0x0c, 0x87, 0x2a, 0x70, 0x7f, 0x39, 0xe4, 0x75, 0xab, 0x43, 0xa3, 0xf9, 0x2b,
+	0xb8, 0xbd, 0xe0, 0x52, 0x81, 0x4d, 0x82, 0x77, 0x6d, 0x3a, 0x15, 0x95, 0x16, 0x96, 0x4f, 0xab,
+	0x4d, 0xa5, 0x5a, 0x63, 0xec, 0x3a, 0x6e, 0xc9, 0xfc, 0x1a, 0xfa, 0x1f, 0xa8, 0x2c, 0xd6, 0x8b,
+	0xe7, 0x52, 0x47, 0x91, 0xab, 0xb8, 0x23, 0xd1, 0x27, 0x8b, 0xc3, 0x01, 0x94, 0x92, 0xfb, 0x1d,
+	0x0d, 0x66, 0x38, 0xa5, 0x71, 0xb5, 0x23, 0x2a, 0x9d, 0x77, 0xb2, 0x18, 0x5b, 0x4d, 0x77, 0x61,
+	0x67, 0x4e, 0x2b, 0x8b, 0x58, 0x6a, 0xd5, 0xc3, 0x99, 0x39, 0xab, 0x3c, 0xc1, 0xe2, 0x9b, 0xae,
+	0xd8, 0xac, 0xbd, 0xe3, 0x53, 0x87, 0x8f, 0x01, 0xcd, 0x6f, 0x41, 0xf4, 0x49, 0xe5, 0x75, 0xb5,
+	0x2d, 0xd5, 0xf8, 0x35, 0x9a, 0x06, 0x4e, 0xba, 0x8d, 0x06, 0xcf, 0xbd, 0xdf, 0x60, 0x55, 0xf2,
+	0xb3, 0xff, 0x0e, 0x00, 0xf4, 0xc7, 0xd9, 0x0f, 0x89, 0x21, 0x00, 0x00,
 }
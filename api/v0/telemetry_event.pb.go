@@ -6,12 +6,41 @@ package capsule8_api_v0
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import google_protobuf "github.com/golang/protobuf/ptypes/any"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
+// Possible changes of the value of a subscription's EdgeTrigger predicate
+type EdgeTransition int32
+
+const (
+	// The event is not an edge
+	EdgeTransition_EDGE_TRANSITION_NONE EdgeTransition = 0
+	// The predicate became true
+	EdgeTransition_EDGE_TRANSITION_RISING EdgeTransition = 1
+	// The predicate became false
+	EdgeTransition_EDGE_TRANSITION_FALLING EdgeTransition = 2
+)
+
+var EdgeTransition_name = map[int32]string{
+	0: "EDGE_TRANSITION_NONE",
+	1: "EDGE_TRANSITION_RISING",
+	2: "EDGE_TRANSITION_FALLING",
+}
+var EdgeTransition_value = map[string]int32{
+	"EDGE_TRANSITION_NONE":    0,
+	"EDGE_TRANSITION_RISING":  1,
+	"EDGE_TRANSITION_FALLING": 2,
+}
+
+func (x EdgeTransition) String() string {
+	return proto.EnumName(EdgeTransition_name, int32(x))
+}
+func (EdgeTransition) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{0} }
+
 type ContainerEventType int32
 
 const (
@@ -43,7 +72,7 @@ var ContainerEventType_value = map[string]int32{
 func (x ContainerEventType) String() string {
 	return proto.EnumName(ContainerEventType_name, int32(x))
 }
-func (ContainerEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{0} }
+func (ContainerEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
 
 // Possible ProcessEvent types
 type ProcessEventType int32
@@ -79,7 +108,7 @@ var ProcessEventType_value = map[string]int32{
 func (x ProcessEventType) String() string {
 	return proto.EnumName(ProcessEventType_name, int32(x))
 }
-func (ProcessEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
+func (ProcessEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
 
 // Possible SyscallEvent types
 type SyscallEventType int32
@@ -91,23 +120,71 @@ const (
 	SyscallEventType_SYSCALL_EVENT_TYPE_ENTER SyscallEventType = 1
 	// The event is a syscall exit event
 	SyscallEventType_SYSCALL_EVENT_TYPE_EXIT SyscallEventType = 2
+	// The event is a completed syscall, combining the information
+	// from both the enter and exit events
+	SyscallEventType_SYSCALL_EVENT_TYPE_COMPLETE SyscallEventType = 3
+	// The event is a periodic summary of the values passed to a system
+	// call argument, rather than a single system call
+	SyscallEventType_SYSCALL_EVENT_TYPE_ARG_DISTRIBUTION SyscallEventType = 4
+	// The event links the task that made a clone, fork, or vfork system
+	// call to the task that it created, and is reported when the system
+	// call returns in the calling task
+	SyscallEventType_SYSCALL_EVENT_TYPE_CLONE_LINKAGE SyscallEventType = 5
 )
 
 var SyscallEventType_name = map[int32]string{
 	0: "SYSCALL_EVENT_TYPE_UNKNOWN",
 	1: "SYSCALL_EVENT_TYPE_ENTER",
 	2: "SYSCALL_EVENT_TYPE_EXIT",
+	3: "SYSCALL_EVENT_TYPE_COMPLETE",
+	4: "SYSCALL_EVENT_TYPE_ARG_DISTRIBUTION",
+	5: "SYSCALL_EVENT_TYPE_CLONE_LINKAGE",
 }
 var SyscallEventType_value = map[string]int32{
-	"SYSCALL_EVENT_TYPE_UNKNOWN": 0,
-	"SYSCALL_EVENT_TYPE_ENTER":   1,
-	"SYSCALL_EVENT_TYPE_EXIT":    2,
+	"SYSCALL_EVENT_TYPE_UNKNOWN":          0,
+	"SYSCALL_EVENT_TYPE_ENTER":            1,
+	"SYSCALL_EVENT_TYPE_EXIT":             2,
+	"SYSCALL_EVENT_TYPE_COMPLETE":         3,
+	"SYSCALL_EVENT_TYPE_ARG_DISTRIBUTION": 4,
+	"SYSCALL_EVENT_TYPE_CLONE_LINKAGE":    5,
 }
 
 func (x SyscallEventType) String() string {
 	return proto.EnumName(SyscallEventType_name, int32(x))
 }
-func (SyscallEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
+func (SyscallEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
+
+// SyscallArgStatus describes whether the value of a system call argument was
+// captured.
+type SyscallArgStatus int32
+
+const (
+	// The argument was not requested by the subscription, or was
+	// removed by the Sensor's syscall argument policy, and its value
+	// is zero
+	SyscallArgStatus_SYSCALL_ARG_STATUS_NOT_REQUESTED SyscallArgStatus = 0
+	// The argument's value was captured
+	SyscallArgStatus_SYSCALL_ARG_STATUS_CAPTURED SyscallArgStatus = 1
+	// The kernel could not read the argument's value from the memory of
+	// the calling process, and its value is empty
+	SyscallArgStatus_SYSCALL_ARG_STATUS_FAULTED SyscallArgStatus = 2
+)
+
+var SyscallArgStatus_name = map[int32]string{
+	0: "SYSCALL_ARG_STATUS_NOT_REQUESTED",
+	1: "SYSCALL_ARG_STATUS_CAPTURED",
+	2: "SYSCALL_ARG_STATUS_FAULTED",
+}
+var SyscallArgStatus_value = map[string]int32{
+	"SYSCALL_ARG_STATUS_NOT_REQUESTED": 0,
+	"SYSCALL_ARG_STATUS_CAPTURED":      1,
+	"SYSCALL_ARG_STATUS_FAULTED":       2,
+}
+
+func (x SyscallArgStatus) String() string {
+	return proto.EnumName(SyscallArgStatus_name, int32(x))
+}
+func (SyscallArgStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
 
 // Possible FileEvent types
 type FileEventType int32
@@ -131,7 +208,39 @@ var FileEventType_value = map[string]int32{
 func (x FileEventType) String() string {
 	return proto.EnumName(FileEventType_name, int32(x))
 }
-func (FileEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
+func (FileEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
+
+// Possible SignalEvent types
+type SignalEventType int32
+
+const (
+	// The type of event is unknown
+	SignalEventType_SIGNAL_EVENT_TYPE_UNKNOWN SignalEventType = 0
+	// The event is a signal being sent (the signal/signal_generate
+	// tracepoint). The process associated with the event is the
+	// sender.
+	SignalEventType_SIGNAL_EVENT_TYPE_GENERATE SignalEventType = 1
+	// The event is a signal being delivered to its target (the
+	// signal/signal_deliver tracepoint). The process associated with
+	// the event is the target.
+	SignalEventType_SIGNAL_EVENT_TYPE_DELIVER SignalEventType = 2
+)
+
+var SignalEventType_name = map[int32]string{
+	0: "SIGNAL_EVENT_TYPE_UNKNOWN",
+	1: "SIGNAL_EVENT_TYPE_GENERATE",
+	2: "SIGNAL_EVENT_TYPE_DELIVER",
+}
+var SignalEventType_value = map[string]int32{
+	"SIGNAL_EVENT_TYPE_UNKNOWN":  0,
+	"SIGNAL_EVENT_TYPE_GENERATE": 1,
+	"SIGNAL_EVENT_TYPE_DELIVER":  2,
+}
+
+func (x SignalEventType) String() string {
+	return proto.EnumName(SignalEventType_name, int32(x))
+}
+func (SignalEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
 
 // Possible KernelFunctionCallEvent types
 type KernelFunctionCallEventType int32
@@ -159,7 +268,7 @@ var KernelFunctionCallEventType_value = map[string]int32{
 func (x KernelFunctionCallEventType) String() string {
 	return proto.EnumName(KernelFunctionCallEventType_name, int32(x))
 }
-func (KernelFunctionCallEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
+func (KernelFunctionCallEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{7} }
 
 // Possible network event types
 type NetworkEventType int32
@@ -230,7 +339,7 @@ var NetworkEventType_value = map[string]int32{
 func (x NetworkEventType) String() string {
 	return proto.EnumName(NetworkEventType_name, int32(x))
 }
-func (NetworkEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
+func (NetworkEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{8} }
 
 // Possible performance event types
 type PerformanceEventType int32
@@ -262,7 +371,7 @@ var PerformanceEventType_value = map[string]int32{
 func (x PerformanceEventType) String() string {
 	return proto.EnumName(PerformanceEventType_name, int32(x))
 }
-func (PerformanceEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
+func (PerformanceEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{9} }
 
 // Possible field types
 type KernelFunctionCallEvent_FieldType int32
@@ -323,7 +432,7 @@ func (x KernelFunctionCallEvent_FieldType) String() string {
 	return proto.EnumName(KernelFunctionCallEvent_FieldType_name, int32(x))
 }
 func (KernelFunctionCallEvent_FieldType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor1, []int{8, 0}
+	return fileDescriptor1, []int{20, 0}
 }
 
 // An event observed by the Sensor.
@@ -355,6 +464,13 @@ type TelemetryEvent struct {
 	// hierarchy, starting with the current process, up to the root of the
 	// process namespace.
 	ProcessLineage []*Process `protobuf:"bytes,8,rep,name=process_lineage,json=processLineage" json:"process_lineage,omitempty"`
+	// Present if the event was derived from other events and the
+	// Subscription requested include_caused_by. The
+	// sensor_sequence_number of each contributing event, in the order
+	// that they occurred. A complete syscall event lists its enter and
+	// exit events; an EdgeTrigger transition lists the event at which the
+	// previous value of the predicate was established.
+	CausedBy []uint64 `protobuf:"varint,9,rep,packed,name=caused_by,json=causedBy" json:"caused_by,omitempty"`
 	// Name of container associated with the event
 	ContainerName string `protobuf:"bytes,30,opt,name=container_name,json=containerName" json:"container_name,omitempty"`
 	// Unique identifier of the container image
@@ -371,7 +487,12 @@ type TelemetryEvent struct {
 	//	*TelemetryEvent_KernelCall
 	//	*TelemetryEvent_Network
 	//	*TelemetryEvent_Performance
+	//	*TelemetryEvent_Signal
 	//	*TelemetryEvent_Container
+	//	*TelemetryEvent_SubscriptionStats
+	//	*TelemetryEvent_DecodeError
+	//	*TelemetryEvent_QuietPeriod
+	//	*TelemetryEvent_FilelessExecution
 	//	*TelemetryEvent_Chargen
 	//	*TelemetryEvent_Ticker
 	Event isTelemetryEvent_Event `protobuf_oneof:"event"`
@@ -380,8 +501,66 @@ type TelemetryEvent struct {
 	// Credentials for the process associated with the event
 	Credentials *Credentials `protobuf:"bytes,202,opt,name=credentials" json:"credentials,omitempty"`
 	// Kernel's TGID of the task associated with the event. This
-	// corresponds the userland's PID.
+	// corresponds the userland's PID. Filters may refer to it as
+	// process_tgid.
 	ProcessTgid int32 `protobuf:"varint,203,opt,name=process_tgid,json=processTgid" json:"process_tgid,omitempty"`
+	// Mount namespace of the task associated with the event, identified
+	// by the inode number of /proc/[pid]/ns/mnt. It is only resolved
+	// while some subscription's filter refers to it as mnt_ns. Zero if
+	// it could not be determined (e.g. the process exited before it was
+	// resolved).
+	MntNs uint64 `protobuf:"varint,204,opt,name=mnt_ns,json=mntNs" json:"mnt_ns,omitempty"`
+	// The instruction pointer when the event occurred. Only present if
+	// the subscription selected SAMPLE_FIELD_IP.
+	SampleIp uint64 `protobuf:"varint,205,opt,name=sample_ip,json=sampleIp" json:"sample_ip,omitempty"`
+	// The call chain when the event occurred, as reported by the kernel
+	// including its context markers. Only present if the subscription
+	// selected SAMPLE_FIELD_CALLCHAIN.
+	Callchain []uint64 `protobuf:"varint,206,rep,packed,name=callchain" json:"callchain,omitempty"`
+	// Number of threads in the process associated with the event and
+	// its resident set size in kilobytes, as recently read from
+	// /proc/[pid]/status. They are only read while some subscription's
+	// filter refers to them as num_threads or rss_kb. Zero if they
+	// could not be determined (e.g. the process exited before they
+	// were read).
+	NumThreads uint32 `protobuf:"varint,207,opt,name=num_threads,json=numThreads" json:"num_threads,omitempty"`
+	RssKb      uint64 `protobuf:"varint,208,opt,name=rss_kb,json=rssKb" json:"rss_kb,omitempty"`
+	// Whether the process associated with the event is a kernel thread.
+	// It is only determined while some subscription's filter refers to
+	// it as is_kernel_thread, which is NULL if it cannot be determined.
+	IsKernelThread bool `protobuf:"varint,209,opt,name=is_kernel_thread,json=isKernelThread" json:"is_kernel_thread,omitempty"`
+	// The data source of the memory access that caused the event, as
+	// reported by the kernel, and its decoded form. Only present if
+	// the subscription selected SAMPLE_FIELD_DATA_SRC and the kernel
+	// reported a data source for the event.
+	DataSrc      uint64        `protobuf:"varint,210,opt,name=data_src,json=dataSrc" json:"data_src,omitempty"`
+	MemoryAccess *MemoryAccess `protobuf:"bytes,211,opt,name=memory_access,json=memoryAccess" json:"memory_access,omitempty"`
+	// Present when the subscription has an EdgeTrigger and the event
+	// changed the value of its predicate for the event's process.
+	EdgeTransition EdgeTransition `protobuf:"varint,212,opt,name=edge_transition,json=edgeTransition,enum=capsule8.api.v0.EdgeTransition" json:"edge_transition,omitempty"`
+	// The executable path and command (comm) of the parent of the
+	// process associated with the event, from the Sensor's process
+	// tree. The parent is the process that created it, even if that
+	// process has since exited. Empty if they could not be determined.
+	// They are only determined while some subscription's filter refers
+	// to them as parent_exe or parent_comm.
+	ParentExe  string `protobuf:"bytes,213,opt,name=parent_exe,json=parentExe" json:"parent_exe,omitempty"`
+	ParentComm string `protobuf:"bytes,214,opt,name=parent_comm,json=parentComm" json:"parent_comm,omitempty"`
+	// The time of the event in nanoseconds by the clock selected by
+	// the subscription's perf_clock. Events sampled from the
+	// subscription's own kernel events are timestamped by the kernel;
+	// the times of other events are converted from
+	// sensor_monotime_nanos. Zero if the subscription uses the
+	// default clock.
+	PerfClockNanos int64 `protobuf:"varint,215,opt,name=perf_clock_nanos,json=perfClockNanos" json:"perf_clock_nanos,omitempty"`
+	// True if the event was generated by a SyntheticEventFilter rather
+	// than observed. Synthetic events are counted separately from the
+	// events that the Sensor observes.
+	Synthetic bool `protobuf:"varint,216,opt,name=synthetic" json:"synthetic,omitempty"`
+	// Present when the subscription has a FailureThreshold. The number
+	// of consecutive failures of the event's key, which reached the
+	// threshold at this event.
+	FailureCount uint32 `protobuf:"varint,217,opt,name=failure_count,json=failureCount" json:"failure_count,omitempty"`
 }
 
 func (m *TelemetryEvent) Reset()                    { *m = TelemetryEvent{} }
@@ -411,9 +590,24 @@ type TelemetryEvent_Network struct {
 type TelemetryEvent_Performance struct {
 	Performance *PerformanceEvent `protobuf:"bytes,15,opt,name=performance,oneof"`
 }
+type TelemetryEvent_Signal struct {
+	Signal *SignalEvent `protobuf:"bytes,16,opt,name=signal,oneof"`
+}
 type TelemetryEvent_Container struct {
 	Container *ContainerEvent `protobuf:"bytes,20,opt,name=container,oneof"`
 }
+type TelemetryEvent_SubscriptionStats struct {
+	SubscriptionStats *SubscriptionStatsEvent `protobuf:"bytes,40,opt,name=subscription_stats,json=subscriptionStats,oneof"`
+}
+type TelemetryEvent_DecodeError struct {
+	DecodeError *DecodeErrorEvent `protobuf:"bytes,41,opt,name=decode_error,json=decodeError,oneof"`
+}
+type TelemetryEvent_QuietPeriod struct {
+	QuietPeriod *QuietPeriodEvent `protobuf:"bytes,42,opt,name=quiet_period,json=quietPeriod,oneof"`
+}
+type TelemetryEvent_FilelessExecution struct {
+	FilelessExecution *FilelessExecutionEvent `protobuf:"bytes,43,opt,name=fileless_execution,json=filelessExecution,oneof"`
+}
 type TelemetryEvent_Chargen struct {
 	Chargen *ChargenEvent `protobuf:"bytes,100,opt,name=chargen,oneof"`
 }
@@ -421,15 +615,20 @@ type TelemetryEvent_Ticker struct {
 	Ticker *TickerEvent `protobuf:"bytes,101,opt,name=ticker,oneof"`
 }
 
-func (*TelemetryEvent_Syscall) isTelemetryEvent_Event()     {}
-func (*TelemetryEvent_Process) isTelemetryEvent_Event()     {}
-func (*TelemetryEvent_File) isTelemetryEvent_Event()        {}
-func (*TelemetryEvent_KernelCall) isTelemetryEvent_Event()  {}
-func (*TelemetryEvent_Network) isTelemetryEvent_Event()     {}
-func (*TelemetryEvent_Performance) isTelemetryEvent_Event() {}
-func (*TelemetryEvent_Container) isTelemetryEvent_Event()   {}
-func (*TelemetryEvent_Chargen) isTelemetryEvent_Event()     {}
-func (*TelemetryEvent_Ticker) isTelemetryEvent_Event()      {}
+func (*TelemetryEvent_Syscall) isTelemetryEvent_Event()           {}
+func (*TelemetryEvent_Process) isTelemetryEvent_Event()           {}
+func (*TelemetryEvent_File) isTelemetryEvent_Event()              {}
+func (*TelemetryEvent_KernelCall) isTelemetryEvent_Event()        {}
+func (*TelemetryEvent_Network) isTelemetryEvent_Event()           {}
+func (*TelemetryEvent_Performance) isTelemetryEvent_Event()       {}
+func (*TelemetryEvent_Signal) isTelemetryEvent_Event()            {}
+func (*TelemetryEvent_Container) isTelemetryEvent_Event()         {}
+func (*TelemetryEvent_SubscriptionStats) isTelemetryEvent_Event() {}
+func (*TelemetryEvent_DecodeError) isTelemetryEvent_Event()       {}
+func (*TelemetryEvent_QuietPeriod) isTelemetryEvent_Event()       {}
+func (*TelemetryEvent_FilelessExecution) isTelemetryEvent_Event() {}
+func (*TelemetryEvent_Chargen) isTelemetryEvent_Event()           {}
+func (*TelemetryEvent_Ticker) isTelemetryEvent_Event()            {}
 
 func (m *TelemetryEvent) GetEvent() isTelemetryEvent_Event {
 	if m != nil {
@@ -494,6 +693,13 @@ func (m *TelemetryEvent) GetProcessLineage() []*Process {
 	return nil
 }
 
+func (m *TelemetryEvent) GetCausedBy() []uint64 {
+	if m != nil {
+		return m.CausedBy
+	}
+	return nil
+}
+
 func (m *TelemetryEvent) GetContainerName() string {
 	if m != nil {
 		return m.ContainerName
@@ -557,6 +763,13 @@ func (m *TelemetryEvent) GetPerformance() *PerformanceEvent {
 	return nil
 }
 
+func (m *TelemetryEvent) GetSignal() *SignalEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_Signal); ok {
+		return x.Signal
+	}
+	return nil
+}
+
 func (m *TelemetryEvent) GetContainer() *ContainerEvent {
 	if x, ok := m.GetEvent().(*TelemetryEvent_Container); ok {
 		return x.Container
@@ -564,6 +777,34 @@ func (m *TelemetryEvent) GetContainer() *ContainerEvent {
 	return nil
 }
 
+func (m *TelemetryEvent) GetSubscriptionStats() *SubscriptionStatsEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_SubscriptionStats); ok {
+		return x.SubscriptionStats
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetDecodeError() *DecodeErrorEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_DecodeError); ok {
+		return x.DecodeError
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetQuietPeriod() *QuietPeriodEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_QuietPeriod); ok {
+		return x.QuietPeriod
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetFilelessExecution() *FilelessExecutionEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_FilelessExecution); ok {
+		return x.FilelessExecution
+	}
+	return nil
+}
+
 func (m *TelemetryEvent) GetChargen() *ChargenEvent {
 	if x, ok := m.GetEvent().(*TelemetryEvent_Chargen); ok {
 		return x.Chargen
@@ -599,6 +840,104 @@ func (m *TelemetryEvent) GetProcessTgid() int32 {
 	return 0
 }
 
+func (m *TelemetryEvent) GetMntNs() uint64 {
+	if m != nil {
+		return m.MntNs
+	}
+	return 0
+}
+
+func (m *TelemetryEvent) GetSampleIp() uint64 {
+	if m != nil {
+		return m.SampleIp
+	}
+	return 0
+}
+
+func (m *TelemetryEvent) GetCallchain() []uint64 {
+	if m != nil {
+		return m.Callchain
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetNumThreads() uint32 {
+	if m != nil {
+		return m.NumThreads
+	}
+	return 0
+}
+
+func (m *TelemetryEvent) GetRssKb() uint64 {
+	if m != nil {
+		return m.RssKb
+	}
+	return 0
+}
+
+func (m *TelemetryEvent) GetIsKernelThread() bool {
+	if m != nil {
+		return m.IsKernelThread
+	}
+	return false
+}
+
+func (m *TelemetryEvent) GetDataSrc() uint64 {
+	if m != nil {
+		return m.DataSrc
+	}
+	return 0
+}
+
+func (m *TelemetryEvent) GetMemoryAccess() *MemoryAccess {
+	if m != nil {
+		return m.MemoryAccess
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetEdgeTransition() EdgeTransition {
+	if m != nil {
+		return m.EdgeTransition
+	}
+	return EdgeTransition_EDGE_TRANSITION_NONE
+}
+
+func (m *TelemetryEvent) GetParentExe() string {
+	if m != nil {
+		return m.ParentExe
+	}
+	return ""
+}
+
+func (m *TelemetryEvent) GetParentComm() string {
+	if m != nil {
+		return m.ParentComm
+	}
+	return ""
+}
+
+func (m *TelemetryEvent) GetPerfClockNanos() int64 {
+	if m != nil {
+		return m.PerfClockNanos
+	}
+	return 0
+}
+
+func (m *TelemetryEvent) GetSynthetic() bool {
+	if m != nil {
+		return m.Synthetic
+	}
+	return false
+}
+
+func (m *TelemetryEvent) GetFailureCount() uint32 {
+	if m != nil {
+		return m.FailureCount
+	}
+	return 0
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*TelemetryEvent) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _TelemetryEvent_OneofMarshaler, _TelemetryEvent_OneofUnmarshaler, _TelemetryEvent_OneofSizer, []interface{}{
@@ -608,7 +947,12 @@ func (*TelemetryEvent) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer
 		(*TelemetryEvent_KernelCall)(nil),
 		(*TelemetryEvent_Network)(nil),
 		(*TelemetryEvent_Performance)(nil),
+		(*TelemetryEvent_Signal)(nil),
 		(*TelemetryEvent_Container)(nil),
+		(*TelemetryEvent_SubscriptionStats)(nil),
+		(*TelemetryEvent_DecodeError)(nil),
+		(*TelemetryEvent_QuietPeriod)(nil),
+		(*TelemetryEvent_FilelessExecution)(nil),
 		(*TelemetryEvent_Chargen)(nil),
 		(*TelemetryEvent_Ticker)(nil),
 	}
@@ -648,11 +992,36 @@ func _TelemetryEvent_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Performance); err != nil {
 			return err
 		}
+	case *TelemetryEvent_Signal:
+		b.EncodeVarint(16<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Signal); err != nil {
+			return err
+		}
 	case *TelemetryEvent_Container:
 		b.EncodeVarint(20<<3 | proto.WireBytes)
 		if err := b.EncodeMessage(x.Container); err != nil {
 			return err
 		}
+	case *TelemetryEvent_SubscriptionStats:
+		b.EncodeVarint(40<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SubscriptionStats); err != nil {
+			return err
+		}
+	case *TelemetryEvent_DecodeError:
+		b.EncodeVarint(41<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.DecodeError); err != nil {
+			return err
+		}
+	case *TelemetryEvent_QuietPeriod:
+		b.EncodeVarint(42<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.QuietPeriod); err != nil {
+			return err
+		}
+	case *TelemetryEvent_FilelessExecution:
+		b.EncodeVarint(43<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.FilelessExecution); err != nil {
+			return err
+		}
 	case *TelemetryEvent_Chargen:
 		b.EncodeVarint(100<<3 | proto.WireBytes)
 		if err := b.EncodeMessage(x.Chargen); err != nil {
@@ -721,6 +1090,14 @@ func _TelemetryEvent_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto
 		err := b.DecodeMessage(msg)
 		m.Event = &TelemetryEvent_Performance{msg}
 		return true, err
+	case 16: // event.signal
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SignalEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_Signal{msg}
+		return true, err
 	case 20: // event.container
 		if wire != proto.WireBytes {
 			return true, proto.ErrInternalBadWireType
@@ -729,6 +1106,38 @@ func _TelemetryEvent_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto
 		err := b.DecodeMessage(msg)
 		m.Event = &TelemetryEvent_Container{msg}
 		return true, err
+	case 40: // event.subscription_stats
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SubscriptionStatsEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_SubscriptionStats{msg}
+		return true, err
+	case 41: // event.decode_error
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(DecodeErrorEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_DecodeError{msg}
+		return true, err
+	case 42: // event.quiet_period
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(QuietPeriodEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_QuietPeriod{msg}
+		return true, err
+	case 43: // event.fileless_execution
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(FilelessExecutionEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_FilelessExecution{msg}
+		return true, err
 	case 100: // event.chargen
 		if wire != proto.WireBytes {
 			return true, proto.ErrInternalBadWireType
@@ -784,11 +1193,36 @@ func _TelemetryEvent_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(15<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *TelemetryEvent_Signal:
+		s := proto.Size(x.Signal)
+		n += proto.SizeVarint(16<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case *TelemetryEvent_Container:
 		s := proto.Size(x.Container)
 		n += proto.SizeVarint(20<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *TelemetryEvent_SubscriptionStats:
+		s := proto.Size(x.SubscriptionStats)
+		n += proto.SizeVarint(40<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_DecodeError:
+		s := proto.Size(x.DecodeError)
+		n += proto.SizeVarint(41<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_QuietPeriod:
+		s := proto.Size(x.QuietPeriod)
+		n += proto.SizeVarint(42<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_FilelessExecution:
+		s := proto.Size(x.FilelessExecution)
+		n += proto.SizeVarint(43<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case *TelemetryEvent_Chargen:
 		s := proto.Size(x.Chargen)
 		n += proto.SizeVarint(100<<3 | proto.WireBytes)
@@ -806,379 +1240,1355 @@ func _TelemetryEvent_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
-type ChargenEvent struct {
-	// Index of the first character in this Event in relation to all of
-	// the characters that have been generated in this stream.
-	Index uint64 `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
-	// The next one or more characters in the autogenerated stream
-	Characters string `protobuf:"bytes,2,opt,name=characters" json:"characters,omitempty"`
+// MemoryAccess describes the data source of a memory access, decoded from
+// the kernel's perf_mem_data_src. Fields that the kernel did not report are
+// empty.
+type MemoryAccess struct {
+	// The type of access: "load", "store", "prefetch", or "exec"
+	Op string `protobuf:"bytes,1,opt,name=op" json:"op,omitempty"`
+	// The memory hierarchy level accessed, e.g. "L1", "LFB", "L2",
+	// "L3", "local RAM", "remote RAM (1 hop)", "remote cache (2
+	// hops)", "I/O", or "uncached"
+	Level string `protobuf:"bytes,2,opt,name=level" json:"level,omitempty"`
+	// Whether the access hit or missed at that level: "hit" or "miss"
+	Result string `protobuf:"bytes,3,opt,name=result" json:"result,omitempty"`
+	// The result of snooping other caches: "none", "hit", "miss", or
+	// "hit modified"
+	Snoop string `protobuf:"bytes,4,opt,name=snoop" json:"snoop,omitempty"`
+	// The TLB access, e.g. "L1 hit" or "L2 miss", and whether it was
+	// handled by the hardware walker ("walker") or OS fault handler
+	// ("OS")
+	Tlb string `protobuf:"bytes,5,opt,name=tlb" json:"tlb,omitempty"`
+	// Whether the access was part of a locked transaction
+	Locked bool `protobuf:"varint,6,opt,name=locked" json:"locked,omitempty"`
+}
+
+func (m *MemoryAccess) Reset()                    { *m = MemoryAccess{} }
+func (m *MemoryAccess) String() string            { return proto.CompactTextString(m) }
+func (*MemoryAccess) ProtoMessage()               {}
+func (*MemoryAccess) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
+
+func (m *MemoryAccess) GetOp() string {
+	if m != nil {
+		return m.Op
+	}
+	return ""
 }
 
-func (m *ChargenEvent) Reset()                    { *m = ChargenEvent{} }
-func (m *ChargenEvent) String() string            { return proto.CompactTextString(m) }
-func (*ChargenEvent) ProtoMessage()               {}
-func (*ChargenEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
-
-func (m *ChargenEvent) GetIndex() uint64 {
+func (m *MemoryAccess) GetLevel() string {
 	if m != nil {
-		return m.Index
+		return m.Level
 	}
-	return 0
+	return ""
 }
 
-func (m *ChargenEvent) GetCharacters() string {
+func (m *MemoryAccess) GetResult() string {
 	if m != nil {
-		return m.Characters
+		return m.Result
 	}
 	return ""
 }
 
-type TickerEvent struct {
-	// The number of seconds elapsed since January 1, 1970 UTC.
-	//
-	// https://golang.org/pkg/time/#Time.Unix
-	Seconds int64 `protobuf:"varint,1,opt,name=seconds" json:"seconds,omitempty"`
-	// The number of nanoseconds elapsed since January 1, 1970 UTC
-	//
-	// https://golang.org/pkg/time/#Time.UnixNano
-	Nanoseconds int64 `protobuf:"varint,2,opt,name=nanoseconds" json:"nanoseconds,omitempty"`
+func (m *MemoryAccess) GetSnoop() string {
+	if m != nil {
+		return m.Snoop
+	}
+	return ""
 }
 
-func (m *TickerEvent) Reset()                    { *m = TickerEvent{} }
-func (m *TickerEvent) String() string            { return proto.CompactTextString(m) }
-func (*TickerEvent) ProtoMessage()               {}
-func (*TickerEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
-
-func (m *TickerEvent) GetSeconds() int64 {
+func (m *MemoryAccess) GetTlb() string {
 	if m != nil {
-		return m.Seconds
+		return m.Tlb
 	}
-	return 0
+	return ""
 }
 
-func (m *TickerEvent) GetNanoseconds() int64 {
+func (m *MemoryAccess) GetLocked() bool {
 	if m != nil {
-		return m.Nanoseconds
+		return m.Locked
 	}
-	return 0
+	return false
 }
 
-// ContainerEvent describes a Docker container or Rkt App lifecycle event
-type ContainerEvent struct {
-	Type ContainerEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.ContainerEventType" json:"type,omitempty"`
-	Name string             `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
-	// Unique identifier of the container image
-	ImageId string `protobuf:"bytes,10,opt,name=image_id,json=imageId" json:"image_id,omitempty"`
-	//
-	// Name of the container image (i.e. "busybox" or
-	// "gcr.io/google_containers/nginx-ingress-controller")
-	//
-	ImageName string `protobuf:"bytes,11,opt,name=image_name,json=imageName" json:"image_name,omitempty"`
-	// Host process identifier of the container's init process.
-	HostPid int32 `protobuf:"zigzag32,20,opt,name=host_pid,json=hostPid" json:"host_pid,omitempty"`
-	// Optional, only included on CONTAINER_EVENT_TYPE_EXIT events
-	ExitCode int32 `protobuf:"zigzag32,30,opt,name=exit_code,json=exitCode" json:"exit_code,omitempty"`
-	// The exit status will typically one of the values defined in
-	// stdlib.h like EXIT_SUCCESS, EXIT_FAILURE, or EXIT_USAGE.
-	ExitStatus uint32 `protobuf:"varint,31,opt,name=exit_status,json=exitStatus" json:"exit_status,omitempty"`
-	// If non-zero, this is the signal number that the process
-	// was terminated with.
-	ExitSignal uint32 `protobuf:"varint,32,opt,name=exit_signal,json=exitSignal" json:"exit_signal,omitempty"`
-	// If true, indicates that the process dumped a core when
-	// it terminated.
-	ExitCoreDumped bool `protobuf:"varint,33,opt,name=exit_core_dumped,json=exitCoreDumped" json:"exit_core_dumped,omitempty"`
-	// Docker container configuration file
-	DockerConfigJson string `protobuf:"bytes,100,opt,name=docker_config_json,json=dockerConfigJson" json:"docker_config_json,omitempty"`
-	// OCI container configuration file
-	OciConfigJson string `protobuf:"bytes,101,opt,name=oci_config_json,json=ociConfigJson" json:"oci_config_json,omitempty"`
+// QuietPeriodEvent reports that a Subscription's events have stopped for a
+// key of its QuietPeriod. The process and container of the event are those of
+// the key's most recent event.
+type QuietPeriodEvent struct {
+	// The process_id or container_id that has had no events
+	Key string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	// The sensor_monotime_nanos of the key's most recent event
+	LastSeenMonotimeNanos int64 `protobuf:"varint,2,opt,name=last_seen_monotime_nanos,json=lastSeenMonotimeNanos" json:"last_seen_monotime_nanos,omitempty"`
+	// The number of nanoseconds since the key's most recent event
+	SilenceNanos int64 `protobuf:"varint,3,opt,name=silence_nanos,json=silenceNanos" json:"silence_nanos,omitempty"`
 }
 
-func (m *ContainerEvent) Reset()                    { *m = ContainerEvent{} }
-func (m *ContainerEvent) String() string            { return proto.CompactTextString(m) }
-func (*ContainerEvent) ProtoMessage()               {}
-func (*ContainerEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
+func (m *QuietPeriodEvent) Reset()                    { *m = QuietPeriodEvent{} }
+func (m *QuietPeriodEvent) String() string            { return proto.CompactTextString(m) }
+func (*QuietPeriodEvent) ProtoMessage()               {}
+func (*QuietPeriodEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
 
-func (m *ContainerEvent) GetType() ContainerEventType {
+func (m *QuietPeriodEvent) GetKey() string {
 	if m != nil {
-		return m.Type
+		return m.Key
 	}
-	return ContainerEventType_CONTAINER_EVENT_TYPE_UNKNOWN
+	return ""
 }
 
-func (m *ContainerEvent) GetName() string {
+func (m *QuietPeriodEvent) GetLastSeenMonotimeNanos() int64 {
 	if m != nil {
-		return m.Name
+		return m.LastSeenMonotimeNanos
 	}
-	return ""
+	return 0
 }
 
-func (m *ContainerEvent) GetImageId() string {
+func (m *QuietPeriodEvent) GetSilenceNanos() int64 {
 	if m != nil {
-		return m.ImageId
+		return m.SilenceNanos
+	}
+	return 0
+}
+
+// FilelessExecutionEvent reports an attempt to execute a program from an
+// anonymous memory file created with memfd_create, a common way of running a
+// payload without writing it to disk. The process and container of the event
+// are those of the execve or execveat system call.
+type FilelessExecutionEvent struct {
+	// The name given to the memory file by memfd_create, or the name
+	// in the executed "/memfd:" path. Empty if it is not known.
+	MemfdName string `protobuf:"bytes,1,opt,name=memfd_name,json=memfdName" json:"memfd_name,omitempty"`
+	// The file descriptor of the memory file in the executing
+	// process, or -1 if the path executed did not refer to one.
+	MemfdFd int32 `protobuf:"varint,2,opt,name=memfd_fd,json=memfdFd" json:"memfd_fd,omitempty"`
+	// The number of the system call that executed the memory file
+	// (execve or execveat) and the path passed to it, which is empty
+	// when execveat executed its file descriptor argument directly.
+	ExecSyscallId int64  `protobuf:"varint,3,opt,name=exec_syscall_id,json=execSyscallId" json:"exec_syscall_id,omitempty"`
+	ExecPath      string `protobuf:"bytes,4,opt,name=exec_path,json=execPath" json:"exec_path,omitempty"`
+	// The pid and sensor_monotime_nanos of the memfd_create call that
+	// created the memory file. Zero if it was not observed, e.g. because
+	// the memory file was created before the subscription.
+	MemfdCreatePid           int32 `protobuf:"varint,5,opt,name=memfd_create_pid,json=memfdCreatePid" json:"memfd_create_pid,omitempty"`
+	MemfdCreateMonotimeNanos int64 `protobuf:"varint,6,opt,name=memfd_create_monotime_nanos,json=memfdCreateMonotimeNanos" json:"memfd_create_monotime_nanos,omitempty"`
+}
+
+func (m *FilelessExecutionEvent) Reset()                    { *m = FilelessExecutionEvent{} }
+func (m *FilelessExecutionEvent) String() string            { return proto.CompactTextString(m) }
+func (*FilelessExecutionEvent) ProtoMessage()               {}
+func (*FilelessExecutionEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
+
+func (m *FilelessExecutionEvent) GetMemfdName() string {
+	if m != nil {
+		return m.MemfdName
+	}
+	return ""
+}
+
+func (m *FilelessExecutionEvent) GetMemfdFd() int32 {
+	if m != nil {
+		return m.MemfdFd
+	}
+	return 0
+}
+
+func (m *FilelessExecutionEvent) GetExecSyscallId() int64 {
+	if m != nil {
+		return m.ExecSyscallId
+	}
+	return 0
+}
+
+func (m *FilelessExecutionEvent) GetExecPath() string {
+	if m != nil {
+		return m.ExecPath
+	}
+	return ""
+}
+
+func (m *FilelessExecutionEvent) GetMemfdCreatePid() int32 {
+	if m != nil {
+		return m.MemfdCreatePid
+	}
+	return 0
+}
+
+func (m *FilelessExecutionEvent) GetMemfdCreateMonotimeNanos() int64 {
+	if m != nil {
+		return m.MemfdCreateMonotimeNanos
+	}
+	return 0
+}
+
+// SubscriptionStatsEvent describes the events that matched a subscription
+// during a sliding window, as periodically reported by the Sensor.
+type SubscriptionStatsEvent struct {
+	// The length in nanoseconds of the window that the statistics cover
+	WindowNanos int64 `protobuf:"varint,1,opt,name=window_nanos,json=windowNanos" json:"window_nanos,omitempty"`
+	// An estimate of the number of distinct processes (thread group
+	// IDs) whose events matched the subscription during the window.
+	// The estimate is made with a HyperLogLog and is typically within
+	// 2% of the actual number.
+	DistinctPids uint64 `protobuf:"varint,2,opt,name=distinct_pids,json=distinctPids" json:"distinct_pids,omitempty"`
+	// The effective sampling rates of each container during the
+	// window, if the subscription samples events by container
+	ContainerSampleRates []*ContainerSampleRate `protobuf:"bytes,3,rep,name=container_sample_rates,json=containerSampleRates" json:"container_sample_rates,omitempty"`
+}
+
+func (m *SubscriptionStatsEvent) Reset()                    { *m = SubscriptionStatsEvent{} }
+func (m *SubscriptionStatsEvent) String() string            { return proto.CompactTextString(m) }
+func (*SubscriptionStatsEvent) ProtoMessage()               {}
+func (*SubscriptionStatsEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
+
+func (m *SubscriptionStatsEvent) GetWindowNanos() int64 {
+	if m != nil {
+		return m.WindowNanos
+	}
+	return 0
+}
+
+func (m *SubscriptionStatsEvent) GetDistinctPids() uint64 {
+	if m != nil {
+		return m.DistinctPids
+	}
+	return 0
+}
+
+func (m *SubscriptionStatsEvent) GetContainerSampleRates() []*ContainerSampleRate {
+	if m != nil {
+		return m.ContainerSampleRates
+	}
+	return nil
+}
+
+// ContainerSampleRate describes the sampling of a container's events by a
+// subscription during an interval.
+type ContainerSampleRate struct {
+	// The container ID, or empty for the host
+	ContainerId string `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
+	// The fraction of the container's events that were returned
+	SampleRate float64 `protobuf:"fixed64,2,opt,name=sample_rate,json=sampleRate" json:"sample_rate,omitempty"`
+	// The number of the container's events that matched the
+	// subscription
+	ObservedEvents uint64 `protobuf:"varint,3,opt,name=observed_events,json=observedEvents" json:"observed_events,omitempty"`
+	// The number of the container's events that were returned
+	SampledEvents uint64 `protobuf:"varint,4,opt,name=sampled_events,json=sampledEvents" json:"sampled_events,omitempty"`
+}
+
+func (m *ContainerSampleRate) Reset()                    { *m = ContainerSampleRate{} }
+func (m *ContainerSampleRate) String() string            { return proto.CompactTextString(m) }
+func (*ContainerSampleRate) ProtoMessage()               {}
+func (*ContainerSampleRate) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
+
+func (m *ContainerSampleRate) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+func (m *ContainerSampleRate) GetSampleRate() float64 {
+	if m != nil {
+		return m.SampleRate
+	}
+	return 0
+}
+
+func (m *ContainerSampleRate) GetObservedEvents() uint64 {
+	if m != nil {
+		return m.ObservedEvents
+	}
+	return 0
+}
+
+func (m *ContainerSampleRate) GetSampledEvents() uint64 {
+	if m != nil {
+		return m.SampledEvents
+	}
+	return 0
+}
+
+// DecodeErrorEvent reports that the Sensor could not decode a sample of one
+// of the kernel events that a subscription uses, so the event it would have
+// produced is missing from the stream.
+type DecodeErrorEvent struct {
+	// The Sensor's identifier for the kernel event whose sample could
+	// not be decoded
+	EventId uint64 `protobuf:"varint,1,opt,name=event_id,json=eventId" json:"event_id,omitempty"`
+	// The reason that the sample could not be decoded
+	Reason string `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+	// The length of the sample's raw data in bytes
+	RawDataLength uint32 `protobuf:"varint,3,opt,name=raw_data_length,json=rawDataLength" json:"raw_data_length,omitempty"`
+	// Whether the decoder panicked rather than returning an error
+	Panic bool `protobuf:"varint,4,opt,name=panic" json:"panic,omitempty"`
+}
+
+func (m *DecodeErrorEvent) Reset()                    { *m = DecodeErrorEvent{} }
+func (m *DecodeErrorEvent) String() string            { return proto.CompactTextString(m) }
+func (*DecodeErrorEvent) ProtoMessage()               {}
+func (*DecodeErrorEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
+
+func (m *DecodeErrorEvent) GetEventId() uint64 {
+	if m != nil {
+		return m.EventId
+	}
+	return 0
+}
+
+func (m *DecodeErrorEvent) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *DecodeErrorEvent) GetRawDataLength() uint32 {
+	if m != nil {
+		return m.RawDataLength
+	}
+	return 0
+}
+
+func (m *DecodeErrorEvent) GetPanic() bool {
+	if m != nil {
+		return m.Panic
+	}
+	return false
+}
+
+// AnyTelemetryEvent is an alternative encoding of a TelemetryEvent for
+// consumers that decode event payloads dynamically. The payload that would
+// be set in the TelemetryEvent's event oneof is instead wrapped in an Any,
+// identified by its type URL.
+type AnyTelemetryEvent struct {
+	// The event with its event oneof unset
+	Event *TelemetryEvent `protobuf:"bytes,1,opt,name=event" json:"event,omitempty"`
+	// The event payload, such as a SyscallEvent or ProcessEvent
+	Payload *google_protobuf.Any `protobuf:"bytes,2,opt,name=payload" json:"payload,omitempty"`
+}
+
+func (m *AnyTelemetryEvent) Reset()                    { *m = AnyTelemetryEvent{} }
+func (m *AnyTelemetryEvent) String() string            { return proto.CompactTextString(m) }
+func (*AnyTelemetryEvent) ProtoMessage()               {}
+func (*AnyTelemetryEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{7} }
+
+func (m *AnyTelemetryEvent) GetEvent() *TelemetryEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *AnyTelemetryEvent) GetPayload() *google_protobuf.Any {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+type ChargenEvent struct {
+	// Index of the first character in this Event in relation to all of
+	// the characters that have been generated in this stream.
+	Index uint64 `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
+	// The next one or more characters in the autogenerated stream
+	Characters string `protobuf:"bytes,2,opt,name=characters" json:"characters,omitempty"`
+}
+
+func (m *ChargenEvent) Reset()                    { *m = ChargenEvent{} }
+func (m *ChargenEvent) String() string            { return proto.CompactTextString(m) }
+func (*ChargenEvent) ProtoMessage()               {}
+func (*ChargenEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{8} }
+
+func (m *ChargenEvent) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ChargenEvent) GetCharacters() string {
+	if m != nil {
+		return m.Characters
+	}
+	return ""
+}
+
+type TickerEvent struct {
+	// The number of seconds elapsed since January 1, 1970 UTC.
+	//
+	// https://golang.org/pkg/time/#Time.Unix
+	Seconds int64 `protobuf:"varint,1,opt,name=seconds" json:"seconds,omitempty"`
+	// The number of nanoseconds elapsed since January 1, 1970 UTC
+	//
+	// https://golang.org/pkg/time/#Time.UnixNano
+	Nanoseconds int64 `protobuf:"varint,2,opt,name=nanoseconds" json:"nanoseconds,omitempty"`
+}
+
+func (m *TickerEvent) Reset()                    { *m = TickerEvent{} }
+func (m *TickerEvent) String() string            { return proto.CompactTextString(m) }
+func (*TickerEvent) ProtoMessage()               {}
+func (*TickerEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{9} }
+
+func (m *TickerEvent) GetSeconds() int64 {
+	if m != nil {
+		return m.Seconds
+	}
+	return 0
+}
+
+func (m *TickerEvent) GetNanoseconds() int64 {
+	if m != nil {
+		return m.Nanoseconds
+	}
+	return 0
+}
+
+// ContainerEvent describes a Docker container or Rkt App lifecycle event
+type ContainerEvent struct {
+	Type ContainerEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.ContainerEventType" json:"type,omitempty"`
+	Name string             `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// Unique identifier of the container image
+	ImageId string `protobuf:"bytes,10,opt,name=image_id,json=imageId" json:"image_id,omitempty"`
+	//
+	// Name of the container image (i.e. "busybox" or
+	// "gcr.io/google_containers/nginx-ingress-controller")
+	//
+	ImageName string `protobuf:"bytes,11,opt,name=image_name,json=imageName" json:"image_name,omitempty"`
+	// Host process identifier of the container's init process.
+	HostPid int32 `protobuf:"zigzag32,20,opt,name=host_pid,json=hostPid" json:"host_pid,omitempty"`
+	// Optional, only included on CONTAINER_EVENT_TYPE_EXIT events
+	ExitCode int32 `protobuf:"zigzag32,30,opt,name=exit_code,json=exitCode" json:"exit_code,omitempty"`
+	// The exit status will typically one of the values defined in
+	// stdlib.h like EXIT_SUCCESS, EXIT_FAILURE, or EXIT_USAGE.
+	ExitStatus uint32 `protobuf:"varint,31,opt,name=exit_status,json=exitStatus" json:"exit_status,omitempty"`
+	// If non-zero, this is the signal number that the process
+	// was terminated with.
+	ExitSignal uint32 `protobuf:"varint,32,opt,name=exit_signal,json=exitSignal" json:"exit_signal,omitempty"`
+	// If true, indicates that the process dumped a core when
+	// it terminated.
+	ExitCoreDumped bool `protobuf:"varint,33,opt,name=exit_core_dumped,json=exitCoreDumped" json:"exit_core_dumped,omitempty"`
+	// Docker container configuration file
+	DockerConfigJson string `protobuf:"bytes,100,opt,name=docker_config_json,json=dockerConfigJson" json:"docker_config_json,omitempty"`
+	// OCI container configuration file
+	OciConfigJson string `protobuf:"bytes,101,opt,name=oci_config_json,json=ociConfigJson" json:"oci_config_json,omitempty"`
+}
+
+func (m *ContainerEvent) Reset()                    { *m = ContainerEvent{} }
+func (m *ContainerEvent) String() string            { return proto.CompactTextString(m) }
+func (*ContainerEvent) ProtoMessage()               {}
+func (*ContainerEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{10} }
+
+func (m *ContainerEvent) GetType() ContainerEventType {
+	if m != nil {
+		return m.Type
+	}
+	return ContainerEventType_CONTAINER_EVENT_TYPE_UNKNOWN
+}
+
+func (m *ContainerEvent) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ContainerEvent) GetImageId() string {
+	if m != nil {
+		return m.ImageId
+	}
+	return ""
+}
+
+func (m *ContainerEvent) GetImageName() string {
+	if m != nil {
+		return m.ImageName
+	}
+	return ""
+}
+
+func (m *ContainerEvent) GetHostPid() int32 {
+	if m != nil {
+		return m.HostPid
+	}
+	return 0
+}
+
+func (m *ContainerEvent) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+func (m *ContainerEvent) GetExitStatus() uint32 {
+	if m != nil {
+		return m.ExitStatus
+	}
+	return 0
+}
+
+func (m *ContainerEvent) GetExitSignal() uint32 {
+	if m != nil {
+		return m.ExitSignal
+	}
+	return 0
+}
+
+func (m *ContainerEvent) GetExitCoreDumped() bool {
+	if m != nil {
+		return m.ExitCoreDumped
+	}
+	return false
+}
+
+func (m *ContainerEvent) GetDockerConfigJson() string {
+	if m != nil {
+		return m.DockerConfigJson
+	}
+	return ""
+}
+
+func (m *ContainerEvent) GetOciConfigJson() string {
+	if m != nil {
+		return m.OciConfigJson
+	}
+	return ""
+}
+
+// ProcessEvent describes an event that occurred related to processes starting
+// and exiting as detected by the Sensor.
+type ProcessEvent struct {
+	// The type of event described by this ProcessEvent message
+	Type ProcessEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.ProcessEventType" json:"type,omitempty"`
+	// Present when the event is a fork event. This is the PID of the
+	// new child process.
+	ForkChildPid int32 `protobuf:"zigzag32,10,opt,name=fork_child_pid,json=forkChildPid" json:"fork_child_pid,omitempty"`
+	// Present when the event is a fork event. This is the Sensor's process
+	// ID of the new child process.
+	ForkChildId string `protobuf:"bytes,11,opt,name=fork_child_id,json=forkChildId" json:"fork_child_id,omitempty"`
+	// Present when the event is an exec event. This is the filename of the
+	// executable that was executed.
+	ExecFilename string `protobuf:"bytes,20,opt,name=exec_filename,json=execFilename" json:"exec_filename,omitempty"`
+	// Present when the event is an exec event. Repeated for each argument
+	// passed to the executable on the command-line.
+	ExecCommandLine []string `protobuf:"bytes,21,rep,name=exec_command_line,json=execCommandLine" json:"exec_command_line,omitempty"`
+	// Present when the event is an exec event. The executables that led
+	// to this one, oldest first and ending with exec_filename, as tracked
+	// by the Sensor across the exec events of the process and its
+	// ancestors. Processes that were running before the Sensor started
+	// contribute the executable they were running. At most the 16 most
+	// recent executables are included.
+	ExecLineage []string `protobuf:"bytes,22,rep,name=exec_lineage,json=execLineage" json:"exec_lineage,omitempty"`
+	// Present when the event is an exec event and the subscription's
+	// exec filter requested an ExecFdSnapshot. These are the file
+	// descriptors that the process had open when the Sensor processed
+	// the event, lowest first. If the process had more than could be
+	// captured, exec_fds_truncated is true. Empty if the process exited
+	// before they could be read.
+	ExecFds          []*FileDescriptor `protobuf:"bytes,23,rep,name=exec_fds,json=execFds" json:"exec_fds,omitempty"`
+	ExecFdsTruncated bool              `protobuf:"varint,24,opt,name=exec_fds_truncated,json=execFdsTruncated" json:"exec_fds_truncated,omitempty"`
+	// Present when the event is an exit event. This is the exit code that
+	// the process exited with.
+	ExitCode int32 `protobuf:"zigzag32,30,opt,name=exit_code,json=exitCode" json:"exit_code,omitempty"`
+	// Present when the event is an exit event. This will typically be one9
+	// of the values defined in stdlib.h like EXIT_SUCCESS, EXIT_FAILURE,
+	// or EXIT_USAGE.
+	ExitStatus uint32 `protobuf:"varint,31,opt,name=exit_status,json=exitStatus" json:"exit_status,omitempty"`
+	// Present when the event is an exit event. If non-zero, this is the
+	// signal number that the process was terminated with.
+	ExitSignal uint32 `protobuf:"varint,32,opt,name=exit_signal,json=exitSignal" json:"exit_signal,omitempty"`
+	// Present when the event is an exit event. If true, indicates that the
+	// process dumped a core when it terminated.
+	ExitCoreDumped bool `protobuf:"varint,33,opt,name=exit_core_dumped,json=exitCoreDumped" json:"exit_core_dumped,omitempty"`
+	// Present when the event is an exit event. The command name and
+	// executable of the process as last known to the Sensor, which
+	// reflect any exec since the process was created. The executable
+	// is empty if it could not be determined.
+	ExitCommand    string `protobuf:"bytes,34,opt,name=exit_command,json=exitCommand" json:"exit_command,omitempty"`
+	ExitExecutable string `protobuf:"bytes,35,opt,name=exit_executable,json=exitExecutable" json:"exit_executable,omitempty"`
+	// Present when the event is an exit event and an exit filter of
+	// any subscription requested exit_resource_usage. Not present if it
+	// could not be read before the task was reaped.
+	ExitResourceUsage *ProcessResourceUsage `protobuf:"bytes,36,opt,name=exit_resource_usage,json=exitResourceUsage" json:"exit_resource_usage,omitempty"`
+	// Present when the event is an update event that informs of an update
+	// to the process's current working directory.
+	UpdateCwd string `protobuf:"bytes,40,opt,name=update_cwd,json=updateCwd" json:"update_cwd,omitempty"`
+}
+
+func (m *ProcessEvent) Reset()                    { *m = ProcessEvent{} }
+func (m *ProcessEvent) String() string            { return proto.CompactTextString(m) }
+func (*ProcessEvent) ProtoMessage()               {}
+func (*ProcessEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{11} }
+
+func (m *ProcessEvent) GetType() ProcessEventType {
+	if m != nil {
+		return m.Type
+	}
+	return ProcessEventType_PROCESS_EVENT_TYPE_UNKNOWN
+}
+
+func (m *ProcessEvent) GetForkChildPid() int32 {
+	if m != nil {
+		return m.ForkChildPid
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetForkChildId() string {
+	if m != nil {
+		return m.ForkChildId
+	}
+	return ""
+}
+
+func (m *ProcessEvent) GetExecFilename() string {
+	if m != nil {
+		return m.ExecFilename
+	}
+	return ""
+}
+
+func (m *ProcessEvent) GetExecCommandLine() []string {
+	if m != nil {
+		return m.ExecCommandLine
+	}
+	return nil
+}
+
+func (m *ProcessEvent) GetExecLineage() []string {
+	if m != nil {
+		return m.ExecLineage
+	}
+	return nil
+}
+
+func (m *ProcessEvent) GetExecFds() []*FileDescriptor {
+	if m != nil {
+		return m.ExecFds
+	}
+	return nil
+}
+
+func (m *ProcessEvent) GetExecFdsTruncated() bool {
+	if m != nil {
+		return m.ExecFdsTruncated
+	}
+	return false
+}
+
+func (m *ProcessEvent) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetExitStatus() uint32 {
+	if m != nil {
+		return m.ExitStatus
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetExitSignal() uint32 {
+	if m != nil {
+		return m.ExitSignal
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetExitCoreDumped() bool {
+	if m != nil {
+		return m.ExitCoreDumped
+	}
+	return false
+}
+
+func (m *ProcessEvent) GetExitCommand() string {
+	if m != nil {
+		return m.ExitCommand
+	}
+	return ""
+}
+
+func (m *ProcessEvent) GetExitExecutable() string {
+	if m != nil {
+		return m.ExitExecutable
+	}
+	return ""
+}
+
+func (m *ProcessEvent) GetExitResourceUsage() *ProcessResourceUsage {
+	if m != nil {
+		return m.ExitResourceUsage
+	}
+	return nil
+}
+
+func (m *ProcessEvent) GetUpdateCwd() string {
+	if m != nil {
+		return m.UpdateCwd
+	}
+	return ""
+}
+
+// ProcessResourceUsage describes the resources used by an exiting task
+type ProcessResourceUsage struct {
+	// The CPU time in nanoseconds that the task spent in user and
+	// kernel mode, at the resolution of the kernel's clock ticks
+	UserTimeNanos   uint64 `protobuf:"varint,1,opt,name=user_time_nanos,json=userTimeNanos" json:"user_time_nanos,omitempty"`
+	SystemTimeNanos uint64 `protobuf:"varint,2,opt,name=system_time_nanos,json=systemTimeNanos" json:"system_time_nanos,omitempty"`
+	// The number of page faults that the task made that did not and
+	// did require loading a page from disk
+	MinorFaults uint64 `protobuf:"varint,3,opt,name=minor_faults,json=minorFaults" json:"minor_faults,omitempty"`
+	MajorFaults uint64 `protobuf:"varint,4,opt,name=major_faults,json=majorFaults" json:"major_faults,omitempty"`
+}
+
+func (m *ProcessResourceUsage) Reset()                    { *m = ProcessResourceUsage{} }
+func (m *ProcessResourceUsage) String() string            { return proto.CompactTextString(m) }
+func (*ProcessResourceUsage) ProtoMessage()               {}
+func (*ProcessResourceUsage) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{12} }
+
+func (m *ProcessResourceUsage) GetUserTimeNanos() uint64 {
+	if m != nil {
+		return m.UserTimeNanos
+	}
+	return 0
+}
+
+func (m *ProcessResourceUsage) GetSystemTimeNanos() uint64 {
+	if m != nil {
+		return m.SystemTimeNanos
+	}
+	return 0
+}
+
+func (m *ProcessResourceUsage) GetMinorFaults() uint64 {
+	if m != nil {
+		return m.MinorFaults
+	}
+	return 0
+}
+
+func (m *ProcessResourceUsage) GetMajorFaults() uint64 {
+	if m != nil {
+		return m.MajorFaults
+	}
+	return 0
+}
+
+// FileDescriptor describes an open file descriptor of a process
+type FileDescriptor struct {
+	// The file descriptor number
+	Fd int32 `protobuf:"varint,1,opt,name=fd" json:"fd,omitempty"`
+	// The path of the file that the descriptor refers to, as shown by
+	// its /proc/[pid]/fd link, e.g. "socket:[12345]" for sockets
+	Path string `protobuf:"bytes,2,opt,name=path" json:"path,omitempty"`
+}
+
+func (m *FileDescriptor) Reset()                    { *m = FileDescriptor{} }
+func (m *FileDescriptor) String() string            { return proto.CompactTextString(m) }
+func (*FileDescriptor) ProtoMessage()               {}
+func (*FileDescriptor) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{13} }
+
+func (m *FileDescriptor) GetFd() int32 {
+	if m != nil {
+		return m.Fd
+	}
+	return 0
+}
+
+func (m *FileDescriptor) GetPath() string {
+	if m != nil {
+		return m.Path
 	}
 	return ""
 }
 
-func (m *ContainerEvent) GetImageName() string {
+// SyscallEvent describes an event that occurred related to system calls being
+// made or returning as detected by the Sensor.
+type SyscallEvent struct {
+	// The type of event described by this SyscallEvent message
+	Type SyscallEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.SyscallEventType" json:"type,omitempty"`
+	// The syscall number for either enter or exit events.
+	Id int64 `protobuf:"varint,2,opt,name=id" json:"id,omitempty"`
+	// Present when the event is an enter event. This is the first
+	// argument passed to the system call.
+	Arg0 uint64 `protobuf:"varint,10,opt,name=arg0" json:"arg0,omitempty"`
+	// Present when the event is an enter event. This is the second
+	// argument passed to the system call.
+	Arg1 uint64 `protobuf:"varint,11,opt,name=arg1" json:"arg1,omitempty"`
+	// Present when the event is an enter event. This is the third
+	// argument passed to the system call.
+	Arg2 uint64 `protobuf:"varint,12,opt,name=arg2" json:"arg2,omitempty"`
+	// Present when the event is an enter event. This is the fourth
+	// argument passed to the system call.
+	Arg3 uint64 `protobuf:"varint,13,opt,name=arg3" json:"arg3,omitempty"`
+	// Present when the event is an enter event. This is the fifth
+	// argument passed to the system call.
+	Arg4 uint64 `protobuf:"varint,14,opt,name=arg4" json:"arg4,omitempty"`
+	// Present when the event is an enter event. This is the sixth
+	// argument passed to the system call.
+	Arg5 uint64 `protobuf:"varint,15,opt,name=arg5" json:"arg5,omitempty"`
+	// Present when the event is an exit event. This is the value that was
+	// returned from the system call.
+	Ret int64 `protobuf:"varint,20,opt,name=ret" json:"ret,omitempty"`
+	// Present when the event is a complete event. This is the time in
+	// nanoseconds between the system call being entered and returning.
+	DurationNanos int64 `protobuf:"varint,21,opt,name=duration_nanos,json=durationNanos" json:"duration_nanos,omitempty"`
+	// Present when the event is a complete event for which only the
+	// enter or only the exit was observed. Partial enter events do not
+	// have ret set and partial exit events do not have arguments set.
+	// Neither have duration_nanos set.
+	Partial bool `protobuf:"varint,22,opt,name=partial" json:"partial,omitempty"`
+	// Present when the event is an exit or complete event for a system
+	// call that transfers data, such as read, write, sendto, or recvfrom,
+	// and the system call succeeded. This is the number of bytes
+	// transferred, which is the same as ret.
+	Bytes uint64 `protobuf:"varint,23,opt,name=bytes" json:"bytes,omitempty"`
+	// Present when the event is an exit or complete event for a system
+	// call that sets user or group IDs, such as setuid, setreuid, or
+	// setresuid. These are the credentials of the calling task before
+	// and after the system call. They are the same if the system call
+	// failed.
+	CredentialsBefore *Credentials `protobuf:"bytes,24,opt,name=credentials_before,json=credentialsBefore" json:"credentials_before,omitempty"`
+	CredentialsAfter  *Credentials `protobuf:"bytes,25,opt,name=credentials_after,json=credentialsAfter" json:"credentials_after,omitempty"`
+	// Present when the event is a clone linkage event. These are the
+	// host pids of the task that made the clone, fork, or vfork system
+	// call and of the task that it created. ret is the pid of the
+	// created task as seen by the calling task, which differs from
+	// child_pid when the calling task is in a child pid namespace.
+	ParentPid int32 `protobuf:"varint,26,opt,name=parent_pid,json=parentPid" json:"parent_pid,omitempty"`
+	ChildPid  int32 `protobuf:"varint,27,opt,name=child_pid,json=childPid" json:"child_pid,omitempty"`
+	// Present when the event is an argument distribution event. These
+	// are the index of the summarized argument, the number of system
+	// calls observed during the interval, and the most frequently seen
+	// argument values in descending order of frequency. The length of
+	// the interval is reported in duration_nanos.
+	DistributionArg   uint32                  `protobuf:"varint,28,opt,name=distribution_arg,json=distributionArg" json:"distribution_arg,omitempty"`
+	DistributionTotal uint64                  `protobuf:"varint,29,opt,name=distribution_total,json=distributionTotal" json:"distribution_total,omitempty"`
+	Distribution      []*SyscallArgValueCount `protobuf:"bytes,30,rep,name=distribution" json:"distribution,omitempty"`
+	// Present when the event is an enter or complete event for a ptrace
+	// system call and its arguments were captured. These are the name
+	// of the request (e.g. "PTRACE_ATTACH", or its number if it is
+	// unknown), the pid of the tracee, and the command of the tracee if
+	// it is known to the Sensor. The tracee is not present for
+	// PTRACE_TRACEME requests. The request and tracee pid may be used
+	// in filters as ptrace_request and ptrace_target_pid.
+	PtraceRequest       string `protobuf:"bytes,31,opt,name=ptrace_request,json=ptraceRequest" json:"ptrace_request,omitempty"`
+	PtraceTargetPid     int32  `protobuf:"varint,32,opt,name=ptrace_target_pid,json=ptraceTargetPid" json:"ptrace_target_pid,omitempty"`
+	PtraceTargetCommand string `protobuf:"bytes,33,opt,name=ptrace_target_command,json=ptraceTargetCommand" json:"ptrace_target_command,omitempty"`
+	// Present when the event is an enter or complete event for an mmap
+	// or mprotect system call and its arguments were captured. These
+	// are the symbolic memory protection (e.g. "PROT_READ|PROT_EXEC",
+	// or "PROT_NONE"), whether the memory is both writable and
+	// executable, and for mmap, the symbolic flags (e.g.
+	// "MAP_PRIVATE|MAP_ANONYMOUS"). Unknown bits are included in
+	// hexadecimal. They may be used in filters as prot,
+	// writable_and_executable, and mmap_flags.
+	Prot                  string `protobuf:"bytes,34,opt,name=prot" json:"prot,omitempty"`
+	WritableAndExecutable bool   `protobuf:"varint,35,opt,name=writable_and_executable,json=writableAndExecutable" json:"writable_and_executable,omitempty"`
+	MmapFlags             string `protobuf:"bytes,36,opt,name=mmap_flags,json=mmapFlags" json:"mmap_flags,omitempty"`
+	// Present when the event is an enter or complete event whose
+	// captured arguments were limited by the Sensor's syscall argument
+	// policy. Bit 0 is set if arg0 was removed, hashed, or truncated,
+	// bit 1 for arg1, and so on through bit 5 for arg5.
+	PolicyArgMask uint32 `protobuf:"varint,37,opt,name=policy_arg_mask,json=policyArgMask" json:"policy_arg_mask,omitempty"`
+	// Present when the event is an enter or complete event for a system
+	// call that modifies a file by path (chmod, chown, lchown, unlink,
+	// rename, link, and their *at variants), or for openat, mkdirat,
+	// mknodat, or symlinkat, and the Subscription requested
+	// capture_paths. These are the path of the file, and for rename and
+	// link, the new path. Relative paths are made absolute using the
+	// working directory of the process, or for the *at system calls the
+	// directory that the directory file descriptor refers to, when it is
+	// known. They may be used in filters as path and new_path, e.g. with
+	// LIKE.
+	Path    string `protobuf:"bytes,38,opt,name=path" json:"path,omitempty"`
+	NewPath string `protobuf:"bytes,39,opt,name=new_path,json=newPath" json:"new_path,omitempty"`
+	// Set when path or new_path could not be made absolute, e.g.
+	// because the directory file descriptor was closed before the event
+	// was decoded. They may be used in filters as path_relative and
+	// new_path_relative.
+	PathRelative    bool `protobuf:"varint,40,opt,name=path_relative,json=pathRelative" json:"path_relative,omitempty"`
+	NewPathRelative bool `protobuf:"varint,41,opt,name=new_path_relative,json=newPathRelative" json:"new_path_relative,omitempty"`
+	// Present when the event is an enter or complete event. This is
+	// the capture status of arg0 through arg5, in order, so that an
+	// argument whose value is zero can be told apart from one that was
+	// not captured.
+	ArgStatus []SyscallArgStatus `protobuf:"varint,42,rep,packed,name=arg_status,json=argStatus,enum=capsule8.api.v0.SyscallArgStatus" json:"arg_status,omitempty"`
+	// Present when the event is an enter or complete event for a system
+	// call whose paths were requested. This is whether path and
+	// new_path were captured, or could not be read from the memory of
+	// the calling process.
+	PathStatus    SyscallArgStatus `protobuf:"varint,43,opt,name=path_status,json=pathStatus,enum=capsule8.api.v0.SyscallArgStatus" json:"path_status,omitempty"`
+	NewPathStatus SyscallArgStatus `protobuf:"varint,44,opt,name=new_path_status,json=newPathStatus,enum=capsule8.api.v0.SyscallArgStatus" json:"new_path_status,omitempty"`
+	// Present when the event is an enter or complete event for a bpf
+	// system call and its first argument was captured. This is the name
+	// of the command (e.g. "BPF_PROG_LOAD", or its number if it is
+	// unknown). It may be used in filters as bpf_command.
+	BpfCommand string `protobuf:"bytes,45,opt,name=bpf_command,json=bpfCommand" json:"bpf_command,omitempty"`
+	// Present when the event is an enter or complete event for a
+	// perf_event_open system call and its arguments were captured.
+	// These are the type of the event (e.g. "PERF_TYPE_TRACEPOINT", or
+	// the number of a dynamic PMU type such as kprobe), its config, the
+	// pid of the process monitored (-1 for all processes, or 0 for the
+	// caller), the command of that process if it is known to the
+	// Sensor, and the CPU monitored (-1 for all CPUs). The type and
+	// config are read from the memory of the calling process and are
+	// zero if they could not be read. Other than the command, they may
+	// be used in filters as perf_event_type, perf_event_config,
+	// perf_event_target_pid, and perf_event_cpu.
+	PerfEventType          string `protobuf:"bytes,46,opt,name=perf_event_type,json=perfEventType" json:"perf_event_type,omitempty"`
+	PerfEventConfig        uint64 `protobuf:"varint,47,opt,name=perf_event_config,json=perfEventConfig" json:"perf_event_config,omitempty"`
+	PerfEventTargetPid     int32  `protobuf:"varint,48,opt,name=perf_event_target_pid,json=perfEventTargetPid" json:"perf_event_target_pid,omitempty"`
+	PerfEventTargetCommand string `protobuf:"bytes,49,opt,name=perf_event_target_command,json=perfEventTargetCommand" json:"perf_event_target_command,omitempty"`
+	PerfEventCpu           int32  `protobuf:"varint,50,opt,name=perf_event_cpu,json=perfEventCpu" json:"perf_event_cpu,omitempty"`
+	// Present when the event is an enter or complete event for a bpf or
+	// perf_event_open system call. These are the command and executable
+	// of the calling process, if they are known to the Sensor.
+	CallerCommand    string `protobuf:"bytes,51,opt,name=caller_command,json=callerCommand" json:"caller_command,omitempty"`
+	CallerExecutable string `protobuf:"bytes,52,opt,name=caller_executable,json=callerExecutable" json:"caller_executable,omitempty"`
+	// Present when the event is an enter or complete event for a
+	// memfd_create system call. This is the name given to the memory
+	// file, which appears in its /proc/[pid]/fd link as
+	// "/memfd:name". It may be used in filters as memfd_name.
+	MemfdName string `protobuf:"bytes,53,opt,name=memfd_name,json=memfdName" json:"memfd_name,omitempty"`
+	// Present when the event is an enter or complete event for a setns
+	// or unshare system call whose namespace types were captured. These
+	// are the symbolic names of the types of namespaces that the call
+	// enters or creates, e.g. "CLONE_NEWNET|CLONE_NEWNS". It is empty
+	// for setns with an nstype of 0, which enters a namespace of any
+	// type. For exit events, these are the types of the namespaces
+	// in namespace_transitions. It may be used in filters as
+	// namespace_types.
+	NamespaceTypes string `protobuf:"bytes,54,opt,name=namespace_types,json=namespaceTypes" json:"namespace_types,omitempty"`
+	// Present when the event is an exit or complete event for a
+	// successful setns or unshare system call. These are the namespaces
+	// that the calling task left and entered in the most recent change
+	// of its namespaces known to the Sensor, ordered by type. The
+	// namespaces of a task are read from /proc when it is first seen
+	// making one of these system calls, so the first call seen may not
+	// be reported if it was not decoded before it completed.
+	NamespaceTransitions []*NamespaceTransition `protobuf:"bytes,55,rep,name=namespace_transitions,json=namespaceTransitions" json:"namespace_transitions,omitempty"`
+	// True if the calling task exited before its namespaces could be
+	// read after a setns or unshare system call.
+	NamespacesUnresolved bool `protobuf:"varint,56,opt,name=namespaces_unresolved,json=namespacesUnresolved" json:"namespaces_unresolved,omitempty"`
+	// Present when the event is an enter or complete event for a
+	// keyctl system call. This is the name of the operation, e.g.
+	// "KEYCTL_READ", or its number if it is unknown. It may be used in
+	// filters as keyctl_operation.
+	KeyctlOperation string `protobuf:"bytes,57,opt,name=keyctl_operation,json=keyctlOperation" json:"keyctl_operation,omitempty"`
+	// Present when the event is an enter or complete event for an
+	// add_key or request_key system call. These are the type and
+	// description of the key, and may be used in filters as key_type
+	// and key_description. Key payloads are never captured. The
+	// Sensor's syscall argument policy applies to these strings as it
+	// does to the arguments that point to them: a rule that disables
+	// or truncates the argument removes the string, and a rule that
+	// hashes it replaces the string with the hex encoding of a keyed
+	// hash.
+	KeyType        string `protobuf:"bytes,58,opt,name=key_type,json=keyType" json:"key_type,omitempty"`
+	KeyDescription string `protobuf:"bytes,59,opt,name=key_description,json=keyDescription" json:"key_description,omitempty"`
+}
+
+func (m *SyscallEvent) Reset()                    { *m = SyscallEvent{} }
+func (m *SyscallEvent) String() string            { return proto.CompactTextString(m) }
+func (*SyscallEvent) ProtoMessage()               {}
+func (*SyscallEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{14} }
+
+func (m *SyscallEvent) GetType() SyscallEventType {
+	if m != nil {
+		return m.Type
+	}
+	return SyscallEventType_SYSCALL_EVENT_TYPE_UNKNOWN
+}
+
+func (m *SyscallEvent) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *SyscallEvent) GetArg0() uint64 {
+	if m != nil {
+		return m.Arg0
+	}
+	return 0
+}
+
+func (m *SyscallEvent) GetArg1() uint64 {
+	if m != nil {
+		return m.Arg1
+	}
+	return 0
+}
+
+func (m *SyscallEvent) GetArg2() uint64 {
+	if m != nil {
+		return m.Arg2
+	}
+	return 0
+}
+
+func (m *SyscallEvent) GetArg3() uint64 {
+	if m != nil {
+		return m.Arg3
+	}
+	return 0
+}
+
+func (m *SyscallEvent) GetArg4() uint64 {
+	if m != nil {
+		return m.Arg4
+	}
+	return 0
+}
+
+func (m *SyscallEvent) GetArg5() uint64 {
+	if m != nil {
+		return m.Arg5
+	}
+	return 0
+}
+
+func (m *SyscallEvent) GetRet() int64 {
+	if m != nil {
+		return m.Ret
+	}
+	return 0
+}
+
+func (m *SyscallEvent) GetDurationNanos() int64 {
+	if m != nil {
+		return m.DurationNanos
+	}
+	return 0
+}
+
+func (m *SyscallEvent) GetPartial() bool {
+	if m != nil {
+		return m.Partial
+	}
+	return false
+}
+
+func (m *SyscallEvent) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *SyscallEvent) GetCredentialsBefore() *Credentials {
+	if m != nil {
+		return m.CredentialsBefore
+	}
+	return nil
+}
+
+func (m *SyscallEvent) GetCredentialsAfter() *Credentials {
+	if m != nil {
+		return m.CredentialsAfter
+	}
+	return nil
+}
+
+func (m *SyscallEvent) GetParentPid() int32 {
+	if m != nil {
+		return m.ParentPid
+	}
+	return 0
+}
+
+func (m *SyscallEvent) GetChildPid() int32 {
+	if m != nil {
+		return m.ChildPid
+	}
+	return 0
+}
+
+func (m *SyscallEvent) GetDistributionArg() uint32 {
+	if m != nil {
+		return m.DistributionArg
+	}
+	return 0
+}
+
+func (m *SyscallEvent) GetDistributionTotal() uint64 {
 	if m != nil {
-		return m.ImageName
+		return m.DistributionTotal
 	}
-	return ""
+	return 0
 }
 
-func (m *ContainerEvent) GetHostPid() int32 {
+func (m *SyscallEvent) GetDistribution() []*SyscallArgValueCount {
 	if m != nil {
-		return m.HostPid
+		return m.Distribution
 	}
-	return 0
+	return nil
 }
 
-func (m *ContainerEvent) GetExitCode() int32 {
+func (m *SyscallEvent) GetPtraceRequest() string {
 	if m != nil {
-		return m.ExitCode
+		return m.PtraceRequest
 	}
-	return 0
+	return ""
 }
 
-func (m *ContainerEvent) GetExitStatus() uint32 {
+func (m *SyscallEvent) GetPtraceTargetPid() int32 {
 	if m != nil {
-		return m.ExitStatus
+		return m.PtraceTargetPid
 	}
 	return 0
 }
 
-func (m *ContainerEvent) GetExitSignal() uint32 {
+func (m *SyscallEvent) GetPtraceTargetCommand() string {
 	if m != nil {
-		return m.ExitSignal
+		return m.PtraceTargetCommand
 	}
-	return 0
+	return ""
 }
 
-func (m *ContainerEvent) GetExitCoreDumped() bool {
+func (m *SyscallEvent) GetProt() string {
 	if m != nil {
-		return m.ExitCoreDumped
+		return m.Prot
+	}
+	return ""
+}
+
+func (m *SyscallEvent) GetWritableAndExecutable() bool {
+	if m != nil {
+		return m.WritableAndExecutable
 	}
 	return false
 }
 
-func (m *ContainerEvent) GetDockerConfigJson() string {
+func (m *SyscallEvent) GetMmapFlags() string {
 	if m != nil {
-		return m.DockerConfigJson
+		return m.MmapFlags
 	}
 	return ""
 }
 
-func (m *ContainerEvent) GetOciConfigJson() string {
+func (m *SyscallEvent) GetPolicyArgMask() uint32 {
 	if m != nil {
-		return m.OciConfigJson
+		return m.PolicyArgMask
+	}
+	return 0
+}
+
+func (m *SyscallEvent) GetPath() string {
+	if m != nil {
+		return m.Path
 	}
 	return ""
 }
 
-// ProcessEvent describes an event that occurred related to processes starting
-// and exiting as detected by the Sensor.
-type ProcessEvent struct {
-	// The type of event described by this ProcessEvent message
-	Type ProcessEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.ProcessEventType" json:"type,omitempty"`
-	// Present when the event is a fork event. This is the PID of the
-	// new child process.
-	ForkChildPid int32 `protobuf:"zigzag32,10,opt,name=fork_child_pid,json=forkChildPid" json:"fork_child_pid,omitempty"`
-	// Present when the event is a fork event. This is the Sensor's process
-	// ID of the new child process.
-	ForkChildId string `protobuf:"bytes,11,opt,name=fork_child_id,json=forkChildId" json:"fork_child_id,omitempty"`
-	// Present when the event is an exec event. This is the filename of the
-	// executable that was executed.
-	ExecFilename string `protobuf:"bytes,20,opt,name=exec_filename,json=execFilename" json:"exec_filename,omitempty"`
-	// Present when the event is an exec event. Repeated for each argument
-	// passed to the executable on the command-line.
-	ExecCommandLine []string `protobuf:"bytes,21,rep,name=exec_command_line,json=execCommandLine" json:"exec_command_line,omitempty"`
-	// Present when the event is an exit event. This is the exit code that
-	// the process exited with.
-	ExitCode int32 `protobuf:"zigzag32,30,opt,name=exit_code,json=exitCode" json:"exit_code,omitempty"`
-	// Present when the event is an exit event. This will typically be one9
-	// of the values defined in stdlib.h like EXIT_SUCCESS, EXIT_FAILURE,
-	// or EXIT_USAGE.
-	ExitStatus uint32 `protobuf:"varint,31,opt,name=exit_status,json=exitStatus" json:"exit_status,omitempty"`
-	// Present when the event is an exit event. If non-zero, this is the
-	// signal number that the process was terminated with.
-	ExitSignal uint32 `protobuf:"varint,32,opt,name=exit_signal,json=exitSignal" json:"exit_signal,omitempty"`
-	// Present when the event is an exit event. If true, indicates that the
-	// process dumped a core when it terminated.
-	ExitCoreDumped bool `protobuf:"varint,33,opt,name=exit_core_dumped,json=exitCoreDumped" json:"exit_core_dumped,omitempty"`
-	// Present when the event is an update event that informs of an update
-	// to the process's current working directory.
-	UpdateCwd string `protobuf:"bytes,40,opt,name=update_cwd,json=updateCwd" json:"update_cwd,omitempty"`
+func (m *SyscallEvent) GetNewPath() string {
+	if m != nil {
+		return m.NewPath
+	}
+	return ""
 }
 
-func (m *ProcessEvent) Reset()                    { *m = ProcessEvent{} }
-func (m *ProcessEvent) String() string            { return proto.CompactTextString(m) }
-func (*ProcessEvent) ProtoMessage()               {}
-func (*ProcessEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
+func (m *SyscallEvent) GetPathRelative() bool {
+	if m != nil {
+		return m.PathRelative
+	}
+	return false
+}
 
-func (m *ProcessEvent) GetType() ProcessEventType {
+func (m *SyscallEvent) GetNewPathRelative() bool {
 	if m != nil {
-		return m.Type
+		return m.NewPathRelative
 	}
-	return ProcessEventType_PROCESS_EVENT_TYPE_UNKNOWN
+	return false
 }
 
-func (m *ProcessEvent) GetForkChildPid() int32 {
+func (m *SyscallEvent) GetArgStatus() []SyscallArgStatus {
 	if m != nil {
-		return m.ForkChildPid
+		return m.ArgStatus
 	}
-	return 0
+	return nil
 }
 
-func (m *ProcessEvent) GetForkChildId() string {
+func (m *SyscallEvent) GetPathStatus() SyscallArgStatus {
 	if m != nil {
-		return m.ForkChildId
+		return m.PathStatus
 	}
-	return ""
+	return SyscallArgStatus_SYSCALL_ARG_STATUS_NOT_REQUESTED
 }
 
-func (m *ProcessEvent) GetExecFilename() string {
+func (m *SyscallEvent) GetNewPathStatus() SyscallArgStatus {
 	if m != nil {
-		return m.ExecFilename
+		return m.NewPathStatus
+	}
+	return SyscallArgStatus_SYSCALL_ARG_STATUS_NOT_REQUESTED
+}
+
+func (m *SyscallEvent) GetBpfCommand() string {
+	if m != nil {
+		return m.BpfCommand
 	}
 	return ""
 }
 
-func (m *ProcessEvent) GetExecCommandLine() []string {
+func (m *SyscallEvent) GetPerfEventType() string {
 	if m != nil {
-		return m.ExecCommandLine
+		return m.PerfEventType
 	}
-	return nil
+	return ""
 }
 
-func (m *ProcessEvent) GetExitCode() int32 {
+func (m *SyscallEvent) GetPerfEventConfig() uint64 {
 	if m != nil {
-		return m.ExitCode
+		return m.PerfEventConfig
 	}
 	return 0
 }
 
-func (m *ProcessEvent) GetExitStatus() uint32 {
+func (m *SyscallEvent) GetPerfEventTargetPid() int32 {
 	if m != nil {
-		return m.ExitStatus
+		return m.PerfEventTargetPid
 	}
 	return 0
 }
 
-func (m *ProcessEvent) GetExitSignal() uint32 {
+func (m *SyscallEvent) GetPerfEventTargetCommand() string {
 	if m != nil {
-		return m.ExitSignal
+		return m.PerfEventTargetCommand
+	}
+	return ""
+}
+
+func (m *SyscallEvent) GetPerfEventCpu() int32 {
+	if m != nil {
+		return m.PerfEventCpu
 	}
 	return 0
 }
 
-func (m *ProcessEvent) GetExitCoreDumped() bool {
+func (m *SyscallEvent) GetCallerCommand() string {
 	if m != nil {
-		return m.ExitCoreDumped
+		return m.CallerCommand
 	}
-	return false
+	return ""
 }
 
-func (m *ProcessEvent) GetUpdateCwd() string {
+func (m *SyscallEvent) GetCallerExecutable() string {
 	if m != nil {
-		return m.UpdateCwd
+		return m.CallerExecutable
 	}
 	return ""
 }
 
-// SyscallEvent describes an event that occurred related to system calls being
-// made or returning as detected by the Sensor.
-type SyscallEvent struct {
-	// The type of event described by this SyscallEvent message
-	Type SyscallEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.SyscallEventType" json:"type,omitempty"`
-	// The syscall number for either enter or exit events.
-	Id int64 `protobuf:"varint,2,opt,name=id" json:"id,omitempty"`
-	// Present when the event is an enter event. This is the first
-	// argument passed to the system call.
-	Arg0 uint64 `protobuf:"varint,10,opt,name=arg0" json:"arg0,omitempty"`
-	// Present when the event is an enter event. This is the second
-	// argument passed to the system call.
-	Arg1 uint64 `protobuf:"varint,11,opt,name=arg1" json:"arg1,omitempty"`
-	// Present when the event is an enter event. This is the third
-	// argument passed to the system call.
-	Arg2 uint64 `protobuf:"varint,12,opt,name=arg2" json:"arg2,omitempty"`
-	// Present when the event is an enter event. This is the fourth
-	// argument passed to the system call.
-	Arg3 uint64 `protobuf:"varint,13,opt,name=arg3" json:"arg3,omitempty"`
-	// Present when the event is an enter event. This is the fifth
-	// argument passed to the system call.
-	Arg4 uint64 `protobuf:"varint,14,opt,name=arg4" json:"arg4,omitempty"`
-	// Present when the event is an enter event. This is the sixth
-	// argument passed to the system call.
-	Arg5 uint64 `protobuf:"varint,15,opt,name=arg5" json:"arg5,omitempty"`
-	// Present when the event is an exit event. This is the value that was
-	// returned from the system call.
-	Ret int64 `protobuf:"varint,20,opt,name=ret" json:"ret,omitempty"`
+func (m *SyscallEvent) GetMemfdName() string {
+	if m != nil {
+		return m.MemfdName
+	}
+	return ""
 }
 
-func (m *SyscallEvent) Reset()                    { *m = SyscallEvent{} }
-func (m *SyscallEvent) String() string            { return proto.CompactTextString(m) }
-func (*SyscallEvent) ProtoMessage()               {}
-func (*SyscallEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
+func (m *SyscallEvent) GetNamespaceTypes() string {
+	if m != nil {
+		return m.NamespaceTypes
+	}
+	return ""
+}
 
-func (m *SyscallEvent) GetType() SyscallEventType {
+func (m *SyscallEvent) GetNamespaceTransitions() []*NamespaceTransition {
 	if m != nil {
-		return m.Type
+		return m.NamespaceTransitions
 	}
-	return SyscallEventType_SYSCALL_EVENT_TYPE_UNKNOWN
+	return nil
 }
 
-func (m *SyscallEvent) GetId() int64 {
+func (m *SyscallEvent) GetNamespacesUnresolved() bool {
 	if m != nil {
-		return m.Id
+		return m.NamespacesUnresolved
 	}
-	return 0
+	return false
 }
 
-func (m *SyscallEvent) GetArg0() uint64 {
+func (m *SyscallEvent) GetKeyctlOperation() string {
 	if m != nil {
-		return m.Arg0
+		return m.KeyctlOperation
 	}
-	return 0
+	return ""
 }
 
-func (m *SyscallEvent) GetArg1() uint64 {
+func (m *SyscallEvent) GetKeyType() string {
 	if m != nil {
-		return m.Arg1
+		return m.KeyType
 	}
-	return 0
+	return ""
 }
 
-func (m *SyscallEvent) GetArg2() uint64 {
+func (m *SyscallEvent) GetKeyDescription() string {
 	if m != nil {
-		return m.Arg2
+		return m.KeyDescription
 	}
-	return 0
+	return ""
 }
 
-func (m *SyscallEvent) GetArg3() uint64 {
+// NamespaceTransition describes a task leaving one namespace for another.
+type NamespaceTransition struct {
+	// The type of the namespace, as named in /proc/[pid]/ns, e.g. "net"
+	Type string `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	// The inode numbers identifying the namespaces before and after the
+	// change
+	InodeBefore uint64 `protobuf:"varint,2,opt,name=inode_before,json=inodeBefore" json:"inode_before,omitempty"`
+	InodeAfter  uint64 `protobuf:"varint,3,opt,name=inode_after,json=inodeAfter" json:"inode_after,omitempty"`
+}
+
+func (m *NamespaceTransition) Reset()                    { *m = NamespaceTransition{} }
+func (m *NamespaceTransition) String() string            { return proto.CompactTextString(m) }
+func (*NamespaceTransition) ProtoMessage()               {}
+func (*NamespaceTransition) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{15} }
+
+func (m *NamespaceTransition) GetType() string {
 	if m != nil {
-		return m.Arg3
+		return m.Type
+	}
+	return ""
+}
+
+func (m *NamespaceTransition) GetInodeBefore() uint64 {
+	if m != nil {
+		return m.InodeBefore
 	}
 	return 0
 }
 
-func (m *SyscallEvent) GetArg4() uint64 {
+func (m *NamespaceTransition) GetInodeAfter() uint64 {
 	if m != nil {
-		return m.Arg4
+		return m.InodeAfter
 	}
 	return 0
 }
 
-func (m *SyscallEvent) GetArg5() uint64 {
+// SyscallArgValueCount is the number of times that a system call argument
+// value was seen during an argument distribution interval.
+type SyscallArgValueCount struct {
+	Value uint64 `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
+	Count uint64 `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *SyscallArgValueCount) Reset()                    { *m = SyscallArgValueCount{} }
+func (m *SyscallArgValueCount) String() string            { return proto.CompactTextString(m) }
+func (*SyscallArgValueCount) ProtoMessage()               {}
+func (*SyscallArgValueCount) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{16} }
+
+func (m *SyscallArgValueCount) GetValue() uint64 {
 	if m != nil {
-		return m.Arg5
+		return m.Value
 	}
 	return 0
 }
 
-func (m *SyscallEvent) GetRet() int64 {
+func (m *SyscallArgValueCount) GetCount() uint64 {
 	if m != nil {
-		return m.Ret
+		return m.Count
 	}
 	return 0
 }
@@ -1203,7 +2613,7 @@ type FileEvent struct {
 func (m *FileEvent) Reset()                    { *m = FileEvent{} }
 func (m *FileEvent) String() string            { return proto.CompactTextString(m) }
 func (*FileEvent) ProtoMessage()               {}
-func (*FileEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
+func (*FileEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{17} }
 
 func (m *FileEvent) GetType() FileEventType {
 	if m != nil {
@@ -1233,6 +2643,125 @@ func (m *FileEvent) GetOpenMode() int32 {
 	return 0
 }
 
+// SignalEvent describes a signal being sent or delivered, as detected by the
+// Sensor.
+type SignalEvent struct {
+	// The type of event described by this SignalEvent message
+	Type SignalEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.SignalEventType" json:"type,omitempty"`
+	// The signal number and its name (e.g. "SIGKILL", or "SIGRTMIN+3").
+	// The name may be used in filters as signal_name.
+	Signal     int32  `protobuf:"varint,2,opt,name=signal" json:"signal,omitempty"`
+	SignalName string `protobuf:"bytes,3,opt,name=signal_name,json=signalName" json:"signal_name,omitempty"`
+	// The signal's si_code and si_errno
+	Code  int32 `protobuf:"varint,4,opt,name=code" json:"code,omitempty"`
+	Errno int32 `protobuf:"varint,5,opt,name=errno" json:"errno,omitempty"`
+	// Present when the event is a generate event. These are the pid
+	// of the sending task, the pid and command of the target task,
+	// whether the signal was sent to the target's thread group rather
+	// than the thread, and the result reported by the kernel (0 if the
+	// signal was queued for delivery, 1 if ignored, 2 if already
+	// pending, 3 if queueing failed, or 4 if its information was lost).
+	SenderPid     int32  `protobuf:"varint,10,opt,name=sender_pid,json=senderPid" json:"sender_pid,omitempty"`
+	TargetPid     int32  `protobuf:"varint,11,opt,name=target_pid,json=targetPid" json:"target_pid,omitempty"`
+	TargetCommand string `protobuf:"bytes,12,opt,name=target_command,json=targetCommand" json:"target_command,omitempty"`
+	Group         bool   `protobuf:"varint,13,opt,name=group" json:"group,omitempty"`
+	Result        int32  `protobuf:"varint,14,opt,name=result" json:"result,omitempty"`
+	// Present when the event is a deliver event. These are the address
+	// of the target's handler for the signal (0 for the default action
+	// and 1 if ignored) and the handler's sigaction flags.
+	SaHandler uint64 `protobuf:"varint,20,opt,name=sa_handler,json=saHandler" json:"sa_handler,omitempty"`
+	SaFlags   uint64 `protobuf:"varint,21,opt,name=sa_flags,json=saFlags" json:"sa_flags,omitempty"`
+}
+
+func (m *SignalEvent) Reset()                    { *m = SignalEvent{} }
+func (m *SignalEvent) String() string            { return proto.CompactTextString(m) }
+func (*SignalEvent) ProtoMessage()               {}
+func (*SignalEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{18} }
+
+func (m *SignalEvent) GetType() SignalEventType {
+	if m != nil {
+		return m.Type
+	}
+	return SignalEventType_SIGNAL_EVENT_TYPE_UNKNOWN
+}
+
+func (m *SignalEvent) GetSignal() int32 {
+	if m != nil {
+		return m.Signal
+	}
+	return 0
+}
+
+func (m *SignalEvent) GetSignalName() string {
+	if m != nil {
+		return m.SignalName
+	}
+	return ""
+}
+
+func (m *SignalEvent) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *SignalEvent) GetErrno() int32 {
+	if m != nil {
+		return m.Errno
+	}
+	return 0
+}
+
+func (m *SignalEvent) GetSenderPid() int32 {
+	if m != nil {
+		return m.SenderPid
+	}
+	return 0
+}
+
+func (m *SignalEvent) GetTargetPid() int32 {
+	if m != nil {
+		return m.TargetPid
+	}
+	return 0
+}
+
+func (m *SignalEvent) GetTargetCommand() string {
+	if m != nil {
+		return m.TargetCommand
+	}
+	return ""
+}
+
+func (m *SignalEvent) GetGroup() bool {
+	if m != nil {
+		return m.Group
+	}
+	return false
+}
+
+func (m *SignalEvent) GetResult() int32 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+func (m *SignalEvent) GetSaHandler() uint64 {
+	if m != nil {
+		return m.SaHandler
+	}
+	return 0
+}
+
+func (m *SignalEvent) GetSaFlags() uint64 {
+	if m != nil {
+		return m.SaFlags
+	}
+	return 0
+}
+
 type Process struct {
 	Pid     int32  `protobuf:"zigzag32,1,opt,name=pid" json:"pid,omitempty"`
 	Command string `protobuf:"bytes,2,opt,name=command" json:"command,omitempty"`
@@ -1241,7 +2770,7 @@ type Process struct {
 func (m *Process) Reset()                    { *m = Process{} }
 func (m *Process) String() string            { return proto.CompactTextString(m) }
 func (*Process) ProtoMessage()               {}
-func (*Process) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{7} }
+func (*Process) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{19} }
 
 func (m *Process) GetPid() int32 {
 	if m != nil {
@@ -1270,7 +2799,7 @@ type KernelFunctionCallEvent struct {
 func (m *KernelFunctionCallEvent) Reset()                    { *m = KernelFunctionCallEvent{} }
 func (m *KernelFunctionCallEvent) String() string            { return proto.CompactTextString(m) }
 func (*KernelFunctionCallEvent) ProtoMessage()               {}
-func (*KernelFunctionCallEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{8} }
+func (*KernelFunctionCallEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{20} }
 
 func (m *KernelFunctionCallEvent) GetArguments() map[string]*KernelFunctionCallEvent_FieldValue {
 	if m != nil {
@@ -1296,7 +2825,7 @@ func (m *KernelFunctionCallEvent_FieldValue) Reset()         { *m = KernelFuncti
 func (m *KernelFunctionCallEvent_FieldValue) String() string { return proto.CompactTextString(m) }
 func (*KernelFunctionCallEvent_FieldValue) ProtoMessage()    {}
 func (*KernelFunctionCallEvent_FieldValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor1, []int{8, 0}
+	return fileDescriptor1, []int{20, 0}
 }
 
 type isKernelFunctionCallEvent_FieldValue_Value interface {
@@ -1469,7 +2998,10 @@ type NetworkEvent struct {
 	Sockfd uint64 `protobuf:"varint,10,opt,name=sockfd" json:"sockfd,omitempty"`
 	// Present when the event describes a network event that is an attempt
 	// to perform a network related action that includes an address. This
-	// is that address.
+	// is that address. Also present when the event is the successful
+	// result of accepting an IPv4 or IPv6 connection, in which case it
+	// is the address of the peer. The peer of an accepted AF_UNIX
+	// connection is not reported.
 	Address *NetworkAddress `protobuf:"bytes,11,opt,name=address" json:"address,omitempty"`
 	// Present when the event describes a network event that is the result
 	// of an attempted network related action. This is the return code from
@@ -1483,7 +3015,7 @@ type NetworkEvent struct {
 func (m *NetworkEvent) Reset()                    { *m = NetworkEvent{} }
 func (m *NetworkEvent) String() string            { return proto.CompactTextString(m) }
 func (*NetworkEvent) ProtoMessage()               {}
-func (*NetworkEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{9} }
+func (*NetworkEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{21} }
 
 func (m *NetworkEvent) GetType() NetworkEventType {
 	if m != nil {
@@ -1535,7 +3067,7 @@ type PerformanceEventValue struct {
 func (m *PerformanceEventValue) Reset()                    { *m = PerformanceEventValue{} }
 func (m *PerformanceEventValue) String() string            { return proto.CompactTextString(m) }
 func (*PerformanceEventValue) ProtoMessage()               {}
-func (*PerformanceEventValue) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{10} }
+func (*PerformanceEventValue) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{22} }
 
 func (m *PerformanceEventValue) GetType() PerformanceEventType {
 	if m != nil {
@@ -1577,7 +3109,7 @@ type PerformanceEvent struct {
 func (m *PerformanceEvent) Reset()                    { *m = PerformanceEvent{} }
 func (m *PerformanceEvent) String() string            { return proto.CompactTextString(m) }
 func (*PerformanceEvent) ProtoMessage()               {}
-func (*PerformanceEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{11} }
+func (*PerformanceEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{23} }
 
 func (m *PerformanceEvent) GetTotalTimeEnabled() uint64 {
 	if m != nil {
@@ -1602,22 +3134,37 @@ func (m *PerformanceEvent) GetValues() []*PerformanceEventValue {
 
 func init() {
 	proto.RegisterType((*TelemetryEvent)(nil), "capsule8.api.v0.TelemetryEvent")
+	proto.RegisterType((*MemoryAccess)(nil), "capsule8.api.v0.MemoryAccess")
+	proto.RegisterType((*QuietPeriodEvent)(nil), "capsule8.api.v0.QuietPeriodEvent")
+	proto.RegisterType((*FilelessExecutionEvent)(nil), "capsule8.api.v0.FilelessExecutionEvent")
+	proto.RegisterType((*SubscriptionStatsEvent)(nil), "capsule8.api.v0.SubscriptionStatsEvent")
+	proto.RegisterType((*ContainerSampleRate)(nil), "capsule8.api.v0.ContainerSampleRate")
+	proto.RegisterType((*DecodeErrorEvent)(nil), "capsule8.api.v0.DecodeErrorEvent")
+	proto.RegisterType((*AnyTelemetryEvent)(nil), "capsule8.api.v0.AnyTelemetryEvent")
 	proto.RegisterType((*ChargenEvent)(nil), "capsule8.api.v0.ChargenEvent")
 	proto.RegisterType((*TickerEvent)(nil), "capsule8.api.v0.TickerEvent")
 	proto.RegisterType((*ContainerEvent)(nil), "capsule8.api.v0.ContainerEvent")
 	proto.RegisterType((*ProcessEvent)(nil), "capsule8.api.v0.ProcessEvent")
+	proto.RegisterType((*ProcessResourceUsage)(nil), "capsule8.api.v0.ProcessResourceUsage")
+	proto.RegisterType((*FileDescriptor)(nil), "capsule8.api.v0.FileDescriptor")
 	proto.RegisterType((*SyscallEvent)(nil), "capsule8.api.v0.SyscallEvent")
+	proto.RegisterType((*NamespaceTransition)(nil), "capsule8.api.v0.NamespaceTransition")
+	proto.RegisterType((*SyscallArgValueCount)(nil), "capsule8.api.v0.SyscallArgValueCount")
 	proto.RegisterType((*FileEvent)(nil), "capsule8.api.v0.FileEvent")
+	proto.RegisterType((*SignalEvent)(nil), "capsule8.api.v0.SignalEvent")
 	proto.RegisterType((*Process)(nil), "capsule8.api.v0.Process")
 	proto.RegisterType((*KernelFunctionCallEvent)(nil), "capsule8.api.v0.KernelFunctionCallEvent")
 	proto.RegisterType((*KernelFunctionCallEvent_FieldValue)(nil), "capsule8.api.v0.KernelFunctionCallEvent.FieldValue")
 	proto.RegisterType((*NetworkEvent)(nil), "capsule8.api.v0.NetworkEvent")
 	proto.RegisterType((*PerformanceEventValue)(nil), "capsule8.api.v0.PerformanceEventValue")
 	proto.RegisterType((*PerformanceEvent)(nil), "capsule8.api.v0.PerformanceEvent")
+	proto.RegisterEnum("capsule8.api.v0.EdgeTransition", EdgeTransition_name, EdgeTransition_value)
 	proto.RegisterEnum("capsule8.api.v0.ContainerEventType", ContainerEventType_name, ContainerEventType_value)
 	proto.RegisterEnum("capsule8.api.v0.ProcessEventType", ProcessEventType_name, ProcessEventType_value)
 	proto.RegisterEnum("capsule8.api.v0.SyscallEventType", SyscallEventType_name, SyscallEventType_value)
+	proto.RegisterEnum("capsule8.api.v0.SyscallArgStatus", SyscallArgStatus_name, SyscallArgStatus_value)
 	proto.RegisterEnum("capsule8.api.v0.FileEventType", FileEventType_name, FileEventType_value)
+	proto.RegisterEnum("capsule8.api.v0.SignalEventType", SignalEventType_name, SignalEventType_value)
 	proto.RegisterEnum("capsule8.api.v0.KernelFunctionCallEventType", KernelFunctionCallEventType_name, KernelFunctionCallEventType_value)
 	proto.RegisterEnum("capsule8.api.v0.NetworkEventType", NetworkEventType_name, NetworkEventType_value)
 	proto.RegisterEnum("capsule8.api.v0.PerformanceEventType", PerformanceEventType_name, PerformanceEventType_value)
@@ -1627,129 +3174,261 @@ func init() {
 func init() { proto.RegisterFile("capsule8/api/v0/telemetry_event.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 1972 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0x3f, 0x77, 0xdb, 0xc8,
-	0x11, 0x37, 0x44, 0x4a, 0x22, 0x87, 0x14, 0x0d, 0x6d, 0xe4, 0x3b, 0x58, 0xb2, 0x2d, 0x8a, 0xf2,
-	0x1f, 0x46, 0xc9, 0x93, 0x6d, 0xca, 0xf6, 0xf9, 0x52, 0xe4, 0x1e, 0x0d, 0x81, 0x31, 0x4f, 0x32,
-	0xa8, 0x2c, 0x21, 0xfb, 0x5c, 0xe1, 0x41, 0xc0, 0x8a, 0x46, 0x44, 0x02, 0x3c, 0x00, 0xb4, 0xad,
-	0x2e, 0x2f, 0x55, 0x9a, 0x14, 0xa9, 0x52, 0xa6, 0x4d, 0x95, 0x7c, 0x8d, 0xdc, 0xe5, 0x43, 0xe4,
-	0xe5, 0x13, 0xa4, 0x49, 0x9d, 0x97, 0xb7, 0xb3, 0x0b, 0xfe, 0x91, 0x08, 0xeb, 0xd2, 0x5d, 0xb7,
-	0xfb, 0x9b, 0xdf, 0x0c, 0x66, 0x76, 0x66, 0x67, 0x87, 0x84, 0x7b, 0xae, 0x33, 0x8c, 0x47, 0x7d,
-	0xf6, 0xfc, 0xa1, 0x33, 0xf4, 0x1f, 0xbe, 0x7f, 0xf4, 0x30, 0x61, 0x7d, 0x36, 0x60, 0x49, 0x74,
-	0x6e, 0xb3, 0xf7, 0x2c, 0x48, 0x76, 0x87, 0x51, 0x98, 0x84, 0xe4, 0x7a, 0x4a, 0xdb, 0x75, 0x86,
-	0xfe, 0xee, 0xfb, 0x47, 0xeb, 0x1b, 0x97, 0xf4, 0xce, 0x87, 0x2c, 0x16, 0xec, 0xda, 0xbf, 0x0b,
-	0x50, 0xb1, 0x52, 0x3b, 0x06, 0x37, 0x43, 0x2a, 0xb0, 0xe0, 0x7b, 0x9a, 0x52, 0x55, 0xea, 0x45,
-	0xba, 0xe0, 0x7b, 0xe4, 0x36, 0xc0, 0x30, 0x0a, 0x5d, 0x16, 0xc7, 0xb6, 0xef, 0x69, 0x0b, 0x88,
-	0x17, 0x25, 0xd2, 0xf6, 0xc8, 0x26, 0x94, 0x52, 0xf1, 0xd0, 0xf7, 0xb4, 0x5c, 0x55, 0xa9, 0x2f,
-	0xd2, 0x54, 0xe3, 0xc8, 0xf7, 0xc8, 0x16, 0x94, 0xdd, 0x30, 0x48, 0x1c, 0x3f, 0x60, 0x11, 0xb7,
-	0x90, 0x47, 0x0b, 0xa5, 0x31, 0xd6, 0xf6, 0xc8, 0x06, 0x14, 0x63, 0x16, 0xc4, 0x21, 0xca, 0x17,
-	0x51, 0x5e, 0x10, 0x40, 0xdb, 0x23, 0x4f, 0xe0, 0x33, 0x29, 0x8c, 0xd9, 0xb7, 0x23, 0x16, 0xb8,
-	0xcc, 0x0e, 0x46, 0x83, 0x13, 0x16, 0x69, 0x4b, 0x55, 0xa5, 0x9e, 0xa7, 0x6b, 0x42, 0xda, 0x95,
-	0x42, 0x13, 0x65, 0xa4, 0x01, 0x37, 0xa4, 0xd6, 0x20, 0x0c, 0xc2, 0xc4, 0x1f, 0x30, 0x3b, 0x70,
-	0x82, 0x30, 0xd6, 0x96, 0xab, 0x4a, 0x3d, 0x47, 0x7f, 0x22, 0x84, 0xaf, 0xa4, 0xcc, 0xe4, 0x22,
-	0xd2, 0x84, 0xeb, 0x69, 0x28, 0x7d, 0x3f, 0x60, 0x4e, 0x8f, 0x69, 0x85, 0x6a, 0xae, 0x5e, 0x6a,
-	0x68, 0xbb, 0x17, 0x0e, 0x75, 0xf7, 0x48, 0xf0, 0x68, 0x45, 0x2a, 0x1c, 0x0a, 0x3e, 0xb9, 0x07,
-	0x95, 0x49, 0xb0, 0x81, 0x33, 0x60, 0xda, 0x1d, 0x0c, 0x67, 0x65, 0x8c, 0x9a, 0xce, 0x80, 0x91,
-	0x9b, 0x50, 0xf0, 0x07, 0x4e, 0x8f, 0xf1, 0x78, 0x37, 0x91, 0xb0, 0x8c, 0xfb, 0x36, 0x1e, 0xb7,
-	0x10, 0xa1, 0x76, 0x55, 0x1c, 0x37, 0x22, 0xa8, 0xf9, 0x25, 0x2c, 0xc7, 0xe7, 0xb1, 0xeb, 0xf4,
-	0xfb, 0x1a, 0x54, 0x95, 0x7a, 0xa9, 0x71, 0xfb, 0x92, 0x6f, 0x5d, 0x21, 0xc7, 0x6c, 0xbe, 0xbc,
-	0x46, 0x53, 0x3e, 0x57, 0x95, 0xde, 0x6a, 0xa5, 0x0c, 0x55, 0x19, 0xd6, 0x58, 0x55, 0xf2, 0xc9,
-	0x23, 0xc8, 0x9f, 0xfa, 0x7d, 0xa6, 0x95, 0x51, 0x6f, 0xfd, 0x92, 0x5e, 0xcb, 0xef, 0xb3, 0x54,
-	0x09, 0x99, 0xe4, 0x00, 0x4a, 0x67, 0x2c, 0x0a, 0x58, 0xdf, 0x46, 0x5f, 0x57, 0x50, 0xb1, 0x7e,
-	0x49, 0xf1, 0x00, 0x39, 0xad, 0x51, 0xe0, 0x26, 0x7e, 0x18, 0xe8, 0x53, 0x6e, 0x83, 0x50, 0xd7,
-	0xa5, 0xe7, 0x01, 0x4b, 0x3e, 0x84, 0xd1, 0x99, 0x56, 0xc9, 0xf0, 0xdc, 0x14, 0xf2, 0xb1, 0xe7,
-	0x92, 0x4f, 0x0c, 0x28, 0x0d, 0x59, 0x74, 0x1a, 0x46, 0x03, 0x27, 0x70, 0x99, 0x76, 0x1d, 0xd5,
-	0xb7, 0x2e, 0x07, 0x3e, 0xe1, 0xa4, 0x26, 0xa6, 0xf5, 0xc8, 0x57, 0x50, 0x1c, 0x67, 0x50, 0x5b,
-	0x43, 0x23, 0x9b, 0x97, 0x8c, 0xe8, 0x29, 0x23, 0x35, 0x31, 0xd1, 0xe1, 0x21, 0xb8, 0xef, 0x9c,
-	0xa8, 0xc7, 0x02, 0xcd, 0xcb, 0x08, 0x41, 0x17, 0xf2, 0x71, 0x08, 0x92, 0x4f, 0x9e, 0xc1, 0x52,
-	0xe2, 0xbb, 0x67, 0x2c, 0xd2, 0x18, 0x6a, 0xde, 0xba, 0xa4, 0x69, 0xa1, 0x38, 0x55, 0x94, 0x6c,
-	0xb2, 0x0a, 0x39, 0x77, 0x38, 0xd2, 0xbe, 0x53, 0xf0, 0x4a, 0xf2, 0x35, 0xf9, 0x0a, 0x4a, 0x6e,
-	0xc4, 0x3c, 0x16, 0x24, 0xbe, 0xd3, 0x8f, 0xb5, 0xef, 0x95, 0x0c, 0x83, 0xfa, 0x84, 0x44, 0xa7,
-	0x35, 0x48, 0x0d, 0xca, 0xe9, 0x15, 0x49, 0x7a, 0xbe, 0xa7, 0xfd, 0x43, 0x18, 0x4f, 0x5b, 0x80,
-	0xd5, 0xf3, 0xbd, 0x17, 0xcb, 0xb0, 0x88, 0x0d, 0xe9, 0xeb, 0xa5, 0xc2, 0xdf, 0x15, 0xf5, 0x3b,
-	0x65, 0x2c, 0xb5, 0x13, 0xdf, 0xab, 0xed, 0x43, 0x79, 0x3a, 0x50, 0xb2, 0x06, 0x8b, 0x7e, 0xe0,
-	0xb1, 0x8f, 0xd8, 0x71, 0xf2, 0x54, 0x6c, 0xc8, 0x1d, 0x00, 0x1e, 0xbe, 0xe3, 0x26, 0x2c, 0x8a,
-	0x65, 0xd3, 0x99, 0x42, 0x6a, 0x6d, 0x28, 0x4d, 0x05, 0x4d, 0x34, 0x58, 0x8e, 0x99, 0x1b, 0x06,
-	0x5e, 0x8c, 0x66, 0x72, 0x34, 0xdd, 0x92, 0x2a, 0x94, 0xf0, 0xde, 0x4b, 0xe9, 0x02, 0x4a, 0xa7,
-	0xa1, 0xda, 0x1f, 0x73, 0x50, 0x99, 0xcd, 0x1c, 0xf9, 0x02, 0xf2, 0xbc, 0x49, 0xa2, 0xad, 0x4a,
-	0x63, 0xfb, 0x8a, 0x44, 0x5b, 0xe7, 0x43, 0x46, 0x51, 0x81, 0x10, 0xc8, 0xe3, 0xb5, 0x15, 0x0e,
-	0xe3, 0x7a, 0xe6, 0xae, 0xc3, 0xa7, 0xee, 0x7a, 0xe9, 0xe2, 0x5d, 0xbf, 0x09, 0x85, 0x77, 0x61,
-	0x9c, 0x60, 0x5f, 0xe5, 0x35, 0xb7, 0x4a, 0x97, 0xf9, 0x9e, 0x37, 0xd5, 0x0d, 0x28, 0xb2, 0x8f,
-	0x7e, 0x62, 0xbb, 0xa1, 0x27, 0x5a, 0xcc, 0x2a, 0x2d, 0x70, 0x40, 0x0f, 0x3d, 0xc6, 0x5b, 0x32,
-	0x0a, 0xe3, 0xc4, 0x49, 0x46, 0x31, 0x36, 0x98, 0x15, 0x0a, 0x1c, 0xea, 0x22, 0x32, 0x21, 0xf8,
-	0xbd, 0xc0, 0xe9, 0x63, 0x93, 0x49, 0x09, 0x88, 0x90, 0x3a, 0xa8, 0xd2, 0x7c, 0xc4, 0x6c, 0x6f,
-	0x34, 0x18, 0x32, 0x4f, 0xdb, 0xaa, 0x2a, 0xf5, 0x02, 0xad, 0x88, 0xaf, 0x44, 0x6c, 0x1f, 0x51,
-	0xf2, 0x73, 0x20, 0x5e, 0xc8, 0x13, 0x61, 0xbb, 0x61, 0x70, 0xea, 0xf7, 0xec, 0xdf, 0xc4, 0xa1,
-	0x28, 0xf1, 0x22, 0x55, 0x85, 0x44, 0x47, 0xc1, 0xd7, 0x71, 0x18, 0x90, 0xfb, 0x70, 0x3d, 0x74,
-	0xfd, 0x19, 0x2a, 0x13, 0xfd, 0x31, 0x74, 0xfd, 0x09, 0xaf, 0xf6, 0xfb, 0x1c, 0x94, 0xa7, 0x7b,
-	0x11, 0x79, 0x3a, 0x93, 0x91, 0xad, 0x4f, 0x36, 0xae, 0xa9, 0x7c, 0xdc, 0x85, 0xca, 0x69, 0x18,
-	0x9d, 0xd9, 0xee, 0x3b, 0xbf, 0xef, 0xe1, 0x39, 0x02, 0x9e, 0x55, 0x99, 0xa3, 0x3a, 0x07, 0xf9,
-	0x61, 0xd6, 0x60, 0x65, 0x8a, 0xe5, 0x7b, 0x32, 0x13, 0xa5, 0x31, 0xa9, 0xed, 0x91, 0x6d, 0x58,
-	0x61, 0x1f, 0x99, 0x6b, 0xf3, 0xe6, 0x86, 0xd9, 0x5a, 0x43, 0x4e, 0x99, 0x83, 0x2d, 0x89, 0x91,
-	0x1d, 0x58, 0x45, 0x92, 0x1b, 0x0e, 0x06, 0x4e, 0xe0, 0xe1, 0x2b, 0xa2, 0xdd, 0xa8, 0xe6, 0xea,
-	0x45, 0x7a, 0x9d, 0x0b, 0x74, 0x81, 0xf3, 0xc7, 0xe2, 0xc7, 0x93, 0xc1, 0xdb, 0x00, 0xa3, 0xa1,
-	0xe7, 0x24, 0xcc, 0x76, 0x3f, 0x78, 0x5a, 0x5d, 0x14, 0xa1, 0x40, 0xf4, 0x0f, 0x5e, 0xed, 0x9f,
-	0x0a, 0x94, 0xa7, 0x5f, 0x94, 0x2b, 0x53, 0x31, 0x4d, 0x9e, 0x4a, 0x85, 0x18, 0x2b, 0xc4, 0xfd,
-	0xe3, 0x63, 0x05, 0x81, 0xbc, 0x13, 0xf5, 0x1e, 0x61, 0x42, 0xf2, 0x14, 0xd7, 0x12, 0x7b, 0x8c,
-	0xe7, 0x2f, 0xb0, 0xc7, 0x12, 0x6b, 0xe0, 0xd3, 0x23, 0xb0, 0x86, 0xc4, 0xf6, 0xf0, 0x55, 0x11,
-	0xd8, 0x9e, 0xc4, 0x9e, 0xe0, 0x03, 0x21, 0xb0, 0x27, 0x12, 0x7b, 0x8a, 0x5d, 0x5f, 0x60, 0x4f,
-	0x89, 0x0a, 0xb9, 0x88, 0x25, 0x98, 0xbe, 0x1c, 0xe5, 0xcb, 0xda, 0x9f, 0x14, 0x28, 0x8e, 0x1f,
-	0x30, 0xd2, 0x98, 0x09, 0xef, 0x4e, 0xf6, 0x53, 0x37, 0x15, 0xdb, 0x3a, 0x14, 0xc6, 0x75, 0x21,
-	0xae, 0xf8, 0x78, 0xcf, 0x8f, 0x37, 0x1c, 0xb2, 0xc0, 0x3e, 0xed, 0x3b, 0x3d, 0xf1, 0xf0, 0xae,
-	0xd2, 0x22, 0x47, 0x5a, 0x1c, 0xe0, 0x65, 0x80, 0xe2, 0x01, 0x2f, 0x83, 0xb2, 0x28, 0x03, 0x0e,
-	0xbc, 0x0a, 0x3d, 0x56, 0x7b, 0x0a, 0xcb, 0xb2, 0xb0, 0xb9, 0xdb, 0x43, 0x39, 0x96, 0xad, 0x52,
-	0xbe, 0xe4, 0x3d, 0x4f, 0xd6, 0x99, 0x6c, 0x37, 0xe9, 0xb6, 0xf6, 0x9f, 0x3c, 0x7c, 0x9e, 0xf1,
-	0xb0, 0x92, 0x63, 0x28, 0x3a, 0x51, 0x6f, 0x34, 0x60, 0x41, 0xc2, 0x7b, 0x25, 0x9f, 0x6e, 0xbe,
-	0xf8, 0xa1, 0xaf, 0xf2, 0x6e, 0x33, 0xd5, 0x34, 0x82, 0x24, 0x3a, 0xa7, 0x13, 0x4b, 0xeb, 0xff,
-	0x55, 0x00, 0x5a, 0x3e, 0xeb, 0x7b, 0xaf, 0x9d, 0xfe, 0x88, 0x91, 0x5f, 0x03, 0x9c, 0xf2, 0x9d,
-	0x3d, 0x75, 0x94, 0x8d, 0x1f, 0xfc, 0x19, 0x34, 0x84, 0xc7, 0x5b, 0x3c, 0x4d, 0x97, 0x64, 0x0b,
-	0x4a, 0x27, 0xe7, 0x09, 0x8b, 0xed, 0xf7, 0xfc, 0x0b, 0x18, 0x72, 0x99, 0x8f, 0x09, 0x08, 0x8a,
-	0xaf, 0x6e, 0x43, 0x39, 0x4e, 0x22, 0x3f, 0xe8, 0x49, 0x0e, 0x9f, 0x45, 0x8b, 0xfc, 0x25, 0x17,
-	0xe8, 0x84, 0xe4, 0xf7, 0x02, 0xe6, 0x49, 0x12, 0x1f, 0x47, 0x09, 0x92, 0x10, 0x15, 0xa4, 0x07,
-	0x50, 0x19, 0x05, 0x33, 0x34, 0x3e, 0x95, 0xe6, 0x5f, 0x5e, 0xa3, 0x2b, 0x29, 0x8e, 0x44, 0xfe,
-	0xd6, 0xa1, 0x7c, 0xfd, 0x5b, 0xa8, 0xcc, 0x9e, 0x0e, 0xcf, 0xd8, 0x19, 0x3b, 0x97, 0x83, 0x34,
-	0x5f, 0x92, 0xb6, 0x24, 0xa3, 0xf3, 0xa5, 0xc6, 0xde, 0xff, 0x77, 0x20, 0xf8, 0x41, 0x2a, 0x2c,
-	0xfc, 0x62, 0xe1, 0xb9, 0x52, 0xfb, 0x03, 0xd6, 0x6d, 0x7a, 0x3e, 0x25, 0x58, 0x3e, 0x36, 0x0f,
-	0xcc, 0xce, 0x1b, 0x53, 0xbd, 0x46, 0x8a, 0xb0, 0xf8, 0xe2, 0xad, 0x65, 0x74, 0x55, 0x85, 0x00,
-	0x2c, 0x75, 0x2d, 0xda, 0x36, 0x7f, 0xa5, 0x2e, 0x70, 0xb8, 0xdb, 0x36, 0xad, 0xe7, 0x6a, 0x0e,
-	0xe1, 0xb6, 0x69, 0x3d, 0x7e, 0xa6, 0xe6, 0xd3, 0xf5, 0x5e, 0x43, 0x5d, 0x4c, 0xd7, 0xcf, 0x9e,
-	0xa8, 0x4b, 0x9c, 0x7e, 0x8c, 0xf4, 0x65, 0x0e, 0x1f, 0x0b, 0x7a, 0x21, 0x5d, 0xef, 0x35, 0xd4,
-	0x62, 0xba, 0x7e, 0xf6, 0x44, 0x85, 0xda, 0xf7, 0x0a, 0x94, 0xa7, 0xc7, 0xb0, 0x2b, 0x3b, 0xc5,
-	0x34, 0x79, 0xea, 0x36, 0x7d, 0x06, 0x4b, 0x71, 0xe8, 0x9e, 0x9d, 0x7a, 0xb2, 0x37, 0xc8, 0x1d,
-	0x1f, 0xa1, 0x1c, 0xcf, 0x8b, 0x26, 0xf3, 0xeb, 0x66, 0x96, 0xc5, 0xa6, 0xa0, 0xd1, 0x94, 0xcf,
-	0x4d, 0x46, 0x2c, 0x1e, 0xf5, 0x13, 0xbc, 0x62, 0x84, 0xca, 0x1d, 0xbf, 0x43, 0x27, 0x8e, 0x7b,
-	0xd6, 0x0f, 0x7b, 0xb2, 0x97, 0xa4, 0xdb, 0xda, 0x6f, 0x15, 0xb8, 0x71, 0x71, 0x28, 0x14, 0xb5,
-	0xf1, 0xe5, 0x4c, 0x54, 0xf7, 0xae, 0x1c, 0x25, 0x67, 0x23, 0x13, 0x4f, 0x1f, 0x56, 0x40, 0x9e,
-	0xca, 0x1d, 0x9f, 0x81, 0x26, 0x15, 0x9b, 0x97, 0x39, 0xae, 0xfd, 0x55, 0x01, 0xf5, 0xa2, 0x31,
-	0xfe, 0xde, 0x26, 0x61, 0xe2, 0xf4, 0x6d, 0xfc, 0x49, 0xc3, 0x02, 0xe7, 0xa4, 0xcf, 0x3c, 0x39,
-	0x3b, 0xa9, 0x28, 0xb1, 0xfc, 0x01, 0x33, 0x04, 0x7e, 0x81, 0x1d, 0x8d, 0x82, 0xc0, 0x0f, 0xd2,
-	0x8f, 0x4f, 0xd8, 0x54, 0xe0, 0xe4, 0x97, 0xb0, 0x84, 0x5f, 0x8e, 0xb5, 0x1c, 0x36, 0x86, 0xfb,
-	0x57, 0xc6, 0x26, 0x6a, 0x52, 0x6a, 0xed, 0xfc, 0x4b, 0x01, 0x72, 0x79, 0x34, 0x22, 0x55, 0xb8,
-	0xa5, 0x77, 0x4c, 0xab, 0xd9, 0x36, 0x0d, 0x6a, 0x1b, 0xaf, 0x0d, 0xd3, 0xb2, 0xad, 0xb7, 0x47,
-	0x86, 0x3d, 0x29, 0xd7, 0x2c, 0x86, 0x4e, 0x8d, 0xa6, 0x65, 0xec, 0xab, 0x4a, 0x26, 0x83, 0x1e,
-	0x9b, 0xa6, 0xa8, 0xed, 0x4d, 0xd8, 0x98, 0xcb, 0x30, 0xbe, 0x69, 0x73, 0x13, 0x39, 0x52, 0x83,
-	0x3b, 0x73, 0x09, 0xfb, 0x46, 0xd7, 0xa2, 0x9d, 0xb7, 0xc6, 0xbe, 0x9a, 0xcf, 0x76, 0xf5, 0x68,
-	0x1f, 0x1d, 0x59, 0xdc, 0xf9, 0x0b, 0x4f, 0xca, 0x85, 0x61, 0x83, 0xdc, 0x81, 0xf5, 0x23, 0xda,
-	0xd1, 0x8d, 0x6e, 0x77, 0x7e, 0x7c, 0x1b, 0xf0, 0xf9, 0x1c, 0x79, 0xab, 0x43, 0x0f, 0x54, 0x25,
-	0x43, 0x68, 0x7c, 0x63, 0xe8, 0xea, 0x42, 0xa6, 0xb0, 0x6d, 0xa9, 0x39, 0x72, 0x1b, 0x6e, 0xce,
-	0xfb, 0x2c, 0xfa, 0xaa, 0xe6, 0x77, 0x06, 0xa0, 0x5e, 0x7c, 0x8b, 0xb9, 0xa7, 0xdd, 0xb7, 0x5d,
-	0xbd, 0x79, 0x78, 0x38, 0xdf, 0xd3, 0x5b, 0xa0, 0xcd, 0x91, 0x1b, 0xa6, 0x65, 0x50, 0xe1, 0xea,
-	0x3c, 0x29, 0xf7, 0x66, 0x61, 0xa7, 0x05, 0x2b, 0x33, 0x6f, 0x23, 0x67, 0xb7, 0xda, 0x87, 0xc6,
-	0xfc, 0x0f, 0x69, 0xb0, 0x76, 0x51, 0xd8, 0x39, 0x32, 0x4c, 0x55, 0xd9, 0xf9, 0xb3, 0x02, 0x1b,
-	0x19, 0x8d, 0x10, 0xcd, 0xfe, 0x0c, 0x1e, 0x1c, 0x18, 0xd4, 0x34, 0x0e, 0xed, 0xd6, 0xb1, 0xa9,
-	0x5b, 0xed, 0x8e, 0x69, 0x67, 0xc7, 0xf3, 0x53, 0xb8, 0x77, 0x15, 0x39, 0x0d, 0xae, 0x0e, 0x77,
-	0xaf, 0xa4, 0x8a, 0x48, 0x7f, 0x97, 0x07, 0xf5, 0x62, 0xef, 0xe2, 0x27, 0x6b, 0x1a, 0xd6, 0x9b,
-	0x0e, 0x3d, 0x98, 0xef, 0xc9, 0x7d, 0xa8, 0xcd, 0x91, 0xeb, 0x1d, 0xd3, 0x34, 0x74, 0xcb, 0x6e,
-	0x5a, 0x96, 0xf1, 0xea, 0xc8, 0x52, 0x15, 0x72, 0x0f, 0xb6, 0x3e, 0xc1, 0xa3, 0x46, 0xf7, 0xf8,
-	0xd0, 0x52, 0x17, 0xc8, 0x36, 0x6c, 0xce, 0xa1, 0xbd, 0x68, 0x9b, 0xfb, 0x63, 0x5b, 0x58, 0xf2,
-	0x59, 0x24, 0x69, 0x28, 0x9f, 0xf1, 0xbd, 0xc3, 0x76, 0xd7, 0x32, 0xcc, 0xb1, 0xa9, 0x45, 0x72,
-	0x17, 0xaa, 0xd9, 0x34, 0x69, 0x6c, 0x29, 0xc3, 0x58, 0x53, 0xd7, 0x8d, 0xa3, 0x49, 0x8c, 0xcb,
-	0x19, 0xc6, 0x24, 0x4d, 0x1a, 0x2b, 0x64, 0x18, 0xeb, 0x1a, 0xe6, 0xbe, 0xd5, 0x19, 0x1b, 0x2b,
-	0x66, 0x18, 0x93, 0x34, 0x69, 0x0c, 0xc8, 0x03, 0xd8, 0x9e, 0xc3, 0xa2, 0x86, 0xfe, 0xba, 0x45,
-	0x3b, 0xaf, 0xc6, 0xe6, 0x4a, 0x19, 0x79, 0x1a, 0x13, 0xa5, 0xc1, 0xf2, 0xce, 0xdf, 0x14, 0x58,
-	0x9b, 0xd7, 0xea, 0xf9, 0xa1, 0x1f, 0x19, 0xb4, 0xd5, 0xa1, 0xaf, 0x9a, 0xa6, 0x9e, 0x51, 0xfd,
-	0xdb, 0xb0, 0x99, 0xc1, 0x79, 0xd9, 0xa4, 0xfb, 0x6f, 0x9a, 0xd4, 0x50, 0x15, 0x5e, 0xbb, 0x57,
-	0x90, 0x6c, 0xbd, 0xa9, 0xbf, 0x34, 0x44, 0x35, 0x64, 0x50, 0xbb, 0x9d, 0x96, 0x85, 0xf6, 0x72,
-	0x27, 0x4b, 0xf8, 0x97, 0xdf, 0xde, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0x17, 0x91, 0x9f, 0xbf,
-	0x49, 0x14, 0x00, 0x00,
+	// 4089 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x79, 0xcb, 0x77, 0xdb, 0xc8,
+	0x72, 0xb7, 0x21, 0x52, 0x12, 0x59, 0xa4, 0x28, 0xa8, 0x2d, 0xc9, 0xb0, 0xfc, 0x92, 0x69, 0x7b,
+	0x2c, 0x6b, 0xee, 0x95, 0x6d, 0xf9, 0x31, 0x8f, 0xef, 0x7c, 0xb9, 0xa1, 0x29, 0xc8, 0xe6, 0xb5,
+	0x4c, 0x69, 0x40, 0x6a, 0x66, 0x9c, 0x0d, 0x0e, 0x04, 0x34, 0x29, 0x8c, 0x40, 0x80, 0x03, 0x80,
+	0xd6, 0x30, 0xab, 0x7b, 0x6e, 0xb6, 0xc9, 0x22, 0xab, 0x2c, 0x93, 0x65, 0xb2, 0x49, 0xb2, 0xcc,
+	0x22, 0x27, 0xeb, 0xdc, 0x9b, 0xf7, 0x3b, 0x59, 0x64, 0x91, 0xbf, 0x21, 0x59, 0xe7, 0xe4, 0x54,
+	0x75, 0x03, 0x04, 0x29, 0xd2, 0x76, 0x76, 0xd9, 0xa1, 0x7f, 0xf5, 0xab, 0x42, 0x3f, 0xaa, 0xab,
+	0xaa, 0xbb, 0xe1, 0x9e, 0x6d, 0xf5, 0xa3, 0x81, 0xc7, 0x3f, 0x7f, 0x68, 0xf5, 0xdd, 0x87, 0xef,
+	0x1e, 0x3d, 0x8c, 0xb9, 0xc7, 0x7b, 0x3c, 0x0e, 0x87, 0x26, 0x7f, 0xc7, 0xfd, 0x78, 0xa7, 0x1f,
+	0x06, 0x71, 0xc0, 0x96, 0x13, 0xda, 0x8e, 0xd5, 0x77, 0x77, 0xde, 0x3d, 0xda, 0xb8, 0x76, 0x41,
+	0x6f, 0xd8, 0xe7, 0x91, 0x60, 0x6f, 0x5c, 0xed, 0x06, 0x41, 0xd7, 0xe3, 0x0f, 0xa9, 0x75, 0x32,
+	0xe8, 0x3c, 0xb4, 0xfc, 0xa1, 0x10, 0x55, 0x7f, 0x4f, 0x85, 0x4a, 0x3b, 0xf9, 0x85, 0x8e, 0x7f,
+	0x60, 0x15, 0x98, 0x73, 0x1d, 0x4d, 0xd9, 0x54, 0xb6, 0x8a, 0xc6, 0x9c, 0xeb, 0xb0, 0x1b, 0x00,
+	0xfd, 0x30, 0xb0, 0x79, 0x14, 0x99, 0xae, 0xa3, 0xcd, 0x11, 0x5e, 0x94, 0x48, 0xc3, 0x61, 0xb7,
+	0xa0, 0x94, 0x88, 0xfb, 0xae, 0xa3, 0xe5, 0x36, 0x95, 0xad, 0x79, 0x23, 0xd1, 0x38, 0x72, 0x1d,
+	0x76, 0x1b, 0xca, 0x76, 0xe0, 0xc7, 0x96, 0xeb, 0xf3, 0x10, 0x2d, 0xe4, 0xc9, 0x42, 0x29, 0xc5,
+	0x1a, 0x0e, 0xbb, 0x06, 0xc5, 0x88, 0xfb, 0x51, 0x40, 0xf2, 0x79, 0x92, 0x17, 0x04, 0xd0, 0x70,
+	0xd8, 0x53, 0x58, 0x97, 0xc2, 0x88, 0x7f, 0x3f, 0xe0, 0xbe, 0xcd, 0x4d, 0x7f, 0xd0, 0x3b, 0xe1,
+	0xa1, 0xb6, 0xb0, 0xa9, 0x6c, 0xe5, 0x8d, 0x55, 0x21, 0x6d, 0x49, 0x61, 0x93, 0x64, 0x6c, 0x17,
+	0xd6, 0xa4, 0x56, 0x2f, 0xf0, 0x83, 0xd8, 0xed, 0x71, 0xd3, 0xb7, 0xfc, 0x20, 0xd2, 0x16, 0x37,
+	0x95, 0xad, 0x9c, 0x71, 0x59, 0x08, 0xdf, 0x48, 0x59, 0x13, 0x45, 0xac, 0x06, 0xcb, 0xc9, 0x50,
+	0x3c, 0xd7, 0xe7, 0x56, 0x97, 0x6b, 0x85, 0xcd, 0xdc, 0x56, 0x69, 0x57, 0xdb, 0x99, 0x98, 0xef,
+	0x9d, 0x23, 0xc1, 0x33, 0x2a, 0x52, 0xe1, 0x40, 0xf0, 0x71, 0x24, 0xb6, 0x35, 0x88, 0xb8, 0x63,
+	0x9e, 0x0c, 0xb5, 0xe2, 0x66, 0x6e, 0x2b, 0x6f, 0x14, 0x04, 0xf0, 0x62, 0xc8, 0xee, 0x41, 0x65,
+	0x34, 0x13, 0xbe, 0xd5, 0xe3, 0xda, 0x4d, 0x1a, 0xeb, 0x52, 0x8a, 0x36, 0xad, 0x1e, 0x67, 0x57,
+	0xa1, 0xe0, 0xf6, 0xac, 0x2e, 0xc7, 0xc9, 0xb8, 0x45, 0x84, 0x45, 0x6a, 0x37, 0x68, 0x2d, 0x84,
+	0x88, 0xb4, 0x37, 0xc5, 0x5a, 0x10, 0x42, 0x9a, 0x5f, 0xc0, 0x62, 0x34, 0x8c, 0x6c, 0xcb, 0xf3,
+	0x34, 0xd8, 0x54, 0xb6, 0x4a, 0xbb, 0x37, 0x2e, 0x74, 0xbc, 0x25, 0xe4, 0xb4, 0xd4, 0xaf, 0x2e,
+	0x19, 0x09, 0x1f, 0x55, 0xe5, 0x50, 0xb4, 0xd2, 0x0c, 0x55, 0x39, 0xe6, 0x54, 0x55, 0xf2, 0xd9,
+	0x23, 0xc8, 0x77, 0x5c, 0x8f, 0x6b, 0x65, 0xd2, 0xdb, 0xb8, 0xa0, 0xb7, 0xef, 0x7a, 0x3c, 0x51,
+	0x22, 0x26, 0x7b, 0x0d, 0xa5, 0x33, 0x1e, 0xfa, 0xdc, 0x33, 0xa9, 0xaf, 0x4b, 0xa4, 0xb8, 0x75,
+	0x41, 0xf1, 0x35, 0x71, 0xf6, 0x07, 0xbe, 0x1d, 0xbb, 0x81, 0x5f, 0xcf, 0x74, 0x1b, 0x84, 0x7a,
+	0x5d, 0xf6, 0xdc, 0xe7, 0xf1, 0x79, 0x10, 0x9e, 0x69, 0x95, 0x19, 0x3d, 0x6f, 0x0a, 0x79, 0xda,
+	0x73, 0xc9, 0x67, 0x3a, 0x94, 0xfa, 0x3c, 0xec, 0x04, 0x61, 0xcf, 0xf2, 0x6d, 0xae, 0x2d, 0x93,
+	0xfa, 0xed, 0x8b, 0x03, 0x1f, 0x71, 0x12, 0x13, 0x59, 0x3d, 0xf6, 0x1c, 0x16, 0x22, 0xb7, 0xeb,
+	0x5b, 0x9e, 0xa6, 0x92, 0x85, 0xeb, 0x17, 0x67, 0x9d, 0xc4, 0x89, 0xb2, 0x64, 0xb3, 0x9f, 0x40,
+	0x31, 0x5d, 0x79, 0x6d, 0x95, 0x54, 0x6f, 0x5d, 0x50, 0xad, 0x27, 0x8c, 0x44, 0x7b, 0xa4, 0xc3,
+	0xbe, 0x05, 0x16, 0x0d, 0x4e, 0x22, 0x3b, 0x74, 0xfb, 0x38, 0x43, 0x66, 0x14, 0x5b, 0x71, 0xa4,
+	0x6d, 0x91, 0xa5, 0xfb, 0x17, 0x3b, 0x91, 0xa1, 0xb6, 0x90, 0x99, 0x58, 0x5c, 0x89, 0x26, 0x25,
+	0x6c, 0x1f, 0xca, 0x0e, 0xb7, 0x03, 0x87, 0x9b, 0x3c, 0x0c, 0x83, 0x50, 0x7b, 0x30, 0x63, 0x6a,
+	0xf6, 0x88, 0xa4, 0x23, 0x27, 0x9d, 0x1a, 0x67, 0x84, 0xa1, 0x9d, 0xef, 0x07, 0x2e, 0x8f, 0xcd,
+	0x3e, 0x0f, 0xdd, 0xc0, 0xd1, 0xb6, 0x67, 0xd8, 0xf9, 0x0a, 0x49, 0x47, 0xc4, 0x49, 0xed, 0x7c,
+	0x3f, 0xc2, 0x70, 0xa4, 0xe8, 0x39, 0x1e, 0xee, 0x4d, 0xfe, 0x03, 0xb7, 0x07, 0xd8, 0x55, 0xed,
+	0xd3, 0x19, 0x23, 0xdd, 0x97, 0x54, 0x3d, 0x61, 0xa6, 0x23, 0xed, 0x4c, 0x4a, 0xd0, 0x7d, 0xec,
+	0x53, 0x2b, 0xec, 0x72, 0x5f, 0x73, 0x66, 0xb8, 0x4f, 0x5d, 0xc8, 0x53, 0xf7, 0x91, 0x7c, 0x5c,
+	0xf7, 0xd8, 0xb5, 0xcf, 0x78, 0xa8, 0xf1, 0x19, 0xeb, 0xde, 0x26, 0x71, 0xba, 0xee, 0x82, 0xcd,
+	0x56, 0x20, 0x67, 0xf7, 0x07, 0xda, 0x2f, 0x14, 0x8a, 0x95, 0xf8, 0xcd, 0x7e, 0x02, 0x25, 0x3b,
+	0xe4, 0x0e, 0xf7, 0x63, 0xd7, 0xf2, 0x22, 0xed, 0x97, 0xca, 0x0c, 0x83, 0xf5, 0x11, 0xc9, 0xc8,
+	0x6a, 0xb0, 0x2a, 0x94, 0x93, 0xd8, 0x15, 0x77, 0x5d, 0x47, 0xfb, 0x0b, 0x61, 0x3c, 0x89, 0xcd,
+	0xed, 0xae, 0xeb, 0xb0, 0x75, 0x58, 0xe8, 0xf9, 0xb1, 0xe9, 0x47, 0xda, 0x5f, 0x2a, 0x14, 0x3a,
+	0xe7, 0x7b, 0x7e, 0xdc, 0x8c, 0xd8, 0x75, 0x28, 0x46, 0x56, 0xaf, 0xef, 0x71, 0xd3, 0xed, 0x6b,
+	0x7f, 0x25, 0x44, 0x05, 0x81, 0x34, 0xfa, 0xec, 0x06, 0x86, 0x34, 0xcf, 0xb3, 0x4f, 0x2d, 0xd7,
+	0xd7, 0xfe, 0x5a, 0xa1, 0x98, 0x36, 0x42, 0xd8, 0x26, 0x94, 0xfc, 0x41, 0xcf, 0x8c, 0x4f, 0x43,
+	0x6e, 0x39, 0x91, 0xf6, 0x37, 0xa8, 0xbe, 0x64, 0x80, 0x3f, 0xe8, 0xb5, 0x05, 0x84, 0xbf, 0x0d,
+	0xa3, 0xc8, 0x3c, 0x3b, 0xd1, 0xfe, 0x56, 0xfe, 0x36, 0x8c, 0xa2, 0xd7, 0x27, 0xec, 0x01, 0xa8,
+	0x6e, 0x64, 0xca, 0x40, 0x20, 0xf4, 0xb5, 0xbf, 0x43, 0x46, 0xc1, 0xa8, 0xb8, 0x91, 0xd8, 0xfc,
+	0xc2, 0x06, 0xdb, 0x80, 0x82, 0x63, 0xc5, 0x96, 0x19, 0x85, 0xb6, 0xf6, 0xf7, 0xc2, 0xc8, 0x22,
+	0x02, 0xad, 0xd0, 0x66, 0x75, 0x58, 0xea, 0xf1, 0x5e, 0x10, 0x0e, 0x4d, 0xcb, 0xa6, 0xf8, 0xf5,
+	0x0f, 0xca, 0x8c, 0x75, 0x7c, 0x43, 0xb4, 0x1a, 0xb1, 0x8c, 0x72, 0x2f, 0xd3, 0x62, 0x0d, 0x58,
+	0xe6, 0x4e, 0x97, 0x9b, 0x71, 0x68, 0xf9, 0x91, 0x4b, 0xce, 0xf5, 0x8f, 0x68, 0xa6, 0x32, 0x65,
+	0x47, 0xea, 0x4e, 0x97, 0xb7, 0x53, 0x9e, 0x51, 0xe1, 0x63, 0x6d, 0x76, 0x13, 0xa0, 0x6f, 0x85,
+	0xdc, 0x8f, 0xd1, 0x51, 0xb5, 0x7f, 0x52, 0x64, 0xc2, 0x24, 0x48, 0xff, 0x81, 0xe3, 0x84, 0x49,
+	0xb9, 0x1d, 0xf4, 0x7a, 0xda, 0x3f, 0x0b, 0x82, 0xd4, 0xa9, 0x07, 0xbd, 0x1e, 0x4e, 0x0c, 0x86,
+	0x17, 0xd3, 0xf6, 0x02, 0xfb, 0x4c, 0xa6, 0xad, 0x7f, 0x51, 0x28, 0x6f, 0x55, 0x50, 0x50, 0x47,
+	0x5c, 0xa4, 0xac, 0x1b, 0x50, 0x8c, 0x86, 0x7e, 0x7c, 0xca, 0x63, 0xd7, 0xd6, 0xfe, 0x55, 0x4c,
+	0xde, 0x08, 0x61, 0x77, 0x61, 0xa9, 0x63, 0xb9, 0xde, 0x20, 0xe4, 0xa6, 0x1d, 0x0c, 0xfc, 0x58,
+	0xfb, 0x37, 0xb1, 0x3c, 0x65, 0x89, 0xd6, 0x11, 0x7c, 0xb1, 0x08, 0xf3, 0x54, 0x5c, 0xfc, 0x74,
+	0xa1, 0xf0, 0xe7, 0x8a, 0xfa, 0x0b, 0x25, 0xf5, 0x1a, 0x33, 0x76, 0x9d, 0xea, 0x6f, 0x2a, 0x50,
+	0xce, 0xce, 0x1c, 0x16, 0x08, 0x41, 0x3f, 0x29, 0x10, 0x82, 0x3e, 0x5b, 0x85, 0x79, 0x8f, 0xbf,
+	0xe3, 0x9e, 0xac, 0x0d, 0x44, 0x83, 0x56, 0x9d, 0x47, 0x03, 0x2f, 0xa6, 0x92, 0xa0, 0x68, 0xc8,
+	0x16, 0xb2, 0x23, 0x3f, 0x08, 0xfa, 0xb2, 0x0e, 0x10, 0x0d, 0xa6, 0x42, 0x2e, 0xf6, 0x4e, 0x64,
+	0xee, 0xc7, 0x4f, 0xd4, 0xc7, 0x61, 0x72, 0x87, 0xd2, 0x7c, 0xc1, 0x90, 0xad, 0xea, 0xcf, 0x15,
+	0x50, 0x27, 0xa3, 0x05, 0xaa, 0x9f, 0xf1, 0xa1, 0xec, 0x13, 0x7e, 0xb2, 0xcf, 0x40, 0xf3, 0xac,
+	0x28, 0x36, 0x23, 0xce, 0xfd, 0xc9, 0x12, 0x60, 0x8e, 0xa6, 0x72, 0x0d, 0xe5, 0x2d, 0xce, 0xfd,
+	0xf1, 0x22, 0xe0, 0x0e, 0x2c, 0x45, 0xae, 0x27, 0xca, 0x0c, 0x62, 0xe7, 0x88, 0x5d, 0x96, 0x20,
+	0x91, 0xaa, 0x3f, 0x9b, 0x83, 0xf5, 0xe9, 0x41, 0x06, 0x53, 0x74, 0x8f, 0xf7, 0x3a, 0x8e, 0x48,
+	0xd1, 0x72, 0xf5, 0x09, 0x49, 0x92, 0xbb, 0x10, 0x77, 0x44, 0x2d, 0x35, 0x6f, 0x2c, 0x52, 0x7b,
+	0xdf, 0x61, 0x9f, 0xc0, 0x32, 0x86, 0x36, 0x53, 0xa6, 0x64, 0x53, 0x56, 0x53, 0x39, 0x63, 0x09,
+	0x61, 0x99, 0xb8, 0x45, 0xb5, 0x44, 0xbc, 0xbe, 0x15, 0x9f, 0xca, 0x59, 0x2c, 0x20, 0x70, 0x64,
+	0xc5, 0xa7, 0x6c, 0x0b, 0x54, 0x61, 0xdf, 0x0e, 0xb9, 0x15, 0x73, 0xaa, 0xc9, 0xe6, 0xe9, 0x3f,
+	0x15, 0xc2, 0xeb, 0x04, 0x63, 0x5d, 0xf6, 0xff, 0xe1, 0xda, 0x18, 0x73, 0x62, 0x92, 0x16, 0xe8,
+	0xd7, 0x5a, 0x46, 0x69, 0x6c, 0x9e, 0xaa, 0x7f, 0xaa, 0xc0, 0xfa, 0xf4, 0x8c, 0x82, 0x15, 0xdf,
+	0xb9, 0xeb, 0x3b, 0xc1, 0xb9, 0x34, 0x25, 0x5c, 0xb7, 0x24, 0xb0, 0x74, 0x96, 0x1d, 0x37, 0x8a,
+	0x5d, 0xdf, 0x8e, 0xb1, 0x8b, 0x62, 0x4d, 0xf2, 0x46, 0x39, 0x01, 0x8f, 0x5c, 0x27, 0x62, 0xbf,
+	0x06, 0xeb, 0xa3, 0x7a, 0x49, 0x46, 0xa8, 0xd0, 0x8a, 0x39, 0xae, 0x09, 0x96, 0x65, 0x77, 0x67,
+	0x27, 0xcb, 0x16, 0xb1, 0x0d, 0x2b, 0xe6, 0xc6, 0xaa, 0x7d, 0x11, 0x8c, 0xaa, 0x7f, 0xa0, 0xc0,
+	0xe5, 0x29, 0xec, 0x0b, 0xd5, 0xaa, 0x72, 0xb1, 0x5a, 0xbd, 0x05, 0xa5, 0x4c, 0x67, 0xa8, 0xe7,
+	0x8a, 0x01, 0xd1, 0xc8, 0xc6, 0x7d, 0x58, 0x0e, 0x4e, 0x22, 0x1e, 0xbe, 0xe3, 0x8e, 0xa8, 0xda,
+	0x85, 0x13, 0xe5, 0x8d, 0x4a, 0x02, 0xd3, 0x3c, 0x45, 0x58, 0x10, 0x0a, 0xb5, 0x94, 0x97, 0x27,
+	0xde, 0x92, 0x44, 0x05, 0xad, 0xfa, 0x1b, 0x0a, 0xa8, 0x93, 0x89, 0x16, 0x1d, 0x89, 0x74, 0x92,
+	0x4e, 0xe6, 0x8d, 0x45, 0x6a, 0x37, 0x1c, 0xb1, 0xf5, 0xac, 0x28, 0xf0, 0xe5, 0x8e, 0x94, 0x2d,
+	0x74, 0xb0, 0xd0, 0x3a, 0x37, 0x29, 0x92, 0x7a, 0xdc, 0xef, 0xc6, 0xa7, 0xd4, 0xaf, 0x25, 0x63,
+	0x29, 0xb4, 0xce, 0xf7, 0xac, 0xd8, 0x3a, 0x20, 0x10, 0xb7, 0x68, 0xdf, 0xf2, 0x5d, 0x9b, 0x7a,
+	0x53, 0x30, 0x44, 0xa3, 0xfa, 0xeb, 0xb0, 0x52, 0xf3, 0x87, 0x13, 0x87, 0x85, 0x67, 0x32, 0x74,
+	0x68, 0xca, 0x8c, 0xf2, 0x65, 0x9c, 0x6f, 0x08, 0x36, 0xdb, 0x81, 0xc5, 0xbe, 0x35, 0xf4, 0x02,
+	0x4b, 0x6c, 0x82, 0xd2, 0xee, 0xea, 0x8e, 0x38, 0xa3, 0xec, 0x24, 0x67, 0x94, 0x9d, 0x9a, 0x3f,
+	0x34, 0x12, 0x52, 0x75, 0x0f, 0xca, 0xd9, 0x24, 0x8c, 0x3d, 0x74, 0x7d, 0x87, 0xff, 0x20, 0x47,
+	0x2e, 0x1a, 0x18, 0x79, 0x31, 0x35, 0x5b, 0x76, 0xcc, 0xc3, 0x48, 0x8e, 0x3d, 0x83, 0x54, 0x1b,
+	0x50, 0xca, 0x24, 0x64, 0xa6, 0xc1, 0x62, 0xc4, 0xed, 0xc0, 0x77, 0x12, 0x0f, 0x4d, 0x9a, 0x94,
+	0xd3, 0xd0, 0x4d, 0xa5, 0x54, 0xc4, 0x8b, 0x2c, 0x54, 0xfd, 0xed, 0x1c, 0x54, 0xc6, 0x2b, 0x33,
+	0xf6, 0x19, 0xe4, 0xf1, 0xd0, 0xa5, 0x89, 0xb4, 0x71, 0xe7, 0x03, 0x85, 0x5c, 0x7b, 0xd8, 0xe7,
+	0x06, 0x29, 0x30, 0x06, 0x79, 0x8a, 0x15, 0xa2, 0xc3, 0xf4, 0x3d, 0x76, 0x06, 0x80, 0xf7, 0x9d,
+	0x01, 0x4a, 0x93, 0x67, 0x80, 0xab, 0x50, 0x38, 0x0d, 0x22, 0xda, 0x55, 0x54, 0x53, 0xae, 0x18,
+	0x8b, 0xd8, 0x3e, 0x72, 0x65, 0xe0, 0x70, 0x31, 0xef, 0x38, 0xe2, 0xe8, 0xb1, 0x82, 0x81, 0xc3,
+	0x8d, 0xeb, 0x81, 0xc3, 0xd1, 0xab, 0x49, 0x88, 0x35, 0xe4, 0x20, 0xa2, 0x83, 0xc7, 0x92, 0x01,
+	0x08, 0xb5, 0x08, 0x19, 0x11, 0x44, 0xa9, 0xbb, 0x99, 0x21, 0x10, 0x82, 0xa1, 0x47, 0x9a, 0x0f,
+	0xb9, 0xe9, 0x0c, 0x7a, 0x7d, 0xee, 0x68, 0xb7, 0x45, 0x3a, 0x17, 0x7f, 0x09, 0xf9, 0x1e, 0xa1,
+	0xec, 0x47, 0xc0, 0x1c, 0x8c, 0xe6, 0xa1, 0x69, 0x07, 0x7e, 0xc7, 0xed, 0x9a, 0xdf, 0xa1, 0xb3,
+	0x3a, 0x34, 0x14, 0x55, 0x48, 0xea, 0x24, 0xf8, 0xa9, 0x74, 0xdb, 0xc0, 0x76, 0xc7, 0xa8, 0x5c,
+	0x9c, 0x9b, 0x02, 0xdb, 0x1d, 0xf1, 0xaa, 0x7f, 0x32, 0x0f, 0xe5, 0xec, 0x19, 0x85, 0x3d, 0x1b,
+	0x5b, 0x91, 0xdb, 0xef, 0x3d, 0xd0, 0x64, 0xd6, 0xe3, 0x2e, 0x54, 0x3a, 0x41, 0x78, 0x66, 0xda,
+	0xa7, 0xae, 0xe7, 0x98, 0x7d, 0xb9, 0x02, 0x2b, 0x46, 0x19, 0xd1, 0x3a, 0x82, 0x38, 0x99, 0x55,
+	0x58, 0xca, 0xb0, 0x5c, 0x47, 0xae, 0x44, 0x29, 0x25, 0x35, 0x1c, 0x8c, 0x72, 0x14, 0xa9, 0xb1,
+	0xea, 0xa4, 0xd5, 0x5a, 0x25, 0x4e, 0x19, 0xc1, 0x7d, 0x89, 0xb1, 0x6d, 0x58, 0x21, 0x12, 0x56,
+	0x03, 0x96, 0xef, 0xd0, 0xd1, 0x53, 0x5b, 0xdb, 0xcc, 0x6d, 0x15, 0x0d, 0xca, 0x07, 0x75, 0x81,
+	0xe3, 0x09, 0x13, 0xa3, 0x13, 0x71, 0x93, 0xe3, 0xe9, 0x3a, 0xd1, 0x4a, 0x88, 0x25, 0x27, 0xd0,
+	0x2f, 0xa1, 0x20, 0xfe, 0xe9, 0x44, 0xda, 0x95, 0xcd, 0xdc, 0xd4, 0x4d, 0x89, 0xff, 0xde, 0xe3,
+	0x22, 0x74, 0x07, 0xa1, 0xb1, 0x48, 0xfd, 0x71, 0x22, 0x5c, 0x97, 0x44, 0xd7, 0x8c, 0xc3, 0x81,
+	0x6f, 0x5b, 0x31, 0x77, 0x34, 0x8d, 0xd6, 0x50, 0x95, 0xa4, 0x76, 0x82, 0xff, 0xdf, 0x71, 0x27,
+	0x9a, 0x15, 0x37, 0x4e, 0x66, 0x50, 0xab, 0x8a, 0x95, 0x10, 0x2c, 0x82, 0x30, 0x24, 0x13, 0x45,
+	0x9c, 0x1d, 0xac, 0x13, 0x8f, 0x6b, 0x77, 0x88, 0x45, 0xb6, 0xf4, 0x14, 0x65, 0xc7, 0x70, 0x99,
+	0x88, 0x21, 0x8f, 0x82, 0x41, 0x68, 0x73, 0x73, 0x10, 0xe1, 0x44, 0xdf, 0xa5, 0x28, 0x75, 0x6f,
+	0xe6, 0x3d, 0x80, 0x64, 0x1f, 0x23, 0xd9, 0x58, 0x41, 0x0b, 0x63, 0x10, 0x6e, 0xda, 0x41, 0xdf,
+	0xc1, 0x34, 0x6b, 0x9f, 0x3b, 0x74, 0x42, 0x2b, 0x1a, 0x45, 0x81, 0xd4, 0xcf, 0x9d, 0xea, 0x1f,
+	0x2b, 0xb0, 0x3a, 0xcd, 0x14, 0xfa, 0xfe, 0x20, 0xe2, 0xa1, 0x99, 0x49, 0xcc, 0x22, 0xe4, 0x2d,
+	0x21, 0xdc, 0x4e, 0xab, 0x96, 0x6d, 0x58, 0x89, 0x86, 0x51, 0xcc, 0x7b, 0xe6, 0x44, 0x9d, 0x93,
+	0x37, 0x96, 0x85, 0x60, 0xc4, 0xbd, 0x0d, 0xe5, 0x9e, 0xeb, 0x07, 0xa1, 0xd9, 0xb1, 0x06, 0x5e,
+	0x9a, 0x9b, 0x4a, 0x84, 0xed, 0x13, 0x44, 0x14, 0xeb, 0xbb, 0x11, 0x25, 0x2f, 0x29, 0xd6, 0x77,
+	0x09, 0xa5, 0xfa, 0x14, 0x2a, 0xe3, 0x6e, 0x84, 0x75, 0x61, 0x47, 0xe4, 0xa2, 0x79, 0x63, 0xae,
+	0xe3, 0x60, 0x5c, 0xa3, 0x12, 0x45, 0xc6, 0x35, 0xfc, 0xae, 0xfe, 0xd9, 0x32, 0x94, 0xb3, 0x57,
+	0x10, 0x1f, 0xdc, 0xa3, 0x59, 0x72, 0x66, 0x8f, 0x8a, 0x4b, 0x2a, 0x11, 0x98, 0xf1, 0x92, 0x8a,
+	0x41, 0xde, 0x0a, 0xbb, 0x8f, 0x68, 0xa7, 0xe6, 0x0d, 0xfa, 0x96, 0xd8, 0x63, 0xad, 0x94, 0x62,
+	0x8f, 0x25, 0xb6, 0xab, 0x95, 0x53, 0x6c, 0x57, 0x62, 0x4f, 0xb4, 0xa5, 0x14, 0x7b, 0x22, 0xb1,
+	0xa7, 0x5a, 0x25, 0xc5, 0x9e, 0x4a, 0xec, 0x99, 0xb6, 0x9c, 0x62, 0xcf, 0xb0, 0xf0, 0x0c, 0x79,
+	0x4c, 0xfb, 0x3a, 0x67, 0xe0, 0x27, 0xe6, 0x74, 0x67, 0x10, 0x5a, 0x74, 0x1e, 0x17, 0xcb, 0xb0,
+	0x26, 0x8a, 0xb8, 0x04, 0x15, 0x8b, 0xa0, 0x61, 0x06, 0x0c, 0xf1, 0xec, 0xa6, 0xad, 0x93, 0x53,
+	0x27, 0x4d, 0xcc, 0x6d, 0x27, 0x43, 0x2c, 0x72, 0xae, 0x88, 0xdc, 0x46, 0x0d, 0xf6, 0x1a, 0x58,
+	0xe6, 0xb8, 0x67, 0x9e, 0xf0, 0x4e, 0x10, 0x72, 0x4d, 0xfb, 0x88, 0x63, 0xe2, 0x4a, 0x46, 0xef,
+	0x05, 0xa9, 0xb1, 0x06, 0x64, 0x41, 0xd3, 0xea, 0xc4, 0x3c, 0xd4, 0xae, 0x7e, 0x84, 0x2d, 0x35,
+	0xa3, 0x56, 0x43, 0x2d, 0xba, 0x1d, 0x14, 0xa7, 0x19, 0x0c, 0x94, 0x1b, 0xb4, 0xf8, 0xf2, 0xb0,
+	0x23, 0x53, 0xce, 0x28, 0x8c, 0x5e, 0x23, 0x69, 0xc1, 0x4e, 0x42, 0xe8, 0x03, 0x50, 0xb1, 0xde,
+	0x0b, 0xdd, 0x13, 0xaa, 0x9f, 0x4d, 0x2b, 0xec, 0x6a, 0xd7, 0x29, 0x0e, 0x2c, 0x67, 0xf1, 0x5a,
+	0xd8, 0x65, 0x3f, 0x06, 0x36, 0x46, 0x8d, 0x83, 0xd8, 0xf2, 0xb4, 0x1b, 0x34, 0x43, 0x2b, 0x59,
+	0x49, 0x1b, 0x05, 0xac, 0x01, 0xe5, 0x2c, 0xa8, 0xdd, 0xdc, 0xcc, 0x4d, 0xdd, 0xbe, 0xd2, 0xbb,
+	0x6a, 0x61, 0xf7, 0x6b, 0xcb, 0x1b, 0x88, 0xe3, 0x90, 0x31, 0xa6, 0x8a, 0xeb, 0xd9, 0x8f, 0x43,
+	0xcb, 0xe6, 0x66, 0x88, 0x37, 0x8c, 0x51, 0x2c, 0xef, 0xe4, 0x96, 0x04, 0x6a, 0x08, 0x10, 0x37,
+	0xa0, 0xa4, 0xc5, 0x58, 0xa7, 0x88, 0xe9, 0xd8, 0xa4, 0x01, 0x2f, 0x0b, 0x41, 0x9b, 0x70, 0x1c,
+	0xf7, 0x2e, 0xac, 0x8d, 0x73, 0x93, 0xc0, 0x75, 0x9b, 0x2c, 0x5f, 0xce, 0xf2, 0x93, 0x00, 0x86,
+	0x9b, 0x29, 0x0c, 0x62, 0x19, 0xdb, 0xe8, 0x9b, 0x3d, 0x87, 0x2b, 0xe7, 0xa1, 0x4b, 0x71, 0xcb,
+	0xc4, 0xcc, 0x31, 0x11, 0xdc, 0x0a, 0xc6, 0x5a, 0x22, 0xae, 0xf9, 0x4e, 0x26, 0xc6, 0xe1, 0x11,
+	0xa5, 0x67, 0xf5, 0xcd, 0x8e, 0x67, 0x75, 0x23, 0xed, 0xae, 0x3c, 0xa2, 0xf4, 0xac, 0xfe, 0x3e,
+	0x02, 0x18, 0x73, 0xfa, 0x81, 0xe7, 0xda, 0x43, 0x5c, 0x10, 0xb3, 0x67, 0x45, 0x67, 0xda, 0x3d,
+	0x51, 0x26, 0x0a, 0xb8, 0x16, 0x76, 0xdf, 0x58, 0xd1, 0x59, 0xba, 0xbf, 0x3f, 0x19, 0xed, 0x6f,
+	0xac, 0x3e, 0x7c, 0x7e, 0x2e, 0x8e, 0x26, 0xf7, 0x45, 0xdd, 0xe2, 0xf3, 0x73, 0x3a, 0x99, 0xdc,
+	0x81, 0x25, 0x84, 0xcd, 0x90, 0x7b, 0x56, 0xec, 0xbe, 0xe3, 0x14, 0x05, 0x0b, 0x46, 0x19, 0x41,
+	0x43, 0x62, 0x38, 0x8d, 0x89, 0xfe, 0x88, 0xf8, 0x80, 0x88, 0xcb, 0xd2, 0x50, 0xca, 0xfd, 0x55,
+	0x00, 0xec, 0xa0, 0xcc, 0x30, 0xdb, 0x9b, 0xb9, 0xf7, 0x05, 0x90, 0x5a, 0xd8, 0x15, 0x89, 0xc7,
+	0x28, 0x5a, 0xc9, 0x27, 0x7b, 0x81, 0x47, 0xf1, 0xf8, 0x34, 0x31, 0xf1, 0xe9, 0xa6, 0xf2, 0x71,
+	0x26, 0x00, 0xb5, 0xa4, 0x8d, 0x06, 0x2c, 0xa7, 0x3d, 0x96, 0x76, 0x7e, 0xf4, 0xb1, 0x76, 0x96,
+	0xe4, 0x90, 0x46, 0x29, 0xf1, 0xa4, 0xdf, 0x49, 0xbd, 0xe1, 0xc7, 0xa2, 0x80, 0x3d, 0xe9, 0x77,
+	0x12, 0x27, 0xc0, 0x95, 0xc1, 0x8b, 0x01, 0x51, 0xf8, 0x53, 0xdc, 0xdc, 0x91, 0xce, 0xc8, 0xc3,
+	0x4e, 0x1a, 0x23, 0xc9, 0x19, 0x47, 0x3c, 0x51, 0x38, 0x69, 0x0f, 0x45, 0x36, 0x48, 0x99, 0xa2,
+	0x72, 0x62, 0x8f, 0x61, 0x2d, 0x6b, 0x73, 0xe4, 0xbc, 0x8f, 0xc8, 0x79, 0xd9, 0xc8, 0x72, 0xea,
+	0xbf, 0x5f, 0xc0, 0xd5, 0x8b, 0x2a, 0x49, 0xaf, 0x1f, 0x53, 0x87, 0xd6, 0x27, 0xd4, 0x92, 0x11,
+	0xdc, 0x85, 0x4a, 0xb6, 0x67, 0xfd, 0x81, 0xb6, 0x4b, 0xbf, 0x29, 0x8f, 0xba, 0xd5, 0x1f, 0xd0,
+	0x45, 0xb9, 0xe5, 0x79, 0x54, 0x1f, 0x0a, 0xab, 0x4f, 0xc4, 0x30, 0x05, 0x9a, 0x18, 0xfb, 0x14,
+	0x56, 0x24, 0x2d, 0xe3, 0xf9, 0x4f, 0x45, 0x15, 0x29, 0x04, 0x13, 0x4e, 0x3f, 0x3a, 0x97, 0x3f,
+	0x9b, 0x3c, 0x97, 0xdf, 0x87, 0x65, 0x14, 0x44, 0x7d, 0xda, 0x96, 0xf8, 0x78, 0xa2, 0x3d, 0x17,
+	0x05, 0x42, 0x0a, 0xe3, 0xd4, 0x46, 0xec, 0x2d, 0xac, 0x65, 0x88, 0xe9, 0xb5, 0x4f, 0xa4, 0x7d,
+	0x36, 0xe3, 0x4c, 0xda, 0x4c, 0xf5, 0x53, 0xb2, 0xb1, 0xea, 0x5f, 0x04, 0x23, 0xf6, 0x24, 0x63,
+	0x3a, 0x32, 0x07, 0x3e, 0xd6, 0x20, 0xde, 0x3b, 0xee, 0x68, 0x9f, 0xd3, 0x06, 0x18, 0x29, 0x45,
+	0xc7, 0xa9, 0x0c, 0x83, 0xe8, 0x19, 0x1f, 0xda, 0xb1, 0x67, 0x06, 0x7d, 0x2e, 0x32, 0x8c, 0xf6,
+	0x05, 0xf5, 0x7c, 0x59, 0xe0, 0x87, 0x09, 0x8c, 0x9b, 0xf3, 0x8c, 0x0f, 0x85, 0xdf, 0x7c, 0x29,
+	0x36, 0xe7, 0x19, 0x1f, 0x92, 0xc7, 0xdc, 0x07, 0x64, 0x9b, 0x0e, 0x4f, 0xcf, 0xf3, 0xda, 0xff,
+	0x13, 0xc3, 0x3f, 0xe3, 0xc3, 0xbd, 0x11, 0x5a, 0xed, 0xc1, 0xe5, 0x29, 0x03, 0xc2, 0x58, 0x90,
+	0xa6, 0xf1, 0xa2, 0xcc, 0xd1, 0xb7, 0xa1, 0xec, 0xfa, 0x78, 0x85, 0x2c, 0x93, 0x95, 0x28, 0x47,
+	0x4a, 0x84, 0xc9, 0x44, 0x74, 0x0b, 0x44, 0x53, 0xa6, 0x20, 0x51, 0x89, 0x00, 0x41, 0x94, 0x5e,
+	0xaa, 0x2f, 0x60, 0x75, 0x5a, 0x8c, 0xc6, 0x24, 0xf9, 0x0e, 0x5b, 0xc9, 0x01, 0x90, 0x1a, 0x88,
+	0x8a, 0x6b, 0x2e, 0xf1, 0x2b, 0xd1, 0xa8, 0xfe, 0x8e, 0x02, 0xc5, 0xf4, 0x0d, 0x82, 0xed, 0x8e,
+	0x15, 0x1c, 0x37, 0x67, 0xbf, 0x56, 0x64, 0xaa, 0x8d, 0x0d, 0x28, 0xa4, 0x25, 0xbc, 0x38, 0x8d,
+	0xa5, 0x6d, 0xf4, 0xab, 0xa0, 0xcf, 0x7d, 0x19, 0x4c, 0x4b, 0x54, 0x06, 0x17, 0x11, 0x11, 0xc1,
+	0xf4, 0x1a, 0x50, 0xc3, 0xec, 0x61, 0x91, 0x5c, 0x16, 0x45, 0x32, 0x02, 0x6f, 0x02, 0x87, 0x57,
+	0xff, 0x73, 0x0e, 0x4a, 0x99, 0xa7, 0x01, 0xf6, 0x74, 0xac, 0x6f, 0x9b, 0xef, 0x7b, 0x46, 0xc8,
+	0xf4, 0x6e, 0x3d, 0x7d, 0x7e, 0x10, 0x17, 0x4a, 0xb2, 0x45, 0xf7, 0x14, 0xf4, 0x25, 0x5c, 0x5e,
+	0x5c, 0xc3, 0x81, 0x80, 0xc8, 0xe7, 0x19, 0xe4, 0xa9, 0x76, 0xcf, 0x93, 0x1a, 0x7d, 0xe3, 0x14,
+	0xf2, 0x30, 0xf4, 0x03, 0x79, 0x69, 0x24, 0x1a, 0x38, 0xc8, 0x88, 0xfb, 0x0e, 0x0f, 0xd3, 0xe3,
+	0xd0, 0xbc, 0x51, 0x14, 0xc8, 0x91, 0x78, 0x22, 0xcc, 0x04, 0x8e, 0x92, 0x10, 0xc7, 0x69, 0xbc,
+	0xb8, 0x07, 0x95, 0x89, 0x20, 0x51, 0x16, 0xdb, 0x39, 0x1e, 0x8b, 0x0d, 0xab, 0x30, 0xdf, 0x0d,
+	0x83, 0x41, 0x9f, 0x0a, 0xb1, 0x82, 0x21, 0x1a, 0x99, 0x7b, 0xc4, 0x8a, 0x18, 0x9d, 0x68, 0x51,
+	0x97, 0x2c, 0xf3, 0xd4, 0xf2, 0x1d, 0x4f, 0xbe, 0x9e, 0xe4, 0x8d, 0x62, 0x64, 0xbd, 0x12, 0x00,
+	0xfa, 0x7a, 0x64, 0xc9, 0x45, 0x59, 0x23, 0xe1, 0x62, 0x64, 0xd1, 0x92, 0x54, 0x9f, 0xc1, 0xa2,
+	0xac, 0xb5, 0xb1, 0x7c, 0xeb, 0xcb, 0xfb, 0x93, 0x15, 0x03, 0x3f, 0xb1, 0x2e, 0x4b, 0x3a, 0x29,
+	0xea, 0xd6, 0xa4, 0x59, 0xfd, 0xaf, 0x3c, 0x5c, 0x99, 0xf1, 0x22, 0xc5, 0x8e, 0x01, 0xb3, 0xca,
+	0xa0, 0x47, 0x77, 0x38, 0x0a, 0x05, 0x82, 0xcf, 0x3e, 0xf6, 0x39, 0x6b, 0xa7, 0x96, 0x68, 0xea,
+	0x7e, 0x1c, 0x0e, 0x8d, 0x91, 0xa5, 0x8d, 0xff, 0x56, 0x00, 0xf6, 0x5d, 0xee, 0x39, 0xe4, 0xf9,
+	0xec, 0x2b, 0x80, 0x0e, 0xb6, 0xcc, 0x8c, 0x93, 0xec, 0x7e, 0xf4, 0x6f, 0xc8, 0x10, 0xb9, 0x4d,
+	0xb1, 0x93, 0x7c, 0xb2, 0xdb, 0x50, 0xa2, 0xfa, 0xd2, 0x14, 0xbb, 0x09, 0x87, 0x5c, 0xc6, 0xf7,
+	0x35, 0x02, 0xc5, 0x5f, 0xef, 0x40, 0x19, 0xcb, 0x21, 0xbf, 0x2b, 0x39, 0xe4, 0x47, 0xf8, 0x3e,
+	0x23, 0xd0, 0x11, 0xc9, 0xed, 0xfa, 0xdc, 0x91, 0x24, 0x74, 0x29, 0x46, 0x24, 0x42, 0x05, 0xe9,
+	0x3e, 0x54, 0x06, 0xfe, 0x18, 0x0d, 0x9d, 0x2c, 0xff, 0xea, 0x92, 0xb1, 0x34, 0xf0, 0x33, 0x44,
+	0xbc, 0x90, 0x26, 0xf9, 0xc6, 0xf7, 0x50, 0x19, 0x9f, 0x9d, 0x29, 0x37, 0xbd, 0x0d, 0x98, 0x1f,
+	0x75, 0xbe, 0xb4, 0xfb, 0xe4, 0x7f, 0x37, 0x21, 0xf4, 0x43, 0x19, 0x3f, 0xbe, 0x9c, 0xfb, 0x5c,
+	0xa9, 0xfe, 0x16, 0x45, 0x8b, 0x64, 0x7e, 0x4a, 0xb0, 0x78, 0xdc, 0x7c, 0xdd, 0x3c, 0xfc, 0xa6,
+	0xa9, 0x5e, 0x62, 0x45, 0x98, 0x7f, 0xf1, 0xb6, 0xad, 0xb7, 0x54, 0x85, 0x01, 0x2c, 0xb4, 0xda,
+	0x46, 0xa3, 0xf9, 0x52, 0x9d, 0x43, 0xb8, 0xd5, 0x68, 0xb6, 0x3f, 0x57, 0x73, 0x04, 0x37, 0x9a,
+	0xed, 0xc7, 0xcf, 0xd5, 0x7c, 0xf2, 0xfd, 0x64, 0x57, 0x9d, 0x4f, 0xbe, 0x9f, 0x3f, 0x55, 0x17,
+	0x90, 0x7e, 0x4c, 0xf4, 0x45, 0x84, 0x8f, 0x05, 0xbd, 0x90, 0x7c, 0x3f, 0xd9, 0x55, 0x8b, 0xc9,
+	0xf7, 0xf3, 0xa7, 0x2a, 0x54, 0x7f, 0xa9, 0x40, 0x39, 0xfb, 0x7e, 0xf9, 0xc1, 0x13, 0x53, 0x96,
+	0x3c, 0x11, 0x25, 0x02, 0xfb, 0xac, 0xe3, 0xc8, 0x33, 0x92, 0x6c, 0xe1, 0xfb, 0x97, 0xe5, 0x38,
+	0xe1, 0xe8, 0xe1, 0xf7, 0xd6, 0x2c, 0x8b, 0x35, 0x41, 0x33, 0x12, 0x7e, 0x66, 0x6b, 0xe2, 0x7e,
+	0x66, 0xe9, 0xd6, 0xd4, 0x60, 0xf1, 0xc4, 0xb2, 0xcf, 0xbc, 0xa0, 0x2b, 0xcf, 0x54, 0x49, 0xb3,
+	0xfa, 0x33, 0x05, 0xd6, 0x26, 0x5f, 0x53, 0x85, 0x6f, 0x7c, 0x31, 0x36, 0xaa, 0x7b, 0x1f, 0x7c,
+	0x83, 0x1d, 0x1f, 0x99, 0x2c, 0x71, 0x44, 0xd8, 0x97, 0xad, 0x51, 0x8e, 0xc8, 0x65, 0x72, 0x44,
+	0xf5, 0x0f, 0x15, 0x50, 0x27, 0x8d, 0xe1, 0xc5, 0x07, 0x9d, 0x28, 0xc4, 0xe9, 0x99, 0xfb, 0x58,
+	0x31, 0x24, 0xd7, 0xaa, 0x2a, 0x49, 0xf0, 0xf8, 0xac, 0x0b, 0x7c, 0x82, 0x1d, 0x0e, 0x7c, 0xdf,
+	0xf5, 0x93, 0x9f, 0x8f, 0xd8, 0x86, 0xc0, 0xd9, 0xaf, 0xc0, 0x02, 0xfd, 0x39, 0xb9, 0xb5, 0xfe,
+	0xe4, 0x83, 0x63, 0x13, 0x3e, 0x29, 0xb5, 0xb6, 0x6d, 0xa8, 0x8c, 0xbf, 0x38, 0x31, 0x0d, 0x56,
+	0xf5, 0xbd, 0x97, 0xba, 0xd9, 0x36, 0x6a, 0xcd, 0x56, 0xa3, 0xdd, 0x38, 0x6c, 0x9a, 0xcd, 0xc3,
+	0xa6, 0xae, 0x5e, 0x62, 0x1b, 0xb0, 0x3e, 0x29, 0x31, 0x1a, 0x2d, 0x74, 0x53, 0x85, 0x5d, 0x83,
+	0x2b, 0x93, 0xb2, 0xfd, 0xda, 0xc1, 0x01, 0xf9, 0xf0, 0xf6, 0x7f, 0x28, 0xc0, 0x2e, 0x5e, 0x50,
+	0xb2, 0x4d, 0xb8, 0x5e, 0x3f, 0x6c, 0xb6, 0x6b, 0x8d, 0xa6, 0x6e, 0x98, 0xfa, 0xd7, 0x7a, 0xb3,
+	0x6d, 0xb6, 0xdf, 0x1e, 0xe9, 0xe6, 0x68, 0x4f, 0xcc, 0x62, 0xd4, 0x0d, 0xbd, 0xd6, 0xd6, 0xf7,
+	0x54, 0x65, 0x26, 0xc3, 0x38, 0x6e, 0x36, 0xc5, 0x06, 0xba, 0x05, 0xd7, 0xa6, 0x32, 0xf4, 0x6f,
+	0x1b, 0x68, 0x22, 0xc7, 0xaa, 0x70, 0x73, 0x2a, 0x61, 0x4f, 0x6f, 0xb5, 0x8d, 0xc3, 0xb7, 0xfa,
+	0x9e, 0x9a, 0x9f, 0xdd, 0xd5, 0xa3, 0x3d, 0xea, 0xc8, 0xfc, 0xf6, 0xef, 0xe3, 0xca, 0x4f, 0x5c,
+	0xf9, 0xb1, 0x9b, 0xb0, 0x71, 0x64, 0x1c, 0xd6, 0xf5, 0x56, 0x6b, 0xfa, 0xf8, 0xae, 0xc1, 0x95,
+	0x29, 0xf2, 0xfd, 0x43, 0xe3, 0xb5, 0xaa, 0xcc, 0x10, 0xea, 0xdf, 0xea, 0x75, 0x75, 0x6e, 0xa6,
+	0xb0, 0xd1, 0x56, 0x73, 0xec, 0x06, 0x5c, 0x9d, 0xf6, 0x5b, 0xea, 0xab, 0x9a, 0xdf, 0xfe, 0x77,
+	0x05, 0xd4, 0xc9, 0x9b, 0x0f, 0xec, 0x6a, 0xeb, 0x6d, 0xab, 0x5e, 0x3b, 0x38, 0x98, 0xde, 0xd5,
+	0xeb, 0xa0, 0x4d, 0x91, 0xeb, 0xcd, 0xb6, 0x6e, 0x88, 0xbe, 0x4e, 0x93, 0x62, 0x77, 0x68, 0x05,
+	0xa6, 0x08, 0xeb, 0x87, 0x6f, 0x8e, 0x0e, 0xf4, 0xb6, 0xae, 0xe6, 0xd8, 0x7d, 0xb8, 0x33, 0x85,
+	0x50, 0x33, 0x5e, 0x9a, 0x7b, 0x0d, 0x0c, 0x84, 0x2f, 0x8e, 0xd1, 0xa1, 0xd4, 0x3c, 0xbb, 0x0b,
+	0x9b, 0xd3, 0x2c, 0x1d, 0x1c, 0x36, 0x75, 0xf3, 0xa0, 0xd1, 0x7c, 0x5d, 0x7b, 0xa9, 0xab, 0xf3,
+	0xdb, 0x43, 0x50, 0x27, 0x0f, 0x43, 0x59, 0x4d, 0xb4, 0xdb, 0x6a, 0xd7, 0xda, 0xc7, 0x2d, 0xb3,
+	0x79, 0xd8, 0x36, 0x0d, 0xfd, 0xab, 0x63, 0xbd, 0x85, 0x8b, 0x78, 0x29, 0xdb, 0xd3, 0x0c, 0xab,
+	0x5e, 0x3b, 0x6a, 0x1f, 0x1b, 0xe4, 0x6e, 0x99, 0x59, 0xca, 0x10, 0xf6, 0x6b, 0xc7, 0x07, 0x68,
+	0x60, 0x6e, 0x7b, 0x1f, 0x96, 0xc6, 0x4a, 0x3c, 0x9c, 0x98, 0xfd, 0xc6, 0x81, 0x3e, 0x7d, 0x4e,
+	0x35, 0x58, 0x9d, 0x14, 0x1e, 0x1e, 0xe9, 0x4d, 0x55, 0xd9, 0x0e, 0x60, 0x79, 0xa2, 0x1c, 0xc3,
+	0x45, 0x6d, 0x35, 0x5e, 0x36, 0x6b, 0x33, 0xd6, 0x07, 0x7b, 0x76, 0x41, 0xfc, 0x52, 0x6f, 0xea,
+	0x06, 0x2e, 0xba, 0x32, 0x5d, 0x7d, 0x4f, 0x3f, 0x68, 0x7c, 0xad, 0x1b, 0xea, 0xdc, 0xf6, 0xef,
+	0x2a, 0x70, 0x6d, 0x46, 0x2a, 0xa3, 0xbf, 0x7f, 0x0a, 0xf7, 0x5f, 0xeb, 0x46, 0x53, 0x3f, 0x30,
+	0xf7, 0x8f, 0x9b, 0x75, 0xda, 0xdf, 0xb3, 0x7d, 0xe5, 0x01, 0xdc, 0xfb, 0x10, 0x39, 0x71, 0x9c,
+	0x2d, 0xb8, 0xfb, 0x41, 0x2a, 0x79, 0xd1, 0xf6, 0xcf, 0xf3, 0xa0, 0x4e, 0x66, 0x1f, 0x1c, 0x75,
+	0x53, 0x6f, 0x7f, 0x73, 0x68, 0xbc, 0x9e, 0xde, 0x93, 0x4f, 0xa0, 0x3a, 0x45, 0x5e, 0x3f, 0x6c,
+	0x36, 0xf5, 0x7a, 0xdb, 0xac, 0xb5, 0xdb, 0xfa, 0x9b, 0xa3, 0xb6, 0xaa, 0xb0, 0x7b, 0x70, 0xfb,
+	0x3d, 0x3c, 0x43, 0x6f, 0x1d, 0x1f, 0xa0, 0x27, 0xdf, 0x81, 0x5b, 0x53, 0x68, 0x2f, 0x1a, 0xcd,
+	0xbd, 0xd4, 0x16, 0xc5, 0x93, 0x59, 0x24, 0x69, 0x28, 0x3f, 0xe3, 0x7f, 0x07, 0x8d, 0x56, 0x5b,
+	0x6f, 0xa6, 0xa6, 0xe6, 0xd1, 0x6b, 0x67, 0xd3, 0xa4, 0xb1, 0x85, 0x19, 0xc6, 0x6a, 0xf5, 0xba,
+	0x7e, 0x34, 0x1a, 0xe3, 0xe2, 0x0c, 0x63, 0x92, 0x26, 0x8d, 0x15, 0x66, 0x18, 0x6b, 0xe9, 0xcd,
+	0xbd, 0xf6, 0x61, 0x6a, 0xac, 0x38, 0xc3, 0x98, 0xa4, 0x49, 0x63, 0x80, 0x1b, 0x7b, 0x0a, 0xcb,
+	0xd0, 0xeb, 0x5f, 0xef, 0x1b, 0x87, 0x6f, 0x52, 0x73, 0xa5, 0x19, 0xeb, 0x94, 0x12, 0xa5, 0xc1,
+	0xf2, 0xf6, 0x1f, 0xe1, 0x55, 0xf6, 0x94, 0x64, 0x8d, 0x93, 0x7e, 0xa4, 0x1b, 0xfb, 0x87, 0xc6,
+	0x9b, 0x5a, 0xb3, 0x3e, 0x63, 0xbb, 0xdd, 0x81, 0x5b, 0x33, 0x38, 0xaf, 0x6a, 0xc6, 0xde, 0x37,
+	0x35, 0x03, 0xf7, 0xc9, 0x03, 0xb8, 0xf7, 0x01, 0x92, 0x59, 0xaf, 0xd5, 0x5f, 0xe9, 0xc2, 0x1b,
+	0x66, 0x50, 0x5b, 0x87, 0xfb, 0x6d, 0xb2, 0x97, 0x3b, 0x59, 0xa0, 0x37, 0xc7, 0x27, 0xff, 0x33,
+	0x00, 0xcb, 0xd7, 0x68, 0xef, 0x7c, 0x29, 0x00, 0x00,
 }
@@ -7,6 +7,7 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import _ "google.golang.org/genproto/googleapis/api/annotations"
+import google_protobuf2 "github.com/golang/protobuf/ptypes/wrappers"
 import google_rpc "google.golang.org/genproto/googleapis/rpc/status"
 
 import (
@@ -19,11 +20,97 @@ var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
+// The reasons that a subscription's events may be discarded. Values are
+// never renumbered; new reasons are added at the end.
+type DropReason int32
+
+const (
+	DropReason_DROP_REASON_UNKNOWN DropReason = 0
+	// The subscription's buffer was full because the client did not
+	// receive events fast enough
+	DropReason_DROP_REASON_BUFFER_FULL DropReason = 1
+	// The Sensor was overloaded and shed the events of low priority
+	// subscriptions
+	DropReason_DROP_REASON_OVERLOAD DropReason = 2
+	// The subscription's AckThrottle throttled the events
+	DropReason_DROP_REASON_ACK_THROTTLE DropReason = 3
+	// The Sensor's max_egress_bytes_per_second was exceeded
+	DropReason_DROP_REASON_EGRESS_LIMIT DropReason = 4
+	// The subscription's ContainerSampling did not sample the events
+	DropReason_DROP_REASON_CONTAINER_SAMPLING DropReason = 5
+	// The Sensor's system call rate limit for the process and system
+	// call was exceeded
+	DropReason_DROP_REASON_SYSCALL_RATE_LIMIT DropReason = 6
+	// The kernel lost records because one of the subscription's ring
+	// buffers was full. These are counts of records, each of which
+	// might have become an event.
+	DropReason_DROP_REASON_RING_BUFFER_LOSS DropReason = 7
+	// Samples of the subscription's events could not be decoded
+	DropReason_DROP_REASON_DECODE_ERROR DropReason = 8
+	// The subscription's ThrottleModifier discarded the events
+	DropReason_DROP_REASON_THROTTLE_MODIFIER DropReason = 9
+	// The Sensor's CPU usage approached max_cpu_percent
+	DropReason_DROP_REASON_CPU_LIMIT DropReason = 10
+	// The subscription's isolated decoding goroutine fell too far
+	// behind (see DecodeIsolation)
+	DropReason_DROP_REASON_DECODE_BACKLOG DropReason = 11
+)
+
+var DropReason_name = map[int32]string{
+	0:  "DROP_REASON_UNKNOWN",
+	1:  "DROP_REASON_BUFFER_FULL",
+	2:  "DROP_REASON_OVERLOAD",
+	3:  "DROP_REASON_ACK_THROTTLE",
+	4:  "DROP_REASON_EGRESS_LIMIT",
+	5:  "DROP_REASON_CONTAINER_SAMPLING",
+	6:  "DROP_REASON_SYSCALL_RATE_LIMIT",
+	7:  "DROP_REASON_RING_BUFFER_LOSS",
+	8:  "DROP_REASON_DECODE_ERROR",
+	9:  "DROP_REASON_THROTTLE_MODIFIER",
+	10: "DROP_REASON_CPU_LIMIT",
+	11: "DROP_REASON_DECODE_BACKLOG",
+}
+var DropReason_value = map[string]int32{
+	"DROP_REASON_UNKNOWN":            0,
+	"DROP_REASON_BUFFER_FULL":        1,
+	"DROP_REASON_OVERLOAD":           2,
+	"DROP_REASON_ACK_THROTTLE":       3,
+	"DROP_REASON_EGRESS_LIMIT":       4,
+	"DROP_REASON_CONTAINER_SAMPLING": 5,
+	"DROP_REASON_SYSCALL_RATE_LIMIT": 6,
+	"DROP_REASON_RING_BUFFER_LOSS":   7,
+	"DROP_REASON_DECODE_ERROR":       8,
+	"DROP_REASON_THROTTLE_MODIFIER":  9,
+	"DROP_REASON_CPU_LIMIT":          10,
+	"DROP_REASON_DECODE_BACKLOG":     11,
+}
+
+func (x DropReason) String() string {
+	return proto.EnumName(DropReason_name, int32(x))
+}
+func (DropReason) EnumDescriptor() ([]byte, []int) { return fileDescriptor2, []int{0} }
+
 // A request message to initiate the streaming of telemetry events
 type GetEventsRequest struct {
 	// The Subscription message defines which events should be
 	// returned in the stream.
 	Subscription *Subscription `protobuf:"bytes,1,opt,name=subscription" json:"subscription,omitempty"`
+	// If true, and if the Sensor supports it (see
+	// GetCapabilitiesResponse), repeated string values in the returned
+	// events are dictionary encoded. The
+	// github.com/capsule8/capsule8/pkg/dictionary package can be used to
+	// decode the stream.
+	DictionaryEncoding bool `protobuf:"varint,2,opt,name=dictionary_encoding,json=dictionaryEncoding" json:"dictionary_encoding,omitempty"`
+	// Optional; a key chosen by the client to identify this request
+	// when it is retried. If a request with the same key from the same
+	// client is still being served, the retried request takes over its
+	// subscription, keeping its subscription_id and events, rather than
+	// creating a duplicate. The stream serving the original request ends
+	// with an ABORTED status. Keys are remembered after their
+	// subscriptions end for the Sensor's configured idempotency key TTL,
+	// during which a retried request creates a new subscription. Reusing
+	// a key with a different request is an error.
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey" json:"idempotency_key,omitempty"`
 }
 
 func (m *GetEventsRequest) Reset()                    { *m = GetEventsRequest{} }
@@ -38,12 +125,52 @@ func (m *GetEventsRequest) GetSubscription() *Subscription {
 	return nil
 }
 
+func (m *GetEventsRequest) GetDictionaryEncoding() bool {
+	if m != nil {
+		return m.DictionaryEncoding
+	}
+	return false
+}
+
+func (m *GetEventsRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
 // A response message containing telemetry events
 type GetEventsResponse struct {
 	// Can publish one or more message(s) at a time
 	Events []*ReceivedTelemetryEvent `protobuf:"bytes,1,rep,name=events" json:"events,omitempty"`
 	// Can publish one or more status(es) at a time
 	Statuses []*google_rpc.Status `protobuf:"bytes,2,rep,name=statuses" json:"statuses,omitempty"`
+	// Dictionary entries defined by this response when dictionary
+	// encoding is in use. Entries remain defined for the lifetime of
+	// the stream and may be referenced by events in this or any later
+	// response.
+	Dictionary []*DictionaryEntry `protobuf:"bytes,3,rep,name=dictionary" json:"dictionary,omitempty"`
+	// The Sensor's identifier for the subscription, for use with
+	// UpdateSyscallIds. Only present in the first response of a stream.
+	SubscriptionId int32 `protobuf:"varint,4,opt,name=subscription_id,json=subscriptionId" json:"subscription_id,omitempty"`
+	// The tags of the subscription. Only present in the first response
+	// of a stream.
+	SubscriptionTags map[string]string `protobuf:"bytes,5,rep,name=subscription_tags,json=subscriptionTags" json:"subscription_tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The clock that the subscription's events report in
+	// TelemetryEvent.perf_clock_nanos, which is PERF_CLOCK_DEFAULT if
+	// none was selected or the kernel does not support selecting it.
+	// Only present in the first response of a stream.
+	PerfClock PerfClock `protobuf:"varint,7,opt,name=perf_clock,json=perfClock,enum=capsule8.api.v0.PerfClock" json:"perf_clock,omitempty"`
+	// An accounting of the subscription's events. Only present in the
+	// last response of a stream, if it can still be sent when the
+	// subscription ends.
+	Summary *SubscriptionSummary `protobuf:"bytes,6,opt,name=summary" json:"summary,omitempty"`
+	// A secret identifying the session that owns the subscription,
+	// which must accompany subscription_id in UpdateSyscallIds. A
+	// stream that resumes the subscription with an idempotency key
+	// receives the same token. Only present in the first response of
+	// a stream.
+	SubscriptionToken string `protobuf:"bytes,8,opt,name=subscription_token,json=subscriptionToken" json:"subscription_token,omitempty"`
 }
 
 func (m *GetEventsResponse) Reset()                    { *m = GetEventsResponse{} }
@@ -51,18 +178,1074 @@ func (m *GetEventsResponse) String() string            { return proto.CompactTex
 func (*GetEventsResponse) ProtoMessage()               {}
 func (*GetEventsResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{1} }
 
-func (m *GetEventsResponse) GetEvents() []*ReceivedTelemetryEvent {
+func (m *GetEventsResponse) GetEvents() []*ReceivedTelemetryEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *GetEventsResponse) GetStatuses() []*google_rpc.Status {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+func (m *GetEventsResponse) GetDictionary() []*DictionaryEntry {
+	if m != nil {
+		return m.Dictionary
+	}
+	return nil
+}
+
+func (m *GetEventsResponse) GetSubscriptionId() int32 {
+	if m != nil {
+		return m.SubscriptionId
+	}
+	return 0
+}
+
+func (m *GetEventsResponse) GetSubscriptionTags() map[string]string {
+	if m != nil {
+		return m.SubscriptionTags
+	}
+	return nil
+}
+
+func (m *GetEventsResponse) GetPerfClock() PerfClock {
+	if m != nil {
+		return m.PerfClock
+	}
+	return PerfClock_PERF_CLOCK_DEFAULT
+}
+
+func (m *GetEventsResponse) GetSummary() *SubscriptionSummary {
+	if m != nil {
+		return m.Summary
+	}
+	return nil
+}
+
+func (m *GetEventsResponse) GetSubscriptionToken() string {
+	if m != nil {
+		return m.SubscriptionToken
+	}
+	return ""
+}
+
+// A summary of the events of a subscription when it ends
+type SubscriptionSummary struct {
+	// Why the subscription ended, e.g. "client disconnected"
+	Reason string `protobuf:"bytes,1,opt,name=reason" json:"reason,omitempty"`
+	// Nanoseconds between the creation of the subscription and its end
+	DurationNanos int64 `protobuf:"varint,2,opt,name=duration_nanos,json=durationNanos" json:"duration_nanos,omitempty"`
+	// Number of events sent to the client
+	EventsDelivered uint64 `protobuf:"varint,3,opt,name=events_delivered,json=eventsDelivered" json:"events_delivered,omitempty"`
+	// Number of events discarded because the subscription's buffer was
+	// full, the Sensor was overloaded, or the subscription's
+	// AckThrottle throttled them
+	EventsDropped uint64 `protobuf:"varint,4,opt,name=events_dropped,json=eventsDropped" json:"events_dropped,omitempty"`
+	// Number of events buffered for the client that had not been sent
+	// when the subscription ended
+	EventsLost uint64 `protobuf:"varint,5,opt,name=events_lost,json=eventsLost" json:"events_lost,omitempty"`
+	// Number of events sent to the client by event type, e.g. "syscall"
+	EventTypeCounts map[string]uint64 `protobuf:"bytes,6,rep,name=event_type_counts,json=eventTypeCounts" json:"event_type_counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// The number of events discarded for each reason, including those
+	// counted by events_dropped. Reasons with no discarded events are
+	// omitted.
+	Drops []*DropCount `protobuf:"bytes,7,rep,name=drops" json:"drops,omitempty"`
+}
+
+func (m *SubscriptionSummary) Reset()                    { *m = SubscriptionSummary{} }
+func (m *SubscriptionSummary) String() string            { return proto.CompactTextString(m) }
+func (*SubscriptionSummary) ProtoMessage()               {}
+func (*SubscriptionSummary) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{2} }
+
+func (m *SubscriptionSummary) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *SubscriptionSummary) GetDurationNanos() int64 {
+	if m != nil {
+		return m.DurationNanos
+	}
+	return 0
+}
+
+func (m *SubscriptionSummary) GetEventsDelivered() uint64 {
+	if m != nil {
+		return m.EventsDelivered
+	}
+	return 0
+}
+
+func (m *SubscriptionSummary) GetEventsDropped() uint64 {
+	if m != nil {
+		return m.EventsDropped
+	}
+	return 0
+}
+
+func (m *SubscriptionSummary) GetEventsLost() uint64 {
+	if m != nil {
+		return m.EventsLost
+	}
+	return 0
+}
+
+func (m *SubscriptionSummary) GetEventTypeCounts() map[string]uint64 {
+	if m != nil {
+		return m.EventTypeCounts
+	}
+	return nil
+}
+
+func (m *SubscriptionSummary) GetDrops() []*DropCount {
+	if m != nil {
+		return m.Drops
+	}
+	return nil
+}
+
+// The number of a subscription's events discarded for one reason
+type DropCount struct {
+	Reason DropReason `protobuf:"varint,1,opt,name=reason,enum=capsule8.api.v0.DropReason" json:"reason,omitempty"`
+	Count  uint64     `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *DropCount) Reset()                    { *m = DropCount{} }
+func (m *DropCount) String() string            { return proto.CompactTextString(m) }
+func (*DropCount) ProtoMessage()               {}
+func (*DropCount) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{3} }
+
+func (m *DropCount) GetReason() DropReason {
+	if m != nil {
+		return m.Reason
+	}
+	return DropReason_DROP_REASON_UNKNOWN
+}
+
+func (m *DropCount) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// A string value added to a GetEvents stream's dictionary
+type DictionaryEntry struct {
+	// The index used to reference the value. Indexes start at 1.
+	Index uint32 `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
+	// The value
+	Value string `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *DictionaryEntry) Reset()                    { *m = DictionaryEntry{} }
+func (m *DictionaryEntry) String() string            { return proto.CompactTextString(m) }
+func (*DictionaryEntry) ProtoMessage()               {}
+func (*DictionaryEntry) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{4} }
+
+func (m *DictionaryEntry) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *DictionaryEntry) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+// Dictionary indexes of TelemetryEvent fields that were elided from a
+// dictionary encoded event. A zero index means that the field was not
+// encoded and its value is present in the event itself.
+type DictionaryReferences struct {
+	ProcessId     uint32 `protobuf:"varint,1,opt,name=process_id,json=processId" json:"process_id,omitempty"`
+	ContainerId   uint32 `protobuf:"varint,2,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
+	SensorId      uint32 `protobuf:"varint,3,opt,name=sensor_id,json=sensorId" json:"sensor_id,omitempty"`
+	ContainerName uint32 `protobuf:"varint,4,opt,name=container_name,json=containerName" json:"container_name,omitempty"`
+	ImageId       uint32 `protobuf:"varint,5,opt,name=image_id,json=imageId" json:"image_id,omitempty"`
+	ImageName     uint32 `protobuf:"varint,6,opt,name=image_name,json=imageName" json:"image_name,omitempty"`
+}
+
+func (m *DictionaryReferences) Reset()                    { *m = DictionaryReferences{} }
+func (m *DictionaryReferences) String() string            { return proto.CompactTextString(m) }
+func (*DictionaryReferences) ProtoMessage()               {}
+func (*DictionaryReferences) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{5} }
+
+func (m *DictionaryReferences) GetProcessId() uint32 {
+	if m != nil {
+		return m.ProcessId
+	}
+	return 0
+}
+
+func (m *DictionaryReferences) GetContainerId() uint32 {
+	if m != nil {
+		return m.ContainerId
+	}
+	return 0
+}
+
+func (m *DictionaryReferences) GetSensorId() uint32 {
+	if m != nil {
+		return m.SensorId
+	}
+	return 0
+}
+
+func (m *DictionaryReferences) GetContainerName() uint32 {
+	if m != nil {
+		return m.ContainerName
+	}
+	return 0
+}
+
+func (m *DictionaryReferences) GetImageId() uint32 {
+	if m != nil {
+		return m.ImageId
+	}
+	return 0
+}
+
+func (m *DictionaryReferences) GetImageName() uint32 {
+	if m != nil {
+		return m.ImageName
+	}
+	return 0
+}
+
+// A request message to retrieve the capabilities of a Sensor
+type GetCapabilitiesRequest struct {
+}
+
+func (m *GetCapabilitiesRequest) Reset()                    { *m = GetCapabilitiesRequest{} }
+func (m *GetCapabilitiesRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetCapabilitiesRequest) ProtoMessage()               {}
+func (*GetCapabilitiesRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{6} }
+
+// A response message describing the capabilities of a Sensor
+type GetCapabilitiesResponse struct {
+	// true if the struct pt_regs offsets used to capture system call
+	// arguments were derived from the kernel's BTF type information
+	// rather than built-in defaults.
+	BtfSyscallArgOffsets bool `protobuf:"varint,1,opt,name=btf_syscall_arg_offsets,json=btfSyscallArgOffsets" json:"btf_syscall_arg_offsets,omitempty"`
+	// true if the Sensor supports dictionary encoding of GetEvents
+	// streams.
+	DictionaryEncoding bool `protobuf:"varint,2,opt,name=dictionary_encoding,json=dictionaryEncoding" json:"dictionary_encoding,omitempty"`
+	// true if the tracing filesystem (tracefs or debugfs) is available.
+	// If false, file, kernel function call, network, and system call
+	// events cannot be monitored.
+	TracingAvailable bool `protobuf:"varint,3,opt,name=tracing_available,json=tracingAvailable" json:"tracing_available,omitempty"`
+}
+
+func (m *GetCapabilitiesResponse) Reset()                    { *m = GetCapabilitiesResponse{} }
+func (m *GetCapabilitiesResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetCapabilitiesResponse) ProtoMessage()               {}
+func (*GetCapabilitiesResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{7} }
+
+func (m *GetCapabilitiesResponse) GetBtfSyscallArgOffsets() bool {
+	if m != nil {
+		return m.BtfSyscallArgOffsets
+	}
+	return false
+}
+
+func (m *GetCapabilitiesResponse) GetDictionaryEncoding() bool {
+	if m != nil {
+		return m.DictionaryEncoding
+	}
+	return false
+}
+
+func (m *GetCapabilitiesResponse) GetTracingAvailable() bool {
+	if m != nil {
+		return m.TracingAvailable
+	}
+	return false
+}
+
+// A request message to retrieve statistics from a Sensor
+type GetStatisticsRequest struct {
+}
+
+func (m *GetStatisticsRequest) Reset()                    { *m = GetStatisticsRequest{} }
+func (m *GetStatisticsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetStatisticsRequest) ProtoMessage()               {}
+func (*GetStatisticsRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{8} }
+
+// A response message containing statistics from a Sensor
+type GetStatisticsResponse struct {
+	// Where the filters of all active subscriptions are evaluated
+	Filters *FilterStatistics `protobuf:"bytes,1,opt,name=filters" json:"filters,omitempty"`
+	// The number of lazy subscriptions that are waiting for a matching
+	// container to run.
+	PendingLazySubscriptions uint32 `protobuf:"varint,2,opt,name=pending_lazy_subscriptions,json=pendingLazySubscriptions" json:"pending_lazy_subscriptions,omitempty"`
+	// The number of lazy subscriptions that have a matching container
+	// running, and so have their events enabled.
+	ActiveLazySubscriptions uint32 `protobuf:"varint,3,opt,name=active_lazy_subscriptions,json=activeLazySubscriptions" json:"active_lazy_subscriptions,omitempty"`
+	// The time spent on the events of each system call since the
+	// Sensor started, across all subscriptions, most expensive first
+	SyscallCosts []*SyscallCost `protobuf:"bytes,4,rep,name=syscall_costs,json=syscallCosts" json:"syscall_costs,omitempty"`
+	// The state of ack throttling for each active subscription that
+	// requested it
+	AckThrottles []*AckThrottleStatistics `protobuf:"bytes,5,rep,name=ack_throttles,json=ackThrottles" json:"ack_throttles,omitempty"`
+	// The activity of each of the Sensor's dispatch goroutines
+	DispatchWorkers []*DispatchWorkerStatistics `protobuf:"bytes,6,rep,name=dispatch_workers,json=dispatchWorkers" json:"dispatch_workers,omitempty"`
+	// The rate of telemetry sent to subscriptions and the events shed
+	// by max_egress_bytes_per_second
+	Egress *EgressStatistics `protobuf:"bytes,7,opt,name=egress" json:"egress,omitempty"`
+	// The records lost because the Sensor's ring buffers were full
+	RingBuffers *RingBufferStatistics `protobuf:"bytes,8,opt,name=ring_buffers,json=ringBuffers" json:"ring_buffers,omitempty"`
+	// The events discarded by each active subscription, by reason
+	SubscriptionDrops []*SubscriptionDropStatistics `protobuf:"bytes,9,rep,name=subscription_drops,json=subscriptionDrops" json:"subscription_drops,omitempty"`
+	// The Sensor's own CPU usage and the events shed by max_cpu_percent
+	Cpu *CPUStatistics `protobuf:"bytes,10,opt,name=cpu" json:"cpu,omitempty"`
+	// The decoding of the kernel events of each active subscription
+	// and of the Sensor's own events
+	Decoding []*DecodeStatistics `protobuf:"bytes,11,rep,name=decoding" json:"decoding,omitempty"`
+}
+
+func (m *GetStatisticsResponse) Reset()                    { *m = GetStatisticsResponse{} }
+func (m *GetStatisticsResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetStatisticsResponse) ProtoMessage()               {}
+func (*GetStatisticsResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{9} }
+
+func (m *GetStatisticsResponse) GetFilters() *FilterStatistics {
+	if m != nil {
+		return m.Filters
+	}
+	return nil
+}
+
+func (m *GetStatisticsResponse) GetPendingLazySubscriptions() uint32 {
+	if m != nil {
+		return m.PendingLazySubscriptions
+	}
+	return 0
+}
+
+func (m *GetStatisticsResponse) GetActiveLazySubscriptions() uint32 {
+	if m != nil {
+		return m.ActiveLazySubscriptions
+	}
+	return 0
+}
+
+func (m *GetStatisticsResponse) GetSyscallCosts() []*SyscallCost {
+	if m != nil {
+		return m.SyscallCosts
+	}
+	return nil
+}
+
+func (m *GetStatisticsResponse) GetAckThrottles() []*AckThrottleStatistics {
+	if m != nil {
+		return m.AckThrottles
+	}
+	return nil
+}
+
+func (m *GetStatisticsResponse) GetDispatchWorkers() []*DispatchWorkerStatistics {
+	if m != nil {
+		return m.DispatchWorkers
+	}
+	return nil
+}
+
+func (m *GetStatisticsResponse) GetEgress() *EgressStatistics {
+	if m != nil {
+		return m.Egress
+	}
+	return nil
+}
+
+func (m *GetStatisticsResponse) GetRingBuffers() *RingBufferStatistics {
+	if m != nil {
+		return m.RingBuffers
+	}
+	return nil
+}
+
+func (m *GetStatisticsResponse) GetSubscriptionDrops() []*SubscriptionDropStatistics {
+	if m != nil {
+		return m.SubscriptionDrops
+	}
+	return nil
+}
+
+func (m *GetStatisticsResponse) GetCpu() *CPUStatistics {
+	if m != nil {
+		return m.Cpu
+	}
+	return nil
+}
+
+func (m *GetStatisticsResponse) GetDecoding() []*DecodeStatistics {
+	if m != nil {
+		return m.Decoding
+	}
+	return nil
+}
+
+// DecodeStatistics describes the decoding of the kernel events of a
+// subscription, or of the Sensor's own events, since it started
+type DecodeStatistics struct {
+	// The ID of the subscription, as returned in its first
+	// GetEventsResponse, or of the Sensor's own event group
+	SubscriptionId int32 `protobuf:"varint,1,opt,name=subscription_id,json=subscriptionId" json:"subscription_id,omitempty"`
+	// True if the events are decoded on a goroutine of their own
+	// rather than the Sensor's shared decoding goroutine
+	Isolated bool `protobuf:"varint,2,opt,name=isolated" json:"isolated,omitempty"`
+	// The number of events decoded
+	Events uint64 `protobuf:"varint,3,opt,name=events" json:"events,omitempty"`
+	// The total time in nanoseconds spent decoding the events
+	DecodeNanos uint64 `protobuf:"varint,4,opt,name=decode_nanos,json=decodeNanos" json:"decode_nanos,omitempty"`
+	// The mean and maximum time in nanoseconds from when events were
+	// read from the kernel's ring buffers until they were decoded,
+	// including the time spent waiting for the decoding of other
+	// events
+	MeanLatencyNanos uint64 `protobuf:"varint,5,opt,name=mean_latency_nanos,json=meanLatencyNanos" json:"mean_latency_nanos,omitempty"`
+	MaxLatencyNanos  uint64 `protobuf:"varint,6,opt,name=max_latency_nanos,json=maxLatencyNanos" json:"max_latency_nanos,omitempty"`
+	// The number of batches of events waiting to be decoded on an
+	// isolated subscription's goroutine
+	QueuedBatches uint32 `protobuf:"varint,7,opt,name=queued_batches,json=queuedBatches" json:"queued_batches,omitempty"`
+	// The number of events dropped because an isolated subscription's
+	// goroutine fell too far behind
+	DroppedEvents uint64 `protobuf:"varint,8,opt,name=dropped_events,json=droppedEvents" json:"dropped_events,omitempty"`
+}
+
+func (m *DecodeStatistics) Reset()                    { *m = DecodeStatistics{} }
+func (m *DecodeStatistics) String() string            { return proto.CompactTextString(m) }
+func (*DecodeStatistics) ProtoMessage()               {}
+func (*DecodeStatistics) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{10} }
+
+func (m *DecodeStatistics) GetSubscriptionId() int32 {
+	if m != nil {
+		return m.SubscriptionId
+	}
+	return 0
+}
+
+func (m *DecodeStatistics) GetIsolated() bool {
+	if m != nil {
+		return m.Isolated
+	}
+	return false
+}
+
+func (m *DecodeStatistics) GetEvents() uint64 {
+	if m != nil {
+		return m.Events
+	}
+	return 0
+}
+
+func (m *DecodeStatistics) GetDecodeNanos() uint64 {
+	if m != nil {
+		return m.DecodeNanos
+	}
+	return 0
+}
+
+func (m *DecodeStatistics) GetMeanLatencyNanos() uint64 {
+	if m != nil {
+		return m.MeanLatencyNanos
+	}
+	return 0
+}
+
+func (m *DecodeStatistics) GetMaxLatencyNanos() uint64 {
+	if m != nil {
+		return m.MaxLatencyNanos
+	}
+	return 0
+}
+
+func (m *DecodeStatistics) GetQueuedBatches() uint32 {
+	if m != nil {
+		return m.QueuedBatches
+	}
+	return 0
+}
+
+func (m *DecodeStatistics) GetDroppedEvents() uint64 {
+	if m != nil {
+		return m.DroppedEvents
+	}
+	return 0
+}
+
+// SubscriptionDropStatistics counts the events of an active subscription that
+// have been discarded
+type SubscriptionDropStatistics struct {
+	// The ID of the subscription, as returned in its first
+	// GetEventsResponse
+	SubscriptionId int32 `protobuf:"varint,1,opt,name=subscription_id,json=subscriptionId" json:"subscription_id,omitempty"`
+	// The number of events discarded for each reason. Reasons with no
+	// discarded events are omitted.
+	Drops []*DropCount `protobuf:"bytes,2,rep,name=drops" json:"drops,omitempty"`
+}
+
+func (m *SubscriptionDropStatistics) Reset()                    { *m = SubscriptionDropStatistics{} }
+func (m *SubscriptionDropStatistics) String() string            { return proto.CompactTextString(m) }
+func (*SubscriptionDropStatistics) ProtoMessage()               {}
+func (*SubscriptionDropStatistics) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{11} }
+
+func (m *SubscriptionDropStatistics) GetSubscriptionId() int32 {
+	if m != nil {
+		return m.SubscriptionId
+	}
+	return 0
+}
+
+func (m *SubscriptionDropStatistics) GetDrops() []*DropCount {
+	if m != nil {
+		return m.Drops
+	}
+	return nil
+}
+
+// SyscallCost is the time that the Sensor has spent on the events of a
+// system call. Times are measured on the Sensor's decoding and dispatch
+// goroutines, and so approximate the CPU time used.
+type SyscallCost struct {
+	// The system call number
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// The number of enter and exit events decoded for the system call
+	Events uint64 `protobuf:"varint,2,opt,name=events" json:"events,omitempty"`
+	// The total time in nanoseconds spent decoding the events
+	DecodeNanos uint64 `protobuf:"varint,3,opt,name=decode_nanos,json=decodeNanos" json:"decode_nanos,omitempty"`
+	// The total time in nanoseconds spent filtering the events and
+	// delivering them to subscriptions
+	DeliveryNanos uint64 `protobuf:"varint,4,opt,name=delivery_nanos,json=deliveryNanos" json:"delivery_nanos,omitempty"`
+	// The fraction of the time spent decoding all system call events
+	// that was spent on this system call
+	DecodeFraction float64 `protobuf:"fixed64,5,opt,name=decode_fraction,json=decodeFraction" json:"decode_fraction,omitempty"`
+}
+
+func (m *SyscallCost) Reset()                    { *m = SyscallCost{} }
+func (m *SyscallCost) String() string            { return proto.CompactTextString(m) }
+func (*SyscallCost) ProtoMessage()               {}
+func (*SyscallCost) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{12} }
+
+func (m *SyscallCost) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *SyscallCost) GetEvents() uint64 {
+	if m != nil {
+		return m.Events
+	}
+	return 0
+}
+
+func (m *SyscallCost) GetDecodeNanos() uint64 {
+	if m != nil {
+		return m.DecodeNanos
+	}
+	return 0
+}
+
+func (m *SyscallCost) GetDeliveryNanos() uint64 {
+	if m != nil {
+		return m.DeliveryNanos
+	}
+	return 0
+}
+
+func (m *SyscallCost) GetDecodeFraction() float64 {
+	if m != nil {
+		return m.DecodeFraction
+	}
+	return 0
+}
+
+// AckThrottleStatistics is the state of a subscription's AckThrottle
+type AckThrottleStatistics struct {
+	// The ID of the subscription, as returned in its first
+	// GetEventsResponse
+	SubscriptionId int32 `protobuf:"varint,1,opt,name=subscription_id,json=subscriptionId" json:"subscription_id,omitempty"`
+	// The moving average of the delivery latency of the subscription's
+	// events in nanoseconds
+	AckLatencyNanos int64 `protobuf:"varint,2,opt,name=ack_latency_nanos,json=ackLatencyNanos" json:"ack_latency_nanos,omitempty"`
+	// Whether the subscription's events are currently being throttled
+	Throttled bool `protobuf:"varint,3,opt,name=throttled" json:"throttled,omitempty"`
+	// The number of events not returned because of throttling
+	ThrottledEvents uint64 `protobuf:"varint,4,opt,name=throttled_events,json=throttledEvents" json:"throttled_events,omitempty"`
+}
+
+func (m *AckThrottleStatistics) Reset()                    { *m = AckThrottleStatistics{} }
+func (m *AckThrottleStatistics) String() string            { return proto.CompactTextString(m) }
+func (*AckThrottleStatistics) ProtoMessage()               {}
+func (*AckThrottleStatistics) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{13} }
+
+func (m *AckThrottleStatistics) GetSubscriptionId() int32 {
+	if m != nil {
+		return m.SubscriptionId
+	}
+	return 0
+}
+
+func (m *AckThrottleStatistics) GetAckLatencyNanos() int64 {
+	if m != nil {
+		return m.AckLatencyNanos
+	}
+	return 0
+}
+
+func (m *AckThrottleStatistics) GetThrottled() bool {
+	if m != nil {
+		return m.Throttled
+	}
+	return false
+}
+
+func (m *AckThrottleStatistics) GetThrottledEvents() uint64 {
+	if m != nil {
+		return m.ThrottledEvents
+	}
+	return 0
+}
+
+// DispatchWorkerStatistics describes the activity of one of the goroutines
+// that deliver events to subscriptions since it was started.
+type DispatchWorkerStatistics struct {
+	// The index of the goroutine, which determines the processes whose
+	// events it delivers
+	Index uint32 `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
+	// The number of batches of samples waiting to be dispatched
+	QueuedBatches uint32 `protobuf:"varint,2,opt,name=queued_batches,json=queuedBatches" json:"queued_batches,omitempty"`
+	// The number of batches and samples dispatched
+	Batches uint64 `protobuf:"varint,3,opt,name=batches" json:"batches,omitempty"`
+	Samples uint64 `protobuf:"varint,4,opt,name=samples" json:"samples,omitempty"`
+	// The time in nanoseconds spent dispatching samples
+	BusyNanos uint64 `protobuf:"varint,5,opt,name=busy_nanos,json=busyNanos" json:"busy_nanos,omitempty"`
+	// The fraction of the time since the goroutine was started that
+	// was spent dispatching samples
+	Utilization float64 `protobuf:"fixed64,6,opt,name=utilization" json:"utilization,omitempty"`
+}
+
+func (m *DispatchWorkerStatistics) Reset()                    { *m = DispatchWorkerStatistics{} }
+func (m *DispatchWorkerStatistics) String() string            { return proto.CompactTextString(m) }
+func (*DispatchWorkerStatistics) ProtoMessage()               {}
+func (*DispatchWorkerStatistics) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{14} }
+
+func (m *DispatchWorkerStatistics) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *DispatchWorkerStatistics) GetQueuedBatches() uint32 {
+	if m != nil {
+		return m.QueuedBatches
+	}
+	return 0
+}
+
+func (m *DispatchWorkerStatistics) GetBatches() uint64 {
+	if m != nil {
+		return m.Batches
+	}
+	return 0
+}
+
+func (m *DispatchWorkerStatistics) GetSamples() uint64 {
+	if m != nil {
+		return m.Samples
+	}
+	return 0
+}
+
+func (m *DispatchWorkerStatistics) GetBusyNanos() uint64 {
+	if m != nil {
+		return m.BusyNanos
+	}
+	return 0
+}
+
+func (m *DispatchWorkerStatistics) GetUtilization() float64 {
+	if m != nil {
+		return m.Utilization
+	}
+	return 0
+}
+
+// EgressStatistics describes the telemetry sent to all subscriptions since
+// the Sensor started. Events are measured before dictionary encoding.
+type EgressStatistics struct {
+	// The limit in effect, or 0 for no limit
+	LimitBytesPerSecond uint64 `protobuf:"varint,1,opt,name=limit_bytes_per_second,json=limitBytesPerSecond" json:"limit_bytes_per_second,omitempty"`
+	// The rate of telemetry sent over the most recent second
+	RateBytesPerSecond float64 `protobuf:"fixed64,2,opt,name=rate_bytes_per_second,json=rateBytesPerSecond" json:"rate_bytes_per_second,omitempty"`
+	// The total number of bytes of telemetry sent
+	SentBytes uint64 `protobuf:"varint,3,opt,name=sent_bytes,json=sentBytes" json:"sent_bytes,omitempty"`
+	// The number of events, and their bytes, not sent because of the
+	// limit
+	ShedEvents uint64 `protobuf:"varint,4,opt,name=shed_events,json=shedEvents" json:"shed_events,omitempty"`
+	ShedBytes  uint64 `protobuf:"varint,5,opt,name=shed_bytes,json=shedBytes" json:"shed_bytes,omitempty"`
+}
+
+func (m *EgressStatistics) Reset()                    { *m = EgressStatistics{} }
+func (m *EgressStatistics) String() string            { return proto.CompactTextString(m) }
+func (*EgressStatistics) ProtoMessage()               {}
+func (*EgressStatistics) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{15} }
+
+func (m *EgressStatistics) GetLimitBytesPerSecond() uint64 {
+	if m != nil {
+		return m.LimitBytesPerSecond
+	}
+	return 0
+}
+
+func (m *EgressStatistics) GetRateBytesPerSecond() float64 {
+	if m != nil {
+		return m.RateBytesPerSecond
+	}
+	return 0
+}
+
+func (m *EgressStatistics) GetSentBytes() uint64 {
+	if m != nil {
+		return m.SentBytes
+	}
+	return 0
+}
+
+func (m *EgressStatistics) GetShedEvents() uint64 {
+	if m != nil {
+		return m.ShedEvents
+	}
+	return 0
+}
+
+func (m *EgressStatistics) GetShedBytes() uint64 {
+	if m != nil {
+		return m.ShedBytes
+	}
+	return 0
+}
+
+// CPUStatistics describes the CPU time used by the Sensor itself, as measured
+// by getrusage(2), which includes all of its threads.
+type CPUStatistics struct {
+	// The limit in effect, or 0 for no limit
+	LimitPercent float64 `protobuf:"fixed64,1,opt,name=limit_percent,json=limitPercent" json:"limit_percent,omitempty"`
+	// The CPU usage measured over the most recent second, as a
+	// percentage of one CPU
+	UsagePercent float64 `protobuf:"fixed64,2,opt,name=usage_percent,json=usagePercent" json:"usage_percent,omitempty"`
+	// How far the Sensor has degraded its telemetry to stay under the
+	// limit. At level 0, no events are shed. At level 1, the events of
+	// low priority subscriptions are shed. At each level above that,
+	// the events of normal priority subscriptions are also sampled at
+	// half the rate of the level below. The events of high priority
+	// subscriptions are never shed.
+	DegradationLevel uint32 `protobuf:"varint,3,opt,name=degradation_level,json=degradationLevel" json:"degradation_level,omitempty"`
+	// The number of events not delivered because of the limit
+	ShedEvents uint64 `protobuf:"varint,4,opt,name=shed_events,json=shedEvents" json:"shed_events,omitempty"`
+}
+
+func (m *CPUStatistics) Reset()                    { *m = CPUStatistics{} }
+func (m *CPUStatistics) String() string            { return proto.CompactTextString(m) }
+func (*CPUStatistics) ProtoMessage()               {}
+func (*CPUStatistics) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{16} }
+
+func (m *CPUStatistics) GetLimitPercent() float64 {
+	if m != nil {
+		return m.LimitPercent
+	}
+	return 0
+}
+
+func (m *CPUStatistics) GetUsagePercent() float64 {
+	if m != nil {
+		return m.UsagePercent
+	}
+	return 0
+}
+
+func (m *CPUStatistics) GetDegradationLevel() uint32 {
+	if m != nil {
+		return m.DegradationLevel
+	}
+	return 0
+}
+
+func (m *CPUStatistics) GetShedEvents() uint64 {
+	if m != nil {
+		return m.ShedEvents
+	}
+	return 0
+}
+
+// RingBufferStatistics describes the overflows of the Sensor's ring buffers
+// since it started. Once an overflowing ring buffer has been drained, the
+// events writing to it are enabled again, since some kernels disable them
+// when it is full.
+type RingBufferStatistics struct {
+	// The number of times that records were lost from a ring buffer
+	Overflows uint64 `protobuf:"varint,1,opt,name=overflows" json:"overflows,omitempty"`
+	// The number of records lost
+	LostRecords uint64 `protobuf:"varint,2,opt,name=lost_records,json=lostRecords" json:"lost_records,omitempty"`
+	// The number of events enabled again after overflows
+	ReenabledEvents uint64 `protobuf:"varint,3,opt,name=reenabled_events,json=reenabledEvents" json:"reenabled_events,omitempty"`
+}
+
+func (m *RingBufferStatistics) Reset()                    { *m = RingBufferStatistics{} }
+func (m *RingBufferStatistics) String() string            { return proto.CompactTextString(m) }
+func (*RingBufferStatistics) ProtoMessage()               {}
+func (*RingBufferStatistics) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{17} }
+
+func (m *RingBufferStatistics) GetOverflows() uint64 {
+	if m != nil {
+		return m.Overflows
+	}
+	return 0
+}
+
+func (m *RingBufferStatistics) GetLostRecords() uint64 {
+	if m != nil {
+		return m.LostRecords
+	}
+	return 0
+}
+
+func (m *RingBufferStatistics) GetReenabledEvents() uint64 {
+	if m != nil {
+		return m.ReenabledEvents
+	}
+	return 0
+}
+
+type GetCountsRequest struct {
+	// The length of time to count events over, ending now. It is
+	// rounded up to a whole number of the Sensor's count intervals. If
+	// zero or longer than the Sensor retains, all retained counts are
+	// returned.
+	DurationSeconds int64 `protobuf:"varint,1,opt,name=duration_seconds,json=durationSeconds" json:"duration_seconds,omitempty"`
+}
+
+func (m *GetCountsRequest) Reset()                    { *m = GetCountsRequest{} }
+func (m *GetCountsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetCountsRequest) ProtoMessage()               {}
+func (*GetCountsRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{18} }
+
+func (m *GetCountsRequest) GetDurationSeconds() int64 {
+	if m != nil {
+		return m.DurationSeconds
+	}
+	return 0
+}
+
+// A response message containing counts of the events dispatched by the
+// Sensor to subscriptions, per syscall and per container. Events are only
+// counted once the filters of some subscription select them, and each event
+// is counted once, however many subscriptions it is dispatched to.
+type GetCountsResponse struct {
+	// The Sensor monotimes at which the counted period starts and ends
+	StartMonotimeNanos int64 `protobuf:"varint,1,opt,name=start_monotime_nanos,json=startMonotimeNanos" json:"start_monotime_nanos,omitempty"`
+	EndMonotimeNanos   int64 `protobuf:"varint,2,opt,name=end_monotime_nanos,json=endMonotimeNanos" json:"end_monotime_nanos,omitempty"`
+	// The length in nanoseconds of the intervals that events are
+	// counted in
+	ResolutionNanos int64 `protobuf:"varint,3,opt,name=resolution_nanos,json=resolutionNanos" json:"resolution_nanos,omitempty"`
+	// The total number of events dispatched during the period
+	TotalEvents uint64 `protobuf:"varint,4,opt,name=total_events,json=totalEvents" json:"total_events,omitempty"`
+	// The number of syscalls made for each syscall, ordered by id
+	Syscalls []*SyscallCount `protobuf:"bytes,5,rep,name=syscalls" json:"syscalls,omitempty"`
+	// The number of events for each container, ordered by id
+	Containers []*ContainerCount `protobuf:"bytes,6,rep,name=containers" json:"containers,omitempty"`
+}
+
+func (m *GetCountsResponse) Reset()                    { *m = GetCountsResponse{} }
+func (m *GetCountsResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetCountsResponse) ProtoMessage()               {}
+func (*GetCountsResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{19} }
+
+func (m *GetCountsResponse) GetStartMonotimeNanos() int64 {
+	if m != nil {
+		return m.StartMonotimeNanos
+	}
+	return 0
+}
+
+func (m *GetCountsResponse) GetEndMonotimeNanos() int64 {
+	if m != nil {
+		return m.EndMonotimeNanos
+	}
+	return 0
+}
+
+func (m *GetCountsResponse) GetResolutionNanos() int64 {
+	if m != nil {
+		return m.ResolutionNanos
+	}
+	return 0
+}
+
+func (m *GetCountsResponse) GetTotalEvents() uint64 {
+	if m != nil {
+		return m.TotalEvents
+	}
+	return 0
+}
+
+func (m *GetCountsResponse) GetSyscalls() []*SyscallCount {
+	if m != nil {
+		return m.Syscalls
+	}
+	return nil
+}
+
+func (m *GetCountsResponse) GetContainers() []*ContainerCount {
+	if m != nil {
+		return m.Containers
+	}
+	return nil
+}
+
+// SyscallCount is the number of times that a syscall was made. A syscall
+// whose enter and exit events are both dispatched is counted once.
+type SyscallCount struct {
+	Id    int64  `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Count uint64 `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *SyscallCount) Reset()                    { *m = SyscallCount{} }
+func (m *SyscallCount) String() string            { return proto.CompactTextString(m) }
+func (*SyscallCount) ProtoMessage()               {}
+func (*SyscallCount) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{20} }
+
+func (m *SyscallCount) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *SyscallCount) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// ContainerCount is the number of events for a container
+type ContainerCount struct {
+	ContainerId string `protobuf:"bytes,1,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
+	Count       uint64 `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *ContainerCount) Reset()                    { *m = ContainerCount{} }
+func (m *ContainerCount) String() string            { return proto.CompactTextString(m) }
+func (*ContainerCount) ProtoMessage()               {}
+func (*ContainerCount) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{21} }
+
+func (m *ContainerCount) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+func (m *ContainerCount) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// FilterStatistics describes how many event sinks of active subscriptions
+// have their filters evaluated by the kernel versus by the Sensor in
+// userspace, and the cost of userspace evaluation.
+type FilterStatistics struct {
+	// The type of events that these statistics apply to, such as
+	// "syscall" or "file". Empty for the totals across all types.
+	EventType string `protobuf:"bytes,1,opt,name=event_type,json=eventType" json:"event_type,omitempty"`
+	// The number of event sinks whose filters are evaluated entirely by
+	// the kernel, including event sinks that have no filter.
+	KernelEventSinks uint32 `protobuf:"varint,2,opt,name=kernel_event_sinks,json=kernelEventSinks" json:"kernel_event_sinks,omitempty"`
+	// The number of event sinks whose filters are evaluated by the Sensor
+	// in userspace.
+	UserspaceEventSinks uint32 `protobuf:"varint,3,opt,name=userspace_event_sinks,json=userspaceEventSinks" json:"userspace_event_sinks,omitempty"`
+	// The number of userspace filter evaluations performed by the event
+	// sinks of active subscriptions.
+	UserspaceEvaluations uint64 `protobuf:"varint,4,opt,name=userspace_evaluations,json=userspaceEvaluations" json:"userspace_evaluations,omitempty"`
+	// The total time in nanoseconds spent performing userspace filter
+	// evaluations for the event sinks of active subscriptions.
+	UserspaceEvaluationNanos uint64 `protobuf:"varint,5,opt,name=userspace_evaluation_nanos,json=userspaceEvaluationNanos" json:"userspace_evaluation_nanos,omitempty"`
+	// Present only for the totals across all types. These are the
+	// statistics for each type of event.
+	EventTypes []*FilterStatistics `protobuf:"bytes,6,rep,name=event_types,json=eventTypes" json:"event_types,omitempty"`
+	// The number of the event sinks counted in userspace_event_sinks
+	// whose filters are evaluated in userspace only because the kernel
+	// could not accept them (see the Sensor's filter lowering policy).
+	FallbackEventSinks uint32 `protobuf:"varint,7,opt,name=fallback_event_sinks,json=fallbackEventSinks" json:"fallback_event_sinks,omitempty"`
+}
+
+func (m *FilterStatistics) Reset()                    { *m = FilterStatistics{} }
+func (m *FilterStatistics) String() string            { return proto.CompactTextString(m) }
+func (*FilterStatistics) ProtoMessage()               {}
+func (*FilterStatistics) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{22} }
+
+func (m *FilterStatistics) GetEventType() string {
+	if m != nil {
+		return m.EventType
+	}
+	return ""
+}
+
+func (m *FilterStatistics) GetKernelEventSinks() uint32 {
+	if m != nil {
+		return m.KernelEventSinks
+	}
+	return 0
+}
+
+func (m *FilterStatistics) GetUserspaceEventSinks() uint32 {
+	if m != nil {
+		return m.UserspaceEventSinks
+	}
+	return 0
+}
+
+func (m *FilterStatistics) GetUserspaceEvaluations() uint64 {
+	if m != nil {
+		return m.UserspaceEvaluations
+	}
+	return 0
+}
+
+func (m *FilterStatistics) GetUserspaceEvaluationNanos() uint64 {
+	if m != nil {
+		return m.UserspaceEvaluationNanos
+	}
+	return 0
+}
+
+func (m *FilterStatistics) GetEventTypes() []*FilterStatistics {
 	if m != nil {
-		return m.Events
+		return m.EventTypes
 	}
 	return nil
 }
 
-func (m *GetEventsResponse) GetStatuses() []*google_rpc.Status {
+func (m *FilterStatistics) GetFallbackEventSinks() uint32 {
 	if m != nil {
-		return m.Statuses
+		return m.FallbackEventSinks
 	}
-	return nil
+	return 0
 }
 
 // A telemetry event received from a Sensor or Recorder.
@@ -78,12 +1261,17 @@ type ReceivedTelemetryEvent struct {
 	// the PubsubService's Acknowledge method or else the TelemetryService
 	// will re-transmit the event.
 	Ack []byte `protobuf:"bytes,3,opt,name=ack,proto3" json:"ack,omitempty"`
+	// The dictionary indexes of fields elided from event when
+	// dictionary encoding is in use.
+	DictionaryReferences *DictionaryReferences `protobuf:"bytes,4,opt,name=dictionary_references,json=dictionaryReferences" json:"dictionary_references,omitempty"`
+	// The tags of the subscription that returned the event.
+	SubscriptionTags map[string]string `protobuf:"bytes,5,rep,name=subscription_tags,json=subscriptionTags" json:"subscription_tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 func (m *ReceivedTelemetryEvent) Reset()                    { *m = ReceivedTelemetryEvent{} }
 func (m *ReceivedTelemetryEvent) String() string            { return proto.CompactTextString(m) }
 func (*ReceivedTelemetryEvent) ProtoMessage()               {}
-func (*ReceivedTelemetryEvent) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{2} }
+func (*ReceivedTelemetryEvent) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{23} }
 
 func (m *ReceivedTelemetryEvent) GetPublishTimeMicros() int64 {
 	if m != nil {
@@ -106,10 +1294,373 @@ func (m *ReceivedTelemetryEvent) GetAck() []byte {
 	return nil
 }
 
+func (m *ReceivedTelemetryEvent) GetDictionaryReferences() *DictionaryReferences {
+	if m != nil {
+		return m.DictionaryReferences
+	}
+	return nil
+}
+
+func (m *ReceivedTelemetryEvent) GetSubscriptionTags() map[string]string {
+	if m != nil {
+		return m.SubscriptionTags
+	}
+	return nil
+}
+
+// A request message to add or remove system call ids from a subscription
+type UpdateSyscallIdsRequest struct {
+	// The subscription to update, as returned in its first
+	// GetEventsResponse
+	SubscriptionId int32 `protobuf:"varint,1,opt,name=subscription_id,json=subscriptionId" json:"subscription_id,omitempty"`
+	// The subscription_token returned along with subscription_id. The
+	// update is denied if it is not that of the subscription's session.
+	SubscriptionToken string `protobuf:"bytes,4,opt,name=subscription_token,json=subscriptionToken" json:"subscription_token,omitempty"`
+	// System call ids to match in addition to those already matched.
+	// The predicates of the syscall event filters on fields other than
+	// the id, including those of the default filter, still apply to
+	// them. Filters that are not a range of ids ANDed with such
+	// predicates cannot be updated.
+	AddIds []int64 `protobuf:"varint,2,rep,packed,name=add_ids,json=addIds" json:"add_ids,omitempty"`
+	// System call ids to no longer match.
+	RemoveIds []int64 `protobuf:"varint,3,rep,packed,name=remove_ids,json=removeIds" json:"remove_ids,omitempty"`
+}
+
+func (m *UpdateSyscallIdsRequest) Reset()                    { *m = UpdateSyscallIdsRequest{} }
+func (m *UpdateSyscallIdsRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateSyscallIdsRequest) ProtoMessage()               {}
+func (*UpdateSyscallIdsRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{24} }
+
+func (m *UpdateSyscallIdsRequest) GetSubscriptionId() int32 {
+	if m != nil {
+		return m.SubscriptionId
+	}
+	return 0
+}
+
+func (m *UpdateSyscallIdsRequest) GetSubscriptionToken() string {
+	if m != nil {
+		return m.SubscriptionToken
+	}
+	return ""
+}
+
+func (m *UpdateSyscallIdsRequest) GetAddIds() []int64 {
+	if m != nil {
+		return m.AddIds
+	}
+	return nil
+}
+
+func (m *UpdateSyscallIdsRequest) GetRemoveIds() []int64 {
+	if m != nil {
+		return m.RemoveIds
+	}
+	return nil
+}
+
+// A response message describing the updated syscall event filters
+type UpdateSyscallIdsResponse struct {
+	// The kernel filters now in effect for the subscription's syscall
+	// events.
+	KernelFilters []string `protobuf:"bytes,1,rep,name=kernel_filters,json=kernelFilters" json:"kernel_filters,omitempty"`
+}
+
+func (m *UpdateSyscallIdsResponse) Reset()                    { *m = UpdateSyscallIdsResponse{} }
+func (m *UpdateSyscallIdsResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateSyscallIdsResponse) ProtoMessage()               {}
+func (*UpdateSyscallIdsResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{25} }
+
+func (m *UpdateSyscallIdsResponse) GetKernelFilters() []string {
+	if m != nil {
+		return m.KernelFilters
+	}
+	return nil
+}
+
+// A request message for the Sensor's global limits
+type GetLimitsRequest struct {
+}
+
+func (m *GetLimitsRequest) Reset()                    { *m = GetLimitsRequest{} }
+func (m *GetLimitsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetLimitsRequest) ProtoMessage()               {}
+func (*GetLimitsRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{26} }
+
+// SensorLimits are the Sensor's global limits. They are initially set from
+// the Sensor's configuration and may be changed at runtime with
+// UpdateLimits.
+type SensorLimits struct {
+	// The maximum number of subscriptions that may be active at once,
+	// or 0 for no limit. Lowering it does not cancel subscriptions
+	// that are already active.
+	MaxSubscriptions uint32 `protobuf:"varint,1,opt,name=max_subscriptions,json=maxSubscriptions" json:"max_subscriptions,omitempty"`
+	// The number of events buffered for each new subscription before
+	// events are dropped
+	ChannelBufferLength uint32 `protobuf:"varint,2,opt,name=channel_buffer_length,json=channelBufferLength" json:"channel_buffer_length,omitempty"`
+	// The number of batches of samples waiting to be dispatched by a
+	// dispatch goroutine at which low priority subscriptions have their
+	// events shed by it
+	DispatchBacklogThreshold uint32 `protobuf:"varint,3,opt,name=dispatch_backlog_threshold,json=dispatchBacklogThreshold" json:"dispatch_backlog_threshold,omitempty"`
+	// The maximum rate of syscall events per second delivered for any
+	// single (pid, syscall) pair, or 0 for no limit. Changes apply to
+	// existing subscriptions.
+	SyscallRateLimit float64 `protobuf:"fixed64,4,opt,name=syscall_rate_limit,json=syscallRateLimit" json:"syscall_rate_limit,omitempty"`
+	// The number of syscall events that a (pid, syscall) pair may
+	// burst above syscall_rate_limit
+	SyscallRateLimitBurst float64 `protobuf:"fixed64,5,opt,name=syscall_rate_limit_burst,json=syscallRateLimitBurst" json:"syscall_rate_limit_burst,omitempty"`
+	// The number of pages in each of the kernel's perf ring buffers,
+	// which must be a power of 2. Each subscription's ring buffers are
+	// created with it, so changes apply to subscriptions created
+	// afterward.
+	RingBufferPages uint32 `protobuf:"varint,6,opt,name=ring_buffer_pages,json=ringBufferPages" json:"ring_buffer_pages,omitempty"`
+	// The number of goroutines that deliver events to subscriptions.
+	// The events of each process are delivered by the same goroutine,
+	// except briefly after this is changed.
+	DispatchWorkers uint32 `protobuf:"varint,7,opt,name=dispatch_workers,json=dispatchWorkers" json:"dispatch_workers,omitempty"`
+	// The maximum number of batches of samples queued for each
+	// dispatch goroutine, or 0 for no limit
+	DispatchQueueDepth uint32 `protobuf:"varint,8,opt,name=dispatch_queue_depth,json=dispatchQueueDepth" json:"dispatch_queue_depth,omitempty"`
+	// The maximum total rate of telemetry sent to all subscriptions, in
+	// bytes of serialized events per second, or 0 for no limit. Events
+	// are measured before dictionary encoding. When the limit is
+	// reached, events are shed from subscriptions using more than their
+	// share of it, which is weighted by priority.
+	MaxEgressBytesPerSecond uint64 `protobuf:"varint,9,opt,name=max_egress_bytes_per_second,json=maxEgressBytesPerSecond" json:"max_egress_bytes_per_second,omitempty"`
+	// The maximum CPU usage of the Sensor itself, as a percentage of
+	// one CPU, or 0 for no limit. Usage is measured every second. As it
+	// approaches the limit, the Sensor progressively sheds the events
+	// of low priority subscriptions and then samples those of normal
+	// priority subscriptions, sending them a status each time that it
+	// changes how much it sheds.
+	MaxCpuPercent float64 `protobuf:"fixed64,10,opt,name=max_cpu_percent,json=maxCpuPercent" json:"max_cpu_percent,omitempty"`
+}
+
+func (m *SensorLimits) Reset()                    { *m = SensorLimits{} }
+func (m *SensorLimits) String() string            { return proto.CompactTextString(m) }
+func (*SensorLimits) ProtoMessage()               {}
+func (*SensorLimits) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{27} }
+
+func (m *SensorLimits) GetMaxSubscriptions() uint32 {
+	if m != nil {
+		return m.MaxSubscriptions
+	}
+	return 0
+}
+
+func (m *SensorLimits) GetChannelBufferLength() uint32 {
+	if m != nil {
+		return m.ChannelBufferLength
+	}
+	return 0
+}
+
+func (m *SensorLimits) GetDispatchBacklogThreshold() uint32 {
+	if m != nil {
+		return m.DispatchBacklogThreshold
+	}
+	return 0
+}
+
+func (m *SensorLimits) GetSyscallRateLimit() float64 {
+	if m != nil {
+		return m.SyscallRateLimit
+	}
+	return 0
+}
+
+func (m *SensorLimits) GetSyscallRateLimitBurst() float64 {
+	if m != nil {
+		return m.SyscallRateLimitBurst
+	}
+	return 0
+}
+
+func (m *SensorLimits) GetRingBufferPages() uint32 {
+	if m != nil {
+		return m.RingBufferPages
+	}
+	return 0
+}
+
+func (m *SensorLimits) GetDispatchWorkers() uint32 {
+	if m != nil {
+		return m.DispatchWorkers
+	}
+	return 0
+}
+
+func (m *SensorLimits) GetDispatchQueueDepth() uint32 {
+	if m != nil {
+		return m.DispatchQueueDepth
+	}
+	return 0
+}
+
+func (m *SensorLimits) GetMaxEgressBytesPerSecond() uint64 {
+	if m != nil {
+		return m.MaxEgressBytesPerSecond
+	}
+	return 0
+}
+
+func (m *SensorLimits) GetMaxCpuPercent() float64 {
+	if m != nil {
+		return m.MaxCpuPercent
+	}
+	return 0
+}
+
+// A request message to change some of the Sensor's global limits. Limits
+// that are not set are left unchanged. If any limit is invalid, none are
+// changed.
+type UpdateLimitsRequest struct {
+	MaxSubscriptions         *google_protobuf2.UInt32Value `protobuf:"bytes,1,opt,name=max_subscriptions,json=maxSubscriptions" json:"max_subscriptions,omitempty"`
+	ChannelBufferLength      *google_protobuf2.UInt32Value `protobuf:"bytes,2,opt,name=channel_buffer_length,json=channelBufferLength" json:"channel_buffer_length,omitempty"`
+	DispatchBacklogThreshold *google_protobuf2.UInt32Value `protobuf:"bytes,3,opt,name=dispatch_backlog_threshold,json=dispatchBacklogThreshold" json:"dispatch_backlog_threshold,omitempty"`
+	SyscallRateLimit         *google_protobuf2.DoubleValue `protobuf:"bytes,4,opt,name=syscall_rate_limit,json=syscallRateLimit" json:"syscall_rate_limit,omitempty"`
+	SyscallRateLimitBurst    *google_protobuf2.DoubleValue `protobuf:"bytes,5,opt,name=syscall_rate_limit_burst,json=syscallRateLimitBurst" json:"syscall_rate_limit_burst,omitempty"`
+	DispatchWorkers          *google_protobuf2.UInt32Value `protobuf:"bytes,6,opt,name=dispatch_workers,json=dispatchWorkers" json:"dispatch_workers,omitempty"`
+	DispatchQueueDepth       *google_protobuf2.UInt32Value `protobuf:"bytes,7,opt,name=dispatch_queue_depth,json=dispatchQueueDepth" json:"dispatch_queue_depth,omitempty"`
+	MaxEgressBytesPerSecond  *google_protobuf2.UInt64Value `protobuf:"bytes,8,opt,name=max_egress_bytes_per_second,json=maxEgressBytesPerSecond" json:"max_egress_bytes_per_second,omitempty"`
+	MaxCpuPercent            *google_protobuf2.DoubleValue `protobuf:"bytes,9,opt,name=max_cpu_percent,json=maxCpuPercent" json:"max_cpu_percent,omitempty"`
+	RingBufferPages          *google_protobuf2.UInt32Value `protobuf:"bytes,10,opt,name=ring_buffer_pages,json=ringBufferPages" json:"ring_buffer_pages,omitempty"`
+}
+
+func (m *UpdateLimitsRequest) Reset()                    { *m = UpdateLimitsRequest{} }
+func (m *UpdateLimitsRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateLimitsRequest) ProtoMessage()               {}
+func (*UpdateLimitsRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{28} }
+
+func (m *UpdateLimitsRequest) GetMaxSubscriptions() *google_protobuf2.UInt32Value {
+	if m != nil {
+		return m.MaxSubscriptions
+	}
+	return nil
+}
+
+func (m *UpdateLimitsRequest) GetChannelBufferLength() *google_protobuf2.UInt32Value {
+	if m != nil {
+		return m.ChannelBufferLength
+	}
+	return nil
+}
+
+func (m *UpdateLimitsRequest) GetDispatchBacklogThreshold() *google_protobuf2.UInt32Value {
+	if m != nil {
+		return m.DispatchBacklogThreshold
+	}
+	return nil
+}
+
+func (m *UpdateLimitsRequest) GetSyscallRateLimit() *google_protobuf2.DoubleValue {
+	if m != nil {
+		return m.SyscallRateLimit
+	}
+	return nil
+}
+
+func (m *UpdateLimitsRequest) GetSyscallRateLimitBurst() *google_protobuf2.DoubleValue {
+	if m != nil {
+		return m.SyscallRateLimitBurst
+	}
+	return nil
+}
+
+func (m *UpdateLimitsRequest) GetDispatchWorkers() *google_protobuf2.UInt32Value {
+	if m != nil {
+		return m.DispatchWorkers
+	}
+	return nil
+}
+
+func (m *UpdateLimitsRequest) GetDispatchQueueDepth() *google_protobuf2.UInt32Value {
+	if m != nil {
+		return m.DispatchQueueDepth
+	}
+	return nil
+}
+
+func (m *UpdateLimitsRequest) GetMaxEgressBytesPerSecond() *google_protobuf2.UInt64Value {
+	if m != nil {
+		return m.MaxEgressBytesPerSecond
+	}
+	return nil
+}
+
+func (m *UpdateLimitsRequest) GetMaxCpuPercent() *google_protobuf2.DoubleValue {
+	if m != nil {
+		return m.MaxCpuPercent
+	}
+	return nil
+}
+
+func (m *UpdateLimitsRequest) GetRingBufferPages() *google_protobuf2.UInt32Value {
+	if m != nil {
+		return m.RingBufferPages
+	}
+	return nil
+}
+
+// A response message describing the Sensor's global limits after an update
+type UpdateLimitsResponse struct {
+	// The limits now in effect
+	Limits *SensorLimits `protobuf:"bytes,1,opt,name=limits" json:"limits,omitempty"`
+	// A status describing each limit that was changed
+	Statuses []*google_rpc.Status `protobuf:"bytes,2,rep,name=statuses" json:"statuses,omitempty"`
+}
+
+func (m *UpdateLimitsResponse) Reset()                    { *m = UpdateLimitsResponse{} }
+func (m *UpdateLimitsResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateLimitsResponse) ProtoMessage()               {}
+func (*UpdateLimitsResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{29} }
+
+func (m *UpdateLimitsResponse) GetLimits() *SensorLimits {
+	if m != nil {
+		return m.Limits
+	}
+	return nil
+}
+
+func (m *UpdateLimitsResponse) GetStatuses() []*google_rpc.Status {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*GetEventsRequest)(nil), "capsule8.api.v0.GetEventsRequest")
 	proto.RegisterType((*GetEventsResponse)(nil), "capsule8.api.v0.GetEventsResponse")
+	proto.RegisterType((*SubscriptionSummary)(nil), "capsule8.api.v0.SubscriptionSummary")
+	proto.RegisterType((*DropCount)(nil), "capsule8.api.v0.DropCount")
+	proto.RegisterType((*DictionaryEntry)(nil), "capsule8.api.v0.DictionaryEntry")
+	proto.RegisterType((*DictionaryReferences)(nil), "capsule8.api.v0.DictionaryReferences")
+	proto.RegisterType((*GetCapabilitiesRequest)(nil), "capsule8.api.v0.GetCapabilitiesRequest")
+	proto.RegisterType((*GetCapabilitiesResponse)(nil), "capsule8.api.v0.GetCapabilitiesResponse")
+	proto.RegisterType((*GetStatisticsRequest)(nil), "capsule8.api.v0.GetStatisticsRequest")
+	proto.RegisterType((*GetStatisticsResponse)(nil), "capsule8.api.v0.GetStatisticsResponse")
+	proto.RegisterType((*DecodeStatistics)(nil), "capsule8.api.v0.DecodeStatistics")
+	proto.RegisterType((*SubscriptionDropStatistics)(nil), "capsule8.api.v0.SubscriptionDropStatistics")
+	proto.RegisterType((*SyscallCost)(nil), "capsule8.api.v0.SyscallCost")
+	proto.RegisterType((*AckThrottleStatistics)(nil), "capsule8.api.v0.AckThrottleStatistics")
+	proto.RegisterType((*DispatchWorkerStatistics)(nil), "capsule8.api.v0.DispatchWorkerStatistics")
+	proto.RegisterType((*EgressStatistics)(nil), "capsule8.api.v0.EgressStatistics")
+	proto.RegisterType((*CPUStatistics)(nil), "capsule8.api.v0.CPUStatistics")
+	proto.RegisterType((*RingBufferStatistics)(nil), "capsule8.api.v0.RingBufferStatistics")
+	proto.RegisterType((*GetCountsRequest)(nil), "capsule8.api.v0.GetCountsRequest")
+	proto.RegisterType((*GetCountsResponse)(nil), "capsule8.api.v0.GetCountsResponse")
+	proto.RegisterType((*SyscallCount)(nil), "capsule8.api.v0.SyscallCount")
+	proto.RegisterType((*ContainerCount)(nil), "capsule8.api.v0.ContainerCount")
+	proto.RegisterType((*FilterStatistics)(nil), "capsule8.api.v0.FilterStatistics")
 	proto.RegisterType((*ReceivedTelemetryEvent)(nil), "capsule8.api.v0.ReceivedTelemetryEvent")
+	proto.RegisterType((*UpdateSyscallIdsRequest)(nil), "capsule8.api.v0.UpdateSyscallIdsRequest")
+	proto.RegisterType((*UpdateSyscallIdsResponse)(nil), "capsule8.api.v0.UpdateSyscallIdsResponse")
+	proto.RegisterType((*GetLimitsRequest)(nil), "capsule8.api.v0.GetLimitsRequest")
+	proto.RegisterType((*SensorLimits)(nil), "capsule8.api.v0.SensorLimits")
+	proto.RegisterType((*UpdateLimitsRequest)(nil), "capsule8.api.v0.UpdateLimitsRequest")
+	proto.RegisterType((*UpdateLimitsResponse)(nil), "capsule8.api.v0.UpdateLimitsResponse")
+	proto.RegisterEnum("capsule8.api.v0.DropReason", DropReason_name, DropReason_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -125,6 +1676,20 @@ const _ = grpc.SupportPackageIsVersion4
 type TelemetryServiceClient interface {
 	// Opens a new stream of telemetry events
 	GetEvents(ctx context.Context, in *GetEventsRequest, opts ...grpc.CallOption) (TelemetryService_GetEventsClient, error)
+	// Returns the capabilities of the Sensor on its host
+	GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error)
+	// Returns statistics describing the Sensor's current workload
+	GetStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*GetStatisticsResponse, error)
+	// Returns counts of the events recently dispatched by the Sensor,
+	// if its count series is enabled
+	GetCounts(ctx context.Context, in *GetCountsRequest, opts ...grpc.CallOption) (*GetCountsResponse, error)
+	// Adds or removes system call ids from the syscall events of a
+	// running subscription by updating their kernel filters in place
+	UpdateSyscallIds(ctx context.Context, in *UpdateSyscallIdsRequest, opts ...grpc.CallOption) (*UpdateSyscallIdsResponse, error)
+	// Returns the Sensor's global limits currently in effect
+	GetLimits(ctx context.Context, in *GetLimitsRequest, opts ...grpc.CallOption) (*SensorLimits, error)
+	// Changes the Sensor's global limits without restarting it
+	UpdateLimits(ctx context.Context, in *UpdateLimitsRequest, opts ...grpc.CallOption) (*UpdateLimitsResponse, error)
 }
 
 type telemetryServiceClient struct {
@@ -167,11 +1732,79 @@ func (x *telemetryServiceGetEventsClient) Recv() (*GetEventsResponse, error) {
 	return m, nil
 }
 
+func (c *telemetryServiceClient) GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error) {
+	out := new(GetCapabilitiesResponse)
+	err := grpc.Invoke(ctx, "/capsule8.api.v0.TelemetryService/GetCapabilities", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telemetryServiceClient) GetStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*GetStatisticsResponse, error) {
+	out := new(GetStatisticsResponse)
+	err := grpc.Invoke(ctx, "/capsule8.api.v0.TelemetryService/GetStatistics", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telemetryServiceClient) GetCounts(ctx context.Context, in *GetCountsRequest, opts ...grpc.CallOption) (*GetCountsResponse, error) {
+	out := new(GetCountsResponse)
+	err := grpc.Invoke(ctx, "/capsule8.api.v0.TelemetryService/GetCounts", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telemetryServiceClient) UpdateSyscallIds(ctx context.Context, in *UpdateSyscallIdsRequest, opts ...grpc.CallOption) (*UpdateSyscallIdsResponse, error) {
+	out := new(UpdateSyscallIdsResponse)
+	err := grpc.Invoke(ctx, "/capsule8.api.v0.TelemetryService/UpdateSyscallIds", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telemetryServiceClient) GetLimits(ctx context.Context, in *GetLimitsRequest, opts ...grpc.CallOption) (*SensorLimits, error) {
+	out := new(SensorLimits)
+	err := grpc.Invoke(ctx, "/capsule8.api.v0.TelemetryService/GetLimits", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telemetryServiceClient) UpdateLimits(ctx context.Context, in *UpdateLimitsRequest, opts ...grpc.CallOption) (*UpdateLimitsResponse, error) {
+	out := new(UpdateLimitsResponse)
+	err := grpc.Invoke(ctx, "/capsule8.api.v0.TelemetryService/UpdateLimits", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for TelemetryService service
 
 type TelemetryServiceServer interface {
 	// Opens a new stream of telemetry events
 	GetEvents(*GetEventsRequest, TelemetryService_GetEventsServer) error
+	// Returns the capabilities of the Sensor on its host
+	GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error)
+	// Returns statistics describing the Sensor's current workload
+	GetStatistics(context.Context, *GetStatisticsRequest) (*GetStatisticsResponse, error)
+	// Returns counts of the events recently dispatched by the Sensor,
+	// if its count series is enabled
+	GetCounts(context.Context, *GetCountsRequest) (*GetCountsResponse, error)
+	// Adds or removes system call ids from the syscall events of a
+	// running subscription by updating their kernel filters in place
+	UpdateSyscallIds(context.Context, *UpdateSyscallIdsRequest) (*UpdateSyscallIdsResponse, error)
+	// Returns the Sensor's global limits currently in effect
+	GetLimits(context.Context, *GetLimitsRequest) (*SensorLimits, error)
+	// Changes the Sensor's global limits without restarting it
+	UpdateLimits(context.Context, *UpdateLimitsRequest) (*UpdateLimitsResponse, error)
 }
 
 func RegisterTelemetryServiceServer(s *grpc.Server, srv TelemetryServiceServer) {
@@ -199,10 +1832,143 @@ func (x *telemetryServiceGetEventsServer) Send(m *GetEventsResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _TelemetryService_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemetryServiceServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/capsule8.api.v0.TelemetryService/GetCapabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemetryServiceServer).GetCapabilities(ctx, req.(*GetCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelemetryService_GetStatistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatisticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemetryServiceServer).GetStatistics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/capsule8.api.v0.TelemetryService/GetStatistics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemetryServiceServer).GetStatistics(ctx, req.(*GetStatisticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelemetryService_GetCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemetryServiceServer).GetCounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/capsule8.api.v0.TelemetryService/GetCounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemetryServiceServer).GetCounts(ctx, req.(*GetCountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelemetryService_UpdateSyscallIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSyscallIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemetryServiceServer).UpdateSyscallIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/capsule8.api.v0.TelemetryService/UpdateSyscallIds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemetryServiceServer).UpdateSyscallIds(ctx, req.(*UpdateSyscallIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelemetryService_GetLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemetryServiceServer).GetLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/capsule8.api.v0.TelemetryService/GetLimits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemetryServiceServer).GetLimits(ctx, req.(*GetLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelemetryService_UpdateLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemetryServiceServer).UpdateLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/capsule8.api.v0.TelemetryService/UpdateLimits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemetryServiceServer).UpdateLimits(ctx, req.(*UpdateLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _TelemetryService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "capsule8.api.v0.TelemetryService",
 	HandlerType: (*TelemetryServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _TelemetryService_GetCapabilities_Handler,
+		},
+		{
+			MethodName: "GetStatistics",
+			Handler:    _TelemetryService_GetStatistics_Handler,
+		},
+		{
+			MethodName: "GetCounts",
+			Handler:    _TelemetryService_GetCounts_Handler,
+		},
+		{
+			MethodName: "UpdateSyscallIds",
+			Handler:    _TelemetryService_UpdateSyscallIds_Handler,
+		},
+		{
+			MethodName: "GetLimits",
+			Handler:    _TelemetryService_GetLimits_Handler,
+		},
+		{
+			MethodName: "UpdateLimits",
+			Handler:    _TelemetryService_UpdateLimits_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "GetEvents",
@@ -216,29 +1982,189 @@ var _TelemetryService_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("capsule8/api/v0/telemetry_service.proto", fileDescriptor2) }
 
 var fileDescriptor2 = []byte{
-	// 372 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xc1, 0x4a, 0xf3, 0x40,
-	0x14, 0x85, 0x99, 0x86, 0xbf, 0xfc, 0x4e, 0x0b, 0x6d, 0x47, 0xd4, 0x50, 0x14, 0x6b, 0x40, 0x5a,
-	0x5c, 0x4c, 0x4a, 0x45, 0x10, 0x37, 0xe2, 0x42, 0x5c, 0xb9, 0x49, 0xeb, 0xba, 0xa4, 0xe3, 0xa5,
-	0x0e, 0x4d, 0x32, 0x63, 0x66, 0x12, 0x70, 0x25, 0x88, 0x2f, 0x20, 0x3e, 0x9a, 0xaf, 0xe0, 0x83,
-	0x48, 0x66, 0xd2, 0x52, 0x53, 0x75, 0x37, 0x70, 0xce, 0xfd, 0x38, 0xf7, 0xcc, 0xc5, 0x7d, 0x16,
-	0x4a, 0x95, 0x45, 0x70, 0xee, 0x87, 0x92, 0xfb, 0xf9, 0xd0, 0xd7, 0x10, 0x41, 0x0c, 0x3a, 0x7d,
-	0x9a, 0x2a, 0x48, 0x73, 0xce, 0x80, 0xca, 0x54, 0x68, 0x41, 0x5a, 0x4b, 0x23, 0x0d, 0x25, 0xa7,
-	0xf9, 0xb0, 0xeb, 0x55, 0x27, 0x55, 0x36, 0x53, 0x2c, 0xe5, 0x52, 0x73, 0x91, 0xd8, 0xa1, 0xee,
-	0xf1, 0xef, 0x74, 0xc8, 0x21, 0xd1, 0xa5, 0x6d, 0x7f, 0x2e, 0xc4, 0x3c, 0x02, 0x63, 0x0a, 0x93,
-	0x44, 0xe8, 0xb0, 0x60, 0xa8, 0x52, 0xdd, 0x2b, 0xd5, 0x54, 0x32, 0x5f, 0xe9, 0x50, 0x67, 0xa5,
-	0xe0, 0xdd, 0xe1, 0xf6, 0x0d, 0xe8, 0xeb, 0x02, 0xa4, 0x02, 0x78, 0xcc, 0x40, 0x69, 0x72, 0x85,
-	0x9b, 0xeb, 0x39, 0x5c, 0xd4, 0x43, 0x83, 0xc6, 0xe8, 0x80, 0x56, 0xd2, 0xd3, 0xf1, 0x9a, 0x29,
-	0xf8, 0x36, 0xe2, 0xbd, 0x22, 0xdc, 0x59, 0xe3, 0x2a, 0x29, 0x12, 0x05, 0xe4, 0x12, 0xd7, 0x4d,
-	0x64, 0xe5, 0xa2, 0x9e, 0x33, 0x68, 0x8c, 0xfa, 0x1b, 0xc8, 0x00, 0x18, 0xf0, 0x1c, 0xee, 0x27,
-	0xcb, 0x1d, 0x0d, 0x21, 0x28, 0xc7, 0x08, 0xc5, 0xff, 0x6d, 0x7a, 0x50, 0x6e, 0xcd, 0x20, 0x08,
-	0xb5, 0x9b, 0xd1, 0x54, 0x32, 0x3a, 0x36, 0x5a, 0xb0, 0xf2, 0x78, 0x6f, 0x08, 0xef, 0xfe, 0x8c,
-	0x24, 0x14, 0x6f, 0xcb, 0x6c, 0x16, 0x71, 0xf5, 0x30, 0xd5, 0x3c, 0x86, 0x69, 0xcc, 0x59, 0x2a,
-	0x94, 0xd9, 0xd5, 0x09, 0x3a, 0xa5, 0x34, 0xe1, 0x31, 0xdc, 0x1a, 0x81, 0x9c, 0xe1, 0x7f, 0x26,
-	0x84, 0x5b, 0x33, 0x6d, 0x1c, 0x6e, 0x44, 0xaf, 0x44, 0xb6, 0x6e, 0xd2, 0xc6, 0x4e, 0xc8, 0x16,
-	0xae, 0xd3, 0x43, 0x83, 0x66, 0x50, 0x3c, 0x47, 0xcf, 0xb8, 0xbd, 0xb2, 0x8e, 0xed, 0x79, 0x90,
-	0x05, 0xde, 0x5a, 0xb5, 0x45, 0x8e, 0x36, 0xd0, 0xd5, 0x1f, 0xea, 0x7a, 0x7f, 0x59, 0x6c, 0xd9,
-	0xde, 0xce, 0xcb, 0xc7, 0xe7, 0x7b, 0xad, 0xe5, 0xe1, 0xe2, 0x66, 0x6c, 0x7f, 0x17, 0xe8, 0x64,
-	0x88, 0x66, 0x75, 0xf3, 0xf3, 0xa7, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x1e, 0x32, 0x1a, 0xb6,
-	0xb7, 0x02, 0x00, 0x00,
+	// 2931 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x3a, 0x4b, 0x73, 0xe3, 0x58,
+	0xd5, 0x9f, 0xec, 0xc4, 0x89, 0x4f, 0xe2, 0x44, 0xb9, 0x71, 0x3a, 0x1e, 0xf7, 0x63, 0xd2, 0xfa,
+	0xa6, 0xa7, 0xd3, 0xdd, 0xdf, 0x97, 0x34, 0xe9, 0x19, 0x98, 0x69, 0xa6, 0x19, 0x9c, 0xc4, 0x9d,
+	0x31, 0xed, 0x4e, 0x82, 0xec, 0xcc, 0x14, 0xbd, 0x51, 0x5d, 0x4b, 0xd7, 0x8e, 0x88, 0x2c, 0x69,
+	0x74, 0xe5, 0x74, 0x67, 0xa8, 0x61, 0x31, 0x54, 0xf1, 0x07, 0xd8, 0xb3, 0x81, 0x2a, 0x60, 0x03,
+	0x2b, 0x58, 0x50, 0xc5, 0x92, 0x1d, 0x3b, 0xaa, 0xa8, 0xa2, 0x8a, 0x25, 0x3f, 0x83, 0xa2, 0xa8,
+	0xfb, 0x90, 0x2c, 0x59, 0x72, 0x92, 0xa9, 0x62, 0x17, 0x9d, 0xd7, 0xbd, 0xe7, 0x7d, 0xce, 0x75,
+	0xe0, 0xbe, 0x89, 0x7d, 0x3a, 0x72, 0xc8, 0x07, 0xdb, 0xd8, 0xb7, 0xb7, 0xcf, 0x1f, 0x6f, 0x87,
+	0xc4, 0x21, 0x43, 0x12, 0x06, 0x17, 0x06, 0x25, 0xc1, 0xb9, 0x6d, 0x92, 0x2d, 0x3f, 0xf0, 0x42,
+	0x0f, 0x2d, 0x47, 0x84, 0x5b, 0xd8, 0xb7, 0xb7, 0xce, 0x1f, 0xd7, 0xb5, 0x49, 0x4e, 0x3a, 0xea,
+	0x51, 0x33, 0xb0, 0xfd, 0xd0, 0xf6, 0x5c, 0xc1, 0x54, 0xbf, 0x37, 0x5d, 0x3a, 0x39, 0x27, 0x6e,
+	0x28, 0xc9, 0x6e, 0x0d, 0x3c, 0x6f, 0xe0, 0x10, 0x4e, 0x84, 0x5d, 0xd7, 0x0b, 0x31, 0x93, 0x41,
+	0x25, 0xf6, 0x8e, 0xc4, 0xf2, 0xaf, 0xde, 0xa8, 0xbf, 0xfd, 0x3a, 0xc0, 0xbe, 0x4f, 0x82, 0x08,
+	0xbf, 0x2e, 0xf1, 0x81, 0x6f, 0x6e, 0xd3, 0x10, 0x87, 0x23, 0x89, 0xd0, 0x7e, 0xa7, 0x80, 0x7a,
+	0x40, 0xc2, 0x26, 0x3b, 0x89, 0xea, 0xe4, 0xf3, 0x11, 0xa1, 0x21, 0x6a, 0xc0, 0x62, 0xf2, 0xa2,
+	0x35, 0x65, 0x43, 0xd9, 0x5c, 0xd8, 0xb9, 0xbd, 0x35, 0xa1, 0xde, 0x56, 0x27, 0x41, 0xa4, 0xa7,
+	0x58, 0xd0, 0x36, 0xac, 0x5a, 0xb6, 0xc9, 0xfe, 0xc4, 0x4c, 0x11, 0xd7, 0xf4, 0x2c, 0xdb, 0x1d,
+	0xd4, 0x0a, 0x1b, 0xca, 0xe6, 0xbc, 0x8e, 0xc6, 0xa8, 0xa6, 0xc4, 0xa0, 0xfb, 0xb0, 0x6c, 0x5b,
+	0x64, 0xe8, 0x7b, 0x21, 0x71, 0xcd, 0x0b, 0xe3, 0x8c, 0x5c, 0xd4, 0x8a, 0x1b, 0xca, 0x66, 0x59,
+	0x5f, 0x4a, 0x80, 0x5f, 0x90, 0x0b, 0xed, 0xcf, 0x33, 0xb0, 0x92, 0xb8, 0x31, 0xf5, 0x3d, 0x97,
+	0x12, 0xf4, 0x31, 0x94, 0xb8, 0xb5, 0x68, 0x4d, 0xd9, 0x28, 0x6e, 0x2e, 0xec, 0xdc, 0xcf, 0x5c,
+	0x56, 0x27, 0x26, 0xb1, 0xcf, 0x89, 0xd5, 0x8d, 0xcc, 0xcb, 0x25, 0xe8, 0x92, 0x0d, 0x6d, 0xc1,
+	0xbc, 0x30, 0x0c, 0xa1, 0xb5, 0x02, 0x17, 0x81, 0xb6, 0x84, 0xd1, 0xb6, 0x02, 0xdf, 0xdc, 0xea,
+	0x70, 0x9c, 0x1e, 0xd3, 0xa0, 0xef, 0x02, 0x8c, 0xb5, 0xa8, 0x15, 0x39, 0xc7, 0x46, 0xe6, 0xd0,
+	0xfd, 0x84, 0xa2, 0x61, 0x70, 0xa1, 0x27, 0x78, 0x98, 0xc6, 0x49, 0x93, 0x19, 0xb6, 0x55, 0x9b,
+	0xd9, 0x50, 0x36, 0x67, 0xf5, 0xa5, 0x24, 0xb8, 0x65, 0x21, 0x02, 0x2b, 0x29, 0xc2, 0x10, 0x0f,
+	0x68, 0x6d, 0x96, 0x9f, 0xf8, 0x41, 0xe6, 0xc4, 0x8c, 0x69, 0x52, 0x5e, 0xea, 0xe2, 0x01, 0x15,
+	0x37, 0x51, 0xe9, 0x04, 0x18, 0x7d, 0x08, 0xe0, 0x93, 0xa0, 0x6f, 0x98, 0x8e, 0x67, 0x9e, 0xd5,
+	0xe6, 0x36, 0x94, 0xcd, 0xa5, 0x9d, 0x7a, 0x46, 0xfe, 0x31, 0x09, 0xfa, 0x7b, 0x8c, 0x42, 0x2f,
+	0xfb, 0xd1, 0x9f, 0xe8, 0x3b, 0x30, 0x47, 0x47, 0xc3, 0x21, 0xb3, 0x44, 0x89, 0xc7, 0xca, 0x3b,
+	0x97, 0xc6, 0x4a, 0x47, 0xd0, 0xea, 0x11, 0x13, 0xfa, 0x7f, 0x40, 0x69, 0x0d, 0xbd, 0x33, 0xe2,
+	0xd6, 0xe6, 0xb9, 0xff, 0x53, 0xba, 0x77, 0x19, 0xa2, 0xbe, 0x07, 0x6b, 0xb9, 0x4a, 0x21, 0x15,
+	0x8a, 0x2c, 0x70, 0x14, 0xce, 0xc8, 0xfe, 0x44, 0x55, 0x98, 0x3d, 0xc7, 0xce, 0x88, 0xf0, 0xc8,
+	0x2b, 0xeb, 0xe2, 0xe3, 0x69, 0xe1, 0x03, 0x45, 0xfb, 0x65, 0x11, 0x56, 0x73, 0x2e, 0x85, 0x6e,
+	0x40, 0x29, 0x20, 0x98, 0xca, 0xb0, 0x2f, 0xeb, 0xf2, 0x0b, 0xdd, 0x83, 0x25, 0x6b, 0x14, 0xf0,
+	0xac, 0x33, 0x5c, 0xec, 0x7a, 0x94, 0x8b, 0x2c, 0xea, 0x95, 0x08, 0x7a, 0xc8, 0x80, 0xe8, 0x01,
+	0xa8, 0x22, 0xa2, 0x0c, 0x8b, 0x38, 0xf6, 0x39, 0x09, 0x88, 0xc5, 0x03, 0x79, 0x46, 0x5f, 0x16,
+	0xf0, 0xfd, 0x08, 0xcc, 0x24, 0x46, 0xa4, 0x81, 0xe7, 0xfb, 0x44, 0xf8, 0x7f, 0x46, 0xaf, 0x48,
+	0x42, 0x01, 0x44, 0x6f, 0xc3, 0x82, 0x24, 0x73, 0x3c, 0x1a, 0xd6, 0x66, 0x39, 0x0d, 0x08, 0x50,
+	0xdb, 0xa3, 0x21, 0x8b, 0x0f, 0xfe, 0x65, 0x84, 0x17, 0x3e, 0x31, 0x4c, 0x6f, 0xc4, 0xd2, 0xa0,
+	0xc4, 0xe3, 0xe3, 0xc3, 0xeb, 0xf8, 0x61, 0x8b, 0x07, 0x4c, 0xf7, 0xc2, 0x27, 0x7b, 0x9c, 0x57,
+	0x04, 0xc8, 0x32, 0x49, 0x43, 0xd1, 0x63, 0x98, 0x65, 0xf7, 0xa4, 0xb5, 0x39, 0x2e, 0x3a, 0x1b,
+	0x1a, 0xec, 0xc2, 0x9c, 0x56, 0x17, 0x84, 0xf5, 0x5d, 0xa8, 0xe6, 0x89, 0xbe, 0xca, 0x4d, 0x33,
+	0x49, 0x37, 0x7d, 0x0a, 0xe5, 0x58, 0x2e, 0x7a, 0x92, 0xf2, 0xcd, 0xd2, 0xce, 0xcd, 0xdc, 0x3b,
+	0xe8, 0x9c, 0x24, 0x76, 0x5c, 0x15, 0x66, 0xb9, 0x4d, 0x22, 0xd9, 0xfc, 0x43, 0x7b, 0x06, 0xcb,
+	0x13, 0xc9, 0xc9, 0x08, 0x6d, 0xd7, 0x22, 0x6f, 0xb8, 0xf0, 0x8a, 0x2e, 0x3e, 0xf2, 0x23, 0x48,
+	0xfb, 0x9b, 0x02, 0xd5, 0x31, 0xbf, 0x4e, 0xfa, 0x24, 0x20, 0xae, 0x49, 0x28, 0xba, 0x0d, 0xe0,
+	0x07, 0x9e, 0x49, 0x28, 0x65, 0x09, 0x2d, 0x24, 0x95, 0x25, 0xa4, 0x65, 0xa1, 0xbb, 0xb0, 0x68,
+	0x7a, 0x6e, 0x88, 0x6d, 0x97, 0x04, 0x8c, 0xa0, 0xc0, 0x09, 0x16, 0x62, 0x58, 0xcb, 0x42, 0x37,
+	0xa1, 0x4c, 0x89, 0x4b, 0x3d, 0x8e, 0x2f, 0x72, 0xfc, 0xbc, 0x00, 0xb4, 0x78, 0xcc, 0x8c, 0xf9,
+	0x5d, 0x3c, 0x24, 0x3c, 0x66, 0x2a, 0x7a, 0x25, 0x86, 0x1e, 0xe2, 0x21, 0x41, 0x6f, 0xc1, 0xbc,
+	0x3d, 0xc4, 0x03, 0xc2, 0x44, 0xcc, 0x72, 0x82, 0x39, 0xfe, 0xdd, 0xb2, 0xd8, 0x05, 0x05, 0x8a,
+	0x73, 0x97, 0xc4, 0x05, 0x39, 0x84, 0x71, 0x6a, 0x35, 0xb8, 0x71, 0x40, 0xc2, 0x3d, 0xec, 0xe3,
+	0x9e, 0xed, 0xd8, 0xa1, 0x4d, 0xa2, 0xae, 0xa0, 0xfd, 0x56, 0x81, 0xf5, 0x0c, 0x4a, 0x96, 0xdf,
+	0xf7, 0x61, 0xbd, 0x17, 0xf6, 0x0d, 0x7a, 0x41, 0x4d, 0xec, 0x38, 0x06, 0x0e, 0x06, 0x86, 0xd7,
+	0xef, 0x53, 0xc2, 0xeb, 0x31, 0x2b, 0xf9, 0xd5, 0x5e, 0xd8, 0xef, 0x08, 0x6c, 0x23, 0x18, 0x1c,
+	0x09, 0xdc, 0xd7, 0xef, 0x12, 0x8f, 0x60, 0x25, 0x0c, 0xb0, 0x69, 0xbb, 0x03, 0x03, 0x9f, 0x63,
+	0xdb, 0xc1, 0x3d, 0x87, 0x70, 0x1b, 0xcd, 0xeb, 0xaa, 0x44, 0x34, 0x22, 0xb8, 0x76, 0x03, 0xaa,
+	0x07, 0x24, 0x64, 0x95, 0xdb, 0xa6, 0xa1, 0x6d, 0xc6, 0x8a, 0xfc, 0xb4, 0x04, 0x6b, 0x13, 0x08,
+	0xa9, 0xc6, 0xb7, 0x61, 0xae, 0x6f, 0x3b, 0x21, 0x09, 0xa8, 0xec, 0x79, 0x77, 0x33, 0x01, 0xf6,
+	0x9c, 0xe3, 0x13, 0xbc, 0x11, 0x07, 0xfa, 0x08, 0xea, 0x3e, 0x71, 0xd9, 0x35, 0x0d, 0x07, 0x7f,
+	0x71, 0x61, 0x24, 0xeb, 0x16, 0x95, 0x8e, 0xae, 0x49, 0x8a, 0x36, 0xfe, 0xe2, 0x22, 0x99, 0x89,
+	0x14, 0x3d, 0x85, 0xb7, 0xb0, 0x19, 0xda, 0xe7, 0x24, 0x8f, 0x59, 0x44, 0xc1, 0xba, 0x20, 0xc8,
+	0xf2, 0x36, 0xa0, 0x12, 0x59, 0xde, 0xf4, 0x68, 0x48, 0x6b, 0x33, 0x3c, 0x43, 0x6f, 0x65, 0x93,
+	0x5f, 0x50, 0xed, 0x79, 0x34, 0xd4, 0x17, 0xe9, 0xf8, 0x83, 0xa2, 0x17, 0x50, 0xc1, 0xe6, 0x99,
+	0x11, 0x9e, 0x06, 0x5e, 0x18, 0x3a, 0x24, 0xea, 0x2f, 0xef, 0x66, 0x44, 0x34, 0xcc, 0xb3, 0xae,
+	0x24, 0x4a, 0x18, 0x61, 0x11, 0x8f, 0xc1, 0x14, 0x75, 0x41, 0xb5, 0x6c, 0xea, 0xe3, 0xd0, 0x3c,
+	0x35, 0x5e, 0x7b, 0xc1, 0x19, 0x09, 0xa2, 0x7a, 0xf4, 0x20, 0xa7, 0x43, 0x0a, 0xc2, 0xcf, 0x38,
+	0x5d, 0x42, 0xe4, 0xb2, 0x95, 0xc2, 0xb0, 0xfe, 0x54, 0x22, 0x83, 0x80, 0x50, 0x5a, 0x9b, 0x9b,
+	0xe2, 0x9b, 0x26, 0x47, 0x27, 0x64, 0x48, 0x06, 0xf4, 0x09, 0x2c, 0x06, 0xcc, 0x2f, 0xbd, 0x51,
+	0xbf, 0xcf, 0x2e, 0x33, 0xcf, 0x05, 0xdc, 0xcb, 0x08, 0xd0, 0x6d, 0x77, 0xb0, 0xcb, 0x69, 0x12,
+	0x42, 0x16, 0x82, 0x18, 0x4a, 0xd1, 0xab, 0x89, 0x4e, 0x25, 0x2a, 0x62, 0x99, 0x2b, 0xf7, 0xe8,
+	0xd2, 0x62, 0xcb, 0x2a, 0x53, 0x42, 0xea, 0x0a, 0x9d, 0xc0, 0xb1, 0x02, 0x5b, 0x34, 0xfd, 0x51,
+	0x0d, 0xf8, 0xe5, 0xee, 0x64, 0x84, 0xed, 0x1d, 0x9f, 0x24, 0xf8, 0x19, 0x29, 0x7a, 0x06, 0xf3,
+	0x16, 0x91, 0x49, 0xb3, 0xb0, 0x51, 0xcc, 0x35, 0xca, 0x3e, 0x23, 0x48, 0xfa, 0x2a, 0x66, 0xd1,
+	0xfe, 0x58, 0x00, 0x75, 0x12, 0x9d, 0x37, 0x96, 0x28, 0xb9, 0x63, 0x49, 0x1d, 0xe6, 0x6d, 0xea,
+	0x39, 0x38, 0x24, 0x96, 0xcc, 0xd8, 0xf8, 0x9b, 0x35, 0x51, 0x39, 0x8e, 0x89, 0xde, 0x27, 0xbf,
+	0x58, 0xf9, 0xe3, 0xa7, 0x13, 0xd9, 0x42, 0x45, 0xc3, 0x5b, 0x10, 0x30, 0xd1, 0x40, 0xff, 0x0f,
+	0xd0, 0x90, 0x60, 0xd7, 0x70, 0xb0, 0x98, 0x04, 0x05, 0xa1, 0xe8, 0x7a, 0x2a, 0xc3, 0xb4, 0x05,
+	0x42, 0x50, 0x3f, 0x84, 0x95, 0x21, 0x7e, 0x33, 0x41, 0x5c, 0x12, 0xfd, 0x76, 0x88, 0xdf, 0xa4,
+	0x68, 0xef, 0xc1, 0xd2, 0xe7, 0x23, 0x32, 0x22, 0x96, 0xd1, 0x63, 0x71, 0x45, 0x44, 0x20, 0x55,
+	0xf4, 0x8a, 0x80, 0xee, 0x0a, 0x20, 0x6f, 0xf4, 0xa2, 0xf5, 0x1a, 0x52, 0x87, 0x79, 0xd1, 0x96,
+	0x25, 0x54, 0x8c, 0x57, 0xda, 0x6b, 0xa8, 0x4f, 0x77, 0xef, 0xf5, 0xad, 0x18, 0x77, 0xd5, 0xc2,
+	0x35, 0xbb, 0xaa, 0xf6, 0x6b, 0x05, 0x16, 0x12, 0x89, 0x8c, 0x96, 0xa0, 0x20, 0xa5, 0x17, 0xf5,
+	0x82, 0x9d, 0xb4, 0x7d, 0xe1, 0x52, 0xdb, 0x17, 0xb3, 0xb6, 0x67, 0xaa, 0x8b, 0xf1, 0xe4, 0x22,
+	0xe5, 0xa0, 0x4a, 0x04, 0x15, 0x64, 0xf7, 0x61, 0x59, 0x4a, 0xea, 0x07, 0x98, 0xd7, 0x68, 0xee,
+	0x1f, 0x45, 0x5f, 0x12, 0xe0, 0xe7, 0x12, 0xaa, 0xfd, 0x5e, 0x81, 0xb5, 0xdc, 0x82, 0x71, 0x7d,
+	0xfb, 0x3c, 0x84, 0x15, 0x56, 0x98, 0xd2, 0x0e, 0x16, 0x93, 0xd7, 0x32, 0x36, 0xcf, 0x52, 0x0e,
+	0xbe, 0x05, 0xe5, 0xa8, 0x80, 0x59, 0xb2, 0x2b, 0x8c, 0x01, 0x6c, 0x32, 0x8b, 0x3f, 0x22, 0xcf,
+	0x0a, 0xf5, 0x96, 0x63, 0xb8, 0xf4, 0xed, 0x5f, 0x14, 0xa8, 0x4d, 0x2b, 0x4c, 0x53, 0xc6, 0x84,
+	0x6c, 0x70, 0x15, 0xf2, 0x82, 0xab, 0x06, 0x73, 0x11, 0x5e, 0xd8, 0x7f, 0xae, 0x37, 0xc6, 0x50,
+	0x3c, 0xf4, 0x1d, 0x12, 0xdd, 0x2a, 0xfa, 0x64, 0x1d, 0xbb, 0x37, 0xa2, 0xe9, 0x4c, 0x28, 0x33,
+	0x88, 0xd0, 0x7a, 0x03, 0x16, 0x46, 0xa1, 0xed, 0xd8, 0x5f, 0xf0, 0x29, 0x94, 0x07, 0xbf, 0xa2,
+	0x27, 0x41, 0xda, 0xdf, 0x15, 0x50, 0x27, 0x6b, 0x23, 0x7a, 0x02, 0x37, 0x1c, 0x7b, 0x68, 0x87,
+	0x46, 0xef, 0x22, 0x24, 0xd4, 0xf0, 0x49, 0x60, 0x50, 0x62, 0x7a, 0xae, 0x70, 0xc4, 0x8c, 0xbe,
+	0xca, 0xb1, 0xbb, 0x0c, 0x79, 0x4c, 0x82, 0x0e, 0x47, 0xa1, 0x6f, 0xc0, 0x5a, 0x80, 0x43, 0x92,
+	0xe5, 0x29, 0xf0, 0x53, 0x11, 0x43, 0x4e, 0xb0, 0xdc, 0x06, 0xa0, 0x6c, 0x38, 0xe5, 0x2c, 0x52,
+	0x69, 0x36, 0xe0, 0x08, 0xd1, 0x6c, 0xba, 0xa5, 0xa7, 0x93, 0x0e, 0x01, 0x7a, 0x1a, 0xf9, 0x82,
+	0xf3, 0x33, 0x02, 0xc1, 0x2f, 0xb5, 0x67, 0x10, 0xce, 0xaf, 0xfd, 0x4a, 0x81, 0x4a, 0xaa, 0x32,
+	0xa2, 0xff, 0x85, 0x8a, 0x50, 0xcc, 0x27, 0x81, 0x49, 0xdc, 0x90, 0xeb, 0xa3, 0xe8, 0x8b, 0x1c,
+	0x78, 0x2c, 0x60, 0x8c, 0x68, 0x44, 0xd9, 0x14, 0x14, 0x11, 0x09, 0x05, 0x16, 0x39, 0x30, 0x22,
+	0x7a, 0x04, 0x2b, 0x16, 0x19, 0x04, 0xd8, 0x12, 0x53, 0xbf, 0x43, 0xce, 0x89, 0x23, 0x7b, 0xb1,
+	0x9a, 0x40, 0xb4, 0x19, 0xfc, 0x4a, 0x45, 0xb4, 0xaf, 0x14, 0xa8, 0xe6, 0x35, 0x18, 0x16, 0xb6,
+	0xde, 0x39, 0x09, 0xfa, 0x8e, 0xf7, 0x9a, 0x4a, 0xe3, 0x8f, 0x01, 0x2c, 0x6d, 0xd9, 0xdc, 0x6f,
+	0x04, 0xc4, 0xf4, 0x02, 0x2b, 0x4a, 0xea, 0x05, 0x06, 0xd3, 0x05, 0x88, 0x45, 0x76, 0x40, 0x88,
+	0xcb, 0x86, 0x9e, 0xf8, 0x7c, 0xb9, 0x73, 0xc4, 0x70, 0x79, 0x89, 0x67, 0x7c, 0xdd, 0x17, 0xc3,
+	0x78, 0xb4, 0xee, 0x3f, 0x00, 0x35, 0xde, 0x6c, 0x84, 0x3b, 0xa9, 0x2c, 0x27, 0xcb, 0x11, 0x5c,
+	0xf8, 0x92, 0x6a, 0x7f, 0x2a, 0xc0, 0x4a, 0x82, 0x5f, 0x8e, 0x4d, 0x8f, 0xa1, 0x4a, 0x43, 0x1c,
+	0x84, 0xc6, 0xd0, 0x73, 0xbd, 0xd0, 0x1e, 0x46, 0x15, 0x46, 0x08, 0x41, 0x1c, 0xf7, 0x52, 0xa2,
+	0xe2, 0x22, 0x4f, 0x5c, 0x6b, 0x92, 0x5e, 0xa4, 0xb5, 0x4a, 0x5c, 0x2b, 0x4d, 0xcd, 0xf5, 0xa3,
+	0x9e, 0x33, 0x4a, 0x2c, 0x5f, 0x45, 0x71, 0xc1, 0x31, 0x5c, 0x90, 0xde, 0x85, 0xc5, 0xd0, 0x0b,
+	0xb1, 0x93, 0x76, 0xc3, 0x02, 0x87, 0xc9, 0x80, 0xfa, 0x10, 0xe6, 0xe5, 0xe8, 0x13, 0x4d, 0x39,
+	0xb7, 0xa7, 0x0f, 0x4a, 0xac, 0xee, 0xc6, 0xe4, 0xe8, 0x63, 0x80, 0x78, 0xce, 0x8e, 0x46, 0x9a,
+	0xb7, 0xb3, 0x8d, 0x3a, 0x22, 0x11, 0xec, 0x09, 0x16, 0xed, 0x3d, 0x58, 0x4c, 0x8a, 0xce, 0xd4,
+	0xee, 0xfc, 0x5d, 0xa5, 0x05, 0x4b, 0x69, 0x99, 0x99, 0x35, 0x42, 0xac, 0x52, 0xa9, 0x35, 0x22,
+	0x5f, 0xd4, 0xbf, 0x0a, 0xa0, 0x4e, 0x8e, 0xb0, 0x2c, 0xc5, 0xc6, 0x0b, 0xa4, 0x94, 0x55, 0x8e,
+	0xd7, 0x3f, 0xe6, 0xac, 0x33, 0x12, 0xb8, 0x44, 0x1a, 0xd5, 0xa0, 0xb6, 0x7b, 0x16, 0x95, 0x37,
+	0x55, 0x60, 0xb8, 0x69, 0x3b, 0x0c, 0x8e, 0x76, 0x60, 0x6d, 0x44, 0x49, 0x40, 0x7d, 0x6c, 0x92,
+	0x14, 0x83, 0x48, 0x9c, 0xd5, 0x18, 0x99, 0xe0, 0x79, 0x92, 0xe6, 0xc1, 0xce, 0x48, 0xbc, 0x6e,
+	0x49, 0xf7, 0x55, 0x13, 0x3c, 0x31, 0x8e, 0xcd, 0xdb, 0x79, 0x4c, 0xa9, 0x32, 0x59, 0xcb, 0xe1,
+	0x14, 0x81, 0xb2, 0x0b, 0x0b, 0x63, 0x9d, 0x23, 0x5f, 0x5e, 0x63, 0xdc, 0x87, 0xd8, 0x2e, 0x6c,
+	0x60, 0xab, 0xf6, 0xb1, 0xe3, 0xf4, 0x58, 0x83, 0x4a, 0x6a, 0x2a, 0xc6, 0x0a, 0x14, 0xe1, 0xc6,
+	0x8a, 0x6a, 0x3f, 0x2f, 0xc2, 0x8d, 0xfc, 0x87, 0x28, 0xb4, 0x05, 0xab, 0xfe, 0xa8, 0xe7, 0xd8,
+	0xf4, 0xd4, 0xe0, 0x29, 0x31, 0xb4, 0xcd, 0x20, 0xce, 0xa1, 0x15, 0x89, 0xea, 0xda, 0x43, 0xf2,
+	0x92, 0x23, 0xd0, 0xfb, 0x30, 0xcb, 0xcf, 0xe4, 0x8e, 0xc8, 0x0b, 0xc3, 0xb4, 0x7c, 0x5d, 0x50,
+	0xb3, 0xdd, 0x1b, 0x9b, 0x67, 0xdc, 0x19, 0x8b, 0x3a, 0xfb, 0x13, 0xbd, 0x82, 0xb5, 0xc4, 0x12,
+	0x16, 0xc4, 0xab, 0x6c, 0x6d, 0x66, 0xca, 0x94, 0x9c, 0xb7, 0xf7, 0xea, 0x55, 0x2b, 0x07, 0x8a,
+	0x7e, 0x38, 0xfd, 0xe9, 0xea, 0xd9, 0x35, 0x5f, 0xe8, 0xae, 0xfb, 0x7e, 0xf5, 0xdf, 0x79, 0x15,
+	0xfa, 0x8d, 0x02, 0xeb, 0x27, 0xbe, 0x85, 0x43, 0x22, 0xf3, 0xb4, 0x65, 0xc5, 0x75, 0xf2, 0xda,
+	0x33, 0x4b, 0xfe, 0x73, 0xd6, 0xcc, 0x94, 0xe7, 0x2c, 0xb4, 0x0e, 0x73, 0xd8, 0xb2, 0x0c, 0xdb,
+	0x12, 0x43, 0x60, 0x51, 0x2f, 0x61, 0xcb, 0x6a, 0x59, 0x3c, 0x2f, 0x03, 0x32, 0xf4, 0xce, 0x09,
+	0xc7, 0x15, 0x39, 0xae, 0x2c, 0x20, 0x2d, 0x8b, 0x6a, 0x0d, 0xa8, 0x65, 0xaf, 0x2a, 0x4b, 0xf2,
+	0x3d, 0x58, 0x92, 0x39, 0x3b, 0x5e, 0x68, 0x8b, 0x9b, 0x65, 0xbd, 0x22, 0xa0, 0x22, 0xac, 0xa9,
+	0x86, 0x78, 0x3b, 0x68, 0xb3, 0xce, 0x18, 0xaf, 0xc7, 0x3f, 0x99, 0x81, 0xc5, 0x0e, 0x7f, 0x6f,
+	0x10, 0x70, 0xf4, 0x48, 0xcc, 0xd8, 0xe9, 0x95, 0x54, 0x0c, 0x3f, 0xea, 0x10, 0xbf, 0x49, 0xef,
+	0xa2, 0x3b, 0xb0, 0x66, 0x9e, 0x62, 0x97, 0x9d, 0x2c, 0xb6, 0x2d, 0xc3, 0x21, 0xee, 0x20, 0x3c,
+	0x95, 0xf5, 0x62, 0x55, 0x22, 0x45, 0x13, 0x6c, 0x73, 0x14, 0xcb, 0xe4, 0x78, 0x5f, 0x64, 0x09,
+	0xe3, 0x78, 0x03, 0xb6, 0x89, 0x12, 0x7a, 0xea, 0x39, 0xd1, 0x13, 0x48, 0x2d, 0xa2, 0xd8, 0x15,
+	0x04, 0xdd, 0x08, 0xcf, 0xca, 0x53, 0xb4, 0xfd, 0xf2, 0xd9, 0x84, 0xf7, 0x79, 0x6e, 0x6d, 0x45,
+	0x57, 0x25, 0x46, 0xc7, 0x21, 0xe1, 0xda, 0xa0, 0x6f, 0x41, 0x2d, 0x4b, 0x6d, 0xf4, 0x46, 0x81,
+	0x7c, 0x5a, 0x53, 0xf4, 0xb5, 0x49, 0x9e, 0x5d, 0x86, 0x64, 0x83, 0x68, 0x62, 0x87, 0x34, 0x7c,
+	0x3c, 0x20, 0x54, 0x3e, 0x9f, 0x2c, 0x8f, 0x37, 0xc4, 0x63, 0x06, 0xe6, 0x1d, 0x75, 0x72, 0x01,
+	0x16, 0x45, 0x21, 0xb3, 0xd5, 0x3e, 0x86, 0x6a, 0x4c, 0xca, 0x47, 0x45, 0xc3, 0x22, 0x7e, 0x78,
+	0xca, 0x77, 0x8e, 0x8a, 0x8e, 0x22, 0xdc, 0xf7, 0x19, 0x6a, 0x9f, 0x61, 0xd0, 0x47, 0x70, 0x93,
+	0xb9, 0x43, 0xac, 0xb6, 0xd9, 0x49, 0xac, 0xcc, 0x0b, 0xdf, 0xfa, 0x10, 0xbf, 0x11, 0x23, 0xdf,
+	0xc4, 0x38, 0xf6, 0x2e, 0xb0, 0xbd, 0xc8, 0x30, 0xfd, 0x51, 0x3c, 0xfa, 0x00, 0x57, 0xbb, 0x32,
+	0xc4, 0x6f, 0xf6, 0xfc, 0x91, 0x9c, 0x7d, 0xb4, 0x3f, 0x94, 0x60, 0x55, 0x44, 0x57, 0x2a, 0x3a,
+	0x50, 0x6b, 0x5a, 0x30, 0xb0, 0xf7, 0x06, 0xf9, 0x60, 0x1e, 0xfd, 0x0a, 0xb1, 0x75, 0xd2, 0x72,
+	0xc3, 0x27, 0x3b, 0x9f, 0xb2, 0xec, 0xca, 0x09, 0x95, 0xe3, 0xcb, 0x42, 0xe5, 0x2a, 0x71, 0xb9,
+	0x81, 0xf4, 0xea, 0xca, 0x40, 0xba, 0x4a, 0xec, 0xf4, 0x30, 0xfb, 0xde, 0xd4, 0x30, 0xcb, 0x93,
+	0xb9, 0xef, 0x8d, 0x7a, 0x0e, 0x91, 0x9a, 0x67, 0x82, 0xf0, 0xe4, 0x8a, 0x20, 0xbc, 0x4a, 0xe2,
+	0x94, 0x10, 0x3d, 0xc8, 0x7d, 0x77, 0xb9, 0x5a, 0xe9, 0x4c, 0x50, 0x1e, 0x4e, 0x09, 0xca, 0xb9,
+	0x6b, 0x08, 0xcb, 0x0b, 0xd9, 0x57, 0x97, 0x87, 0xec, 0xfc, 0x25, 0x62, 0xbf, 0xf9, 0x9e, 0x10,
+	0x3b, 0x35, 0xa0, 0xf7, 0xb3, 0x01, 0x5d, 0xbe, 0x86, 0x09, 0xd3, 0xe1, 0x8e, 0x3e, 0xc9, 0xcb,
+	0x6e, 0xb8, 0x8e, 0xed, 0x26, 0x72, 0x5f, 0xfb, 0x12, 0xaa, 0xe9, 0xbc, 0x89, 0x9f, 0x48, 0x4b,
+	0xdc, 0xcd, 0x74, 0xfa, 0xcf, 0x69, 0x89, 0xa2, 0xab, 0x4b, 0xe2, 0xaf, 0xfb, 0xbb, 0xd4, 0xc3,
+	0x7f, 0x17, 0x00, 0xc6, 0x8f, 0xe0, 0x68, 0x1d, 0x56, 0xf7, 0xf5, 0xa3, 0x63, 0x43, 0x6f, 0x36,
+	0x3a, 0x47, 0x87, 0xc6, 0xc9, 0xe1, 0x8b, 0xc3, 0xa3, 0xcf, 0x0e, 0xd5, 0xff, 0x41, 0x37, 0x61,
+	0x3d, 0x89, 0xd8, 0x3d, 0x79, 0xfe, 0xbc, 0xa9, 0x1b, 0xcf, 0x4f, 0xda, 0x6d, 0x55, 0x41, 0x35,
+	0xa8, 0x26, 0x91, 0x47, 0x9f, 0x36, 0xf5, 0xf6, 0x51, 0x63, 0x5f, 0x2d, 0xa0, 0x5b, 0x50, 0x4b,
+	0x62, 0x1a, 0x7b, 0x2f, 0x8c, 0xee, 0x27, 0xfa, 0x51, 0xb7, 0xdb, 0x6e, 0xaa, 0xc5, 0x49, 0x6c,
+	0xf3, 0x40, 0x6f, 0x76, 0x3a, 0x46, 0xbb, 0xf5, 0xb2, 0xd5, 0x55, 0x67, 0x90, 0x06, 0x77, 0x92,
+	0xd8, 0xbd, 0xa3, 0xc3, 0x6e, 0xa3, 0x75, 0xd8, 0xd4, 0x8d, 0x4e, 0xe3, 0xe5, 0x71, 0xbb, 0x75,
+	0x78, 0xa0, 0xce, 0x4e, 0xd2, 0x74, 0x7e, 0xd0, 0xd9, 0x6b, 0xb4, 0xdb, 0x86, 0xde, 0xe8, 0x36,
+	0xa5, 0x9c, 0x12, 0xda, 0x80, 0x5b, 0x49, 0x1a, 0xbd, 0x75, 0x78, 0x10, 0xdd, 0xbf, 0x7d, 0xd4,
+	0xe9, 0xa8, 0x73, 0x93, 0xf7, 0xd8, 0x6f, 0xee, 0x1d, 0xed, 0x37, 0x8d, 0xa6, 0xae, 0x1f, 0xe9,
+	0xea, 0x3c, 0xba, 0x0b, 0xb7, 0x93, 0xd8, 0xe8, 0xfe, 0xc6, 0xcb, 0xa3, 0xfd, 0xd6, 0xf3, 0x56,
+	0x53, 0x57, 0xcb, 0xe8, 0x2d, 0x58, 0x4b, 0x5d, 0xf5, 0xf8, 0x44, 0x9e, 0x0e, 0xe8, 0x0e, 0xd4,
+	0x73, 0x64, 0xef, 0x36, 0xf6, 0x5e, 0xb4, 0x8f, 0x0e, 0xd4, 0x85, 0x9d, 0x7f, 0x94, 0x40, 0x8d,
+	0x27, 0x98, 0x8e, 0xf8, 0x7d, 0x18, 0x9d, 0x41, 0x39, 0xfe, 0x61, 0x0e, 0xdd, 0xbd, 0xec, 0x47,
+	0x3b, 0x5e, 0x65, 0xeb, 0xda, 0xd5, 0xbf, 0xeb, 0x69, 0x6b, 0x5f, 0xfd, 0xf5, 0x9f, 0x3f, 0x2b,
+	0x2c, 0x6b, 0xc0, 0x7e, 0x34, 0x16, 0xeb, 0xce, 0x53, 0xe5, 0xe1, 0x63, 0x05, 0xfd, 0x18, 0x96,
+	0x27, 0xde, 0xe9, 0xd1, 0xfd, 0x3c, 0x79, 0x39, 0x8f, 0xfc, 0xf5, 0xcd, 0xab, 0x09, 0xe5, 0xf1,
+	0x35, 0x7e, 0x3c, 0x42, 0x2a, 0x3b, 0xde, 0x4c, 0x1e, 0x76, 0x0e, 0x95, 0xd4, 0xf3, 0x3a, 0xba,
+	0x97, 0x27, 0x34, 0xf3, 0x2e, 0x5f, 0x7f, 0xf7, 0x2a, 0x32, 0x79, 0xf2, 0x0d, 0x7e, 0xb2, 0x8a,
+	0x96, 0xd8, 0xc9, 0x74, 0x7c, 0x4c, 0x9f, 0x1b, 0x59, 0xfe, 0x5a, 0x95, 0x6b, 0xe4, 0xd4, 0xde,
+	0x5b, 0xd7, 0x2e, 0x23, 0x91, 0x67, 0x21, 0x7e, 0xd6, 0x22, 0xe2, 0x46, 0x16, 0x3f, 0xad, 0xa1,
+	0x5f, 0x28, 0xa0, 0x4e, 0x0e, 0x5e, 0x28, 0x6b, 0xb8, 0x29, 0x63, 0x64, 0xfd, 0xc1, 0x35, 0x28,
+	0xe5, 0xe9, 0x4f, 0xf9, 0xe9, 0xef, 0x69, 0xdb, 0x93, 0xff, 0x3b, 0x40, 0xb7, 0x7f, 0x34, 0x31,
+	0x8a, 0x7e, 0xb9, 0x1d, 0x75, 0x14, 0xdb, 0x62, 0x71, 0x80, 0x30, 0xb7, 0x86, 0x1c, 0xe1, 0x72,
+	0xad, 0x91, 0x6a, 0xec, 0xf5, 0xcb, 0xeb, 0x51, 0xda, 0x10, 0xb2, 0x36, 0x05, 0xb0, 0x98, 0x2c,
+	0x75, 0xe8, 0x9d, 0x29, 0x9a, 0xa5, 0x0f, 0xba, 0x77, 0x05, 0x55, 0x3a, 0xbc, 0x9f, 0x2a, 0x0f,
+	0xb5, 0xc4, 0x99, 0xbd, 0x12, 0xaf, 0xc2, 0x4f, 0xfe, 0x33, 0x00, 0x0e, 0xea, 0xc8, 0x8b, 0x95,
+	0x21, 0x00, 0x00,
 }
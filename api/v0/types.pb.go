@@ -19,12 +19,22 @@ It has these top-level messages:
 	NetworkAddress
 	Credentials
 	TelemetryEvent
+	MemoryAccess
+	QuietPeriodEvent
+	FilelessExecutionEvent
+	SubscriptionStatsEvent
+	ContainerSampleRate
+	DecodeErrorEvent
+	AnyTelemetryEvent
 	ChargenEvent
 	TickerEvent
 	ContainerEvent
 	ProcessEvent
+	FileDescriptor
 	SyscallEvent
+	SyscallArgValueCount
 	FileEvent
+	SignalEvent
 	Process
 	KernelFunctionCallEvent
 	NetworkEvent
@@ -32,13 +42,48 @@ It has these top-level messages:
 	PerformanceEvent
 	GetEventsRequest
 	GetEventsResponse
+	SubscriptionSummary
+	DropCount
+	DictionaryEntry
+	DictionaryReferences
+	GetCapabilitiesRequest
+	GetCapabilitiesResponse
+	GetStatisticsRequest
+	GetStatisticsResponse
+	SubscriptionDropStatistics
+	SyscallCost
+	AckThrottleStatistics
+	DispatchWorkerStatistics
+	EgressStatistics
+	RingBufferStatistics
+	GetCountsRequest
+	GetCountsResponse
+	SyscallCount
+	ContainerCount
+	FilterStatistics
 	ReceivedTelemetryEvent
+	UpdateSyscallIdsRequest
+	UpdateSyscallIdsResponse
+	GetLimitsRequest
+	SensorLimits
+	UpdateLimitsRequest
+	UpdateLimitsResponse
 	Subscription
 	ContainerFilter
+	PidFilter
+	PidCardinality
+	ContainerSampling
+	AckThrottle
+	QuietPeriod
+	EdgeTrigger
 	EventFilter
+	FilelessExecutionFilter
 	SyscallEventFilter
+	SyscallArgDistribution
 	ProcessEventFilter
+	ExecFdSnapshot
 	FileEventFilter
+	SignalEventFilter
 	KernelFunctionCallFilter
 	NetworkEventFilter
 	PerformanceEventCounter
@@ -453,34 +498,33 @@ func init() {
 func init() { proto.RegisterFile("capsule8/api/v0/types.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 449 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0x51, 0x6f, 0x9a, 0x50,
-	0x1c, 0xc5, 0x45, 0x5b, 0x5c, 0xff, 0xd4, 0x8e, 0xdc, 0xf8, 0x60, 0xd2, 0xa6, 0x23, 0x2c, 0xcd,
-	0xcc, 0x1e, 0xb0, 0x69, 0x1b, 0xb2, 0x97, 0x3d, 0xb0, 0xd6, 0x46, 0x53, 0x87, 0xe6, 0xea, 0x62,
-	0xf6, 0xc4, 0x98, 0xa0, 0xde, 0x8c, 0x09, 0xe1, 0x22, 0xc6, 0x0f, 0xb2, 0xe7, 0x7d, 0x82, 0x7d,
-	0xc7, 0xe5, 0x5e, 0x2e, 0x4c, 0x37, 0x35, 0x4b, 0xdf, 0xfe, 0xf7, 0xf0, 0xfb, 0x1f, 0x0e, 0xe7,
-	0x06, 0x38, 0x9f, 0xb8, 0x11, 0x5d, 0x06, 0xfe, 0xbb, 0x96, 0x1b, 0x91, 0x56, 0x7a, 0xdd, 0x4a,
-	0xd6, 0x91, 0x4f, 0x8d, 0x28, 0x0e, 0x93, 0x10, 0xbd, 0xcc, 0x1f, 0x1a, 0x6e, 0x44, 0x8c, 0xf4,
-	0x5a, 0x7f, 0x03, 0x4a, 0x77, 0x90, 0xde, 0x59, 0x9e, 0x17, 0xfb, 0x94, 0xa2, 0x06, 0x54, 0xdd,
-	0x6c, 0x6c, 0x48, 0x9a, 0xd4, 0xac, 0xe2, 0xfc, 0xa8, 0x7f, 0x01, 0xb4, 0x01, 0x5a, 0x0b, 0x6f,
-	0x10, 0xc6, 0x09, 0x32, 0xb7, 0x79, 0xe5, 0xe6, 0xc2, 0xf8, 0xeb, 0x0d, 0xc6, 0xc6, 0x56, 0xe1,
-	0x86, 0x10, 0x1c, 0x45, 0x61, 0x9c, 0x34, 0xca, 0x9a, 0xd4, 0xac, 0x61, 0x3e, 0xeb, 0xb7, 0x3c,
-	0x8a, 0x69, 0xfd, 0x41, 0xe6, 0x64, 0x36, 0xe7, 0xbe, 0x32, 0xe6, 0x33, 0x52, 0xa1, 0x12, 0x84,
-	0x2b, 0xbe, 0x25, 0x63, 0x36, 0x8a, 0x58, 0xe6, 0xb3, 0x62, 0x99, 0xff, 0x15, 0xeb, 0x47, 0x19,
-	0xce, 0x6c, 0x3f, 0x59, 0x85, 0xf1, 0xb7, 0x3c, 0xda, 0x7b, 0x90, 0xa7, 0xee, 0x77, 0x12, 0xac,
-	0xb9, 0xfb, 0xd9, 0xcd, 0xd5, 0x3f, 0xee, 0xdb, 0x0b, 0x8f, 0x1c, 0xc6, 0x62, 0x09, 0x75, 0xe0,
-	0x94, 0x44, 0xe9, 0x9d, 0x93, 0x47, 0x04, 0x1e, 0xf1, 0xf5, 0xa1, 0xe6, 0xc4, 0x87, 0x75, 0x4a,
-	0x58, 0x61, 0xab, 0x79, 0x90, 0xcc, 0xc9, 0x2c, 0x9c, 0xea, 0xfb, 0x9d, 0xcc, 0x9d, 0x4e, 0x45,
-	0xdb, 0x57, 0x50, 0x0b, 0xc2, 0x89, 0x1b, 0x14, 0x56, 0x97, 0x9a, 0xd4, 0x3c, 0xe9, 0x94, 0xf0,
-	0x29, 0x97, 0x05, 0xf6, 0xe1, 0xa4, 0x28, 0x56, 0xff, 0x25, 0x81, 0x72, 0x1f, 0xfb, 0x9e, 0xbf,
-	0x48, 0x88, 0x1b, 0x50, 0x76, 0x37, 0x4b, 0xe2, 0xf1, 0x46, 0x6a, 0x98, 0x8d, 0x4c, 0x99, 0x11,
-	0x4f, 0x94, 0xc9, 0x46, 0xd6, 0xaf, 0xcf, 0xa0, 0x4a, 0xd6, 0x2f, 0x9b, 0xb9, 0xc6, 0xb0, 0x23,
-	0xa1, 0x09, 0x8e, 0x32, 0xee, 0x38, 0xd3, 0xa8, 0xe0, 0x28, 0xe3, 0x64, 0xa1, 0x31, 0xae, 0x0e,
-	0xc7, 0x53, 0x0e, 0x56, 0xb9, 0x98, 0x1d, 0x32, 0x95, 0xa1, 0x2f, 0x72, 0x75, 0x46, 0xbc, 0xb7,
-	0x3f, 0x25, 0xa8, 0xef, 0xba, 0x16, 0xa4, 0xc3, 0xa5, 0xdd, 0x1e, 0x8d, 0xfb, 0xf8, 0xc9, 0xb1,
-	0x1e, 0x1e, 0x70, 0x7b, 0x38, 0x74, 0x1e, 0xad, 0x8f, 0xdd, 0xde, 0x67, 0xe7, 0x93, 0xfd, 0x64,
-	0xf7, 0xc7, 0xb6, 0x5a, 0x42, 0xaf, 0xe0, 0x7c, 0x0f, 0xd3, 0xb5, 0xdb, 0x23, 0x55, 0x42, 0x1a,
-	0x5c, 0x1c, 0x00, 0x4c, 0xb5, 0x7c, 0x80, 0xe8, 0xf5, 0xef, 0xad, 0x9e, 0x5a, 0xf9, 0x2a, 0xf3,
-	0x7f, 0xf4, 0xf6, 0x77, 0x00, 0x00, 0x00, 0xff, 0xff, 0x05, 0xf4, 0xc6, 0xf8, 0xc2, 0x03, 0x00,
-	0x00,
+	// 446 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0x41, 0x4f, 0xdb, 0x30,
+	0x1c, 0xc5, 0x9b, 0x16, 0xd2, 0xf1, 0x0f, 0x65, 0x91, 0xd5, 0x43, 0x25, 0x10, 0x8b, 0x32, 0xa1,
+	0x55, 0x3b, 0xa4, 0x08, 0x50, 0xb4, 0xcb, 0x0e, 0x19, 0x14, 0xb5, 0xa2, 0x4b, 0x91, 0x61, 0x42,
+	0x3b, 0x65, 0x19, 0x31, 0xc5, 0x5a, 0x46, 0xac, 0x38, 0x4d, 0xd5, 0x0f, 0xb2, 0xf3, 0x3e, 0xc1,
+	0xbe, 0xe3, 0x64, 0xc7, 0xc9, 0xda, 0xad, 0xad, 0x26, 0x6e, 0x7f, 0xbf, 0xfc, 0xfe, 0x2f, 0x2f,
+	0xcf, 0x0a, 0xec, 0xdf, 0x87, 0x8c, 0x4f, 0x63, 0xf2, 0xae, 0x17, 0x32, 0xda, 0xcb, 0x8f, 0x7b,
+	0xd9, 0x9c, 0x11, 0xee, 0xb0, 0x34, 0xc9, 0x12, 0xf4, 0xb2, 0x7c, 0xe8, 0x84, 0x8c, 0x3a, 0xf9,
+	0xb1, 0xfd, 0x06, 0x8c, 0xe1, 0x75, 0x7e, 0xe6, 0x45, 0x51, 0x4a, 0x38, 0x47, 0x1d, 0x68, 0x86,
+	0xc5, 0xd8, 0xd1, 0x2c, 0xad, 0xdb, 0xc4, 0xe5, 0xd1, 0xfe, 0x02, 0x68, 0x01, 0xf4, 0x9e, 0xa2,
+	0xeb, 0x24, 0xcd, 0x90, 0xbb, 0xcc, 0x1b, 0x27, 0x07, 0xce, 0x5f, 0x6f, 0x70, 0x16, 0xb6, 0x2a,
+	0x37, 0x84, 0x60, 0x8b, 0x25, 0x69, 0xd6, 0xa9, 0x5b, 0x5a, 0xb7, 0x85, 0xe5, 0x6c, 0x9f, 0xca,
+	0x28, 0xae, 0xf7, 0x07, 0x79, 0xa4, 0x93, 0x47, 0xe9, 0xab, 0x63, 0x39, 0x23, 0x13, 0x1a, 0x71,
+	0x32, 0x93, 0x5b, 0x3a, 0x16, 0xa3, 0x8a, 0xe5, 0x3e, 0x2b, 0x96, 0xfb, 0x5f, 0xb1, 0x7e, 0xd4,
+	0x61, 0xcf, 0x27, 0xd9, 0x2c, 0x49, 0xbf, 0x95, 0xd1, 0xde, 0x83, 0xfe, 0x10, 0x7e, 0xa7, 0xf1,
+	0x5c, 0xba, 0xef, 0x9d, 0x1c, 0xfd, 0xe3, 0xbe, 0xbc, 0x70, 0x29, 0x61, 0xac, 0x96, 0xd0, 0x00,
+	0x76, 0x29, 0xcb, 0xcf, 0x82, 0x32, 0x22, 0xc8, 0x88, 0xaf, 0x37, 0x35, 0xa7, 0x3e, 0x6c, 0x50,
+	0xc3, 0x06, 0x65, 0x95, 0xaa, 0x9c, 0xdc, 0xca, 0xa9, 0xbd, 0xde, 0xc9, 0x5d, 0xe9, 0x54, 0xb5,
+	0x7d, 0x04, 0xad, 0x38, 0xb9, 0x0f, 0xe3, 0xca, 0xea, 0xd0, 0xd2, 0xba, 0x3b, 0x83, 0x1a, 0xde,
+	0x95, 0xb2, 0xc2, 0x3e, 0xec, 0x54, 0xc5, 0xda, 0xbf, 0x34, 0x30, 0xce, 0x53, 0x12, 0x91, 0xa7,
+	0x8c, 0x86, 0x31, 0x17, 0x77, 0x33, 0xa5, 0x91, 0x6c, 0xa4, 0x85, 0xc5, 0x28, 0x94, 0x09, 0x8d,
+	0x54, 0x99, 0x62, 0x14, 0xfd, 0x12, 0x01, 0x35, 0x8a, 0x7e, 0xc9, 0x54, 0x69, 0x02, 0xdb, 0x52,
+	0x9a, 0xe2, 0xb8, 0xe0, 0xb6, 0x0b, 0x8d, 0x2b, 0x8e, 0x0b, 0x4e, 0x57, 0x9a, 0xe0, 0xda, 0xb0,
+	0xfd, 0x20, 0xc1, 0xa6, 0x14, 0x8b, 0x43, 0xa1, 0x0a, 0xf4, 0x45, 0xa9, 0x4e, 0x68, 0xf4, 0xf6,
+	0xa7, 0x06, 0xed, 0x55, 0xd7, 0x82, 0x6c, 0x38, 0xf4, 0xfb, 0xb7, 0x77, 0x63, 0x7c, 0x15, 0x78,
+	0x17, 0x17, 0xb8, 0x7f, 0x73, 0x13, 0x5c, 0x7a, 0x1f, 0x87, 0xa3, 0xcf, 0xc1, 0x27, 0xff, 0xca,
+	0x1f, 0xdf, 0xf9, 0x66, 0x0d, 0xbd, 0x82, 0xfd, 0x35, 0xcc, 0xd0, 0xef, 0xdf, 0x9a, 0x1a, 0xb2,
+	0xe0, 0x60, 0x03, 0xe0, 0x9a, 0xf5, 0x0d, 0xc4, 0x68, 0x7c, 0xee, 0x8d, 0xcc, 0xc6, 0x57, 0x5d,
+	0xfe, 0xa3, 0xa7, 0xbf, 0x07, 0x00, 0x05, 0xf4, 0xc6, 0xf8, 0xc2, 0x03, 0x00, 0x00,
 }
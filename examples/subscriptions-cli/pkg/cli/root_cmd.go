@@ -9,12 +9,15 @@ import (
 	"os/signal"
 
 	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/auditrule"
 
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 )
 
-type options struct{}
+type options struct {
+	auditRules []string
+}
 
 // NewRootCommand creates the root command of the example CLI and returns it to be executed
 func NewRootCommand(out, errorOut io.Writer) *cobra.Command {
@@ -22,9 +25,9 @@ func NewRootCommand(out, errorOut io.Writer) *cobra.Command {
 	opts := options{}
 
 	var rootCommand = &cobra.Command{
-		Use:   "c8cli <subscription_file>",
+		Use:   "c8cli [subscription_file]",
 		Short: "Subscribe to capsule8 telemetry events",
-		Args:  cobra.ExactArgs(1),
+		Args:  cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			err := opts.Run(out, errorOut, args)
 			if err != nil {
@@ -33,15 +36,28 @@ func NewRootCommand(out, errorOut io.Writer) *cobra.Command {
 			}
 		},
 	}
+	rootCommand.Flags().StringArrayVar(&opts.auditRules, "audit-rule", nil,
+		"auditctl system call rule to subscribe to (may be repeated)")
 	return rootCommand
 }
 
 // Run executes the logic of the example CLI based on its receiver options
 func (opts *options) Run(out, errorOut io.Writer, args []string) error {
 
-	subscription, err := getSubscipritionsFromFile(os.Args[1])
-	if err != nil {
-		return fmt.Errorf("Error reading subscription: %s", err.Error())
+	if len(args) == 0 && len(opts.auditRules) == 0 {
+		return fmt.Errorf("A subscription file or audit rule is required")
+	}
+
+	subscription := &api.Subscription{}
+	if len(args) > 0 {
+		var err error
+		subscription, err = getSubscipritionsFromFile(args[0])
+		if err != nil {
+			return fmt.Errorf("Error reading subscription: %s", err.Error())
+		}
+	}
+	if err := addAuditRules(subscription, opts.auditRules); err != nil {
+		return err
 	}
 
 	// Create telemetry service client
@@ -91,3 +107,20 @@ func (opts *options) Run(out, errorOut io.Writer, args []string) error {
 		}
 	}
 }
+
+// addAuditRules translates auditctl system call rules into syscall event
+// filters and adds them to the subscription
+func addAuditRules(subscription *api.Subscription, rules []string) error {
+	for _, rule := range rules {
+		sef, err := auditrule.Parse(rule)
+		if err != nil {
+			return fmt.Errorf("Error translating audit rule %q: %s", rule, err)
+		}
+		if subscription.EventFilter == nil {
+			subscription.EventFilter = &api.EventFilter{}
+		}
+		subscription.EventFilter.SyscallEvents =
+			append(subscription.EventFilter.SyscallEvents, sef)
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auditrule translates auditd system call rules, as given to
+// auditctl, into Sensor syscall event filters. This eases moving existing
+// audit rules to Sensor subscriptions, such as:
+//
+//	-a always,exit -F arch=b64 -S execve -F euid=0 -k root_exec
+//
+// Only rules on the exit list with the always action are supported. Each rule
+// must name at least one system call with -S, by name or number. Keys given
+// with -k or -F key are ignored. The supported -F fields are:
+//
+//	arch        b64 or x86_64 (only with =)
+//	success     1 if the system call succeeded, 0 if it failed (= or !=)
+//	exit        the return value of the system call, as a number or an
+//	            error name such as -EACCES
+//	a0 - a3     the first four system call arguments
+//	uid, gid    the real user and group IDs of the calling task
+//	euid, egid  the effective user and group IDs of the calling task
+//
+// The supported operators are =, !=, <, <=, >, and >=. Every rule produces a
+// SYSCALL_EVENT_TYPE_COMPLETE filter, so that success and exit may be compared
+// along with system call arguments. Any other option, field, or operator is
+// rejected with an error.
+package auditrule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+)
+
+// The only architecture that system call names are resolved for
+const defaultArch = "x86_64"
+
+var archNames = map[string]string{
+	"b64":    "x86_64",
+	"x86_64": "x86_64",
+}
+
+type comparison func(lhs, rhs *api.Expression) *api.Expression
+
+// Operators are ordered so that the longest match is tried first
+var operators = []struct {
+	text string
+	cmp  comparison
+}{
+	{"!=", expression.NotEqual},
+	{"<=", expression.LessThanEqualTo},
+	{">=", expression.GreaterThanEqualTo},
+	{"&=", nil},
+	{"=", expression.Equal},
+	{"<", expression.LessThan},
+	{">", expression.GreaterThan},
+	{"&", nil},
+}
+
+var argIdentifiers = map[string]string{
+	"a0": "arg0",
+	"a1": "arg1",
+	"a2": "arg2",
+	"a3": "arg3",
+}
+
+var credIdentifiers = map[string]string{
+	"uid":  "task_uid",
+	"gid":  "task_gid",
+	"euid": "task_euid",
+	"egid": "task_egid",
+}
+
+type rule struct {
+	arch     string
+	syscalls []string
+
+	filter *api.Expression
+}
+
+// Parse translates a single auditctl system call rule into a syscall event
+// filter.
+func Parse(text string) (*api.SyscallEventFilter, error) {
+	args := strings.Fields(text)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("Empty audit rule")
+	}
+
+	r := rule{arch: defaultArch}
+	list := false
+	for i := 0; i < len(args); i++ {
+		option := args[i]
+		var value string
+		switch option {
+		case "-a", "-A", "-S", "-F", "-k":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("Audit rule option %s requires a value", option)
+			}
+			i++
+			value = args[i]
+		default:
+			return nil, fmt.Errorf("Unsupported audit rule option %s", option)
+		}
+
+		var err error
+		switch option {
+		case "-a", "-A":
+			err = parseList(value)
+			list = true
+		case "-S":
+			r.syscalls = append(r.syscalls, strings.Split(value, ",")...)
+		case "-F":
+			err = r.parseField(value)
+		case "-k":
+			// Keys only label audit records
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !list {
+		return nil, fmt.Errorf("Audit rule requires -a always,exit")
+	}
+
+	// System call names are resolved last, because auditctl allows the
+	// arch field to follow them.
+	if len(r.syscalls) == 0 {
+		return nil, fmt.Errorf("Audit rule requires at least one system call (-S)")
+	}
+	var idFilter *api.Expression
+	for _, name := range r.syscalls {
+		id, err := syscallNumber(r.arch, name)
+		if err != nil {
+			return nil, err
+		}
+		idFilter = expression.LogicalOr(idFilter,
+			expression.Equal(
+				expression.Identifier("id"),
+				expression.Value(id)))
+	}
+
+	return &api.SyscallEventFilter{
+		Type:             api.SyscallEventType_SYSCALL_EVENT_TYPE_COMPLETE,
+		FilterExpression: expression.LogicalAnd(idFilter, r.filter),
+	}, nil
+}
+
+func parseList(value string) error {
+	parts := strings.Split(value, ",")
+	if len(parts) != 2 {
+		return fmt.Errorf("Invalid audit rule list and action %q", value)
+	}
+	list, action := parts[0], parts[1]
+	if list == "always" || list == "never" {
+		list, action = action, list
+	}
+	if list != "exit" {
+		return fmt.Errorf("Unsupported audit rule list %q", list)
+	}
+	if action != "always" {
+		return fmt.Errorf("Unsupported audit rule action %q", action)
+	}
+	return nil
+}
+
+func (r *rule) parseField(value string) error {
+	var (
+		field, op, operand string
+		cmp                comparison
+	)
+	for _, o := range operators {
+		if i := strings.Index(value, o.text); i > 0 {
+			field, op, operand = value[:i], o.text, value[i+len(o.text):]
+			cmp = o.cmp
+			break
+		}
+	}
+	if len(field) == 0 || len(operand) == 0 {
+		return fmt.Errorf("Invalid audit rule field %q", value)
+	}
+	if cmp == nil {
+		return fmt.Errorf("Unsupported audit rule operator %s", op)
+	}
+
+	var expr *api.Expression
+	switch {
+	case field == "arch":
+		arch, ok := archNames[operand]
+		if !ok || op != "=" {
+			return fmt.Errorf("Unsupported audit rule architecture %s%s", op, operand)
+		}
+		r.arch = arch
+		return nil
+
+	case field == "key":
+		return nil
+
+	case field == "success":
+		if op != "=" && op != "!=" {
+			return fmt.Errorf("Unsupported operator %s for audit rule field success", op)
+		}
+		var success bool
+		switch operand {
+		case "1", "yes":
+			success = true
+		case "0", "no":
+		default:
+			return fmt.Errorf("Invalid audit rule success value %q", operand)
+		}
+		if op == "!=" {
+			success = !success
+		}
+		if success {
+			expr = expression.GreaterThanEqualTo(
+				expression.Identifier("ret"),
+				expression.Value(int64(0)))
+		} else {
+			expr = expression.LessThan(
+				expression.Identifier("ret"),
+				expression.Value(int64(0)))
+		}
+
+	case field == "exit":
+		v, err := exitValue(operand)
+		if err != nil {
+			return err
+		}
+		expr = cmp(expression.Identifier("ret"), expression.Value(v))
+
+	case argIdentifiers[field] != "":
+		v, err := strconv.ParseUint(operand, 0, 64)
+		if err != nil {
+			return fmt.Errorf("Invalid audit rule %s value %q", field, operand)
+		}
+		expr = cmp(expression.Identifier(argIdentifiers[field]),
+			expression.Value(v))
+
+	case credIdentifiers[field] != "":
+		v, err := strconv.ParseUint(operand, 10, 32)
+		if err != nil {
+			return fmt.Errorf("Invalid audit rule %s value %q (only numeric IDs are supported)", field, operand)
+		}
+		expr = cmp(expression.Identifier(credIdentifiers[field]),
+			expression.Value(uint32(v)))
+
+	default:
+		return fmt.Errorf("Unsupported audit rule field %s", field)
+	}
+
+	r.filter = expression.LogicalAnd(r.filter, expr)
+	return nil
+}
+
+// exitValue parses the value of an exit field, which auditctl allows to be an
+// error name, negated for the return value of a failed system call.
+func exitValue(operand string) (int64, error) {
+	if v, err := strconv.ParseInt(operand, 0, 64); err == nil {
+		return v, nil
+	}
+	name, sign := operand, int64(1)
+	if strings.HasPrefix(name, "-") {
+		name, sign = name[1:], -1
+	}
+	if errno, ok := sys.ErrnoNumber(name); ok {
+		return sign * errno, nil
+	}
+	return 0, fmt.Errorf("Invalid audit rule exit value %q", operand)
+}
+
+func syscallNumber(arch, name string) (int64, error) {
+	if name == "all" {
+		return 0, fmt.Errorf("Audit rules for all system calls are not supported")
+	}
+	if id, err := strconv.ParseInt(name, 10, 64); err == nil {
+		return id, nil
+	}
+	id, ok := sys.SyscallNumber(arch, name)
+	if !ok {
+		return 0, fmt.Errorf("Unknown %s system call %q", arch, name)
+	}
+	return id, nil
+}
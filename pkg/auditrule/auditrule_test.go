@@ -0,0 +1,114 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditrule
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		rule     string
+		typ      api.SyscallEventType
+		expected string
+	}{
+		{
+			"-a always,exit -F arch=b64 -S execve -F euid=0 -k root_exec",
+			api.SyscallEventType_SYSCALL_EVENT_TYPE_COMPLETE,
+			"id = 59 AND task_euid = uint32(0)",
+		},
+		{
+			"-a exit,always -S open,openat -F a1&=3 -k x",
+			0, "",
+		},
+		{
+			"-a exit,always -S open -S 257 -F success=0",
+			api.SyscallEventType_SYSCALL_EVENT_TYPE_COMPLETE,
+			"(id = 2 OR id = 257) AND ret < 0",
+		},
+		{
+			"-A always,exit -S connect -F a0!=2 -F a2>=16",
+			api.SyscallEventType_SYSCALL_EVENT_TYPE_COMPLETE,
+			"id = 42 AND (arg0 != uint64(2) AND arg2 >= uint64(16))",
+		},
+		{
+			"-a always,exit -S kill -F exit=-1",
+			api.SyscallEventType_SYSCALL_EVENT_TYPE_COMPLETE,
+			"id = 62 AND ret = -1",
+		},
+		{
+			"-a always,exit -S open -F exit=-EACCES",
+			api.SyscallEventType_SYSCALL_EVENT_TYPE_COMPLETE,
+			"id = 2 AND ret = -13",
+		},
+		{
+			"-a always,exit -S open -F a1=2 -F success=1",
+			api.SyscallEventType_SYSCALL_EVENT_TYPE_COMPLETE,
+			"id = 2 AND (arg1 = uint64(2) AND ret >= 0)",
+		},
+	}
+	for _, tc := range tests {
+		sef, err := Parse(tc.rule)
+		if tc.expected == "" {
+			if err == nil {
+				t.Errorf("Expected error for %q", tc.rule)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %v", tc.rule, err)
+			continue
+		}
+		if sef.Type != tc.typ {
+			t.Errorf("Expected type %s for %q, got %s", tc.typ,
+				tc.rule, sef.Type)
+		}
+		s, err := expression.Format(sef.FilterExpression)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s != tc.expected {
+			t.Errorf("Expected %q for %q, got %q", tc.expected,
+				tc.rule, s)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	rules := []string{
+		"",
+		"-w /etc/passwd -p wa",
+		"-a never,exit -S execve",
+		"-a always,task",
+		"-a always,exit",
+		"-a always,exit -S all",
+		"-a always,exit -S nosuchsyscall",
+		"-a always,exit -F arch=b32 -S execve",
+		"-a always,exit -S execve -F auid>=1000",
+		"-a always,exit -S execve -F uid=root",
+		"-a always,exit -S open -F exit=-ENOSUCHERROR",
+		"-S execve",
+		"-a always,exit -S",
+	}
+	for _, rule := range rules {
+		if _, err := Parse(rule); err == nil {
+			t.Errorf("Expected error for %q", rule)
+		}
+	}
+}
@@ -15,6 +15,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/golang/glog"
 	"github.com/kelseyhightower/envconfig"
 )
@@ -78,6 +80,53 @@ var Sensor struct {
 	// Ignore missing perf_event cgroup filesystem mount
 	DontMountPerfEvent bool `split_words:"true"`
 
+	// Exclude events caused by the sensor's own threads from kernel
+	// events (file, kernel, network, signal, and syscall) for all
+	// subscriptions by default. This keeps the kernel from generating
+	// samples for the sensor's own activity that would otherwise be
+	// discarded later. Events of threads that the sensor creates later
+	// are still discarded by the sensor after the kernel has generated
+	// their samples. The exclusion is evaluated entirely by the kernel,
+	// so it does not prevent filters from being offloaded to it.
+	DefaultFilterExcludeSensor bool `split_words:"true" default:"true"`
+
+	// Process IDs (kernel task IDs) to exclude from kernel events
+	// (file, kernel, network, signal, and syscall) for all subscriptions
+	// by default, e.g. kernel threads such as 2 (kthreadd).
+	DefaultFilterExcludePids []int `split_words:"true"`
+
+	// Exclude events caused by kernel threads from kernel events (file,
+	// kernel, network, signal, and syscall) for all subscriptions by
+	// default. Unlike the pid exclusions, this can only be evaluated by
+	// the sensor rather than by the kernel, so it makes all of those
+	// filters userspace filters.
+	DefaultFilterExcludeKernelThreads bool `split_words:"true"`
+
+	// Directory to write events to for local retention. Events matching
+	// RingFileSubscription are written to files in this directory that
+	// are rotated by size and age. If empty, no events are written.
+	RingFileDir string `split_words:"true"`
+
+	// Path to a JSON encoded Subscription that selects the events
+	// written to RingFileDir.
+	RingFileSubscription string `split_words:"true"`
+
+	// The encoding of events written to RingFileDir, either "protobuf"
+	// (length-delimited), "json" (one event per line), "any"
+	// (length-delimited api.AnyTelemetryEvent), "cef" (one ArcSight
+	// Common Event Format event per line), or "sysmon" (one Sysmon-like
+	// JSON event per line, see docs/Sysmon.md).
+	RingFileFormat string `split_words:"true" default:"protobuf"`
+
+	// The size in bytes and age at which the current file in RingFileDir
+	// is rotated.
+	RingFileMaxSize int64         `split_words:"true" default:"67108864"`
+	RingFileMaxAge  time.Duration `split_words:"true" default:"1h"`
+
+	// The maximum total size in bytes of the files in RingFileDir. The
+	// oldest files are deleted to stay under it.
+	RingFileRetention int64 `split_words:"true" default:"1073741824"`
+
 	//
 	// Performance knobs below here
 	//
@@ -89,9 +138,148 @@ var Sensor struct {
 	// The default buffer length for Go channels used internally
 	ChannelBufferLength int `split_words:"true" default:"1024"`
 
+	// The maximum number of subscriptions that may be active at once, or
+	// 0 for no limit
+	MaxSubscriptions int `split_words:"true" default:"0"`
+
+	// The maximum total rate of telemetry sent to all subscriptions, in
+	// bytes of serialized events per second, or 0 for no limit. When the
+	// limit is reached, events are shed from the subscriptions using
+	// more than their share, weighted by priority.
+	MaxEgressBytesPerSecond uint64 `split_words:"true" default:"0"`
+
+	// The maximum CPU usage of the sensor itself, as a percentage of one
+	// CPU, or 0 for no limit. As usage approaches the limit, the events
+	// of low priority subscriptions are shed, and then those of normal
+	// priority subscriptions are sampled.
+	MaxCPUPercent float64 `split_words:"true" default:"0"`
+
+	// How long the idempotency key of a GetEvents request is remembered
+	// after its subscription ends, so that a retried request is not
+	// mistaken for a different request reusing the key.
+	IdempotencyKeyTTL time.Duration `split_words:"true" default:"5m"`
+
+	// The number of batches of samples waiting to be dispatched to
+	// subscriptions by a dispatch goroutine at which it considers itself
+	// overloaded.
+	// While overloaded, events are not delivered to low priority
+	// subscriptions.
+	DispatchBacklogThreshold int `split_words:"true" default:"32"`
+
+	// The number of goroutines that deliver decoded events to
+	// subscriptions, or 0 to use one per CPU up to 8. Samples are
+	// assigned to goroutines by process, so that the events of each
+	// process are delivered in order. Samples are always decoded on a
+	// single goroutine to keep the process info cache consistent.
+	DispatchWorkers int `split_words:"true" default:"0"`
+
+	// The maximum number of batches of samples queued for each dispatch
+	// goroutine, or 0 for no limit. Decoding waits while a queue is full,
+	// so that samples are lost by the kernel's ring buffers instead.
+	DispatchQueueDepth int `split_words:"true" default:"0"`
+
 	// The size of the process info cache. If the system pid_max is greater
 	// than this size, a less performant method of caching will be used.
 	ProcessInfoCacheSize uint `split_words:"true" default:"131072"`
+
+	// The amount of time to wait for a system call to return before its
+	// enter event is considered orphaned by subscriptions that combine
+	// enter and exit events into a single complete event.
+	SyscallCompleteTimeout time.Duration `split_words:"true" default:"10s"`
+
+	// How long the memory files created by memfd_create are remembered by
+	// subscriptions that detect their execution. A window of 0 remembers
+	// them for the lifetime of the subscription.
+	FilelessExecutionWindow time.Duration `split_words:"true" default:"10m"`
+
+	// How long the process status (thread count and resident set size)
+	// used to enrich events may be cached before it is read again from
+	// /proc. It is only read while some subscription's filter refers to
+	// num_threads or rss_kb. A TTL of 0 disables process status enrichment.
+	ProcessStatusTTL time.Duration `split_words:"true" default:"1s"`
+
+	// The names of the process environment variables that may be read
+	// from /proc to enrich events. Filters refer to them as identifiers
+	// of the form env.<name>, which are NULL if the variable is not set.
+	// The environment is filtered as it is parsed, so that the values of
+	// variables that are not listed, which may be secrets, are never
+	// retained.
+	EnvironmentVariables []string `split_words:"true"`
+
+	// The maximum number of file descriptors that are read from /proc
+	// for each exec event of subscriptions requesting an ExecFdSnapshot.
+	// A limit of 0 disables the snapshots.
+	ExecFdSnapshotMaxFds int `split_words:"true" default:"256"`
+
+	// The maximum rate of syscall events per second delivered for any
+	// single (pid, syscall) pair. Events beyond this rate are shed so that
+	// one busy process cannot crowd out events from others. Each type of
+	// event, such as enter and exit, is limited separately, and only
+	// events selected by some subscription's filters count toward the
+	// rate. A rate of 0 disables rate limiting.
+	SyscallRateLimit float64 `split_words:"true" default:"0"`
+
+	// The number of syscall events that a (pid, syscall) pair may burst
+	// above SyscallRateLimit.
+	SyscallRateLimitBurst float64 `split_words:"true" default:"100"`
+
+	// The maximum number of (pid, syscall) pairs tracked for rate
+	// limiting. The least recently seen pairs are forgotten first.
+	SyscallRateLimitKeys int `split_words:"true" default:"4096"`
+
+	// How often to log a summary of the syscall events shed by rate
+	// limiting for each (pid, syscall) pair.
+	SyscallRateLimitSummaryInterval time.Duration `split_words:"true" default:"1m"`
+
+	// Limits the capture of sensitive syscall arguments for all
+	// subscriptions, regardless of the arguments they request. Comma
+	// separated rules of the form <id>[:arg<n>]=<action>, where the
+	// action is "disable" to remove the argument, "hash" to replace it
+	// with a keyed hash that is consistent for the life of the sensor, or
+	// "truncate[:<bits>]" to keep only its low-order bits (16 by
+	// default). A rule without an argument applies to all arguments of
+	// the system call, e.g. "1:arg1=disable,250=hash". Filters see the
+	// limited values, so filters on an argument that any rule limits are
+	// always evaluated in userspace. Rules for the type and description
+	// arguments of add_key and request_key also apply to the key strings
+	// that they point to.
+	SyscallArgPolicy string `split_words:"true"`
+
+	// How long to retain counts of dispatched events per syscall and per
+	// container for GetCounts, and the length of the intervals that they
+	// are counted in. A retention of 0 disables the count series.
+	CountSeriesRetention  time.Duration `split_words:"true" default:"0"`
+	CountSeriesResolution time.Duration `split_words:"true" default:"1m"`
+
+	// How often to check for newly loaded kernel modules when kernel
+	// function call filters name symbols that are not yet available.
+	// Registration of those kprobes is retried once a module load makes
+	// their symbols available. 0 disables retrying, so that such filters
+	// fail immediately.
+	KprobeRetryInterval time.Duration `split_words:"true" default:"10s"`
+
+	// How to handle subscription filters that cannot be evaluated by the
+	// kernel, either "lenient" to evaluate them in userspace instead and
+	// report a warning status, or "strict" to reject them. Filters that
+	// refer to fields only known to the sensor are always evaluated in
+	// userspace.
+	FilterLoweringPolicy string `split_words:"true" default:"lenient"`
+
+	// Whether to report a status for each subscription that uses the
+	// deprecated Id, Arg0 - Arg5, or Ret fields of a SyscallEventFilter,
+	// naming the equivalent filter expression.
+	WarnDeprecatedFilterFields bool `split_words:"true"`
+
+	// Whether to send a DecodeErrorEvent to the subscriptions of a kernel
+	// event when one of its samples can't be decoded. Subscriptions whose
+	// filters are evaluated in userspace are not sent it, because their
+	// filters can't be applied. Decode errors are always counted and
+	// logged.
+	DecodeErrorEvents bool `split_words:"true"`
+
+	// The maximum rate of DecodeErrorEvents per second. Decode errors
+	// beyond this rate are only counted and logged.
+	DecodeErrorEventRate float64 `split_words:"true" default:"10"`
 }
 
 func init() {
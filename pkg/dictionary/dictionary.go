@@ -0,0 +1,160 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dictionary implements the dictionary encoding of GetEvents
+// streams. Repeated string values, such as process and container IDs, are
+// sent in full once and then referenced by index in later events.
+package dictionary
+
+import (
+	"fmt"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+// DefaultMaxEntries is the default maximum number of dictionary entries that
+// an Encoder will define for a single stream.
+const DefaultMaxEntries = 65536
+
+type field struct {
+	value     func(*api.TelemetryEvent) *string
+	reference func(*api.DictionaryReferences) *uint32
+}
+
+var fields = []field{
+	field{
+		value:     func(e *api.TelemetryEvent) *string { return &e.ProcessId },
+		reference: func(r *api.DictionaryReferences) *uint32 { return &r.ProcessId },
+	},
+	field{
+		value:     func(e *api.TelemetryEvent) *string { return &e.ContainerId },
+		reference: func(r *api.DictionaryReferences) *uint32 { return &r.ContainerId },
+	},
+	field{
+		value:     func(e *api.TelemetryEvent) *string { return &e.SensorId },
+		reference: func(r *api.DictionaryReferences) *uint32 { return &r.SensorId },
+	},
+	field{
+		value:     func(e *api.TelemetryEvent) *string { return &e.ContainerName },
+		reference: func(r *api.DictionaryReferences) *uint32 { return &r.ContainerName },
+	},
+	field{
+		value:     func(e *api.TelemetryEvent) *string { return &e.ImageId },
+		reference: func(r *api.DictionaryReferences) *uint32 { return &r.ImageId },
+	},
+	field{
+		value:     func(e *api.TelemetryEvent) *string { return &e.ImageName },
+		reference: func(r *api.DictionaryReferences) *uint32 { return &r.ImageName },
+	},
+}
+
+// Encoder dictionary encodes the responses of a single GetEvents stream.
+type Encoder struct {
+	maxEntries int
+	indexes    map[string]uint32
+}
+
+// NewEncoder creates a new Encoder that defines at most maxEntries dictionary
+// entries. Once the dictionary is full, values that are not already in it are
+// sent in full.
+func NewEncoder(maxEntries int) *Encoder {
+	return &Encoder{
+		maxEntries: maxEntries,
+		indexes:    make(map[string]uint32),
+	}
+}
+
+// Encode dictionary encodes the events in a response in place. Events that
+// are encoded are replaced with copies, so the original events are not
+// modified.
+func (enc *Encoder) Encode(r *api.GetEventsResponse) {
+	for _, re := range r.Events {
+		if re.Event == nil {
+			continue
+		}
+
+		e := *re.Event
+		refs := api.DictionaryReferences{}
+		encoded := false
+		for _, f := range fields {
+			v := f.value(&e)
+			if *v == "" {
+				continue
+			}
+			index, ok := enc.indexes[*v]
+			if !ok {
+				if len(enc.indexes) >= enc.maxEntries {
+					continue
+				}
+				index = uint32(len(enc.indexes) + 1)
+				enc.indexes[*v] = index
+				r.Dictionary = append(r.Dictionary,
+					&api.DictionaryEntry{
+						Index: index,
+						Value: *v,
+					})
+			}
+			*f.reference(&refs) = index
+			*v = ""
+			encoded = true
+		}
+		if encoded {
+			re.Event = &e
+			re.DictionaryReferences = &refs
+		}
+	}
+}
+
+// Decoder decodes the dictionary encoded responses of a single GetEvents
+// stream. Responses must be decoded in the order that they are received.
+type Decoder struct {
+	values map[uint32]string
+}
+
+// NewDecoder creates a new Decoder.
+func NewDecoder() *Decoder {
+	return &Decoder{
+		values: make(map[uint32]string),
+	}
+}
+
+// Decode restores the fields of dictionary encoded events in a response in
+// place. An error is returned if an event references an index that has not
+// been defined.
+func (dec *Decoder) Decode(r *api.GetEventsResponse) error {
+	for _, entry := range r.Dictionary {
+		dec.values[entry.Index] = entry.Value
+	}
+	r.Dictionary = nil
+
+	for _, re := range r.Events {
+		refs := re.DictionaryReferences
+		if refs == nil || re.Event == nil {
+			continue
+		}
+		for _, f := range fields {
+			index := *f.reference(refs)
+			if index == 0 {
+				continue
+			}
+			v, ok := dec.values[index]
+			if !ok {
+				return fmt.Errorf("Undefined dictionary index %d", index)
+			}
+			*f.value(re.Event) = v
+		}
+		re.DictionaryReferences = nil
+	}
+	return nil
+}
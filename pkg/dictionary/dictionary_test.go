@@ -0,0 +1,130 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dictionary
+
+import (
+	"fmt"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func newTestResponse(i int) *api.GetEventsResponse {
+	return &api.GetEventsResponse{
+		Events: []*api.ReceivedTelemetryEvent{
+			&api.ReceivedTelemetryEvent{
+				Event: &api.TelemetryEvent{
+					Id:            fmt.Sprintf("event-%d", i),
+					ProcessId:     fmt.Sprintf("process-%d", i%2),
+					ProcessPid:    int32(1000 + i%2),
+					ContainerId:   "0123456789abcdef0123456789abcdef",
+					SensorId:      "fedcba9876543210fedcba9876543210",
+					ContainerName: "/test",
+					ImageId:       "sha256:0123456789abcdef",
+					ImageName:     "docker.io/library/test:latest",
+				},
+			},
+		},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	enc := NewEncoder(DefaultMaxEntries)
+	dec := NewDecoder()
+
+	var plainSize, encodedSize int
+	for i := 0; i < 100; i++ {
+		r := newTestResponse(i)
+		original := proto.Clone(r).(*api.GetEventsResponse)
+		plainSize += proto.Size(r)
+
+		enc.Encode(r)
+		if !proto.Equal(r.Events[0].Event, original.Events[0].Event) &&
+			r.Events[0].DictionaryReferences == nil {
+			t.Fatalf("Event %d modified without references", i)
+		}
+		b, err := proto.Marshal(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		encodedSize += len(b)
+
+		decoded := &api.GetEventsResponse{}
+		if err = proto.Unmarshal(b, decoded); err != nil {
+			t.Fatal(err)
+		}
+		if err = dec.Decode(decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(decoded, original) {
+			t.Fatalf("Event %d: expected %+v, got %+v",
+				i, original, decoded)
+		}
+	}
+
+	if encodedSize >= plainSize/2 {
+		t.Errorf("Encoded size %d not substantially smaller than %d",
+			encodedSize, plainSize)
+	}
+}
+
+func TestEncodeDoesNotModifyEvent(t *testing.T) {
+	r := newTestResponse(0)
+	e := r.Events[0].Event
+	original := proto.Clone(e)
+
+	NewEncoder(DefaultMaxEntries).Encode(r)
+	if r.Events[0].Event == e {
+		t.Error("Encoded event was not copied")
+	}
+	if !proto.Equal(e, original) {
+		t.Errorf("Original event modified: %+v", e)
+	}
+}
+
+func TestEncodeMaxEntries(t *testing.T) {
+	enc := NewEncoder(2)
+	r := newTestResponse(0)
+	enc.Encode(r)
+
+	if len(r.Dictionary) != 2 {
+		t.Fatalf("Expected 2 dictionary entries, got %d", len(r.Dictionary))
+	}
+	e := r.Events[0].Event
+	if e.ProcessId != "" || e.ContainerId != "" {
+		t.Errorf("Expected process and container IDs to be encoded")
+	}
+	if e.SensorId == "" || e.ImageName == "" {
+		t.Errorf("Expected values beyond the limit to be sent in full")
+	}
+}
+
+func TestDecodeUndefinedIndex(t *testing.T) {
+	r := &api.GetEventsResponse{
+		Events: []*api.ReceivedTelemetryEvent{
+			&api.ReceivedTelemetryEvent{
+				Event: &api.TelemetryEvent{},
+				DictionaryReferences: &api.DictionaryReferences{
+					ProcessId: 7,
+				},
+			},
+		},
+	}
+	if err := NewDecoder().Decode(r); err == nil {
+		t.Error("Expected error for undefined dictionary index")
+	}
+}
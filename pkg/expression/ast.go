@@ -135,12 +135,27 @@ func (e binaryExpr) String() string {
 		panic("internal error: invalid binaryExpr")
 	}
 
+	x := e.x.String()
+	if e.needsLeftParens() {
+		x = "(" + x + ")"
+	}
 	if y, ok := e.y.(binaryExpr); ok {
 		if y.op == binaryOpLogicalAnd || y.op == binaryOpLogicalOr {
-			return fmt.Sprintf("%s %s (%s)", e.x, binaryOpStrings[e.op], e.y)
+			return fmt.Sprintf("%s %s (%s)", x, binaryOpStrings[e.op], e.y)
 		}
 	}
-	return fmt.Sprintf("%s %s %s", e.x, binaryOpStrings[e.op], e.y)
+	return fmt.Sprintf("%s %s %s", x, binaryOpStrings[e.op], e.y)
+}
+
+// needsLeftParens returns true if the left operand of a binary expression
+// must be parenthesized to preserve the expression's meaning, because it is
+// a logical OR and the expression is a logical AND.
+func (e binaryExpr) needsLeftParens() bool {
+	if e.op != binaryOpLogicalAnd {
+		return false
+	}
+	x, ok := e.x.(binaryExpr)
+	return ok && x.op == binaryOpLogicalOr
 }
 
 func (e binaryExpr) KernelString() string {
@@ -156,13 +171,17 @@ func (e binaryExpr) KernelString() string {
 		}
 	}
 
+	x := e.x.KernelString()
+	if e.needsLeftParens() {
+		x = "(" + x + ")"
+	}
 	if y, ok := e.y.(binaryExpr); ok {
 		if y.op == binaryOpLogicalAnd || y.op == binaryOpLogicalOr {
-			return fmt.Sprintf("%s %s (%s)", e.x.KernelString(),
+			return fmt.Sprintf("%s %s (%s)", x,
 				binaryOpKernelStrings[e.op], e.y.KernelString())
 		}
 	}
-	return fmt.Sprintf("%s %s %s", e.x.KernelString(),
+	return fmt.Sprintf("%s %s %s", x,
 		binaryOpKernelStrings[e.op], e.y.KernelString())
 }
 
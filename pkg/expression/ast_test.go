@@ -399,3 +399,31 @@ func TestUnaryExprString(t *testing.T) {
 		}
 	}
 }
+
+func TestBinaryExprStringPrecedence(t *testing.T) {
+	eq := func(name string) binaryExpr {
+		return binaryExpr{
+			op: binaryOpEQ,
+			x:  identExpr{name: name},
+			y:  valueExpr{v: int32(8)},
+		}
+	}
+	be := binaryExpr{
+		op: binaryOpLogicalAnd,
+		x: binaryExpr{
+			op: binaryOpLogicalOr,
+			x:  eq("foo"),
+			y:  eq("bar"),
+		},
+		y: eq("baz"),
+	}
+
+	expect := "(foo = 8 OR bar = 8) AND baz = 8"
+	if s := be.String(); s != expect {
+		t.Errorf("binaryExpr.String failure (want %q; got %q)", expect, s)
+	}
+	expect = "(foo == 8 || bar == 8) && baz == 8"
+	if s := be.KernelString(); s != expect {
+		t.Errorf("binaryExpr.KernelString failure (want %q; got %q)", expect, s)
+	}
+}
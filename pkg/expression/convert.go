@@ -147,7 +147,7 @@ func convertNode(node *api.Expression, logical bool) (r expr) {
 		if ident == "" {
 			exprRaise(errors.New("Identifier missing for IDENTIFIER node"))
 		}
-		if err := validateIdentifier(ident); err != nil {
+		if err := validateQualifiedIdentifier(ident); err != nil {
 			exprRaise(err)
 		}
 		r = identExpr{name: ident}
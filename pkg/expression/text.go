@@ -0,0 +1,579 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+// This file implements a canonical textual form for expression trees. Format
+// produces it and Parse reads it back, such that for any tree that Format
+// accepts, Parse(Format(tree)) produces an identical tree. The form is:
+//
+//	expr    = expr "OR" expr | expr "AND" expr | expr cmp expr |
+//	          expr "&" expr | expr "IS" ["NOT"] "NULL" | primary
+//	cmp     = "=" | "!=" | "<" | "<=" | ">" | ">=" | "LIKE"
+//	primary = identifier | value | "(" expr ")"
+//	value   = string | integer | "TRUE" | "FALSE" |
+//	          type "(" number ")" | "TIMESTAMP(" integer "," integer ")"
+//
+// Operators are listed from lowest to highest precedence, and all binary
+// operators are left associative. Parentheses are only emitted where needed
+// to preserve the shape of the tree. Identifiers may be qualified with dots,
+// as in env.LD_PRELOAD. Strings are quoted as Go string literals.
+// Bare integers are int64 values; all other numeric types use a cast-like
+// syntax such as uint32(8) or float64(1.5) so that value types survive the
+// round trip.
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+const (
+	textPrecOr = iota + 1
+	textPrecAnd
+	textPrecCompare
+	textPrecBitwiseAnd
+	textPrecUnary
+	textPrecPrimary
+)
+
+var textBinaryOps = map[api.Expression_ExpressionType]struct {
+	text string
+	prec int
+}{
+	api.Expression_LOGICAL_OR:  {"OR", textPrecOr},
+	api.Expression_LOGICAL_AND: {"AND", textPrecAnd},
+	api.Expression_EQ:          {"=", textPrecCompare},
+	api.Expression_NE:          {"!=", textPrecCompare},
+	api.Expression_LT:          {"<", textPrecCompare},
+	api.Expression_LE:          {"<=", textPrecCompare},
+	api.Expression_GT:          {">", textPrecCompare},
+	api.Expression_GE:          {">=", textPrecCompare},
+	api.Expression_LIKE:        {"LIKE", textPrecCompare},
+	api.Expression_BITWISE_AND: {"&", textPrecBitwiseAnd},
+}
+
+// Value types that are written as casts, along with the number of bits used
+// to range check them.
+var textValueCasts = map[api.ValueType]struct {
+	name string
+	bits int
+}{
+	api.ValueType_SINT8:  {"int8", 8},
+	api.ValueType_SINT16: {"int16", 16},
+	api.ValueType_SINT32: {"int32", 32},
+	api.ValueType_SINT64: {"int64", 64},
+	api.ValueType_UINT8:  {"uint8", 8},
+	api.ValueType_UINT16: {"uint16", 16},
+	api.ValueType_UINT32: {"uint32", 32},
+	api.ValueType_UINT64: {"uint64", 64},
+	api.ValueType_DOUBLE: {"float64", 64},
+}
+
+var textKeywords = map[string]bool{
+	"AND":       true,
+	"OR":        true,
+	"LIKE":      true,
+	"IS":        true,
+	"NOT":       true,
+	"NULL":      true,
+	"TRUE":      true,
+	"FALSE":     true,
+	"TIMESTAMP": true,
+}
+
+// Format returns the canonical textual form of an expression tree. An error
+// is returned if the tree is not well-formed or if it contains identifiers
+// that cannot be represented, such as reserved words.
+func Format(tree *api.Expression) (s string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(exprError); ok {
+				err = e
+			} else {
+				panic(r)
+			}
+		}
+	}()
+
+	var b bytes.Buffer
+	formatNode(&b, tree)
+	s = b.String()
+	return
+}
+
+func textPrecedence(node *api.Expression) int {
+	switch node.GetType() {
+	case api.Expression_IS_NULL, api.Expression_IS_NOT_NULL:
+		return textPrecUnary
+	case api.Expression_IDENTIFIER, api.Expression_VALUE:
+		return textPrecPrimary
+	}
+	return textBinaryOps[node.GetType()].prec
+}
+
+func formatOperand(b *bytes.Buffer, node *api.Expression, parens bool) {
+	if parens {
+		b.WriteByte('(')
+		formatNode(b, node)
+		b.WriteByte(')')
+	} else {
+		formatNode(b, node)
+	}
+}
+
+func formatNode(b *bytes.Buffer, node *api.Expression) {
+	switch t := node.GetType(); t {
+	case api.Expression_IDENTIFIER:
+		ident := node.GetIdentifier()
+		if err := validateQualifiedIdentifier(ident); err != nil {
+			exprRaise(err)
+		}
+		if textKeywords[ident] {
+			exprRaise(fmt.Errorf("Identifier %q is a reserved word", ident))
+		}
+		b.WriteString(ident)
+	case api.Expression_VALUE:
+		value := node.GetValue()
+		if value == nil {
+			exprRaise(errors.New("Value missing for VALUE node"))
+		}
+		formatValue(b, value)
+	case api.Expression_IS_NULL, api.Expression_IS_NOT_NULL:
+		operand := node.GetUnaryOp()
+		if operand == nil {
+			exprRaise(errors.New("UnaryOp missing for unary compare"))
+		}
+		formatOperand(b, operand, textPrecedence(operand) < textPrecUnary)
+		if t == api.Expression_IS_NULL {
+			b.WriteString(" IS NULL")
+		} else {
+			b.WriteString(" IS NOT NULL")
+		}
+	default:
+		op, ok := textBinaryOps[t]
+		if !ok {
+			exprRaise(fmt.Errorf("Unrecognized expression type %d", t))
+		}
+		operands := node.GetBinaryOp()
+		if operands == nil {
+			exprRaise(errors.New("BinaryOp missing for binary operation node"))
+		}
+		if operands.Lhs == nil {
+			exprRaise(errors.New("BinaryOp missing lhs"))
+		}
+		if operands.Rhs == nil {
+			exprRaise(errors.New("BinaryOp missing rhs"))
+		}
+		formatOperand(b, operands.Lhs,
+			textPrecedence(operands.Lhs) < op.prec)
+		b.WriteByte(' ')
+		b.WriteString(op.text)
+		b.WriteByte(' ')
+		formatOperand(b, operands.Rhs,
+			textPrecedence(operands.Rhs) <= op.prec)
+	}
+}
+
+func formatValue(b *bytes.Buffer, value *api.Value) {
+	switch t := value.GetType(); t {
+	case api.ValueType_STRING:
+		v, ok := value.GetValue().(*api.Value_StringValue)
+		if !ok {
+			exprRaise(errors.New("STRING value has no StringValue set"))
+		}
+		b.WriteString(strconv.Quote(v.StringValue))
+	case api.ValueType_SINT8, api.ValueType_SINT16, api.ValueType_SINT32,
+		api.ValueType_SINT64:
+		v, ok := value.GetValue().(*api.Value_SignedValue)
+		if !ok {
+			exprRaise(fmt.Errorf("%s value has no SignedValue set", t))
+		}
+		cast := textValueCasts[t]
+		shift := uint(64 - cast.bits)
+		if v.SignedValue<<shift>>shift != v.SignedValue {
+			exprRaise(fmt.Errorf("%s value %d is out of range",
+				t, v.SignedValue))
+		}
+		if t == api.ValueType_SINT64 {
+			b.WriteString(strconv.FormatInt(v.SignedValue, 10))
+		} else {
+			fmt.Fprintf(b, "%s(%d)", cast.name, v.SignedValue)
+		}
+	case api.ValueType_UINT8, api.ValueType_UINT16, api.ValueType_UINT32,
+		api.ValueType_UINT64:
+		v, ok := value.GetValue().(*api.Value_UnsignedValue)
+		if !ok {
+			exprRaise(fmt.Errorf("%s value has no UnsignedValue set", t))
+		}
+		cast := textValueCasts[t]
+		shift := uint(64 - cast.bits)
+		if v.UnsignedValue<<shift>>shift != v.UnsignedValue {
+			exprRaise(fmt.Errorf("%s value %d is out of range",
+				t, v.UnsignedValue))
+		}
+		fmt.Fprintf(b, "%s(%d)", cast.name, v.UnsignedValue)
+	case api.ValueType_BOOL:
+		v, ok := value.GetValue().(*api.Value_BoolValue)
+		if !ok {
+			exprRaise(errors.New("BOOL value has no BoolValue set"))
+		}
+		if v.BoolValue {
+			b.WriteString("TRUE")
+		} else {
+			b.WriteString("FALSE")
+		}
+	case api.ValueType_DOUBLE:
+		v, ok := value.GetValue().(*api.Value_DoubleValue)
+		if !ok {
+			exprRaise(errors.New("DOUBLE value has no DoubleValue set"))
+		}
+		fmt.Fprintf(b, "float64(%s)",
+			strconv.FormatFloat(v.DoubleValue, 'g', -1, 64))
+	case api.ValueType_TIMESTAMP:
+		v, ok := value.GetValue().(*api.Value_TimestampValue)
+		if !ok || v.TimestampValue == nil {
+			exprRaise(errors.New("TIMESTAMP value has no TimestampValue set"))
+		}
+		fmt.Fprintf(b, "TIMESTAMP(%d, %d)", v.TimestampValue.Seconds,
+			v.TimestampValue.Nanos)
+	default:
+		exprRaise(fmt.Errorf("Unrecognized value type %d", t))
+	}
+}
+
+//////////////////////////////////////////////////////////////////////////////
+
+type textTokenKind int
+
+const (
+	textTokenEOF textTokenKind = iota
+	textTokenWord
+	textTokenNumber
+	textTokenString
+	textTokenPunct
+)
+
+type textToken struct {
+	kind textTokenKind
+	text string
+	pos  int
+}
+
+type textParser struct {
+	s      string
+	pos    int
+	tok    textToken
+	peeked bool
+}
+
+// Parse parses the canonical textual form of an expression produced by Format
+// and returns the expression tree that it represents.
+func Parse(s string) (tree *api.Expression, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(exprError); ok {
+				err = e
+			} else {
+				panic(r)
+			}
+		}
+	}()
+
+	p := textParser{s: s}
+	tree = p.parseBinary(textPrecOr)
+	if tok := p.next(); tok.kind != textTokenEOF {
+		p.raise(tok, "unexpected %q", tok.text)
+	}
+	return
+}
+
+func (p *textParser) raise(tok textToken, format string, args ...interface{}) {
+	exprRaise(fmt.Errorf("Parse error at offset %d: %s", tok.pos,
+		fmt.Sprintf(format, args...)))
+}
+
+func (p *textParser) peek() textToken {
+	if !p.peeked {
+		p.tok = p.scan()
+		p.peeked = true
+	}
+	return p.tok
+}
+
+func (p *textParser) next() textToken {
+	tok := p.peek()
+	p.peeked = false
+	return tok
+}
+
+// expect consumes the next token, which must be of the specified kind. If
+// text is not empty, the token must also match it.
+func (p *textParser) expect(kind textTokenKind, text string) textToken {
+	tok := p.next()
+	if tok.kind != kind || (text != "" && tok.text != text) {
+		want := strconv.Quote(text)
+		if text == "" {
+			want = "number"
+		}
+		if tok.kind == textTokenEOF {
+			p.raise(tok, "expected %s, got end of input", want)
+		}
+		p.raise(tok, "expected %s, got %q", want, tok.text)
+	}
+	return tok
+}
+
+func isTextWordRune(r rune, first bool) bool {
+	if unicode.IsLetter(r) || r == '_' {
+		return true
+	}
+	return !first && (unicode.IsDigit(r) || r == '.')
+}
+
+func (p *textParser) scan() textToken {
+	for p.pos < len(p.s) && unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+
+	start := p.pos
+	if start >= len(p.s) {
+		return textToken{kind: textTokenEOF, pos: start}
+	}
+
+	c := p.s[start]
+	switch {
+	case c == '"':
+		for p.pos++; p.pos < len(p.s); p.pos++ {
+			if p.s[p.pos] == '\\' {
+				p.pos++
+			} else if p.s[p.pos] == '"' {
+				p.pos++
+				return textToken{
+					kind: textTokenString,
+					text: p.s[start:p.pos],
+					pos:  start,
+				}
+			}
+		}
+		p.raise(textToken{pos: start}, "unterminated string")
+	case c == '-' || c == '+' || (c >= '0' && c <= '9'):
+		// Numbers include anything strconv can parse as an integer or
+		// a float, including exponents and Inf/NaN.
+		for p.pos++; p.pos < len(p.s); p.pos++ {
+			c = p.s[p.pos]
+			if c == '.' || c == '_' ||
+				(c >= '0' && c <= '9') ||
+				(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+				continue
+			}
+			if (c == '-' || c == '+') &&
+				(p.s[p.pos-1] == 'e' || p.s[p.pos-1] == 'E') {
+				continue
+			}
+			break
+		}
+		return textToken{
+			kind: textTokenNumber,
+			text: p.s[start:p.pos],
+			pos:  start,
+		}
+	}
+
+	for _, op := range []string{"!=", "<=", ">=", "=", "<", ">", "&", "(", ")", ","} {
+		if strings.HasPrefix(p.s[start:], op) {
+			p.pos += len(op)
+			return textToken{kind: textTokenPunct, text: op, pos: start}
+		}
+	}
+
+	for i, r := range p.s[start:] {
+		if !isTextWordRune(r, i == 0) {
+			break
+		}
+		p.pos = start + i + len(string(r))
+	}
+	if p.pos == start {
+		p.raise(textToken{pos: start}, "unexpected character %q",
+			p.s[start])
+	}
+	return textToken{kind: textTokenWord, text: p.s[start:p.pos], pos: start}
+}
+
+func (p *textParser) peekBinaryOp() (api.Expression_ExpressionType, int) {
+	tok := p.peek()
+	if tok.kind != textTokenWord && tok.kind != textTokenPunct {
+		return api.Expression_EXPRESSIONTYPE_UNSPECIFIED, 0
+	}
+	for t, op := range textBinaryOps {
+		if op.text == tok.text {
+			return t, op.prec
+		}
+	}
+	return api.Expression_EXPRESSIONTYPE_UNSPECIFIED, 0
+}
+
+func (p *textParser) parseBinary(minPrec int) *api.Expression {
+	lhs := p.parseUnary()
+	for {
+		t, prec := p.peekBinaryOp()
+		if prec == 0 || prec < minPrec {
+			return lhs
+		}
+		p.next()
+		rhs := p.parseBinary(prec + 1)
+		lhs = newBinaryExpr(t, lhs, rhs)
+	}
+}
+
+func (p *textParser) parseUnary() *api.Expression {
+	operand := p.parsePrimary()
+	for {
+		if tok := p.peek(); tok.kind != textTokenWord || tok.text != "IS" {
+			return operand
+		}
+		p.next()
+		if tok := p.peek(); tok.kind == textTokenWord && tok.text == "NOT" {
+			p.next()
+			p.expect(textTokenWord, "NULL")
+			operand = IsNotNull(operand)
+		} else {
+			p.expect(textTokenWord, "NULL")
+			operand = IsNull(operand)
+		}
+	}
+}
+
+func (p *textParser) parsePrimary() *api.Expression {
+	tok := p.next()
+	switch tok.kind {
+	case textTokenEOF:
+		p.raise(tok, "unexpected end of input")
+	case textTokenString:
+		s, err := strconv.Unquote(tok.text)
+		if err != nil {
+			p.raise(tok, "invalid string %s", tok.text)
+		}
+		return Value(s)
+	case textTokenNumber:
+		v, err := strconv.ParseInt(tok.text, 10, 64)
+		if err != nil {
+			p.raise(tok, "invalid int64 value %s", tok.text)
+		}
+		return Value(v)
+	case textTokenPunct:
+		if tok.text == "(" {
+			e := p.parseBinary(textPrecOr)
+			p.expect(textTokenPunct, ")")
+			return e
+		}
+	case textTokenWord:
+		switch tok.text {
+		case "TRUE":
+			return Value(true)
+		case "FALSE":
+			return Value(false)
+		case "TIMESTAMP":
+			return p.parseTimestamp()
+		}
+		if textKeywords[tok.text] {
+			break
+		}
+		if next := p.peek(); next.kind == textTokenPunct && next.text == "(" {
+			return p.parseCast(tok)
+		}
+		return Identifier(tok.text)
+	}
+	p.raise(tok, "unexpected %q", tok.text)
+	return nil
+}
+
+func (p *textParser) parseCast(typeTok textToken) *api.Expression {
+	var (
+		t    api.ValueType
+		bits int
+	)
+	for vt, cast := range textValueCasts {
+		if cast.name == typeTok.text {
+			t, bits = vt, cast.bits
+			break
+		}
+	}
+	if bits == 0 {
+		p.raise(typeTok, "unknown value type %q", typeTok.text)
+	}
+
+	p.expect(textTokenPunct, "(")
+	tok := p.next()
+	if tok.kind != textTokenNumber && tok.kind != textTokenWord {
+		p.raise(tok, "expected number, got %q", tok.text)
+	}
+	p.expect(textTokenPunct, ")")
+
+	value := &api.Value{Type: t}
+	switch t {
+	case api.ValueType_DOUBLE:
+		v, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			p.raise(tok, "invalid float64 value %s", tok.text)
+		}
+		value.Value = &api.Value_DoubleValue{DoubleValue: v}
+	case api.ValueType_UINT8, api.ValueType_UINT16, api.ValueType_UINT32,
+		api.ValueType_UINT64:
+		v, err := strconv.ParseUint(tok.text, 10, bits)
+		if err != nil {
+			p.raise(tok, "invalid %s value %s", typeTok.text, tok.text)
+		}
+		value.Value = &api.Value_UnsignedValue{UnsignedValue: v}
+	default:
+		v, err := strconv.ParseInt(tok.text, 10, bits)
+		if err != nil {
+			p.raise(tok, "invalid %s value %s", typeTok.text, tok.text)
+		}
+		value.Value = &api.Value_SignedValue{SignedValue: v}
+	}
+
+	return &api.Expression{
+		Type: api.Expression_VALUE,
+		Expr: &api.Expression_Value{Value: value},
+	}
+}
+
+func (p *textParser) parseTimestamp() *api.Expression {
+	p.expect(textTokenPunct, "(")
+	secondsTok := p.expect(textTokenNumber, "")
+	seconds, err := strconv.ParseInt(secondsTok.text, 10, 64)
+	if err != nil {
+		p.raise(secondsTok, "invalid seconds %s", secondsTok.text)
+	}
+	p.expect(textTokenPunct, ",")
+	nanosTok := p.expect(textTokenNumber, "")
+	nanos, err := strconv.ParseInt(nanosTok.text, 10, 32)
+	if err != nil {
+		p.raise(nanosTok, "invalid nanoseconds %s", nanosTok.text)
+	}
+	p.expect(textTokenPunct, ")")
+
+	return Value(&timestamp.Timestamp{
+		Seconds: seconds,
+		Nanos:   int32(nanos),
+	})
+}
@@ -0,0 +1,235 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+func TestFormat(t *testing.T) {
+	type testCase struct {
+		tree     *api.Expression
+		expected string
+	}
+	testCases := []testCase{
+		{
+			LogicalAnd(
+				Equal(Identifier("id"), Value(int64(59))),
+				LogicalOr(
+					Like(Identifier("path"), Value("/etc/*")),
+					NotEqual(Identifier("uid"), Value(uint32(0))))),
+			`id = 59 AND (path LIKE "/etc/*" OR uid != uint32(0))`,
+		},
+		{
+			LogicalOr(
+				LogicalOr(IsNull(Identifier("a")), Value(true)),
+				IsNotNull(Equal(Identifier("b"), Value(int8(-1))))),
+			"a IS NULL OR TRUE OR (b = int8(-1)) IS NOT NULL",
+		},
+		{
+			NotEqual(
+				BitwiseAnd(Identifier("flags"), Value(uint64(4))),
+				Value(uint64(0))),
+			"flags & uint64(4) != uint64(0)",
+		},
+		{
+			LogicalAnd(
+				IsNotNull(Identifier("env.LD_PRELOAD")),
+				NotEqual(Identifier("env.HOME"), Value("/root"))),
+			`env.LD_PRELOAD IS NOT NULL AND env.HOME != "/root"`,
+		},
+		{
+			GreaterThan(Identifier("t"), Value(&timestamp.Timestamp{
+				Seconds: 1500000000,
+				Nanos:   12,
+			})),
+			"t > TIMESTAMP(1500000000, 12)",
+		},
+		{
+			LessThan(Identifier("f"), Value(float64(1.5))),
+			"f < float64(1.5)",
+		},
+	}
+	for _, tc := range testCases {
+		s, err := Format(tc.tree)
+		if err != nil {
+			t.Errorf("Unexpected error formatting %q: %v", tc.expected, err)
+			continue
+		}
+		if s != tc.expected {
+			t.Errorf("Expected %q, got %q", tc.expected, s)
+		}
+	}
+
+	failCases := []*api.Expression{
+		nil,
+		Identifier("AND"),
+		Equal(Identifier("x"), nil),
+		&api.Expression{
+			Type: api.Expression_VALUE,
+			Expr: &api.Expression_Value{
+				Value: &api.Value{
+					Type:  api.ValueType_UINT8,
+					Value: &api.Value_UnsignedValue{UnsignedValue: 256},
+				},
+			},
+		},
+	}
+	for _, tc := range failCases {
+		if s, err := Format(tc); err == nil {
+			t.Errorf("Expected error formatting %v; got %q", tc, s)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tree, err := Parse(`(a = 1 OR b<=uint16(2))AND c IS NOT NULL`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := LogicalAnd(
+		LogicalOr(
+			Equal(Identifier("a"), Value(int64(1))),
+			LessThanEqualTo(Identifier("b"), Value(uint16(2)))),
+		IsNotNull(Identifier("c")))
+	if !proto.Equal(tree, expected) {
+		t.Errorf("Expected %v, got %v", expected, tree)
+	}
+
+	failCases := []string{
+		"",
+		"a =",
+		"(a = 1",
+		"a = 1)",
+		`a = "foo`,
+		"a = int8(128)",
+		"a = uint32(-1)",
+		"a = int128(1)",
+		"a IS FALSE",
+		"a = TIMESTAMP(1)",
+		"a = 1 AND",
+		"a ! 1",
+	}
+	for _, s := range failCases {
+		if tree, err := Parse(s); err == nil {
+			t.Errorf("Expected error parsing %q; got %v", s, tree)
+		}
+	}
+}
+
+func randomTextValue(r *rand.Rand) *api.Expression {
+	switch r.Intn(12) {
+	case 0:
+		const chars = "abc \"\\\t/*é"
+		runes := []rune(chars)
+		s := make([]rune, r.Intn(8))
+		for i := range s {
+			s[i] = runes[r.Intn(len(runes))]
+		}
+		return Value(string(s))
+	case 1:
+		return Value(int8(r.Uint32()))
+	case 2:
+		return Value(int16(r.Uint32()))
+	case 3:
+		return Value(int32(r.Uint32()))
+	case 4:
+		return Value(int64(r.Uint64()))
+	case 5:
+		return Value(uint8(r.Uint32()))
+	case 6:
+		return Value(uint16(r.Uint32()))
+	case 7:
+		return Value(r.Uint32())
+	case 8:
+		return Value(r.Uint64())
+	case 9:
+		return Value(r.Intn(2) == 0)
+	case 10:
+		switch r.Intn(4) {
+		case 0:
+			return Value(math.Inf(1 - 2*r.Intn(2)))
+		case 1:
+			return Value(math.Float64frombits(r.Uint64() &^ (0x7ff << 52)))
+		}
+		return Value(r.NormFloat64() * 1e6)
+	}
+	return Value(&timestamp.Timestamp{
+		Seconds: r.Int63(),
+		Nanos:   r.Int31n(1000000000),
+	})
+}
+
+func randomTextExpression(r *rand.Rand, depth int) *api.Expression {
+	if depth == 0 || r.Intn(4) == 0 {
+		if r.Intn(2) == 0 {
+			names := []string{"a", "b_1", "_c", "int8", "path"}
+			return Identifier(names[r.Intn(len(names))])
+		}
+		return randomTextValue(r)
+	}
+
+	switch r.Intn(6) {
+	case 0:
+		return IsNull(randomTextExpression(r, depth-1))
+	case 1:
+		return IsNotNull(randomTextExpression(r, depth-1))
+	}
+
+	ops := []api.Expression_ExpressionType{
+		api.Expression_LOGICAL_AND,
+		api.Expression_LOGICAL_OR,
+		api.Expression_EQ,
+		api.Expression_NE,
+		api.Expression_LT,
+		api.Expression_LE,
+		api.Expression_GT,
+		api.Expression_GE,
+		api.Expression_LIKE,
+		api.Expression_BITWISE_AND,
+	}
+	return newBinaryExpr(ops[r.Intn(len(ops))],
+		randomTextExpression(r, depth-1),
+		randomTextExpression(r, depth-1))
+}
+
+func TestFormatParseRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+	for i := 0; i < 5000; i++ {
+		tree := randomTextExpression(r, 5)
+		s, err := Format(tree)
+		if err != nil {
+			t.Fatalf("Unexpected error formatting %v: %v", tree, err)
+		}
+		parsed, err := Parse(s)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %q: %v", s, err)
+		}
+		if !proto.Equal(parsed, tree) {
+			t.Fatalf("Round trip of %q failed:\nexpected %v\ngot %v",
+				s, tree, parsed)
+		}
+		if s2, _ := Format(parsed); s2 != s {
+			t.Fatalf("Format is not canonical: %q != %q", s, s2)
+		}
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"unicode"
 )
 
@@ -41,6 +42,18 @@ func validateIdentifier(ident string) error {
 	return nil
 }
 
+// validateQualifiedIdentifier validates an identifier that may be qualified
+// by a namespace, e.g. env.LD_PRELOAD. Each dot separated component must be a
+// valid identifier. Qualified identifiers cannot be used in kernel filters.
+func validateQualifiedIdentifier(ident string) error {
+	for _, component := range strings.Split(ident, ".") {
+		if err := validateIdentifier(component); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func validateBitwiseAnd(e binaryExpr) {
 	// lhs must be identifier; rhs must be an integer value
 	if _, ok := e.x.(identExpr); !ok {
@@ -54,6 +54,22 @@ func TestValidateIdentifier(t *testing.T) {
 	testValidIdentifier(t, "_83_")
 }
 
+func TestValidateQualifiedIdentifier(t *testing.T) {
+	for _, s := range []string{"env.", ".env", "env..x", "env.1x"} {
+		if validateQualifiedIdentifier(s) == nil {
+			t.Errorf("Invalid identifier %q accepted", s)
+		}
+	}
+	for _, s := range []string{"x", "env.LD_PRELOAD", "a.b.c"} {
+		if err := validateQualifiedIdentifier(s); err != nil {
+			t.Errorf("Valid identifier %q; got %s", s, err)
+		}
+	}
+
+	// Qualified identifiers are not valid in kernel filters
+	testInvalidIdentifier(t, "env.LD_PRELOAD")
+}
+
 func TestValidateKernelFilter(t *testing.T) {
 	// identExpr tests
 	ie := identExpr{name: "foo"}
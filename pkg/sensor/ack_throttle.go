@@ -0,0 +1,165 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+// The weight given to each new latency measurement in the moving average
+const ackLatencyWeight = 0.2
+
+// ackThrottle throttles a subscription's events according to how quickly the
+// client acknowledges them. The latency of each event's delivery is measured
+// as the time that the stream takes to accept it, which grows when the client
+// stops acknowledging data and its flow control window is exhausted. While
+// the average latency is high, events are admitted only at the rate that the
+// client has been accepting them, so that they are not buffered without
+// bound.
+type ackThrottle struct {
+	// Events not admitted while throttled. Updated atomically. It is the
+	// first field so that it is 64-bit aligned.
+	throttledEvents uint64
+
+	sync.Mutex
+
+	high, low time.Duration
+
+	latency   time.Duration
+	throttled bool
+
+	// Token bucket admitting events while throttled
+	tokens float64
+	last   time.Time
+}
+
+func newAckThrottle(high, low time.Duration) *ackThrottle {
+	return &ackThrottle{
+		high: high,
+		low:  low,
+	}
+}
+
+// observe records the delivery latency of an event.
+func (t *ackThrottle) observe(latency time.Duration) {
+	t.Lock()
+	if t.latency == 0 {
+		t.latency = latency
+	} else {
+		t.latency += time.Duration(ackLatencyWeight *
+			float64(latency-t.latency))
+	}
+	if !t.throttled && t.latency > t.high {
+		t.throttled = true
+		t.tokens = 1
+		t.last = time.Time{}
+	} else if t.throttled && t.latency < t.low {
+		t.throttled = false
+	}
+	t.Unlock()
+}
+
+// admit returns whether an event dispatched at the specified time should be
+// delivered.
+func (t *ackThrottle) admit(now time.Time) bool {
+	t.Lock()
+	if !t.throttled {
+		t.Unlock()
+		return true
+	}
+
+	if !t.last.IsZero() && t.latency > 0 {
+		rate := float64(time.Second) / float64(t.latency)
+		t.tokens += rate * now.Sub(t.last).Seconds()
+		if t.tokens > 1 {
+			t.tokens = 1
+		}
+	}
+	t.last = now
+	ok := t.tokens >= 1
+	if ok {
+		t.tokens--
+	}
+	t.Unlock()
+
+	if !ok {
+		atomic.AddUint64(&t.throttledEvents, 1)
+	}
+	return ok
+}
+
+// statistics returns the current state of the throttle.
+func (t *ackThrottle) statistics(subscriptionID int32) *api.AckThrottleStatistics {
+	t.Lock()
+	defer t.Unlock()
+	return &api.AckThrottleStatistics{
+		SubscriptionId:  subscriptionID,
+		AckLatencyNanos: int64(t.latency),
+		Throttled:       t.throttled,
+		ThrottledEvents: atomic.LoadUint64(&t.throttledEvents),
+	}
+}
+
+func newSubscriptionAckThrottle(
+	at *api.AckThrottle,
+	dispatchFn eventSinkDispatchFn,
+) (*ackThrottle, eventSinkDispatchFn, error) {
+	if at.HighLatencyMillis <= 0 {
+		return nil, nil, errors.New("Ack throttling requires high_latency_millis")
+	}
+	high := time.Duration(at.HighLatencyMillis) * time.Millisecond
+	low := high / 2
+	if at.LowLatencyMillis != 0 {
+		if at.LowLatencyMillis < 0 || at.LowLatencyMillis > at.HighLatencyMillis {
+			return nil, nil, errors.New("Ack throttling low_latency_millis must be between 0 and high_latency_millis")
+		}
+		low = time.Duration(at.LowLatencyMillis) * time.Millisecond
+	}
+
+	t := newAckThrottle(high, low)
+	return t, func(e *api.TelemetryEvent) {
+		if t.admit(time.Now()) {
+			dispatchFn(e)
+		}
+	}, nil
+}
+
+// AckThrottleStatistics returns the state of ack throttling for each active
+// subscription that requested it.
+func (s *Sensor) AckThrottleStatistics() []*api.AckThrottleStatistics {
+	var stats []*api.AckThrottleStatistics
+	seen := make(map[*subscription]bool)
+	for _, eventSinks := range s.eventMap.getMap() {
+		for _, es := range eventSinks {
+			subscr := es.subscription
+			if subscr.ackThrottle == nil || seen[subscr] {
+				continue
+			}
+			seen[subscr] = true
+			stats = append(stats,
+				subscr.ackThrottle.statistics(subscr.eventGroupID))
+		}
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].SubscriptionId < stats[j].SubscriptionId
+	})
+	return stats
+}
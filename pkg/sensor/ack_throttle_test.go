@@ -0,0 +1,86 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+func TestAckThrottle(t *testing.T) {
+	if _, _, err := newSubscriptionAckThrottle(&api.AckThrottle{}, nil); err == nil {
+		t.Error("Expected error for missing high_latency_millis")
+	}
+	if _, _, err := newSubscriptionAckThrottle(&api.AckThrottle{
+		HighLatencyMillis: 10,
+		LowLatencyMillis:  20,
+	}, nil); err == nil {
+		t.Error("Expected error for low_latency_millis above high")
+	}
+
+	var delivered int
+	throttle, dispatchFn, err := newSubscriptionAckThrottle(
+		&api.AckThrottle{HighLatencyMillis: 100},
+		func(e *api.TelemetryEvent) { delivered++ })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if throttle.low != 50*time.Millisecond {
+		t.Errorf("Expected default low threshold of 50ms, got %s",
+			throttle.low)
+	}
+
+	// Fast acknowledgements do not throttle
+	throttle.observe(time.Millisecond)
+	for i := 0; i < 10; i++ {
+		dispatchFn(&api.TelemetryEvent{})
+	}
+	if delivered != 10 {
+		t.Errorf("Expected 10 events delivered, got %d", delivered)
+	}
+
+	// Slow acknowledgements limit events to the client's rate of 5/s
+	throttle.observe(time.Second)
+	throttle.observe(200 * time.Millisecond)
+	stats := throttle.statistics(7)
+	if !stats.Throttled || stats.SubscriptionId != 7 {
+		t.Fatalf("Expected subscription 7 throttled, got %+v", stats)
+	}
+	now := time.Now()
+	admitted := 0
+	for i := 0; i < 100; i++ {
+		if throttle.admit(now.Add(time.Duration(i) * 10 * time.Millisecond)) {
+			admitted++
+		}
+	}
+	if admitted < 4 || admitted > 6 {
+		t.Errorf("Expected about 5 events admitted in 1s, got %d", admitted)
+	}
+	stats = throttle.statistics(7)
+	if stats.ThrottledEvents != uint64(100-admitted) {
+		t.Errorf("Expected %d throttled events, got %d",
+			100-admitted, stats.ThrottledEvents)
+	}
+
+	// Throttling stops once latency falls below the low threshold
+	for i := 0; i < 20; i++ {
+		throttle.observe(time.Millisecond)
+	}
+	if !throttle.admit(now) || throttle.statistics(7).Throttled {
+		t.Error("Expected throttling to stop")
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"strconv"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+// The x86_64 system call numbers of perf_event_open and bpf
+const (
+	syscallPerfEventOpenID = 298
+	syscallBPFID           = 321
+)
+
+// bpfCommandNames are the names of the bpf system call's commands, from
+// include/uapi/linux/bpf.h.
+var bpfCommandNames = []string{
+	"BPF_MAP_CREATE",
+	"BPF_MAP_LOOKUP_ELEM",
+	"BPF_MAP_UPDATE_ELEM",
+	"BPF_MAP_DELETE_ELEM",
+	"BPF_MAP_GET_NEXT_KEY",
+	"BPF_PROG_LOAD",
+	"BPF_OBJ_PIN",
+	"BPF_OBJ_GET",
+	"BPF_PROG_ATTACH",
+	"BPF_PROG_DETACH",
+	"BPF_PROG_TEST_RUN",
+	"BPF_PROG_GET_NEXT_ID",
+	"BPF_MAP_GET_NEXT_ID",
+	"BPF_PROG_GET_FD_BY_ID",
+	"BPF_MAP_GET_FD_BY_ID",
+	"BPF_OBJ_GET_INFO_BY_FD",
+	"BPF_PROG_QUERY",
+	"BPF_RAW_TRACEPOINT_OPEN",
+	"BPF_BTF_LOAD",
+	"BPF_BTF_GET_FD_BY_ID",
+	"BPF_TASK_FD_QUERY",
+	"BPF_MAP_LOOKUP_AND_DELETE_ELEM",
+	"BPF_MAP_FREEZE",
+	"BPF_BTF_GET_NEXT_ID",
+	"BPF_MAP_LOOKUP_BATCH",
+	"BPF_MAP_LOOKUP_AND_DELETE_BATCH",
+	"BPF_MAP_UPDATE_BATCH",
+	"BPF_MAP_DELETE_BATCH",
+	"BPF_LINK_CREATE",
+	"BPF_LINK_UPDATE",
+	"BPF_LINK_GET_FD_BY_ID",
+	"BPF_LINK_GET_NEXT_ID",
+	"BPF_ENABLE_STATS",
+	"BPF_ITER_CREATE",
+	"BPF_LINK_DETACH",
+	"BPF_PROG_BIND_MAP",
+	"BPF_TOKEN_CREATE",
+}
+
+// bpfCommandName returns the name of a bpf command, or its number if the
+// command is unknown.
+func bpfCommandName(cmd uint64) string {
+	if cmd < uint64(len(bpfCommandNames)) {
+		return bpfCommandNames[cmd]
+	}
+	return strconv.FormatUint(cmd, 10)
+}
+
+// perfTypeNames are the names of the generic perf event types, from
+// include/uapi/linux/perf_event.h. Other types are dynamically assigned to
+// PMUs such as kprobe and uprobe, which are listed in
+// /sys/bus/event_source/devices.
+var perfTypeNames = []string{
+	"PERF_TYPE_HARDWARE",
+	"PERF_TYPE_SOFTWARE",
+	"PERF_TYPE_TRACEPOINT",
+	"PERF_TYPE_HW_CACHE",
+	"PERF_TYPE_RAW",
+	"PERF_TYPE_BREAKPOINT",
+}
+
+// perfTypeName returns the name of a perf event type, or its number if the
+// type is not one of the generic types.
+func perfTypeName(t uint32) string {
+	if t < uint32(len(perfTypeNames)) {
+		return perfTypeNames[t]
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
+// perfEventAttrFetchargs returns the fetchargs that capture the type and
+// config of the struct perf_event_attr pointed to by the first argument of
+// perf_event_open. They are captured for every system call that the kprobe
+// reports, so they should only be used if perf_event_open is one of them.
+func (l *syscallEnterLayout) perfEventAttrFetchargs() string {
+	return fmt.Sprintf("perf_type=+0(+%d(%s)):u32 perf_config=+8(+%d(%s)):u64",
+		l.args[0], l.register, l.args[0], l.register)
+}
+
+// perfEventAttrsReferenced returns whether a filter selects perf_event_open
+// system calls, and so should capture their struct perf_event_attr.
+func perfEventAttrsReferenced(filter *api.Expression) bool {
+	if syscallIDsExcludedByExpression(filter)[syscallPerfEventOpenID] {
+		return false
+	}
+	for _, id := range syscallIDsFromExpression(filter) {
+		if id == syscallPerfEventOpenID {
+			return true
+		}
+	}
+	return false
+}
+
+// setBPFCommand decodes the command of a bpf system call, if its first
+// argument was captured.
+func setBPFCommand(
+	syscall *api.SyscallEvent,
+	data perf.TraceEventSampleData,
+) {
+	if cmd, ok := data["arg0"].(uint64); ok {
+		syscall.BpfCommand = bpfCommandName(cmd)
+		data["bpf_command"] = syscall.BpfCommand
+	}
+}
+
+// setPerfEventOpen decodes the attributes, target process, and CPU of a
+// perf_event_open system call, if they were captured, and resolves the
+// target's command from the process cache. As with ptrace, the target's pid
+// is in the caller's pid namespace.
+func (f *syscallFilter) setPerfEventOpen(
+	ev *api.TelemetryEvent,
+	syscall *api.SyscallEvent,
+	data perf.TraceEventSampleData,
+) {
+	if t, ok := data["perf_type"].(uint32); ok {
+		syscall.PerfEventType = perfTypeName(t)
+		data["perf_event_type"] = syscall.PerfEventType
+	}
+	if config, ok := data["perf_config"].(uint64); ok {
+		syscall.PerfEventConfig = config
+		data["perf_event_config"] = config
+	}
+	if cpu, ok := data["arg2"].(uint64); ok {
+		syscall.PerfEventCpu = int32(cpu)
+		data["perf_event_cpu"] = syscall.PerfEventCpu
+	}
+
+	// A pid of -1 monitors all processes on a CPU, and 0 the caller.
+	pid, ok := data["arg1"].(uint64)
+	if !ok {
+		return
+	}
+	syscall.PerfEventTargetPid = int32(pid)
+	data["perf_event_target_pid"] = syscall.PerfEventTargetPid
+	if syscall.PerfEventTargetPid > 0 {
+		t := f.sensor.ProcessCache.LookupNamespaceTask(
+			int(ev.ProcessPid), int(syscall.PerfEventTargetPid))
+		if t != nil {
+			syscall.PerfEventTargetCommand = t.Command
+		}
+	}
+}
+
+// setSyscallCaller adds the command and executable of the process making a
+// system call to its event, so that the use of sensitive system calls can be
+// attributed without correlating process events.
+func (f *syscallFilter) setSyscallCaller(
+	ev *api.TelemetryEvent,
+	syscall *api.SyscallEvent,
+) {
+	t := f.sensor.ProcessCache.LookupTask(int(ev.ProcessPid))
+	if t == nil {
+		return
+	}
+	syscall.CallerCommand = t.Command
+	syscall.CallerExecutable = f.sensor.ProcessCache.LookupTaskExecutable(t)
+}
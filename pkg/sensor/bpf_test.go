@@ -0,0 +1,126 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func TestBPFCommandAndPerfType(t *testing.T) {
+	for cmd, name := range map[uint64]string{
+		0:    "BPF_MAP_CREATE",
+		5:    "BPF_PROG_LOAD",
+		17:   "BPF_RAW_TRACEPOINT_OPEN",
+		1000: "1000",
+	} {
+		if got := bpfCommandName(cmd); got != name {
+			t.Errorf("Expected %q for command %d, got %q", name, cmd, got)
+		}
+	}
+	for typ, name := range map[uint32]string{
+		2: "PERF_TYPE_TRACEPOINT",
+		5: "PERF_TYPE_BREAKPOINT",
+		8: "8",
+	} {
+		if got := perfTypeName(typ); got != name {
+			t.Errorf("Expected %q for type %d, got %q", name, typ, got)
+		}
+	}
+
+	syscall := &api.SyscallEvent{Id: syscallBPFID}
+	data := perf.TraceEventSampleData{"arg0": uint64(5)}
+	setBPFCommand(syscall, data)
+	if syscall.BpfCommand != "BPF_PROG_LOAD" ||
+		data["bpf_command"] != "BPF_PROG_LOAD" {
+		t.Errorf("Unexpected command %q", syscall.BpfCommand)
+	}
+
+	// Monitoring all processes on a CPU needs no process lookup
+	f := &syscallFilter{}
+	syscall = &api.SyscallEvent{Id: syscallPerfEventOpenID}
+	data = perf.TraceEventSampleData{
+		"perf_type":   uint32(2),
+		"perf_config": uint64(1234),
+		"arg1":        uint64(0xffffffffffffffff),
+		"arg2":        uint64(3),
+	}
+	f.setPerfEventOpen(&api.TelemetryEvent{}, syscall, data)
+	if syscall.PerfEventType != "PERF_TYPE_TRACEPOINT" ||
+		syscall.PerfEventConfig != 1234 ||
+		syscall.PerfEventTargetPid != -1 ||
+		syscall.PerfEventCpu != 3 ||
+		data["perf_event_target_pid"] != int32(-1) {
+		t.Errorf("Unexpected perf_event_open decoding %+v", syscall)
+	}
+
+	// Pids beyond the largest that the kernel assigns are not resolved
+	oldProcFS := procFS
+	procFS = &pidNamespaceTestFS{nspids: map[int][]int{10: {10}}}
+	defer func() { procFS = oldProcFS }()
+	f.sensor = &Sensor{
+		ProcessCache: &ProcessInfoCache{
+			cache:  newArrayTaskCache(32),
+			maxPID: 32,
+		},
+	}
+	syscall = &api.SyscallEvent{Id: syscallPerfEventOpenID}
+	data = perf.TraceEventSampleData{"arg1": uint64(0x7fffffff)}
+	f.setPerfEventOpen(&api.TelemetryEvent{ProcessPid: 10}, syscall, data)
+	if syscall.PerfEventTargetPid != 0x7fffffff ||
+		syscall.PerfEventTargetCommand != "" {
+		t.Errorf("Unexpected perf_event_open decoding %+v", syscall)
+	}
+}
+
+func TestPerfEventOpenArgMaskAndFetchargs(t *testing.T) {
+	for ident, expected := range map[string]uint8{
+		"bpf_command":           1 << 0,
+		"perf_event_type":       1 << 0,
+		"perf_event_target_pid": 1 << 1,
+		"perf_event_cpu":        1 << 2,
+	} {
+		expr := expression.Equal(
+			expression.Identifier(ident),
+			expression.Value("x"))
+		if mask := syscallArgMaskFromExpression(expr); mask != expected {
+			t.Errorf("%s: expected arg mask %#x, got %#x",
+				ident, expected, mask)
+		}
+	}
+
+	expected := "perf_type=+0(+112(%di)):u32 perf_config=+8(+112(%di)):u64"
+	if got := syscallEnterLayoutX86_64.perfEventAttrFetchargs(); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	expr := expression.LogicalOr(
+		expression.Equal(
+			expression.Identifier("id"),
+			expression.Value(int64(syscallBPFID))),
+		expression.Equal(
+			expression.Identifier("id"),
+			expression.Value(int64(syscallPerfEventOpenID))))
+	if !perfEventAttrsReferenced(expr) {
+		t.Error("Expected perf_event_open to be referenced")
+	}
+	if perfEventAttrsReferenced(expr.GetBinaryOp().Lhs) {
+		t.Error("Expected perf_event_open not to be referenced")
+	}
+}
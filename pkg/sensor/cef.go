@@ -0,0 +1,367 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/version"
+)
+
+const (
+	cefVendor  = "Capsule8"
+	cefProduct = "Sensor"
+)
+
+// cefEventClass describes how events of one kind are identified in CEF.
+type cefEventClass struct {
+	name     string
+	severity int
+}
+
+// cefEventClasses maps the name of each event kind, which is also the
+// prefix of its CEF signature id, to its CEF name and severity.
+var cefEventClasses = map[string]cefEventClass{
+	"syscall":            {"Syscall", 3},
+	"process":            {"Process", 3},
+	"file":               {"File", 3},
+	"kernel_call":        {"Kernel function call", 3},
+	"network":            {"Network", 3},
+	"performance":        {"Performance", 1},
+	"signal":             {"Signal", 3},
+	"container":          {"Container", 3},
+	"subscription_stats": {"Subscription statistics", 1},
+	"decode_error":       {"Decode error", 5},
+	"quiet_period":       {"Quiet period", 5},
+	"fileless_execution": {"Fileless execution", 8},
+	"chargen":            {"Chargen", 0},
+	"ticker":             {"Ticker", 0},
+}
+
+var (
+	// Header fields escape backslashes and pipes, and may not contain
+	// line breaks.
+	cefHeaderEscaper = strings.NewReplacer(
+		`\`, `\\`,
+		`|`, `\|`,
+		"\r", " ",
+		"\n", " ")
+
+	// Extension values escape backslashes and equals signs, and encode
+	// line breaks. Pipes need no escaping.
+	cefExtensionEscaper = strings.NewReplacer(
+		`\`, `\\`,
+		`=`, `\=`,
+		"\r", `\r`,
+		"\n", `\n`)
+)
+
+// cefOutputEncoder encodes each event as a line of ArcSight Common Event
+// Format. The signature id of an event is its kind and type, e.g.
+// "process:exec", and its fields are mapped to standard CEF extension keys
+// where one exists, or to labeled custom keys otherwise.
+type cefOutputEncoder struct{}
+
+func (cefOutputEncoder) Encode(event *api.TelemetryEvent) ([]byte, error) {
+	kind, eventType := cefEventKind(event)
+	class, ok := cefEventClasses[kind]
+	if !ok {
+		return nil, fmt.Errorf("Unsupported event payload %T",
+			event.Event)
+	}
+	signature, name := kind, class.name
+	if eventType != "" {
+		signature = kind + ":" + eventType
+		name = class.name + " " + strings.Replace(eventType, "_", " ", -1)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CEF:0")
+	for _, f := range []string{
+		cefVendor, cefProduct, version.Version, signature, name,
+		strconv.Itoa(class.severity),
+	} {
+		buf.WriteByte('|')
+		buf.WriteString(cefHeaderEscaper.Replace(f))
+	}
+	buf.WriteByte('|')
+
+	x := cefExtension{buf: &buf}
+	x.add("cat", kind)
+	x.add("externalId", event.Id)
+	x.add("deviceExternalId", event.SensorId)
+	pid := event.ProcessTgid
+	if pid == 0 {
+		pid = event.ProcessPid
+	}
+	x.addPid("spid", pid)
+	if len(event.ProcessLineage) > 0 {
+		x.add("sproc", event.ProcessLineage[0].Command)
+	}
+	if event.Credentials != nil {
+		x.add("suid", strconv.FormatUint(uint64(event.Credentials.Uid), 10))
+	}
+	x.addLabeled("cs1", "containerId", event.ContainerId)
+	x.addLabeled("cs2", "containerName", event.ContainerName)
+	x.addLabeled("cs3", "imageName", event.ImageName)
+
+	switch e := event.Event.(type) {
+	case *api.TelemetryEvent_Syscall:
+		x.encodeSyscall(e.Syscall)
+	case *api.TelemetryEvent_Process:
+		x.encodeProcess(e.Process)
+	case *api.TelemetryEvent_File:
+		x.add("filePath", e.File.Filename)
+		x.addLabeled("cn1", "openFlags",
+			strconv.FormatInt(int64(e.File.OpenFlags), 10))
+	case *api.TelemetryEvent_Network:
+		x.encodeNetwork(e.Network)
+	case *api.TelemetryEvent_Signal:
+		x.add("act", e.Signal.SignalName)
+		x.addPid("dpid", e.Signal.TargetPid)
+		x.add("dproc", e.Signal.TargetCommand)
+		x.addLabeled("cn1", "signal",
+			strconv.FormatInt(int64(e.Signal.Signal), 10))
+	case *api.TelemetryEvent_Container:
+		if e.Container.Type == api.ContainerEventType_CONTAINER_EVENT_TYPE_EXITED {
+			x.addLabeled("cn2", "exitCode",
+				strconv.FormatInt(int64(e.Container.ExitCode), 10))
+		}
+	case *api.TelemetryEvent_DecodeError:
+		x.add("reason", e.DecodeError.Reason)
+	case *api.TelemetryEvent_QuietPeriod:
+		x.addLabeled("cs4", "key", e.QuietPeriod.Key)
+	case *api.TelemetryEvent_FilelessExecution:
+		x.add("fname", e.FilelessExecution.MemfdName)
+		x.add("filePath", e.FilelessExecution.ExecPath)
+		x.addLabeled("cn1", "memfdFd",
+			strconv.FormatInt(int64(e.FilelessExecution.MemfdFd), 10))
+		if e.FilelessExecution.MemfdCreatePid != 0 {
+			x.addLabeled("cn2", "memfdCreatePid", strconv.FormatInt(
+				int64(e.FilelessExecution.MemfdCreatePid), 10))
+		}
+	}
+
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+func (cefOutputEncoder) Extension() string {
+	return "cef"
+}
+
+// cefEventKind returns the name of the kind of an event and the name of its
+// type within that kind, if it has one, in lower case.
+func cefEventKind(event *api.TelemetryEvent) (string, string) {
+	switch e := event.Event.(type) {
+	case *api.TelemetryEvent_Syscall:
+		return "syscall", cefEventType(e.Syscall.Type.String(),
+			"SYSCALL_EVENT_TYPE_")
+	case *api.TelemetryEvent_Process:
+		return "process", cefEventType(e.Process.Type.String(),
+			"PROCESS_EVENT_TYPE_")
+	case *api.TelemetryEvent_File:
+		return "file", cefEventType(e.File.Type.String(),
+			"FILE_EVENT_TYPE_")
+	case *api.TelemetryEvent_KernelCall:
+		return "kernel_call", ""
+	case *api.TelemetryEvent_Network:
+		return "network", cefEventType(e.Network.Type.String(),
+			"NETWORK_EVENT_TYPE_")
+	case *api.TelemetryEvent_Performance:
+		return "performance", ""
+	case *api.TelemetryEvent_Signal:
+		return "signal", cefEventType(e.Signal.Type.String(),
+			"SIGNAL_EVENT_TYPE_")
+	case *api.TelemetryEvent_Container:
+		return "container", cefEventType(e.Container.Type.String(),
+			"CONTAINER_EVENT_TYPE_")
+	case *api.TelemetryEvent_SubscriptionStats:
+		return "subscription_stats", ""
+	case *api.TelemetryEvent_DecodeError:
+		return "decode_error", ""
+	case *api.TelemetryEvent_QuietPeriod:
+		return "quiet_period", ""
+	case *api.TelemetryEvent_FilelessExecution:
+		return "fileless_execution", ""
+	case *api.TelemetryEvent_Chargen:
+		return "chargen", ""
+	case *api.TelemetryEvent_Ticker:
+		return "ticker", ""
+	}
+	return "", ""
+}
+
+func cefEventType(name, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(name, prefix))
+}
+
+// cefExtension appends key=value pairs to the extension of a CEF event.
+type cefExtension struct {
+	buf     *bytes.Buffer
+	started bool
+}
+
+// add appends a key and its escaped value. Empty values are omitted.
+func (x *cefExtension) add(key, value string) {
+	if value == "" {
+		return
+	}
+	if x.started {
+		x.buf.WriteByte(' ')
+	}
+	x.started = true
+	x.buf.WriteString(key)
+	x.buf.WriteByte('=')
+	x.buf.WriteString(cefExtensionEscaper.Replace(value))
+}
+
+// addLabeled appends one of the custom keys, such as cs1 or cn1, along with
+// the label that names it.
+func (x *cefExtension) addLabeled(key, label, value string) {
+	if value == "" {
+		return
+	}
+	x.add(key+"Label", label)
+	x.add(key, value)
+}
+
+// addPid appends a process id, which is omitted if it is unknown.
+func (x *cefExtension) addPid(key string, pid int32) {
+	if pid != 0 {
+		x.add(key, strconv.FormatInt(int64(pid), 10))
+	}
+}
+
+func (x *cefExtension) encodeSyscall(e *api.SyscallEvent) {
+	x.addLabeled("cn1", "syscallId", strconv.FormatInt(e.Id, 10))
+	if e.Type == api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT ||
+		e.Type == api.SyscallEventType_SYSCALL_EVENT_TYPE_COMPLETE ||
+		e.Type == api.SyscallEventType_SYSCALL_EVENT_TYPE_CLONE_LINKAGE {
+		x.addLabeled("cn2", "returnValue", strconv.FormatInt(e.Ret, 10))
+	}
+	if e.NewPath != "" {
+		x.add("oldFilePath", e.Path)
+		x.add("filePath", e.NewPath)
+	} else {
+		x.add("filePath", e.Path)
+	}
+	switch {
+	case e.BpfCommand != "":
+		x.add("act", e.BpfCommand)
+	case e.PtraceTargetPid != 0:
+		x.addPid("dpid", e.PtraceTargetPid)
+		x.add("dproc", e.PtraceTargetCommand)
+		x.add("act", e.PtraceRequest)
+	case e.PerfEventTargetPid != 0:
+		x.addPid("dpid", e.PerfEventTargetPid)
+		x.add("dproc", e.PerfEventTargetCommand)
+	case e.ChildPid != 0:
+		x.addPid("dpid", e.ChildPid)
+	}
+}
+
+func (x *cefExtension) encodeProcess(e *api.ProcessEvent) {
+	switch e.Type {
+	case api.ProcessEventType_PROCESS_EVENT_TYPE_FORK:
+		x.addPid("dpid", e.ForkChildPid)
+	case api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC:
+		x.add("filePath", e.ExecFilename)
+		x.addLabeled("cs4", "commandLine",
+			strings.Join(e.ExecCommandLine, " "))
+	case api.ProcessEventType_PROCESS_EVENT_TYPE_EXIT:
+		x.addLabeled("cn2", "exitCode",
+			strconv.FormatInt(int64(e.ExitCode), 10))
+	case api.ProcessEventType_PROCESS_EVENT_TYPE_UPDATE:
+		x.add("filePath", e.UpdateCwd)
+	}
+}
+
+func (x *cefExtension) encodeNetwork(e *api.NetworkEvent) {
+	// Bound addresses are local; the others are the remote peer.
+	host, port := "dst", "dpt"
+	if e.Type == api.NetworkEventType_NETWORK_EVENT_TYPE_BIND_ATTEMPT {
+		host, port = "src", "spt"
+	}
+	if ip, p, ok := inetAddress(e.Address); ok {
+		if ip != nil {
+			x.add(host, ip.String())
+		}
+		x.add(port, p)
+	} else if e.Address != nil {
+		if a, ok := e.Address.Address.(*api.NetworkAddress_LocalAddress); ok {
+			x.add("filePath", a.LocalAddress)
+		}
+	}
+	switch e.Type {
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_CONNECT_RESULT,
+		api.NetworkEventType_NETWORK_EVENT_TYPE_BIND_RESULT,
+		api.NetworkEventType_NETWORK_EVENT_TYPE_LISTEN_RESULT,
+		api.NetworkEventType_NETWORK_EVENT_TYPE_ACCEPT_RESULT,
+		api.NetworkEventType_NETWORK_EVENT_TYPE_SENDTO_RESULT,
+		api.NetworkEventType_NETWORK_EVENT_TYPE_RECVFROM_RESULT:
+		if e.Result < 0 {
+			x.add("outcome", "failure")
+		} else {
+			x.add("outcome", "success")
+		}
+		x.addLabeled("cn2", "returnValue",
+			strconv.FormatInt(e.Result, 10))
+	}
+}
+
+// inetAddress returns the IP address and port of an IPv4 or IPv6 network
+// address. The IP address is nil if it is not known. The last return value
+// is false if the address is not an IPv4 or IPv6 address.
+func inetAddress(address *api.NetworkAddress) (net.IP, string, bool) {
+	if address == nil {
+		return nil, "", false
+	}
+	switch a := address.Address.(type) {
+	case *api.NetworkAddress_Ipv4Address:
+		var ip net.IP
+		if a.Ipv4Address.Address != nil {
+			ip = make(net.IP, net.IPv4len)
+			binary.LittleEndian.PutUint32(ip,
+				a.Ipv4Address.Address.Address)
+		}
+		return ip, cefPort(a.Ipv4Address.Port), true
+	case *api.NetworkAddress_Ipv6Address:
+		var ip net.IP
+		if a.Ipv6Address.Address != nil {
+			ip = make(net.IP, net.IPv6len)
+			binary.LittleEndian.PutUint64(ip[:8],
+				a.Ipv6Address.Address.High)
+			binary.LittleEndian.PutUint64(ip[8:],
+				a.Ipv6Address.Address.Low)
+		}
+		return ip, cefPort(a.Ipv6Address.Port), true
+	}
+	return nil, "", false
+}
+
+// cefPort converts a port as read from a sockaddr, in network byte order,
+// to a string.
+func cefPort(port uint32) string {
+	var b [2]byte
+	binary.LittleEndian.PutUint16(b[:], uint16(port))
+	return strconv.FormatUint(uint64(binary.BigEndian.Uint16(b[:])), 10)
+}
@@ -0,0 +1,155 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/version"
+)
+
+func TestCEFEscaping(t *testing.T) {
+	headerTests := map[string]string{
+		"plain":       "plain",
+		`a|b`:         `a\|b`,
+		`a\b`:         `a\\b`,
+		`a\|b`:        `a\\\|b`,
+		"a=b":         "a=b",
+		"line\nbreak": "line break",
+		"a\r\nb":      "a  b",
+	}
+	for in, expected := range headerTests {
+		if out := cefHeaderEscaper.Replace(in); out != expected {
+			t.Errorf("Header %q: expected %q, got %q", in, expected, out)
+		}
+	}
+
+	extensionTests := map[string]string{
+		"plain":       "plain",
+		`a|b`:         `a|b`,
+		`a\b`:         `a\\b`,
+		"a=b":         `a\=b`,
+		`a\=b`:        `a\\\=b`,
+		"==":          `\=\=`,
+		"line\nbreak": `line\nbreak`,
+		"a\r\nb":      `a\r\nb`,
+		"a b":         "a b",
+	}
+	for in, expected := range extensionTests {
+		if out := cefExtensionEscaper.Replace(in); out != expected {
+			t.Errorf("Extension %q: expected %q, got %q", in, expected,
+				out)
+		}
+	}
+}
+
+func TestCEFOutputEncoder(t *testing.T) {
+	version.Version = "1.0|rc1"
+	defer func() { version.Version = "" }()
+
+	tests := []struct {
+		event    *api.TelemetryEvent
+		expected string
+	}{
+		{
+			&api.TelemetryEvent{
+				Id:             "id",
+				SensorId:       "sensor",
+				ProcessTgid:    42,
+				ProcessLineage: []*api.Process{{Pid: 42, Command: "sh"}},
+				Credentials:    &api.Credentials{Uid: 1000},
+				ContainerId:    "c=1",
+				Event: &api.TelemetryEvent_Process{
+					Process: &api.ProcessEvent{
+						Type:            api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC,
+						ExecFilename:    `C:\bin|ls`,
+						ExecCommandLine: []string{"ls", "a=b\nc"},
+					},
+				},
+			},
+			`CEF:0|Capsule8|Sensor|1.0\|rc1|process:exec|Process exec|3|` +
+				`cat=process externalId=id deviceExternalId=sensor ` +
+				`spid=42 sproc=sh suid=1000 cs1Label=containerId ` +
+				`cs1=c\=1 filePath=C:\\bin|ls cs4Label=commandLine ` +
+				`cs4=ls a\=b\nc` + "\n",
+		},
+		{
+			&api.TelemetryEvent{
+				ProcessPid: 7,
+				Event: &api.TelemetryEvent_Syscall{
+					Syscall: &api.SyscallEvent{
+						Type:    api.SyscallEventType_SYSCALL_EVENT_TYPE_COMPLETE,
+						Id:      82,
+						Ret:     -2,
+						Path:    "/a",
+						NewPath: "/b",
+					},
+				},
+			},
+			`CEF:0|Capsule8|Sensor|1.0\|rc1|syscall:complete|Syscall complete|3|` +
+				`cat=syscall spid=7 cn1Label=syscallId cn1=82 ` +
+				`cn2Label=returnValue cn2=-2 oldFilePath=/a filePath=/b` + "\n",
+		},
+		{
+			&api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Network{
+					Network: &api.NetworkEvent{
+						Type: api.NetworkEventType_NETWORK_EVENT_TYPE_CONNECT_ATTEMPT,
+						Address: &api.NetworkAddress{
+							Family: api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET,
+							Address: &api.NetworkAddress_Ipv4Address{
+								Ipv4Address: &api.IPv4AddressAndPort{
+									// 10.0.0.1:443 as read from a sockaddr_in
+									Address: &api.IPv4Address{Address: 0x0100000a},
+									Port:    0xbb01,
+								},
+							},
+						},
+					},
+				},
+			},
+			`CEF:0|Capsule8|Sensor|1.0\|rc1|network:connect_attempt|` +
+				`Network connect attempt|3|cat=network dst=10.0.0.1 dpt=443` +
+				"\n",
+		},
+		{
+			&api.TelemetryEvent{
+				Event: &api.TelemetryEvent_DecodeError{
+					DecodeError: &api.DecodeErrorEvent{Reason: "bad"},
+				},
+			},
+			"CEF:0|Capsule8|Sensor|1.0\\|rc1|decode_error|Decode error|5|" +
+				"cat=decode_error reason=bad\n",
+		},
+	}
+	for _, tc := range tests {
+		b, err := cefOutputEncoder{}.Encode(tc.event)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if string(b) != tc.expected {
+			t.Errorf("Expected %q, got %q", tc.expected, b)
+		}
+	}
+
+	if _, err := (cefOutputEncoder{}).Encode(&api.TelemetryEvent{}); err == nil {
+		t.Error("Expected error for event without a payload")
+	}
+	if enc, err := NewOutputEncoder("cef"); err != nil || enc.Extension() != "cef" {
+		t.Errorf("Unexpected CEF encoder %v, %v", enc, err)
+	}
+}
@@ -192,6 +192,7 @@ func (cc *ContainerCache) DeleteContainer(
 	cc.Unlock()
 
 	if ok {
+		cc.sensor.updateLazySubscriptions(info, false)
 		glog.V(2).Infof("Sending CONTAINER_DESTROYED for %s", info.ID)
 		cc.enqueueContainerEvent(cc.ContainerDestroyedEventID,
 			sampleID, info)
@@ -222,6 +223,21 @@ func (cc *ContainerCache) LookupContainer(containerID string, create bool) *Cont
 	return info
 }
 
+// runningContainers returns information for all cached containers that are
+// currently running.
+func (cc *ContainerCache) runningContainers() []*ContainerInfo {
+	cc.Lock()
+	defer cc.Unlock()
+
+	var running []*ContainerInfo
+	for _, info := range cc.cache {
+		if info.State == ContainerStateRunning {
+			running = append(running, info)
+		}
+	}
+	return running
+}
+
 func (cc *ContainerCache) enqueueContainerEvent(
 	eventID uint64,
 	sampleID perf.SampleID,
@@ -367,6 +383,11 @@ func (info *ContainerInfo) Update(
 		}
 	}
 
+	if info.State != oldState || dataChanged {
+		info.cache.sensor.updateLazySubscriptions(info,
+			info.State == ContainerStateRunning)
+	}
+
 	if info.State != oldState {
 		if oldState < ContainerStateCreated {
 			glog.V(2).Infof("Sending CONTAINER_CREATED for %s", info.ID)
@@ -503,6 +524,10 @@ func newContainerFilter(ecf *api.ContainerFilter) (*containerFilter, error) {
 }
 
 type containerFilter struct {
+	// Serializes match, which is called by all of the dispatch goroutines
+	// and caches the ids of matching containers
+	mutex sync.Mutex
+
 	containerIds   map[string]bool
 	containerNames map[string]bool
 	imageIds       map[string]bool
@@ -558,9 +583,29 @@ func (c *containerFilter) addImageName(iname string) {
 	}
 }
 
+// matchContainer determines whether a container matches the criteria set forth
+// by the filter. Unlike match, it does not modify the filter.
+func (c *containerFilter) matchContainer(info *ContainerInfo) bool {
+	if c.containerIds[info.ID] || c.containerNames[info.Name] ||
+		c.imageIds[info.ImageID] {
+		return true
+	}
+	if info.ImageName != "" {
+		for _, g := range c.imageGlobs {
+			if g.Match(info.ImageName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // match evaluates the container filter for an event and determines whether the
 // event matches the criteria set forth by the filter.
 func (c *containerFilter) match(e *api.TelemetryEvent) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	// Fast path: Check if containerId is in containerIds map
 	if c.containerIds[e.ContainerId] {
 		return true
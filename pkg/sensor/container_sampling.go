@@ -0,0 +1,220 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+const (
+	defaultContainerSamplingInterval = 10 * time.Second
+
+	// The maximum number of containers whose events are sampled. Events
+	// from further containers are not sampled until others become idle.
+	maxContainerSamplingKeys = 4096
+)
+
+// containerSampleState is the sampling state of one container.
+type containerSampleState struct {
+	// The probability with which events are currently returned
+	rate float64
+
+	// Accumulated fractional events. An event is returned each time
+	// the credit reaches 1, which spreads returned events evenly.
+	credit float64
+
+	observed uint64
+	sampled  uint64
+}
+
+// containerSampler samples a subscription's events by container. Rates are
+// recomputed at the end of each interval from the number of events each
+// container had during it. Containers start unsampled until their first
+// interval ends, though in EQUAL mode no container may exceed its budget.
+type containerSampler struct {
+	sync.Mutex
+
+	newEvent   func() *api.TelemetryEvent
+	dispatchFn eventSinkDispatchFn
+	mode       api.ContainerSampling_Mode
+	budget     uint64
+	interval   time.Duration
+
+	states map[string]*containerSampleState
+
+	stopChan chan struct{}
+}
+
+func newContainerSampler(
+	newEvent func() *api.TelemetryEvent,
+	dispatchFn eventSinkDispatchFn,
+	mode api.ContainerSampling_Mode,
+	budget uint64,
+	interval time.Duration,
+) *containerSampler {
+	return &containerSampler{
+		newEvent:   newEvent,
+		dispatchFn: dispatchFn,
+		mode:       mode,
+		budget:     budget,
+		interval:   interval,
+		states:     make(map[string]*containerSampleState),
+	}
+}
+
+// sample returns true if the event should be returned.
+func (c *containerSampler) sample(e *api.TelemetryEvent) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	st, ok := c.states[e.ContainerId]
+	if !ok {
+		if len(c.states) >= maxContainerSamplingKeys {
+			return true
+		}
+		st = &containerSampleState{rate: 1}
+		c.states[e.ContainerId] = st
+	}
+	st.observed++
+
+	if c.mode == api.ContainerSampling_EQUAL && st.sampled >= c.budget {
+		return false
+	}
+	st.credit += st.rate
+	if st.credit < 1 {
+		return false
+	}
+	st.credit--
+	st.sampled++
+	return true
+}
+
+// flush dispatches the effective sampling rates for the interval that ended
+// and computes the rates for the next one. Containers that had no events
+// during the interval are forgotten.
+func (c *containerSampler) flush() {
+	c.Lock()
+
+	var total uint64
+	rates := make([]*api.ContainerSampleRate, 0, len(c.states))
+	for id, st := range c.states {
+		if st.observed == 0 {
+			delete(c.states, id)
+			continue
+		}
+		total += st.observed
+		rates = append(rates, &api.ContainerSampleRate{
+			ContainerId:    id,
+			SampleRate:     float64(st.sampled) / float64(st.observed),
+			ObservedEvents: st.observed,
+			SampledEvents:  st.sampled,
+		})
+	}
+
+	var uniform float64
+	if c.mode == api.ContainerSampling_PROPORTIONAL && total > 0 {
+		uniform = float64(c.budget) * float64(len(c.states)) /
+			float64(total)
+	}
+	for _, st := range c.states {
+		switch c.mode {
+		case api.ContainerSampling_PROPORTIONAL:
+			// Don't let a quiet container disappear entirely
+			st.rate = uniform
+			if floor := 1 / float64(st.observed); floor > st.rate {
+				st.rate = floor
+			}
+		default:
+			st.rate = float64(c.budget) / float64(st.observed)
+		}
+		if st.rate > 1 {
+			st.rate = 1
+		}
+		st.observed = 0
+		st.sampled = 0
+	}
+	c.Unlock()
+
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].ContainerId < rates[j].ContainerId
+	})
+
+	e := c.newEvent()
+	e.Event = &api.TelemetryEvent_SubscriptionStats{
+		SubscriptionStats: &api.SubscriptionStatsEvent{
+			WindowNanos:          int64(c.interval),
+			ContainerSampleRates: rates,
+		},
+	}
+	c.dispatchFn(e)
+}
+
+func (c *containerSampler) start() {
+	c.stopChan = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.stopChan:
+				return
+			case <-ticker.C:
+				c.flush()
+			}
+		}
+	}()
+}
+
+func (c *containerSampler) stop() {
+	if c.stopChan != nil {
+		close(c.stopChan)
+	}
+}
+
+// newSubscriptionContainerSampler creates the container sampler for a
+// subscription, returning it along with a dispatch function that samples
+// events before passing them to dispatchFn. The sampler is not started.
+func newSubscriptionContainerSampler(
+	sensor *Sensor,
+	cs *api.ContainerSampling,
+	drops *dropCounts,
+	dispatchFn eventSinkDispatchFn,
+) (*containerSampler, eventSinkDispatchFn, error) {
+	if _, ok := api.ContainerSampling_Mode_name[int32(cs.Mode)]; !ok {
+		return nil, nil, errors.New("Invalid container sampling mode")
+	}
+	if cs.EventsPerContainer == 0 {
+		return nil, nil, errors.New("Container sampling requires events_per_container")
+	}
+	interval := defaultContainerSamplingInterval
+	if cs.IntervalSeconds != 0 {
+		interval = time.Duration(cs.IntervalSeconds) * time.Second
+	}
+
+	c := newContainerSampler(sensor.NewEvent, dispatchFn, cs.Mode,
+		cs.EventsPerContainer, interval)
+	return c, func(e *api.TelemetryEvent) {
+		if c.sample(e) {
+			dispatchFn(e)
+		} else {
+			drops.add(api.DropReason_DROP_REASON_CONTAINER_SAMPLING, 1)
+		}
+	}, nil
+}
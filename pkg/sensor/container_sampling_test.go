@@ -0,0 +1,107 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+func TestContainerSampler(t *testing.T) {
+	var events []*api.TelemetryEvent
+	newSampler := func(mode api.ContainerSampling_Mode) *containerSampler {
+		return newContainerSampler(
+			func() *api.TelemetryEvent { return &api.TelemetryEvent{} },
+			func(e *api.TelemetryEvent) { events = append(events, e) },
+			mode, 10, 10*time.Second)
+	}
+	add := func(c *containerSampler, containerID string, n int) int {
+		sampled := 0
+		for i := 0; i < n; i++ {
+			if c.sample(&api.TelemetryEvent{ContainerId: containerID}) {
+				sampled++
+			}
+		}
+		return sampled
+	}
+	flush := func(c *containerSampler) map[string]*api.ContainerSampleRate {
+		c.flush()
+		stats := events[len(events)-1].GetSubscriptionStats()
+		if stats.WindowNanos != int64(10*time.Second) {
+			t.Errorf("Expected 10s window, got %d", stats.WindowNanos)
+		}
+		rates := make(map[string]*api.ContainerSampleRate)
+		for _, r := range stats.ContainerSampleRates {
+			rates[r.ContainerId] = r
+		}
+		return rates
+	}
+
+	// Each container is capped at its budget, even before rates adapt
+	c := newSampler(api.ContainerSampling_EQUAL)
+	if n := add(c, "busy", 1000); n != 10 {
+		t.Errorf("Expected 10 busy events, got %d", n)
+	}
+	if n := add(c, "quiet", 5); n != 5 {
+		t.Errorf("Expected 5 quiet events, got %d", n)
+	}
+	rates := flush(c)
+	if r := rates["busy"]; r == nil || r.ObservedEvents != 1000 ||
+		r.SampledEvents != 10 || r.SampleRate != 0.01 {
+		t.Errorf("Unexpected busy rate %+v", r)
+	}
+	if r := rates["quiet"]; r == nil || r.SampleRate != 1 {
+		t.Errorf("Unexpected quiet rate %+v", r)
+	}
+
+	// The adapted rate spreads the budget over the interval
+	if n := add(c, "busy", 500); n != 5 {
+		t.Errorf("Expected 5 busy events, got %d", n)
+	}
+	if n := add(c, "quiet", 5); n != 5 {
+		t.Errorf("Expected 5 quiet events, got %d", n)
+	}
+	flush(c)
+
+	// Idle containers are forgotten
+	add(c, "busy", 100)
+	if rates = flush(c); len(rates) != 1 || rates["quiet"] != nil {
+		t.Errorf("Expected only busy container, got %+v", rates)
+	}
+
+	// All containers are sampled at the same rate, but quiet ones still
+	// appear
+	c = newSampler(api.ContainerSampling_PROPORTIONAL)
+	add(c, "", 1000)
+	add(c, "busy", 3000)
+	add(c, "quiet", 5)
+	flush(c)
+	// 30 events per interval out of 4005
+	if n := add(c, "", 1000); n != 7 {
+		t.Errorf("Expected 7 host events, got %d", n)
+	}
+	if n := add(c, "busy", 3000); n != 22 {
+		t.Errorf("Expected 22 busy events, got %d", n)
+	}
+	if n := add(c, "quiet", 5); n != 1 {
+		t.Errorf("Expected 1 quiet event, got %d", n)
+	}
+	rates = flush(c)
+	if r := rates[""]; r == nil || r.SampleRate != 0.007 {
+		t.Errorf("Unexpected host rate %+v", r)
+	}
+}
@@ -145,3 +145,29 @@ func TestFilterContainerNames(t *testing.T) {
 		t.Error("Unexpected matching container name found for bill")
 	}
 }
+
+func TestFilterMatchContainer(t *testing.T) {
+	cf, err := newContainerFilter(&api.ContainerFilter{
+		Names:      []string{"alice"},
+		ImageNames: []string{"docker.io/library/*"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cf.matchContainer(&ContainerInfo{ID: "1", Name: "alice"}) {
+		t.Error("No matching container name found for alice")
+	}
+	if !cf.matchContainer(&ContainerInfo{
+		ID:        "2",
+		ImageName: "docker.io/library/nginx",
+	}) {
+		t.Error("No matching container image name found for nginx")
+	}
+	if cf.matchContainer(&ContainerInfo{ID: "3", Name: "bill"}) {
+		t.Error("Unexpected matching container found for bill")
+	}
+	if len(cf.containerIds) != 0 {
+		t.Error("matchContainer modified the filter")
+	}
+}
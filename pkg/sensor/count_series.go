@@ -0,0 +1,227 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// The maximum number of buckets that a count series may retain
+	maxCountSeriesBuckets = 10080
+
+	// The maximum number of distinct syscalls or containers counted in a
+	// bucket. Events for keys first seen after the limit is reached are
+	// included in the bucket's total, but are not counted individually.
+	maxCountSeriesKeys = 4096
+
+	// The maximum number of tasks whose syscall enters are remembered so
+	// that their exits are not counted again
+	maxCountSeriesEnters = 65536
+)
+
+// countBucket holds the counts of events seen during one interval of a
+// count series.
+type countBucket struct {
+	// The index of the interval, i.e. the sensor monotime at which the
+	// interval starts divided by the resolution. Buckets whose interval
+	// has passed out of the series are reset before reuse.
+	interval int64
+
+	total      uint64
+	syscalls   map[int64]uint64
+	containers map[string]uint64
+}
+
+func (b *countBucket) reset(interval int64) {
+	b.interval = interval
+	b.total = 0
+	b.syscalls = make(map[int64]uint64)
+	b.containers = make(map[string]uint64)
+}
+
+// countSeries retains counts of the events dispatched by the sensor per
+// syscall and per container in a fixed ring of buckets, so that recent
+// historical counts can be queried without streaming events.
+type countSeries struct {
+	sync.Mutex
+
+	resolution time.Duration
+	buckets    []countBucket
+
+	// The most recent interval that an event has been counted in
+	latest int64
+
+	// The ids of the syscalls that tasks have entered, by task id, so
+	// that a syscall whose enter and exit are both counted is only
+	// counted once.
+	entered map[int32]int64
+}
+
+func newCountSeries(
+	retention, resolution time.Duration,
+) (*countSeries, error) {
+	if resolution <= 0 {
+		return nil, fmt.Errorf("Invalid count series resolution %v",
+			resolution)
+	}
+	n := int64((retention + resolution - 1) / resolution)
+	if n < 1 || n > maxCountSeriesBuckets {
+		return nil, fmt.Errorf("Count series retention %v at resolution %v requires %d buckets (the maximum is %d)",
+			retention, resolution, n, maxCountSeriesBuckets)
+	}
+
+	c := &countSeries{
+		resolution: resolution,
+		buckets:    make([]countBucket, n),
+		entered:    make(map[int32]int64),
+	}
+	for i := range c.buckets {
+		c.buckets[i].reset(-1)
+	}
+	return c, nil
+}
+
+// countsSyscall returns whether a syscall event is counted for its syscall.
+// Each syscall is counted once, by its enter event if that was counted and
+// otherwise by its exit event. Clone linkage events are not counted. The
+// caller must hold the lock.
+func (c *countSeries) countsSyscall(tid int32, ev *api.SyscallEvent) bool {
+	switch ev.Type {
+	case api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER:
+		if _, ok := c.entered[tid]; ok || len(c.entered) < maxCountSeriesEnters {
+			c.entered[tid] = ev.Id
+		}
+	case api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT:
+		if id, ok := c.entered[tid]; ok {
+			delete(c.entered, tid)
+			return id != ev.Id
+		}
+	case api.SyscallEventType_SYSCALL_EVENT_TYPE_CLONE_LINKAGE:
+		return false
+	}
+	return true
+}
+
+// add counts an event that has been dispatched to at least one
+// subscription. Events are assigned to buckets by their sensor monotime.
+func (c *countSeries) add(e *api.TelemetryEvent) {
+	interval := e.SensorMonotimeNanos / int64(c.resolution)
+
+	c.Lock()
+	if interval <= c.latest-int64(len(c.buckets)) {
+		// Too old to be retained
+		c.Unlock()
+		return
+	}
+	if interval > c.latest {
+		c.latest = interval
+	}
+	b := &c.buckets[interval%int64(len(c.buckets))]
+	if b.interval != interval {
+		b.reset(interval)
+	}
+	b.total++
+	if sev, ok := e.Event.(*api.TelemetryEvent_Syscall); ok &&
+		c.countsSyscall(e.ProcessPid, sev.Syscall) {
+		id := sev.Syscall.Id
+		if _, ok = b.syscalls[id]; ok || len(b.syscalls) < maxCountSeriesKeys {
+			b.syscalls[id]++
+		}
+	}
+	if id := e.ContainerId; len(id) > 0 {
+		if _, ok := b.containers[id]; ok || len(b.containers) < maxCountSeriesKeys {
+			b.containers[id]++
+		}
+	}
+	c.Unlock()
+}
+
+// query returns the counts of events during the specified duration before
+// now, which is a sensor monotime. The duration is rounded up to a whole
+// number of buckets and limited to the retention of the series.
+func (c *countSeries) query(now int64, duration time.Duration) *api.GetCountsResponse {
+	n := int64((duration + c.resolution - 1) / c.resolution)
+	if n < 1 || n > int64(len(c.buckets)) {
+		n = int64(len(c.buckets))
+	}
+	last := now / int64(c.resolution)
+	first := last - n + 1
+
+	syscalls := make(map[int64]uint64)
+	containers := make(map[string]uint64)
+	r := &api.GetCountsResponse{
+		StartMonotimeNanos: first * int64(c.resolution),
+		EndMonotimeNanos:   now,
+		ResolutionNanos:    int64(c.resolution),
+	}
+
+	c.Lock()
+	for i := range c.buckets {
+		b := &c.buckets[i]
+		if b.interval < first || b.interval > last {
+			continue
+		}
+		r.TotalEvents += b.total
+		for id, count := range b.syscalls {
+			syscalls[id] += count
+		}
+		for id, count := range b.containers {
+			containers[id] += count
+		}
+	}
+	c.Unlock()
+
+	for id, count := range syscalls {
+		r.Syscalls = append(r.Syscalls, &api.SyscallCount{
+			Id:    id,
+			Count: count,
+		})
+	}
+	sort.Slice(r.Syscalls, func(i, j int) bool {
+		return r.Syscalls[i].Id < r.Syscalls[j].Id
+	})
+	for id, count := range containers {
+		r.Containers = append(r.Containers, &api.ContainerCount{
+			ContainerId: id,
+			Count:       count,
+		})
+	}
+	sort.Slice(r.Containers, func(i, j int) bool {
+		return r.Containers[i].ContainerId < r.Containers[j].ContainerId
+	})
+	return r
+}
+
+// EventCounts returns the counts of events dispatched by the sensor per
+// syscall and per container during the specified duration before now.
+func (s *Sensor) EventCounts(duration time.Duration) (*api.GetCountsResponse, error) {
+	if s.countSeries == nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"Event count series is not enabled")
+	}
+	now := sys.CurrentMonotonicRaw() - s.bootMonotimeNanos
+	return s.countSeries.query(now, duration), nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+func newCountSeriesTestEvent(monotime int64, id int64, containerID string) *api.TelemetryEvent {
+	return &api.TelemetryEvent{
+		SensorMonotimeNanos: monotime,
+		ContainerId:         containerID,
+		Event: &api.TelemetryEvent_Syscall{
+			Syscall: &api.SyscallEvent{Id: id},
+		},
+	}
+}
+
+func TestCountSeries(t *testing.T) {
+	if _, err := newCountSeries(time.Hour, 0); err == nil {
+		t.Error("Expected error for zero resolution")
+	}
+	if _, err := newCountSeries(24*time.Hour, time.Second); err == nil {
+		t.Error("Expected error for too many buckets")
+	}
+
+	c, err := newCountSeries(3*time.Second, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const sec = int64(time.Second)
+	c.add(newCountSeriesTestEvent(0, 1, "a"))
+	c.add(newCountSeriesTestEvent(2*sec, 1, "b"))
+	c.add(newCountSeriesTestEvent(3*sec, 2, ""))
+	c.add(newCountSeriesTestEvent(4*sec+1, 1, "b"))
+
+	// The event in the first second has been overwritten, and events
+	// older than the retained buckets are ignored.
+	c.add(newCountSeriesTestEvent(sec, 3, "c"))
+	for _, b := range c.buckets {
+		if b.interval == 1 {
+			t.Errorf("Expected expired event to be ignored, got %+v", b)
+		}
+	}
+
+	r := c.query(4*sec+2, 0)
+	if r.TotalEvents != 3 || r.StartMonotimeNanos != 2*sec {
+		t.Fatalf("Unexpected counts %+v", r)
+	}
+	if len(r.Syscalls) != 2 ||
+		r.Syscalls[0].Id != 1 || r.Syscalls[0].Count != 2 ||
+		r.Syscalls[1].Id != 2 || r.Syscalls[1].Count != 1 {
+		t.Errorf("Unexpected syscall counts %+v", r.Syscalls)
+	}
+	if len(r.Containers) != 1 || r.Containers[0].ContainerId != "b" ||
+		r.Containers[0].Count != 2 {
+		t.Errorf("Unexpected container counts %+v", r.Containers)
+	}
+
+	r = c.query(4*sec+2, time.Second)
+	if r.TotalEvents != 1 || r.StartMonotimeNanos != 4*sec {
+		t.Errorf("Unexpected counts for last second %+v", r)
+	}
+}
+
+func TestCountSeriesSyscallOnce(t *testing.T) {
+	c, err := newCountSeries(time.Second, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	add := func(tid int32, id int64, typ api.SyscallEventType) {
+		e := newCountSeriesTestEvent(0, id, "")
+		e.ProcessPid = tid
+		e.Event.(*api.TelemetryEvent_Syscall).Syscall.Type = typ
+		c.add(e)
+	}
+	enter := api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER
+	exit := api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT
+
+	// Enter and exit of the same syscall, an exit whose enter was not
+	// counted, an exit of a different syscall than was entered, and a
+	// clone linkage event.
+	add(1, 0, enter)
+	add(1, 0, exit)
+	add(2, 0, exit)
+	add(3, 56, enter)
+	add(3, 0, exit)
+	add(3, 56, api.SyscallEventType_SYSCALL_EVENT_TYPE_CLONE_LINKAGE)
+
+	r := c.query(0, 0)
+	if r.TotalEvents != 6 {
+		t.Errorf("Expected 6 events, got %d", r.TotalEvents)
+	}
+	if len(r.Syscalls) != 2 ||
+		r.Syscalls[0].Id != 0 || r.Syscalls[0].Count != 3 ||
+		r.Syscalls[1].Id != 56 || r.Syscalls[1].Count != 1 {
+		t.Errorf("Unexpected syscall counts %+v", r.Syscalls)
+	}
+	if len(c.entered) != 0 {
+		t.Errorf("Expected no remembered enters, got %v", c.entered)
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/golang/glog"
+
+	"golang.org/x/sys/unix"
+	"google.golang.org/genproto/googleapis/rpc/code"
+)
+
+const (
+	// How often the sensor's CPU usage is measured
+	cpuLimitInterval = time.Second
+
+	// The degradation level is raised when usage is at least the high
+	// water fraction of the limit, and lowered when usage is below the
+	// low water fraction of it.
+	cpuLimitHighWater = 0.9
+	cpuLimitLowWater  = 0.7
+
+	// At the highest level, 1 in 8 events of normal priority
+	// subscriptions are delivered.
+	cpuLimitMaxLevel = 4
+)
+
+// cpuLimiter keeps the CPU usage of the sensor under a limit. Usage is
+// measured once per interval. While it is close to the limit, the degradation
+// level is raised by one each interval, and once it has fallen well below the
+// limit, the level is lowered by one each interval. The level determines which
+// events are shed: first all events of low priority subscriptions, then an
+// increasing share of the events of normal priority subscriptions.
+type cpuLimiter struct {
+	// Updated atomically. These are the first fields so that they are
+	// 64-bit aligned.
+	shedEvents uint64
+	level      int32
+
+	sync.Mutex
+
+	limit float64 // percent of one CPU, or 0 for no limit
+	usage float64 // percent of one CPU over the last interval
+
+	lastCPU  time.Duration
+	lastTime time.Time
+
+	// Called with the new level each time that it changes
+	onChange func(level int32, usage, limit float64)
+
+	cpuTime  func() (time.Duration, error)
+	now      func() time.Time
+	stopChan chan struct{}
+}
+
+func newCPULimiter(
+	limit float64,
+	onChange func(level int32, usage, limit float64),
+) *cpuLimiter {
+	return &cpuLimiter{
+		limit:    limit,
+		onChange: onChange,
+		cpuTime:  processCPUTime,
+		now:      time.Now,
+	}
+}
+
+// processCPUTime returns the user and system CPU time used by all threads of
+// the sensor process.
+func processCPUTime() (time.Duration, error) {
+	var ru unix.Rusage
+	if err := unix.Getrusage(unix.RUSAGE_SELF, &ru); err != nil {
+		return 0, err
+	}
+	return time.Duration(ru.Utime.Nano() + ru.Stime.Nano()), nil
+}
+
+func (l *cpuLimiter) start() {
+	l.measure()
+	l.stopChan = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(cpuLimitInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-l.stopChan:
+				return
+			case <-ticker.C:
+				l.measure()
+			}
+		}
+	}()
+}
+
+func (l *cpuLimiter) stop() {
+	if l.stopChan != nil {
+		close(l.stopChan)
+		l.stopChan = nil
+	}
+}
+
+// setLimit changes the limit of the limiter. A limit of 0 disables it. The
+// degradation level is adjusted at the next measurement.
+func (l *cpuLimiter) setLimit(limit float64) {
+	l.Lock()
+	l.limit = limit
+	l.Unlock()
+}
+
+// measure samples the CPU time used since the last measurement and adjusts
+// the degradation level accordingly.
+func (l *cpuLimiter) measure() {
+	cpu, err := l.cpuTime()
+	if err != nil {
+		glog.V(1).Infof("Could not measure sensor CPU usage: %v", err)
+		return
+	}
+
+	l.Lock()
+	now := l.now()
+	if l.lastTime.IsZero() {
+		l.lastCPU, l.lastTime = cpu, now
+		l.Unlock()
+		return
+	}
+	elapsed := now.Sub(l.lastTime)
+	if elapsed <= 0 {
+		l.Unlock()
+		return
+	}
+	l.usage = 100 * float64(cpu-l.lastCPU) / float64(elapsed)
+	l.lastCPU, l.lastTime = cpu, now
+
+	old := atomic.LoadInt32(&l.level)
+	level := old
+	switch {
+	case l.limit <= 0:
+		level = 0
+	case l.usage >= l.limit*cpuLimitHighWater:
+		if level < cpuLimitMaxLevel {
+			level++
+		}
+	case l.usage < l.limit*cpuLimitLowWater:
+		if level > 0 {
+			level--
+		}
+	}
+	atomic.StoreInt32(&l.level, level)
+	usage, limit := l.usage, l.limit
+	l.Unlock()
+
+	if level != old && l.onChange != nil {
+		l.onChange(level, usage, limit)
+	}
+}
+
+// admit returns whether an event may be delivered to the subscription at the
+// current degradation level.
+func (l *cpuLimiter) admit(subscr *subscription) bool {
+	if l == nil {
+		return true
+	}
+	level := atomic.LoadInt32(&l.level)
+	if level == 0 {
+		return true
+	}
+
+	ok := true
+	switch subscr.priority {
+	case api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_LOW:
+		ok = false
+	case api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_NORMAL:
+		if level > 1 {
+			n := uint64(1) << uint(level-1)
+			ok = atomic.AddUint64(&subscr.cpuSamples, 1)%n == 0
+		}
+	}
+	if !ok {
+		atomic.AddUint64(&l.shedEvents, 1)
+	}
+	return ok
+}
+
+// cpuLimitDegradation describes the events shed at a degradation level.
+func cpuLimitDegradation(level int32) string {
+	switch {
+	case level <= 0:
+		return "no events are shed"
+	case level == 1:
+		return "the events of low priority subscriptions are shed"
+	}
+	return fmt.Sprintf("the events of low priority subscriptions are shed, and 1 in %d events of normal priority subscriptions are delivered",
+		1<<uint(level-1))
+}
+
+// statistics returns the current state of the limiter.
+func (l *cpuLimiter) statistics() *api.CPUStatistics {
+	l.Lock()
+	limit, usage := l.limit, l.usage
+	l.Unlock()
+
+	return &api.CPUStatistics{
+		LimitPercent:     limit,
+		UsagePercent:     usage,
+		DegradationLevel: uint32(atomic.LoadInt32(&l.level)),
+		ShedEvents:       atomic.LoadUint64(&l.shedEvents),
+	}
+}
+
+// CPUStatistics returns the sensor's own CPU usage and the events shed by the
+// CPU limit.
+func (s *Sensor) CPUStatistics() *api.CPUStatistics {
+	if s.cpuLimit == nil {
+		return nil
+	}
+	return s.cpuLimit.statistics()
+}
+
+// cpuLimitChanged tells the subscriptions whose events may be shed by the CPU
+// limit that the degradation level changed.
+func (s *Sensor) cpuLimitChanged(level int32, usage, limit float64) {
+	var (
+		c   code.Code
+		msg string
+	)
+	switch {
+	case level > 0:
+		c = code.Code_RESOURCE_EXHAUSTED
+		msg = fmt.Sprintf("Sensor CPU usage %.1f%% is approaching its limit of %.1f%%; %s",
+			usage, limit, cpuLimitDegradation(level))
+		glog.Warning(msg)
+	case limit > 0:
+		c = code.Code_OK
+		msg = fmt.Sprintf("Sensor CPU usage %.1f%% is under its limit of %.1f%%; %s",
+			usage, limit, cpuLimitDegradation(level))
+		glog.Info(msg)
+	default:
+		c = code.Code_OK
+		msg = "Sensor CPU limit removed; " + cpuLimitDegradation(level)
+		glog.Info(msg)
+	}
+
+	seen := make(map[*subscription]bool)
+	for _, eventSinks := range s.eventMap.getMap() {
+		for _, es := range eventSinks {
+			subscr := es.subscription
+			if seen[subscr] || subscr.priority ==
+				api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_HIGH {
+				continue
+			}
+			seen[subscr] = true
+			subscr.sendLateStatus(c, msg)
+		}
+	}
+}
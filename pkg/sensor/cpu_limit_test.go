@@ -0,0 +1,176 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func TestCPULimiter(t *testing.T) {
+	var levels []int32
+	l := newCPULimiter(50, func(level int32, usage, limit float64) {
+		levels = append(levels, level)
+	})
+	now := time.Unix(1000, 0)
+	var cpu time.Duration
+	l.now = func() time.Time { return now }
+	l.cpuTime = func() (time.Duration, error) { return cpu, nil }
+
+	// Advance one second, using the specified percentage of a CPU
+	use := func(percent int) {
+		now = now.Add(time.Second)
+		cpu += time.Duration(percent) * 10 * time.Millisecond
+		l.measure()
+	}
+
+	low := &subscription{
+		priority: api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_LOW,
+	}
+	normal := &subscription{
+		priority: api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_NORMAL,
+	}
+	high := &subscription{
+		priority: api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_HIGH,
+	}
+	admitted := func(subscr *subscription) (n int) {
+		for i := 0; i < 16; i++ {
+			if l.admit(subscr) {
+				n++
+			}
+		}
+		return
+	}
+
+	l.measure()
+	use(40)
+	if len(levels) != 0 || admitted(low) != 16 {
+		t.Fatalf("Events shed under the limit, levels %v", levels)
+	}
+
+	// Usage approaching the limit raises the level each interval, up to
+	// the maximum
+	for i := 0; i < cpuLimitMaxLevel+1; i++ {
+		use(46)
+	}
+	if len(levels) != cpuLimitMaxLevel || levels[0] != 1 ||
+		levels[cpuLimitMaxLevel-1] != cpuLimitMaxLevel {
+		t.Fatalf("Unexpected levels %v", levels)
+	}
+	if n := admitted(low); n != 0 {
+		t.Errorf("Admitted %d low priority events", n)
+	}
+	if n := admitted(normal); n != 2 {
+		t.Errorf("Admitted %d normal priority events; expected 2", n)
+	}
+	if n := admitted(high); n != 16 {
+		t.Errorf("Admitted %d high priority events", n)
+	}
+
+	stats := l.statistics()
+	if stats.LimitPercent != 50 || stats.UsagePercent != 46 ||
+		stats.DegradationLevel != cpuLimitMaxLevel ||
+		stats.ShedEvents != 30 {
+		t.Errorf("Unexpected statistics %+v", stats)
+	}
+
+	// Usage between the water marks holds the level, and usage well
+	// under the limit lowers it
+	use(40)
+	if len(levels) != cpuLimitMaxLevel {
+		t.Errorf("Level changed between the water marks: %v", levels)
+	}
+	use(20)
+	if levels[len(levels)-1] != cpuLimitMaxLevel-1 {
+		t.Errorf("Unexpected levels %v", levels)
+	}
+
+	// Removing the limit stops shedding at the next measurement
+	l.setLimit(0)
+	use(100)
+	if levels[len(levels)-1] != 0 || admitted(low) != 16 {
+		t.Errorf("Events shed without a limit, levels %v", levels)
+	}
+
+	var nilLimiter *cpuLimiter
+	if !nilLimiter.admit(low) {
+		t.Error("nil limiter shed an event")
+	}
+}
+
+func TestCPULimitDegradation(t *testing.T) {
+	expected := "the events of low priority subscriptions are shed, and 1 in 4 events of normal priority subscriptions are delivered"
+	if got := cpuLimitDegradation(3); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestCPULimitAfterFilter(t *testing.T) {
+	l := newCPULimiter(50, nil)
+	l.level = 1
+	s := &Sensor{
+		eventMap: newSafeSubscriptionMap(),
+		cpuLimit: l,
+	}
+
+	var delivered int
+	subscr := newSubscription(s, 1, func(*api.TelemetryEvent) {
+		delivered++
+	})
+	subscr.priority = api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_LOW
+	subscr.drops = &dropCounts{}
+	filter := expression.Equal(
+		expression.Identifier("arg0"), expression.Value(uint64(1)))
+	if _, err := subscr.addDerivedEventSink(1, filter,
+		expression.FieldTypeMap{}, syscallEnterEventTypes); err != nil {
+		t.Fatal(err)
+	}
+	s.eventMap.subscribe(subscr)
+
+	newSample := func(arg0 uint64) perf.EventMonitorSample {
+		return perf.EventMonitorSample{
+			EventID: 1,
+			DecodedSample: &api.TelemetryEvent{
+				ProcessPid: 100,
+				Event: &api.TelemetryEvent_Syscall{
+					Syscall: &api.SyscallEvent{Id: 0, Arg0: arg0},
+				},
+			},
+			DecodedData: perf.TraceEventSampleData{"arg0": arg0},
+		}
+	}
+
+	// Events that the filter rejects are not counted as shed
+	s.dispatchQueuedSamples([]perf.EventMonitorSample{
+		newSample(2), newSample(2), newSample(1),
+	}, false)
+	if delivered != 0 {
+		t.Errorf("Expected no events delivered, got %d", delivered)
+	}
+	if n := subscr.drops.load(api.DropReason_DROP_REASON_CPU_LIMIT); n != 1 {
+		t.Errorf("Expected 1 CPU limit drop, got %d", n)
+	}
+	if l.shedEvents != 1 {
+		t.Errorf("Expected 1 shed event, got %d", l.shedEvents)
+	}
+	priority := api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_LOW
+	if n := s.Metrics.DroppedEvents[priority]; n != 1 {
+		t.Errorf("Expected 1 dropped event, got %d", n)
+	}
+}
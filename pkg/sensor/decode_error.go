@@ -0,0 +1,128 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/golang/glog"
+)
+
+// decodeErrorLimiter is a token bucket that limits the rate of
+// DecodeErrorEvents so that a decoder failing for every sample does not
+// flood subscriptions. It is shared by the dispatch goroutines.
+type decodeErrorLimiter struct {
+	sync.Mutex
+
+	rate   float64 // tokens per nanosecond
+	burst  float64
+	tokens float64
+	last   int64
+}
+
+func newDecodeErrorLimiter(rate float64) *decodeErrorLimiter {
+	burst := rate
+	if burst < 1 {
+		burst = 1
+	}
+	return &decodeErrorLimiter{
+		rate:   rate / float64(time.Second),
+		burst:  burst,
+		tokens: burst,
+	}
+}
+
+func (l *decodeErrorLimiter) allow(now int64) bool {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.last != 0 && now > l.last {
+		l.tokens += float64(now-l.last) * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	if now > l.last {
+		l.last = now
+	}
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// newDecodeErrorEvent creates the event reporting a sample that could not be
+// decoded.
+func (s *Sensor) newDecodeErrorEvent(esm *perf.EventMonitorSample) *api.TelemetryEvent {
+	de := &api.DecodeErrorEvent{
+		EventId: esm.EventID,
+		Reason:  esm.Err.Error(),
+	}
+	if _, ok := esm.Err.(perf.DecoderPanicError); ok {
+		de.Panic = true
+	}
+	if sr, ok := esm.RawSample.Record.(*perf.SampleRecord); ok {
+		de.RawDataLength = uint32(len(sr.RawData))
+	}
+
+	e := s.NewEvent()
+	e.ProcessPid = int32(esm.RawSample.TID)
+	e.ProcessTgid = int32(esm.RawSample.PID)
+	e.Cpu = int32(esm.RawSample.CPU)
+	e.Event = &api.TelemetryEvent_DecodeError{DecodeError: de}
+	return e
+}
+
+// decodeError counts and logs a sample that could not be decoded and, if
+// enabled, sends a DecodeErrorEvent to the subscriptions of its event whose
+// filters it passed. The kernel has already applied kernel filters, but a
+// filter evaluated in userspace cannot be applied to a sample that could not
+// be decoded, so subscriptions with one are not sent the event.
+func (s *Sensor) decodeError(
+	esm *perf.EventMonitorSample,
+	eventSinks map[int32]*eventSink,
+) {
+	atomic.AddUint64(&s.Metrics.DecodeErrors, 1)
+	glog.Warning(esm.Err)
+	for _, es := range eventSinks {
+		es.subscription.drops.add(api.DropReason_DROP_REASON_DECODE_ERROR, 1)
+	}
+
+	if s.decodeErrorLimiter == nil || len(eventSinks) == 0 {
+		return
+	}
+	if !s.decodeErrorLimiter.allow(int64(esm.RawSample.Time)) {
+		return
+	}
+
+	e := s.newDecodeErrorEvent(esm)
+	for _, es := range eventSinks {
+		if es.filter != nil {
+			continue
+		}
+		if es.dispatchFn != nil {
+			es.dispatchFn(e)
+		} else {
+			es.subscription.dispatchFn(e)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func TestDecodeErrorEvents(t *testing.T) {
+	var events []*api.TelemetryEvent
+	subscr := &subscription{
+		dispatchFn: func(e *api.TelemetryEvent) {
+			events = append(events, e)
+		},
+	}
+	filtered := &subscription{
+		dispatchFn: func(e *api.TelemetryEvent) {
+			t.Errorf("Unexpected event for userspace filtered sink %+v", e)
+		},
+	}
+	expr, err := expression.NewExpression(expression.Equal(
+		expression.Identifier("pid"), expression.Value(int32(1))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sinks := map[int32]*eventSink{
+		1: {subscription: subscr},
+		2: {subscription: filtered, filter: expr},
+	}
+
+	s := &Sensor{decodeErrorLimiter: newDecodeErrorLimiter(2)}
+	newSample := func(err error, when time.Duration) *perf.EventMonitorSample {
+		esm := &perf.EventMonitorSample{EventID: 7, Err: err}
+		esm.RawSample.Time = uint64(when)
+		esm.RawSample.PID = 100
+		esm.RawSample.TID = 101
+		esm.RawSample.Record = &perf.SampleRecord{RawData: make([]byte, 12)}
+		return esm
+	}
+
+	s.decodeError(newSample(errors.New("short sample"), time.Second), sinks)
+	s.decodeError(newSample(perf.DecoderPanicError{Value: "oops"},
+		time.Second), sinks)
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	de := events[0].GetDecodeError()
+	if de == nil || de.EventId != 7 || de.Reason != "short sample" ||
+		de.RawDataLength != 12 || de.Panic {
+		t.Errorf("Unexpected decode error event %+v", de)
+	}
+	if events[0].ProcessTgid != 100 || events[0].ProcessPid != 101 {
+		t.Errorf("Unexpected process %d/%d", events[0].ProcessTgid,
+			events[0].ProcessPid)
+	}
+	if de = events[1].GetDecodeError(); de == nil || !de.Panic {
+		t.Errorf("Expected panic decode error event, got %+v", de)
+	}
+
+	// The burst is exhausted until tokens accumulate
+	s.decodeError(newSample(errors.New("short sample"), time.Second), sinks)
+	if len(events) != 2 {
+		t.Errorf("Expected decode error event to be rate limited")
+	}
+	s.decodeError(newSample(errors.New("short sample"),
+		time.Second+500*time.Millisecond), sinks)
+	if len(events) != 3 {
+		t.Errorf("Expected 3 events, got %d", len(events))
+	}
+	if s.Metrics.DecodeErrors != 4 {
+		t.Errorf("Expected 4 decode errors, got %d", s.Metrics.DecodeErrors)
+	}
+
+	// Errors are only counted when the events are disabled
+	s.decodeErrorLimiter = nil
+	s.decodeError(newSample(errors.New("short sample"), 2*time.Second), sinks)
+	if len(events) != 3 || s.Metrics.DecodeErrors != 5 {
+		t.Errorf("Expected only a count with events disabled")
+	}
+}
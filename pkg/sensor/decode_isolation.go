@@ -0,0 +1,103 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"google.golang.org/genproto/googleapis/rpc/code"
+)
+
+// decodeGroupOptions returns the options to register a subscription's event
+// group with for its decode isolation.
+func decodeGroupOptions(
+	isolation api.DecodeIsolation,
+) ([]perf.EventGroupOption, error) {
+	switch isolation {
+	case api.DecodeIsolation_DECODE_ISOLATION_SHARED:
+		return nil, nil
+	case api.DecodeIsolation_DECODE_ISOLATION_ISOLATED:
+		return []perf.EventGroupOption{perf.WithIsolatedDecoding()}, nil
+	}
+	return nil, fmt.Errorf("Invalid decode isolation %d", isolation)
+}
+
+// receivesSample returns whether the subscription's event sinks receive a
+// sample from the goroutine that dispatched it. External samples are
+// dispatched by the shared decoding goroutine and again by the goroutine of
+// each subscription with isolated decoding, so that they are in order with
+// its events, and each subscription receives them only once.
+func (subscr *subscription) receivesSample(esm *perf.EventMonitorSample) bool {
+	if !esm.External {
+		return true
+	}
+	if subscr.decodeIsolated {
+		return esm.Isolated && esm.IsolatedGroupID == subscr.eventGroupID
+	}
+	return !esm.Isolated
+}
+
+// isolatedDecodeDrop is called by the EventMonitor once the decoding
+// goroutine of a subscription with isolated decoding has caught up after
+// falling so far behind that samples were dropped.
+func (s *Sensor) isolatedDecodeDrop(d perf.IsolatedDecodeDrop) {
+	set := &s.ringBufferOverflows
+	set.Lock()
+	subscr := set.subscriptions[d.GroupID]
+	set.Unlock()
+	if subscr == nil {
+		return
+	}
+	subscr.drops.add(api.DropReason_DROP_REASON_DECODE_BACKLOG, d.Dropped)
+	subscr.sendLateStatus(code.Code_DATA_LOSS,
+		fmt.Sprintf("Isolated decoding fell behind: %d events dropped",
+			d.Dropped))
+}
+
+// DecodeStatistics returns the decoding statistics of the event groups of
+// active subscriptions and of the sensor's own events, ordered by group ID.
+func (s *Sensor) DecodeStatistics() []*api.DecodeStatistics {
+	set := &s.ringBufferOverflows
+	set.Lock()
+	defer set.Unlock()
+
+	var stats []*api.DecodeStatistics
+	for _, gs := range s.Monitor.DecodeStatistics() {
+		// The counter groups of performance events are reported as
+		// part of their subscription's event group.
+		if subscr := set.subscriptions[gs.GroupID]; subscr != nil &&
+			subscr.eventGroupID != gs.GroupID {
+			continue
+		}
+		ds := &api.DecodeStatistics{
+			SubscriptionId:  gs.GroupID,
+			Isolated:        gs.Isolated,
+			Events:          gs.Samples,
+			DecodeNanos:     gs.DecodeNanos,
+			MaxLatencyNanos: gs.MaxLatencyNanos,
+			QueuedBatches:   uint32(gs.QueuedBatches),
+			DroppedEvents:   gs.DroppedSamples,
+		}
+		if gs.Samples > 0 {
+			ds.MeanLatencyNanos = gs.LatencyNanos / gs.Samples
+		}
+		stats = append(stats, ds)
+	}
+	return stats
+}
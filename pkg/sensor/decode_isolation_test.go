@@ -0,0 +1,104 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"google.golang.org/genproto/googleapis/rpc/code"
+)
+
+func TestDecodeGroupOptions(t *testing.T) {
+	options, err := decodeGroupOptions(api.DecodeIsolation_DECODE_ISOLATION_SHARED)
+	if err != nil || options != nil {
+		t.Errorf("Unexpected shared decoding options %v %v", options, err)
+	}
+
+	options, err = decodeGroupOptions(api.DecodeIsolation_DECODE_ISOLATION_ISOLATED)
+	if err != nil || len(options) != 1 {
+		t.Errorf("Unexpected isolated decoding options %v %v", options, err)
+	}
+
+	if _, err = decodeGroupOptions(api.DecodeIsolation(99)); err == nil {
+		t.Error("Expected error for invalid decode isolation")
+	}
+}
+
+func TestIsolatedDecodeDrop(t *testing.T) {
+	s := &Sensor{}
+	subscr := newSubscription(s, 7, nil)
+	subscr.drops = new(dropCounts)
+	s.ringBufferOverflows.add(subscr)
+
+	s.isolatedDecodeDrop(perf.IsolatedDecodeDrop{GroupID: 7, Dropped: 12})
+	select {
+	case st := <-subscr.lateStatus:
+		expected := "Isolated decoding fell behind: 12 events dropped"
+		if st.Code != int32(code.Code_DATA_LOSS) || st.Message != expected {
+			t.Errorf("Unexpected status %+v", st)
+		}
+	default:
+		t.Fatal("Expected status for dropped events")
+	}
+	if n := subscr.drops.load(api.DropReason_DROP_REASON_DECODE_BACKLOG); n != 12 {
+		t.Errorf("Expected 12 decode backlog drops, got %d", n)
+	}
+
+	// Drops of removed subscriptions are ignored
+	s.ringBufferOverflows.remove(subscr)
+	s.isolatedDecodeDrop(perf.IsolatedDecodeDrop{GroupID: 7, Dropped: 1})
+	if n := subscr.drops.load(api.DropReason_DROP_REASON_DECODE_BACKLOG); n != 12 {
+		t.Errorf("Expected 12 decode backlog drops, got %d", n)
+	}
+}
+
+func TestReceivesSample(t *testing.T) {
+	shared := newSubscription(nil, 3, nil)
+	isolated := newSubscription(nil, 7, nil)
+	isolated.decodeIsolated = true
+
+	type testCase struct {
+		esm                  perf.EventMonitorSample
+		toShared, toIsolated bool
+	}
+	testCases := []testCase{
+		{perf.EventMonitorSample{}, true, true},
+		{perf.EventMonitorSample{External: true}, true, false},
+		{perf.EventMonitorSample{
+			External:        true,
+			Isolated:        true,
+			IsolatedGroupID: 7,
+		}, false, true},
+		{perf.EventMonitorSample{
+			External:        true,
+			Isolated:        true,
+			IsolatedGroupID: 8,
+		}, false, false},
+	}
+	for i, tc := range testCases {
+		if got := shared.receivesSample(&tc.esm); got != tc.toShared {
+			t.Errorf("%d: expected %v for shared subscription, got %v",
+				i, tc.toShared, got)
+		}
+		if got := isolated.receivesSample(&tc.esm); got != tc.toIsolated {
+			t.Errorf("%d: expected %v for isolated subscription, got %v",
+				i, tc.toIsolated, got)
+		}
+	}
+}
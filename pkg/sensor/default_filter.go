@@ -0,0 +1,145 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/config"
+	"github.com/capsule8/capsule8/pkg/expression"
+
+	"github.com/golang/glog"
+)
+
+// Event types that sensor default filters may be set for. Default filters are
+// only applied to kernel events, so they may refer to common_pid.
+const (
+	DefaultFilterFile    = "file"
+	DefaultFilterKernel  = "kernel"
+	DefaultFilterNetwork = "network"
+	DefaultFilterSignal  = "signal"
+	DefaultFilterSyscall = "syscall"
+)
+
+var defaultFilterEventTypes = map[string]bool{
+	DefaultFilterFile:    true,
+	DefaultFilterKernel:  true,
+	DefaultFilterNetwork: true,
+	DefaultFilterSignal:  true,
+	DefaultFilterSyscall: true,
+}
+
+// SetDefaultFilter overrides the default filter for the specified event type.
+// The default filter is ANDed with the filters of every subscription for
+// events of that type registered after it is set. A nil expression removes
+// the default filter for the event type entirely, including the filters that
+// are configured via config.Sensor.
+func (s *Sensor) SetDefaultFilter(eventType string, expr *api.Expression) error {
+	if !defaultFilterEventTypes[eventType] {
+		return fmt.Errorf("Invalid default filter event type %q", eventType)
+	}
+	if expr != nil {
+		if _, err := expression.NewExpression(expr); err != nil {
+			return err
+		}
+	}
+
+	s.defaultFilterMutex.Lock()
+	if s.defaultFilters == nil {
+		s.defaultFilters = make(map[string]*api.Expression)
+	}
+	s.defaultFilters[eventType] = expr
+	s.defaultFilterMutex.Unlock()
+
+	return nil
+}
+
+// applyDefaultFilter returns filter ANDed with the default filter for the
+// specified event type. If filter is nil, the default filter is returned by
+// itself, which may also be nil.
+func (s *Sensor) applyDefaultFilter(
+	eventType string,
+	filter *api.Expression,
+) *api.Expression {
+	return expression.LogicalAnd(filter, s.defaultFilter(eventType))
+}
+
+func (s *Sensor) defaultFilter(eventType string) *api.Expression {
+	s.defaultFilterMutex.Lock()
+	expr, ok := s.defaultFilters[eventType]
+	s.defaultFilterMutex.Unlock()
+	if ok {
+		return expr
+	}
+
+	var pids []int
+	if config.Sensor.DefaultFilterExcludeSensor {
+		pids = append(pids, sensorThreadIDs()...)
+	}
+	pids = append(pids, config.Sensor.DefaultFilterExcludePids...)
+	expr = excludePidsExpression(pids)
+
+	if config.Sensor.DefaultFilterExcludeKernelThreads {
+		expr = expression.LogicalAnd(expr, excludeKernelThreadsExpression())
+	}
+	return expr
+}
+
+// excludeKernelThreadsExpression returns an expression that is true for
+// events from tasks that are not known to be kernel threads.
+func excludeKernelThreadsExpression() *api.Expression {
+	return expression.LogicalOr(
+		expression.IsNull(expression.Identifier("is_kernel_thread")),
+		expression.Equal(
+			expression.Identifier("is_kernel_thread"),
+			expression.Value(false)))
+}
+
+// excludePidsExpression returns an expression that is true for events from
+// tasks other than those specified, or nil if pids is empty.
+func excludePidsExpression(pids []int) *api.Expression {
+	var expr *api.Expression
+	for _, pid := range pids {
+		expr = expression.LogicalAnd(expr,
+			expression.NotEqual(
+				expression.Identifier("common_pid"),
+				expression.Value(int32(pid))))
+	}
+	return expr
+}
+
+// sensorThreadIDs returns the IDs of the sensor process's threads as they are
+// currently known. Threads created later will not be included, so the kernel
+// generates samples for them, which NewEventFromSample discards because they
+// belong to the sensor process.
+func sensorThreadIDs() []int {
+	infos, err := ioutil.ReadDir("/proc/self/task")
+	if err != nil {
+		glog.Warningf("Couldn't read sensor thread IDs: %v", err)
+		return nil
+	}
+
+	tids := make([]int, 0, len(infos))
+	for _, info := range infos {
+		if tid, err := strconv.Atoi(info.Name()); err == nil {
+			tids = append(tids, tid)
+		}
+	}
+	return tids
+}
@@ -0,0 +1,152 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"os"
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/config"
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func TestDefaultFilter(t *testing.T) {
+	saveExcludeSensor := config.Sensor.DefaultFilterExcludeSensor
+	saveExcludePids := config.Sensor.DefaultFilterExcludePids
+	defer func() {
+		config.Sensor.DefaultFilterExcludeSensor = saveExcludeSensor
+		config.Sensor.DefaultFilterExcludePids = saveExcludePids
+	}()
+	config.Sensor.DefaultFilterExcludeSensor = true
+	config.Sensor.DefaultFilterExcludePids = []int{2}
+
+	s := &Sensor{}
+	filter := expression.Equal(
+		expression.Identifier("id"),
+		expression.Value(int64(59)))
+	filter = s.applyDefaultFilter(DefaultFilterSyscall, filter)
+
+	expr, err := expression.NewExpression(filter)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	types := expression.FieldTypeMap{
+		"id":         expression.ValueTypeSignedInt64,
+		"common_pid": expression.ValueTypeSignedInt32,
+	}
+	for pid, match := range map[int32]bool{
+		int32(os.Getpid()): false,
+		2:                  false,
+		1:                  true,
+	} {
+		values := expression.FieldValueMap{
+			"id":         int64(59),
+			"common_pid": pid,
+		}
+		v, err := expr.Evaluate(types, values)
+		if err != nil {
+			t.Fatalf("Unexpected evaluation error: %v", err)
+		}
+		if expression.IsValueTrue(v) != match {
+			t.Errorf("Expected match %v for common_pid %d", match, pid)
+		}
+	}
+
+	if err = s.SetDefaultFilter(DefaultFilterSyscall, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if f := s.applyDefaultFilter(DefaultFilterSyscall, nil); f != nil {
+		t.Errorf("Expected no default filter, got %s", f)
+	}
+	if err = s.SetDefaultFilter("bogus", nil); err == nil {
+		t.Error("Expected error for invalid event type")
+	}
+}
+
+func TestDefaultFilterKernelOffload(t *testing.T) {
+	saveExcludeSensor := config.Sensor.DefaultFilterExcludeSensor
+	saveExcludePids := config.Sensor.DefaultFilterExcludePids
+	saveExcludeKernelThreads := config.Sensor.DefaultFilterExcludeKernelThreads
+	defer func() {
+		config.Sensor.DefaultFilterExcludeSensor = saveExcludeSensor
+		config.Sensor.DefaultFilterExcludePids = saveExcludePids
+		config.Sensor.DefaultFilterExcludeKernelThreads = saveExcludeKernelThreads
+	}()
+	config.Sensor.DefaultFilterExcludeSensor = true
+	config.Sensor.DefaultFilterExcludePids = []int{2}
+	config.Sensor.DefaultFilterExcludeKernelThreads = false
+
+	monitor, err := perf.NewEventMonitor(perf.WithTracingDir(t.TempDir()))
+	if err != nil {
+		t.Skipf("Cannot create event monitor: %v", err)
+	}
+	defer monitor.Close()
+
+	// The default filter does not keep a syscall filter from being
+	// evaluated by the kernel.
+	s := &Sensor{Monitor: monitor}
+	subscr := newSubscription(s, 1, nil)
+	filter := s.applyDefaultFilter(DefaultFilterSyscall,
+		expression.Equal(
+			expression.Identifier("id"),
+			expression.Value(int64(59))))
+	es, err := subscr.addDerivedEventSink(1, filter,
+		syscallEnterEventTypes, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if es.kernelFilter == nil || es.filter != nil {
+		t.Errorf("Expected kernel filter only, got %v and %v",
+			es.kernelFilter, es.filter)
+	}
+}
+
+func TestDefaultFilterExcludeKernelThreads(t *testing.T) {
+	saveExcludeSensor := config.Sensor.DefaultFilterExcludeSensor
+	saveExcludeKernelThreads := config.Sensor.DefaultFilterExcludeKernelThreads
+	defer func() {
+		config.Sensor.DefaultFilterExcludeSensor = saveExcludeSensor
+		config.Sensor.DefaultFilterExcludeKernelThreads = saveExcludeKernelThreads
+	}()
+	config.Sensor.DefaultFilterExcludeSensor = false
+	config.Sensor.DefaultFilterExcludeKernelThreads = true
+
+	s := &Sensor{}
+	expr, err := expression.NewExpression(
+		s.applyDefaultFilter(DefaultFilterSyscall, nil))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	types := expression.FieldTypeMap{
+		"is_kernel_thread": expression.ValueTypeBool,
+	}
+	for _, tc := range []struct {
+		values expression.FieldValueMap
+		match  bool
+	}{
+		{expression.FieldValueMap{"is_kernel_thread": true}, false},
+		{expression.FieldValueMap{"is_kernel_thread": false}, true},
+		{expression.FieldValueMap{}, true},
+	} {
+		v, err := expr.Evaluate(types, tc.values)
+		if err != nil {
+			t.Fatalf("Unexpected evaluation error: %v", err)
+		}
+		if expression.IsValueTrue(v) != tc.match {
+			t.Errorf("Expected match %v for %v", tc.match, tc.values)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/config"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/golang/glog"
+)
+
+// The largest number of dispatch goroutines used when the number is not
+// configured
+const maxDefaultDispatchWorkers = 8
+
+// defaultDispatchWorkers returns the number of dispatch goroutines to use
+// when the configuration leaves it to the sensor.
+func defaultDispatchWorkers() int {
+	n := runtime.NumCPU()
+	if n > maxDefaultDispatchWorkers {
+		n = maxDefaultDispatchWorkers
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// configDispatchWorkers returns the number of dispatch goroutines set by the
+// sensor's configuration.
+func configDispatchWorkers() int {
+	if config.Sensor.DispatchWorkers > 0 {
+		return config.Sensor.DispatchWorkers
+	}
+	return defaultDispatchWorkers()
+}
+
+type queuedSamples struct {
+	next    *queuedSamples
+	samples []perf.EventMonitorSample
+}
+
+// dispatchWorker is a goroutine that delivers the events of the batches of
+// samples queued for it to subscriptions. Its queue is protected by the
+// sensor's dispatchMutex.
+type dispatchWorker struct {
+	// Updated atomically. These are the first fields so that they are
+	// 64-bit aligned.
+	batches   uint64
+	samples   uint64
+	busyNanos uint64
+
+	index   int
+	started time.Time
+	cond    sync.Cond
+
+	head   *queuedSamples
+	tail   *queuedSamples
+	length int
+
+	// Set when the number of workers is reduced. The worker exits once
+	// its queue is empty.
+	retired bool
+}
+
+// dispatchWorkerIndex returns the index of the worker that delivers the
+// events of a sample. The events of a process always go to the same worker,
+// so that they are delivered in the order they were decoded. Samples that
+// could not be decoded are assigned by the process that the kernel recorded
+// for them.
+func dispatchWorkerIndex(esm *perf.EventMonitorSample, workers int) int {
+	if workers <= 1 {
+		return 0
+	}
+	if esm.Err != nil {
+		return int(esm.RawSample.PID % uint32(workers))
+	}
+	event, ok := esm.DecodedSample.(*api.TelemetryEvent)
+	if !ok || event == nil {
+		return 0
+	}
+	return int(uint32(event.ProcessTgid) % uint32(workers))
+}
+
+// splitSamples divides a batch of samples among workers. Batches are not
+// copied if there is only one worker.
+func splitSamples(
+	samples []perf.EventMonitorSample,
+	workers int,
+) [][]perf.EventMonitorSample {
+	if workers <= 1 {
+		return [][]perf.EventMonitorSample{samples}
+	}
+	split := make([][]perf.EventMonitorSample, workers)
+	for i := range samples {
+		w := dispatchWorkerIndex(&samples[i], workers)
+		split[w] = append(split[w], samples[i])
+	}
+	return split
+}
+
+// resizeDispatchWorkers changes the number of dispatch workers. Workers that
+// are removed finish their queued batches before exiting, and so the events
+// of a process may briefly be delivered out of order. The caller must hold
+// dispatchMutex.
+func (s *Sensor) resizeDispatchWorkers(n int) {
+	for len(s.dispatchWorkers) < n {
+		w := &dispatchWorker{index: len(s.dispatchWorkers)}
+		w.cond.L = &s.dispatchMutex
+		s.dispatchWorkers = append(s.dispatchWorkers, w)
+		if s.dispatchRunning {
+			s.startDispatchWorker(w)
+		}
+	}
+	for _, w := range s.dispatchWorkers[n:] {
+		w.retired = true
+		w.cond.Signal()
+	}
+	s.dispatchWorkers = s.dispatchWorkers[:n]
+}
+
+func (s *Sensor) startDispatchWorker(w *dispatchWorker) {
+	w.started = time.Now()
+	s.dispatchWaitGroup.Add(1)
+	go s.sampleDispatchLoop(w)
+}
+
+// startDispatch starts the dispatch workers.
+func (s *Sensor) startDispatch() {
+	s.dispatchMutex.Lock()
+	s.dispatchRunning = true
+	s.resizeDispatchWorkers(int(s.Limits().DispatchWorkers))
+	for _, w := range s.dispatchWorkers {
+		if w.started.IsZero() {
+			s.startDispatchWorker(w)
+		}
+	}
+	s.dispatchMutex.Unlock()
+}
+
+// stopDispatch stops the dispatch workers and waits for them to exit.
+// Batches that are still queued are discarded.
+func (s *Sensor) stopDispatch() {
+	s.dispatchMutex.Lock()
+	if !s.dispatchRunning {
+		s.dispatchMutex.Unlock()
+		return
+	}
+	s.dispatchRunning = false
+	for _, w := range s.dispatchWorkers {
+		w.cond.Broadcast()
+	}
+	s.dispatchSpace.Broadcast()
+	s.dispatchMutex.Unlock()
+	s.dispatchWaitGroup.Wait()
+}
+
+// dispatchSamples queues a batch of decoded samples for the dispatch workers.
+// It is called by the EventMonitor's decoding goroutines, which are
+// serialized by dispatchCallerMutex. It is the only caller of
+// resizeDispatchWorkers once the sensor has started, and so the workers do
+// not change while it waits for room in a queue.
+func (s *Sensor) dispatchSamples(samples []perf.EventMonitorSample) {
+	s.dispatchCallerMutex.Lock()
+	defer s.dispatchCallerMutex.Unlock()
+
+	limits := s.Limits()
+	depth := int(limits.DispatchQueueDepth)
+
+	s.dispatchMutex.Lock()
+	if n := int(limits.DispatchWorkers); n != len(s.dispatchWorkers) {
+		s.resizeDispatchWorkers(n)
+	}
+	for i, batch := range splitSamples(samples, len(s.dispatchWorkers)) {
+		if len(batch) == 0 {
+			continue
+		}
+		w := s.dispatchWorkers[i]
+		for depth > 0 && w.length >= depth && s.dispatchRunning {
+			s.dispatchSpace.Wait()
+		}
+		s.queueSamples(w, batch)
+	}
+	s.dispatchMutex.Unlock()
+}
+
+// queueSamples adds a batch of samples to a worker's queue. The caller must
+// hold dispatchMutex.
+func (s *Sensor) queueSamples(w *dispatchWorker, samples []perf.EventMonitorSample) {
+	var qs *queuedSamples
+	if s.dispatchFreelist == nil {
+		qs = &queuedSamples{samples: samples}
+	} else {
+		qs = s.dispatchFreelist
+		s.dispatchFreelist = qs.next
+		qs.next = nil
+		qs.samples = samples
+	}
+
+	if w.tail == nil {
+		w.head = qs
+		w.cond.Signal()
+	} else {
+		w.tail.next = qs
+	}
+	w.tail = qs
+	w.length++
+}
+
+// popSamples removes the first batch of samples from a worker's queue. The
+// caller must hold dispatchMutex.
+func (s *Sensor) popSamples(w *dispatchWorker) []perf.EventMonitorSample {
+	qs := w.head
+	samples := qs.samples
+
+	w.head = qs.next
+	if w.head == nil {
+		w.tail = nil
+	}
+	w.length--
+
+	qs.next = s.dispatchFreelist
+	qs.samples = nil
+	s.dispatchFreelist = qs
+
+	return samples
+}
+
+func (s *Sensor) sampleDispatchLoop(w *dispatchWorker) {
+	glog.V(2).Infof("Sample dispatch loop %d started", w.index)
+
+	s.dispatchMutex.Lock()
+	for s.dispatchRunning {
+		if w.head == nil {
+			if w.retired {
+				break
+			}
+			w.cond.Wait()
+			continue
+		}
+		overloaded := w.length >=
+			int(s.Limits().DispatchBacklogThreshold)
+		samples := s.popSamples(w)
+		s.dispatchSpace.Broadcast()
+
+		s.dispatchMutex.Unlock()
+		start := time.Now()
+		s.dispatchQueuedSamples(samples, overloaded)
+		atomic.AddUint64(&w.busyNanos, uint64(time.Since(start)))
+		atomic.AddUint64(&w.batches, 1)
+		atomic.AddUint64(&w.samples, uint64(len(samples)))
+		s.dispatchMutex.Lock()
+	}
+	s.dispatchMutex.Unlock()
+
+	glog.V(2).Infof("Sample dispatch loop %d stopped", w.index)
+	s.dispatchWaitGroup.Done()
+}
+
+// DispatchWorkerStatistics returns the activity of each of the sensor's
+// dispatch workers, ordered by index.
+func (s *Sensor) DispatchWorkerStatistics() []*api.DispatchWorkerStatistics {
+	now := time.Now()
+
+	s.dispatchMutex.Lock()
+	stats := make([]*api.DispatchWorkerStatistics, 0, len(s.dispatchWorkers))
+	for _, w := range s.dispatchWorkers {
+		busy := atomic.LoadUint64(&w.busyNanos)
+		ws := &api.DispatchWorkerStatistics{
+			Index:         uint32(w.index),
+			QueuedBatches: uint32(w.length),
+			Batches:       atomic.LoadUint64(&w.batches),
+			Samples:       atomic.LoadUint64(&w.samples),
+			BusyNanos:     busy,
+		}
+		if !w.started.IsZero() {
+			if elapsed := now.Sub(w.started); elapsed > 0 {
+				ws.Utilization = float64(busy) / float64(elapsed)
+			}
+		}
+		stats = append(stats, ws)
+	}
+	s.dispatchMutex.Unlock()
+
+	return stats
+}
@@ -0,0 +1,124 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"errors"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func newDispatchTestSample(tgid int32) perf.EventMonitorSample {
+	return perf.EventMonitorSample{
+		DecodedSample: &api.TelemetryEvent{ProcessTgid: tgid},
+	}
+}
+
+func TestSplitSamples(t *testing.T) {
+	samples := []perf.EventMonitorSample{
+		newDispatchTestSample(4),
+		newDispatchTestSample(5),
+		{},
+		newDispatchTestSample(6),
+		newDispatchTestSample(7),
+		newDispatchTestSample(10),
+	}
+
+	split := splitSamples(samples, 1)
+	if len(split) != 1 || len(split[0]) != len(samples) {
+		t.Fatalf("Expected a single unsplit batch, got %v", split)
+	}
+
+	split = splitSamples(samples, 3)
+	expected := [][]int32{{6}, {4, 7, 10}, {5}}
+	for i, batch := range split {
+		var tgids []int32
+		for _, esm := range batch {
+			if e, ok := esm.DecodedSample.(*api.TelemetryEvent); ok {
+				tgids = append(tgids, e.ProcessTgid)
+			}
+		}
+		if len(tgids) != len(expected[i]) {
+			t.Fatalf("Worker %d: expected %v, got %v",
+				i, expected[i], tgids)
+		}
+		for j := range tgids {
+			if tgids[j] != expected[i][j] {
+				t.Fatalf("Worker %d: expected %v, got %v",
+					i, expected[i], tgids)
+			}
+		}
+	}
+	// Samples without an event go to the first worker
+	if len(split[0]) != 2 {
+		t.Errorf("Expected 2 samples for worker 0, got %d", len(split[0]))
+	}
+}
+
+func TestDispatchWorkerIndexDecodeError(t *testing.T) {
+	// Samples that could not be decoded are spread by the process that
+	// the kernel recorded for them
+	counts := make([]int, 3)
+	for pid := uint32(1); pid <= 6; pid++ {
+		esm := perf.EventMonitorSample{Err: errors.New("short sample")}
+		esm.RawSample.PID = pid
+		counts[dispatchWorkerIndex(&esm, len(counts))]++
+	}
+	for i, n := range counts {
+		if n != 2 {
+			t.Errorf("Expected 2 decode errors for worker %d, got %d",
+				i, n)
+		}
+	}
+}
+
+func TestDispatchWorkerResize(t *testing.T) {
+	s := &Sensor{}
+	s.dispatchSpace.L = &s.dispatchMutex
+	s.limits.Store(&api.SensorLimits{DispatchWorkers: 2})
+
+	s.dispatchSamples([]perf.EventMonitorSample{
+		newDispatchTestSample(1),
+		newDispatchTestSample(2),
+		newDispatchTestSample(3),
+	})
+	if len(s.dispatchWorkers) != 2 {
+		t.Fatalf("Expected 2 workers, got %d", len(s.dispatchWorkers))
+	}
+	stats := s.DispatchWorkerStatistics()
+	if len(stats) != 2 || stats[0].QueuedBatches != 1 ||
+		stats[1].QueuedBatches != 1 {
+		t.Fatalf("Unexpected statistics %+v", stats)
+	}
+	if n := len(s.popSamples(s.dispatchWorkers[1])); n != 2 {
+		t.Errorf("Expected 2 samples for worker 1, got %d", n)
+	}
+
+	retired := s.dispatchWorkers[1]
+	s.limits.Store(&api.SensorLimits{DispatchWorkers: 1})
+	s.dispatchSamples([]perf.EventMonitorSample{
+		newDispatchTestSample(1),
+	})
+	if len(s.dispatchWorkers) != 1 || !retired.retired {
+		t.Fatal("Expected the second worker to be retired")
+	}
+	if s.dispatchWorkers[0].length != 2 {
+		t.Errorf("Expected 2 batches for worker 0, got %d",
+			s.dispatchWorkers[0].length)
+	}
+}
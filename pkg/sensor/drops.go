@@ -0,0 +1,96 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"sort"
+	"sync/atomic"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+// dropCounts counts the events of a subscription that were discarded, by
+// reason. It is allocated before the subscription so that the dispatch
+// functions wrapping it may count the events that they discard. Counts are
+// updated atomically. A nil dropCounts counts nothing.
+type dropCounts [api.DropReason_DROP_REASON_DECODE_BACKLOG + 1]uint64
+
+func (d *dropCounts) add(reason api.DropReason, n uint64) {
+	if d != nil {
+		atomic.AddUint64(&d[reason], n)
+	}
+}
+
+func (d *dropCounts) load(reason api.DropReason) uint64 {
+	if d == nil {
+		return 0
+	}
+	return atomic.LoadUint64(&d[reason])
+}
+
+// dropStatistics returns the number of the subscription's events that were
+// discarded for each reason, omitting reasons with none. Events shed by the
+// sensor or throttled are counted where they are discarded.
+func (subscr *subscription) dropStatistics() []*api.DropCount {
+	var counts dropCounts
+	for i := range counts {
+		counts[i] = subscr.drops.load(api.DropReason(i))
+	}
+	counts[api.DropReason_DROP_REASON_OVERLOAD] +=
+		atomic.LoadUint64(&subscr.shedEvents)
+	if subscr.ackThrottle != nil {
+		counts[api.DropReason_DROP_REASON_ACK_THROTTLE] +=
+			atomic.LoadUint64(&subscr.ackThrottle.throttledEvents)
+	}
+	if subscr.egressShare != nil {
+		counts[api.DropReason_DROP_REASON_EGRESS_LIMIT] +=
+			atomic.LoadUint64(&subscr.egressShare.shedEvents)
+	}
+
+	var drops []*api.DropCount
+	for i, n := range counts {
+		if n > 0 {
+			drops = append(drops, &api.DropCount{
+				Reason: api.DropReason(i),
+				Count:  n,
+			})
+		}
+	}
+	return drops
+}
+
+// DropStatistics returns the number of events discarded by each active
+// subscription, by reason.
+func (s *Sensor) DropStatistics() []*api.SubscriptionDropStatistics {
+	var stats []*api.SubscriptionDropStatistics
+	seen := make(map[*subscription]bool)
+	for _, eventSinks := range s.eventMap.getMap() {
+		for _, es := range eventSinks {
+			subscr := es.subscription
+			if seen[subscr] {
+				continue
+			}
+			seen[subscr] = true
+			stats = append(stats, &api.SubscriptionDropStatistics{
+				SubscriptionId: subscr.eventGroupID,
+				Drops:          subscr.dropStatistics(),
+			})
+		}
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].SubscriptionId < stats[j].SubscriptionId
+	})
+	return stats
+}
@@ -0,0 +1,105 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func TestDropStatistics(t *testing.T) {
+	// A nil dropCounts counts nothing
+	var none *dropCounts
+	none.add(api.DropReason_DROP_REASON_BUFFER_FULL, 1)
+	if n := none.load(api.DropReason_DROP_REASON_BUFFER_FULL); n != 0 {
+		t.Errorf("Expected 0 from nil dropCounts, got %d", n)
+	}
+
+	subscr := &subscription{
+		shedEvents:  3,
+		drops:       new(dropCounts),
+		ackThrottle: &ackThrottle{throttledEvents: 4},
+		egressShare: &egressShare{shedEvents: 5},
+	}
+	if drops := subscr.dropStatistics(); len(drops) != 3 {
+		t.Fatalf("Expected 3 reasons, got %v", drops)
+	}
+
+	subscr.drops.add(api.DropReason_DROP_REASON_RING_BUFFER_LOSS, 100)
+	subscr.drops.add(api.DropReason_DROP_REASON_OVERLOAD, 1)
+	expected := []*api.DropCount{
+		{Reason: api.DropReason_DROP_REASON_OVERLOAD, Count: 4},
+		{Reason: api.DropReason_DROP_REASON_ACK_THROTTLE, Count: 4},
+		{Reason: api.DropReason_DROP_REASON_EGRESS_LIMIT, Count: 5},
+		{Reason: api.DropReason_DROP_REASON_RING_BUFFER_LOSS, Count: 100},
+	}
+	drops := subscr.dropStatistics()
+	if len(drops) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, drops)
+	}
+	for i, d := range drops {
+		if *d != *expected[i] {
+			t.Errorf("Expected %v, got %v", expected[i], d)
+		}
+	}
+}
+
+func TestOverloadShedAfterFilter(t *testing.T) {
+	s := &Sensor{eventMap: newSafeSubscriptionMap()}
+
+	var delivered int
+	subscr := newSubscription(s, 1, func(*api.TelemetryEvent) {
+		delivered++
+	})
+	subscr.priority = api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_LOW
+	filter := expression.Equal(
+		expression.Identifier("arg0"), expression.Value(uint64(1)))
+	if _, err := subscr.addDerivedEventSink(1, filter,
+		expression.FieldTypeMap{}, syscallEnterEventTypes); err != nil {
+		t.Fatal(err)
+	}
+	s.eventMap.subscribe(subscr)
+
+	newSample := func(arg0 uint64) perf.EventMonitorSample {
+		return perf.EventMonitorSample{
+			EventID: 1,
+			DecodedSample: &api.TelemetryEvent{
+				ProcessPid: 100,
+				Event: &api.TelemetryEvent_Syscall{
+					Syscall: &api.SyscallEvent{Id: 0, Arg0: arg0},
+				},
+			},
+			DecodedData: perf.TraceEventSampleData{"arg0": arg0},
+		}
+	}
+
+	// Events that the filter rejects are not counted as shed
+	s.dispatchQueuedSamples([]perf.EventMonitorSample{
+		newSample(2), newSample(1), newSample(2),
+	}, true)
+	if delivered != 0 {
+		t.Errorf("Expected no events delivered, got %d", delivered)
+	}
+	if subscr.shedEvents != 1 {
+		t.Errorf("Expected 1 shed event, got %d", subscr.shedEvents)
+	}
+	priority := api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_LOW
+	if n := s.Metrics.DroppedEvents[priority]; n != 1 {
+		t.Errorf("Expected 1 dropped event, got %d", n)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"sync"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+
+	"github.com/golang/glog"
+
+	"google.golang.org/genproto/googleapis/rpc/code"
+)
+
+const defaultEdgeTriggerMaxProcesses = 4096
+
+type edgeState struct {
+	value bool
+
+	// The sequence number of the event at which value was established,
+	// or 0 if it is the initial value.
+	sequenceNumber uint64
+}
+
+// edgeTrigger tracks the value of a subscription's predicate for each
+// process, so that only events that change it are dispatched. Values are
+// kept in a bounded LRU; a forgotten process is assumed not to satisfy the
+// predicate again.
+type edgeTrigger struct {
+	sync.Mutex
+
+	predicate *api.Expression
+	rising    bool
+	falling   bool
+	states    *keyedLRU
+}
+
+func newEdgeTrigger(et *api.EdgeTrigger) (*edgeTrigger, error) {
+	if et.Predicate == nil {
+		return nil, fmt.Errorf("EdgeTrigger requires a predicate")
+	}
+	if _, err := expression.NewExpression(et.Predicate); err != nil {
+		return nil, fmt.Errorf("Invalid EdgeTrigger predicate: %v", err)
+	}
+
+	maxProcesses := int(et.MaxProcesses)
+	if maxProcesses <= 0 {
+		maxProcesses = defaultEdgeTriggerMaxProcesses
+	}
+	t := &edgeTrigger{
+		predicate: et.Predicate,
+		rising:    et.Rising,
+		falling:   et.Falling,
+		states:    newKeyedLRU(maxProcesses),
+	}
+	if !t.rising && !t.falling {
+		t.rising, t.falling = true, true
+	}
+	return t, nil
+}
+
+// compile returns the predicate for events of an event sink and the types
+// of the fields that it may refer to, or nil if it refers to fields that the
+// sink's events do not have, in which case none of them are dispatched.
+// filterTypes and derivedTypes are the types of the fields of the sink's
+// events.
+func (t *edgeTrigger) compile(
+	subscr *subscription,
+	filterTypes expression.FieldTypeMap,
+	derivedTypes expression.FieldTypeMap,
+) (*expression.Expression, expression.FieldTypeMap) {
+	expr, types, err := compileEventSinkPredicate(t.predicate, nil,
+		filterTypes, derivedTypes)
+	if err != nil {
+		subscr.logStatus(
+			code.Code_INVALID_ARGUMENT,
+			fmt.Sprintf("EdgeTrigger predicate does not apply to %s events, which will not be delivered: %v",
+				subscr.eventType, err))
+		return nil, nil
+	}
+	return expr, types
+}
+
+// transition evaluates the predicate for an event and returns the change
+// in its value for the event's process, if any, the sequence number of the
+// event at which the previous value was established, and whether the change
+// should be dispatched.
+func (t *edgeTrigger) transition(
+	predicate *expression.Expression,
+	types expression.FieldTypeMap,
+	event *api.TelemetryEvent,
+	data expression.FieldValueMap,
+) (api.EdgeTransition, uint64, bool) {
+	if len(event.ProcessId) == 0 {
+		return api.EdgeTransition_EDGE_TRANSITION_NONE, 0, false
+	}
+
+	v, err := predicate.Evaluate(types, data)
+	if err != nil {
+		glog.V(1).Infof("EdgeTrigger predicate evaluation error: %s", err)
+		return api.EdgeTransition_EDGE_TRANSITION_NONE, 0, false
+	}
+	value := expression.IsValueTrue(v)
+
+	t.Lock()
+	s, ok := t.states.get(event.ProcessId).(*edgeState)
+	if !ok {
+		s = &edgeState{}
+		t.states.add(event.ProcessId, s)
+	}
+	changed := s.value != value
+	cause := s.sequenceNumber
+	if changed {
+		s.value = value
+		s.sequenceNumber = event.SensorSequenceNumber
+	}
+	t.Unlock()
+
+	switch {
+	case !changed:
+		return api.EdgeTransition_EDGE_TRANSITION_NONE, 0, false
+	case value:
+		return api.EdgeTransition_EDGE_TRANSITION_RISING, cause, t.rising
+	default:
+		return api.EdgeTransition_EDGE_TRANSITION_FALLING, cause, t.falling
+	}
+}
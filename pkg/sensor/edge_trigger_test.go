@@ -0,0 +1,111 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+)
+
+func TestEdgeTrigger(t *testing.T) {
+	if _, err := newEdgeTrigger(&api.EdgeTrigger{}); err == nil {
+		t.Error("Expected error for missing predicate")
+	}
+
+	et, err := newEdgeTrigger(&api.EdgeTrigger{
+		Predicate: expression.LogicalAnd(
+			expression.Equal(
+				expression.Identifier("task_euid"),
+				expression.Value(uint32(0))),
+			expression.IsNotNull(expression.Identifier("filename"))),
+		Rising:       true,
+		MaxProcesses: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := newSubscription(nil, 1, nil)
+	s.eventType = "file"
+	predicate, types := et.compile(s, fileOpenEventTypes, nil)
+	if predicate == nil {
+		t.Fatalf("Expected predicate to apply, got %v", s.takeStatus())
+	}
+	if p, _ := et.compile(s, nil, nil); p != nil {
+		t.Error("Expected predicate not to apply")
+	}
+
+	a := &api.TelemetryEvent{ProcessId: "a"}
+	b := &api.TelemetryEvent{ProcessId: "b"}
+	root := expression.FieldValueMap{
+		"task_euid": uint32(0),
+		"filename":  "/etc/shadow",
+	}
+	user := expression.FieldValueMap{
+		"task_euid": uint32(1000),
+		"filename":  "/etc/shadow",
+	}
+
+	for i, tc := range []struct {
+		event    *api.TelemetryEvent
+		data     expression.FieldValueMap
+		edge     api.EdgeTransition
+		dispatch bool
+	}{
+		{a, user, api.EdgeTransition_EDGE_TRANSITION_NONE, false},
+		{a, root, api.EdgeTransition_EDGE_TRANSITION_RISING, true},
+		{a, root, api.EdgeTransition_EDGE_TRANSITION_NONE, false},
+		{a, user, api.EdgeTransition_EDGE_TRANSITION_FALLING, false},
+		{a, root, api.EdgeTransition_EDGE_TRANSITION_RISING, true},
+
+		// Only one process is remembered, so a is forgotten
+		{b, user, api.EdgeTransition_EDGE_TRANSITION_NONE, false},
+		{a, root, api.EdgeTransition_EDGE_TRANSITION_RISING, true},
+
+		{&api.TelemetryEvent{}, root, api.EdgeTransition_EDGE_TRANSITION_NONE, false},
+	} {
+		edge, _, dispatch := et.transition(predicate, types, tc.event, tc.data)
+		if edge != tc.edge || dispatch != tc.dispatch {
+			t.Errorf("Event %d: expected %s %v, got %s %v", i,
+				tc.edge, tc.dispatch, edge, dispatch)
+		}
+	}
+
+	// Each transition is caused by the event that established the
+	// previous value, if any.
+	for i, tc := range []struct {
+		sequenceNumber uint64
+		data           expression.FieldValueMap
+		cause          uint64
+	}{
+		{10, root, 0},
+		{11, root, 0},
+		{12, user, 10},
+		{13, root, 12},
+	} {
+		e := &api.TelemetryEvent{
+			ProcessId:            "c",
+			SensorSequenceNumber: tc.sequenceNumber,
+		}
+		_, cause, _ := et.transition(predicate, types, e, tc.data)
+		if cause != tc.cause {
+			t.Errorf("Event %d: expected cause %d, got %d", i,
+				tc.cause, cause)
+		}
+	}
+}
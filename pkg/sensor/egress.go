@@ -0,0 +1,241 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+// egressPriorityWeights are the relative shares of the egress bandwidth given
+// to subscriptions of each priority when the bandwidth is contended.
+var egressPriorityWeights = map[api.SubscriptionPriority]float64{
+	api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_LOW:    1,
+	api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_NORMAL: 2,
+	api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_HIGH:   4,
+}
+
+// egressShaper limits the total rate of telemetry sent to all subscriptions,
+// in bytes of serialized events per second. A global token bucket holding up
+// to one second of bandwidth enforces the limit. Each subscription also has a
+// share of the bandwidth proportional to the weight of its priority. While
+// the global bucket is at least half full, any subscription may send; below
+// that, only subscriptions that have not used their share may, so that
+// subscriptions flooding events are shed before the others.
+type egressShaper struct {
+	// Updated atomically. These are the first fields so that they are
+	// 64-bit aligned.
+	sentBytes  uint64
+	shedEvents uint64
+	shedBytes  uint64
+
+	sync.Mutex
+
+	limit       float64 // bytes per second, or 0 for no limit
+	tokens      float64
+	last        time.Time
+	totalWeight float64
+
+	// The rate measured over the most recent complete second
+	windowStart time.Time
+	windowBytes uint64
+	rate        float64
+
+	now func() time.Time
+}
+
+// egressShare is a subscription's share of the egress bandwidth.
+type egressShare struct {
+	// Events not sent because of the egress limit. Updated atomically.
+	// It is the first field so that it is 64-bit aligned.
+	shedEvents uint64
+
+	shaper *egressShaper
+	weight float64
+	tokens float64
+	last   time.Time
+}
+
+func newEgressShaper(limit uint64) *egressShaper {
+	return &egressShaper{
+		limit:  float64(limit),
+		tokens: float64(limit),
+		now:    time.Now,
+	}
+}
+
+// setLimit changes the limit of the shaper. A limit of 0 disables it.
+func (e *egressShaper) setLimit(limit uint64) {
+	e.Lock()
+	if e.limit == 0 {
+		// The bucket starts full, as it does when the sensor starts
+		e.last = time.Time{}
+	}
+	e.limit = float64(limit)
+	if e.tokens > e.limit {
+		e.tokens = e.limit
+	}
+	e.Unlock()
+}
+
+// join creates the share of a new subscription with the specified priority.
+func (e *egressShaper) join(priority api.SubscriptionPriority) *egressShare {
+	if e == nil {
+		return nil
+	}
+	weight, ok := egressPriorityWeights[priority]
+	if !ok {
+		weight = 1
+	}
+	e.Lock()
+	e.totalWeight += weight
+	e.Unlock()
+	return &egressShare{
+		shaper: e,
+		weight: weight,
+	}
+}
+
+// leave removes a subscription's share once it has ended.
+func (sh *egressShare) leave() {
+	if sh == nil {
+		return
+	}
+	e := sh.shaper
+	e.Lock()
+	e.totalWeight -= sh.weight
+	if e.totalWeight < 0 {
+		e.totalWeight = 0
+	}
+	e.Unlock()
+}
+
+// refill adds the tokens accrued since last to a bucket with the specified
+// rate, holding up to one second of tokens. A bucket that has never been
+// refilled starts full.
+func refill(tokens *float64, last *time.Time, rate float64, now time.Time) {
+	if last.IsZero() {
+		*tokens = rate
+	} else {
+		*tokens += rate * now.Sub(*last).Seconds()
+	}
+	if *tokens > rate {
+		*tokens = rate
+	}
+	*last = now
+}
+
+// hasTokens returns whether a bucket holding up to capacity tokens has the
+// tokens for an event. Events larger than the bucket are allowed when it is
+// full, leaving it in debt.
+func hasTokens(tokens, capacity, size float64) bool {
+	if size > capacity {
+		size = capacity
+	}
+	return tokens >= size
+}
+
+// admit returns whether an event of the specified size in bytes may be sent
+// to the subscription, and records it as sent if so.
+func (sh *egressShare) admit(size int) bool {
+	if sh == nil {
+		return true
+	}
+	e := sh.shaper
+	n := float64(size)
+
+	e.Lock()
+	now := e.now()
+	ok := true
+	if e.limit > 0 {
+		refill(&e.tokens, &e.last, e.limit, now)
+		shareRate := e.limit
+		if e.totalWeight > 0 {
+			shareRate = e.limit * sh.weight / e.totalWeight
+		}
+		refill(&sh.tokens, &sh.last, shareRate, now)
+
+		ok = hasTokens(e.tokens, e.limit, n) &&
+			(e.tokens >= e.limit/2 ||
+				hasTokens(sh.tokens, shareRate, n))
+		if ok {
+			e.tokens -= n
+			sh.tokens -= n
+			if sh.tokens < 0 {
+				sh.tokens = 0
+			}
+		}
+	}
+	if ok {
+		e.record(uint64(size), now)
+	}
+	e.Unlock()
+
+	if ok {
+		atomic.AddUint64(&e.sentBytes, uint64(size))
+	} else {
+		atomic.AddUint64(&sh.shedEvents, 1)
+		atomic.AddUint64(&e.shedEvents, 1)
+		atomic.AddUint64(&e.shedBytes, uint64(size))
+	}
+	return ok
+}
+
+// record adds bytes sent to the rate measurement. The caller must hold the
+// lock.
+func (e *egressShaper) record(size uint64, now time.Time) {
+	if e.windowStart.IsZero() {
+		e.windowStart = now
+	}
+	if elapsed := now.Sub(e.windowStart); elapsed >= time.Second {
+		e.rate = float64(e.windowBytes) / elapsed.Seconds()
+		e.windowStart = now
+		e.windowBytes = 0
+	}
+	e.windowBytes += size
+}
+
+// statistics returns the current state of the shaper.
+func (e *egressShaper) statistics() *api.EgressStatistics {
+	e.Lock()
+	rate := e.rate
+	if !e.windowStart.IsZero() &&
+		e.now().Sub(e.windowStart) >= 2*time.Second {
+		// Nothing has been sent for over a second
+		rate = 0
+	}
+	limit := e.limit
+	e.Unlock()
+
+	return &api.EgressStatistics{
+		LimitBytesPerSecond: uint64(limit),
+		RateBytesPerSecond:  rate,
+		SentBytes:           atomic.LoadUint64(&e.sentBytes),
+		ShedEvents:          atomic.LoadUint64(&e.shedEvents),
+		ShedBytes:           atomic.LoadUint64(&e.shedBytes),
+	}
+}
+
+// EgressStatistics returns the rate of telemetry sent to subscriptions and
+// the events shed by the egress limit.
+func (s *Sensor) EgressStatistics() *api.EgressStatistics {
+	if s.egress == nil {
+		return nil
+	}
+	return s.egress.statistics()
+}
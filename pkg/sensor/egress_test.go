@@ -0,0 +1,191 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+func newTestEgressShaper(limit uint64) (*egressShaper, *time.Time) {
+	now := time.Unix(1000, 0)
+	e := newEgressShaper(limit)
+	e.now = func() time.Time { return now }
+	return e, &now
+}
+
+func TestEgressShaperUnlimited(t *testing.T) {
+	e, _ := newTestEgressShaper(0)
+	sh := e.join(api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_NORMAL)
+	for i := 0; i < 1000; i++ {
+		if !sh.admit(1000) {
+			t.Fatalf("event %d shed with no limit", i)
+		}
+	}
+	stats := e.statistics()
+	if stats.SentBytes != 1000000 || stats.ShedEvents != 0 {
+		t.Errorf("unexpected statistics %+v", stats)
+	}
+
+	var nilShare *egressShare
+	if !nilShare.admit(1000) {
+		t.Error("nil share shed an event")
+	}
+}
+
+func TestEgressShaperLimit(t *testing.T) {
+	e, now := newTestEgressShaper(1000)
+	sh := e.join(api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_NORMAL)
+
+	sent := 0
+	for i := 0; i < 20; i++ {
+		if sh.admit(100) {
+			sent++
+		}
+	}
+	if sent != 10 {
+		t.Errorf("sent %d events; expected 10", sent)
+	}
+
+	*now = now.Add(500 * time.Millisecond)
+	sent = 0
+	for i := 0; i < 20; i++ {
+		if sh.admit(100) {
+			sent++
+		}
+	}
+	if sent != 5 {
+		t.Errorf("sent %d events after refill; expected 5", sent)
+	}
+
+	stats := e.statistics()
+	if stats.LimitBytesPerSecond != 1000 || stats.SentBytes != 1500 ||
+		stats.ShedEvents != 25 || stats.ShedBytes != 2500 {
+		t.Errorf("unexpected statistics %+v", stats)
+	}
+	if sh.shedEvents != 25 {
+		t.Errorf("share shed %d events; expected 25", sh.shedEvents)
+	}
+}
+
+func TestEgressShaperLargeEvent(t *testing.T) {
+	e, now := newTestEgressShaper(1000)
+	sh := e.join(api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_NORMAL)
+
+	if !sh.admit(3000) {
+		t.Fatal("event larger than the limit shed with a full bucket")
+	}
+	*now = now.Add(time.Second)
+	if sh.admit(100) {
+		t.Error("event sent while the bucket is in debt")
+	}
+	*now = now.Add(2 * time.Second)
+	if !sh.admit(100) {
+		t.Error("event shed after the debt was repaid")
+	}
+}
+
+func TestEgressShaperPriority(t *testing.T) {
+	e, now := newTestEgressShaper(7000)
+	low := e.join(api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_LOW)
+	high := e.join(api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_HIGH)
+	normal := e.join(api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_NORMAL)
+
+	// Drain the global bucket below half so that the shares apply, then
+	// let each subscription flood events for a while.
+	for low.admit(100) {
+	}
+	counts := make(map[*egressShare]int)
+	for i := 0; i < 100; i++ {
+		*now = now.Add(100 * time.Millisecond)
+		for _, sh := range []*egressShare{low, normal, high} {
+			for j := 0; j < 20; j++ {
+				if sh.admit(100) {
+					counts[sh]++
+				}
+			}
+		}
+	}
+	if counts[high] <= counts[normal] || counts[normal] <= counts[low] {
+		t.Errorf("events not shared by priority: low %d normal %d high %d",
+			counts[low], counts[normal], counts[high])
+	}
+
+	high.leave()
+	normal.leave()
+	low.leave()
+	if e.totalWeight != 0 {
+		t.Errorf("total weight %v after all shares left", e.totalWeight)
+	}
+}
+
+func TestEgressInvalidSubscription(t *testing.T) {
+	e, _ := newTestEgressShaper(0)
+	s := &Sensor{egress: e}
+	for _, sub := range []*api.Subscription{
+		{
+			EventFilter: &api.EventFilter{},
+			EdgeTrigger: &api.EdgeTrigger{},
+		},
+		{
+			EventFilter:      &api.EventFilter{},
+			FailureThreshold: &api.FailureThreshold{},
+		},
+		{
+			EventFilter: &api.EventFilter{},
+			PidFilter: &api.PidFilter{
+				Pids:              []int32{1},
+				FalsePositiveRate: 2,
+			},
+		},
+	} {
+		_, _, err := s.createSubscription(context.Background(), sub,
+			nil, nil)
+		if err == nil {
+			t.Fatalf("Invalid subscription %+v accepted", sub)
+		}
+		if e.totalWeight != 0 {
+			t.Errorf("total weight %v after invalid subscription",
+				e.totalWeight)
+		}
+	}
+}
+
+func TestEgressShaperSetLimit(t *testing.T) {
+	e, now := newTestEgressShaper(0)
+	sh := e.join(api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_NORMAL)
+	sh.admit(5000)
+
+	e.setLimit(1000)
+	if !sh.admit(1000) {
+		t.Error("event shed after the limit was first set")
+	}
+	if sh.admit(100) {
+		t.Error("event sent over the new limit")
+	}
+
+	e.setLimit(0)
+	if !sh.admit(100000) {
+		t.Error("event shed after the limit was removed")
+	}
+
+	*now = now.Add(3 * time.Second)
+	if rate := e.statistics().RateBytesPerSecond; rate != 0 {
+		t.Errorf("rate %v after sending nothing; expected 0", rate)
+	}
+}
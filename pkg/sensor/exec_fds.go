@@ -0,0 +1,77 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/config"
+	"github.com/capsule8/capsule8/pkg/sys/proc"
+
+	"github.com/golang/glog"
+)
+
+// execFdSnapshotMaxFds returns the number of file descriptors to capture for
+// an exec filter requesting requested of them, given the number already
+// requested by other filters of the subscription.
+func execFdSnapshotMaxFds(maxFds int, requested uint32) int {
+	limit := config.Sensor.ExecFdSnapshotMaxFds
+	n := int(requested)
+	if n == 0 || n > limit {
+		n = limit
+	}
+	if n > maxFds {
+		return n
+	}
+	return maxFds
+}
+
+// newExecFdSnapshotter returns a dispatch function that captures the open file
+// descriptors of the process of each exec event before passing it to
+// dispatchFn. At most maxFds descriptors are read for each event.
+func newExecFdSnapshotter(
+	readFds func(pid, max int) ([]proc.FileDescriptor, bool, error),
+	maxFds int,
+	dispatchFn eventSinkDispatchFn,
+) eventSinkDispatchFn {
+	return func(e *api.TelemetryEvent) {
+		pid := e.ProcessTgid
+		if pid == 0 {
+			pid = e.ProcessPid
+		}
+		fds, truncated, err := readFds(int(pid), maxFds)
+		if err != nil {
+			// The process has most likely exited
+			glog.V(2).Infof("Couldn't read file descriptors of pid %d: %v",
+				pid, err)
+		}
+
+		// The event is shared with other subscriptions, so the
+		// snapshot is added to a copy.
+		e = copyTelemetryEvent(e)
+		pev := e.GetProcess()
+		pev.ExecFdsTruncated = truncated
+		if len(fds) > 0 {
+			pev.ExecFds = make([]*api.FileDescriptor, len(fds))
+			for i, fd := range fds {
+				pev.ExecFds[i] = &api.FileDescriptor{
+					Fd:   int32(fd.FD),
+					Path: fd.Path,
+				}
+			}
+		}
+		dispatchFn(e)
+	}
+}
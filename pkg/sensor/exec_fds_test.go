@@ -0,0 +1,104 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"errors"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/config"
+	"github.com/capsule8/capsule8/pkg/sys/proc"
+)
+
+func TestExecFdSnapshotMaxFds(t *testing.T) {
+	saved := config.Sensor.ExecFdSnapshotMaxFds
+	defer func() { config.Sensor.ExecFdSnapshotMaxFds = saved }()
+	config.Sensor.ExecFdSnapshotMaxFds = 100
+
+	tests := []struct {
+		maxFds    int
+		requested uint32
+		want      int
+	}{
+		{0, 0, 100},
+		{0, 10, 10},
+		{0, 1000, 100},
+		{50, 10, 50},
+		{10, 50, 50},
+	}
+	for _, tc := range tests {
+		if got := execFdSnapshotMaxFds(tc.maxFds, tc.requested); got != tc.want {
+			t.Errorf("execFdSnapshotMaxFds(%d, %d) = %d, expected %d",
+				tc.maxFds, tc.requested, got, tc.want)
+		}
+	}
+}
+
+func TestExecFdSnapshotter(t *testing.T) {
+	var (
+		events  []*api.TelemetryEvent
+		readPid int
+		readMax int
+		readErr error
+	)
+	dispatchFn := newExecFdSnapshotter(
+		func(pid, max int) ([]proc.FileDescriptor, bool, error) {
+			readPid, readMax = pid, max
+			if readErr != nil {
+				return nil, false, readErr
+			}
+			return []proc.FileDescriptor{
+				{FD: 0, Path: "/dev/null"},
+				{FD: 3, Path: "socket:[1234]"},
+			}, true, nil
+		},
+		2,
+		func(e *api.TelemetryEvent) { events = append(events, e) })
+
+	shared := &api.TelemetryEvent{
+		ProcessPid:  11,
+		ProcessTgid: 10,
+		Event: &api.TelemetryEvent_Process{
+			Process: &api.ProcessEvent{
+				Type: api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC,
+			},
+		},
+	}
+	dispatchFn(shared)
+	if readPid != 10 || readMax != 2 {
+		t.Errorf("Expected fds of pid 10 (max 2), read pid %d (max %d)",
+			readPid, readMax)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+	pev := events[0].GetProcess()
+	if len(pev.ExecFds) != 2 || pev.ExecFds[1].Fd != 3 ||
+		pev.ExecFds[1].Path != "socket:[1234]" || !pev.ExecFdsTruncated {
+		t.Errorf("Unexpected snapshot %v", pev)
+	}
+	if p := shared.GetProcess(); len(p.ExecFds) != 0 || p.ExecFdsTruncated {
+		t.Errorf("Shared event was modified: %v", p)
+	}
+
+	// The event is still delivered if the process has exited
+	readErr = errors.New("no such process")
+	dispatchFn(shared)
+	if len(events) != 2 || len(events[1].GetProcess().ExecFds) != 0 {
+		t.Errorf("Expected event without snapshot, got %v", events[1:])
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+
+	"github.com/golang/glog"
+
+	"google.golang.org/genproto/googleapis/rpc/code"
+)
+
+const defaultFailureThresholdMaxKeys = 4096
+
+// failureState is the consecutive failures of one key.
+type failureState struct {
+	count uint32
+
+	// The monotime and sequence number of the first failure counted
+	firstSeen      int64
+	sequenceNumber uint64
+}
+
+// failureThreshold counts the consecutive failures of each key of a
+// subscription's events, so that only the event at which the count reaches
+// the threshold is dispatched. Counts are kept in a bounded LRU; a forgotten
+// key starts counting again.
+type failureThreshold struct {
+	sync.Mutex
+
+	failure   *api.Expression
+	key       api.FailureThreshold_Key
+	keyFields []string
+	threshold uint32
+	window    int64
+	states    *keyedLRU
+}
+
+func newFailureThreshold(ft *api.FailureThreshold) (*failureThreshold, error) {
+	if _, ok := api.FailureThreshold_Key_name[int32(ft.Key)]; !ok {
+		return nil, errors.New("Invalid FailureThreshold key")
+	}
+	if ft.Threshold == 0 {
+		return nil, errors.New("FailureThreshold requires threshold")
+	}
+	if ft.WindowSeconds == 0 {
+		return nil, errors.New("FailureThreshold requires window_seconds")
+	}
+	failure := ft.Failure
+	if failure == nil {
+		failure = expression.LessThan(
+			expression.Identifier("ret"),
+			expression.Value(int64(0)))
+	}
+	if _, err := expression.NewExpression(failure); err != nil {
+		return nil, fmt.Errorf("Invalid FailureThreshold failure: %v", err)
+	}
+
+	maxKeys := int(ft.MaxKeys)
+	if maxKeys <= 0 {
+		maxKeys = defaultFailureThresholdMaxKeys
+	}
+	return &failureThreshold{
+		failure:   failure,
+		key:       ft.Key,
+		keyFields: ft.KeyFields,
+		threshold: ft.Threshold,
+		window:    int64(time.Duration(ft.WindowSeconds) * time.Second),
+		states:    newKeyedLRU(maxKeys),
+	}, nil
+}
+
+// compile returns the failure predicate for events of an event sink and the
+// types of the fields that it and the key fields may refer to, or nil if
+// either refers to fields that the sink's events do not have, in which case
+// none of them are dispatched. filterTypes and derivedTypes are the types of
+// the fields of the sink's events.
+func (t *failureThreshold) compile(
+	subscr *subscription,
+	filterTypes expression.FieldTypeMap,
+	derivedTypes expression.FieldTypeMap,
+) (*expression.Expression, expression.FieldTypeMap) {
+	expr, types, err := compileEventSinkPredicate(t.failure, t.keyFields,
+		filterTypes, derivedTypes)
+	if err != nil {
+		subscr.logStatus(
+			code.Code_INVALID_ARGUMENT,
+			fmt.Sprintf("FailureThreshold does not apply to %s events, which will not be delivered: %v",
+				subscr.eventType, err))
+		return nil, nil
+	}
+	return expr, types
+}
+
+// eventKey returns the key of an event, or "" if it has none.
+func (t *failureThreshold) eventKey(
+	event *api.TelemetryEvent,
+	data expression.FieldValueMap,
+) string {
+	var key string
+	switch t.key {
+	case api.FailureThreshold_CONTAINER:
+		key = event.ContainerId
+	default:
+		key = event.ProcessId
+	}
+	if key == "" || len(t.keyFields) == 0 {
+		return key
+	}
+
+	parts := make([]string, 0, len(t.keyFields)+1)
+	parts = append(parts, key)
+	for _, f := range t.keyFields {
+		v, ok := data[f]
+		if !ok {
+			return ""
+		}
+		parts = append(parts, fmt.Sprint(v))
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// observe evaluates the failure predicate for an event and counts it for
+// the event's key. It returns the count, the sequence number of the first
+// failure counted, and whether the event should be dispatched, which is
+// only when the count reaches the threshold.
+func (t *failureThreshold) observe(
+	predicate *expression.Expression,
+	types expression.FieldTypeMap,
+	event *api.TelemetryEvent,
+	data expression.FieldValueMap,
+) (uint32, uint64, bool) {
+	key := t.eventKey(event, data)
+	if key == "" {
+		return 0, 0, false
+	}
+
+	v, err := predicate.Evaluate(types, data)
+	if err != nil {
+		glog.V(1).Infof("FailureThreshold predicate evaluation error: %s", err)
+		return 0, 0, false
+	}
+	failed := expression.IsValueTrue(v)
+
+	t.Lock()
+	defer t.Unlock()
+
+	if !failed {
+		t.states.remove(key)
+		return 0, 0, false
+	}
+
+	s, ok := t.states.get(key).(*failureState)
+	if !ok {
+		s = &failureState{}
+		t.states.add(key, s)
+	} else if event.SensorMonotimeNanos-s.firstSeen > t.window {
+		s.count = 0
+	}
+	if s.count == 0 {
+		s.firstSeen = event.SensorMonotimeNanos
+		s.sequenceNumber = event.SensorSequenceNumber
+	}
+	if s.count < t.threshold {
+		s.count++
+		if s.count == t.threshold {
+			return s.count, s.sequenceNumber, true
+		}
+	}
+	return 0, 0, false
+}
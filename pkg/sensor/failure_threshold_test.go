@@ -0,0 +1,175 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func TestNewFailureThreshold(t *testing.T) {
+	for _, ft := range []*api.FailureThreshold{
+		{WindowSeconds: 60},
+		{Threshold: 3},
+		{Key: api.FailureThreshold_Key(99), Threshold: 3, WindowSeconds: 60},
+		{
+			Threshold:     3,
+			WindowSeconds: 60,
+			Failure:       &api.Expression{},
+		},
+	} {
+		if _, err := newFailureThreshold(ft); err == nil {
+			t.Errorf("Expected error for %v", ft)
+		}
+	}
+
+	ft, err := newFailureThreshold(&api.FailureThreshold{
+		Threshold:     3,
+		WindowSeconds: 60,
+		KeyFields:     []string{"filename"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := newSubscription(nil, 1, nil)
+	s.eventType = "syscall"
+	if p, _ := ft.compile(s, syscallExitEventTypes, nil); p != nil {
+		t.Error("Expected key fields not to apply")
+	}
+	if p, _ := ft.compile(s, fileOpenEventTypes, nil); p != nil {
+		t.Error("Expected default failure predicate not to apply")
+	}
+	if len(s.takeStatus()) != 2 {
+		t.Error("Expected status for each event type that does not apply")
+	}
+}
+
+func TestFailureThreshold(t *testing.T) {
+	ft, err := newFailureThreshold(&api.FailureThreshold{
+		Threshold:     3,
+		WindowSeconds: 10,
+		MaxKeys:       1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := newSubscription(nil, 1, nil)
+	s.eventType = "syscall"
+	predicate, types := ft.compile(s, syscallExitEventTypes, nil)
+	if predicate == nil {
+		t.Fatalf("Expected predicate to apply, got %v", s.takeStatus())
+	}
+
+	failed := expression.FieldValueMap{"id": int64(2), "ret": int64(-13)}
+	succeeded := expression.FieldValueMap{"id": int64(2), "ret": int64(3)}
+	second := int64(time.Second)
+
+	for i, tc := range []struct {
+		processID string
+		time      int64
+		data      expression.FieldValueMap
+		count     uint32
+		cause     uint64
+	}{
+		{"a", 0, failed, 0, 0},
+		{"a", 1, failed, 0, 0},
+		{"a", 2, failed, 3, 1},
+
+		// Only a single alert is sent for the count
+		{"a", 3, failed, 0, 0},
+
+		// A success resets the count
+		{"a", 4, succeeded, 0, 0},
+		{"a", 5, failed, 0, 0},
+		{"a", 6, failed, 0, 0},
+		{"a", 7, failed, 3, 6},
+
+		// Failures outside of the window start a new count
+		{"a", 18, failed, 0, 0},
+		{"a", 19, failed, 0, 0},
+		{"a", 29, failed, 0, 0},
+		{"a", 30, failed, 0, 0},
+		{"a", 31, failed, 3, 11},
+
+		// Only one key is remembered, so a is forgotten
+		{"b", 32, failed, 0, 0},
+		{"a", 33, failed, 0, 0},
+		{"a", 34, failed, 0, 0},
+		{"b", 35, failed, 0, 0},
+		{"b", 36, failed, 0, 0},
+
+		// Events without a key are ignored
+		{"", 37, failed, 0, 0},
+	} {
+		e := &api.TelemetryEvent{
+			ProcessId:            tc.processID,
+			SensorMonotimeNanos:  tc.time * second,
+			SensorSequenceNumber: uint64(i + 1),
+		}
+		count, cause, ok := ft.observe(predicate, types, e, tc.data)
+		if count != tc.count || cause != tc.cause ||
+			ok != (tc.count != 0) {
+			t.Errorf("Event %d: expected %d %d, got %d %d %v", i,
+				tc.count, tc.cause, count, cause, ok)
+		}
+	}
+}
+
+func TestFailureThresholdInapplicableSink(t *testing.T) {
+	s := &Sensor{eventMap: newSafeSubscriptionMap()}
+
+	var delivered int
+	subscr := newSubscription(s, 1, func(*api.TelemetryEvent) {
+		delivered++
+	})
+	subscr.eventType = "file"
+	subscr.failureThreshold, _ = newFailureThreshold(&api.FailureThreshold{
+		Threshold:     1,
+		WindowSeconds: 60,
+	})
+	es, err := subscr.addEventSink(1, nil, fileOpenEventTypes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !es.triggerInapplicable {
+		t.Fatal("Expected default failure predicate not to apply")
+	}
+	s.eventMap.subscribe(subscr)
+
+	s.dispatchQueuedSamples([]perf.EventMonitorSample{
+		{
+			EventID: 1,
+			DecodedSample: &api.TelemetryEvent{
+				ProcessId: "a",
+				Event: &api.TelemetryEvent_File{
+					File: &api.FileEvent{Filename: "/etc/shadow"},
+				},
+			},
+			DecodedData: perf.TraceEventSampleData{
+				"filename": "/etc/shadow",
+			},
+		},
+	}, false)
+	if delivered != 0 {
+		t.Errorf("Expected no events delivered, got %d", delivered)
+	}
+}
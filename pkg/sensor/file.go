@@ -125,6 +125,7 @@ func registerFileEvents(
 	if wildcard == false && filter == nil {
 		return
 	}
+	filter = sensor.applyDefaultFilter(DefaultFilterFile, filter)
 
 	f := fileOpenFilter{
 		sensor: sensor,
@@ -133,6 +134,7 @@ func registerFileEvents(
 	eventID, err := sensor.RegisterKprobe(
 		fsDoSysOpenKprobeAddress, false,
 		fsDoSysOpenKprobeFetchargs, f.decodeDoSysOpen,
+		perf.WithEventAttr(subscr.eventAttr),
 		perf.WithEventGroup(subscr.eventGroupID))
 	if err != nil {
 		subscr.logStatus(
@@ -0,0 +1,441 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/config"
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"google.golang.org/genproto/googleapis/rpc/code"
+)
+
+// The x86_64 system call numbers used to detect fileless execution
+const (
+	syscallExecveID      = 59
+	syscallMemfdCreateID = 319
+	syscallExecveatID    = 322
+)
+
+// The number of ancestors of an executing process that are searched for the
+// memory file that it executes, since file descriptors are inherited
+const filelessExecutionMaxAncestors = 4
+
+// memfdNamePathMask returns the mask of the string arguments to capture so
+// that the names passed to memfd_create are reported, if it is among ids.
+func memfdNamePathMask(ids []int64) uint8 {
+	for _, id := range ids {
+		if id == syscallMemfdCreateID {
+			return 1
+		}
+	}
+	return 0
+}
+
+// setMemfdName sets the name passed to a memfd_create system call.
+func setMemfdName(syscall *api.SyscallEvent, data perf.TraceEventSampleData) {
+	if name, ok := data["path0"].(string); ok {
+		syscall.MemfdName = name
+		data["memfd_name"] = name
+	}
+}
+
+// The prefixes of paths that refer to a file descriptor of the process that
+// resolves them
+var selfFDPathPrefixes = []string{
+	"/proc/self/fd/",
+	"/proc/thread-self/fd/",
+	"/dev/fd/",
+}
+
+// parseMemfdExecPath returns the memory file referred to by an executed path:
+// either the pid of the process that owns a file descriptor (0 for the
+// executing process) and the descriptor, or the name of a memory file given
+// by a "/memfd:" path, in which case fd is -1. ok is false if the path can't
+// refer to a memory file.
+func parseMemfdExecPath(path string) (pid, fd int32, name string, ok bool) {
+	if strings.HasPrefix(path, "/memfd:") {
+		name = strings.TrimSuffix(path[len("/memfd:"):], " (deleted)")
+		return 0, -1, name, true
+	}
+
+	var rest string
+	for _, prefix := range selfFDPathPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			rest = path[len(prefix):]
+			break
+		}
+	}
+	if rest == "" && strings.HasPrefix(path, "/proc/") {
+		parts := strings.SplitN(path[len("/proc/"):], "/", 3)
+		if len(parts) != 3 || parts[1] != "fd" {
+			return 0, 0, "", false
+		}
+		p, err := strconv.ParseInt(parts[0], 10, 32)
+		if err != nil {
+			return 0, 0, "", false
+		}
+		pid, rest = int32(p), parts[2]
+	}
+	n, err := strconv.ParseInt(rest, 10, 32)
+	if err != nil || n < 0 {
+		return 0, 0, "", false
+	}
+	return pid, int32(n), "", true
+}
+
+// memfdRecord is a memory file created by memfd_create.
+type memfdRecord struct {
+	name           string
+	pid            int32
+	monotime       int64
+	sequenceNumber uint64
+}
+
+// filelessExecutionTracker correlates the memory files created by the
+// processes of a subscription with their later execution.
+type filelessExecutionTracker struct {
+	sync.Mutex
+
+	dispatchFn eventSinkDispatchFn
+	newEvent   func() *api.TelemetryEvent
+
+	// Returns the tgid of the parent of a process, or 0 if it is not
+	// known
+	parentTgid func(tgid int32) int32
+
+	emitRaw, emitDerived bool
+
+	// If true, derived events carry the sequence numbers of the
+	// memfd_create and exec events that they were derived from.
+	causedBy bool
+
+	// memfd_create enter events awaiting their exit, keyed by pid
+	pending map[int32]*api.TelemetryEvent
+
+	// The memory files created by each process, keyed by tgid and then by
+	// file descriptor
+	memfds map[int32]map[int32]*memfdRecord
+
+	stopChan chan struct{}
+}
+
+func newFilelessExecutionTracker(
+	dispatchFn eventSinkDispatchFn,
+	newEvent func() *api.TelemetryEvent,
+	parentTgid func(int32) int32,
+	output api.FilelessExecutionOutput,
+) *filelessExecutionTracker {
+	return &filelessExecutionTracker{
+		dispatchFn: dispatchFn,
+		newEvent:   newEvent,
+		parentTgid: parentTgid,
+		emitRaw: output == api.FilelessExecutionOutput_FILELESS_EXECUTION_OUTPUT_RAW ||
+			output == api.FilelessExecutionOutput_FILELESS_EXECUTION_OUTPUT_BOTH,
+		emitDerived: output != api.FilelessExecutionOutput_FILELESS_EXECUTION_OUTPUT_RAW,
+		pending:     make(map[int32]*api.TelemetryEvent),
+		memfds:      make(map[int32]map[int32]*memfdRecord),
+	}
+}
+
+func (t *filelessExecutionTracker) enter(e *api.TelemetryEvent) {
+	syscall := e.GetSyscall()
+	switch syscall.Id {
+	case syscallMemfdCreateID:
+		// The decoded event may be seen by other event sinks, so
+		// make a private copy that can be completed by the exit.
+		e = copyTelemetryEvent(e)
+		t.Lock()
+		t.pending[e.ProcessPid] = e
+		t.Unlock()
+	case syscallExecveID, syscallExecveatID:
+		t.exec(e, syscall)
+	}
+}
+
+func (t *filelessExecutionTracker) exit(e *api.TelemetryEvent) {
+	exit := e.GetSyscall()
+
+	t.Lock()
+	ce, ok := t.pending[e.ProcessPid]
+	if !ok || ce.GetSyscall().Id != exit.Id {
+		t.Unlock()
+		return
+	}
+	delete(t.pending, e.ProcessPid)
+	syscall := ce.GetSyscall()
+	if exit.Ret >= 0 {
+		fds, ok := t.memfds[ce.ProcessTgid]
+		if !ok {
+			fds = make(map[int32]*memfdRecord)
+			t.memfds[ce.ProcessTgid] = fds
+		}
+		fds[int32(exit.Ret)] = &memfdRecord{
+			name:           syscall.MemfdName,
+			pid:            ce.ProcessPid,
+			monotime:       ce.SensorMonotimeNanos,
+			sequenceNumber: ce.SensorSequenceNumber,
+		}
+	}
+	t.Unlock()
+
+	if t.emitRaw {
+		syscall.Type = api.SyscallEventType_SYSCALL_EVENT_TYPE_COMPLETE
+		syscall.Ret = exit.Ret
+		syscall.DurationNanos = e.SensorMonotimeNanos - ce.SensorMonotimeNanos
+		if t.causedBy {
+			ce.CausedBy = []uint64{
+				ce.SensorSequenceNumber,
+				e.SensorSequenceNumber,
+			}
+		}
+		t.dispatchFn(ce)
+	}
+}
+
+// lookup finds the memory file with a file descriptor in a process or its
+// ancestors.
+func (t *filelessExecutionTracker) lookup(tgid, fd int32) *memfdRecord {
+	t.Lock()
+	defer t.Unlock()
+	for i := 0; i <= filelessExecutionMaxAncestors && tgid > 0; i++ {
+		if r, ok := t.memfds[tgid][fd]; ok {
+			return r
+		}
+		tgid = t.parentTgid(tgid)
+	}
+	return nil
+}
+
+// exec checks whether an execve or execveat system call executes a memory
+// file.
+func (t *filelessExecutionTracker) exec(
+	e *api.TelemetryEvent,
+	syscall *api.SyscallEvent,
+) {
+	var (
+		pid, fd int32
+		name    string
+		ok      bool
+	)
+	if syscall.Id == syscallExecveatID && syscall.Path == "" {
+		// execveat(fd, "", ..., AT_EMPTY_PATH), as used by fexecve
+		if len(syscall.ArgStatus) == 0 ||
+			syscall.ArgStatus[0] != api.SyscallArgStatus_SYSCALL_ARG_STATUS_CAPTURED {
+			return
+		}
+		fd, ok = int32(syscall.Arg0), true
+	} else {
+		pid, fd, name, ok = parseMemfdExecPath(syscall.Path)
+	}
+	if !ok {
+		return
+	}
+
+	var r *memfdRecord
+	if fd >= 0 {
+		if pid == 0 {
+			r = t.lookup(e.ProcessTgid, fd)
+		} else {
+			r = t.lookup(pid, fd)
+		}
+		if r == nil {
+			// The descriptor may be an ordinary file
+			return
+		}
+		name = r.name
+	}
+
+	if t.emitRaw {
+		t.dispatchFn(e)
+	}
+	if !t.emitDerived {
+		return
+	}
+
+	// The derived event belongs to the process that executed the
+	// memory file, but is a new event.
+	d := *e
+	n := t.newEvent()
+	d.Id = n.Id
+	d.SensorSequenceNumber = n.SensorSequenceNumber
+	d.CausedBy = nil
+	fe := &api.FilelessExecutionEvent{
+		MemfdName:     name,
+		MemfdFd:       fd,
+		ExecSyscallId: syscall.Id,
+		ExecPath:      syscall.Path,
+	}
+	if r != nil {
+		fe.MemfdCreatePid = r.pid
+		fe.MemfdCreateMonotimeNanos = r.monotime
+	}
+	if t.causedBy {
+		if r != nil {
+			d.CausedBy = append(d.CausedBy, r.sequenceNumber)
+		}
+		d.CausedBy = append(d.CausedBy, e.SensorSequenceNumber)
+	}
+	d.Event = &api.TelemetryEvent_FilelessExecution{
+		FilelessExecution: fe,
+	}
+	t.dispatchFn(&d)
+}
+
+// expire forgets the memory files created, and the memfd_create calls
+// entered, before the specified monotime.
+func (t *filelessExecutionTracker) expire(before int64) {
+	t.Lock()
+	for pid, e := range t.pending {
+		if e.SensorMonotimeNanos < before {
+			delete(t.pending, pid)
+		}
+	}
+	for tgid, fds := range t.memfds {
+		for fd, r := range fds {
+			if r.monotime < before {
+				delete(fds, fd)
+			}
+		}
+		if len(fds) == 0 {
+			delete(t.memfds, tgid)
+		}
+	}
+	t.Unlock()
+}
+
+func (t *filelessExecutionTracker) start(sensor *Sensor, window time.Duration) {
+	if window <= 0 {
+		return
+	}
+	t.stopChan = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(window)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-t.stopChan:
+				return
+			case <-ticker.C:
+				now := sys.CurrentMonotonicRaw() - sensor.bootMonotimeNanos
+				t.expire(now - int64(window))
+			}
+		}
+	}()
+}
+
+func (t *filelessExecutionTracker) stop() {
+	if t.stopChan != nil {
+		close(t.stopChan)
+	}
+}
+
+func syscallIDsExpression(ids ...int64) (expr *api.Expression) {
+	for _, id := range ids {
+		expr = expression.LogicalOr(expr, expression.Equal(
+			expression.Identifier("id"), expression.Value(id)))
+	}
+	return
+}
+
+func registerFilelessExecutionEvents(
+	sensor *Sensor,
+	subscr *subscription,
+	events []*api.FilelessExecutionFilter,
+) {
+	if len(events) == 0 {
+		return
+	}
+	if !sensor.syscallTablesSupported() {
+		subscr.logStatus(
+			code.Code_UNIMPLEMENTED,
+			fmt.Sprintf("Fileless execution events are not supported on %s",
+				sensor.machineArch))
+		return
+	}
+	var raw, derived bool
+	for _, fef := range events {
+		switch fef.Output {
+		case api.FilelessExecutionOutput_FILELESS_EXECUTION_OUTPUT_DERIVED:
+			derived = true
+		case api.FilelessExecutionOutput_FILELESS_EXECUTION_OUTPUT_RAW:
+			raw = true
+		case api.FilelessExecutionOutput_FILELESS_EXECUTION_OUTPUT_BOTH:
+			raw, derived = true, true
+		}
+	}
+	output := api.FilelessExecutionOutput_FILELESS_EXECUTION_OUTPUT_DERIVED
+	if raw && derived {
+		output = api.FilelessExecutionOutput_FILELESS_EXECUTION_OUTPUT_BOTH
+	} else if raw {
+		output = api.FilelessExecutionOutput_FILELESS_EXECUTION_OUTPUT_RAW
+	}
+
+	parentTgid := func(tgid int32) int32 {
+		task := sensor.ProcessCache.LookupTask(int(tgid))
+		if task == nil {
+			return 0
+		}
+		if p := sensor.ProcessCache.LookupTaskParent(task); p != nil {
+			return int32(p.TGID)
+		}
+		return 0
+	}
+	t := newFilelessExecutionTracker(subscr.dispatchFn, sensor.NewEvent,
+		parentTgid, output)
+	t.causedBy = subscr.includeCausedBy
+
+	// memfd_create's name and execve's path are the first argument, and
+	// execveat's directory file descriptor and path are the first and
+	// second.
+	f := syscallFilter{
+		sensor: sensor,
+	}
+	enterSink := f.registerEnterKprobe(subscr,
+		sensor.applyDefaultFilter(DefaultFilterSyscall,
+			syscallIDsExpression(syscallMemfdCreateID,
+				syscallExecveID, syscallExecveatID)),
+		0x3, 0x3)
+	if enterSink == nil {
+		return
+	}
+	exitSink := f.registerExitTracepoint(subscr,
+		sensor.applyDefaultFilter(DefaultFilterSyscall,
+			syscallIDsExpression(syscallMemfdCreateID)))
+	if exitSink == nil {
+		subscr.removeEventSink(enterSink)
+		sensor.Monitor.UnregisterEvent(enterSink.eventID)
+		if enterSink.unregister != nil {
+			enterSink.unregister(enterSink)
+		}
+		return
+	}
+
+	enterSink.dispatchFn = t.enter
+	exitSink.dispatchFn = t.exit
+	exitSink.unregister = func(*eventSink) {
+		t.stop()
+	}
+	t.start(sensor, config.Sensor.FilelessExecutionWindow)
+}
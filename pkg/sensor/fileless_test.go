@@ -0,0 +1,204 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+func TestParseMemfdExecPath(t *testing.T) {
+	type result struct {
+		pid, fd int32
+		name    string
+		ok      bool
+	}
+	tests := map[string]result{
+		"/proc/self/fd/3":          {0, 3, "", true},
+		"/proc/thread-self/fd/4":   {0, 4, "", true},
+		"/dev/fd/5":                {0, 5, "", true},
+		"/proc/1234/fd/6":          {1234, 6, "", true},
+		"/memfd:payload":           {0, -1, "payload", true},
+		"/memfd:payload (deleted)": {0, -1, "payload", true},
+		"/proc/self/fd/x":          {0, 0, "", false},
+		"/proc/1234/exe":           {0, 0, "", false},
+		"/proc/self/exe":           {0, 0, "", false},
+		"/usr/bin/id":              {0, 0, "", false},
+		"":                         {0, 0, "", false},
+	}
+	for path, want := range tests {
+		var got result
+		got.pid, got.fd, got.name, got.ok = parseMemfdExecPath(path)
+		if got != want {
+			t.Errorf("%q: expected %+v, got %+v", path, want, got)
+		}
+	}
+}
+
+func TestFilelessExecutionTracker(t *testing.T) {
+	var (
+		events []*api.TelemetryEvent
+		seq    uint64
+		now    int64
+	)
+	parents := map[int32]int32{200: 100, 300: 200}
+	tracker := newFilelessExecutionTracker(
+		func(e *api.TelemetryEvent) { events = append(events, e) },
+		func() *api.TelemetryEvent {
+			seq++
+			return &api.TelemetryEvent{SensorSequenceNumber: seq}
+		},
+		func(tgid int32) int32 { return parents[tgid] },
+		api.FilelessExecutionOutput_FILELESS_EXECUTION_OUTPUT_BOTH)
+	tracker.causedBy = true
+
+	syscall := func(pid int32, e *api.SyscallEvent) *api.TelemetryEvent {
+		seq++
+		now++
+		return &api.TelemetryEvent{
+			ProcessPid:           pid,
+			ProcessTgid:          pid,
+			SensorSequenceNumber: seq,
+			SensorMonotimeNanos:  now,
+			Event:                &api.TelemetryEvent_Syscall{Syscall: e},
+		}
+	}
+
+	// Process 100 creates a memory file with fd 3
+	tracker.enter(syscall(100, &api.SyscallEvent{
+		Type:      api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER,
+		Id:        syscallMemfdCreateID,
+		MemfdName: "payload",
+	}))
+	create := syscall(100, &api.SyscallEvent{
+		Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT,
+		Id:   syscallMemfdCreateID,
+		Ret:  3,
+	})
+	tracker.exit(create)
+	if len(events) != 1 ||
+		events[0].GetSyscall().Type != api.SyscallEventType_SYSCALL_EVENT_TYPE_COMPLETE ||
+		events[0].GetSyscall().Ret != 3 {
+		t.Fatalf("Expected memfd_create complete event, got %v", events)
+	}
+	createSeq := events[0].SensorSequenceNumber
+	events = nil
+
+	// Executing an ordinary file or an unknown fd is not reported
+	tracker.enter(syscall(100, &api.SyscallEvent{
+		Id:   syscallExecveID,
+		Path: "/usr/bin/id",
+	}))
+	tracker.enter(syscall(100, &api.SyscallEvent{
+		Id:   syscallExecveID,
+		Path: "/proc/self/fd/4",
+	}))
+	if len(events) != 0 {
+		t.Fatalf("Expected no events, got %v", events)
+	}
+
+	// A grandchild inherits the fd and executes it
+	exec := syscall(300, &api.SyscallEvent{
+		Id:   syscallExecveID,
+		Path: "/proc/self/fd/3",
+	})
+	tracker.enter(exec)
+	if len(events) != 2 || events[0] != exec {
+		t.Fatalf("Expected raw and derived events, got %v", events)
+	}
+	d := events[1]
+	fe := d.GetFilelessExecution()
+	if fe == nil || fe.MemfdName != "payload" || fe.MemfdFd != 3 ||
+		fe.MemfdCreatePid != 100 || fe.ExecPath != "/proc/self/fd/3" ||
+		fe.ExecSyscallId != syscallExecveID {
+		t.Fatalf("Unexpected derived event %v", d)
+	}
+	if d.ProcessPid != 300 || d.SensorSequenceNumber == exec.SensorSequenceNumber {
+		t.Fatalf("Unexpected derived event %v", d)
+	}
+	if len(d.CausedBy) != 2 || d.CausedBy[0] != createSeq ||
+		d.CausedBy[1] != exec.SensorSequenceNumber {
+		t.Fatalf("Expected caused by %d and %d, got %v", createSeq,
+			exec.SensorSequenceNumber, d.CausedBy)
+	}
+	events = nil
+
+	// fexecve executes the fd directly
+	tracker.enter(syscall(100, &api.SyscallEvent{
+		Id:        syscallExecveatID,
+		Arg0:      3,
+		ArgStatus: []api.SyscallArgStatus{api.SyscallArgStatus_SYSCALL_ARG_STATUS_CAPTURED},
+	}))
+	if len(events) != 2 || events[1].GetFilelessExecution().MemfdFd != 3 {
+		t.Fatalf("Expected execveat to be reported, got %v", events)
+	}
+	events = nil
+
+	// Expired memory files are forgotten, but "/memfd:" paths are
+	// always reported
+	tracker.expire(now + 1)
+	tracker.enter(syscall(100, &api.SyscallEvent{
+		Id:   syscallExecveID,
+		Path: "/proc/self/fd/3",
+	}))
+	if len(events) != 0 {
+		t.Fatalf("Expected no events after expiry, got %v", events)
+	}
+	tracker.enter(syscall(100, &api.SyscallEvent{
+		Id:   syscallExecveID,
+		Path: "/memfd:payload (deleted)",
+	}))
+	if len(events) != 2 || events[1].GetFilelessExecution().MemfdName != "payload" ||
+		events[1].GetFilelessExecution().MemfdFd != -1 {
+		t.Fatalf("Expected /memfd: path to be reported, got %v", events)
+	}
+}
+
+func TestFilelessExecutionTrackerDerived(t *testing.T) {
+	var events []*api.TelemetryEvent
+	tracker := newFilelessExecutionTracker(
+		func(e *api.TelemetryEvent) { events = append(events, e) },
+		func() *api.TelemetryEvent { return &api.TelemetryEvent{} },
+		func(int32) int32 { return 0 },
+		api.FilelessExecutionOutput_FILELESS_EXECUTION_OUTPUT_DERIVED)
+
+	tracker.enter(&api.TelemetryEvent{
+		ProcessPid:  1,
+		ProcessTgid: 1,
+		Event: &api.TelemetryEvent_Syscall{Syscall: &api.SyscallEvent{
+			Id: syscallMemfdCreateID,
+		}},
+	})
+	tracker.exit(&api.TelemetryEvent{
+		ProcessPid: 1,
+		Event: &api.TelemetryEvent_Syscall{Syscall: &api.SyscallEvent{
+			Id:  syscallMemfdCreateID,
+			Ret: 5,
+		}},
+	})
+	tracker.enter(&api.TelemetryEvent{
+		ProcessPid:  1,
+		ProcessTgid: 1,
+		Event: &api.TelemetryEvent_Syscall{Syscall: &api.SyscallEvent{
+			Id:   syscallExecveID,
+			Path: "/dev/fd/5",
+		}},
+	})
+	if len(events) != 1 || events[0].GetFilelessExecution() == nil ||
+		events[0].CausedBy != nil {
+		t.Fatalf("Expected only the derived event, got %v", events)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/golang/protobuf/proto"
+
+	"google.golang.org/grpc/peer"
+)
+
+// idempotencyKey identifies a GetEvents request by the client that made it
+// and the key that the client chose for it.
+type idempotencyKey struct {
+	client string
+	key    string
+}
+
+// idempotentSubscription is the subscription created by a GetEvents request
+// with an idempotency key. While it is being served, a retry of the request
+// takes it over. Once it has ended, the key is remembered until it expires.
+type idempotentSubscription struct {
+	key     idempotencyKey
+	request *api.GetEventsRequest
+
+	// The session being served. It is set before the session is served
+	// and only accessed by the goroutine serving it.
+	session *getEventsSession
+
+	// A retried request takes over the session by sending a channel,
+	// which the stream serving the session closes once it has stopped.
+	takeover chan chan struct{}
+
+	// Closed once the session has ended or could not be created
+	ended   chan struct{}
+	expires time.Time
+}
+
+// idempotencyKeys tracks the subscriptions created by GetEvents requests with
+// idempotency keys.
+type idempotencyKeys struct {
+	sync.Mutex
+	ttl     time.Duration
+	entries map[idempotencyKey]*idempotentSubscription
+	now     func() time.Time
+}
+
+func newIdempotencyKeys(ttl time.Duration) *idempotencyKeys {
+	return &idempotencyKeys{
+		ttl:     ttl,
+		entries: make(map[idempotencyKey]*idempotentSubscription),
+		now:     time.Now,
+	}
+}
+
+// peerClient identifies the client of a stream by its host, since a retried
+// request is normally made from a different port.
+func peerClient(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// acquire returns the subscription for a request with an idempotency key. If
+// a subscription for the same request is being served, it is taken over and
+// its session is returned. Otherwise, the session is nil, and the caller must
+// create one and call release when it ends, or abandon if it cannot be
+// created. Reusing a key with a different request is an error.
+func (k *idempotencyKeys) acquire(
+	ctx context.Context,
+	client string,
+	req *api.GetEventsRequest,
+) (*idempotentSubscription, *getEventsSession, error) {
+	key := idempotencyKey{
+		client: client,
+		key:    req.IdempotencyKey,
+	}
+	for {
+		k.Lock()
+		now := k.now()
+		for ik, e := range k.entries {
+			if !e.expires.IsZero() && now.After(e.expires) {
+				delete(k.entries, ik)
+			}
+		}
+		e := k.entries[key]
+		if e != nil && !proto.Equal(e.request, req) {
+			k.Unlock()
+			return nil, nil, fmt.Errorf("Idempotency key %q was used for a different request",
+				req.IdempotencyKey)
+		}
+		if e == nil || !e.expires.IsZero() {
+			e = &idempotentSubscription{
+				key:      key,
+				request:  proto.Clone(req).(*api.GetEventsRequest),
+				takeover: make(chan chan struct{}),
+				ended:    make(chan struct{}),
+			}
+			k.entries[key] = e
+			k.Unlock()
+			return e, nil, nil
+		}
+		k.Unlock()
+
+		stopped := make(chan struct{})
+		select {
+		case e.takeover <- stopped:
+			<-stopped
+			return e, e.session, nil
+		case <-e.ended:
+			// Try again now that the subscription has ended
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+	}
+}
+
+// release records that the session of a subscription has ended. Its key is
+// remembered until the TTL passes.
+func (k *idempotencyKeys) release(e *idempotentSubscription) {
+	k.Lock()
+	e.expires = k.now().Add(k.ttl)
+	k.Unlock()
+	close(e.ended)
+}
+
+// abandon forgets a subscription whose session could not be created.
+func (k *idempotencyKeys) abandon(e *idempotentSubscription) {
+	k.Lock()
+	if k.entries[e.key] == e {
+		delete(k.entries, e.key)
+	}
+	k.Unlock()
+	close(e.ended)
+}
@@ -0,0 +1,88 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+func TestIdempotencyKeys(t *testing.T) {
+	k := newIdempotencyKeys(time.Minute)
+	now := time.Unix(1000, 0)
+	k.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	req := &api.GetEventsRequest{
+		Subscription:   &api.Subscription{},
+		IdempotencyKey: "retry-me",
+	}
+	e, sess, err := k.acquire(ctx, "10.0.0.1", req)
+	if err != nil || sess != nil {
+		t.Fatalf("Unexpected acquire %v, %v", sess, err)
+	}
+	e.session = &getEventsSession{}
+
+	// A retry takes over the session once its stream stops
+	go func() {
+		stopped := <-e.takeover
+		close(stopped)
+	}()
+	e2, sess, err := k.acquire(ctx, "10.0.0.1", req)
+	if err != nil || e2 != e || sess != e.session {
+		t.Fatalf("Retry did not take over the session: %v, %v", sess, err)
+	}
+
+	// The same key from another client is a different request
+	if _, sess, err = k.acquire(ctx, "10.0.0.2", req); err != nil ||
+		sess != nil {
+		t.Errorf("Unexpected acquire for another client %v, %v",
+			sess, err)
+	}
+
+	different := &api.GetEventsRequest{
+		Subscription:       &api.Subscription{},
+		IdempotencyKey:     "retry-me",
+		DictionaryEncoding: true,
+	}
+	if _, _, err = k.acquire(ctx, "10.0.0.1", different); err == nil {
+		t.Error("Expected reusing a key for a different request to fail")
+	}
+
+	// Once the subscription has ended, a retry creates a new one, but the
+	// key is still reserved for the same request until it expires.
+	k.release(e)
+	e3, sess, err := k.acquire(ctx, "10.0.0.1", req)
+	if err != nil || sess != nil || e3 == e {
+		t.Errorf("Unexpected acquire after release %v, %v", sess, err)
+	}
+	k.release(e3)
+	if _, _, err = k.acquire(ctx, "10.0.0.1", different); err == nil {
+		t.Error("Expected reusing an ended key for a different request to fail")
+	}
+
+	now = now.Add(2 * time.Minute)
+	e4, _, err := k.acquire(ctx, "10.0.0.1", different)
+	if err != nil {
+		t.Errorf("Expected expired key to be reusable: %v", err)
+	}
+	k.abandon(e4)
+	if len(k.entries) != 1 {
+		t.Errorf("Unexpected entries %v", k.entries)
+	}
+}
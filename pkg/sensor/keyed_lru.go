@@ -0,0 +1,73 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"container/list"
+)
+
+type keyedLRUEntry struct {
+	key   string
+	value interface{}
+}
+
+// keyedLRU is a bounded map of per-key state that forgets the least recently
+// used key when it is full. It is not safe for concurrent use.
+type keyedLRU struct {
+	maxKeys int
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+func newKeyedLRU(maxKeys int) *keyedLRU {
+	return &keyedLRU{
+		maxKeys: maxKeys,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// get returns the state of a key and marks it as the most recently used, or
+// returns nil if the key is not known.
+func (c *keyedLRU) get(key string) interface{} {
+	e, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.lru.MoveToFront(e)
+	return e.Value.(*keyedLRUEntry).value
+}
+
+// add sets the state of a key that is not known, forgetting the least
+// recently used key if there are already maxKeys.
+func (c *keyedLRU) add(key string, value interface{}) {
+	if c.lru.Len() >= c.maxKeys {
+		e := c.lru.Back()
+		c.lru.Remove(e)
+		delete(c.entries, e.Value.(*keyedLRUEntry).key)
+	}
+	c.entries[key] = c.lru.PushFront(&keyedLRUEntry{
+		key:   key,
+		value: value,
+	})
+}
+
+// remove forgets a key.
+func (c *keyedLRU) remove(key string) {
+	if e, ok := c.entries[key]; ok {
+		c.lru.Remove(e)
+		delete(c.entries, key)
+	}
+}
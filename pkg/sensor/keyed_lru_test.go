@@ -0,0 +1,41 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import "testing"
+
+func TestKeyedLRU(t *testing.T) {
+	c := newKeyedLRU(2)
+	c.add("a", 1)
+	c.add("b", 2)
+
+	// Getting a makes b the least recently used, so it is forgotten
+	if v := c.get("a"); v != 1 {
+		t.Errorf("Expected 1 for a, got %v", v)
+	}
+	c.add("c", 3)
+	if v := c.get("b"); v != nil {
+		t.Errorf("Expected b to be forgotten, got %v", v)
+	}
+	if v := c.get("c"); v != 3 {
+		t.Errorf("Expected 3 for c, got %v", v)
+	}
+
+	c.remove("a")
+	c.remove("x")
+	if v := c.get("a"); v != nil || len(c.entries) != 1 || c.lru.Len() != 1 {
+		t.Errorf("Expected only c to remain, got %v %v", c.entries, v)
+	}
+}
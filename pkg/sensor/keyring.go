@@ -0,0 +1,126 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"strconv"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+// The x86_64 system call numbers of the kernel key management system calls
+const (
+	syscallAddKeyID     = 248
+	syscallRequestKeyID = 249
+	syscallKeyctlID     = 250
+)
+
+// keyctlOperationNames maps keyctl operations to their names, from
+// include/uapi/linux/keyctl.h.
+var keyctlOperationNames = map[uint64]string{
+	0:  "KEYCTL_GET_KEYRING_ID",
+	1:  "KEYCTL_JOIN_SESSION_KEYRING",
+	2:  "KEYCTL_UPDATE",
+	3:  "KEYCTL_REVOKE",
+	4:  "KEYCTL_CHOWN",
+	5:  "KEYCTL_SETPERM",
+	6:  "KEYCTL_DESCRIBE",
+	7:  "KEYCTL_CLEAR",
+	8:  "KEYCTL_LINK",
+	9:  "KEYCTL_UNLINK",
+	10: "KEYCTL_SEARCH",
+	11: "KEYCTL_READ",
+	12: "KEYCTL_INSTANTIATE",
+	13: "KEYCTL_NEGATE",
+	14: "KEYCTL_SET_REQKEY_KEYRING",
+	15: "KEYCTL_SET_TIMEOUT",
+	16: "KEYCTL_ASSUME_AUTHORITY",
+	17: "KEYCTL_GET_SECURITY",
+	18: "KEYCTL_SESSION_TO_PARENT",
+	19: "KEYCTL_REJECT",
+	20: "KEYCTL_INSTANTIATE_IOV",
+	21: "KEYCTL_INVALIDATE",
+	22: "KEYCTL_GET_PERSISTENT",
+	23: "KEYCTL_DH_COMPUTE",
+	24: "KEYCTL_PKEY_QUERY",
+	25: "KEYCTL_PKEY_ENCRYPT",
+	26: "KEYCTL_PKEY_DECRYPT",
+	27: "KEYCTL_PKEY_SIGN",
+	28: "KEYCTL_PKEY_VERIFY",
+	29: "KEYCTL_RESTRICT_KEYRING",
+	30: "KEYCTL_MOVE",
+	31: "KEYCTL_CAPABILITIES",
+	32: "KEYCTL_WATCH_KEY",
+}
+
+// keyctlOperationName returns the name of a keyctl operation, or its number
+// if the operation is unknown.
+func keyctlOperationName(op uint64) string {
+	if name, ok := keyctlOperationNames[op]; ok {
+		return name
+	}
+	return strconv.FormatUint(op, 10)
+}
+
+// keyStringPathMask returns the mask of the string arguments to capture so
+// that the key types and descriptions passed to add_key and request_key are
+// reported, if either is among ids. Both take the type as their first
+// argument and the description as their second. The payload of add_key is
+// never captured.
+func keyStringPathMask(ids []int64) uint8 {
+	for _, id := range ids {
+		if id == syscallAddKeyID || id == syscallRequestKeyID {
+			return 1<<0 | 1<<1
+		}
+	}
+	return 0
+}
+
+// setKeyctlOperation decodes the operation of a keyctl system call, if its
+// first argument was captured. The arguments of the operation, some of
+// which point to key payloads, are never dereferenced.
+func setKeyctlOperation(
+	syscall *api.SyscallEvent,
+	data perf.TraceEventSampleData,
+) {
+	if op, ok := data["arg0"].(uint64); ok {
+		syscall.KeyctlOperation = keyctlOperationName(op)
+		data["keyctl_operation"] = syscall.KeyctlOperation
+	}
+}
+
+// setKeyStrings sets the key type and description passed to an add_key or
+// request_key system call. The sensor's syscall argument policy applies to
+// them as it does to the arguments that point to them.
+func (f *syscallFilter) setKeyStrings(
+	syscall *api.SyscallEvent,
+	data perf.TraceEventSampleData,
+) {
+	p := f.sensor.syscallArgPolicy
+	if s, ok := data["path0"].(string); ok {
+		if s, ok = p.applyString(syscall.Id, 0, s); ok {
+			syscall.KeyType = s
+			data["key_type"] = s
+		}
+	}
+	if s, ok := data["path1"].(string); ok {
+		if s, ok = p.applyString(syscall.Id, 1, s); ok {
+			syscall.KeyDescription = s
+			data["key_description"] = s
+		}
+	}
+}
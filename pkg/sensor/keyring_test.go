@@ -0,0 +1,65 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import "testing"
+
+func TestKeyctlOperationName(t *testing.T) {
+	if got := keyctlOperationName(11); got != "KEYCTL_READ" {
+		t.Errorf("Unexpected operation name %q", got)
+	}
+	if got := keyctlOperationName(999); got != "999" {
+		t.Errorf("Unexpected operation name %q", got)
+	}
+}
+
+func TestKeyStringPathMask(t *testing.T) {
+	if got := keyStringPathMask([]int64{1, syscallRequestKeyID}); got != 3 {
+		t.Errorf("Unexpected path mask %#x", got)
+	}
+	if got := keyStringPathMask([]int64{syscallKeyctlID}); got != 0 {
+		t.Errorf("Unexpected path mask %#x", got)
+	}
+}
+
+func TestSyscallArgPolicyApplyString(t *testing.T) {
+	p, err := parseSyscallArgPolicy(
+		"248:arg0=hash,248:arg1=disable,249:arg1=truncate")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h, ok := p.applyString(syscallAddKeyID, 0, "user")
+	if !ok || h == "user" || len(h) != 16 {
+		t.Errorf("Unexpected hashed string %q, %v", h, ok)
+	}
+	if h2, _ := p.applyString(syscallAddKeyID, 0, "user"); h2 != h {
+		t.Errorf("Inconsistent hash %q != %q", h2, h)
+	}
+	if _, ok = p.applyString(syscallAddKeyID, 1, "secret"); ok {
+		t.Error("Expected disabled string to be removed")
+	}
+	if _, ok = p.applyString(syscallRequestKeyID, 1, "secret"); ok {
+		t.Error("Expected truncated string to be removed")
+	}
+	if s, ok := p.applyString(syscallRequestKeyID, 0, "user"); !ok || s != "user" {
+		t.Errorf("Unexpected string %q, %v", s, ok)
+	}
+
+	var nilPolicy *syscallArgPolicy
+	if s, ok := nilPolicy.applyString(syscallAddKeyID, 1, "x"); !ok || s != "x" {
+		t.Errorf("Unexpected string %q, %v", s, ok)
+	}
+}
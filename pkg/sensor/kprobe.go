@@ -17,13 +17,16 @@ package sensor
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	api "github.com/capsule8/capsule8/api/v0"
 
+	"github.com/capsule8/capsule8/pkg/config"
 	"github.com/capsule8/capsule8/pkg/expression"
 	"github.com/capsule8/capsule8/pkg/sys/perf"
 
+	"github.com/golang/glog"
 	"google.golang.org/genproto/googleapis/rpc/code"
 )
 
@@ -58,6 +61,15 @@ func newKprobeFilter(kef *api.KernelFunctionCallFilter) (*kprobeFilter, error) {
 		return nil, fmt.Errorf("Kprobe symbol %q is invalid", kef.Symbol)
 	}
 
+	// Each argument is a fetcharg specification of the form
+	// location[:type]. Reject types that the kernel won't understand
+	// here rather than letting the registration fail later.
+	for name, spec := range kef.Arguments {
+		if err := validateKprobeFetcharg(name, spec); err != nil {
+			return nil, err
+		}
+	}
+
 	filter := &kprobeFilter{
 		symbol:    kef.Symbol,
 		arguments: kef.Arguments,
@@ -85,6 +97,9 @@ func (f *kprobeFilter) decodeKprobe(sample *perf.SampleRecord, data perf.TraceEv
 
 	args := make(map[string]*api.KernelFunctionCallEvent_FieldValue)
 	for k, v := range data {
+		if k == perf.TraceEventFaultedFields || k == "__task__" {
+			continue
+		}
 		value := &api.KernelFunctionCallEvent_FieldValue{}
 		switch v := v.(type) {
 		case []byte:
@@ -131,14 +146,62 @@ func (f *kprobeFilter) decodeKprobe(sample *perf.SampleRecord, data perf.TraceEv
 }
 
 func (f *kprobeFilter) fetchargs() string {
-	args := make([]string, 0, len(f.arguments))
-	for k, v := range f.arguments {
-		args = append(args, fmt.Sprintf("%s=%s", k, v))
+	names := make([]string, 0, len(f.arguments))
+	for k := range f.arguments {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	args := make([]string, 0, len(names))
+	for _, k := range names {
+		location, t := splitKprobeFetcharg(f.arguments[k])
+		arg, err := formatKprobeFetcharg(k, location, t)
+		if err != nil {
+			// Arguments are validated by newKprobeFilter
+			glog.Warningf("Invalid kprobe fetcharg: %v", err)
+			continue
+		}
+		args = append(args, arg)
 	}
 
 	return strings.Join(args, " ")
 }
 
+// register registers the kprobe for the filter and adds an event sink for it
+// to the subscription.
+func (f *kprobeFilter) register(subscr *subscription) error {
+	sensor := f.sensor
+	eventID, err := sensor.RegisterKprobe(
+		f.symbol, f.onReturn, f.fetchargs(),
+		f.decodeKprobe,
+		perf.WithEventAttr(subscr.eventAttr),
+		perf.WithEventGroup(subscr.eventGroupID))
+	if err != nil {
+		var loc string
+		if f.onReturn {
+			loc = "return"
+		} else {
+			loc = "entry"
+		}
+		return fmt.Errorf("Couldn't register kprobe on %s %s [%s]: %v",
+			f.symbol, loc, f.fetchargs(), err)
+	}
+
+	kprobeFields := sensor.Monitor.RegisteredEventFields(eventID)
+	filterTypes := make(expression.FieldTypeMap, len(kprobeFields))
+	for k, v := range kprobeFields {
+		filterTypes[k] = perfTypeMapping[v]
+	}
+
+	filter := sensor.applyDefaultFilter(DefaultFilterKernel, f.filter)
+	_, err = subscr.addEventSink(eventID, filter, filterTypes)
+	if err != nil {
+		sensor.Monitor.UnregisterEvent(eventID)
+		return fmt.Errorf("Invalid filter expression for kernel function call filter: %v", err)
+	}
+	return nil
+}
+
 func registerKernelEvents(
 	sensor *Sensor,
 	subscr *subscription,
@@ -152,38 +215,21 @@ func registerKernelEvents(
 				fmt.Sprintf("Invalid kprobe filter %s: %v", kef.Symbol, err))
 			continue
 		}
-
 		f.sensor = sensor
-		eventID, err := sensor.RegisterKprobe(
-			f.symbol, f.onReturn, f.fetchargs(),
-			f.decodeKprobe,
-			perf.WithEventGroup(subscr.eventGroupID))
-		if err != nil {
-			var loc string
-			if f.onReturn {
-				loc = "return"
-			} else {
-				loc = "entry"
-			}
 
+		// The symbol may be in a kernel module that has not been
+		// loaded yet. Retry the registration once it is.
+		if config.Sensor.KprobeRetryInterval > 0 &&
+			!sensor.IsKernelSymbolAvailable(f.symbol) {
+			subscr.deferredKprobes = append(subscr.deferredKprobes, f)
 			subscr.logStatus(
-				code.Code_UNKNOWN,
-				fmt.Sprintf("Couldn't register kprobe on %s %s [%s]: %v", f.symbol, loc, f.fetchargs(), err))
+				code.Code_UNAVAILABLE,
+				fmt.Sprintf("Kernel symbol %s not found; kprobe will be registered if it becomes available", f.symbol))
 			continue
 		}
 
-		kprobeFields := sensor.Monitor.RegisteredEventFields(eventID)
-		filterTypes := make(expression.FieldTypeMap, len(kprobeFields))
-		for k, v := range kprobeFields {
-			filterTypes[k] = perfTypeMapping[v]
-		}
-
-		_, err = subscr.addEventSink(eventID, f.filter, filterTypes)
-		if err != nil {
-			subscr.logStatus(
-				code.Code_UNKNOWN,
-				fmt.Sprintf("Invalid filter expression for kernel function call filter: %v", err))
-			sensor.Monitor.UnregisterEvent(eventID)
+		if err = f.register(subscr); err != nil {
+			subscr.logStatus(code.Code_UNKNOWN, err.Error())
 		}
 	}
 }
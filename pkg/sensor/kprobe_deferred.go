@@ -0,0 +1,175 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/capsule8/capsule8/pkg/config"
+	"github.com/capsule8/capsule8/pkg/sys"
+
+	"github.com/golang/glog"
+
+	"google.golang.org/genproto/googleapis/rpc/code"
+)
+
+// deferredKprobeSet tracks the kernel function call filters of running
+// subscriptions whose symbols were not available when the subscriptions
+// were created. /proc/modules is polled while there are any, and their
+// registrations are retried whenever it changes.
+type deferredKprobeSet struct {
+	sync.Mutex
+
+	subscriptions map[int32]*subscription
+
+	// The kernel modules that were loaded when last checked
+	modules []string
+
+	stopChan chan struct{}
+}
+
+// addDeferredKprobes starts retrying the deferred kprobes of a subscription.
+func (s *Sensor) addDeferredKprobes(subscr *subscription) {
+	d := &s.deferredKprobes
+	d.Lock()
+	defer d.Unlock()
+
+	if d.subscriptions == nil {
+		d.subscriptions = make(map[int32]*subscription)
+	}
+	d.subscriptions[subscr.eventGroupID] = subscr
+
+	if d.stopChan == nil {
+		d.modules = readKernelModules()
+		d.stopChan = make(chan struct{})
+		go s.pollKernelModules(d.stopChan,
+			config.Sensor.KprobeRetryInterval)
+	}
+}
+
+// removeDeferredKprobes stops retrying the deferred kprobes of a
+// subscription.
+func (s *Sensor) removeDeferredKprobes(subscr *subscription) {
+	d := &s.deferredKprobes
+	d.Lock()
+	delete(d.subscriptions, subscr.eventGroupID)
+	d.stopIfIdle()
+	d.Unlock()
+}
+
+// stopIfIdle stops polling if there are no deferred kprobes left. The caller
+// must hold the lock.
+func (d *deferredKprobeSet) stopIfIdle() {
+	if len(d.subscriptions) == 0 && d.stopChan != nil {
+		close(d.stopChan)
+		d.stopChan = nil
+	}
+}
+
+func readKernelModules() []string {
+	names, err := sys.HostProcFS().KernelModuleNames()
+	if err != nil {
+		glog.V(1).Infof("Couldn't read kernel modules: %v", err)
+		return nil
+	}
+	return names
+}
+
+func sameKernelModules(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Sensor) pollKernelModules(stopChan chan struct{}, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-ticker.C:
+			s.retryDeferredKprobes(stopChan)
+		}
+	}
+}
+
+// retryDeferredKprobes registers any deferred kprobes whose symbols have
+// become available since kernel modules were last checked.
+func (s *Sensor) retryDeferredKprobes(stopChan chan struct{}) {
+	d := &s.deferredKprobes
+	d.Lock()
+	defer d.Unlock()
+
+	// The set may have been emptied and polling restarted since the
+	// tick was received.
+	if d.stopChan != stopChan {
+		return
+	}
+
+	modules := readKernelModules()
+	if modules == nil || sameKernelModules(modules, d.modules) {
+		return
+	}
+	d.modules = modules
+
+	kallsyms, err := sys.HostProcFS().KernelTextSymbolNames()
+	if err != nil {
+		glog.Warningf("Could not reload kernel symbols: %v", err)
+		return
+	}
+	s.kallsymsMutex.Lock()
+	s.kallsyms = kallsyms
+	s.kallsymsMutex.Unlock()
+
+	for groupID, subscr := range d.subscriptions {
+		var pending []*kprobeFilter
+		registered := false
+		for _, f := range subscr.deferredKprobes {
+			if !s.IsKernelSymbolAvailable(f.symbol) {
+				pending = append(pending, f)
+				continue
+			}
+			if err = f.register(subscr); err != nil {
+				subscr.sendLateStatus(code.Code_UNKNOWN,
+					err.Error())
+				continue
+			}
+			registered = true
+			subscr.sendLateStatus(code.Code_OK,
+				fmt.Sprintf("Kprobe on kernel symbol %s registered after it became available", f.symbol))
+		}
+		subscr.deferredKprobes = pending
+		if len(pending) == 0 {
+			delete(d.subscriptions, groupID)
+		}
+
+		if registered {
+			s.eventMap.subscribe(subscr)
+			if s.isSubscriptionEnabled(subscr) {
+				s.Monitor.EnableGroup(subscr.eventGroupID)
+			}
+		}
+	}
+	d.stopIfIdle()
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// kprobeFetchargTypes is the set of fetcharg type suffixes supported by the
+// kernel's kprobe tracer. The x types are unsigned values that the kernel
+// formats as hexadecimal; they are reported like the corresponding u types.
+var kprobeFetchargTypes = map[string]bool{
+	"u8": true, "u16": true, "u32": true, "u64": true,
+	"s8": true, "s16": true, "s32": true, "s64": true,
+	"x8": true, "x16": true, "x32": true, "x64": true,
+	"string": true, "ustring": true, "symbol": true,
+}
+
+// maxKprobeFetchargArrayLen is the largest array length that the kernel
+// accepts for a fetcharg type.
+const maxKprobeFetchargArrayLen = 64
+
+var (
+	kprobeBitfieldRegex = regexp.MustCompile(`^b(\d+)@(\d+)/(8|16|32|64)$`)
+	kprobeArrayRegex    = regexp.MustCompile(`^(\w+)\[(\d+)\]$`)
+)
+
+// validateKprobeFetchargType checks that t is a fetcharg type suffix that
+// the kernel understands. In addition to the basic types, bitfields of the
+// form b<width>@<offset>/<container> and arrays of the form <type>[<n>] are
+// accepted.
+func validateKprobeFetchargType(t string) error {
+	if kprobeFetchargTypes[t] {
+		return nil
+	}
+
+	if m := kprobeBitfieldRegex.FindStringSubmatch(t); m != nil {
+		width, _ := strconv.Atoi(m[1])
+		offset, _ := strconv.Atoi(m[2])
+		container, _ := strconv.Atoi(m[3])
+		if width == 0 || width+offset > container {
+			return fmt.Errorf("Bitfield type %q does not fit in its container", t)
+		}
+		return nil
+	}
+
+	if m := kprobeArrayRegex.FindStringSubmatch(t); m != nil {
+		n, _ := strconv.Atoi(m[2])
+		if n == 0 || n > maxKprobeFetchargArrayLen {
+			return fmt.Errorf("Array length in type %q must be between 1 and %d",
+				t, maxKprobeFetchargArrayLen)
+		}
+		switch m[1] {
+		case "string", "ustring", "symbol":
+			return fmt.Errorf("Type %q cannot be used in an array", m[1])
+		}
+		if !kprobeFetchargTypes[m[1]] {
+			return fmt.Errorf("Fetcharg type %q is not supported", m[1])
+		}
+		return nil
+	}
+
+	return fmt.Errorf("Fetcharg type %q is not supported", t)
+}
+
+// splitKprobeFetcharg splits a fetcharg specification into its location and
+// type. The type is empty if the specification does not include one, in
+// which case the kernel uses its default.
+func splitKprobeFetcharg(spec string) (string, string) {
+	if i := strings.LastIndexByte(spec, ':'); i >= 0 {
+		return spec[:i], spec[i+1:]
+	}
+	return spec, ""
+}
+
+// validateKprobeFetcharg checks a fetcharg name and its specification.
+func validateKprobeFetcharg(name, spec string) error {
+	if !validSymbolRegex.MatchString(name) {
+		return fmt.Errorf("Fetcharg name %q is invalid", name)
+	}
+	location, t := splitKprobeFetcharg(spec)
+	if location == "" {
+		return fmt.Errorf("Fetcharg %s has no location", name)
+	}
+	if strings.ContainsAny(location, " \t\n") {
+		return fmt.Errorf("Fetcharg %s location %q is invalid", name, location)
+	}
+	if t == "" && strings.HasSuffix(spec, ":") {
+		return fmt.Errorf("Fetcharg %s has an empty type", name)
+	}
+	if t != "" {
+		if err := validateKprobeFetchargType(t); err != nil {
+			return fmt.Errorf("Fetcharg %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// formatKprobeFetcharg formats a single fetcharg for use with RegisterKprobe.
+// If t is empty, the kernel's default type is used.
+func formatKprobeFetcharg(name, location, t string) (string, error) {
+	spec := location
+	if t != "" {
+		spec = location + ":" + t
+	}
+	if err := validateKprobeFetcharg(name, spec); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s=%s", name, spec), nil
+}
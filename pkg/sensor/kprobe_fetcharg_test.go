@@ -0,0 +1,111 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+func TestFormatKprobeFetcharg(t *testing.T) {
+	types := []string{
+		"u8", "u16", "u32", "u64",
+		"s8", "s16", "s32", "s64",
+		"x8", "x16", "x32", "x64",
+		"string", "ustring", "symbol",
+		"b4@2/32", "b1@0/8", "b64@0/64",
+		"u8[16]", "x32[4]",
+	}
+	for _, typ := range types {
+		got, err := formatKprobeFetcharg("arg", "+8(%di)", typ)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", typ, err)
+			continue
+		}
+		if want := "arg=+8(%di):" + typ; got != want {
+			t.Errorf("%s: got %q, want %q", typ, got, want)
+		}
+	}
+
+	got, err := formatKprobeFetcharg("arg", "%si", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "arg=%si" {
+		t.Errorf("got %q, want %q", got, "arg=%si")
+	}
+}
+
+func TestValidateKprobeFetcharg(t *testing.T) {
+	invalid := []struct {
+		name, spec string
+	}{
+		{"arg", "%di:u128"},
+		{"arg", "%di:int"},
+		{"arg", "%di:"},
+		{"arg", ":u32"},
+		{"arg", "%di %si:u32"},
+		{"1arg", "%di:u32"},
+		{"arg-1", "%di:u32"},
+		{"arg", "%di:b0@0/32"},
+		{"arg", "%di:b8@30/32"},
+		{"arg", "%di:b4@0/24"},
+		{"arg", "%di:u8[0]"},
+		{"arg", "%di:u8[65]"},
+		{"arg", "%di:string[4]"},
+		{"arg", "%di:f32[4]"},
+	}
+	for _, tc := range invalid {
+		if err := validateKprobeFetcharg(tc.name, tc.spec); err == nil {
+			t.Errorf("%s=%s: expected error", tc.name, tc.spec)
+		}
+	}
+
+	_, err := newKprobeFilter(&api.KernelFunctionCallFilter{
+		Type:   api.KernelFunctionCallEventType_KERNEL_FUNCTION_CALL_EVENT_TYPE_ENTER,
+		Symbol: "do_sys_open",
+		Arguments: map[string]string{
+			"filename": "+0(%si):string",
+			"flags":    "%dx:x32",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = newKprobeFilter(&api.KernelFunctionCallFilter{
+		Type:      api.KernelFunctionCallEventType_KERNEL_FUNCTION_CALL_EVENT_TYPE_ENTER,
+		Symbol:    "do_sys_open",
+		Arguments: map[string]string{"flags": "%dx:q32"},
+	})
+	if err == nil {
+		t.Fatal("expected error for unsupported fetcharg type")
+	}
+}
+
+func TestKprobeFilterFetchargs(t *testing.T) {
+	f := &kprobeFilter{
+		arguments: map[string]string{
+			"mode":     "%dx:x16",
+			"filename": "+0(%si):string",
+			"dfd":      "%di:s32",
+		},
+	}
+	want := "dfd=%di:s32 filename=+0(%si):string mode=%dx:x16"
+	if got := f.fetchargs(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"sync"
+
+	"github.com/golang/glog"
+)
+
+// lazySubscription tracks the running containers that match a lazy
+// subscription's container filter. The subscription's events are only
+// enabled while there is at least one.
+type lazySubscription struct {
+	subscr *subscription
+
+	// A private copy of the subscription's container filter. The
+	// subscription's own filter is owned by the dispatch goroutine.
+	filter *containerFilter
+
+	running map[string]bool
+	active  bool
+}
+
+// setActive records whether the events of a lazy subscription should be
+// enabled and returns whether that changed. The caller must hold the lock of
+// the lazy subscription set.
+func (ls *lazySubscription) setActive(active bool) bool {
+	if ls.active == active {
+		return false
+	}
+	ls.active = active
+	return true
+}
+
+// enableGroups enables or disables the event groups of a lazy subscription.
+func (ls *lazySubscription) enableGroups(sensor *Sensor, active bool) {
+	groupIDs := append([]int32{ls.subscr.eventGroupID},
+		ls.subscr.counterGroupIDs...)
+	for _, id := range groupIDs {
+		var err error
+		if active {
+			err = sensor.Monitor.EnableGroup(id)
+		} else {
+			err = sensor.Monitor.DisableGroup(id)
+		}
+		if err != nil {
+			glog.V(1).Infof("Subscription %d: %v",
+				ls.subscr.eventGroupID, err)
+		}
+	}
+	glog.V(2).Infof("Subscription %d active: %v",
+		ls.subscr.eventGroupID, active)
+}
+
+// lazySubscriptionSet is the set of all lazy subscriptions for a sensor.
+type lazySubscriptionSet struct {
+	sync.Mutex
+	subscriptions map[int32]*lazySubscription
+
+	// Serializes enabling and disabling event groups, which is done
+	// without holding the set's lock, so that it happens in the order in
+	// which the subscriptions changed.
+	groups sync.Mutex
+}
+
+// unlockLazySubscriptions releases the lock of the lazy subscription set and
+// then enables or disables the event groups of the lazy subscriptions that
+// changed while it was held.
+func (s *Sensor) unlockLazySubscriptions(changed []*lazySubscription) {
+	active := make([]bool, len(changed))
+	for i, ls := range changed {
+		active[i] = ls.active
+	}
+
+	s.lazySubscriptions.groups.Lock()
+	s.lazySubscriptions.Unlock()
+	for i, ls := range changed {
+		ls.enableGroups(s, active[i])
+	}
+	s.lazySubscriptions.groups.Unlock()
+}
+
+// addLazySubscription starts tracking a lazy subscription, enabling its events
+// immediately if a matching container is already running.
+func (s *Sensor) addLazySubscription(subscr *subscription, filter *containerFilter) {
+	ls := &lazySubscription{
+		subscr:  subscr,
+		filter:  filter,
+		running: make(map[string]bool),
+	}
+
+	s.lazySubscriptions.Lock()
+	if s.lazySubscriptions.subscriptions == nil {
+		s.lazySubscriptions.subscriptions =
+			make(map[int32]*lazySubscription)
+	}
+	s.lazySubscriptions.subscriptions[subscr.eventGroupID] = ls
+
+	if s.ContainerCache != nil {
+		for _, info := range s.ContainerCache.runningContainers() {
+			if filter.matchContainer(info) {
+				ls.running[info.ID] = true
+			}
+		}
+	}
+	var changed []*lazySubscription
+	if ls.setActive(len(ls.running) > 0) {
+		changed = append(changed, ls)
+	}
+	s.unlockLazySubscriptions(changed)
+}
+
+// removeLazySubscription stops tracking a lazy subscription. It waits for any
+// event groups of the subscription that are being enabled or disabled, so
+// that the groups may be unregistered once it returns.
+func (s *Sensor) removeLazySubscription(subscr *subscription) {
+	s.lazySubscriptions.Lock()
+	delete(s.lazySubscriptions.subscriptions, subscr.eventGroupID)
+	s.unlockLazySubscriptions(nil)
+}
+
+// updateLazySubscriptions enables or disables the events of lazy
+// subscriptions as containers matching them start and stop running.
+func (s *Sensor) updateLazySubscriptions(info *ContainerInfo, running bool) {
+	var changed []*lazySubscription
+	s.lazySubscriptions.Lock()
+	for _, ls := range s.lazySubscriptions.subscriptions {
+		if !ls.filter.matchContainer(info) {
+			continue
+		}
+		if running {
+			ls.running[info.ID] = true
+		} else {
+			delete(ls.running, info.ID)
+		}
+		if ls.setActive(len(ls.running) > 0) {
+			changed = append(changed, ls)
+		}
+	}
+	s.unlockLazySubscriptions(changed)
+}
+
+// lazySubscriptionCounts returns the number of lazy subscriptions that are
+// waiting for a matching container and the number that are active.
+func (s *Sensor) lazySubscriptionCounts() (pending, active uint32) {
+	s.lazySubscriptions.Lock()
+	defer s.lazySubscriptions.Unlock()
+
+	for _, ls := range s.lazySubscriptions.subscriptions {
+		if ls.active {
+			active++
+		} else {
+			pending++
+		}
+	}
+	return
+}
+
+// isSubscriptionEnabled returns true if the events of a subscription should
+// currently be enabled, which is always the case unless it is a lazy
+// subscription that is waiting for a matching container.
+func (s *Sensor) isSubscriptionEnabled(subscr *subscription) bool {
+	s.lazySubscriptions.Lock()
+	defer s.lazySubscriptions.Unlock()
+
+	if ls, ok := s.lazySubscriptions.subscriptions[subscr.eventGroupID]; ok {
+		return ls.active
+	}
+	return true
+}
@@ -0,0 +1,179 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/config"
+
+	"github.com/golang/glog"
+
+	"google.golang.org/genproto/googleapis/rpc/code"
+	google_rpc "google.golang.org/genproto/googleapis/rpc/status"
+)
+
+// configLimits returns the global limits set by the sensor's configuration.
+func configLimits() *api.SensorLimits {
+	return &api.SensorLimits{
+		MaxSubscriptions:         uint32(config.Sensor.MaxSubscriptions),
+		ChannelBufferLength:      uint32(config.Sensor.ChannelBufferLength),
+		DispatchBacklogThreshold: uint32(config.Sensor.DispatchBacklogThreshold),
+		SyscallRateLimit:         config.Sensor.SyscallRateLimit,
+		SyscallRateLimitBurst:    config.Sensor.SyscallRateLimitBurst,
+		RingBufferPages:          uint32(config.Sensor.RingBufferPages),
+		DispatchWorkers:          uint32(configDispatchWorkers()),
+		DispatchQueueDepth:       uint32(config.Sensor.DispatchQueueDepth),
+		MaxEgressBytesPerSecond:  config.Sensor.MaxEgressBytesPerSecond,
+		MaxCpuPercent:            config.Sensor.MaxCPUPercent,
+	}
+}
+
+// reserveSubscription counts a new active subscription unless that would
+// exceed the MaxSubscriptions limit, which it returns along with whether the
+// subscription was counted.
+func (s *Sensor) reserveSubscription() (uint32, bool) {
+	max := s.Limits().MaxSubscriptions
+	for {
+		n := atomic.LoadInt32(&s.activeSubscriptions)
+		if max > 0 && n >= int32(max) {
+			return max, false
+		}
+		if atomic.CompareAndSwapInt32(&s.activeSubscriptions, n, n+1) {
+			return max, true
+		}
+	}
+}
+
+// Limits returns the global limits currently in effect. The return must not
+// be modified.
+func (s *Sensor) Limits() *api.SensorLimits {
+	if l, ok := s.limits.Load().(*api.SensorLimits); ok {
+		return l
+	}
+	return configLimits()
+}
+
+// UpdateLimits changes the global limits that are set in the request. The
+// request is validated in full before any limit is changed. The return is the
+// limits now in effect along with a status for each limit that changed, which
+// is also logged for auditing.
+func (s *Sensor) UpdateLimits(
+	req *api.UpdateLimitsRequest,
+) (*api.SensorLimits, []*google_rpc.Status, error) {
+	if v := req.ChannelBufferLength; v != nil && v.Value == 0 {
+		return nil, nil, fmt.Errorf("channel_buffer_length must be at least 1")
+	}
+	if v := req.DispatchBacklogThreshold; v != nil && v.Value == 0 {
+		return nil, nil, fmt.Errorf("dispatch_backlog_threshold must be at least 1")
+	}
+	if v := req.SyscallRateLimit; v != nil && !(v.Value >= 0) {
+		return nil, nil, fmt.Errorf("syscall_rate_limit must not be negative")
+	}
+	if v := req.SyscallRateLimitBurst; v != nil && !(v.Value >= 1) {
+		return nil, nil, fmt.Errorf("syscall_rate_limit_burst must be at least 1")
+	}
+	if v := req.RingBufferPages; v != nil && (v.Value == 0 || v.Value&(v.Value-1) != 0) {
+		return nil, nil, fmt.Errorf("ring_buffer_pages must be a power of 2")
+	}
+	if v := req.DispatchWorkers; v != nil && v.Value == 0 {
+		return nil, nil, fmt.Errorf("dispatch_workers must be at least 1")
+	}
+	if v := req.MaxCpuPercent; v != nil && !(v.Value >= 0) {
+		return nil, nil, fmt.Errorf("max_cpu_percent must not be negative")
+	}
+
+	s.limitsMutex.Lock()
+	defer s.limitsMutex.Unlock()
+
+	old := s.Limits()
+	limits := *old
+	var status []*google_rpc.Status
+	changed := func(name string, from, to interface{}) {
+		msg := fmt.Sprintf("Changed %s from %v to %v", name, from, to)
+		glog.Infof("Sensor limits: %s", msg)
+		status = append(status, &google_rpc.Status{
+			Code:    int32(code.Code_OK),
+			Message: msg,
+		})
+	}
+
+	if v := req.MaxSubscriptions; v != nil && v.Value != limits.MaxSubscriptions {
+		changed("max_subscriptions", limits.MaxSubscriptions, v.Value)
+		limits.MaxSubscriptions = v.Value
+	}
+	if v := req.ChannelBufferLength; v != nil && v.Value != limits.ChannelBufferLength {
+		changed("channel_buffer_length", limits.ChannelBufferLength, v.Value)
+		limits.ChannelBufferLength = v.Value
+	}
+	if v := req.DispatchBacklogThreshold; v != nil && v.Value != limits.DispatchBacklogThreshold {
+		changed("dispatch_backlog_threshold",
+			limits.DispatchBacklogThreshold, v.Value)
+		limits.DispatchBacklogThreshold = v.Value
+	}
+	if v := req.SyscallRateLimit; v != nil && v.Value != limits.SyscallRateLimit {
+		changed("syscall_rate_limit", limits.SyscallRateLimit, v.Value)
+		limits.SyscallRateLimit = v.Value
+	}
+	if v := req.SyscallRateLimitBurst; v != nil && v.Value != limits.SyscallRateLimitBurst {
+		changed("syscall_rate_limit_burst",
+			limits.SyscallRateLimitBurst, v.Value)
+		limits.SyscallRateLimitBurst = v.Value
+	}
+	if v := req.RingBufferPages; v != nil && v.Value != limits.RingBufferPages {
+		changed("ring_buffer_pages", limits.RingBufferPages, v.Value)
+		limits.RingBufferPages = v.Value
+	}
+	if v := req.DispatchWorkers; v != nil && v.Value != limits.DispatchWorkers {
+		changed("dispatch_workers", limits.DispatchWorkers, v.Value)
+		limits.DispatchWorkers = v.Value
+	}
+	if v := req.DispatchQueueDepth; v != nil && v.Value != limits.DispatchQueueDepth {
+		changed("dispatch_queue_depth", limits.DispatchQueueDepth, v.Value)
+		limits.DispatchQueueDepth = v.Value
+	}
+	if v := req.MaxEgressBytesPerSecond; v != nil && v.Value != limits.MaxEgressBytesPerSecond {
+		changed("max_egress_bytes_per_second",
+			limits.MaxEgressBytesPerSecond, v.Value)
+		limits.MaxEgressBytesPerSecond = v.Value
+	}
+	if v := req.MaxCpuPercent; v != nil && v.Value != limits.MaxCpuPercent {
+		changed("max_cpu_percent", limits.MaxCpuPercent, v.Value)
+		limits.MaxCpuPercent = v.Value
+	}
+
+	if len(status) == 0 {
+		return old, nil, nil
+	}
+
+	s.limits.Store(&limits)
+	if s.syscallRateLimiter != nil &&
+		(limits.SyscallRateLimit != old.SyscallRateLimit ||
+			limits.SyscallRateLimitBurst != old.SyscallRateLimitBurst) {
+		s.syscallRateLimiter.setRate(limits.SyscallRateLimit,
+			limits.SyscallRateLimitBurst)
+	}
+	if s.egress != nil &&
+		limits.MaxEgressBytesPerSecond != old.MaxEgressBytesPerSecond {
+		s.egress.setLimit(limits.MaxEgressBytesPerSecond)
+	}
+	if s.cpuLimit != nil && limits.MaxCpuPercent != old.MaxCpuPercent {
+		s.cpuLimit.setLimit(limits.MaxCpuPercent)
+	}
+	return &limits, status, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+)
+
+func TestUpdateLimits(t *testing.T) {
+	s := &Sensor{syscallRateLimiter: newSyscallRateLimiter(0, 100, 16)}
+	s.limits.Store(&api.SensorLimits{
+		ChannelBufferLength:      1024,
+		DispatchBacklogThreshold: 32,
+		SyscallRateLimitBurst:    100,
+		RingBufferPages:          8,
+	})
+	key := syscallRateKey{pid: 100, id: 202}
+
+	// Rate limiting is disabled
+	for i := 0; i < 10; i++ {
+		if !s.syscallRateLimiter.allow(key, 0) {
+			t.Fatal("Expected events to be allowed")
+		}
+	}
+
+	// Nothing is changed if any limit is invalid
+	_, _, err := s.UpdateLimits(&api.UpdateLimitsRequest{
+		MaxSubscriptions:    &wrappers.UInt32Value{Value: 4},
+		ChannelBufferLength: &wrappers.UInt32Value{Value: 0},
+	})
+	if err == nil {
+		t.Fatal("Expected error for invalid channel_buffer_length")
+	}
+	if s.Limits().MaxSubscriptions != 0 {
+		t.Fatal("Expected limits to be unchanged")
+	}
+	_, _, err = s.UpdateLimits(&api.UpdateLimitsRequest{
+		SyscallRateLimit: &wrappers.DoubleValue{Value: -1},
+	})
+	if err == nil {
+		t.Fatal("Expected error for negative syscall_rate_limit")
+	}
+	_, _, err = s.UpdateLimits(&api.UpdateLimitsRequest{
+		DispatchWorkers: &wrappers.UInt32Value{Value: 0},
+	})
+	if err == nil {
+		t.Fatal("Expected error for zero dispatch_workers")
+	}
+	_, _, err = s.UpdateLimits(&api.UpdateLimitsRequest{
+		RingBufferPages: &wrappers.UInt32Value{Value: 12},
+	})
+	if err == nil {
+		t.Fatal("Expected error for ring_buffer_pages not a power of 2")
+	}
+
+	limits, status, err := s.UpdateLimits(&api.UpdateLimitsRequest{
+		MaxSubscriptions:         &wrappers.UInt32Value{Value: 4},
+		DispatchBacklogThreshold: &wrappers.UInt32Value{Value: 32},
+		SyscallRateLimit:         &wrappers.DoubleValue{Value: 1},
+		SyscallRateLimitBurst:    &wrappers.DoubleValue{Value: 2},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(status) != 3 ||
+		status[0].Message != "Changed max_subscriptions from 0 to 4" ||
+		status[1].Message != "Changed syscall_rate_limit from 0 to 1" ||
+		status[2].Message != "Changed syscall_rate_limit_burst from 100 to 2" {
+		t.Errorf("Unexpected status %+v", status)
+	}
+	if limits != s.Limits() || limits.MaxSubscriptions != 4 ||
+		limits.ChannelBufferLength != 1024 || limits.RingBufferPages != 8 {
+		t.Errorf("Unexpected limits %+v", limits)
+	}
+
+	// The new rate applies to the existing limiter
+	allowed := 0
+	for i := 0; i < 10; i++ {
+		if s.syscallRateLimiter.allow(key, 0) {
+			allowed++
+		}
+	}
+	if allowed != 2 {
+		t.Errorf("Expected burst of 2 events, got %d", allowed)
+	}
+
+	// Ring buffers of new subscriptions use the new size
+	limits, status, err = s.UpdateLimits(&api.UpdateLimitsRequest{
+		RingBufferPages: &wrappers.UInt32Value{Value: 16},
+	})
+	if err != nil || len(status) != 1 ||
+		status[0].Message != "Changed ring_buffer_pages from 8 to 16" ||
+		limits.RingBufferPages != 16 {
+		t.Errorf("Unexpected status %+v %v", status, err)
+	}
+
+	// Unchanged limits produce no status
+	_, status, err = s.UpdateLimits(&api.UpdateLimitsRequest{
+		MaxSubscriptions: &wrappers.UInt32Value{Value: 4},
+	})
+	if err != nil || len(status) != 0 {
+		t.Errorf("Expected no changes, got %+v %v", status, err)
+	}
+}
+
+func TestReserveSubscription(t *testing.T) {
+	s := &Sensor{}
+	s.limits.Store(&api.SensorLimits{MaxSubscriptions: 4})
+
+	// Concurrent reservations never exceed the limit
+	var (
+		wg       sync.WaitGroup
+		reserved int32
+	)
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, ok := s.reserveSubscription(); ok {
+				atomic.AddInt32(&reserved, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	if reserved != 4 || s.activeSubscriptions != 4 {
+		t.Errorf("Expected 4 reservations, got %d (%d active)",
+			reserved, s.activeSubscriptions)
+	}
+	if max, ok := s.reserveSubscription(); ok || max != 4 {
+		t.Errorf("Expected limit 4 to be reached, got %d %v", max, ok)
+	}
+
+	s.limits.Store(&api.SensorLimits{})
+	if _, ok := s.reserveSubscription(); !ok {
+		t.Error("Expected no limit")
+	}
+}
@@ -40,6 +40,15 @@ func Main() {
 		defer sensor.Stop()
 		service := NewTelemetryService(sensor, config.Sensor.ListenAddr)
 		manager.RegisterService(service)
+
+		if len(config.Sensor.RingFileDir) > 0 {
+			rfs, err := NewRingFileService(sensor)
+			if err != nil {
+				glog.Fatalf("Could not create ring file writer: %s",
+					err.Error())
+			}
+			manager.RegisterService(rfs)
+		}
 	}
 
 	manager.Run()
@@ -0,0 +1,101 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"strings"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+type memFlagName struct {
+	flag uint64
+	name string
+}
+
+var memOpNames = []memFlagName{
+	{perf.PERF_MEM_OP_LOAD, "load"},
+	{perf.PERF_MEM_OP_STORE, "store"},
+	{perf.PERF_MEM_OP_PFETCH, "prefetch"},
+	{perf.PERF_MEM_OP_EXEC, "exec"},
+}
+
+var memLevelNames = []memFlagName{
+	{perf.PERF_MEM_LVL_L1, "L1"},
+	{perf.PERF_MEM_LVL_LFB, "LFB"},
+	{perf.PERF_MEM_LVL_L2, "L2"},
+	{perf.PERF_MEM_LVL_L3, "L3"},
+	{perf.PERF_MEM_LVL_LOC_RAM, "local RAM"},
+	{perf.PERF_MEM_LVL_REM_RAM1, "remote RAM (1 hop)"},
+	{perf.PERF_MEM_LVL_REM_RAM2, "remote RAM (2 hops)"},
+	{perf.PERF_MEM_LVL_REM_CCE1, "remote cache (1 hop)"},
+	{perf.PERF_MEM_LVL_REM_CCE2, "remote cache (2 hops)"},
+	{perf.PERF_MEM_LVL_IO, "I/O"},
+	{perf.PERF_MEM_LVL_UNC, "uncached"},
+}
+
+var memSnoopNames = []memFlagName{
+	{perf.PERF_MEM_SNOOP_NONE, "none"},
+	{perf.PERF_MEM_SNOOP_HIT, "hit"},
+	{perf.PERF_MEM_SNOOP_MISS, "miss"},
+	{perf.PERF_MEM_SNOOP_HITM, "hit modified"},
+}
+
+var memTLBNames = []memFlagName{
+	{perf.PERF_MEM_TLB_L1, "L1"},
+	{perf.PERF_MEM_TLB_L2, "L2"},
+	{perf.PERF_MEM_TLB_HIT, "hit"},
+	{perf.PERF_MEM_TLB_MISS, "miss"},
+	{perf.PERF_MEM_TLB_WK, "walker"},
+	{perf.PERF_MEM_TLB_OS, "OS"},
+}
+
+// memFlagNames returns the names of the flags set in a field of a data
+// source, joined by sep. The kernel may set several flags when it cannot
+// determine which applies.
+func memFlagNames(field uint64, names []memFlagName, sep string) string {
+	var s []string
+	for _, n := range names {
+		if field&n.flag != 0 {
+			s = append(s, n.name)
+		}
+	}
+	return strings.Join(s, sep)
+}
+
+// decodeMemoryAccess decodes the data source of a memory access reported
+// with PERF_SAMPLE_DATA_SRC.
+func decodeMemoryAccess(dataSrc uint64) *api.MemoryAccess {
+	op := (dataSrc >> perf.PERF_MEM_OP_SHIFT) & 0x1f
+	lvl := (dataSrc >> perf.PERF_MEM_LVL_SHIFT) & 0x3fff
+	snoop := (dataSrc >> perf.PERF_MEM_SNOOP_SHIFT) & 0x1f
+	lock := (dataSrc >> perf.PERF_MEM_LOCK_SHIFT) & 0x3
+	tlb := (dataSrc >> perf.PERF_MEM_TLB_SHIFT) & 0x7f
+
+	ma := &api.MemoryAccess{
+		Op:     memFlagNames(op, memOpNames, "|"),
+		Level:  memFlagNames(lvl, memLevelNames, "|"),
+		Snoop:  memFlagNames(snoop, memSnoopNames, "|"),
+		Tlb:    memFlagNames(tlb, memTLBNames, " "),
+		Locked: lock&perf.PERF_MEM_LOCK_LOCKED != 0,
+	}
+	if lvl&perf.PERF_MEM_LVL_HIT != 0 {
+		ma.Result = "hit"
+	} else if lvl&perf.PERF_MEM_LVL_MISS != 0 {
+		ma.Result = "miss"
+	}
+	return ma
+}
@@ -0,0 +1,52 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func TestDecodeMemoryAccess(t *testing.T) {
+	dataSrc := uint64(perf.PERF_MEM_OP_LOAD)<<perf.PERF_MEM_OP_SHIFT |
+		uint64(perf.PERF_MEM_LVL_L3|perf.PERF_MEM_LVL_MISS)<<perf.PERF_MEM_LVL_SHIFT |
+		uint64(perf.PERF_MEM_SNOOP_HITM)<<perf.PERF_MEM_SNOOP_SHIFT |
+		uint64(perf.PERF_MEM_LOCK_LOCKED)<<perf.PERF_MEM_LOCK_SHIFT |
+		uint64(perf.PERF_MEM_TLB_L2|perf.PERF_MEM_TLB_HIT)<<perf.PERF_MEM_TLB_SHIFT
+
+	expected := api.MemoryAccess{
+		Op:     "load",
+		Level:  "L3",
+		Result: "miss",
+		Snoop:  "hit modified",
+		Tlb:    "L2 hit",
+		Locked: true,
+	}
+	if ma := decodeMemoryAccess(dataSrc); *ma != expected {
+		t.Errorf("Expected %+v, got %+v", expected, *ma)
+	}
+
+	// Fields the kernel reports as not available decode as empty
+	na := uint64(perf.PERF_MEM_OP_NA)<<perf.PERF_MEM_OP_SHIFT |
+		uint64(perf.PERF_MEM_LVL_NA)<<perf.PERF_MEM_LVL_SHIFT |
+		uint64(perf.PERF_MEM_SNOOP_NA)<<perf.PERF_MEM_SNOOP_SHIFT |
+		uint64(perf.PERF_MEM_LOCK_NA)<<perf.PERF_MEM_LOCK_SHIFT |
+		uint64(perf.PERF_MEM_TLB_NA)<<perf.PERF_MEM_TLB_SHIFT
+	if ma := decodeMemoryAccess(na); *ma != (api.MemoryAccess{}) {
+		t.Errorf("Expected empty memory access, got %+v", *ma)
+	}
+}
@@ -14,11 +14,37 @@
 
 package sensor
 
+import api "github.com/capsule8/capsule8/api/v0"
+
+// The number of subscription priorities, which are numbered from zero. This
+// must follow the highest value of api.SubscriptionPriority.
+const numSubscriptionPriorities = api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_HIGH + 1
+
 // MetricsCounters is used for tracking metrics information in the sensor
 type MetricsCounters struct {
-	// Number of events created during the sample period
+	// Number of events created during the sample period, excluding
+	// synthetic events
 	Events uint64
 
+	// Number of synthetic events generated for subscriptions'
+	// SyntheticEventFilters
+	SyntheticEvents uint64
+
 	// Number of subscriptions
 	Subscriptions int32
+
+	// Number of events dropped rather than delivered to subscriptions,
+	// indexed by subscription priority (api.SubscriptionPriority)
+	DroppedEvents [numSubscriptionPriorities]uint64
+
+	// Number of syscall events shed by per-(pid, syscall) rate limiting
+	RateLimitedEvents uint64
+
+	// Number of events dropped rather than written to the ring file,
+	// because the writer could not keep up or the write failed
+	RingFileDroppedEvents uint64
+
+	// Number of samples that could not be decoded, including those whose
+	// decoder panicked
+	DecodeErrors uint64
 }
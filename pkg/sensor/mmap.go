@@ -0,0 +1,120 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"strconv"
+	"strings"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+// The x86_64 system call numbers of mmap and mprotect
+const (
+	syscallMmapID     = 9
+	syscallMprotectID = 10
+)
+
+// Memory protection bits, from include/uapi/asm-generic/mman-common.h
+const (
+	protRead      = 0x1
+	protWrite     = 0x2
+	protExec      = 0x4
+	protSem       = 0x8
+	protGrowsDown = 0x01000000
+	protGrowsUp   = 0x02000000
+)
+
+var protFlagNames = []memFlagName{
+	{protRead, "PROT_READ"},
+	{protWrite, "PROT_WRITE"},
+	{protExec, "PROT_EXEC"},
+	{protSem, "PROT_SEM"},
+	{protGrowsDown, "PROT_GROWSDOWN"},
+	{protGrowsUp, "PROT_GROWSUP"},
+}
+
+// x86_64 mmap flags, from include/uapi/asm-generic/mman-common.h,
+// include/uapi/linux/mman.h, and arch/x86/include/uapi/asm/mman.h
+var mmapFlagNames = []memFlagName{
+	{0x01, "MAP_SHARED"},
+	{0x02, "MAP_PRIVATE"},
+	{0x10, "MAP_FIXED"},
+	{0x20, "MAP_ANONYMOUS"},
+	{0x40, "MAP_32BIT"},
+	{0x0100, "MAP_GROWSDOWN"},
+	{0x0800, "MAP_DENYWRITE"},
+	{0x1000, "MAP_EXECUTABLE"},
+	{0x2000, "MAP_LOCKED"},
+	{0x4000, "MAP_NORESERVE"},
+	{0x8000, "MAP_POPULATE"},
+	{0x10000, "MAP_NONBLOCK"},
+	{0x20000, "MAP_STACK"},
+	{0x40000, "MAP_HUGETLB"},
+	{0x80000, "MAP_SYNC"},
+	{0x100000, "MAP_FIXED_NOREPLACE"},
+}
+
+// protString returns the symbolic names of memory protection bits. Bits
+// without names are included as a hexadecimal remainder.
+func protString(prot uint64) string {
+	if prot == 0 {
+		return "PROT_NONE"
+	}
+	return symbolicFlags(prot, protFlagNames)
+}
+
+func mmapFlagsString(flags uint64) string {
+	return symbolicFlags(flags, mmapFlagNames)
+}
+
+func symbolicFlags(value uint64, names []memFlagName) string {
+	var s []string
+	for _, n := range names {
+		if value&n.flag != 0 {
+			s = append(s, n.name)
+			value &^= n.flag
+		}
+	}
+	if value != 0 {
+		s = append(s, "0x"+strconv.FormatUint(value, 16))
+	}
+	return strings.Join(s, "|")
+}
+
+// setMemoryProtection decodes the memory protection of an mmap or mprotect
+// system call, and the flags of an mmap system call, if the arguments were
+// captured.
+func setMemoryProtection(
+	syscall *api.SyscallEvent,
+	data perf.TraceEventSampleData,
+) {
+	if prot, ok := data["arg2"].(uint64); ok {
+		syscall.Prot = protString(prot)
+		syscall.WritableAndExecutable =
+			prot&(protWrite|protExec) == protWrite|protExec
+		data["prot"] = syscall.Prot
+		data["writable_and_executable"] = syscall.WritableAndExecutable
+	}
+	if syscall.Id != syscallMmapID {
+		return
+	}
+	if flags, ok := data["arg3"].(uint64); ok {
+		syscall.MmapFlags = mmapFlagsString(flags)
+		data["mmap_flags"] = syscall.MmapFlags
+	}
+}
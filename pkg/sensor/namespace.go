@@ -0,0 +1,174 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"sort"
+	"strings"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+// The x86_64 system call numbers of unshare and setns
+const (
+	syscallUnshareID = 272
+	syscallSetnsID   = 308
+)
+
+// namespaceFlag is a clone flag that creates a new namespace, along with the
+// type of the namespace as named in /proc/[pid]/ns.
+type namespaceFlag struct {
+	memFlagName
+	nsType string
+}
+
+// namespaceFlags are the clone flags that create namespaces, from
+// include/uapi/linux/sched.h. They are also the nstypes of setns.
+var namespaceFlags = []namespaceFlag{
+	{memFlagName{0x00000080, "CLONE_NEWTIME"}, "time"},
+	{memFlagName{0x00020000, "CLONE_NEWNS"}, "mnt"},
+	{memFlagName{0x02000000, "CLONE_NEWCGROUP"}, "cgroup"},
+	{memFlagName{0x04000000, "CLONE_NEWUTS"}, "uts"},
+	{memFlagName{0x08000000, "CLONE_NEWIPC"}, "ipc"},
+	{memFlagName{0x10000000, "CLONE_NEWUSER"}, "user"},
+	{memFlagName{0x20000000, "CLONE_NEWPID"}, "pid"},
+	{memFlagName{0x40000000, "CLONE_NEWNET"}, "net"},
+}
+
+// namespaceTypesString returns the symbolic names of the namespace flags in
+// the flags of an unshare system call or the nstype of a setns system call.
+// Other flags, such as CLONE_FS, are ignored.
+func namespaceTypesString(flags uint64) string {
+	var s []string
+	for _, f := range namespaceFlags {
+		if flags&f.flag != 0 {
+			s = append(s, f.name)
+		}
+	}
+	return strings.Join(s, "|")
+}
+
+// setNamespaceTypes decodes the namespace types of a setns or unshare system
+// call, if the argument holding them was captured. The task's namespaces are
+// resolved now if they are not already known, so that they may be compared
+// with those after the call.
+func (f *syscallFilter) setNamespaceTypes(
+	ev *api.TelemetryEvent,
+	syscall *api.SyscallEvent,
+	data perf.TraceEventSampleData,
+) {
+	arg := "arg0"
+	if syscall.Id == syscallSetnsID {
+		arg = "arg1"
+	}
+	if flags, ok := data[arg].(uint64); ok {
+		syscall.NamespaceTypes = namespaceTypesString(flags)
+		data["namespace_types"] = syscall.NamespaceTypes
+	}
+
+	if t := f.sensor.ProcessCache.LookupTask(int(ev.ProcessPid)); t != nil {
+		f.sensor.ProcessCache.LookupTaskNamespaces(t)
+	}
+}
+
+// setNamespaceTransitions records the namespaces that the task that made a
+// setns or unshare system call left and entered. As with credentials, the
+// namespaces before the call are the ones that its change replaced, so that
+// every subscription decoding the exit reports the same transitions.
+func (f *syscallFilter) setNamespaceTransitions(
+	ev *api.TelemetryEvent,
+	syscall *api.SyscallEvent,
+	data perf.TraceEventSampleData,
+) {
+	if syscall.Ret != 0 {
+		return
+	}
+	t := f.sensor.ProcessCache.LookupTask(int(ev.ProcessPid))
+	if t == nil {
+		return
+	}
+	if !f.sensor.ProcessCache.updateTaskNamespaces(t) {
+		syscall.NamespacesUnresolved = true
+	}
+
+	var flags uint64
+	for _, nf := range namespaceFlags {
+		before, ok := t.PreviousNamespaces[nf.nsType]
+		if !ok {
+			continue
+		}
+		flags |= nf.flag
+		syscall.NamespaceTransitions = append(
+			syscall.NamespaceTransitions,
+			&api.NamespaceTransition{
+				Type:        nf.nsType,
+				InodeBefore: before,
+				InodeAfter:  t.Namespaces[nf.nsType],
+			})
+	}
+	sort.Slice(syscall.NamespaceTransitions, func(i, j int) bool {
+		return syscall.NamespaceTransitions[i].Type <
+			syscall.NamespaceTransitions[j].Type
+	})
+	syscall.NamespaceTypes = namespaceTypesString(flags)
+	data["namespace_types"] = syscall.NamespaceTypes
+}
+
+// LookupTaskNamespaces returns the namespaces of the specified task, resolving
+// them from /proc if they are not already known. The return is nil if they
+// cannot be resolved, which is normal for tasks that have already exited.
+func (pc *ProcessInfoCache) LookupTaskNamespaces(t *Task) map[string]uint64 {
+	if t.Namespaces == nil && t.ExitTime == 0 {
+		ns, err := procFS.TaskNamespaces(t.TGID, t.PID)
+		if err == nil {
+			t.Namespaces = ns
+		}
+	}
+	return t.Namespaces
+}
+
+// updateTaskNamespaces reads the namespaces of a task from /proc after it has
+// made a setns or unshare system call. If any changed, the ones that they
+// replaced become the task's previous namespaces. The return is false if they
+// cannot be read, because the task has exited.
+func (pc *ProcessInfoCache) updateTaskNamespaces(t *Task) bool {
+	ns, err := procFS.TaskNamespaces(t.TGID, t.PID)
+	if err != nil {
+		return false
+	}
+
+	var replaced map[string]uint64
+	for nsType, ino := range ns {
+		if old, ok := t.Namespaces[nsType]; ok && old != ino {
+			if replaced == nil {
+				replaced = make(map[string]uint64)
+			}
+			replaced[nsType] = old
+		}
+	}
+	if replaced != nil {
+		t.PreviousNamespaces = replaced
+	}
+	t.Namespaces = ns
+	if ino, ok := ns["mnt"]; ok {
+		pc.mountNamespaceLock.Lock()
+		t.MountNamespace = ino
+		t.mountNamespaceUnresolvable = false
+		pc.mountNamespaceLock.Unlock()
+	}
+	return true
+}
@@ -0,0 +1,140 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/sys/proc"
+)
+
+// namespaceTestFS is a proc.FileSystem that only knows the namespaces of
+// tasks.
+type namespaceTestFS struct {
+	proc.FileSystem
+	namespaces map[string]uint64
+	reads      int
+}
+
+func (fs *namespaceTestFS) TaskMountNamespace(tgid, pid int) (uint64, error) {
+	fs.reads++
+	if fs.namespaces == nil {
+		return 0, errors.New("task exited")
+	}
+	return fs.namespaces["mnt"], nil
+}
+
+func (fs *namespaceTestFS) TaskNamespaces(tgid, pid int) (map[string]uint64, error) {
+	if fs.namespaces == nil {
+		return nil, errors.New("task exited")
+	}
+	ns := make(map[string]uint64, len(fs.namespaces))
+	for k, v := range fs.namespaces {
+		ns[k] = v
+	}
+	return ns, nil
+}
+
+func TestNamespaceTypesString(t *testing.T) {
+	// CLONE_NEWNS|CLONE_NEWNET|CLONE_FS
+	if got := namespaceTypesString(0x40020200); got != "CLONE_NEWNS|CLONE_NEWNET" {
+		t.Errorf("Unexpected namespace types %q", got)
+	}
+	if got := namespaceTypesString(0); got != "" {
+		t.Errorf("Unexpected namespace types %q", got)
+	}
+}
+
+func TestUpdateTaskNamespaces(t *testing.T) {
+	fs := &namespaceTestFS{
+		namespaces: map[string]uint64{"mnt": 1, "net": 2, "uts": 3},
+	}
+	oldProcFS := procFS
+	procFS = fs
+	defer func() { procFS = oldProcFS }()
+
+	pc := &ProcessInfoCache{}
+	task := &Task{TGID: 10, PID: 10}
+	if ns := pc.LookupTaskNamespaces(task); len(ns) != 3 {
+		t.Fatalf("Unexpected namespaces %v", ns)
+	}
+
+	fs.namespaces = map[string]uint64{"mnt": 4, "net": 5, "uts": 3}
+	if !pc.updateTaskNamespaces(task) {
+		t.Fatal("Could not update namespaces")
+	}
+	expected := map[string]uint64{"mnt": 1, "net": 2}
+	if !reflect.DeepEqual(task.PreviousNamespaces, expected) {
+		t.Errorf("Expected previous namespaces %v, got %v",
+			expected, task.PreviousNamespaces)
+	}
+	if task.Namespaces["net"] != 5 || task.MountNamespace != 4 {
+		t.Errorf("Unexpected namespaces %v", task.Namespaces)
+	}
+
+	// Reading the same namespaces again, as when another subscription
+	// decodes the same exit, keeps the transitions.
+	if !pc.updateTaskNamespaces(task) ||
+		!reflect.DeepEqual(task.PreviousNamespaces, expected) {
+		t.Errorf("Unexpected previous namespaces %v",
+			task.PreviousNamespaces)
+	}
+
+	fs.namespaces = nil
+	if pc.updateTaskNamespaces(task) {
+		t.Error("Updated namespaces of an exited task")
+	}
+}
+
+func TestLookupTaskMountNamespace(t *testing.T) {
+	fs := &namespaceTestFS{}
+	oldProcFS := procFS
+	procFS = fs
+	defer func() { procFS = oldProcFS }()
+
+	pc := &ProcessInfoCache{}
+	task := &Task{TGID: 10, PID: 10}
+
+	// Failure is remembered
+	if ns := pc.LookupTaskMountNamespace(task); ns != 0 {
+		t.Errorf("Unexpected mount namespace %d", ns)
+	}
+	fs.namespaces = map[string]uint64{"mnt": 1}
+	if ns := pc.LookupTaskMountNamespace(task); ns != 0 || fs.reads != 1 {
+		t.Errorf("Unexpected mount namespace %d after %d reads",
+			ns, fs.reads)
+	}
+
+	// Until the task's namespaces are read again
+	if !pc.updateTaskNamespaces(task) {
+		t.Fatal("Could not update namespaces")
+	}
+	if ns := pc.LookupTaskMountNamespace(task); ns != 1 {
+		t.Errorf("Unexpected mount namespace %d", ns)
+	}
+
+	// Success is cached
+	task = &Task{TGID: 11, PID: 11}
+	for i := 0; i < 2; i++ {
+		if ns := pc.LookupTaskMountNamespace(task); ns != 1 {
+			t.Errorf("Unexpected mount namespace %d", ns)
+		}
+	}
+	if fs.reads != 2 {
+		t.Errorf("Expected 2 reads, got %d", fs.reads)
+	}
+}
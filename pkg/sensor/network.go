@@ -16,12 +16,18 @@ package sensor
 
 import (
 	"fmt"
+	"strconv"
+	"sync"
+	"time"
 
 	api "github.com/capsule8/capsule8/api/v0"
 
 	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/btf"
 	"github.com/capsule8/capsule8/pkg/sys/perf"
 
+	"github.com/golang/glog"
+
 	"google.golang.org/genproto/googleapis/rpc/code"
 )
 
@@ -49,6 +55,32 @@ var networkResultEventTypes = expression.FieldTypeMap{
 	"ret": expression.ValueTypeSignedInt64,
 }
 
+// networkAcceptResultDerivedEventTypes are the types of the peer address
+// fields that decodeSysExitAccept adds to accept result events. They are not
+// fields of the tracepoint, so filters on them are evaluated in userspace.
+// The peer address is read from the struct sock_common of the accepted
+// socket by the inet_csk_accept kretprobe, which AF_UNIX sockets do not go
+// through, so accepted AF_UNIX connections have no peer address fields.
+var networkAcceptResultDerivedEventTypes = expression.FieldTypeMap{
+	"sa_family":      expression.ValueTypeUnsignedInt16,
+	"sin_port":       expression.ValueTypeUnsignedInt16,
+	"sin_addr":       expression.ValueTypeUnsignedInt32,
+	"sin6_port":      expression.ValueTypeUnsignedInt16,
+	"sin6_addr_high": expression.ValueTypeUnsignedInt64,
+	"sin6_addr_low":  expression.ValueTypeUnsignedInt64,
+}
+
+// networkAcceptAddressFields are the fields captured by the accept kretprobe
+// that are added to the data of the accept result event that follows it.
+var networkAcceptAddressFields = []string{
+	"sa_family",
+	"sin_port",
+	"sin_addr",
+	"sin6_port",
+	"sin6_addr_high",
+	"sin6_addr_low",
+}
+
 const (
 	networkKprobeBindSymbol    = "sys_bind"
 	networkKprobeBindFetchargs = "fd=%di sa_family=+0(%si):u16 " +
@@ -68,6 +100,19 @@ const (
 		"sun_path=+2(+0(%si)):string " +
 		"sin6_port=+2(+0(%si)):u16 sin6_addr_high=+8(+0(%si)):u64 sin6_addr_low=+16(+0(%si)):u64"
 
+	// The peer address of an accepted connection is not available from
+	// the arguments to accept(2) until it returns, so it is read from
+	// the struct sock_common of the new socket returned by
+	// inet_csk_accept() (see acceptAddressLayout).
+	networkKretprobeAcceptSymbol = "inet_csk_accept"
+
+	// The most peer addresses remembered for tasks whose accept result
+	// event has not been seen yet, and how long they are remembered.
+	// The result event follows the kretprobe in the same task as the
+	// system call returns, so they are normally only remembered briefly.
+	networkAcceptMaxTasks   = 4096
+	networkAcceptAddressTTL = time.Second
+
 	networkKprobeSendtoSymbol    = "sys_sendto"
 	networkKprobeSendtoFetchargs = "fd=%di sa_family=+0(%r8):u16 " +
 		"sin_port=+2(%r8):u16 sin_addr=+4(%r8):u32 " +
@@ -75,8 +120,82 @@ const (
 		"sin6_port=+2(%r8):u16 sin6_addr_high=+8(%r8):u64 sin6_addr_low=+16(%r8):u64"
 )
 
+// acceptAddressLayout describes where the peer address is found in the
+// struct sock_common of a new socket.
+type acceptAddressLayout struct {
+	// Byte offsets of skc_daddr, skc_dport, skc_family, and skc_v6_daddr
+	daddr   uint32
+	dport   uint32
+	family  uint32
+	v6Daddr uint32
+
+	// true if the offsets were derived from kernel BTF type information
+	fromBTF bool
+}
+
+// These offsets have been stable since at least Linux 3.x.
+var acceptAddressLayoutBuiltin = acceptAddressLayout{
+	daddr:   0,
+	dport:   12,
+	family:  16,
+	v6Daddr: 56,
+}
+
+// newAcceptAddressLayout returns the struct sock_common layout to use for the
+// accept kretprobe. Offsets are derived from the running kernel's BTF type
+// information when it is available (spec is not nil); otherwise, the
+// built-in offsets are used.
+func newAcceptAddressLayout(spec *btf.Spec) *acceptAddressLayout {
+	if spec == nil {
+		return &acceptAddressLayoutBuiltin
+	}
+
+	layout := acceptAddressLayout{fromBTF: true}
+	members := []struct {
+		name   string
+		offset *uint32
+	}{
+		{"skc_daddr", &layout.daddr},
+		{"skc_dport", &layout.dport},
+		{"skc_family", &layout.family},
+		{"skc_v6_daddr", &layout.v6Daddr},
+	}
+	for _, m := range members {
+		var ok bool
+		*m.offset, ok = spec.StructMemberOffset("sock_common", m.name)
+		if !ok {
+			glog.V(1).Info("Kernel BTF does not describe sock_common, using built-in offsets")
+			return &acceptAddressLayoutBuiltin
+		}
+	}
+	glog.V(1).Info("Using sock_common offsets derived from kernel BTF")
+	return &layout
+}
+
+// fetchargs returns the fetchargs string for the accept kretprobe.
+func (l *acceptAddressLayout) fetchargs() string {
+	return fmt.Sprintf("sa_family=+%d($retval):u16 "+
+		"sin_port=+%d($retval):u16 sin_addr=+%d($retval):u32 "+
+		"sin6_port=+%d($retval):u16 sin6_addr_high=+%d($retval):u64 sin6_addr_low=+%d($retval):u64",
+		l.family, l.dport, l.daddr, l.dport, l.v6Daddr, l.v6Daddr+8)
+}
+
+// acceptAddress is a peer address captured by the accept kretprobe, along
+// with the monotime of the sample that captured it.
+type acceptAddress struct {
+	data perf.TraceEventSampleData
+	time uint64
+}
+
 type networkFilter struct {
 	sensor *Sensor
+
+	// Peer addresses captured by the accept kretprobe waiting for the
+	// accept result event from the same task, keyed by tid. Tasks that
+	// never see their result event, such as those killed while in
+	// accept(2), are forgotten when more recently used tasks need room.
+	acceptLock      sync.Mutex
+	acceptAddresses *keyedLRU
 }
 
 func (f *networkFilter) newNetworkEvent(
@@ -168,7 +287,79 @@ func (f *networkFilter) decodeSysEnterAccept(sample *perf.SampleRecord, data per
 	return event, nil
 }
 
+// acceptAddressKey returns the key of the task of a sample by its thread id,
+// which is the kernel's pid of the task (common_pid), or "" if it is unknown.
+func acceptAddressKey(data perf.TraceEventSampleData) string {
+	if tid, _ := data["common_pid"].(int32); tid != 0 {
+		return strconv.FormatInt(int64(tid), 10)
+	}
+	return ""
+}
+
+func (f *networkFilter) decodeInetCskAccept(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	key := acceptAddressKey(data)
+	if key == "" {
+		return nil, nil
+	}
+
+	address := make(perf.TraceEventSampleData, len(networkAcceptAddressFields))
+	for _, k := range networkAcceptAddressFields {
+		address[k] = data[k]
+	}
+
+	f.acceptLock.Lock()
+	if f.acceptAddresses == nil {
+		f.acceptAddresses = newKeyedLRU(networkAcceptMaxTasks)
+	}
+	f.acceptAddresses.remove(key)
+	f.acceptAddresses.add(key, &acceptAddress{
+		data: address,
+		time: sample.Time,
+	})
+	f.acceptLock.Unlock()
+
+	return nil, nil
+}
+
+// takeAcceptAddress returns and forgets the peer address captured for a task,
+// or returns nil if none was captured within networkAcceptAddressTTL before
+// the specified monotime.
+func (f *networkFilter) takeAcceptAddress(
+	key string,
+	now uint64,
+) perf.TraceEventSampleData {
+	f.acceptLock.Lock()
+	defer f.acceptLock.Unlock()
+
+	if f.acceptAddresses == nil {
+		return nil
+	}
+	a, ok := f.acceptAddresses.get(key).(*acceptAddress)
+	if !ok {
+		return nil
+	}
+	f.acceptAddresses.remove(key)
+	if now-a.time > uint64(networkAcceptAddressTTL) {
+		return nil
+	}
+	return a.data
+}
+
 func (f *networkFilter) decodeSysExitAccept(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	if key := acceptAddressKey(data); key != "" {
+		address := f.takeAcceptAddress(key, sample.Time)
+
+		// Only successful accepts have a peer address. Add it to the
+		// sample data so that it is both decoded and filterable.
+		family, _ := address["sa_family"].(uint16)
+		ret, _ := data["ret"].(int64)
+		if address != nil && ret >= 0 && (family == 2 || family == 10) {
+			for k, v := range address {
+				data[k] = v
+			}
+		}
+	}
+
 	event := f.newNetworkEvent(api.NetworkEventType_NETWORK_EVENT_TYPE_ACCEPT_RESULT, sample, data)
 	return event, nil
 }
@@ -300,12 +491,27 @@ func registerEvent(
 	fn perf.TraceEventDecoderFn,
 	filter networkFilterItem,
 	filterTypes expression.FieldTypeMap,
+) {
+	registerDerivedEvent(sensor, subscr, name, fn, filter, filterTypes, nil)
+}
+
+// registerDerivedEvent is like registerEvent, but also accepts the types of
+// fields that the decoder derives and adds to the sample data.
+func registerDerivedEvent(
+	sensor *Sensor,
+	subscr *subscription,
+	name string,
+	fn perf.TraceEventDecoderFn,
+	filter networkFilterItem,
+	filterTypes expression.FieldTypeMap,
+	derivedTypes expression.FieldTypeMap,
 ) {
 	if !filter.wildcard && filter.filter == nil {
 		return
 	}
 
 	eventID, err := sensor.Monitor.RegisterTracepoint(name, fn,
+		perf.WithEventAttr(subscr.eventAttr),
 		perf.WithEventGroup(subscr.eventGroupID))
 	if err != nil {
 		subscr.logStatus(
@@ -314,7 +520,9 @@ func registerEvent(
 		return
 	}
 
-	_, err = subscr.addEventSink(eventID, filter.filter, filterTypes)
+	_, err = subscr.addDerivedEventSink(eventID,
+		sensor.applyDefaultFilter(DefaultFilterNetwork, filter.filter),
+		filterTypes, derivedTypes)
 	if err != nil {
 		subscr.logStatus(
 			code.Code_UNKNOWN,
@@ -337,6 +545,7 @@ func registerKprobe(
 	}
 
 	eventID, err := sensor.RegisterKprobe(symbol, false, fetchargs, fn,
+		perf.WithEventAttr(subscr.eventAttr),
 		perf.WithEventGroup(subscr.eventGroupID))
 	if err != nil {
 		subscr.logStatus(
@@ -345,7 +554,9 @@ func registerKprobe(
 		return
 	}
 
-	_, err = subscr.addEventSink(eventID, filter.filter, filterTypes)
+	_, err = subscr.addEventSink(eventID,
+		sensor.applyDefaultFilter(DefaultFilterNetwork, filter.filter),
+		filterTypes)
 	if err != nil {
 		subscr.logStatus(
 			code.Code_UNKNOWN,
@@ -368,10 +579,30 @@ func registerNetworkEvents(
 		sensor: sensor,
 	}
 
+	if nfs.acceptResultFilters.wildcard || nfs.acceptResultFilters.filter != nil {
+		// This kprobe produces no events of its own; it only records
+		// peer addresses for the accept result events.
+		layout := sensor.acceptAddressLayout
+		if layout == nil {
+			layout = &acceptAddressLayoutBuiltin
+		}
+		_, err := sensor.RegisterKprobe(networkKretprobeAcceptSymbol,
+			true, layout.fetchargs(),
+			f.decodeInetCskAccept,
+			perf.WithEventAttr(subscr.eventAttr),
+			perf.WithEventGroup(subscr.eventGroupID))
+		if err != nil {
+			subscr.logStatus(
+				code.Code_UNKNOWN,
+				fmt.Sprintf("Could not register network kprobe %s; accept results will not include peer addresses: %v",
+					networkKretprobeAcceptSymbol, err))
+		}
+	}
+
 	registerEvent(sensor, subscr, "syscalls/sys_enter_accept", f.decodeSysEnterAccept, nfs.acceptAttemptFilters, networkAttemptEventTypes)
-	registerEvent(sensor, subscr, "syscalls/sys_exit_accept", f.decodeSysExitAccept, nfs.acceptResultFilters, networkResultEventTypes)
+	registerDerivedEvent(sensor, subscr, "syscalls/sys_exit_accept", f.decodeSysExitAccept, nfs.acceptResultFilters, networkResultEventTypes, networkAcceptResultDerivedEventTypes)
 	registerEvent(sensor, subscr, "syscalls/sys_enter_accept4", f.decodeSysEnterAccept, nfs.acceptAttemptFilters, networkAttemptEventTypes)
-	registerEvent(sensor, subscr, "syscalls/sys_exit_accept4", f.decodeSysExitAccept, nfs.acceptResultFilters, networkResultEventTypes)
+	registerDerivedEvent(sensor, subscr, "syscalls/sys_exit_accept4", f.decodeSysExitAccept, nfs.acceptResultFilters, networkResultEventTypes, networkAcceptResultDerivedEventTypes)
 
 	registerKprobe(sensor, subscr, networkKprobeBindSymbol, networkKprobeBindFetchargs, f.decodeSysBind, nfs.bindAttemptFilters, networkAttemptWithAddressEventTypes)
 	registerEvent(sensor, subscr, "syscalls/sys_exit_bind", f.decodeSysExitBind, nfs.bindResultFilters, networkResultEventTypes)
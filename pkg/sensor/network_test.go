@@ -0,0 +1,83 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/sys/btf"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func TestAcceptAddressLayoutFetchargs(t *testing.T) {
+	expected := "sa_family=+16($retval):u16 " +
+		"sin_port=+12($retval):u16 sin_addr=+0($retval):u32 " +
+		"sin6_port=+12($retval):u16 sin6_addr_high=+56($retval):u64 sin6_addr_low=+64($retval):u64"
+	if got := acceptAddressLayoutBuiltin.fetchargs(); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	// The built-in offsets are used unless BTF describes all members
+	if layout := newAcceptAddressLayout(nil); layout != &acceptAddressLayoutBuiltin {
+		t.Errorf("Expected built-in layout without BTF, got %+v", layout)
+	}
+	if layout := newAcceptAddressLayout(&btf.Spec{}); layout != &acceptAddressLayoutBuiltin {
+		t.Errorf("Expected built-in layout from empty BTF, got %+v", layout)
+	}
+}
+
+func TestAcceptAddressExpiry(t *testing.T) {
+	f := &networkFilter{}
+	capture := func(tid int32, time uint64) {
+		f.decodeInetCskAccept(&perf.SampleRecord{Time: time},
+			perf.TraceEventSampleData{
+				"common_pid": tid,
+				"sa_family":  uint16(2),
+			})
+	}
+
+	// Addresses are matched by thread, and only once
+	capture(100, 0)
+	capture(101, 0)
+	if a := f.takeAcceptAddress("101", 10); a == nil {
+		t.Error("Expected an address for thread 101")
+	}
+	if a := f.takeAcceptAddress("101", 10); a != nil {
+		t.Errorf("Unexpected address %v taken twice", a)
+	}
+
+	// Addresses that were captured too long ago are forgotten
+	ttl := uint64(networkAcceptAddressTTL)
+	if a := f.takeAcceptAddress("100", ttl+1); a != nil {
+		t.Errorf("Unexpected expired address %v", a)
+	}
+
+	// Capturing again for the same thread replaces its address
+	capture(100, ttl)
+	if a := f.takeAcceptAddress("100", ttl+10); a == nil {
+		t.Error("Expected an address for thread 100")
+	}
+
+	// The number of threads remembered is bounded
+	for tid := int32(1); tid <= networkAcceptMaxTasks+1; tid++ {
+		capture(tid, 0)
+	}
+	if a := f.takeAcceptAddress("1", 0); a != nil {
+		t.Errorf("Unexpected address %v for least recently used thread", a)
+	}
+	if a := f.takeAcceptAddress("2", 0); a == nil {
+		t.Error("Expected an address for thread 2")
+	}
+}
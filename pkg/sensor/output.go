@@ -0,0 +1,144 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// OutputEncoder serializes telemetry events for transports that write them
+// somewhere other than a gRPC stream.
+type OutputEncoder interface {
+	// Encode returns the serialized form of an event, including any
+	// framing needed to separate it from the events around it. It may
+	// return nothing for events that the encoding cannot represent.
+	Encode(event *api.TelemetryEvent) ([]byte, error)
+
+	// Extension returns the file name extension conventionally used for
+	// the encoding, without the leading dot.
+	Extension() string
+}
+
+// NewOutputEncoder returns an OutputEncoder for the named format, which may
+// be "protobuf", "json", "any", "cef", or "sysmon".
+func NewOutputEncoder(format string) (OutputEncoder, error) {
+	switch format {
+	case "protobuf":
+		return protobufOutputEncoder{}, nil
+	case "json":
+		return jsonOutputEncoder{}, nil
+	case "any":
+		return anyOutputEncoder{}, nil
+	case "cef":
+		return cefOutputEncoder{}, nil
+	case "sysmon":
+		return sysmonOutputEncoder{
+			now:           time.Now,
+			syscallTables: sys.MachineArch() == syscallTableArch,
+		}, nil
+	}
+	return nil, fmt.Errorf("Unknown output format %q", format)
+}
+
+// protobufOutputEncoder encodes each event as a varint length followed by
+// the event's protobuf encoding.
+type protobufOutputEncoder struct{}
+
+func (protobufOutputEncoder) Encode(event *api.TelemetryEvent) ([]byte, error) {
+	b, err := proto.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	return append(proto.EncodeVarint(uint64(len(b))), b...), nil
+}
+
+func (protobufOutputEncoder) Extension() string {
+	return "pb"
+}
+
+// jsonOutputEncoder encodes each event as a single line of JSON.
+type jsonOutputEncoder struct{}
+
+func (jsonOutputEncoder) Encode(event *api.TelemetryEvent) ([]byte, error) {
+	var buf bytes.Buffer
+	m := jsonpb.Marshaler{}
+	if err := m.Marshal(&buf, event); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+func (jsonOutputEncoder) Extension() string {
+	return "json"
+}
+
+// anyOutputEncoder encodes each event as a varint length followed by the
+// protobuf encoding of an api.AnyTelemetryEvent, which carries the event's
+// payload in a google.protobuf.Any rather than the event oneof.
+type anyOutputEncoder struct{}
+
+func (anyOutputEncoder) Encode(event *api.TelemetryEvent) ([]byte, error) {
+	ae, err := newAnyTelemetryEvent(event)
+	if err != nil {
+		return nil, err
+	}
+	b, err := proto.Marshal(ae)
+	if err != nil {
+		return nil, err
+	}
+	return append(proto.EncodeVarint(uint64(len(b))), b...), nil
+}
+
+func (anyOutputEncoder) Extension() string {
+	return "pb"
+}
+
+func newAnyTelemetryEvent(event *api.TelemetryEvent) (*api.AnyTelemetryEvent, error) {
+	ae := &api.AnyTelemetryEvent{}
+	if event.Event != nil {
+		// Each oneof wrapper type has a single field holding the
+		// payload message.
+		payload, ok := reflect.ValueOf(event.Event).Elem().Field(0).
+			Interface().(proto.Message)
+		if !ok {
+			return nil, fmt.Errorf("Unsupported event payload %T",
+				event.Event)
+		}
+		any, err := ptypes.MarshalAny(payload)
+		if err != nil {
+			return nil, err
+		}
+		ae.Payload = any
+	}
+
+	// The event may be shared with other subscriptions, so make a shallow
+	// copy without the payload.
+	e := *event
+	e.Event = nil
+	ae.Event = &e
+	return ae, nil
+}
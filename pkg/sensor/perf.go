@@ -80,6 +80,9 @@ func registerPerformanceEvents(
 		attr := perf.EventAttr{
 			SampleType: perf.PERF_SAMPLE_CPU | perf.PERF_SAMPLE_RAW,
 		}
+		if subscr.eventAttr != nil {
+			attr.SampleType = subscr.eventAttr.SampleType
+		}
 		switch pef.SampleRateType {
 		case api.SampleRateType_SAMPLE_RATE_TYPE_PERIOD:
 			if rate, ok := pef.SampleRate.(*api.PerformanceEventFilter_Period); !ok {
@@ -109,6 +112,7 @@ func registerPerformanceEvents(
 		}
 
 		counters := make([]perf.CounterEventGroupMember, 0, len(pef.Events))
+		hardware := false
 		for _, e := range pef.Events {
 			m := perf.CounterEventGroupMember{
 				Config: e.Config,
@@ -116,8 +120,10 @@ func registerPerformanceEvents(
 			switch e.Type {
 			case api.PerformanceEventType_PERFORMANCE_EVENT_TYPE_HARDWARE:
 				m.EventType = perf.EventTypeHardware
+				hardware = true
 			case api.PerformanceEventType_PERFORMANCE_EVENT_TYPE_HARDWARE_CACHE:
 				m.EventType = perf.EventTypeHardwareCache
+				hardware = true
 			case api.PerformanceEventType_PERFORMANCE_EVENT_TYPE_SOFTWARE:
 				m.EventType = perf.EventTypeSoftware
 			default:
@@ -129,6 +135,18 @@ func registerPerformanceEvents(
 			counters = append(counters, m)
 		}
 
+		if subscr.sampleDataSrc {
+			// Only memory accesses counted by the hardware have a
+			// data source.
+			if hardware {
+				attr.SampleType |= perf.PERF_SAMPLE_DATA_SRC
+			} else {
+				subscr.logStatus(
+					code.Code_INVALID_ARGUMENT,
+					"SAMPLE_FIELD_DATA_SRC requires HARDWARE or HARDWARE_CACHE performance events")
+			}
+		}
+
 		eventName := fmt.Sprintf("Performance Counters %p", pef)
 		groupID, eventID, err := sensor.Monitor.RegisterCounterEventGroup(
 			eventName, counters, f.decodePerfCounterEvent,
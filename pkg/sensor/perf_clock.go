@@ -0,0 +1,81 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"golang.org/x/sys/unix"
+)
+
+// perfClockIDs maps the clocks that subscriptions may select to their clock
+// ids.
+var perfClockIDs = map[api.PerfClock]int32{
+	api.PerfClock_PERF_CLOCK_MONOTONIC:     unix.CLOCK_MONOTONIC,
+	api.PerfClock_PERF_CLOCK_BOOTTIME:      unix.CLOCK_BOOTTIME,
+	api.PerfClock_PERF_CLOCK_REALTIME:      unix.CLOCK_REALTIME,
+	api.PerfClock_PERF_CLOCK_MONOTONIC_RAW: unix.CLOCK_MONOTONIC_RAW,
+}
+
+// perfClockGroupOptions returns the clock that a subscription requesting
+// clock will use, and the options to register its event group with. The
+// default clock is used if the kernel can not timestamp events with the
+// requested one.
+func perfClockGroupOptions(
+	clock api.PerfClock,
+) (api.PerfClock, []perf.EventGroupOption, error) {
+	if clock == api.PerfClock_PERF_CLOCK_DEFAULT {
+		return clock, nil, nil
+	}
+	clockID, ok := perfClockIDs[clock]
+	if !ok {
+		return clock, nil, fmt.Errorf("Invalid perf clock %d", clock)
+	}
+	if !perf.HaveClockID() {
+		return api.PerfClock_PERF_CLOCK_DEFAULT, nil, nil
+	}
+	return clock, []perf.EventGroupOption{perf.WithClockID(clockID)}, nil
+}
+
+// newSubscriptionPerfClock returns a dispatch function that reports the time
+// of each event by the specified clock before passing it to dispatchFn.
+// Events sampled from the subscription's own kernel events already carry the
+// time read by the kernel; the times of other events, such as the Sensor's
+// process events, are converted from their monotimes.
+func newSubscriptionPerfClock(
+	sensor *Sensor,
+	clock api.PerfClock,
+	dispatchFn eventSinkDispatchFn,
+) eventSinkDispatchFn {
+	clockID := perfClockIDs[clock]
+	return func(e *api.TelemetryEvent) {
+		if e.PerfClockNanos == 0 {
+			// The event may be shared with other subscriptions,
+			// so annotate a shallow copy.
+			c := *e
+			c.PerfClockNanos = e.SensorMonotimeNanos +
+				sensor.bootMonotimeNanos +
+				sys.CurrentClockTime(clockID) -
+				sys.CurrentMonotonicRaw()
+			e = &c
+		}
+		dispatchFn(e)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestPerfClockGroupOptions(t *testing.T) {
+	clock, options, err := perfClockGroupOptions(api.PerfClock_PERF_CLOCK_DEFAULT)
+	if err != nil || clock != api.PerfClock_PERF_CLOCK_DEFAULT || options != nil {
+		t.Errorf("Unexpected default clock %s %v %v", clock, options, err)
+	}
+
+	if _, _, err = perfClockGroupOptions(api.PerfClock(99)); err == nil {
+		t.Error("Expected error for invalid clock")
+	}
+
+	clock, options, err = perfClockGroupOptions(api.PerfClock_PERF_CLOCK_BOOTTIME)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perf.HaveClockID() {
+		if clock != api.PerfClock_PERF_CLOCK_BOOTTIME || len(options) != 1 {
+			t.Errorf("Expected boottime clock, got %s %v", clock, options)
+		}
+	} else if clock != api.PerfClock_PERF_CLOCK_DEFAULT || options != nil {
+		t.Errorf("Expected default clock, got %s %v", clock, options)
+	}
+}
+
+func TestSubscriptionPerfClock(t *testing.T) {
+	sensor := &Sensor{bootMonotimeNanos: sys.CurrentMonotonicRaw()}
+	var events []*api.TelemetryEvent
+	dispatchFn := newSubscriptionPerfClock(sensor,
+		api.PerfClock_PERF_CLOCK_REALTIME,
+		func(e *api.TelemetryEvent) { events = append(events, e) })
+
+	// Events stamped by the kernel are passed through
+	stamped := &api.TelemetryEvent{PerfClockNanos: 12345}
+	dispatchFn(stamped)
+
+	// Other events are converted without modifying the shared event
+	shared := &api.TelemetryEvent{
+		SensorMonotimeNanos: sys.CurrentMonotonicRaw() -
+			sensor.bootMonotimeNanos,
+	}
+	before := sys.CurrentClockTime(unix.CLOCK_REALTIME)
+	dispatchFn(shared)
+	after := sys.CurrentClockTime(unix.CLOCK_REALTIME)
+
+	if len(events) != 2 || events[0] != stamped ||
+		events[0].PerfClockNanos != 12345 {
+		t.Fatalf("Expected stamped event to be unchanged, got %v", events)
+	}
+	if shared.PerfClockNanos != 0 || events[1] == shared {
+		t.Fatalf("Shared event was modified")
+	}
+	// Allow for the time between reading the clocks
+	slop := int64(time.Second)
+	if ns := events[1].PerfClockNanos; ns < before-slop || ns > after+slop {
+		t.Errorf("Expected time between %d and %d, got %d",
+			before, after, ns)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"math"
+	"math/bits"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+const (
+	defaultPidCardinalityInterval = 60 * time.Second
+
+	// The number of bits of a pid's hash that select a HyperLogLog
+	// register. 2^12 registers use 4KB and have a standard error of about
+	// 1.6%.
+	hyperLogLogPrecision = 12
+	hyperLogLogRegisters = 1 << hyperLogLogPrecision
+)
+
+// hyperLogLog estimates the number of distinct pids added to it.
+type hyperLogLog struct {
+	registers [hyperLogLogRegisters]uint8
+}
+
+func (h *hyperLogLog) add(pid int32) {
+	hash, _ := pidHash(pid)
+	index := hash >> (64 - hyperLogLogPrecision)
+
+	// The remaining bits, with a sentinel so that the rank is bounded
+	rest := hash<<hyperLogLogPrecision | 1<<(hyperLogLogPrecision-1)
+	rank := uint8(bits.LeadingZeros64(rest) + 1)
+	if rank > h.registers[index] {
+		h.registers[index] = rank
+	}
+}
+
+func (h *hyperLogLog) reset() {
+	h.registers = [hyperLogLogRegisters]uint8{}
+}
+
+// estimateDistinctPids estimates the number of distinct pids added to any of
+// the specified HyperLogLogs.
+func estimateDistinctPids(sketches []*hyperLogLog) uint64 {
+	const m = float64(hyperLogLogRegisters)
+	alpha := 0.7213 / (1 + 1.079/m)
+
+	var (
+		sum   float64
+		zeros int
+	)
+	for i := 0; i < hyperLogLogRegisters; i++ {
+		var r uint8
+		for _, h := range sketches {
+			if h.registers[i] > r {
+				r = h.registers[i]
+			}
+		}
+		if r == 0 {
+			zeros++
+		}
+		sum += math.Ldexp(1, -int(r))
+	}
+
+	estimate := alpha * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		// Linear counting is more accurate for small cardinalities
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// pidCardinality periodically reports an estimate of the number of distinct
+// processes whose events matched a subscription during a sliding window. The
+// window is divided into one HyperLogLog per reporting interval, the oldest
+// of which is reset after each report.
+type pidCardinality struct {
+	sync.Mutex
+
+	newEvent   func() *api.TelemetryEvent
+	dispatchFn eventSinkDispatchFn
+	interval   time.Duration
+	window     time.Duration
+
+	sketches []*hyperLogLog
+	current  int
+
+	stopChan chan struct{}
+}
+
+func newPidCardinality(
+	newEvent func() *api.TelemetryEvent,
+	dispatchFn eventSinkDispatchFn,
+	interval time.Duration,
+	window time.Duration,
+) *pidCardinality {
+	n := int((window + interval - 1) / interval)
+	if n < 1 {
+		n = 1
+	}
+	c := &pidCardinality{
+		newEvent:   newEvent,
+		dispatchFn: dispatchFn,
+		interval:   interval,
+		window:     time.Duration(n) * interval,
+		sketches:   make([]*hyperLogLog, n),
+	}
+	for i := range c.sketches {
+		c.sketches[i] = &hyperLogLog{}
+	}
+	return c
+}
+
+// add counts the process of an event matched by the subscription. Events
+// that are not associated with a process are ignored.
+func (c *pidCardinality) add(e *api.TelemetryEvent) {
+	if e.ProcessTgid == 0 {
+		return
+	}
+	c.Lock()
+	c.sketches[c.current].add(e.ProcessTgid)
+	c.Unlock()
+}
+
+// flush dispatches the estimate for the current window and starts the next
+// interval.
+func (c *pidCardinality) flush() {
+	c.Lock()
+	distinct := estimateDistinctPids(c.sketches)
+	c.current = (c.current + 1) % len(c.sketches)
+	c.sketches[c.current].reset()
+	c.Unlock()
+
+	e := c.newEvent()
+	e.Event = &api.TelemetryEvent_SubscriptionStats{
+		SubscriptionStats: &api.SubscriptionStatsEvent{
+			WindowNanos:  int64(c.window),
+			DistinctPids: distinct,
+		},
+	}
+	c.dispatchFn(e)
+}
+
+func (c *pidCardinality) start() {
+	c.stopChan = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.stopChan:
+				return
+			case <-ticker.C:
+				c.flush()
+			}
+		}
+	}()
+}
+
+func (c *pidCardinality) stop() {
+	if c.stopChan != nil {
+		close(c.stopChan)
+	}
+}
+
+// newSubscriptionPidCardinality creates the pid cardinality estimator for a
+// subscription, returning it along with a dispatch function that counts
+// events before passing them to dispatchFn. The estimator is not started.
+func newSubscriptionPidCardinality(
+	sensor *Sensor,
+	pc *api.PidCardinality,
+	dispatchFn eventSinkDispatchFn,
+) (*pidCardinality, eventSinkDispatchFn) {
+	interval := defaultPidCardinalityInterval
+	if pc.IntervalSeconds != 0 {
+		interval = time.Duration(pc.IntervalSeconds) * time.Second
+	}
+	window := interval
+	if pc.WindowSeconds != 0 {
+		window = time.Duration(pc.WindowSeconds) * time.Second
+	}
+
+	c := newPidCardinality(sensor.NewEvent, dispatchFn, interval, window)
+	return c, func(e *api.TelemetryEvent) {
+		c.add(e)
+		dispatchFn(e)
+	}
+}
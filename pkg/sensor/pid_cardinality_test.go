@@ -0,0 +1,69 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+func TestPidCardinality(t *testing.T) {
+	var events []*api.TelemetryEvent
+	c := newPidCardinality(
+		func() *api.TelemetryEvent { return &api.TelemetryEvent{} },
+		func(e *api.TelemetryEvent) { events = append(events, e) },
+		10*time.Second, 15*time.Second)
+
+	add := func(first, n int32) {
+		for pid := first; pid < first+n; pid++ {
+			// Repeated pids are only counted once
+			for i := 0; i < 3; i++ {
+				c.add(&api.TelemetryEvent{ProcessTgid: pid})
+			}
+		}
+	}
+	estimate := func() uint64 {
+		c.flush()
+		stats := events[len(events)-1].GetSubscriptionStats()
+		if stats.WindowNanos != int64(20*time.Second) {
+			t.Errorf("Expected window rounded up to 20s, got %d",
+				stats.WindowNanos)
+		}
+		return stats.DistinctPids
+	}
+	within := func(estimate, actual uint64) bool {
+		return float64(estimate) > 0.95*float64(actual) &&
+			float64(estimate) < 1.05*float64(actual)
+	}
+
+	add(1, 10000)
+	if n := estimate(); !within(n, 10000) {
+		t.Errorf("Expected about 10000 distinct pids, got %d", n)
+	}
+	add(20001, 50)
+	if n := estimate(); !within(n, 10050) {
+		t.Errorf("Expected about 10050 distinct pids, got %d", n)
+	}
+
+	// The first interval has left the window
+	if n := estimate(); n != 50 {
+		t.Errorf("Expected 50 distinct pids, got %d", n)
+	}
+	if n := estimate(); n != 0 {
+		t.Errorf("Expected 0 distinct pids, got %d", n)
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+const (
+	defaultPidFilterFalsePositiveRate = 0.01
+
+	// The smallest number of pids that a pid filter's Bloom filter is
+	// sized for, so that small sets don't need to be rebuilt as they grow
+	minPidFilterCapacity = 1024
+)
+
+// pidBloomFilter is a Bloom filter of pids. It may report that a pid is
+// present when it is not, but never the reverse.
+type pidBloomFilter struct {
+	bits   []uint64
+	nbits  uint64
+	hashes uint64
+}
+
+// newPidBloomFilter returns a Bloom filter sized to hold capacity pids with
+// the specified false positive rate.
+func newPidBloomFilter(capacity int, rate float64) *pidBloomFilter {
+	n := float64(capacity)
+	m := math.Ceil(-n * math.Log(rate) / (math.Ln2 * math.Ln2))
+	k := math.Max(1, math.Floor(m/n*math.Ln2+0.5))
+
+	nbits := uint64(m)
+	return &pidBloomFilter{
+		bits:   make([]uint64, (nbits+63)/64),
+		nbits:  nbits,
+		hashes: uint64(k),
+	}
+}
+
+// pidHash returns two independent hashes of a pid, using the splitmix64
+// finalizer, which are combined for each of the Bloom filter's hashes.
+func pidHash(pid int32) (uint64, uint64) {
+	h := uint64(uint32(pid)) + 0x9e3779b97f4a7c15
+	h = (h ^ (h >> 30)) * 0xbf58476d1ce4e5b9
+	h = (h ^ (h >> 27)) * 0x94d049bb133111eb
+	h ^= h >> 31
+	return h, (h >> 32) | (h << 32) | 1
+}
+
+func (b *pidBloomFilter) add(pid int32) {
+	h1, h2 := pidHash(pid)
+	for i := uint64(0); i < b.hashes; i++ {
+		bit := (h1 + i*h2) % b.nbits
+		b.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+func (b *pidBloomFilter) test(pid int32) bool {
+	h1, h2 := pidHash(pid)
+	for i := uint64(0); i < b.hashes; i++ {
+		bit := (h1 + i*h2) % b.nbits
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// pidFilter is the set of processes that a subscription's events are
+// restricted to. Events are checked against a Bloom filter before the exact
+// set, so that events from other processes are usually rejected without a
+// map lookup.
+type pidFilter struct {
+	sync.RWMutex
+
+	followChildren bool
+	rate           float64
+
+	pids  map[int32]bool
+	bloom *pidBloomFilter
+
+	// The number of pids that the Bloom filter was sized for, and the
+	// number of pids that have been removed from the set since it was
+	// built. Bits cannot be cleared from a Bloom filter, so it is rebuilt
+	// once either is exceeded.
+	capacity int
+	removed  int
+}
+
+func newPidFilter(pf *api.PidFilter) (*pidFilter, error) {
+	rate := pf.FalsePositiveRate
+	if rate == 0 {
+		rate = defaultPidFilterFalsePositiveRate
+	} else if rate < 0 || rate >= 1 {
+		return nil, fmt.Errorf("Invalid pid filter false positive rate %v",
+			rate)
+	}
+
+	f := &pidFilter{
+		followChildren: pf.FollowChildren,
+		rate:           rate,
+		pids:           make(map[int32]bool, len(pf.Pids)),
+	}
+	for _, pid := range pf.Pids {
+		f.pids[pid] = true
+	}
+	f.rebuild()
+	return f, nil
+}
+
+// rebuild creates a new Bloom filter from the exact set of pids. The caller
+// must hold the write lock.
+func (f *pidFilter) rebuild() {
+	f.capacity = 2 * len(f.pids)
+	if f.capacity < minPidFilterCapacity {
+		f.capacity = minPidFilterCapacity
+	}
+	f.removed = 0
+	f.bloom = newPidBloomFilter(f.capacity, f.rate)
+	for pid := range f.pids {
+		f.bloom.add(pid)
+	}
+}
+
+func (f *pidFilter) add(pid int32) {
+	f.Lock()
+	if !f.pids[pid] {
+		f.pids[pid] = true
+		if len(f.pids) > f.capacity {
+			f.rebuild()
+		} else {
+			f.bloom.add(pid)
+		}
+	}
+	f.Unlock()
+}
+
+func (f *pidFilter) remove(pid int32) {
+	f.Lock()
+	if f.pids[pid] {
+		delete(f.pids, pid)
+		f.removed++
+		if f.removed > f.capacity/2 {
+			f.rebuild()
+		}
+	}
+	f.Unlock()
+}
+
+// fork adds a child process to the set if its parent is in the set and the
+// filter follows children.
+func (f *pidFilter) fork(parent, child int32) {
+	if !f.followChildren {
+		return
+	}
+	f.RLock()
+	ok := f.pids[parent]
+	f.RUnlock()
+	if ok {
+		f.add(child)
+	}
+}
+
+func (f *pidFilter) match(pid int32) bool {
+	f.RLock()
+	ok := f.bloom.test(pid) && f.pids[pid]
+	f.RUnlock()
+	return ok
+}
+
+// pidFilterSet is the set of the pid filters of all subscriptions for a
+// sensor, which are updated as processes are created and exit.
+type pidFilterSet struct {
+	sync.Mutex
+	filters map[int32]*pidFilter
+}
+
+func (s *pidFilterSet) add(groupID int32, f *pidFilter) {
+	s.Lock()
+	if s.filters == nil {
+		s.filters = make(map[int32]*pidFilter)
+	}
+	s.filters[groupID] = f
+	s.Unlock()
+}
+
+func (s *pidFilterSet) remove(groupID int32) {
+	s.Lock()
+	delete(s.filters, groupID)
+	s.Unlock()
+}
+
+// fork is called when a new process is created.
+func (s *pidFilterSet) fork(parent, child int32) {
+	s.Lock()
+	for _, f := range s.filters {
+		f.fork(parent, child)
+	}
+	s.Unlock()
+}
+
+// exit is called once the exit event of a process has been dispatched.
+func (s *pidFilterSet) exit(event *api.TelemetryEvent) {
+	pe, ok := event.Event.(*api.TelemetryEvent_Process)
+	if !ok || pe.Process.Type != api.ProcessEventType_PROCESS_EVENT_TYPE_EXIT ||
+		event.ProcessPid != event.ProcessTgid {
+		return
+	}
+
+	s.Lock()
+	for _, f := range s.filters {
+		f.remove(event.ProcessTgid)
+	}
+	s.Unlock()
+}
@@ -0,0 +1,87 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+func TestPidBloomFilter(t *testing.T) {
+	b := newPidBloomFilter(1000, 0.01)
+	for pid := int32(1); pid <= 1000; pid++ {
+		b.add(pid)
+	}
+	for pid := int32(1); pid <= 1000; pid++ {
+		if !b.test(pid) {
+			t.Fatalf("Expected pid %d to be present", pid)
+		}
+	}
+
+	falsePositives := 0
+	for pid := int32(100000); pid < 110000; pid++ {
+		if b.test(pid) {
+			falsePositives++
+		}
+	}
+	// Allow for some variance from the target rate of 1%
+	if falsePositives > 300 {
+		t.Errorf("Expected about 100 false positives, got %d",
+			falsePositives)
+	}
+}
+
+func TestPidFilter(t *testing.T) {
+	if _, err := newPidFilter(&api.PidFilter{
+		Pids:              []int32{1},
+		FalsePositiveRate: 1.5,
+	}); err == nil {
+		t.Error("Expected error for invalid false positive rate")
+	}
+
+	f, err := newPidFilter(&api.PidFilter{
+		Pids:           []int32{100, 200},
+		FollowChildren: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.match(100) || !f.match(200) || f.match(300) {
+		t.Error("Unexpected initial pid filter membership")
+	}
+
+	f.fork(100, 300)
+	f.fork(400, 500)
+	if !f.match(300) || f.match(500) {
+		t.Error("Expected only children of included processes to be added")
+	}
+
+	f.remove(100)
+	if f.match(100) {
+		t.Error("Expected removed pid not to match")
+	}
+
+	// Growing past the capacity rebuilds the Bloom filter
+	for pid := int32(1000); pid < 1000+minPidFilterCapacity; pid++ {
+		f.add(pid)
+	}
+	if f.capacity <= minPidFilterCapacity {
+		t.Errorf("Expected capacity to grow, got %d", f.capacity)
+	}
+	if !f.match(200) || !f.match(1000) || f.match(100) {
+		t.Error("Unexpected pid filter membership after rebuild")
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
@@ -65,6 +66,8 @@ var processExitEventTypes = expression.FieldTypeMap{
 	"exit_status":      expression.ValueTypeUnsignedInt32,
 	"exit_signal":      expression.ValueTypeUnsignedInt32,
 	"exit_core_dumped": expression.ValueTypeBool,
+	"exit_command":     expression.ValueTypeString,
+	"exit_executable":  expression.ValueTypeString,
 }
 
 var processUpdateEventTypes = expression.FieldTypeMap{
@@ -174,6 +177,20 @@ func newCredentials(
 	}
 }
 
+// toAPI returns the api.Credentials equivalent of c.
+func (c *Cred) toAPI() *api.Credentials {
+	return &api.Credentials{
+		Uid:   c.UID,
+		Gid:   c.GID,
+		Euid:  c.EUID,
+		Egid:  c.EGID,
+		Suid:  c.SUID,
+		Sgid:  c.SGID,
+		Fsuid: c.FSUID,
+		Fsgid: c.FSGID,
+	}
+}
+
 const cloneEventThreshold = uint64(100 * time.Millisecond)
 
 type cloneEvent struct {
@@ -234,6 +251,10 @@ type Task struct {
 	// commit_creds().
 	Creds *Cred
 
+	// PreviousCreds are the credentials that were replaced by the most
+	// recent change to Creds observed via commit_creds(), if any.
+	PreviousCreds *Cred
+
 	// ContainerID is the ID of the container to which the task belongs,
 	// if any.
 	ContainerID string
@@ -255,6 +276,63 @@ type Task struct {
 	// process can each have their own independent CWD.
 	CWD string
 
+	// Executable is the path of the executable that the process is
+	// running. It is set when the process is created or exec's, and
+	// otherwise resolved lazily from /proc when first needed; empty if
+	// unknown.
+	Executable string
+
+	// ExecLineage is the executables that led to the one that the process
+	// is running, oldest first and ending with its own. It is inherited
+	// from the parent when the process is created and extended when it
+	// exec's. The slice is shared and must not be modified in place.
+	ExecLineage []string
+
+	// Environment is the process's environment variables that are named
+	// in the sensor's configuration. It is resolved lazily from /proc
+	// when first needed and is reset when the process exec's; nil if
+	// not yet resolved.
+	Environment map[string]string
+
+	// MountNamespace is the inode number of the task's mount namespace. It
+	// is resolved lazily from /proc when first needed; zero if unknown.
+	// It is only accessed while holding the ProcessInfoCache's
+	// mountNamespaceLock.
+	MountNamespace uint64
+
+	// Namespaces are the inode numbers of the task's namespaces, by type
+	// as named in /proc/[pid]/ns. They are resolved lazily from /proc when
+	// the task is first seen making a setns or unshare system call and
+	// updated after each such call; nil if not yet resolved.
+	Namespaces map[string]uint64
+
+	// PreviousNamespaces are the namespaces that were replaced by the most
+	// recent change to Namespaces, if any, by type.
+	PreviousNamespaces map[string]uint64
+
+	// status is the task's most recently read process status, used to
+	// enrich events; nil if it has never been read. It is read again from
+	// /proc when statusTime, the monotime of the last attempt to read it,
+	// becomes older than config.Sensor.ProcessStatusTTL, whether or not
+	// that attempt succeeded. Both are only accessed by event decoders,
+	// which the EventMonitor serializes.
+	status     *taskStatus
+	statusTime int64
+
+	// executableUnresolvable is set if Executable could not be resolved
+	// from /proc, so that it is not tried again until the process
+	// exec's.
+	executableUnresolvable bool
+
+	// mountNamespaceUnresolvable is set if MountNamespace could not be
+	// resolved from /proc, so that it is not tried again unless the
+	// task's namespaces are read after it changes them.
+	mountNamespaceUnresolvable bool
+
+	// kernelThread is whether the task is a kernel thread. It is
+	// resolved lazily from /proc when first needed.
+	kernelThread kernelThreadState
+
 	// parent is an internal reference to the parent of this task, which
 	// could be either the thread group leader or another process. Use
 	// Parent() to get the parent of a container.
@@ -264,6 +342,10 @@ type Task struct {
 	// task clone executed by the clone(2) system call. In kernels >= 3.9
 	// this is not necessary
 	pendingClone *cloneEvent
+
+	// clonedChild is the task created by the most recent clone, fork, or
+	// vfork system call made by this task.
+	clonedChild *Task
 }
 
 var rootTask = Task{}
@@ -461,6 +543,23 @@ type ProcessInfoCache struct {
 	ProcessExitEventID   uint64 // api.ProcessEventType_PROCESS_EVENT_TYPE_EXIT
 	ProcessUpdateEventID uint64 // api.ProcessEventType_PROCESS_EVENT_TYPE_UPDATE
 
+	// The number of event sinks of exit events that requested the
+	// resource usage of exiting tasks. Updated atomically.
+	exitResourceUsageSinks int32
+
+	// maxPID is the largest pid that the kernel will assign.
+	maxPID int
+
+	// nsTasks caches the tasks found by LookupNamespaceTask for callers
+	// in pid namespaces other than the initial one.
+	nsTaskLock sync.Mutex
+	nsTasks    map[namespacePID]*Task
+
+	// mountNamespaceLock protects the mount namespaces of tasks, which
+	// are resolved while decoding events and updated when tasks change
+	// their namespaces.
+	mountNamespaceLock sync.Mutex
+
 	startLock  sync.Mutex
 	startQueue []scannerDeferredAction
 	started    bool
@@ -484,6 +583,7 @@ func NewProcessInfoCache(sensor *Sensor) *ProcessInfoCache {
 	}
 
 	maxPID := procFS.MaxPID()
+	cache.maxPID = int(maxPID)
 	if maxPID > config.Sensor.ProcessInfoCacheSize {
 		cache.cache = newMapTaskCache(maxPID)
 	} else {
@@ -515,6 +615,12 @@ func NewProcessInfoCache(sensor *Sensor) *ProcessInfoCache {
 		glog.Fatalf("Failed to register external event: %s", err)
 	}
 
+	// Without the tracing filesystem, the cache only knows about the
+	// tasks found by scanning procfs at startup.
+	if !sensor.tracingAvailable {
+		return cache
+	}
+
 	// Register with the sensor's global event monitor...
 	eventName := "task/task_newtask"
 	_, err = sensor.Monitor.RegisterTracepoint(eventName,
@@ -748,6 +854,117 @@ func (pc *ProcessInfoCache) LookupTask(pid int) *Task {
 	return pc.cache.LookupTask(pid)
 }
 
+// namespacePID is a pid as seen from within the pid namespace identified by
+// its inode number.
+type namespacePID struct {
+	namespace uint64
+	pid       int
+}
+
+// maxNamespaceTasks bounds the number of tasks cached by LookupNamespaceTask.
+const maxNamespaceTasks = 1024
+
+// taskNamespacePIDs returns the pids of a task in each of the pid namespaces
+// that it belongs to, from the initial namespace to its own.
+func taskNamespacePIDs(tgid, pid int) ([]int, error) {
+	var s struct {
+		NSpid []int `NSpid`
+	}
+	if err := procFS.ReadTaskStatus(tgid, pid, &s); err != nil {
+		return nil, err
+	}
+	return s.NSpid, nil
+}
+
+// LookupNamespaceTask finds the task information for a pid that the process
+// tgid named in a system call, which is in that process's pid namespace. The
+// return is nil if the pid is invalid or cannot be resolved, which is normal
+// when the task has already exited. Tasks in pid namespaces nested within
+// that of the process are not resolved.
+func (pc *ProcessInfoCache) LookupNamespaceTask(tgid, pid int) *Task {
+	if tgid <= 0 || tgid > pc.maxPID || pid <= 0 || pid > pc.maxPID {
+		return nil
+	}
+	nspids, err := taskNamespacePIDs(tgid, tgid)
+	if err != nil || len(nspids) == 0 {
+		return nil
+	}
+	if len(nspids) == 1 {
+		// The process is in the initial pid namespace.
+		return pc.LookupTask(pid)
+	}
+
+	namespaces, err := procFS.TaskNamespaces(tgid, tgid)
+	if err != nil {
+		return nil
+	}
+	key := namespacePID{
+		namespace: namespaces["pid"],
+		pid:       pid,
+	}
+	pc.nsTaskLock.Lock()
+	t := pc.nsTasks[key]
+	pc.nsTaskLock.Unlock()
+	if t != nil && t.ExitTime == 0 && pc.LookupTask(t.PID) == t {
+		return t
+	}
+
+	// Nothing but procfs maps pids between namespaces, so search it for a
+	// task with the same depth, pid, and namespace.
+	depth := len(nspids)
+	t = nil
+	procFS.WalkTasks(func(tgid, tid int) bool {
+		p, err := taskNamespacePIDs(tgid, tid)
+		if err != nil || len(p) != depth || p[depth-1] != pid {
+			return true
+		}
+		ns, err := procFS.TaskNamespaces(tgid, tid)
+		if err != nil || ns["pid"] != key.namespace {
+			return true
+		}
+		if tid > 0 && tid <= pc.maxPID {
+			t = pc.LookupTask(tid)
+		}
+		return false
+	})
+
+	pc.nsTaskLock.Lock()
+	if t == nil {
+		delete(pc.nsTasks, key)
+	} else {
+		if pc.nsTasks == nil || len(pc.nsTasks) >= maxNamespaceTasks {
+			pc.nsTasks = make(map[namespacePID]*Task)
+		}
+		pc.nsTasks[key] = t
+	}
+	pc.nsTaskLock.Unlock()
+	return t
+}
+
+// LookupClonedTask finds the task information for the task created by a
+// clone, fork, or vfork system call that the task pid of process tgid made,
+// given the pid of the new task as returned to the caller, which is in the
+// caller's pid namespace. The return is nil if the task cannot be resolved.
+func (pc *ProcessInfoCache) LookupClonedTask(tgid, pid, childPid int) *Task {
+	if pid <= 0 || pid > pc.maxPID || childPid <= 0 {
+		return nil
+	}
+	if c := pc.LookupTask(pid).clonedChild; c != nil {
+		if c.PID == childPid {
+			return c
+		}
+		nspids, err := taskNamespacePIDs(c.TGID, c.PID)
+		if err == nil {
+			for _, p := range nspids {
+				if p == childPid {
+					return c
+				}
+			}
+		}
+	}
+	return pc.LookupNamespaceTask(tgid, childPid)
+}
+
 // LookupTaskAndLeader finds the task information for both a given PID and the
 // thread group leader.
 func (pc *ProcessInfoCache) LookupTaskAndLeader(pid int) (*Task, *Task) {
@@ -776,6 +993,261 @@ func (pc *ProcessInfoCache) LookupTaskContainerInfo(t *Task) *ContainerInfo {
 	return nil
 }
 
+// LookupTaskMountNamespace returns the mount namespace of the specified task,
+// resolving it from /proc if it is not already known. The return is zero if
+// it cannot be resolved, which is normal for tasks that have already exited.
+// Failure is remembered until the task's namespaces are read again.
+func (pc *ProcessInfoCache) LookupTaskMountNamespace(t *Task) uint64 {
+	pc.mountNamespaceLock.Lock()
+	ns := t.MountNamespace
+	resolve := ns == 0 && !t.mountNamespaceUnresolvable && t.ExitTime == 0
+	pc.mountNamespaceLock.Unlock()
+	if !resolve {
+		return ns
+	}
+
+	ns, err := procFS.TaskMountNamespace(t.TGID, t.PID)
+
+	pc.mountNamespaceLock.Lock()
+	defer pc.mountNamespaceLock.Unlock()
+	if t.MountNamespace == 0 {
+		if err == nil {
+			t.MountNamespace = ns
+		} else {
+			t.mountNamespaceUnresolvable = true
+		}
+	}
+	return t.MountNamespace
+}
+
+// LookupTaskExecutable returns the path of the executable that the process
+// to which the specified task belongs is running, resolving it from /proc if
+// it is not already known. The return is empty if it cannot be resolved,
+// which is normal for processes that have already exited and for kernel
+// threads. Failure is remembered until the process exec's.
+func (pc *ProcessInfoCache) LookupTaskExecutable(t *Task) string {
+	t = t.Leader()
+	if t.Executable == "" && !t.executableUnresolvable &&
+		t.ExitTime == 0 && t.TGID != 0 {
+		exe, err := procFS.ProcessExecutable(t.TGID)
+		if err == nil {
+			t.Executable = exe
+		} else {
+			t.executableUnresolvable = true
+		}
+	}
+	return t.Executable
+}
+
+// LookupTaskEnvironment returns the environment variables of the process to
+// which the specified task belongs that are named in the sensor's
+// configuration, resolving them from /proc if they are not already known.
+// Since the environment of a process is fixed when it exec's, they are read at
+// most once per exec. The return is empty if they cannot be resolved, which is
+// normal for processes that have already exited.
+func (pc *ProcessInfoCache) LookupTaskEnvironment(t *Task) map[string]string {
+	t = t.Leader()
+	if t.Environment != nil {
+		return t.Environment
+	}
+
+	env := make(map[string]string)
+	if t.ExitTime == 0 && t.TGID != 0 {
+		var err error
+		env, err = procFS.ProcessEnvironment(t.TGID,
+			config.Sensor.EnvironmentVariables)
+		if err != nil {
+			glog.V(2).Infof("Cannot read environment of %d: %v",
+				t.TGID, err)
+			env = make(map[string]string)
+		}
+	}
+	t.Environment = env
+	return env
+}
+
+// LookupTaskParent returns the thread group leader of the process that
+// created the process to which the specified task belongs, or nil if it is
+// unknown. The parent is found by walking the cached process tree, so it is
+// returned even if it has since exited and the process was reparented.
+func (pc *ProcessInfoCache) LookupTaskParent(t *Task) *Task {
+	leader := t.Leader()
+	if leader.parent == nil || leader.parent == &rootTask {
+		return nil
+	}
+	return leader.parent.Leader()
+}
+
+// The maximum number of executables in a process's exec lineage. Older
+// executables are dropped first.
+const maxExecLineage = 16
+
+// appendExecLineage returns a new exec lineage that extends lineage with exe,
+// leaving lineage unmodified.
+func appendExecLineage(lineage []string, exe string) []string {
+	if len(lineage) >= maxExecLineage {
+		lineage = lineage[len(lineage)-maxExecLineage+1:]
+	}
+	l := make([]string, len(lineage), len(lineage)+1)
+	copy(l, lineage)
+	return append(l, exe)
+}
+
+// LookupTaskExecLineage returns the exec lineage of the process to which the
+// specified task belongs. For processes that were not created while the
+// Sensor was watching, the lineage is built from the executables of the
+// process and its cached ancestors, in which consecutive processes running
+// the same executable are assumed to have forked without exec'ing.
+func (pc *ProcessInfoCache) LookupTaskExecLineage(t *Task) []string {
+	leader := t.Leader()
+	if leader.ExecLineage != nil {
+		return leader.ExecLineage
+	}
+
+	// Walk up the ancestry collecting executables newest first until an
+	// ancestor with a known lineage is reached.
+	var exes []string
+	add := func(exe string) {
+		if len(exes) == 0 || exes[len(exes)-1] != exe {
+			exes = append(exes, exe)
+		}
+	}
+	p := leader
+	for p != nil && len(exes) < maxExecLineage {
+		if p.ExecLineage != nil {
+			for i := len(p.ExecLineage) - 1; i >= 0; i-- {
+				add(p.ExecLineage[i])
+			}
+			break
+		}
+		exe := pc.LookupTaskExecutable(p)
+		if exe == "" {
+			break
+		}
+		add(exe)
+		p = pc.LookupTaskParent(p)
+	}
+	if len(exes) > maxExecLineage {
+		exes = exes[:maxExecLineage]
+	}
+
+	lineage := make([]string, len(exes))
+	for i, exe := range exes {
+		lineage[len(exes)-1-i] = exe
+	}
+	leader.ExecLineage = lineage
+	return lineage
+}
+
+// execLineageBeforeExec returns the exec lineage of the process to which the
+// specified task belongs as it was before the exec that is being decoded.
+// For processes that were not created while the Sensor was watching, the
+// executable in /proc is already the one being exec'd, so only the process's
+// executable as previously cached is added to its parent's lineage.
+func (pc *ProcessInfoCache) execLineageBeforeExec(t *Task) []string {
+	leader := t.Leader()
+	if leader.ExecLineage != nil {
+		return leader.ExecLineage
+	}
+
+	var lineage []string
+	if parent := pc.LookupTaskParent(leader); parent != nil {
+		lineage = pc.LookupTaskExecLineage(parent)
+	}
+	if exe := leader.Executable; exe != "" &&
+		(len(lineage) == 0 || lineage[len(lineage)-1] != exe) {
+		lineage = appendExecLineage(lineage, exe)
+	}
+	return lineage
+}
+
+// taskStatus is the state of a task's process that is read from
+// /proc/[pid]/status for enrichment.
+type taskStatus struct {
+	numThreads uint32
+	rssKB      uint64
+}
+
+// parseStatusKB parses a /proc/[pid]/status size, e.g. "1234 kB".
+func parseStatusKB(s string) (uint64, error) {
+	return strconv.ParseUint(strings.TrimSpace(strings.TrimSuffix(s, "kB")),
+		10, 64)
+}
+
+// LookupTaskStatus returns the number of threads and resident set size in kB
+// of the process to which the specified task belongs. They are read from
+// /proc when the task's cached status is older than ttl. A failed read is not
+// retried until ttl has passed either, and the last status read, if any, is
+// returned instead. The return is nil if the status has never been read,
+// which is normal for tasks that have already exited.
+func (pc *ProcessInfoCache) LookupTaskStatus(
+	t *Task,
+	ttl time.Duration,
+) *taskStatus {
+	if t.ExitTime != 0 {
+		return t.status
+	}
+	now := sys.CurrentMonotonicRaw()
+	if t.statusTime != 0 && now-t.statusTime < int64(ttl) {
+		return t.status
+	}
+	t.statusTime = now
+
+	var s struct {
+		Threads uint32 `Threads`
+		VmRSS   string `VmRSS`
+	}
+	if err := procFS.ReadTaskStatus(t.TGID, t.PID, &s); err != nil {
+		return t.status
+	}
+	st := &taskStatus{
+		numThreads: s.Threads,
+	}
+	if s.VmRSS != "" {
+		st.rssKB, _ = parseStatusKB(s.VmRSS)
+	}
+	t.status = st
+	return st
+}
+
+// kernelThreadState is whether a task is a kernel thread, as resolved by
+// LookupTaskIsKernelThread.
+type kernelThreadState uint8
+
+const (
+	kernelThreadUnresolved kernelThreadState = iota
+	kernelThreadNo
+	kernelThreadYes
+
+	// The task's flags could not be read, typically because it has
+	// already been reaped
+	kernelThreadUnknown
+)
+
+// The task flag that marks kernel threads, from include/linux/sched.h
+const pfKthread = 0x00200000
+
+// LookupTaskIsKernelThread returns whether a task is a kernel thread, as
+// given by the PF_KTHREAD flag in its /proc/[pid]/stat. Unlike a task's
+// command line, which is also empty for zombies, the flag is kept until the
+// task is reaped. The second return value is false if the flags cannot be
+// read. The result is cached in the task, including failure.
+func (pc *ProcessInfoCache) LookupTaskIsKernelThread(t *Task) (bool, bool) {
+	if t.kernelThread == kernelThreadUnresolved {
+		flags, err := procFS.TaskFlags(t.TGID, t.PID)
+		if err != nil {
+			glog.V(2).Infof("Cannot read flags of %d: %v", t.PID, err)
+			t.kernelThread = kernelThreadUnknown
+		} else if flags&pfKthread != 0 {
+			t.kernelThread = kernelThreadYes
+		} else {
+			t.kernelThread = kernelThreadNo
+		}
+	}
+	return t.kernelThread == kernelThreadYes,
+		t.kernelThread != kernelThreadUnknown
+}
+
 func (pc *ProcessInfoCache) maybeDeferAction(f func()) {
 	if !pc.started {
 		pc.startLock.Lock()
@@ -808,6 +1280,7 @@ func (pc *ProcessInfoCache) newProcessEvent(
 	case api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC:
 		pev.ExecFilename = data["filename"].(string)
 		pev.ExecCommandLine = data["exec_command_line"].([]string)
+		pev.ExecLineage, _ = data["exec_lineage"].([]string)
 	case api.ProcessEventType_PROCESS_EVENT_TYPE_FORK:
 		pev.ForkChildPid = data["fork_child_pid"].(int32)
 		pev.ForkChildId = data["fork_child_id"].(string)
@@ -816,6 +1289,16 @@ func (pc *ProcessInfoCache) newProcessEvent(
 		pev.ExitStatus = data["exit_status"].(uint32)
 		pev.ExitSignal = data["exit_signal"].(uint32)
 		pev.ExitCoreDumped = data["exit_core_dumped"].(bool)
+		pev.ExitCommand, _ = data["exit_command"].(string)
+		pev.ExitExecutable, _ = data["exit_executable"].(string)
+		if ru, ok := data["exit_resource_usage"].(proc.ResourceUsage); ok {
+			pev.ExitResourceUsage = &api.ProcessResourceUsage{
+				UserTimeNanos:   uint64(ru.UserTime),
+				SystemTimeNanos: uint64(ru.SystemTime),
+				MinorFaults:     ru.MinorFaults,
+				MajorFaults:     ru.MajorFaults,
+			}
+		}
 	case api.ProcessEventType_PROCESS_EVENT_TYPE_UPDATE:
 		pev.UpdateCwd = data["cwd"].(string)
 	}
@@ -873,9 +1356,11 @@ func (pc *ProcessInfoCache) handleSysClone(
 	childComm string,
 	sample *perf.SampleRecord,
 ) {
+	// The child starts in its parent's working directory.
 	changes := map[string]interface{}{
 		"Command": childComm,
 		"Creds":   parentTask.Creds,
+		"CWD":     parentTask.CWD,
 	}
 
 	if (cloneFlags & CLONE_THREAD) != 0 {
@@ -885,11 +1370,21 @@ func (pc *ProcessInfoCache) handleSysClone(
 		changes["TGID"] = childTask.PID
 		changes["ContainerID"] = parentLeader.ContainerID
 		changes["ContainerInfo"] = parentLeader.ContainerInfo
+
+		// The child runs its parent's executable until it exec's, so
+		// it inherits whatever is already known of it. Anything that
+		// isn't is resolved lazily, from the child's own /proc entry,
+		// rather than for every fork.
+		changes["Executable"] = parentLeader.Executable
+		changes["ExecLineage"] = parentLeader.ExecLineage
+		pc.sensor.pidFilters.fork(int32(parentLeader.PID),
+			int32(childTask.PID))
 	}
 
 	// This must be done before filling in eventData, because otherwise
 	// childTask.ProcessID won't be set yet.
 	childTask.parent = parentTask
+	parentTask.clonedChild = childTask
 	childTask.Update(changes, sample.Time)
 
 	eventData := map[string]interface{}{
@@ -955,6 +1450,26 @@ func (pc *ProcessInfoCache) decodeDoExit(
 
 	pc.maybeDeferAction(func() {
 		t := pc.LookupTask(pid)
+
+		// The executable can no longer be resolved once the task is
+		// marked as having exited.
+		eventData["exit_command"] = t.Command
+		eventData["exit_executable"] = pc.LookupTaskExecutable(t)
+		if atomic.LoadInt32(&pc.exitResourceUsageSinks) > 0 {
+			tgid := t.TGID
+			if tgid == 0 {
+				tgid = pid
+			}
+			ru, err := procFS.TaskResourceUsage(tgid, pid)
+			if err == nil {
+				eventData["exit_resource_usage"] = ru
+			} else {
+				// The task has most likely been reaped
+				glog.V(2).Infof("Couldn't read resource usage of pid %d: %v",
+					pid, err)
+			}
+		}
+
 		t.Update(changes, sample.Time)
 		eventData["__task__"] = t
 		pc.sensor.Monitor.EnqueueExternalSample(
@@ -991,6 +1506,7 @@ func (pc *ProcessInfoCache) decodeCommitCreds(
 
 	pc.maybeDeferAction(func() {
 		t := pc.LookupTask(pid)
+		changes["PreviousCreds"] = t.Creds
 		t.Update(changes, sample.Time)
 	})
 
@@ -1044,17 +1560,43 @@ func (pc *ProcessInfoCache) decodeExecve(
 		commandLine = append(commandLine, s)
 	}
 
+	filename := data["filename"].(string)
 	changes := map[string]interface{}{
 		"CommandLine": commandLine,
+		"Environment": map[string]string(nil),
 	}
 
 	eventData := map[string]interface{}{
-		"filename":          data["filename"].(string),
+		"filename":          filename,
 		"exec_command_line": commandLine,
 	}
 
 	pc.maybeDeferAction(func() {
 		t := pc.LookupTask(pid)
+
+		// The filename is only a reliable path to the executable if
+		// it is absolute or the working directory is known; otherwise
+		// it will be resolved from /proc if needed.
+		exe := filename
+		if !filepath.IsAbs(exe) {
+			if t.CWD != "" {
+				exe = filepath.Join(t.CWD, exe)
+			} else {
+				exe = ""
+			}
+		}
+		changes["Executable"] = exe
+		t.executableUnresolvable = false
+
+		lineageExe := exe
+		if lineageExe == "" {
+			lineageExe = filename
+		}
+		lineage := appendExecLineage(pc.execLineageBeforeExec(t),
+			lineageExe)
+		changes["ExecLineage"] = lineage
+		eventData["exec_lineage"] = lineage
+
 		t.Update(changes, sample.Time)
 		eventData["__task__"] = t
 		pc.sensor.Monitor.EnqueueExternalSample(
@@ -1242,6 +1784,8 @@ func registerProcessEvents(
 		filters       [5]*api.Expression
 		subscriptions [5]*eventSink
 		wildcards     [5]bool
+		maxFds        int
+		resourceUsage bool
 	)
 
 	for _, pef := range events {
@@ -1279,6 +1823,15 @@ func registerProcessEvents(
 			subscriptions[t], _ =
 				subscr.addEventSink(eventID, nil, types)
 		}
+		if pef.ExecFdSnapshot != nil &&
+			t == api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC {
+			maxFds = execFdSnapshotMaxFds(maxFds,
+				pef.ExecFdSnapshot.MaxFds)
+		}
+		if pef.ExitResourceUsage &&
+			t == api.ProcessEventType_PROCESS_EVENT_TYPE_EXIT {
+			resourceUsage = true
+		}
 		if pef.FilterExpression == nil {
 			wildcards[t] = true
 			filters[t] = nil
@@ -1302,6 +1855,7 @@ func registerProcessEvents(
 				code.Code_INVALID_ARGUMENT,
 				fmt.Sprintf("Invalid process filter expression: %v", err))
 			subscr.removeEventSink(s)
+			subscriptions[t] = nil
 			continue
 		}
 
@@ -1321,9 +1875,25 @@ func registerProcessEvents(
 				code.Code_INVALID_ARGUMENT,
 				fmt.Sprintf("Invalid process filter expression: %v", err))
 			subscr.removeEventSink(s)
+			subscriptions[t] = nil
 			continue
 		}
 
 		s.filter = expr
 	}
+
+	s := subscriptions[api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC]
+	if s != nil && maxFds > 0 {
+		s.dispatchFn = newExecFdSnapshotter(procFS.ProcessFileDescriptors,
+			maxFds, subscr.dispatchFn)
+	}
+
+	s = subscriptions[api.ProcessEventType_PROCESS_EVENT_TYPE_EXIT]
+	if s != nil && resourceUsage {
+		pc := sensor.ProcessCache
+		atomic.AddInt32(&pc.exitResourceUsageSinks, 1)
+		s.unregister = func(*eventSink) {
+			atomic.AddInt32(&pc.exitResourceUsageSinks, -1)
+		}
+	}
 }
@@ -15,17 +15,26 @@
 package sensor
 
 import (
+	"errors"
+	"reflect"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/proc"
 )
 
 const arrayTaskCacheSize = 32768
 const mapTaskCacheSize = 32768
 
 var values = []Task{
-	{1, 2, "foo", nil, nil, "6e250051f33e0988aa6e549daa6c36de5ddf296bced4f31cf1b8249556f27ed2", nil, 0, 0, "", "", nil, nil},
-	{1, 2, "bar", nil, nil, "6e250051f33e0988aa6e549daa6c36de5ddf296bced4f31cf1b8249556f27ed2", nil, 0, 0, "", "", nil, nil},
-	{1, 2, "baz", nil, nil, "6e250051f33e0988aa6e549daa6c36de5ddf296bced4f31cf1b8249556f27ed2", nil, 0, 0, "", "", nil, nil},
-	{1, 2, "qux", nil, nil, "6e250051f33e0988aa6e549daa6c36de5ddf296bced4f31cf1b8249556f27ed2", nil, 0, 0, "", "", nil, nil},
+	{1, 2, "foo", nil, nil, nil, "6e250051f33e0988aa6e549daa6c36de5ddf296bced4f31cf1b8249556f27ed2", nil, 0, 0, "", "", "", nil, nil, 0, nil, nil, nil, 0, false, false, 0, nil, nil, nil},
+	{1, 2, "bar", nil, nil, nil, "6e250051f33e0988aa6e549daa6c36de5ddf296bced4f31cf1b8249556f27ed2", nil, 0, 0, "", "", "", nil, nil, 0, nil, nil, nil, 0, false, false, 0, nil, nil, nil},
+	{1, 2, "baz", nil, nil, nil, "6e250051f33e0988aa6e549daa6c36de5ddf296bced4f31cf1b8249556f27ed2", nil, 0, 0, "", "", "", nil, nil, 0, nil, nil, nil, 0, false, false, 0, nil, nil, nil},
+	{1, 2, "qux", nil, nil, nil, "6e250051f33e0988aa6e549daa6c36de5ddf296bced4f31cf1b8249556f27ed2", nil, 0, 0, "", "", "", nil, nil, 0, nil, nil, nil, 0, false, false, 0, nil, nil, nil},
 }
 
 func TestCaches(t *testing.T) {
@@ -61,3 +70,298 @@ func TestCaches(t *testing.T) {
 		}
 	}
 }
+
+func TestParseStatusKB(t *testing.T) {
+	for s, expected := range map[string]uint64{
+		"1234 kB": 1234,
+		"0 kB":    0,
+		"77":      77,
+	} {
+		kb, err := parseStatusKB(s)
+		if err != nil {
+			t.Errorf("Couldn't parse %q: %v", s, err)
+		} else if kb != expected {
+			t.Errorf("Expected %d for %q, got %d", expected, s, kb)
+		}
+	}
+	if _, err := parseStatusKB("lots"); err == nil {
+		t.Error("Expected error for invalid size")
+	}
+}
+
+func TestLookupTaskParent(t *testing.T) {
+	pc := &ProcessInfoCache{}
+
+	initTask := &Task{PID: 1, TGID: 1, Command: "systemd", parent: &rootTask}
+	shell := &Task{PID: 10, TGID: 10, Command: "bash",
+		Executable: "/bin/bash", parent: initTask}
+	child := &Task{PID: 20, TGID: 20, Command: "curl", parent: shell}
+	thread := &Task{PID: 21, TGID: 20, Command: "curl", parent: child}
+
+	// The shell has exited and the child was reparented to init, but
+	// the cached tree still leads to the shell.
+	shell.ExitTime = 1
+
+	for _, task := range []*Task{child, thread} {
+		parent := pc.LookupTaskParent(task)
+		if parent != shell {
+			t.Fatalf("Expected parent of %d to be %d, got %+v",
+				task.PID, shell.PID, parent)
+		}
+		if exe := pc.LookupTaskExecutable(parent); exe != "/bin/bash" {
+			t.Errorf("Expected /bin/bash, got %q", exe)
+		}
+	}
+
+	if parent := pc.LookupTaskParent(initTask); parent != nil {
+		t.Errorf("Expected no parent for init, got %+v", parent)
+	}
+	if parent := pc.LookupTaskParent(&Task{PID: 30, TGID: 30}); parent != nil {
+		t.Errorf("Expected no parent for unknown task, got %+v", parent)
+	}
+}
+
+func TestExecLineage(t *testing.T) {
+	pc := &ProcessInfoCache{}
+
+	initTask := &Task{PID: 1, TGID: 1, Executable: "/sbin/init",
+		ExitTime: 1, parent: &rootTask}
+	sshd := &Task{PID: 10, TGID: 10, Executable: "/usr/sbin/sshd",
+		ExitTime: 1, parent: initTask}
+	forked := &Task{PID: 11, TGID: 11, Executable: "/usr/sbin/sshd",
+		ExitTime: 1, parent: sshd}
+
+	// Forks without exec do not extend the lineage
+	lineage := pc.LookupTaskExecLineage(forked)
+	expected := []string{"/sbin/init", "/usr/sbin/sshd"}
+	if !reflect.DeepEqual(lineage, expected) {
+		t.Fatalf("Expected %v, got %v", expected, lineage)
+	}
+
+	// Exec'ing extends the lineage without modifying the parent's
+	shell := &Task{PID: 12, TGID: 12, ExecLineage: lineage, parent: forked}
+	shell.ExecLineage = appendExecLineage(shell.ExecLineage, "/bin/bash")
+	expected = []string{"/sbin/init", "/usr/sbin/sshd", "/bin/bash"}
+	if got := pc.LookupTaskExecLineage(shell); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if len(forked.ExecLineage) != 2 {
+		t.Errorf("Parent lineage was modified: %v", forked.ExecLineage)
+	}
+
+	// A process that existed before the Sensor started is seeded with
+	// its executable only if it was cached before the exec, because /proc
+	// already reports the executable being exec'd.
+	existing := &Task{PID: 13, TGID: 13, parent: forked}
+	expected = []string{"/sbin/init", "/usr/sbin/sshd"}
+	if got := pc.execLineageBeforeExec(existing); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	existing.Executable = "/usr/bin/python"
+	expected = []string{"/sbin/init", "/usr/sbin/sshd", "/usr/bin/python"}
+	if got := pc.execLineageBeforeExec(existing); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if existing.ExecLineage != nil {
+		t.Errorf("Lineage was cached before the exec: %v",
+			existing.ExecLineage)
+	}
+
+	// Only the most recent executables are kept
+	for i := 0; i < maxExecLineage-1; i++ {
+		lineage = appendExecLineage(lineage, "/bin/sh")
+	}
+	if len(lineage) != maxExecLineage || lineage[0] != "/usr/sbin/sshd" {
+		t.Errorf("Expected lineage trimmed to %d, got %v",
+			maxExecLineage, lineage)
+	}
+}
+
+func TestExitResourceUsageSinks(t *testing.T) {
+	s := &Sensor{
+		eventMap:     newSafeSubscriptionMap(),
+		ProcessCache: &ProcessInfoCache{ProcessExitEventID: 3},
+	}
+	pc := s.ProcessCache
+
+	subscr := newSubscription(s, 1, nil)
+	registerProcessEvents(s, subscr, []*api.ProcessEventFilter{
+		{
+			Type:              api.ProcessEventType_PROCESS_EVENT_TYPE_EXIT,
+			ExitResourceUsage: true,
+		},
+	})
+	s.eventMap.subscribe(subscr)
+	if n := atomic.LoadInt32(&pc.exitResourceUsageSinks); n != 1 {
+		t.Fatalf("Expected 1 resource usage sink, got %d", n)
+	}
+
+	// Exit filters that do not request it, or are invalid, do not count
+	other := newSubscription(s, 2, nil)
+	registerProcessEvents(s, other, []*api.ProcessEventFilter{
+		{Type: api.ProcessEventType_PROCESS_EVENT_TYPE_EXIT},
+	})
+	invalid := newSubscription(s, 3, nil)
+	registerProcessEvents(s, invalid, []*api.ProcessEventFilter{
+		{
+			Type:              api.ProcessEventType_PROCESS_EVENT_TYPE_EXIT,
+			ExitResourceUsage: true,
+			FilterExpression: expression.Equal(
+				expression.Identifier("comm"),
+				expression.Value("sh")),
+		},
+	})
+	if n := atomic.LoadInt32(&pc.exitResourceUsageSinks); n != 1 {
+		t.Fatalf("Expected 1 resource usage sink, got %d", n)
+	}
+
+	s.eventMap.unsubscribe(subscr, nil)
+	if n := atomic.LoadInt32(&pc.exitResourceUsageSinks); n != 0 {
+		t.Errorf("Expected no resource usage sinks, got %d", n)
+	}
+}
+
+// statusTestFS is a proc.FileSystem that counts reads of task status, which
+// fail unless threads is set.
+type statusTestFS struct {
+	proc.FileSystem
+	threads uint32
+	reads   int
+}
+
+func (fs *statusTestFS) ReadTaskStatus(tgid, pid int, i interface{}) error {
+	fs.reads++
+	if fs.threads == 0 {
+		return errors.New("task exited")
+	}
+	v := reflect.ValueOf(i).Elem()
+	v.FieldByName("Threads").SetUint(uint64(fs.threads))
+	v.FieldByName("VmRSS").SetString("1024 kB")
+	return nil
+}
+
+func TestLookupTaskStatus(t *testing.T) {
+	fs := &statusTestFS{}
+	oldProcFS := procFS
+	procFS = fs
+	defer func() { procFS = oldProcFS }()
+
+	pc := &ProcessInfoCache{}
+	task := &Task{PID: 10, TGID: 10}
+
+	// Failures are cached for the TTL too
+	if st := pc.LookupTaskStatus(task, time.Hour); st != nil {
+		t.Errorf("Expected no status, got %+v", st)
+	}
+	fs.threads = 4
+	if st := pc.LookupTaskStatus(task, time.Hour); st != nil {
+		t.Errorf("Expected no status, got %+v", st)
+	}
+	if fs.reads != 1 {
+		t.Errorf("Expected 1 read, got %d", fs.reads)
+	}
+
+	st := pc.LookupTaskStatus(task, 0)
+	if st == nil || st.numThreads != 4 || st.rssKB != 1024 {
+		t.Errorf("Expected 4 threads and 1024 kB, got %+v", st)
+	}
+
+	// The last status read is kept when a read fails
+	fs.threads = 0
+	if st = pc.LookupTaskStatus(task, 0); st == nil || st.numThreads != 4 {
+		t.Errorf("Expected 4 threads, got %+v", st)
+	}
+	if fs.reads != 3 {
+		t.Errorf("Expected 3 reads, got %d", fs.reads)
+	}
+}
+
+// flagsTestFS is a proc.FileSystem that counts reads of task flags, which
+// fail for tasks that it does not know.
+type flagsTestFS struct {
+	proc.FileSystem
+	flags map[int]uint64
+	reads int
+}
+
+func (fs *flagsTestFS) TaskFlags(tgid, pid int) (uint64, error) {
+	fs.reads++
+	flags, ok := fs.flags[pid]
+	if !ok {
+		return 0, errors.New("task reaped")
+	}
+	return flags, nil
+}
+
+func TestLookupTaskIsKernelThread(t *testing.T) {
+	fs := &flagsTestFS{
+		flags: map[int]uint64{
+			2:   0x208040,   // kthreadd
+			100: 0x40400140, // a user process, even as a zombie
+		},
+	}
+	oldProcFS := procFS
+	procFS = fs
+	defer func() { procFS = oldProcFS }()
+
+	pc := &ProcessInfoCache{}
+	for _, tc := range []struct {
+		pid         int
+		kthread, ok bool
+	}{
+		{2, true, true},
+		{100, false, true},
+		{200, false, false},
+	} {
+		task := &Task{PID: tc.pid, TGID: tc.pid}
+		for i := 0; i < 2; i++ {
+			kthread, ok := pc.LookupTaskIsKernelThread(task)
+			if kthread != tc.kthread || ok != tc.ok {
+				t.Errorf("%d: expected %v, %v, got %v, %v",
+					tc.pid, tc.kthread, tc.ok, kthread, ok)
+			}
+		}
+	}
+
+	// Each result, including failure, is read once
+	if fs.reads != 3 {
+		t.Errorf("Expected 3 reads, got %d", fs.reads)
+	}
+}
+
+// executableTestFS is a proc.FileSystem that counts reads of process
+// executables, which fail for processes that it does not know.
+type executableTestFS struct {
+	proc.FileSystem
+	exes  map[int]string
+	reads int
+}
+
+func (fs *executableTestFS) ProcessExecutable(pid int) (string, error) {
+	fs.reads++
+	exe, ok := fs.exes[pid]
+	if !ok {
+		return "", errors.New("no such file or directory")
+	}
+	return exe, nil
+}
+
+func TestLookupTaskExecutableFailure(t *testing.T) {
+	fs := &executableTestFS{exes: map[int]string{}}
+	oldProcFS := procFS
+	procFS = fs
+	defer func() { procFS = oldProcFS }()
+
+	// Kernel threads have no executable. Failure is remembered, so that
+	// it isn't tried again for every event or fork.
+	pc := &ProcessInfoCache{}
+	task := &Task{PID: 2, TGID: 2}
+	for i := 0; i < 2; i++ {
+		if exe := pc.LookupTaskExecutable(task); exe != "" {
+			t.Errorf("Expected no executable, got %q", exe)
+		}
+	}
+	if fs.reads != 1 {
+		t.Errorf("Expected 1 read, got %d", fs.reads)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"strconv"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+// The x86_64 system call number of ptrace
+const syscallPtraceID = 101
+
+const ptraceTraceMe = 0
+
+// ptraceRequestNames maps x86_64 ptrace requests to their names, from
+// include/uapi/linux/ptrace.h and arch/x86/include/uapi/asm/ptrace-abi.h.
+var ptraceRequestNames = map[uint64]string{
+	0:      "PTRACE_TRACEME",
+	1:      "PTRACE_PEEKTEXT",
+	2:      "PTRACE_PEEKDATA",
+	3:      "PTRACE_PEEKUSER",
+	4:      "PTRACE_POKETEXT",
+	5:      "PTRACE_POKEDATA",
+	6:      "PTRACE_POKEUSER",
+	7:      "PTRACE_CONT",
+	8:      "PTRACE_KILL",
+	9:      "PTRACE_SINGLESTEP",
+	12:     "PTRACE_GETREGS",
+	13:     "PTRACE_SETREGS",
+	14:     "PTRACE_GETFPREGS",
+	15:     "PTRACE_SETFPREGS",
+	16:     "PTRACE_ATTACH",
+	17:     "PTRACE_DETACH",
+	18:     "PTRACE_GETFPXREGS",
+	19:     "PTRACE_SETFPXREGS",
+	24:     "PTRACE_SYSCALL",
+	25:     "PTRACE_GET_THREAD_AREA",
+	26:     "PTRACE_SET_THREAD_AREA",
+	30:     "PTRACE_ARCH_PRCTL",
+	31:     "PTRACE_SYSEMU",
+	32:     "PTRACE_SYSEMU_SINGLESTEP",
+	33:     "PTRACE_SINGLEBLOCK",
+	0x4200: "PTRACE_SETOPTIONS",
+	0x4201: "PTRACE_GETEVENTMSG",
+	0x4202: "PTRACE_GETSIGINFO",
+	0x4203: "PTRACE_SETSIGINFO",
+	0x4204: "PTRACE_GETREGSET",
+	0x4205: "PTRACE_SETREGSET",
+	0x4206: "PTRACE_SEIZE",
+	0x4207: "PTRACE_INTERRUPT",
+	0x4208: "PTRACE_LISTEN",
+	0x4209: "PTRACE_PEEKSIGINFO",
+	0x420a: "PTRACE_GETSIGMASK",
+	0x420b: "PTRACE_SETSIGMASK",
+	0x420c: "PTRACE_SECCOMP_GET_FILTER",
+	0x420d: "PTRACE_SECCOMP_GET_METADATA",
+	0x420e: "PTRACE_GET_SYSCALL_INFO",
+}
+
+// ptraceRequestName returns the name of a ptrace request, or its number if
+// the request is unknown.
+func ptraceRequestName(request uint64) string {
+	if name, ok := ptraceRequestNames[request]; ok {
+		return name
+	}
+	return strconv.FormatUint(request, 10)
+}
+
+// setPtraceTarget decodes the request and tracee of a ptrace system call, if
+// its first two arguments were captured, and resolves the tracee's command
+// from the process cache. The tracee's pid is reported as the caller passed
+// it, in the caller's pid namespace.
+func (f *syscallFilter) setPtraceTarget(
+	ev *api.TelemetryEvent,
+	syscall *api.SyscallEvent,
+	data perf.TraceEventSampleData,
+) {
+	request, ok := data["arg0"].(uint64)
+	if !ok {
+		return
+	}
+	syscall.PtraceRequest = ptraceRequestName(request)
+	data["ptrace_request"] = syscall.PtraceRequest
+
+	// PTRACE_TRACEME ignores its pid; the tracer is the parent.
+	pid, ok := data["arg1"].(uint64)
+	if !ok || request == ptraceTraceMe {
+		return
+	}
+	syscall.PtraceTargetPid = int32(pid)
+	data["ptrace_target_pid"] = syscall.PtraceTargetPid
+
+	t := f.sensor.ProcessCache.LookupNamespaceTask(int(ev.ProcessPid),
+		int(syscall.PtraceTargetPid))
+	if t != nil {
+		syscall.PtraceTargetCommand = t.Command
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys"
+)
+
+const defaultQuietPeriodMaxKeys = 4096
+
+// quietPeriodState is the activity of one key.
+type quietPeriodState struct {
+	key string
+
+	// The monotime of the key's most recent event
+	lastSeen int64
+
+	// Identify the source of the key's most recent event in alerts
+	processID   string
+	processPid  int32
+	processTgid int32
+	containerID string
+
+	// Whether an alert has been sent for the current silence
+	alerted bool
+}
+
+// quietPeriodDetector alerts when a subscription's events stop for a key.
+// The most recent event for each key is kept in a bounded LRU; a forgotten
+// key is not alerted on.
+type quietPeriodDetector struct {
+	sync.Mutex
+
+	newEvent   func() *api.TelemetryEvent
+	now        func() int64
+	dispatchFn eventSinkDispatchFn
+	key        api.QuietPeriod_Key
+	silence    time.Duration
+	maxKeys    int
+
+	states map[string]*list.Element
+	lru    *list.List
+
+	stopChan chan struct{}
+}
+
+func newQuietPeriodDetector(
+	newEvent func() *api.TelemetryEvent,
+	now func() int64,
+	dispatchFn eventSinkDispatchFn,
+	key api.QuietPeriod_Key,
+	silence time.Duration,
+	maxKeys int,
+) *quietPeriodDetector {
+	return &quietPeriodDetector{
+		newEvent:   newEvent,
+		now:        now,
+		dispatchFn: dispatchFn,
+		key:        key,
+		silence:    silence,
+		maxKeys:    maxKeys,
+		states:     make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// eventKey returns the key of an event, or "" if it has none.
+func (d *quietPeriodDetector) eventKey(e *api.TelemetryEvent) string {
+	switch d.key {
+	case api.QuietPeriod_CONTAINER:
+		return e.ContainerId
+	default:
+		return e.ProcessId
+	}
+}
+
+// observe records activity for the key of an event.
+func (d *quietPeriodDetector) observe(e *api.TelemetryEvent) {
+	key := d.eventKey(e)
+	if key == "" {
+		return
+	}
+
+	d.Lock()
+	var s *quietPeriodState
+	if el, ok := d.states[key]; ok {
+		d.lru.MoveToFront(el)
+		s = el.Value.(*quietPeriodState)
+	} else {
+		if d.lru.Len() >= d.maxKeys {
+			el = d.lru.Back()
+			d.lru.Remove(el)
+			delete(d.states, el.Value.(*quietPeriodState).key)
+		}
+		s = &quietPeriodState{key: key}
+		d.states[key] = d.lru.PushFront(s)
+	}
+	s.lastSeen = e.SensorMonotimeNanos
+	s.processID = e.ProcessId
+	s.processPid = e.ProcessPid
+	s.processTgid = e.ProcessTgid
+	s.containerID = e.ContainerId
+	s.alerted = false
+	d.Unlock()
+}
+
+// check dispatches an alert for each key that has been silent for longer
+// than the silence threshold and has not already been alerted on.
+func (d *quietPeriodDetector) check() {
+	now := d.now()
+	var alerts []*api.TelemetryEvent
+
+	d.Lock()
+	for _, el := range d.states {
+		s := el.Value.(*quietPeriodState)
+		if s.alerted || now-s.lastSeen < int64(d.silence) {
+			continue
+		}
+		s.alerted = true
+
+		e := d.newEvent()
+		e.ProcessId = s.processID
+		e.ProcessPid = s.processPid
+		e.ProcessTgid = s.processTgid
+		e.ContainerId = s.containerID
+		e.Event = &api.TelemetryEvent_QuietPeriod{
+			QuietPeriod: &api.QuietPeriodEvent{
+				Key:                   s.key,
+				LastSeenMonotimeNanos: s.lastSeen,
+				SilenceNanos:          now - s.lastSeen,
+			},
+		}
+		alerts = append(alerts, e)
+	}
+	d.Unlock()
+
+	for _, e := range alerts {
+		d.dispatchFn(e)
+	}
+}
+
+func (d *quietPeriodDetector) start() {
+	// Check often enough that alerts are at most a quarter of the
+	// silence threshold late.
+	interval := d.silence / 4
+	if interval < 100*time.Millisecond {
+		interval = 100 * time.Millisecond
+	}
+
+	d.stopChan = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-d.stopChan:
+				return
+			case <-ticker.C:
+				d.check()
+			}
+		}
+	}()
+}
+
+func (d *quietPeriodDetector) stop() {
+	if d.stopChan != nil {
+		close(d.stopChan)
+	}
+}
+
+// newSubscriptionQuietPeriodDetector creates the quiet period detector for a
+// subscription, returning it along with a dispatch function that records
+// events before passing them to dispatchFn, unless only alerts are requested.
+// The detector is not started.
+func newSubscriptionQuietPeriodDetector(
+	sensor *Sensor,
+	qp *api.QuietPeriod,
+	dispatchFn eventSinkDispatchFn,
+) (*quietPeriodDetector, eventSinkDispatchFn, error) {
+	if _, ok := api.QuietPeriod_Key_name[int32(qp.Key)]; !ok {
+		return nil, nil, errors.New("Invalid quiet period key")
+	}
+	if qp.SilenceSeconds == 0 {
+		return nil, nil, errors.New("Quiet period requires silence_seconds")
+	}
+	maxKeys := defaultQuietPeriodMaxKeys
+	if qp.MaxKeys != 0 {
+		maxKeys = int(qp.MaxKeys)
+	}
+
+	now := func() int64 {
+		return sys.CurrentMonotonicRaw() - sensor.bootMonotimeNanos
+	}
+	d := newQuietPeriodDetector(sensor.NewEvent, now, dispatchFn, qp.Key,
+		time.Duration(qp.SilenceSeconds)*time.Second, maxKeys)
+	if qp.AlertsOnly {
+		return d, d.observe, nil
+	}
+	return d, func(e *api.TelemetryEvent) {
+		d.observe(e)
+		dispatchFn(e)
+	}, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+func TestQuietPeriodDetector(t *testing.T) {
+	var (
+		now    int64
+		alerts []*api.QuietPeriodEvent
+	)
+	d := newQuietPeriodDetector(
+		func() *api.TelemetryEvent { return &api.TelemetryEvent{} },
+		func() int64 { return now },
+		func(e *api.TelemetryEvent) {
+			alerts = append(alerts, e.GetQuietPeriod())
+		},
+		api.QuietPeriod_PROCESS, time.Second, 2)
+
+	event := func(processID string) *api.TelemetryEvent {
+		return &api.TelemetryEvent{
+			ProcessId:           processID,
+			SensorMonotimeNanos: now,
+		}
+	}
+
+	d.observe(event("a"))
+	d.observe(event("b"))
+	d.observe(event(""))
+	now = int64(500 * time.Millisecond)
+	d.observe(event("b"))
+	d.check()
+	if len(alerts) != 0 {
+		t.Fatalf("Expected no alerts, got %v", alerts)
+	}
+
+	// a is silent for the threshold; b is not
+	now = int64(time.Second)
+	d.check()
+	if len(alerts) != 1 || alerts[0].Key != "a" ||
+		alerts[0].SilenceNanos != int64(time.Second) {
+		t.Fatalf("Expected alert for a, got %v", alerts)
+	}
+
+	// a is only alerted once per silence, and is forgotten when c is seen
+	// because only the two most recently active keys are tracked
+	now = int64(2 * time.Second)
+	d.observe(event("c"))
+	d.check()
+	if len(alerts) != 2 || alerts[1].Key != "b" {
+		t.Fatalf("Expected alert for b, got %v", alerts[1:])
+	}
+
+	// Activity from a starts tracking it again
+	d.observe(event("a"))
+	now = int64(3 * time.Second)
+	d.check()
+	if len(alerts) != 4 {
+		t.Fatalf("Expected alerts for a and c, got %v", alerts[2:])
+	}
+
+	if _, _, err := newSubscriptionQuietPeriodDetector(nil,
+		&api.QuietPeriod{}, nil); err == nil {
+		t.Error("Expected error for missing silence_seconds")
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"container/list"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/golang/glog"
+)
+
+// syscallRateKey identifies the source of syscall events that are rate
+// limited together. Each type of event for a system call, such as its enter
+// and exit, is limited separately, so that shedding one does not leave the
+// other unpaired more often than the rate requires.
+type syscallRateKey struct {
+	pid int32
+	id  int64
+	typ api.SyscallEventType
+}
+
+type syscallRateBucket struct {
+	key    syscallRateKey
+	tokens float64
+	last   int64
+}
+
+// syscallRateLimiter sheds syscall events from any (pid, syscall) key that
+// exceeds a maximum rate, without affecting events from other keys. Each key
+// has its own token bucket. Buckets are kept in a bounded LRU, so keys that
+// have gone quiet are eventually forgotten.
+type syscallRateLimiter struct {
+	sync.Mutex
+
+	// Nonzero if events are being limited. Updated atomically so that
+	// a disabled limiter costs nothing.
+	enabled int32
+
+	rate    float64 // tokens per nanosecond
+	burst   float64
+	maxKeys int
+
+	buckets map[syscallRateKey]*list.Element
+	lru     *list.List
+
+	// Number of events shed per key since the last summary, for at most
+	// maxKeys keys; nil if summaries are not logged.
+	shed map[syscallRateKey]uint64
+
+	stopChan chan struct{}
+}
+
+func newSyscallRateLimiter(
+	rate float64,
+	burst float64,
+	maxKeys int,
+) *syscallRateLimiter {
+	if maxKeys < 1 {
+		maxKeys = 1
+	}
+	l := &syscallRateLimiter{
+		maxKeys: maxKeys,
+		buckets: make(map[syscallRateKey]*list.Element),
+		lru:     list.New(),
+		shed:    make(map[syscallRateKey]uint64),
+	}
+	l.setRate(rate, burst)
+	return l
+}
+
+// setRate changes the maximum rate and burst of events per key. Existing
+// buckets keep their tokens, up to the new burst. A rate of 0 disables
+// limiting.
+func (l *syscallRateLimiter) setRate(rate float64, burst float64) {
+	if burst < 1 {
+		burst = 1
+	}
+	l.Lock()
+	l.rate = rate / float64(time.Second)
+	l.burst = burst
+	for _, e := range l.buckets {
+		if b := e.Value.(*syscallRateBucket); b.tokens > burst {
+			b.tokens = burst
+		}
+	}
+	if rate > 0 {
+		atomic.StoreInt32(&l.enabled, 1)
+	} else {
+		atomic.StoreInt32(&l.enabled, 0)
+	}
+	l.Unlock()
+}
+
+// allow returns true if an event for the specified key at the specified
+// monotime should be delivered, or false if it should be shed.
+func (l *syscallRateLimiter) allow(key syscallRateKey, now int64) bool {
+	if atomic.LoadInt32(&l.enabled) == 0 {
+		return true
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	var b *syscallRateBucket
+	if e, ok := l.buckets[key]; ok {
+		l.lru.MoveToFront(e)
+		b = e.Value.(*syscallRateBucket)
+		if now > b.last {
+			b.tokens += float64(now-b.last) * l.rate
+			if b.tokens > l.burst {
+				b.tokens = l.burst
+			}
+			b.last = now
+		}
+	} else {
+		if l.lru.Len() >= l.maxKeys {
+			e = l.lru.Back()
+			l.lru.Remove(e)
+			delete(l.buckets, e.Value.(*syscallRateBucket).key)
+		}
+		b = &syscallRateBucket{
+			key:    key,
+			tokens: l.burst,
+			last:   now,
+		}
+		l.buckets[key] = l.lru.PushFront(b)
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true
+	}
+	if l.shed != nil {
+		if _, ok := l.shed[key]; ok || len(l.shed) < l.maxKeys {
+			l.shed[key]++
+		}
+	}
+	return false
+}
+
+// takeSummary returns the number of events shed for each key since the last
+// summary was taken, sorted by key.
+func (l *syscallRateLimiter) takeSummary() ([]syscallRateKey, []uint64) {
+	l.Lock()
+	shed := l.shed
+	if shed != nil {
+		l.shed = make(map[syscallRateKey]uint64)
+	}
+	l.Unlock()
+
+	keys := make([]syscallRateKey, 0, len(shed))
+	for k := range shed {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].pid != keys[j].pid {
+			return keys[i].pid < keys[j].pid
+		}
+		if keys[i].id != keys[j].id {
+			return keys[i].id < keys[j].id
+		}
+		return keys[i].typ < keys[j].typ
+	})
+
+	counts := make([]uint64, len(keys))
+	for i, k := range keys {
+		counts[i] = shed[k]
+	}
+	return keys, counts
+}
+
+func (l *syscallRateLimiter) logSummary() {
+	keys, counts := l.takeSummary()
+	for i, k := range keys {
+		glog.Infof("Rate limited syscall events for pid %d syscall %d %s: %d shed",
+			k.pid, k.id, k.typ, counts[i])
+	}
+}
+
+func (l *syscallRateLimiter) start(interval time.Duration) {
+	if interval <= 0 {
+		// Nothing would ever take the counts of shed events
+		l.Lock()
+		l.shed = nil
+		l.Unlock()
+		return
+	}
+	l.stopChan = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-l.stopChan:
+				l.logSummary()
+				return
+			case <-ticker.C:
+				l.logSummary()
+			}
+		}
+	}()
+}
+
+func (l *syscallRateLimiter) stop() {
+	if l.stopChan != nil {
+		close(l.stopChan)
+		l.stopChan = nil
+	}
+}
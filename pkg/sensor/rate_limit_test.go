@@ -0,0 +1,152 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func TestSyscallRateLimiter(t *testing.T) {
+	l := newSyscallRateLimiter(10, 5, 2)
+	hot := syscallRateKey{pid: 100, id: 0}
+	cold := syscallRateKey{pid: 200, id: 0}
+
+	allowed := 0
+	for i := 0; i < 20; i++ {
+		if l.allow(hot, 0) {
+			allowed++
+		}
+	}
+	if allowed != 5 {
+		t.Errorf("Expected burst of 5 events, got %d", allowed)
+	}
+	if !l.allow(cold, 0) {
+		t.Error("Expected event from another key to be allowed")
+	}
+
+	// 10 events per second refills one token every 100ms
+	if !l.allow(hot, int64(100*time.Millisecond)) {
+		t.Error("Expected event to be allowed after refill")
+	}
+	if l.allow(hot, int64(100*time.Millisecond)) {
+		t.Error("Expected event to be shed")
+	}
+
+	// Adding a third key evicts the least recently used key (cold),
+	// which starts over with a full bucket.
+	l.allow(syscallRateKey{pid: 300, id: 1}, 0)
+	if _, ok := l.buckets[cold]; ok {
+		t.Error("Expected least recently used key to be evicted")
+	}
+	if _, ok := l.buckets[hot]; !ok {
+		t.Error("Expected most recently used key to be kept")
+	}
+
+	keys, counts := l.takeSummary()
+	if len(keys) != 1 || keys[0] != hot || counts[0] != 16 {
+		t.Errorf("Unexpected summary %v %v", keys, counts)
+	}
+	if keys, _ = l.takeSummary(); len(keys) != 0 {
+		t.Errorf("Expected empty summary, got %v", keys)
+	}
+}
+
+func TestSyscallRateLimiterShedCounts(t *testing.T) {
+	l := newSyscallRateLimiter(10, 1, 2)
+	for pid := int32(1); pid <= 4; pid++ {
+		key := syscallRateKey{pid: pid, id: 0}
+		l.allow(key, 0)
+		l.allow(key, 0)
+	}
+	if len(l.shed) != 2 {
+		t.Errorf("Expected shed counts for 2 keys, got %d", len(l.shed))
+	}
+
+	// Without summaries, shed events are not counted
+	l.start(0)
+	l.allow(syscallRateKey{pid: 1, id: 0}, 0)
+	if keys, _ := l.takeSummary(); len(keys) != 0 {
+		t.Errorf("Expected empty summary, got %v", keys)
+	}
+}
+
+func TestSyscallRateLimiterEventTypes(t *testing.T) {
+	l := newSyscallRateLimiter(10, 1, 16)
+	enter := syscallRateKey{pid: 100, id: 0,
+		typ: api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER}
+	exit := syscallRateKey{pid: 100, id: 0,
+		typ: api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT}
+
+	if !l.allow(enter, 0) || l.allow(enter, 0) {
+		t.Fatal("Expected a burst of 1 enter event")
+	}
+	if !l.allow(exit, 0) {
+		t.Error("Expected exit events to be limited separately from enter")
+	}
+}
+
+func TestSyscallRateLimitAfterFilter(t *testing.T) {
+	s := &Sensor{
+		eventMap:           newSafeSubscriptionMap(),
+		syscallRateLimiter: newSyscallRateLimiter(1, 1, 16),
+	}
+
+	var delivered int
+	subscr := newSubscription(s, 1, func(*api.TelemetryEvent) {
+		delivered++
+	})
+	subscr.drops = &dropCounts{}
+	filter := expression.Equal(
+		expression.Identifier("arg0"), expression.Value(uint64(1)))
+	if _, err := subscr.addDerivedEventSink(1, filter,
+		expression.FieldTypeMap{}, syscallEnterEventTypes); err != nil {
+		t.Fatal(err)
+	}
+	s.eventMap.subscribe(subscr)
+
+	newSample := func(arg0 uint64) perf.EventMonitorSample {
+		return perf.EventMonitorSample{
+			EventID: 1,
+			DecodedSample: &api.TelemetryEvent{
+				ProcessPid: 100,
+				Event: &api.TelemetryEvent_Syscall{
+					Syscall: &api.SyscallEvent{Id: 0, Arg0: arg0},
+				},
+			},
+			DecodedData: perf.TraceEventSampleData{"arg0": arg0},
+		}
+	}
+
+	// Events that no filter selects do not use up the key's burst
+	s.dispatchQueuedSamples([]perf.EventMonitorSample{
+		newSample(2), newSample(2), newSample(1), newSample(1),
+	}, false)
+	if delivered != 1 {
+		t.Errorf("Expected 1 event delivered, got %d", delivered)
+	}
+	if n := subscr.drops.load(api.DropReason_DROP_REASON_SYSCALL_RATE_LIMIT); n != 1 {
+		t.Errorf("Expected 1 rate limited drop, got %d", n)
+	}
+	if s.Metrics.RateLimitedEvents != 1 {
+		t.Errorf("Expected 1 rate limited event, got %d",
+			s.Metrics.RateLimitedEvents)
+	}
+}
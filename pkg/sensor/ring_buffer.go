@@ -0,0 +1,106 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"google.golang.org/genproto/googleapis/rpc/code"
+)
+
+// ringBufferOverflowSet maps the event groups of subscriptions to the
+// subscriptions, so that they may be told when records are lost because a
+// ringbuffer of one of their groups was full.
+type ringBufferOverflowSet struct {
+	// Updated atomically. These are the first fields so that they are
+	// 64-bit aligned.
+	overflows       uint64
+	lostRecords     uint64
+	reenabledEvents uint64
+
+	sync.Mutex
+	subscriptions map[int32]*subscription
+}
+
+func (s *ringBufferOverflowSet) add(subscr *subscription) {
+	s.Lock()
+	if s.subscriptions == nil {
+		s.subscriptions = make(map[int32]*subscription)
+	}
+	s.subscriptions[subscr.eventGroupID] = subscr
+	for _, id := range subscr.counterGroupIDs {
+		s.subscriptions[id] = subscr
+	}
+	s.Unlock()
+}
+
+func (s *ringBufferOverflowSet) remove(subscr *subscription) {
+	s.Lock()
+	delete(s.subscriptions, subscr.eventGroupID)
+	for _, id := range subscr.counterGroupIDs {
+		delete(s.subscriptions, id)
+	}
+	s.Unlock()
+}
+
+// ringBufferOverflow is called by the EventMonitor once a ringbuffer that
+// overflowed has been drained and its events enabled again. The subscription
+// that owns the ringbuffer is sent a status describing the loss. Records lost
+// from the sensor's own group are only logged, since its events are shared by
+// all subscriptions.
+func (s *Sensor) ringBufferOverflow(o perf.RingBufferOverflow) {
+	set := &s.ringBufferOverflows
+	atomic.AddUint64(&set.overflows, 1)
+	atomic.AddUint64(&set.lostRecords, o.Lost)
+	atomic.AddUint64(&set.reenabledEvents, uint64(o.Reenabled))
+
+	set.Lock()
+	subscr := set.subscriptions[o.GroupID]
+	set.Unlock()
+	if subscr == nil {
+		return
+	}
+	subscr.drops.add(api.DropReason_DROP_REASON_RING_BUFFER_LOSS, o.Lost)
+
+	end := int64(o.EndTime) - s.bootMonotimeNanos
+	var window string
+	if o.StartTime == 0 {
+		window = fmt.Sprintf("before sensor_monotime_nanos %d", end)
+	} else {
+		window = fmt.Sprintf("between sensor_monotime_nanos %d and %d",
+			int64(o.StartTime)-s.bootMonotimeNanos, end)
+	}
+	subscr.sendLateStatus(code.Code_DATA_LOSS,
+		fmt.Sprintf("Ring buffer on CPU %d overflowed: %d records lost %s; %d events enabled again",
+			o.CPU, o.Lost, window, o.Reenabled))
+}
+
+// RingBufferStatistics returns the number of ringbuffer overflows since the
+// sensor started, and the records lost and events enabled again because of
+// them.
+func (s *Sensor) RingBufferStatistics() *api.RingBufferStatistics {
+	set := &s.ringBufferOverflows
+	return &api.RingBufferStatistics{
+		Overflows:       atomic.LoadUint64(&set.overflows),
+		LostRecords:     atomic.LoadUint64(&set.lostRecords),
+		ReenabledEvents: atomic.LoadUint64(&set.reenabledEvents),
+	}
+}
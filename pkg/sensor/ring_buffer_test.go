@@ -0,0 +1,65 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"google.golang.org/genproto/googleapis/rpc/code"
+)
+
+func TestRingBufferOverflow(t *testing.T) {
+	s := &Sensor{bootMonotimeNanos: 1000}
+	subscr := newSubscription(s, 7, nil)
+	subscr.counterGroupIDs = []int32{8}
+	s.ringBufferOverflows.add(subscr)
+
+	s.ringBufferOverflow(perf.RingBufferOverflow{
+		GroupID:   8,
+		CPU:       2,
+		Lost:      10,
+		StartTime: 1500,
+		EndTime:   2000,
+		Reenabled: 3,
+	})
+	select {
+	case st := <-subscr.lateStatus:
+		expected := "Ring buffer on CPU 2 overflowed: 10 records lost between sensor_monotime_nanos 500 and 1000; 3 events enabled again"
+		if st.Code != int32(code.Code_DATA_LOSS) || st.Message != expected {
+			t.Errorf("Unexpected status %+v", st)
+		}
+	default:
+		t.Fatal("Expected status for overflow")
+	}
+
+	// Losses from other groups are counted but not reported
+	s.ringBufferOverflow(perf.RingBufferOverflow{GroupID: 0, Lost: 5})
+	s.ringBufferOverflows.remove(subscr)
+	s.ringBufferOverflow(perf.RingBufferOverflow{GroupID: 7, Lost: 1})
+	select {
+	case st := <-subscr.lateStatus:
+		t.Errorf("Unexpected status %+v", st)
+	default:
+	}
+
+	s.ringBufferOverflow(perf.RingBufferOverflow{})
+	stats := s.RingBufferStatistics()
+	if stats.Overflows != 4 || stats.LostRecords != 16 ||
+		stats.ReenabledEvents != 3 {
+		t.Errorf("Unexpected statistics %+v", stats)
+	}
+}
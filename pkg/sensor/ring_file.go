@@ -0,0 +1,337 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/config"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/jsonpb"
+)
+
+const ringFilePrefix = "events-"
+
+// ringFile writes encoded events to a series of files in a directory. The
+// current file is rotated when it reaches a maximum size or age, and the
+// oldest files are deleted to keep the total size of the directory's event
+// files under a retention cap. File names include a sequence number and the
+// time that the file was created, e.g.
+// events-0000000042-20180102T150405Z.pb
+type ringFile struct {
+	dir       string
+	extension string
+
+	maxFileSize  int64
+	maxFileAge   time.Duration
+	maxTotalSize int64
+
+	file     *os.File
+	fileSize int64
+	fileTime time.Time
+	index    uint64
+}
+
+func newRingFile(
+	dir, extension string,
+	maxFileSize int64,
+	maxFileAge time.Duration,
+	maxTotalSize int64,
+) (*ringFile, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	r := &ringFile{
+		dir:          dir,
+		extension:    extension,
+		maxFileSize:  maxFileSize,
+		maxFileAge:   maxFileAge,
+		maxTotalSize: maxTotalSize,
+	}
+
+	// Continue numbering after any files left by a previous run.
+	names, _, err := r.files()
+	if err != nil {
+		return nil, err
+	}
+	if n := len(names); n > 0 {
+		r.index = ringFileIndex(names[n-1]) + 1
+	}
+	return r, nil
+}
+
+// ringFileIndex returns the sequence number in a ring file name.
+func ringFileIndex(name string) uint64 {
+	name = strings.TrimPrefix(name, ringFilePrefix)
+	if i := strings.IndexByte(name, '-'); i >= 0 {
+		name = name[:i]
+	}
+	index, _ := strconv.ParseUint(name, 10, 64)
+	return index
+}
+
+// files returns the names and sizes of the event files in the directory,
+// oldest first.
+func (r *ringFile) files() ([]string, []int64, error) {
+	infos, err := ioutil.ReadDir(r.dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		names []string
+		sizes []int64
+	)
+	for _, info := range infos {
+		if info.Mode().IsRegular() &&
+			strings.HasPrefix(info.Name(), ringFilePrefix) {
+			names = append(names, info.Name())
+			sizes = append(sizes, info.Size())
+		}
+	}
+	sort.Sort(ringFileList{names, sizes})
+	return names, sizes, nil
+}
+
+type ringFileList struct {
+	names []string
+	sizes []int64
+}
+
+func (l ringFileList) Len() int {
+	return len(l.names)
+}
+
+func (l ringFileList) Less(i, j int) bool {
+	return ringFileIndex(l.names[i]) < ringFileIndex(l.names[j])
+}
+
+func (l ringFileList) Swap(i, j int) {
+	l.names[i], l.names[j] = l.names[j], l.names[i]
+	l.sizes[i], l.sizes[j] = l.sizes[j], l.sizes[i]
+}
+
+func (r *ringFile) rotate(now time.Time) error {
+	if err := r.close(); err != nil {
+		glog.Warningf("Couldn't close event file: %v", err)
+	}
+
+	name := fmt.Sprintf("%s%010d-%s.%s", ringFilePrefix, r.index,
+		now.UTC().Format("20060102T150405Z"), r.extension)
+	f, err := os.OpenFile(filepath.Join(r.dir, name),
+		os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	r.index++
+	r.file = f
+	r.fileSize = 0
+	r.fileTime = now
+
+	r.enforceRetention()
+	return nil
+}
+
+// enforceRetention deletes the oldest event files until the total size of
+// the files, allowing for the current file to grow to its maximum size, is no
+// more than the retention cap. The current file is never deleted.
+func (r *ringFile) enforceRetention() {
+	if r.maxTotalSize <= 0 {
+		return
+	}
+	limit := r.maxTotalSize
+	if r.maxFileSize > 0 {
+		limit -= r.maxFileSize
+	}
+	names, sizes, err := r.files()
+	if err != nil {
+		glog.Warningf("Couldn't list event files: %v", err)
+		return
+	}
+
+	var total int64
+	for _, size := range sizes {
+		total += size
+	}
+	for i := 0; i < len(names)-1 && total > limit; i++ {
+		if err = os.Remove(filepath.Join(r.dir, names[i])); err != nil {
+			glog.Warningf("Couldn't remove event file: %v", err)
+			continue
+		}
+		total -= sizes[i]
+	}
+}
+
+// write writes an encoded event, rotating the current file first if
+// needed. If the write fails, any partial write is discarded so that the
+// file remains readable.
+func (r *ringFile) write(b []byte, now time.Time) error {
+	if r.file == nil ||
+		(r.maxFileSize > 0 && r.fileSize+int64(len(b)) > r.maxFileSize && r.fileSize > 0) ||
+		(r.maxFileAge > 0 && now.Sub(r.fileTime) >= r.maxFileAge) {
+		if err := r.rotate(now); err != nil {
+			return err
+		}
+	}
+
+	n, err := r.file.Write(b)
+	if err != nil {
+		if n > 0 {
+			r.file.Truncate(r.fileSize)
+			r.file.Seek(r.fileSize, io.SeekStart)
+		}
+		return err
+	}
+	r.fileSize += int64(n)
+	return nil
+}
+
+func (r *ringFile) close() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// RingFileService writes the events of a subscription to size and time
+// rotated files on local disk as configured by config.Sensor. It provides a
+// local record of events that survives outages of remote collectors.
+type RingFileService struct {
+	sensor  *Sensor
+	encoder OutputEncoder
+	sub     *api.Subscription
+	ring    *ringFile
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewRingFileService creates a new RingFileService for the specified sensor
+// using the configuration in config.Sensor.
+func NewRingFileService(sensor *Sensor) (*RingFileService, error) {
+	if config.Sensor.RingFileSubscription == "" {
+		return nil, errors.New("No ring file subscription configured")
+	}
+	f, err := os.Open(config.Sensor.RingFileSubscription)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sub := &api.Subscription{}
+	if err = jsonpb.Unmarshal(f, sub); err != nil {
+		return nil, fmt.Errorf("Invalid ring file subscription %s: %v",
+			config.Sensor.RingFileSubscription, err)
+	}
+
+	encoder, err := NewOutputEncoder(config.Sensor.RingFileFormat)
+	if err != nil {
+		return nil, err
+	}
+	ring, err := newRingFile(config.Sensor.RingFileDir,
+		encoder.Extension(), config.Sensor.RingFileMaxSize,
+		config.Sensor.RingFileMaxAge, config.Sensor.RingFileRetention)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &RingFileService{
+		sensor:  sensor,
+		encoder: encoder,
+		sub:     sub,
+		ring:    ring,
+		ctx:     ctx,
+		cancel:  cancel,
+	}, nil
+}
+
+// Name returns the human-readable name of the RingFileService.
+func (rfs *RingFileService) Name() string {
+	return "Ring File Writer"
+}
+
+// Serve writes events to the ring file until the service is stopped. It is
+// normally called by the ServiceManager.
+func (rfs *RingFileService) Serve() error {
+	defer rfs.ring.close()
+
+	// Never block the sensor on disk I/O. Events that can't be queued are
+	// dropped and counted.
+	events := make(chan *api.TelemetryEvent,
+		rfs.sensor.Limits().ChannelBufferLength)
+	f := func(e *api.TelemetryEvent) {
+		select {
+		case events <- e:
+		default:
+			atomic.AddUint64(&rfs.sensor.Metrics.RingFileDroppedEvents, 1)
+		}
+	}
+
+	status, err := rfs.sensor.NewSubscription(rfs.ctx, rfs.sub, f)
+	if err != nil {
+		return err
+	}
+	for _, s := range status {
+		glog.Infof("Ring file subscription: %s", s.Message)
+	}
+
+	failing := false
+	for {
+		select {
+		case <-rfs.ctx.Done():
+			return nil
+		case e := <-events:
+			b, err := rfs.encoder.Encode(e)
+			if err == nil && len(b) == 0 {
+				continue
+			}
+			if err == nil {
+				err = rfs.ring.write(b, time.Now())
+			}
+			if err != nil {
+				atomic.AddUint64(&rfs.sensor.Metrics.RingFileDroppedEvents, 1)
+				if !failing {
+					glog.Warningf("Couldn't write event to ring file: %v", err)
+					failing = true
+				}
+			} else if failing {
+				glog.Infof("Resumed writing events to ring file")
+				failing = false
+			}
+		}
+	}
+}
+
+// Stop stops the RingFileService.
+func (rfs *RingFileService) Stop() {
+	rfs.cancel()
+}
@@ -0,0 +1,166 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+)
+
+func TestRingFileRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ringfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	r, err := newRingFile(dir, "pb", 100, time.Hour, 250)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2018, 1, 2, 15, 4, 5, 0, time.UTC)
+	record := make([]byte, 40)
+	for i := 0; i < 20; i++ {
+		if err = r.write(record, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r.close()
+
+	names, sizes, err := r.files()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var total int64
+	for _, size := range sizes {
+		if size > 100 {
+			t.Errorf("File larger than maximum size: %d", size)
+		}
+		total += size
+	}
+	if total > 250 {
+		t.Errorf("Total size %d exceeds retention", total)
+	}
+	if names[0] == "events-0000000000-20180102T150405Z.pb" {
+		t.Errorf("Oldest file was not deleted")
+	}
+	if last := names[len(names)-1]; last != "events-0000000009-20180102T150405Z.pb" {
+		t.Errorf("Unexpected newest file %s", last)
+	}
+
+	// Rotation by age, continuing the numbering of existing files
+	r, err = newRingFile(dir, "pb", 100, time.Hour, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.write(record, now)
+	r.write(record, now.Add(time.Hour))
+	r.close()
+
+	names, _, _ = r.files()
+	if last := names[len(names)-1]; last != "events-0000000011-20180102T160405Z.pb" {
+		t.Errorf("Unexpected newest file %s", last)
+	}
+}
+
+func TestProtobufOutputEncoder(t *testing.T) {
+	e := &api.TelemetryEvent{
+		Id:        "event",
+		ProcessId: "process",
+	}
+	enc, err := NewOutputEncoder("protobuf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := enc.Encode(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	size, n := proto.DecodeVarint(b)
+	if int(size) != len(b)-n {
+		t.Fatalf("Expected length %d, got %d", len(b)-n, size)
+	}
+	decoded := &api.TelemetryEvent{}
+	if err = proto.Unmarshal(b[n:], decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(e, decoded) {
+		t.Errorf("Expected %+v, got %+v", e, decoded)
+	}
+
+	if _, err = NewOutputEncoder("xml"); err == nil {
+		t.Error("Expected error for unknown output format")
+	}
+}
+
+func TestAnyOutputEncoder(t *testing.T) {
+	syscall := &api.SyscallEvent{
+		Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER,
+		Id:   59,
+		Arg0: 0x1234,
+	}
+	e := &api.TelemetryEvent{
+		Id:        "event",
+		ProcessId: "process",
+		Event: &api.TelemetryEvent_Syscall{
+			Syscall: syscall,
+		},
+	}
+	enc, err := NewOutputEncoder("any")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := enc.Encode(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	size, n := proto.DecodeVarint(b)
+	if int(size) != len(b)-n {
+		t.Fatalf("Expected length %d, got %d", len(b)-n, size)
+	}
+	decoded := &api.AnyTelemetryEvent{}
+	if err = proto.Unmarshal(b[n:], decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Event.Event != nil || decoded.Event.Id != e.Id {
+		t.Errorf("Unexpected event %+v", decoded.Event)
+	}
+	if e.Event == nil {
+		t.Error("Encoding modified the original event")
+	}
+
+	expectedURL := "type.googleapis.com/capsule8.api.v0.SyscallEvent"
+	if decoded.Payload.TypeUrl != expectedURL {
+		t.Errorf("Expected type URL %s, got %s", expectedURL,
+			decoded.Payload.TypeUrl)
+	}
+	var payload ptypes.DynamicAny
+	if err = ptypes.UnmarshalAny(decoded.Payload, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(payload.Message, syscall) {
+		t.Errorf("Expected %+v, got %+v", syscall, payload.Message)
+	}
+}
@@ -28,12 +28,14 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	api "github.com/capsule8/capsule8/api/v0"
 
 	"github.com/capsule8/capsule8/pkg/config"
 	"github.com/capsule8/capsule8/pkg/expression"
 	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/btf"
 	"github.com/capsule8/capsule8/pkg/sys/perf"
 
 	"github.com/golang/glog"
@@ -68,6 +70,10 @@ type Sensor struct {
 	perfEventMountPoint string
 	traceFSMountPoint   string
 
+	// Whether a tracefs or debugfs mount is available for registering
+	// kprobes and tracepoints
+	tracingAvailable bool
+
 	// A sensor-global event monitor that is used for events to aid in
 	// caching process information
 	Monitor *perf.EventMonitor
@@ -75,8 +81,14 @@ type Sensor struct {
 	// A lookup table of available kernel symbols. The key is the symbol
 	// name as would be used with RegisterKprobe. The value is the actual
 	// symbol that should be used, which is normally the same, but can
-	// sometimes differ due to compiler name mangling.
-	kallsyms map[string]string
+	// sometimes differ due to compiler name mangling. It is reloaded when
+	// kernel modules are loaded while kprobes are deferred.
+	kallsyms      map[string]string
+	kallsymsMutex sync.RWMutex
+
+	// Kernel function call filters waiting for their symbols to become
+	// available
+	deferredKprobes deferredKprobeSet
 
 	// Per-sensor caches and monitors
 	ProcessCache   *ProcessInfoCache
@@ -87,23 +99,93 @@ type Sensor struct {
 	// Mapping of event ids to subscriptions
 	eventMap *safeSubscriptionMap
 
+	// Serializes updates of the system call ids of running
+	// subscriptions
+	syscallUpdateMutex sync.Mutex
+
+	// The goroutines that deliver decoded samples to subscriptions, and
+	// the free list of their queue entries. dispatchSpace is signaled
+	// when a batch is removed from a queue.
 	dispatchMutex     sync.Mutex
-	dispatchCond      sync.Cond
-	dispatchQueueHead *queuedSamples
-	dispatchQueueTail *queuedSamples
+	dispatchSpace     sync.Cond
+	dispatchWorkers   []*dispatchWorker
 	dispatchFreelist  *queuedSamples
 	dispatchRunning   bool
 	dispatchWaitGroup sync.WaitGroup
 
+	// Serializes calls to dispatchSamples, which is called by the
+	// EventMonitor's shared decoding goroutine and by the goroutines of
+	// subscriptions with isolated decoding
+	dispatchCallerMutex sync.Mutex
+
 	// Used by syscall events to handle syscall enter events with
 	// argument filters
 	dummySyscallEventID    uint64
 	dummySyscallEventCount int64
-}
 
-type queuedSamples struct {
-	next    *queuedSamples
-	samples []perf.EventMonitorSample
+	// Locations of the system call number and arguments used by the
+	// syscall enter kprobe, or nil if the machine architecture is not
+	// supported
+	syscallEnterLayout *syscallEnterLayout
+
+	// Where the accept kretprobe finds the peer address of new sockets
+	acceptAddressLayout *acceptAddressLayout
+
+	// The machine architecture of the running kernel, e.g. "x86_64"
+	machineArch string
+
+	// Sheds syscall events from (pid, syscall) pairs that exceed the
+	// configured rate. It is created even if rate limiting is disabled,
+	// so that it may be enabled at runtime with UpdateLimits.
+	syscallRateLimiter *syscallRateLimiter
+
+	// The global limits in effect (*api.SensorLimits) and the mutex
+	// that serializes changes to them
+	limits      atomic.Value
+	limitsMutex sync.Mutex
+
+	// Number of subscriptions currently active. Updated atomically.
+	activeSubscriptions int32
+
+	// Retains recent counts of dispatched events for GetCounts. Nil if
+	// the count series is disabled.
+	countSeries *countSeries
+
+	// Limits the capture of sensitive syscall arguments. Nil if no
+	// policy is configured.
+	syscallArgPolicy *syscallArgPolicy
+
+	// Limits the rate of DecodeErrorEvents. Nil if they are disabled.
+	decodeErrorLimiter *decodeErrorLimiter
+
+	// The time spent decoding and delivering each system call's events
+	syscallCosts syscallCostAccounting
+
+	// Subscriptions whose events are only enabled while a matching
+	// container is running
+	lazySubscriptions lazySubscriptionSet
+
+	// The pid filters of all subscriptions, which follow processes as
+	// they are created and exit
+	pidFilters pidFilterSet
+
+	// The subscriptions to tell when records are lost from their
+	// ringbuffers, and counts of the losses
+	ringBufferOverflows ringBufferOverflowSet
+
+	// Limits the total rate of telemetry sent to subscriptions. It is
+	// created even if there is no limit, so that the rate is measured and
+	// a limit may be set at runtime with UpdateLimits.
+	egress *egressShaper
+
+	// Keeps the sensor's own CPU usage under a limit by shedding events.
+	// Like egress, it is created even if there is no limit.
+	cpuLimit *cpuLimiter
+
+	// Default filters per event type set via SetDefaultFilter. Event
+	// types not present use the defaults from config.Sensor.
+	defaultFilterMutex sync.Mutex
+	defaultFilters     map[string]*api.Expression
 }
 
 // NewSensor creates a new Sensor instance.
@@ -117,7 +199,7 @@ func NewSensor() (*Sensor, error) {
 		bootMonotimeNanos: sys.CurrentMonotonicRaw(),
 		eventMap:          newSafeSubscriptionMap(),
 	}
-	s.dispatchCond = sync.Cond{L: &s.dispatchMutex}
+	s.dispatchSpace.L = &s.dispatchMutex
 
 	return s, nil
 }
@@ -143,14 +225,32 @@ func (s *Sensor) Start() error {
 		return err
 	}
 
-	// If there is no mounted tracefs, the Sensor really can't do anything.
-	// Try mounting our own private mount of it.
-	if !config.Sensor.DontMountTracing && len(sys.TracingDir()) == 0 {
+	switch config.Sensor.FilterLoweringPolicy {
+	case filterLoweringLenient, filterLoweringStrict:
+	default:
+		return fmt.Errorf("Invalid filter lowering policy %q",
+			config.Sensor.FilterLoweringPolicy)
+	}
+
+	if len(config.Sensor.SyscallArgPolicy) > 0 {
+		p, err := parseSyscallArgPolicy(config.Sensor.SyscallArgPolicy)
+		if err != nil {
+			return err
+		}
+		s.syscallArgPolicy = p
+	}
+
+	// If there is no mounted tracefs, the Sensor can't monitor any events
+	// that use kprobes or tracepoints. Try mounting our own private mount
+	// of it, and otherwise run without those events.
+	s.tracingAvailable = len(sys.TracingDir()) > 0
+	if !s.tracingAvailable && !config.Sensor.DontMountTracing {
 		// If we couldn't find one, try mounting our own private one
 		glog.V(2).Info("Can't find mounted tracefs, mounting one")
 		if err := s.mountTraceFS(); err != nil {
-			glog.V(1).Info(err)
-			return err
+			glog.Errorf("The tracing filesystem is not mounted and could not be mounted (%v). File, kernel, network, signal, and syscall events are unavailable. Mount tracefs or debugfs, or run the sensor with CAP_SYS_ADMIN.", err)
+		} else {
+			s.tracingAvailable = true
 		}
 	}
 
@@ -179,6 +279,18 @@ func (s *Sensor) Start() error {
 		glog.Warning("Could not load kernel symbols: %v", err)
 	}
 
+	s.machineArch = sys.MachineArch()
+	kernelBTF, btfErr := btf.LoadKernelSpec()
+	if btfErr != nil {
+		glog.V(1).Infof("Kernel BTF is not available, using built-in struct offsets: %v", btfErr)
+	}
+	s.syscallEnterLayout = newSyscallEnterLayout(s.machineArch, kernelBTF)
+	if s.syscallEnterLayout == nil {
+		glog.Warningf("System call enter events are not supported on %s",
+			s.machineArch)
+	}
+	s.acceptAddressLayout = newAcceptAddressLayout(kernelBTF)
+
 	s.ContainerCache = NewContainerCache(s)
 	s.ProcessCache = NewProcessInfoCache(s)
 	s.ProcessCache.Start()
@@ -202,33 +314,56 @@ func (s *Sensor) Start() error {
 	// are active
 	s.Monitor.EnableGroup(0)
 
-	// Start dispatch goroutine(s). We'll just spin one up for now, but we
-	// can run multiples if we want. The sensor needs to keep samples in
+	s.limits.Store(configLimits())
+	s.syscallRateLimiter = newSyscallRateLimiter(
+		config.Sensor.SyscallRateLimit,
+		config.Sensor.SyscallRateLimitBurst,
+		config.Sensor.SyscallRateLimitKeys)
+	s.syscallRateLimiter.start(
+		config.Sensor.SyscallRateLimitSummaryInterval)
+	s.egress = newEgressShaper(config.Sensor.MaxEgressBytesPerSecond)
+	s.cpuLimit = newCPULimiter(config.Sensor.MaxCPUPercent,
+		s.cpuLimitChanged)
+	s.cpuLimit.start()
+
+	if config.Sensor.CountSeriesRetention > 0 {
+		s.countSeries, err = newCountSeries(
+			config.Sensor.CountSeriesRetention,
+			config.Sensor.CountSeriesResolution)
+		if err != nil {
+			s.Stop()
+			return err
+		}
+	}
+
+	if config.Sensor.DecodeErrorEvents {
+		s.decodeErrorLimiter = newDecodeErrorLimiter(
+			config.Sensor.DecodeErrorEventRate)
+	}
+
+	// Start dispatch goroutines. The sensor needs to keep samples in
 	// order coming from the EventMonitor in order to maintain internal
-	// consistency, but it makes no guarantees about the order in which
+	// consistency, and so each process's samples are dispatched by the
+	// same goroutine, but it makes no guarantees about the order in which
 	// telemetry events are emitted to external clients.
-	// NOTE: if more than one dispatch goroutine is spun up, then there
-	// needs to be synchronization in containerFilter
-	s.dispatchRunning = true
-	s.dispatchWaitGroup.Add(1)
-	go s.sampleDispatchLoop()
+	s.startDispatch()
 
 	return nil
 }
 
 // Stop stops a running sensor instance.
 func (s *Sensor) Stop() {
-	if s.dispatchRunning {
-		s.dispatchMutex.Lock()
-		if s.dispatchRunning {
-			s.dispatchRunning = false
-			s.dispatchCond.Broadcast()
-			s.dispatchMutex.Unlock()
-			s.dispatchWaitGroup.Wait()
-		} else {
-			s.dispatchMutex.Unlock()
-		}
+	s.stopDispatch()
+	if s.syscallRateLimiter != nil {
+		s.syscallRateLimiter.stop()
+	}
+	if s.cpuLimit != nil {
+		s.cpuLimit.stop()
 	}
+	s.deferredKprobes.Lock()
+	s.deferredKprobes.subscriptions = nil
+	s.deferredKprobes.stopIfIdle()
+	s.deferredKprobes.Unlock()
 	if s.Monitor != nil {
 		glog.V(2).Info("Stopping sensor-global EventMonitor")
 		s.Monitor.Close()
@@ -286,6 +421,13 @@ func (s *Sensor) unmountPerfEventCgroupFS() {
 // NewEvent creates a new API Event instance with common sensor-specific fields
 // correctly populated.
 func (s *Sensor) NewEvent() *api.TelemetryEvent {
+	atomic.AddUint64(&s.Metrics.Events, 1)
+	return s.newEvent()
+}
+
+// newEvent is like NewEvent, but does not count the event in the Sensor's
+// metrics.
+func (s *Sensor) newEvent() *api.TelemetryEvent {
 	monotime := sys.CurrentMonotonicRaw() - s.bootMonotimeNanos
 
 	// The first sequence number is intentionally 1 to disambiguate
@@ -301,8 +443,6 @@ func (s *Sensor) NewEvent() *api.TelemetryEvent {
 	h := sha256.Sum256(buf.Bytes())
 	eventID := hex.EncodeToString(h[:])
 
-	atomic.AddUint64(&s.Metrics.Events, 1)
-
 	return &api.TelemetryEvent{
 		Id:                   eventID,
 		SensorId:             s.ID,
@@ -321,7 +461,9 @@ func (s *Sensor) NewEventFromContainer(containerID string) *api.TelemetryEvent {
 
 // NewEventFromSample creates a new API Event instance using perf_event sample
 // information. If the sample comes from the calling process, no event will be
-// created, and the return will be nil.
+// created, and the return will be nil. Enrichment fields (see
+// enrichmentEventTypes and environmentEventTypes) that are known for the task
+// are added to data so that they may be used by filters.
 func (s *Sensor) NewEventFromSample(
 	sample *perf.SampleRecord,
 	data perf.TraceEventSampleData,
@@ -340,34 +482,107 @@ func (s *Sensor) NewEventFromSample(
 		// are in containers, the sample.Pid and sample.Tid fields will
 		// be zero. Use "common_pid" from the trace event data instead.
 		task, leader = s.ProcessCache.LookupTaskAndLeader(int(pid))
+	} else if sample.Tid != 0 {
+		// Samples without trace event data, such as performance
+		// counter samples, only identify the task if the
+		// subscription collects PERF_SAMPLE_TID.
+		task, leader = s.ProcessCache.LookupTaskAndLeader(int(sample.Tid))
 	}
 	if leader != nil && leader.IsSensor() {
 		return nil
 	}
+	if task != nil && data != nil {
+		// Decoders may need more of the task than the event has.
+		data["__task__"] = task
+	}
 
 	e := s.NewEvent()
 	e.SensorMonotimeNanos = int64(sample.Time) - s.bootMonotimeNanos
+	if sample.ClockTime != 0 {
+		e.PerfClockNanos = int64(sample.ClockTime)
+	}
 	e.Cpu = int32(sample.CPU)
+	e.SampleIp = sample.IP
+	if len(sample.IPs) > 0 {
+		e.Callchain = sample.IPs
+	}
+	if sample.DataSrc != 0 {
+		e.DataSrc = sample.DataSrc
+		e.MemoryAccess = decodeMemoryAccess(sample.DataSrc)
+	}
 
 	if task != nil {
 		e.ProcessPid = int32(task.PID)
 		e.ProcessId = task.ProcessID
 		e.ProcessTgid = int32(task.TGID)
+		if data != nil {
+			data["process_tgid"] = e.ProcessTgid
+		}
+
+		enrichment := s.eventMap.lazyEnrichment()
+		if enrichment&lazyEnrichmentMountNamespace != 0 {
+			ns := s.ProcessCache.LookupTaskMountNamespace(task)
+			if ns != 0 {
+				e.MntNs = ns
+				if data != nil {
+					data["mnt_ns"] = ns
+				}
+			}
+		}
+
+		if ttl := config.Sensor.ProcessStatusTTL; ttl > 0 &&
+			enrichment&lazyEnrichmentStatus != 0 {
+			st := s.ProcessCache.LookupTaskStatus(task, ttl)
+			if st != nil {
+				e.NumThreads = st.numThreads
+				e.RssKb = st.rssKB
+				if data != nil {
+					data["num_threads"] = st.numThreads
+					data["rss_kb"] = st.rssKB
+				}
+			}
+		}
 
 		if c := task.Creds; c != nil {
-			e.Credentials = &api.Credentials{
-				Uid:   c.UID,
-				Gid:   c.GID,
-				Euid:  c.EUID,
-				Egid:  c.EGID,
-				Suid:  c.SUID,
-				Sgid:  c.SGID,
-				Fsuid: c.FSUID,
-				Fsgid: c.FSGID,
+			e.Credentials = c.toAPI()
+			if data != nil {
+				data["task_uid"] = c.UID
+				data["task_gid"] = c.GID
+				data["task_euid"] = c.EUID
+				data["task_egid"] = c.EGID
 			}
 		}
 
 		// if task != nil, leader is also guaranteed != nil
+		if enrichment&lazyEnrichmentKernelThread != 0 {
+			kthread, ok := s.ProcessCache.LookupTaskIsKernelThread(leader)
+			if ok {
+				e.IsKernelThread = kthread
+				if data != nil {
+					data["is_kernel_thread"] = kthread
+				}
+			}
+		}
+
+		if enrichment&lazyEnrichmentParent != 0 {
+			parent := s.ProcessCache.LookupTaskParent(task)
+			if parent != nil {
+				e.ParentExe = s.ProcessCache.LookupTaskExecutable(parent)
+				e.ParentComm = parent.Command
+				if data != nil {
+					data["parent_exe"] = e.ParentExe
+					data["parent_comm"] = e.ParentComm
+				}
+			}
+		}
+
+		if data != nil && len(config.Sensor.EnvironmentVariables) > 0 {
+			env := s.ProcessCache.LookupTaskEnvironment(leader)
+			for k, v := range env {
+				data[environmentIdentifierPrefix+k] = v
+			}
+		}
+
 		if i := s.ProcessCache.LookupTaskContainerInfo(leader); i != nil {
 			e.ContainerId = i.ID
 			e.ContainerName = i.Name
@@ -410,7 +625,10 @@ func (s *Sensor) buildMonitorGroups() ([]string, []int, error) {
 }
 
 func (s *Sensor) createEventMonitor() error {
-	eventMonitorOptions := []perf.EventMonitorOption{}
+	eventMonitorOptions := []perf.EventMonitorOption{
+		perf.WithRingBufferOverflowFn(s.ringBufferOverflow),
+		perf.WithIsolatedDecodeDropFn(s.isolatedDecodeDrop),
+	}
 
 	if len(s.traceFSMountPoint) > 0 {
 		eventMonitorOptions = append(eventMonitorOptions,
@@ -460,7 +678,9 @@ func (s *Sensor) createEventMonitor() error {
 				strings.Join(cgroups, ","), err)
 
 			glog.V(1).Info("Creating new system-wide event monitor")
-			s.Monitor, err = perf.NewEventMonitor()
+			s.Monitor, err = perf.NewEventMonitor(
+				perf.WithRingBufferOverflowFn(s.ringBufferOverflow),
+				perf.WithIsolatedDecodeDropFn(s.isolatedDecodeDrop))
 		}
 		if err != nil {
 			glog.V(1).Infof("Couldn't create event monitor: %s", err)
@@ -482,6 +702,9 @@ func (s *Sensor) createEventMonitor() error {
 // IsKernelSymbolAvailable checks to see if the specified kprobe symbol is
 // available for use in the running kernel.
 func (s *Sensor) IsKernelSymbolAvailable(symbol string) bool {
+	s.kallsymsMutex.RLock()
+	defer s.kallsymsMutex.RUnlock()
+
 	// If the kallsyms mapping is nil, the table could not be
 	// loaded for some reason; assume anything is available
 	if s.kallsyms == nil {
@@ -501,8 +724,12 @@ func (s *Sensor) RegisterKprobe(
 	fn perf.TraceEventDecoderFn,
 	options ...perf.RegisterEventOption,
 ) (uint64, error) {
-	if s.kallsyms != nil {
-		if actual, ok := s.kallsyms[address]; ok {
+	s.kallsymsMutex.RLock()
+	kallsyms := s.kallsyms
+	s.kallsymsMutex.RUnlock()
+
+	if kallsyms != nil {
+		if actual, ok := kallsyms[address]; ok {
 			if actual != address {
 				glog.V(2).Infof("Using %q for kprobe symbol %q", actual, address)
 				address = actual
@@ -523,37 +750,227 @@ func (s *Sensor) NewSubscription(
 	sub *api.Subscription,
 	dispatchFn eventSinkDispatchFn,
 ) ([]*google_rpc.Status, error) {
+	_, status, err := s.createSubscription(ctx, sub, dispatchFn, nil)
+	return status, err
+}
+
+// eventFilterUsesTracing returns true if the event filter includes any events
+// that are monitored with kprobes or tracepoints.
+func eventFilterUsesTracing(ef *api.EventFilter) bool {
+	return len(ef.FileEvents) > 0 || len(ef.KernelEvents) > 0 ||
+		len(ef.NetworkEvents) > 0 || len(ef.SignalEvents) > 0 ||
+		len(ef.SyscallEvents) > 0
+}
+
+// createSubscription implements NewSubscription, additionally returning the
+// subscription that was created if there was no error. The subscription
+// counts the events that it discards in drops, which may be nil if the caller
+// does not count any itself.
+func (s *Sensor) createSubscription(
+	ctx context.Context,
+	sub *api.Subscription,
+	dispatchFn eventSinkDispatchFn,
+	drops *dropCounts,
+) (*subscription, []*google_rpc.Status, error) {
 	if sub.EventFilter == nil {
 		glog.V(1).Infof("Invalid subscription: %+v", sub)
-		return nil, errors.New("Invalid subscription (no EventFilter)")
+		return nil, nil, errors.New("Invalid subscription (no EventFilter)")
+	}
+	if _, ok := api.SubscriptionPriority_name[int32(sub.Priority)]; !ok {
+		return nil, nil, fmt.Errorf("Invalid subscription priority %d",
+			sub.Priority)
+	}
+	if _, ok := api.UnsupportedFieldPolicy_name[int32(sub.UnsupportedFieldPolicy)]; !ok {
+		return nil, nil, fmt.Errorf("Invalid unsupported field policy %d",
+			sub.UnsupportedFieldPolicy)
+	}
+	if max, ok := s.reserveSubscription(); !ok {
+		return nil, nil, fmt.Errorf("Too many subscriptions (limit %d)",
+			max)
+	}
+	// The reservation is released if the subscription is rejected, and
+	// otherwise once it ends.
+	reserved := true
+	defer func() {
+		if reserved {
+			atomic.AddInt32(&s.activeSubscriptions, -1)
+		}
+	}()
+
+	// Everything that can reject the subscription without registering
+	// its events is checked before its event group is created.
+	var (
+		cf  *containerFilter
+		et  *edgeTrigger
+		ft  *failureThreshold
+		pf  *pidFilter
+		err error
+	)
+	if sub.ContainerFilter != nil {
+		if cf, err = newContainerFilter(sub.ContainerFilter); err != nil {
+			return nil, nil, err
+		}
+	}
+	if sub.EdgeTrigger != nil {
+		if et, err = newEdgeTrigger(sub.EdgeTrigger); err != nil {
+			return nil, nil, err
+		}
+	}
+	if sub.FailureThreshold != nil {
+		if ft, err = newFailureThreshold(sub.FailureThreshold); err != nil {
+			return nil, nil, err
+		}
+	}
+	if sub.PidFilter != nil && len(sub.PidFilter.Pids) > 0 {
+		if pf, err = newPidFilter(sub.PidFilter); err != nil {
+			return nil, nil, err
+		}
 	}
 
-	groupID, err := s.Monitor.RegisterEventGroup("")
+	if drops == nil {
+		drops = new(dropCounts)
+	}
+
+	perfClock, groupOptions, err := perfClockGroupOptions(sub.PerfClock)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	decodeOptions, err := decodeGroupOptions(sub.DecodeIsolation)
+	if err != nil {
+		return nil, nil, err
+	}
+	groupOptions = append(groupOptions, decodeOptions...)
+	groupOptions = append(groupOptions, perf.WithGroupRingBufferNumPages(
+		int(s.Limits().RingBufferPages)))
+	groupID, err := s.Monitor.RegisterEventGroup("", groupOptions...)
+	if err != nil {
+		return nil, nil, err
 	}
-	subscr := newSubscription(s, groupID, dispatchFn)
-	glog.V(1).Infof("Subscription %d: %+v", groupID, sub)
 
-	if sub.ContainerFilter != nil {
-		subscr.containerFilter, err = newContainerFilter(sub.ContainerFilter)
+	// Events are stamped with the subscription's clock as they are
+	// delivered, after everything that may drop them.
+	if perfClock != api.PerfClock_PERF_CLOCK_DEFAULT {
+		dispatchFn = newSubscriptionPerfClock(s, perfClock, dispatchFn)
+	}
+
+	// Throttle events just before they are handed to the client, since
+	// the throttle rate is the rate at which the client accepts them.
+	var throttle *ackThrottle
+	if sub.AckThrottle != nil {
+		throttle, dispatchFn, err = newSubscriptionAckThrottle(
+			sub.AckThrottle, dispatchFn)
 		if err != nil {
-			return nil, err
+			s.Monitor.UnregisterEventGroup(groupID)
+			return nil, nil, err
+		}
+	}
+
+	// Count the processes of all events delivered to the subscription,
+	// however they are dispatched.
+	var cardinality *pidCardinality
+	if sub.PidCardinality != nil {
+		cardinality, dispatchFn = newSubscriptionPidCardinality(s,
+			sub.PidCardinality, dispatchFn)
+	}
+
+	// Sample events by container before they are counted, so that the
+	// estimate reflects the events that are delivered.
+	var sampler *containerSampler
+	if sub.ContainerSampling != nil {
+		sampler, dispatchFn, err = newSubscriptionContainerSampler(s,
+			sub.ContainerSampling, drops, dispatchFn)
+		if err != nil {
+			s.Monitor.UnregisterEventGroup(groupID)
+			return nil, nil, err
+		}
+	}
+
+	// Activity is recorded before events are sampled or throttled, since
+	// those events still show that the key is active.
+	var quiet *quietPeriodDetector
+	if sub.QuietPeriod != nil {
+		quiet, dispatchFn, err = newSubscriptionQuietPeriodDetector(s,
+			sub.QuietPeriod, dispatchFn)
+		if err != nil {
+			s.Monitor.UnregisterEventGroup(groupID)
+			return nil, nil, err
+		}
+	}
+
+	subscr := newSubscription(s, groupID, dispatchFn)
+	glog.V(1).Infof("Subscription %d: %+v", groupID, sub)
+	subscr.priority = sub.Priority
+	subscr.includeCausedBy = sub.IncludeCausedBy
+	subscr.perfClock = perfClock
+	subscr.unsupportedFieldPolicy = sub.UnsupportedFieldPolicy
+	if perfClock != sub.PerfClock {
+		subscr.logStatus(
+			code.Code_UNIMPLEMENTED,
+			fmt.Sprintf("The kernel does not support %s; using the default clock",
+				sub.PerfClock))
+	}
+	subscr.ackThrottle = throttle
+	subscr.drops = drops
+	subscr.decodeIsolated = sub.DecodeIsolation ==
+		api.DecodeIsolation_DECODE_ISOLATION_ISOLATED
+	subscr.setSampleFields(sub.SampleFields)
+
+	var lazyFilter *containerFilter
+	subscr.containerFilter = cf
+	if cf != nil {
+		if sub.Lazy {
+			lazyFilter, _ = newContainerFilter(sub.ContainerFilter)
 		}
+	} else if sub.Lazy {
+		subscr.logStatus(
+			code.Code_INVALID_ARGUMENT,
+			"Lazy subscription ignored without a container filter")
+	}
+	subscr.edgeTrigger = et
+	subscr.failureThreshold = ft
+	subscr.pidFilter = pf
+
+	// Events that need kprobes or tracepoints can't be registered without
+	// the tracing filesystem. Report that once instead of failing each
+	// registration separately.
+	tracing := s.tracingAvailable
+	if !tracing && eventFilterUsesTracing(sub.EventFilter) {
+		subscr.logStatus(
+			code.Code_FAILED_PRECONDITION,
+			"File, kernel, network, signal, and syscall events are unavailable because the tracing filesystem is not mounted")
 	}
 
+	subscr.eventType = "chargen"
 	registerChargenEvents(s, subscr, sub.EventFilter.ChargenEvents)
+	subscr.eventType = "container"
 	registerContainerEvents(s, subscr, sub.EventFilter.ContainerEvents)
-	registerFileEvents(s, subscr, sub.EventFilter.FileEvents)
-	registerKernelEvents(s, subscr, sub.EventFilter.KernelEvents)
-	registerNetworkEvents(s, subscr, sub.EventFilter.NetworkEvents)
+	if tracing {
+		subscr.eventType = "file"
+		registerFileEvents(s, subscr, sub.EventFilter.FileEvents)
+		subscr.eventType = "kernel"
+		registerKernelEvents(s, subscr, sub.EventFilter.KernelEvents)
+		subscr.eventType = "network"
+		registerNetworkEvents(s, subscr, sub.EventFilter.NetworkEvents)
+	}
+	subscr.eventType = "performance"
 	registerPerformanceEvents(s, subscr, sub.EventFilter.PerformanceEvents)
+	subscr.eventType = "process"
 	registerProcessEvents(s, subscr, sub.EventFilter.ProcessEvents)
-	registerSyscallEvents(s, subscr, sub.EventFilter.SyscallEvents)
+	if tracing {
+		subscr.eventType = "signal"
+		registerSignalEvents(s, subscr, sub.EventFilter.SignalEvents)
+		subscr.eventType = "syscall"
+		registerSyscallEvents(s, subscr, sub.EventFilter.SyscallEvents)
+		subscr.eventType = "fileless_execution"
+		registerFilelessExecutionEvents(s, subscr,
+			sub.EventFilter.FilelessExecutionEvents)
+	}
+	subscr.eventType = "ticker"
 	registerTimerEvents(s, subscr, sub.EventFilter.TickerEvents)
+	subscr.eventType = "synthetic"
+	registerSyntheticEvents(s, subscr, sub.EventFilter.SyntheticEvents)
 
-	status := subscr.status
-	subscr.status = nil
+	status := subscr.takeStatus()
 	if len(status) > 0 {
 		for _, s := range status {
 			glog.V(1).Infof("Subscription %d: [%s] %s",
@@ -563,80 +980,100 @@ func (s *Sensor) NewSubscription(
 		status = []*google_rpc.Status{{Code: int32(code.Code_OK)}}
 	}
 
-	if len(subscr.eventSinks) == 0 {
-		return status, errors.New("Invalid subscription (no filters specified)")
+	if len(subscr.eventSinks) == 0 && len(subscr.deferredKprobes) == 0 {
+		for _, id := range subscr.counterGroupIDs {
+			s.Monitor.UnregisterEventGroup(id)
+		}
+		s.Monitor.UnregisterEventGroup(groupID)
+		return nil, status, errors.New("Invalid subscription (no filters specified)")
 	}
 
+	// The subscription only shares the egress bandwidth once nothing
+	// can reject it.
+	subscr.egressShare = s.egress.join(sub.Priority)
+
+	if subscr.pidFilter != nil {
+		s.pidFilters.add(subscr.eventGroupID, subscr.pidFilter)
+	}
+	s.ringBufferOverflows.add(subscr)
 	s.eventMap.subscribe(subscr)
 	glog.V(2).Infof("Subscription %d registered", subscr.eventGroupID)
+	if len(subscr.deferredKprobes) > 0 {
+		s.addDeferredKprobes(subscr)
+	}
+	if cardinality != nil {
+		cardinality.start()
+	}
+	if sampler != nil {
+		sampler.start()
+	}
+	if quiet != nil {
+		quiet.start()
+	}
 
+	reserved = false
 	go func() {
 		<-ctx.Done()
 		glog.V(2).Infof("Subscription %d control channel closed",
 			subscr.eventGroupID)
 
+		s.removeDeferredKprobes(subscr)
+		subscr.egressShare.leave()
+		s.ringBufferOverflows.remove(subscr)
+		if cardinality != nil {
+			cardinality.stop()
+		}
+		if sampler != nil {
+			sampler.stop()
+		}
+		if quiet != nil {
+			quiet.stop()
+		}
+		if lazyFilter != nil {
+			s.removeLazySubscription(subscr)
+		}
+		if subscr.pidFilter != nil {
+			s.pidFilters.remove(subscr.eventGroupID)
+		}
+
 		for _, id := range subscr.counterGroupIDs {
 			s.Monitor.UnregisterEventGroup(id)
 		}
 
 		s.Monitor.UnregisterEventGroup(subscr.eventGroupID)
 		s.eventMap.unsubscribe(subscr, nil)
+		atomic.AddInt32(&s.activeSubscriptions, -1)
 	}()
 
-	s.Monitor.EnableGroup(groupID)
-	for _, id := range subscr.counterGroupIDs {
-		s.Monitor.EnableGroup(id)
-	}
-
-	atomic.AddInt32(&s.Metrics.Subscriptions, 1)
-	return status, nil
-}
-
-func (s *Sensor) dispatchSamples(samples []perf.EventMonitorSample) {
-	s.dispatchMutex.Lock()
-
-	var qs *queuedSamples
-	if s.dispatchFreelist == nil {
-		qs = &queuedSamples{samples: samples}
-	} else {
-		qs = s.dispatchFreelist
-		s.dispatchFreelist = qs.next
-		qs.next = nil
-		qs.samples = samples
-	}
-
-	if s.dispatchQueueTail == nil {
-		s.dispatchQueueHead = qs
-		s.dispatchCond.Signal()
+	if lazyFilter != nil {
+		s.addLazySubscription(subscr, lazyFilter)
 	} else {
-		s.dispatchQueueTail.next = qs
+		s.Monitor.EnableGroup(groupID)
+		for _, id := range subscr.counterGroupIDs {
+			s.Monitor.EnableGroup(id)
+		}
 	}
-	s.dispatchQueueTail = qs
 
-	s.dispatchMutex.Unlock()
+	atomic.AddInt32(&s.Metrics.Subscriptions, 1)
+	return subscr, status, nil
 }
 
-func (s *Sensor) popSamples() []perf.EventMonitorSample {
-	qs := s.dispatchQueueHead
-	samples := qs.samples
-
-	s.dispatchQueueHead = qs.next
-	if s.dispatchQueueHead == nil {
-		s.dispatchQueueTail = nil
+// DropEvent records that an event was dropped rather than delivered to a
+// subscription with the specified priority.
+func (s *Sensor) DropEvent(priority api.SubscriptionPriority) {
+	if int(priority) < len(s.Metrics.DroppedEvents) {
+		atomic.AddUint64(&s.Metrics.DroppedEvents[priority], 1)
 	}
-
-	qs.next = s.dispatchFreelist
-	qs.samples = nil
-	s.dispatchFreelist = qs
-
-	return samples
 }
 
-func (s *Sensor) dispatchQueuedSamples(samples []perf.EventMonitorSample) {
+func (s *Sensor) dispatchQueuedSamples(
+	samples []perf.EventMonitorSample,
+	overloaded bool,
+) {
 	eventMap := s.eventMap.getMap()
 	for _, esm := range samples {
 		if esm.Err != nil {
-			glog.Warning(esm.Err)
+			s.decodeError(&esm, eventMap[esm.EventID])
 			continue
 		}
 
@@ -645,16 +1082,55 @@ func (s *Sensor) dispatchQueuedSamples(samples []perf.EventMonitorSample) {
 			continue
 		}
 
+		// External samples dispatched again for isolated decoding
+		// have already been accounted for by the shared dispatch.
+		isolatedCopy := esm.External && esm.Isolated
+
 		eventSinks, ok := eventMap[esm.EventID]
 		if !ok {
+			if !isolatedCopy {
+				s.pidFilters.exit(event)
+			}
 			continue
 		}
 
+		sev, isSyscall := event.Event.(*api.TelemetryEvent_Syscall)
+		isSyscall = isSyscall && !event.Synthetic
+		var deliveryStart time.Time
+		if isSyscall {
+			deliveryStart = time.Now()
+		}
+
+		// Syscall events are rate limited only once some event sink's
+		// filters have selected them, so that events that no
+		// subscription wants do not use up a key's rate. Synthetic
+		// events are excluded from the accounting of observed events.
+		rateLimit := isSyscall && s.syscallRateLimiter != nil
+		rateLimited := false
+
+		// Events are counted once some event sink dispatches them.
+		var counts *countSeries
+		if !event.Synthetic && !isolatedCopy {
+			counts = s.countSeries
+		}
+
 		for _, es := range eventSinks {
+			if es.triggerInapplicable ||
+				!es.subscription.receivesSample(&esm) {
+				continue
+			}
+			if pf := es.subscription.pidFilter; pf != nil &&
+				!pf.match(event.ProcessTgid) {
+				continue
+			}
 			if es.filter != nil {
+				start := time.Now()
 				v, err := es.filter.Evaluate(
 					es.filterTypes,
 					expression.FieldValueMap(esm.DecodedData))
+				atomic.AddUint64(&es.evaluations, 1)
+				atomic.AddUint64(&es.evaluationNanos,
+					uint64(time.Since(start)))
 				if err != nil {
 					glog.V(1).Infof("Expression evaluation error: %s", err)
 					continue
@@ -663,11 +1139,46 @@ func (s *Sensor) dispatchQueuedSamples(samples []perf.EventMonitorSample) {
 					continue
 				}
 			}
-			s := es.subscription
-			if s.containerFilter != nil &&
-				!s.containerFilter.match(event) {
+			if cf := es.subscription.containerFilter; cf != nil &&
+				!cf.match(event) {
+				continue
+			}
+			// Shed low priority subscriptions first so that the
+			// backlog drains faster for everyone else. Only events
+			// that pass the subscription's filters are counted as shed.
+			if overloaded && es.subscription.priority ==
+				api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_LOW {
+				s.DropEvent(es.subscription.priority)
+				atomic.AddUint64(&es.subscription.shedEvents, 1)
+				continue
+			}
+			// Likewise, only events that the subscription would
+			// receive are sampled by the CPU limit.
+			if !s.cpuLimit.admit(es.subscription) {
+				s.DropEvent(es.subscription.priority)
+				es.subscription.drops.add(
+					api.DropReason_DROP_REASON_CPU_LIMIT, 1)
 				continue
 			}
+			if rateLimit {
+				rateLimit = false
+				key := syscallRateKey{
+					pid: event.ProcessPid,
+					id:  sev.Syscall.Id,
+					typ: sev.Syscall.Type,
+				}
+				rateLimited = !s.syscallRateLimiter.allow(key,
+					event.SensorMonotimeNanos)
+				if rateLimited {
+					atomic.AddUint64(&s.Metrics.RateLimitedEvents, 1)
+				}
+			}
+			if rateLimited {
+				es.subscription.drops.add(
+					api.DropReason_DROP_REASON_SYSCALL_RATE_LIMIT, 1)
+				continue
+			}
+			s := es.subscription
 			if cef, ok := event.Event.(*api.TelemetryEvent_Container); ok {
 				if es.containerView != api.ContainerEventView_FULL {
 					if len(eventSinks) > 1 {
@@ -678,56 +1189,91 @@ func (s *Sensor) dispatchQueuedSamples(samples []perf.EventMonitorSample) {
 					cef.Container.OciConfigJson = ""
 				}
 			}
-			s.dispatchFn(event)
+			dispatchEvent := event
+			if es.edgePredicate != nil {
+				edge, cause, ok := s.edgeTrigger.transition(
+					es.edgePredicate, es.edgeTypes, event,
+					expression.FieldValueMap(esm.DecodedData))
+				if !ok {
+					continue
+				}
+				// The event may be shared with other event
+				// sinks, so annotate a shallow copy.
+				e := *event
+				e.EdgeTransition = edge
+				if s.includeCausedBy && cause != 0 {
+					e.CausedBy = []uint64{cause}
+				}
+				dispatchEvent = &e
+			}
+			if es.failurePredicate != nil {
+				count, cause, ok := s.failureThreshold.observe(
+					es.failurePredicate, es.failureTypes, event,
+					expression.FieldValueMap(esm.DecodedData))
+				if !ok {
+					continue
+				}
+				e := *dispatchEvent
+				e.FailureCount = count
+				if s.includeCausedBy && cause != 0 {
+					e.CausedBy = []uint64{cause}
+				}
+				dispatchEvent = &e
+			}
+			if counts != nil {
+				counts.add(event)
+				counts = nil
+			}
+			if es.dispatchFn != nil {
+				es.dispatchFn(dispatchEvent)
+			} else {
+				s.dispatchFn(dispatchEvent)
+			}
 		}
-	}
-}
-
-func (s *Sensor) sampleDispatchLoop() {
-	glog.V(2).Info("Sample dispatch loop started")
 
-	s.dispatchMutex.Lock()
-	for s.dispatchRunning {
-		if s.dispatchQueueHead == nil {
-			s.dispatchCond.Wait()
-			continue
+		if isSyscall {
+			s.syscallCosts.addDelivery(sev.Syscall.Id,
+				time.Since(deliveryStart))
 		}
-		samples := s.popSamples()
 
-		s.dispatchMutex.Unlock()
-		s.dispatchQueuedSamples(samples)
-		s.dispatchMutex.Lock()
+		// Exited processes are only forgotten by pid filters after
+		// their exit events have been dispatched.
+		if !isolatedCopy {
+			s.pidFilters.exit(event)
+		}
 	}
-	s.dispatchMutex.Unlock()
-
-	glog.V(2).Info("Sample dispatch loop stopped")
-	s.dispatchWaitGroup.Done()
 }
 
 func copyTelemetryEvent(oldEvent *api.TelemetryEvent) *api.TelemetryEvent {
 	newEvent := *oldEvent
+
+	// The oneof wrapper is replaced as well as the payload, since the
+	// wrapper is shared with oldEvent.
 	switch event := newEvent.Event.(type) {
 	case *api.TelemetryEvent_Chargen:
 		newChargen := *event.Chargen
-		newEvent.Event.(*api.TelemetryEvent_Chargen).Chargen = &newChargen
+		newEvent.Event = &api.TelemetryEvent_Chargen{Chargen: &newChargen}
 	case *api.TelemetryEvent_Container:
 		newContainer := *event.Container
-		newEvent.Event.(*api.TelemetryEvent_Container).Container = &newContainer
+		newEvent.Event = &api.TelemetryEvent_Container{Container: &newContainer}
 	case *api.TelemetryEvent_File:
 		newFile := *event.File
-		newEvent.Event.(*api.TelemetryEvent_File).File = &newFile
+		newEvent.Event = &api.TelemetryEvent_File{File: &newFile}
 	case *api.TelemetryEvent_KernelCall:
 		newKernelCall := *event.KernelCall
-		newEvent.Event.(*api.TelemetryEvent_KernelCall).KernelCall = &newKernelCall
+		newEvent.Event = &api.TelemetryEvent_KernelCall{KernelCall: &newKernelCall}
 	case *api.TelemetryEvent_Network:
 		newNetwork := *event.Network
-		newEvent.Event.(*api.TelemetryEvent_Network).Network = &newNetwork
+		newEvent.Event = &api.TelemetryEvent_Network{Network: &newNetwork}
 	case *api.TelemetryEvent_Process:
 		newProcess := *event.Process
-		newEvent.Event.(*api.TelemetryEvent_Process).Process = &newProcess
+		newEvent.Event = &api.TelemetryEvent_Process{Process: &newProcess}
+	case *api.TelemetryEvent_Signal:
+		newSignal := *event.Signal
+		newEvent.Event = &api.TelemetryEvent_Signal{Signal: &newSignal}
 	case *api.TelemetryEvent_Syscall:
 		newSyscall := *event.Syscall
-		newEvent.Event.(*api.TelemetryEvent_Syscall).Syscall = &newSyscall
+		newEvent.Event = &api.TelemetryEvent_Syscall{Syscall: &newSyscall}
 	default:
 		glog.Fatal("Unable to copy event: %+v", oldEvent)
 	}
@@ -0,0 +1,266 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"strconv"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"google.golang.org/genproto/googleapis/rpc/code"
+)
+
+const (
+	signalGenerateTracepoint = "signal/signal_generate"
+	signalDeliverTracepoint  = "signal/signal_deliver"
+
+	// The number of the first real-time signal as seen by the kernel.
+	// glibc reserves the first few, so SIGRTMIN in userspace is higher.
+	signalRTMin = 32
+	signalRTMax = 64
+)
+
+var signalGenerateEventTypes = expression.FieldTypeMap{
+	"sig":    expression.ValueTypeSignedInt32,
+	"errno":  expression.ValueTypeSignedInt32,
+	"code":   expression.ValueTypeSignedInt32,
+	"pid":    expression.ValueTypeSignedInt32,
+	"group":  expression.ValueTypeSignedInt32,
+	"result": expression.ValueTypeSignedInt32,
+}
+
+var signalDeliverEventTypes = expression.FieldTypeMap{
+	"sig":        expression.ValueTypeSignedInt32,
+	"errno":      expression.ValueTypeSignedInt32,
+	"code":       expression.ValueTypeSignedInt32,
+	"sa_handler": expression.ValueTypeUnsignedInt64,
+	"sa_flags":   expression.ValueTypeUnsignedInt64,
+}
+
+// signalDerivedEventTypes are the types of fields that the signal event
+// decoders derive from the tracepoint data.
+var signalDerivedEventTypes = expression.FieldTypeMap{
+	"signal_name": expression.ValueTypeString,
+}
+
+// signalNames maps x86_64 signal numbers to their names, from
+// arch/x86/include/uapi/asm/signal.h.
+var signalNames = map[int32]string{
+	1:  "SIGHUP",
+	2:  "SIGINT",
+	3:  "SIGQUIT",
+	4:  "SIGILL",
+	5:  "SIGTRAP",
+	6:  "SIGABRT",
+	7:  "SIGBUS",
+	8:  "SIGFPE",
+	9:  "SIGKILL",
+	10: "SIGUSR1",
+	11: "SIGSEGV",
+	12: "SIGUSR2",
+	13: "SIGPIPE",
+	14: "SIGALRM",
+	15: "SIGTERM",
+	16: "SIGSTKFLT",
+	17: "SIGCHLD",
+	18: "SIGCONT",
+	19: "SIGSTOP",
+	20: "SIGTSTP",
+	21: "SIGTTIN",
+	22: "SIGTTOU",
+	23: "SIGURG",
+	24: "SIGXCPU",
+	25: "SIGXFSZ",
+	26: "SIGVTALRM",
+	27: "SIGPROF",
+	28: "SIGWINCH",
+	29: "SIGIO",
+	30: "SIGPWR",
+	31: "SIGSYS",
+}
+
+// signalName returns the name of a signal. Real-time signals are named
+// relative to the kernel's SIGRTMIN, and unknown signals by their number.
+func signalName(sig int32) string {
+	if name, ok := signalNames[sig]; ok {
+		return name
+	}
+	if sig == signalRTMin {
+		return "SIGRTMIN"
+	}
+	if sig > signalRTMin && sig <= signalRTMax {
+		return "SIGRTMIN+" + strconv.Itoa(int(sig-signalRTMin))
+	}
+	return strconv.Itoa(int(sig))
+}
+
+type signalFilter struct {
+	sensor *Sensor
+}
+
+// newSignalEvent creates the event for a signal tracepoint sample, setting
+// the fields that both tracepoints have in common.
+func (f *signalFilter) newSignalEvent(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+	eventType api.SignalEventType,
+) (*api.TelemetryEvent, *api.SignalEvent) {
+	ev := f.sensor.NewEventFromSample(sample, data)
+	if ev == nil {
+		return nil, nil
+	}
+
+	signal := &api.SignalEvent{Type: eventType}
+	signal.Signal, _ = data["sig"].(int32)
+	signal.Errno, _ = data["errno"].(int32)
+	signal.Code, _ = data["code"].(int32)
+	signal.SignalName = signalName(signal.Signal)
+	data["signal_name"] = signal.SignalName
+
+	ev.Event = &api.TelemetryEvent_Signal{
+		Signal: signal,
+	}
+	return ev, signal
+}
+
+func (f *signalFilter) decodeSignalGenerate(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	ev, signal := f.newSignalEvent(sample, data,
+		api.SignalEventType_SIGNAL_EVENT_TYPE_GENERATE)
+	if ev == nil {
+		return nil, nil
+	}
+
+	signal.SenderPid = ev.ProcessPid
+	signal.TargetPid, _ = data["pid"].(int32)
+	if comm, ok := data["comm"].([]interface{}); ok {
+		signal.TargetCommand = commToString(comm)
+	}
+	// group was a bool before Linux 4.10
+	switch group := data["group"].(type) {
+	case int32:
+		signal.Group = group != 0
+	case bool:
+		signal.Group = group
+	}
+	signal.Result, _ = data["result"].(int32)
+
+	return ev, nil
+}
+
+func (f *signalFilter) decodeSignalDeliver(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	ev, signal := f.newSignalEvent(sample, data,
+		api.SignalEventType_SIGNAL_EVENT_TYPE_DELIVER)
+	if ev == nil {
+		return nil, nil
+	}
+
+	signal.SaHandler, _ = data["sa_handler"].(uint64)
+	signal.SaFlags, _ = data["sa_flags"].(uint64)
+
+	return ev, nil
+}
+
+func registerSignalEvents(
+	sensor *Sensor,
+	subscr *subscription,
+	events []*api.SignalEventFilter,
+) {
+	var (
+		generateFilter, deliverFilter     *api.Expression
+		generateWildcard, deliverWildcard bool
+	)
+
+	for _, sef := range events {
+		switch sef.Type {
+		case api.SignalEventType_SIGNAL_EVENT_TYPE_GENERATE:
+			if sef.FilterExpression == nil {
+				generateWildcard = true
+			} else {
+				generateFilter = expression.LogicalOr(
+					generateFilter, sef.FilterExpression)
+			}
+		case api.SignalEventType_SIGNAL_EVENT_TYPE_DELIVER:
+			if sef.FilterExpression == nil {
+				deliverWildcard = true
+			} else {
+				deliverFilter = expression.LogicalOr(
+					deliverFilter, sef.FilterExpression)
+			}
+		default:
+			subscr.logStatus(
+				code.Code_INVALID_ARGUMENT,
+				fmt.Sprintf("SignalEventType %d is invalid", sef.Type))
+		}
+	}
+
+	f := signalFilter{
+		sensor: sensor,
+	}
+	if generateWildcard {
+		generateFilter = nil
+	}
+	if generateWildcard || generateFilter != nil {
+		registerSignalTracepoint(sensor, subscr, signalGenerateTracepoint,
+			f.decodeSignalGenerate, generateFilter,
+			signalGenerateEventTypes)
+	}
+	if deliverWildcard {
+		deliverFilter = nil
+	}
+	if deliverWildcard || deliverFilter != nil {
+		registerSignalTracepoint(sensor, subscr, signalDeliverTracepoint,
+			f.decodeSignalDeliver, deliverFilter,
+			signalDeliverEventTypes)
+	}
+}
+
+func registerSignalTracepoint(
+	sensor *Sensor,
+	subscr *subscription,
+	name string,
+	fn perf.TraceEventDecoderFn,
+	filter *api.Expression,
+	filterTypes expression.FieldTypeMap,
+) {
+	eventID, err := sensor.Monitor.RegisterTracepoint(name, fn,
+		perf.WithEventAttr(subscr.eventAttr),
+		perf.WithEventGroup(subscr.eventGroupID))
+	if err != nil {
+		subscr.logStatus(
+			code.Code_UNKNOWN,
+			fmt.Sprintf("Could not register tracepoint %s: %v", name, err))
+		return
+	}
+
+	_, err = subscr.addDerivedEventSink(eventID,
+		sensor.applyDefaultFilter(DefaultFilterSignal, filter),
+		filterTypes, signalDerivedEventTypes)
+	if err != nil {
+		subscr.logStatus(
+			code.Code_UNKNOWN,
+			fmt.Sprintf("Invalid filter expression for signal filter: %v", err))
+		sensor.Monitor.UnregisterEvent(eventID)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import "testing"
+
+func TestSignalName(t *testing.T) {
+	for sig, name := range map[int32]string{
+		9:  "SIGKILL",
+		31: "SIGSYS",
+		32: "SIGRTMIN",
+		35: "SIGRTMIN+3",
+		64: "SIGRTMIN+32",
+		65: "65",
+		0:  "0",
+	} {
+		if got := signalName(sig); got != name {
+			t.Errorf("Expected %q for signal %d, got %q", name, sig, got)
+		}
+	}
+}
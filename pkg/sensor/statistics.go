@@ -0,0 +1,65 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"sort"
+	"sync/atomic"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+func addEventSinkFilterStatistics(fs *api.FilterStatistics, es *eventSink) {
+	if es.filter == nil {
+		fs.KernelEventSinks++
+		return
+	}
+	fs.UserspaceEventSinks++
+	if es.filterFallback {
+		fs.FallbackEventSinks++
+	}
+	fs.UserspaceEvaluations += atomic.LoadUint64(&es.evaluations)
+	fs.UserspaceEvaluationNanos += atomic.LoadUint64(&es.evaluationNanos)
+}
+
+// FilterStatistics returns the number of event sinks across all active
+// subscriptions that have their filters evaluated by the kernel versus in
+// userspace, along with the cost of userspace evaluation. The totals are
+// broken down by event type.
+func (s *Sensor) FilterStatistics() *api.FilterStatistics {
+	total := &api.FilterStatistics{}
+	eventTypes := make(map[string]*api.FilterStatistics)
+
+	for _, eventSinks := range s.eventMap.getMap() {
+		for _, es := range eventSinks {
+			fs, ok := eventTypes[es.eventType]
+			if !ok {
+				fs = &api.FilterStatistics{EventType: es.eventType}
+				eventTypes[es.eventType] = fs
+			}
+			addEventSinkFilterStatistics(fs, es)
+			addEventSinkFilterStatistics(total, es)
+		}
+	}
+
+	for _, fs := range eventTypes {
+		total.EventTypes = append(total.EventTypes, fs)
+	}
+	sort.Slice(total.EventTypes, func(i, j int) bool {
+		return total.EventTypes[i].EventType < total.EventTypes[j].EventType
+	})
+
+	return total
+}
@@ -0,0 +1,91 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+)
+
+func TestFilterStatistics(t *testing.T) {
+	s := &Sensor{eventMap: newSafeSubscriptionMap()}
+	subscr := newSubscription(s, 1, nil)
+
+	subscr.eventType = "chargen"
+	subscr.addEventSink(1, nil, chargenEventTypes)
+	subscr.eventType = "syscall"
+	es, err := subscr.addEventSink(2,
+		expression.Equal(
+			expression.Identifier("mnt_ns"),
+			expression.Value(uint64(1))),
+		syscallExitEventTypes)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	es.evaluations = 3
+	es.evaluationNanos = 300
+	s.eventMap.subscribe(subscr)
+
+	fs := s.FilterStatistics()
+	if fs.KernelEventSinks != 1 || fs.UserspaceEventSinks != 1 ||
+		fs.UserspaceEvaluations != 3 ||
+		fs.UserspaceEvaluationNanos != 300 {
+		t.Errorf("Unexpected totals: %+v", fs)
+	}
+	if len(fs.EventTypes) != 2 {
+		t.Fatalf("Expected 2 event types, got %d", len(fs.EventTypes))
+	}
+	if et := fs.EventTypes[0]; et.EventType != "chargen" ||
+		et.KernelEventSinks != 1 || et.UserspaceEventSinks != 0 {
+		t.Errorf("Unexpected chargen statistics: %+v", et)
+	}
+	if et := fs.EventTypes[1]; et.EventType != "syscall" ||
+		et.KernelEventSinks != 0 || et.UserspaceEventSinks != 1 ||
+		et.UserspaceEvaluations != 3 {
+		t.Errorf("Unexpected syscall statistics: %+v", et)
+	}
+}
+
+func TestSyscallCosts(t *testing.T) {
+	s := &Sensor{}
+	if costs := s.SyscallCosts(); len(costs) != 0 {
+		t.Fatalf("Expected no costs, got %+v", costs)
+	}
+
+	s.syscallCosts.addDecode(202, 300)
+	s.syscallCosts.addDecode(202, 300)
+	s.syscallCosts.addDelivery(202, 100)
+	s.syscallCosts.addDecode(59, 200)
+	s.syscallCosts.addDelivery(59, 1000)
+	s.syscallCosts.addDecode(0, 200)
+
+	// Ids outside of the tracked range are ignored
+	s.syscallCosts.addDecode(-1, 200)
+	s.syscallCosts.addDelivery(maxSyscallCostID, 200)
+
+	costs := s.SyscallCosts()
+	if len(costs) != 3 {
+		t.Fatalf("Expected 3 costs, got %d", len(costs))
+	}
+	if costs[0].Id != 59 || costs[1].Id != 202 || costs[2].Id != 0 {
+		t.Errorf("Expected costs ordered by total time, got %+v", costs)
+	}
+	c := costs[1]
+	if c.Events != 2 || c.DecodeNanos != 600 || c.DeliveryNanos != 100 ||
+		c.DecodeFraction != 0.6 {
+		t.Errorf("Unexpected futex cost %+v", c)
+	}
+}
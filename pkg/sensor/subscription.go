@@ -15,25 +15,113 @@
 package sensor
 
 import (
+	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 
 	api "github.com/capsule8/capsule8/api/v0"
 
+	"github.com/capsule8/capsule8/pkg/config"
 	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/golang/glog"
 
 	"google.golang.org/genproto/googleapis/rpc/code"
 	google_rpc "google.golang.org/genproto/googleapis/rpc/status"
 )
 
 type subscription struct {
+	// Number of events shed because the sensor was overloaded, and the
+	// number of events considered for sampling by the CPU limit. Updated
+	// atomically. These are the first fields so that they are 64-bit
+	// aligned.
+	shedEvents uint64
+	cpuSamples uint64
+
 	sensor          *Sensor
 	eventGroupID    int32
 	counterGroupIDs []int32
 	containerFilter *containerFilter
+	pidFilter       *pidFilter
+	edgeTrigger     *edgeTrigger
+	includeCausedBy bool
+	ackThrottle     *ackThrottle
+	egressShare     *egressShare
+	drops           *dropCounts
+	priority        api.SubscriptionPriority
+	perfClock       api.PerfClock
 	eventSinks      map[uint64]*eventSink
 	status          []*google_rpc.Status
+	statusCounts    map[statusKey]*statusCount
 	dispatchFn      eventSinkDispatchFn
+
+	// The type of events being registered, e.g. "syscall". It is
+	// recorded in each event sink added for use in statistics.
+	eventType string
+
+	// The EventAttr to register the subscription's kernel events with,
+	// or nil to use the EventMonitor's default.
+	eventAttr *perf.EventAttr
+
+	// Whether the data source of memory accesses is collected for the
+	// subscription's hardware performance events. Other kernel events
+	// have no meaningful data source, so it is not part of eventAttr.
+	sampleDataSrc bool
+
+	// Kernel function call filters whose symbols were not available when
+	// the subscription was created
+	deferredKprobes []*kprobeFilter
+
+	// Status messages reported after the subscription was created
+	lateStatus chan *google_rpc.Status
+
+	// How filters that refer to unsupported fields are handled
+	unsupportedFieldPolicy api.UnsupportedFieldPolicy
+
+	// Counts consecutive failures if the subscription has a
+	// FailureThreshold
+	failureThreshold *failureThreshold
+
+	// Set if the subscription's events are decoded on a goroutine of
+	// its own. External events reach it only through that goroutine.
+	decodeIsolated bool
+}
+
+// The number of late status messages buffered for a subscription. Messages
+// are dropped if the buffer is full.
+const lateStatusBufferLength = 16
+
+// Values of config.Sensor.FilterLoweringPolicy
+const (
+	filterLoweringLenient = "lenient"
+	filterLoweringStrict  = "strict"
+)
+
+// sampleFieldTypes maps the sample fields that subscriptions may select to
+// the perf sample types that collect them.
+var sampleFieldTypes = map[api.SampleField]uint64{
+	api.SampleField_SAMPLE_FIELD_CPU:       perf.PERF_SAMPLE_CPU,
+	api.SampleField_SAMPLE_FIELD_TID:       perf.PERF_SAMPLE_TID,
+	api.SampleField_SAMPLE_FIELD_IP:        perf.PERF_SAMPLE_IP,
+	api.SampleField_SAMPLE_FIELD_CALLCHAIN: perf.PERF_SAMPLE_CALLCHAIN,
+}
+
+// requiredSampleTypes are always collected, because filters and enrichment
+// are evaluated against the raw trace event data.
+const requiredSampleTypes = perf.PERF_SAMPLE_RAW
+
+// statusKey identifies identical status messages so that they may be
+// coalesced rather than repeated.
+type statusKey struct {
+	code    code.Code
+	message string
+}
+
+type statusCount struct {
+	status *google_rpc.Status
+	count  int
 }
 
 func newSubscription(
@@ -45,6 +133,7 @@ func newSubscription(
 		sensor:       sensor,
 		eventGroupID: eventGroupID,
 		dispatchFn:   dispatchFn,
+		lateStatus:   make(chan *google_rpc.Status, lateStatusBufferLength),
 	}
 }
 
@@ -58,17 +147,191 @@ type eventSink struct {
 	filter        *expression.Expression
 	filterTypes   expression.FieldTypeMap
 	containerView api.ContainerEventView
+
+	// The filter evaluated by the kernel, if any. Only one of filter and
+	// kernelFilter is set.
+	kernelFilter *api.Expression
+
+	// The lazily resolved enrichment fields that filter refers to
+	lazyEnrichment lazyEnrichment
+
+	// The ids of a syscall sink's kernel filter as updated by
+	// UpdateSyscallIDs; nil if they have never been updated.
+	syscallIDs *syscallIDUpdate
+
+	// Set if filter is evaluated in userspace only because the kernel
+	// could not accept it.
+	filterFallback bool
+
+	// If set, events matching the sink are passed to this function rather
+	// than to the subscription's dispatch function.
+	dispatchFn eventSinkDispatchFn
+
+	// Set if the subscription's EdgeTrigger or FailureThreshold does not
+	// apply to the sink's events, none of which are then dispatched.
+	triggerInapplicable bool
+
+	// The subscription's EdgeTrigger predicate and the types of the
+	// fields that it may refer to, if it applies to the sink's events
+	edgePredicate *expression.Expression
+	edgeTypes     expression.FieldTypeMap
+
+	// The subscription's FailureThreshold predicate and the types of the
+	// fields that it and the key fields may refer to, if it applies to the
+	// sink's events
+	failurePredicate *expression.Expression
+	failureTypes     expression.FieldTypeMap
+
+	// Statistics for filter evaluation in userspace. Updated atomically.
+	eventType       string
+	evaluations     uint64
+	evaluationNanos uint64
+}
+
+// commonEventTypes are the types of fields present in the sample data of all
+// kernel trace events. Filters on them may be evaluated by the kernel.
+var commonEventTypes = expression.FieldTypeMap{
+	"common_pid": expression.ValueTypeSignedInt32,
+}
+
+// enrichmentEventTypes are the types of fields that the sensor adds to the
+// sample data of every event from its own state rather than from the kernel.
+// They are available to all filter expressions, but can only be evaluated in
+// userspace.
+var enrichmentEventTypes = expression.FieldTypeMap{
+	"process_tgid":     expression.ValueTypeSignedInt32,
+	"mnt_ns":           expression.ValueTypeUnsignedInt64,
+	"num_threads":      expression.ValueTypeUnsignedInt32,
+	"rss_kb":           expression.ValueTypeUnsignedInt64,
+	"task_uid":         expression.ValueTypeUnsignedInt32,
+	"task_gid":         expression.ValueTypeUnsignedInt32,
+	"task_euid":        expression.ValueTypeUnsignedInt32,
+	"task_egid":        expression.ValueTypeUnsignedInt32,
+	"is_kernel_thread": expression.ValueTypeBool,
+	"parent_exe":       expression.ValueTypeString,
+	"parent_comm":      expression.ValueTypeString,
+}
+
+// lazyEnrichment is a set of enrichment fields that are costly to resolve. They
+// are only resolved for events while the filter of some event sink refers to
+// them.
+type lazyEnrichment uint32
+
+const (
+	// num_threads and rss_kb, read from /proc/[pid]/status
+	lazyEnrichmentStatus lazyEnrichment = 1 << iota
+
+	// is_kernel_thread, read from /proc/[pid]/stat
+	lazyEnrichmentKernelThread
+
+	// parent_exe and parent_comm, read from /proc/[pid]/exe if the
+	// parent's executable is not already known
+	lazyEnrichmentParent
+
+	// mnt_ns, read from /proc/[pid]/ns/mnt if the task's mount namespace
+	// is not already known
+	lazyEnrichmentMountNamespace
+)
+
+// lazyEnrichmentFields maps the identifiers of enrichment fields that are
+// resolved lazily to the sets that they belong to.
+var lazyEnrichmentFields = map[string]lazyEnrichment{
+	"num_threads": lazyEnrichmentStatus,
+	"rss_kb":      lazyEnrichmentStatus,
+
+	"is_kernel_thread": lazyEnrichmentKernelThread,
+
+	"parent_exe":  lazyEnrichmentParent,
+	"parent_comm": lazyEnrichmentParent,
+
+	"mnt_ns": lazyEnrichmentMountNamespace,
+}
+
+// environmentIdentifierPrefix is the prefix of the identifiers by which
+// filters refer to process environment variables.
+const environmentIdentifierPrefix = "env."
+
+// environmentEventTypes returns the types of the enrichment fields for the
+// process environment variables that the sensor is configured to read. Like
+// other enrichment fields, they can only be evaluated in userspace.
+func environmentEventTypes() expression.FieldTypeMap {
+	types := make(expression.FieldTypeMap,
+		len(config.Sensor.EnvironmentVariables))
+	for _, name := range config.Sensor.EnvironmentVariables {
+		types[environmentIdentifierPrefix+name] = expression.ValueTypeString
+	}
+	return types
+}
+
+// walkExpressionIdentifiers calls the specified function for each identifier
+// referenced by an expression.
+func walkExpressionIdentifiers(expr *api.Expression, f func(string)) {
+	if expr == nil {
+		return
+	}
+
+	switch expr.GetType() {
+	case api.Expression_IDENTIFIER:
+		f(expr.GetIdentifier())
+	case api.Expression_VALUE:
+		// Nothing to do
+	default:
+		if operands := expr.GetBinaryOp(); operands != nil {
+			walkExpressionIdentifiers(operands.Lhs, f)
+			walkExpressionIdentifiers(operands.Rhs, f)
+		} else {
+			walkExpressionIdentifiers(expr.GetUnaryOp(), f)
+		}
+	}
 }
 
 func (s *subscription) addEventSink(
 	eventID uint64,
 	filterExpression *api.Expression,
 	filterTypes expression.FieldTypeMap,
+) (*eventSink, error) {
+	return s.addDerivedEventSink(eventID, filterExpression, filterTypes, nil)
+}
+
+// addDerivedEventSink is like addEventSink, but also accepts the types of
+// fields that the event's decoder derives and adds to the sample data. Like
+// enrichment fields, filters that refer to them can only be evaluated in
+// userspace.
+func (s *subscription) addDerivedEventSink(
+	eventID uint64,
+	filterExpression *api.Expression,
+	filterTypes expression.FieldTypeMap,
+	derivedTypes expression.FieldTypeMap,
 ) (*eventSink, error) {
 	es := &eventSink{
 		subscription: s,
 		eventID:      eventID,
 		filterTypes:  filterTypes,
+		eventType:    s.eventType,
+	}
+
+	// Fields that none of the event's field maps define are unsupported,
+	// typically because the running kernel's version of the event lacks
+	// them. The subscription's policy decides whether predicates that
+	// refer to them are dropped or the subscription is rejected.
+	envTypes := environmentEventTypes()
+	filterExpression, err := s.dropUnsupportedPredicates(filterExpression,
+		func(ident string) bool {
+			for _, types := range []expression.FieldTypeMap{
+				filterTypes, derivedTypes, enrichmentEventTypes,
+				envTypes, commonEventTypes,
+			} {
+				if _, ok := types[ident]; ok {
+					return true
+				}
+			}
+			// Unconfigured environment variables are reported
+			// separately below.
+			return strings.HasPrefix(ident,
+				environmentIdentifierPrefix)
+		})
+	if err != nil {
+		return nil, err
 	}
 
 	if filterExpression != nil {
@@ -77,25 +340,96 @@ func (s *subscription) addEventSink(
 			return nil, err
 		}
 
-		if err = expr.Validate(filterTypes); err != nil {
-			return nil, err
+		// Filters may also refer to fields common to all kernel events
+		// and to derived or enrichment fields added by the sensor. Add
+		// the types of any that are referenced.
+		var (
+			userspace bool
+			types     expression.FieldTypeMap
+			envErr    error
+		)
+		walkExpressionIdentifiers(filterExpression, func(ident string) {
+			if _, ok := filterTypes[ident]; ok {
+				return
+			}
+			t, ok := derivedTypes[ident]
+			if !ok {
+				t, ok = enrichmentEventTypes[ident]
+				es.lazyEnrichment |= lazyEnrichmentFields[ident]
+			}
+			if !ok {
+				t, ok = envTypes[ident]
+				if !ok && envErr == nil && strings.HasPrefix(ident,
+					environmentIdentifierPrefix) {
+					envErr = fmt.Errorf("Environment variable %q is not configured to be read by the sensor",
+						strings.TrimPrefix(ident,
+							environmentIdentifierPrefix))
+				}
+			}
+			if ok {
+				userspace = true
+			} else if t, ok = commonEventTypes[ident]; !ok {
+				return
+			}
+			if types == nil {
+				types = make(expression.FieldTypeMap,
+					len(filterTypes)+1)
+				for k, v := range filterTypes {
+					types[k] = v
+				}
+			}
+			types[ident] = t
+		})
+		if envErr != nil {
+			return nil, envErr
+		}
+		if types != nil {
+			es.filterTypes = types
 		}
 
-		// If this is a valid kernel filter, attempt to set it as a
-		// kernel filter. If it is either not a valid kernel filter or
-		// it fails to set as a kernel filter, set the filter in the
-		// sink to fallback to evaluation via the expression package.
-		// The err checking code here looks a little weird, but it is
-		// what is intended.
-		if err = expr.ValidateKernelFilter(); err == nil {
-			err = s.sensor.Monitor.SetFilter(eventID,
-				expr.KernelFilterString())
+		if err = expr.Validate(es.filterTypes); err != nil {
+			return nil, err
 		}
-		if err != nil {
+
+		// Derived and enrichment fields are unknown to the kernel, so
+		// those filters are always evaluated via the expression
+		// package. Otherwise, attempt to set it as a kernel filter. If
+		// it is either not a valid kernel filter or it fails to set as
+		// a kernel filter, the filter lowering policy decides whether
+		// to fall back to evaluation via the expression package.
+		if userspace {
 			es.filter = expr
+		} else {
+			if err = expr.ValidateKernelFilter(); err == nil {
+				err = s.sensor.Monitor.SetFilter(eventID,
+					expr.KernelFilterString())
+			}
+			if err == nil {
+				es.kernelFilter = filterExpression
+			} else if config.Sensor.FilterLoweringPolicy == filterLoweringStrict {
+				return nil, fmt.Errorf("Filter cannot be evaluated by the kernel: %v", err)
+			} else {
+				s.logStatus(
+					code.Code_UNIMPLEMENTED,
+					fmt.Sprintf("Filter evaluated in userspace because it cannot be evaluated by the kernel: %v", err))
+				es.filter = expr
+				es.filterFallback = true
+			}
 		}
 	}
 
+	if s.edgeTrigger != nil {
+		es.edgePredicate, es.edgeTypes = s.edgeTrigger.compile(s,
+			filterTypes, derivedTypes)
+		es.triggerInapplicable = es.edgePredicate == nil
+	}
+	if s.failureThreshold != nil {
+		es.failurePredicate, es.failureTypes =
+			s.failureThreshold.compile(s, filterTypes, derivedTypes)
+		es.triggerInapplicable = es.triggerInapplicable ||
+			es.failurePredicate == nil
+	}
+
 	if s.eventSinks == nil {
 		s.eventSinks = make(map[uint64]*eventSink)
 	}
@@ -103,16 +437,218 @@ func (s *subscription) addEventSink(
 	return es, nil
 }
 
+// compileEventSinkPredicate compiles a predicate that a subscription applies
+// to the events of each of its event sinks, such as an EdgeTrigger predicate,
+// and returns it with the types of the fields that it may refer to. It is an
+// error for the predicate to refer to, or for keyFields to name, fields that
+// the sink's events do not have. filterTypes and derivedTypes are the types
+// of the fields of the sink's events.
+func compileEventSinkPredicate(
+	predicate *api.Expression,
+	keyFields []string,
+	filterTypes expression.FieldTypeMap,
+	derivedTypes expression.FieldTypeMap,
+) (*expression.Expression, expression.FieldTypeMap, error) {
+	expr, err := expression.NewExpression(predicate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	types := make(expression.FieldTypeMap)
+	for _, m := range []expression.FieldTypeMap{
+		commonEventTypes, enrichmentEventTypes, environmentEventTypes(),
+		derivedTypes, filterTypes,
+	} {
+		for k, v := range m {
+			types[k] = v
+		}
+	}
+	if err = expr.Validate(types); err != nil {
+		return nil, nil, err
+	}
+	for _, f := range keyFields {
+		if _, ok := types[f]; !ok {
+			return nil, nil, fmt.Errorf("Unknown key field %q", f)
+		}
+	}
+	return expr, types, nil
+}
+
+// dropUnsupportedPredicates checks a filter expression for references to
+// fields that are not supported. Under the strict policy, any reference is
+// an error. Under the lenient policy, the predicates that refer to them are
+// dropped from the expression with a status naming them, and the remaining
+// expression is returned. It is nil if nothing remains.
+func (s *subscription) dropUnsupportedPredicates(
+	filterExpression *api.Expression,
+	supported func(string) bool,
+) (*api.Expression, error) {
+	var unsupported []string
+	seen := make(map[string]bool)
+	walkExpressionIdentifiers(filterExpression, func(ident string) {
+		if !seen[ident] && !supported(ident) {
+			unsupported = append(unsupported, ident)
+		}
+		seen[ident] = true
+	})
+	if len(unsupported) == 0 {
+		return filterExpression, nil
+	}
+
+	if s.unsupportedFieldPolicy != api.UnsupportedFieldPolicy_UNSUPPORTED_FIELD_POLICY_LENIENT {
+		return nil, fmt.Errorf("Filter refers to unsupported fields: %s",
+			strings.Join(unsupported, ", "))
+	}
+
+	kept, dropped := dropExpressionPredicates(filterExpression,
+		func(ident string) bool {
+			return !supported(ident)
+		})
+	predicates := make([]string, len(dropped))
+	for i, p := range dropped {
+		if expr, err := expression.NewExpression(p); err == nil {
+			predicates[i] = expr.String()
+		} else {
+			predicates[i] = p.String()
+		}
+	}
+	s.logStatus(
+		code.Code_FAILED_PRECONDITION,
+		fmt.Sprintf("Dropped filter predicates that refer to unsupported fields (%s): %s",
+			strings.Join(unsupported, ", "),
+			strings.Join(predicates, "; ")))
+	return kept, nil
+}
+
+// dropExpressionPredicates removes the predicates of an expression that
+// refer to any identifier for which drop returns true. The operands of
+// logical ands are dropped individually; any other expression, including a
+// logical or or not, is dropped as a whole. This treats dropped predicates as
+// true, so that the remaining expression matches at least the events that
+// the original would have. It returns the remaining expression, which is nil
+// if nothing remains, and the dropped predicates.
+func dropExpressionPredicates(
+	expr *api.Expression,
+	drop func(string) bool,
+) (*api.Expression, []*api.Expression) {
+	if expr.GetType() == api.Expression_LOGICAL_AND {
+		operands := expr.GetBinaryOp()
+		lhs, lhsDropped := dropExpressionPredicates(operands.Lhs, drop)
+		rhs, rhsDropped := dropExpressionPredicates(operands.Rhs, drop)
+		dropped := append(lhsDropped, rhsDropped...)
+		if len(dropped) == 0 {
+			return expr, nil
+		}
+		return expression.LogicalAnd(lhs, rhs), dropped
+	}
+
+	referenced := false
+	walkExpressionIdentifiers(expr, func(ident string) {
+		if drop(ident) {
+			referenced = true
+		}
+	})
+	if referenced {
+		return nil, []*api.Expression{expr}
+	}
+	return expr, nil
+}
+
 func (s *subscription) removeEventSink(es *eventSink) {
 	delete(s.eventSinks, es.eventID)
 }
 
+// setSampleFields selects the sample fields collected for the subscription's
+// kernel events. If no fields are specified, the EventMonitor's defaults are
+// used.
+func (s *subscription) setSampleFields(fields []api.SampleField) {
+	s.sampleDataSrc = false
+	for i := 0; i < len(fields); {
+		if fields[i] == api.SampleField_SAMPLE_FIELD_DATA_SRC {
+			s.sampleDataSrc = true
+			fields = append(fields[:i:i], fields[i+1:]...)
+			continue
+		}
+		i++
+	}
+	if len(fields) == 0 {
+		s.eventAttr = nil
+		return
+	}
+
+	sampleType := requiredSampleTypes
+	for _, f := range fields {
+		t, ok := sampleFieldTypes[f]
+		if !ok {
+			s.logStatus(
+				code.Code_INVALID_ARGUMENT,
+				fmt.Sprintf("SampleField %d is invalid", f))
+			continue
+		}
+		sampleType |= t
+	}
+	s.eventAttr = &perf.EventAttr{
+		SampleType: sampleType,
+	}
+}
+
+// logStatus records a status message for the subscription. The first
+// occurrence of a message is recorded immediately; identical messages that
+// follow are only counted, and the count is reported when the status
+// messages are collected by takeStatus.
 func (s *subscription) logStatus(code code.Code, message string) {
-	s.status = append(s.status,
-		&google_rpc.Status{
-			Code:    int32(code),
-			Message: message,
-		})
+	key := statusKey{
+		code:    code,
+		message: message,
+	}
+	if c, ok := s.statusCounts[key]; ok {
+		c.count++
+		return
+	}
+
+	st := &google_rpc.Status{
+		Code:    int32(code),
+		Message: message,
+	}
+	s.status = append(s.status, st)
+
+	if s.statusCounts == nil {
+		s.statusCounts = make(map[statusKey]*statusCount)
+	}
+	s.statusCounts[key] = &statusCount{
+		status: st,
+		count:  1,
+	}
+}
+
+// takeStatus returns the status messages recorded for the subscription since
+// the last call and resets them.
+func (s *subscription) takeStatus() []*google_rpc.Status {
+	for _, c := range s.statusCounts {
+		if c.count > 1 {
+			c.status.Message = fmt.Sprintf("%s (repeated %d times)",
+				c.status.Message, c.count)
+		}
+	}
+
+	status := s.status
+	s.status = nil
+	s.statusCounts = nil
+	return status
+}
+
+// sendLateStatus reports a status message for the subscription after it has
+// been created. Unlike logStatus, it may be called from any goroutine.
+func (s *subscription) sendLateStatus(code code.Code, message string) {
+	glog.V(1).Infof("Subscription %d: [%s] %s",
+		s.eventGroupID, code, message)
+	select {
+	case s.lateStatus <- &google_rpc.Status{
+		Code:    int32(code),
+		Message: message,
+	}:
+	default:
+	}
 }
 
 //
@@ -129,6 +665,10 @@ func newSubscriptionMap() subscriptionMap {
 type safeSubscriptionMap struct {
 	sync.Mutex              // used only by writers
 	active     atomic.Value // map[uint64]map[int32]*eventSink
+
+	// The lazyEnrichment referred to by the sinks in active. Updated
+	// atomically.
+	enrichment uint32
 }
 
 func newSafeSubscriptionMap() *safeSubscriptionMap {
@@ -143,6 +683,25 @@ func (ssm *safeSubscriptionMap) getMap() subscriptionMap {
 	return value.(subscriptionMap)
 }
 
+// lazyEnrichment returns the lazily resolved enrichment fields that the
+// filters of active event sinks refer to.
+func (ssm *safeSubscriptionMap) lazyEnrichment() lazyEnrichment {
+	return lazyEnrichment(atomic.LoadUint32(&ssm.enrichment))
+}
+
+// store makes a new map active, along with the lazily resolved enrichment
+// fields that its sinks refer to. The caller must hold the lock.
+func (ssm *safeSubscriptionMap) store(m subscriptionMap) {
+	var enrichment lazyEnrichment
+	for _, v := range m {
+		for _, es := range v {
+			enrichment |= es.lazyEnrichment
+		}
+	}
+	ssm.active.Store(m)
+	atomic.StoreUint32(&ssm.enrichment, uint32(enrichment))
+}
+
 func (ssm *safeSubscriptionMap) subscribe(subscr *subscription) {
 	ssm.Lock()
 	defer ssm.Unlock()
@@ -169,7 +728,7 @@ func (ssm *safeSubscriptionMap) subscribe(subscr *subscription) {
 		subscriptionMap[subscr.eventGroupID] = es
 	}
 
-	ssm.active.Store(nm)
+	ssm.store(nm)
 }
 
 func (ssm *safeSubscriptionMap) unsubscribe(
@@ -206,7 +765,7 @@ func (ssm *safeSubscriptionMap) unsubscribe(
 				nm[eventID] = m
 			}
 		}
-		ssm.active.Store(nm)
+		ssm.store(nm)
 	}
 
 	ssm.Unlock()
@@ -0,0 +1,134 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+// subscriptionSummary accumulates the counts of a subscription's events that
+// are reported when the subscription ends.
+type subscriptionSummary struct {
+	// Events discarded by the client's stream, and passed to the
+	// subscription to count the events that it discards
+	drops *dropCounts
+
+	start      time.Time
+	delivered  uint64
+	typeCounts map[string]uint64
+}
+
+func newSubscriptionSummary() *subscriptionSummary {
+	return &subscriptionSummary{
+		drops:      new(dropCounts),
+		start:      time.Now(),
+		typeCounts: make(map[string]uint64),
+	}
+}
+
+// telemetryEventType returns the name of the type of an event, matching the
+// names used for filter statistics. Synthetic events are counted as their
+// own type.
+func telemetryEventType(e *api.TelemetryEvent) string {
+	if e.Synthetic {
+		return "synthetic"
+	}
+	switch e.Event.(type) {
+	case *api.TelemetryEvent_Chargen:
+		return "chargen"
+	case *api.TelemetryEvent_Container:
+		return "container"
+	case *api.TelemetryEvent_DecodeError:
+		return "decode_error"
+	case *api.TelemetryEvent_File:
+		return "file"
+	case *api.TelemetryEvent_FilelessExecution:
+		return "fileless_execution"
+	case *api.TelemetryEvent_KernelCall:
+		return "kernel"
+	case *api.TelemetryEvent_Network:
+		return "network"
+	case *api.TelemetryEvent_Performance:
+		return "performance"
+	case *api.TelemetryEvent_Process:
+		return "process"
+	case *api.TelemetryEvent_QuietPeriod:
+		return "quiet_period"
+	case *api.TelemetryEvent_Signal:
+		return "signal"
+	case *api.TelemetryEvent_SubscriptionStats:
+		return "subscription_stats"
+	case *api.TelemetryEvent_Syscall:
+		return "syscall"
+	case *api.TelemetryEvent_Ticker:
+		return "ticker"
+	}
+	return "unknown"
+}
+
+// deliver counts an event sent to the client.
+func (s *subscriptionSummary) deliver(e *api.TelemetryEvent) {
+	s.delivered++
+	s.typeCounts[telemetryEventType(e)]++
+}
+
+// drop counts an event discarded because the subscription's buffer was full.
+func (s *subscriptionSummary) drop() {
+	s.drops.add(api.DropReason_DROP_REASON_BUFFER_FULL, 1)
+}
+
+// summarize returns the summary of a subscription that is ending. Events
+// discarded by the sensor are counted by the subscription, if it was created,
+// and lost is the number of events still buffered.
+func (s *subscriptionSummary) summarize(
+	subscr *subscription,
+	lost int,
+	reason string,
+) *api.SubscriptionSummary {
+	var drops []*api.DropCount
+	if subscr != nil {
+		drops = subscr.dropStatistics()
+	} else if n := s.drops.load(api.DropReason_DROP_REASON_BUFFER_FULL); n > 0 {
+		drops = []*api.DropCount{{
+			Reason: api.DropReason_DROP_REASON_BUFFER_FULL,
+			Count:  n,
+		}}
+	}
+
+	// events_dropped predates the breakdown by reason, and only counts
+	// the events discarded on their way to the client.
+	var dropped uint64
+	for _, d := range drops {
+		switch d.Reason {
+		case api.DropReason_DROP_REASON_BUFFER_FULL,
+			api.DropReason_DROP_REASON_OVERLOAD,
+			api.DropReason_DROP_REASON_ACK_THROTTLE,
+			api.DropReason_DROP_REASON_EGRESS_LIMIT,
+			api.DropReason_DROP_REASON_CPU_LIMIT:
+			dropped += d.Count
+		}
+	}
+	return &api.SubscriptionSummary{
+		Reason:          reason,
+		DurationNanos:   int64(time.Since(s.start)),
+		EventsDelivered: s.delivered,
+		EventsDropped:   dropped,
+		EventsLost:      uint64(lost),
+		EventTypeCounts: s.typeCounts,
+		Drops:           drops,
+	}
+}
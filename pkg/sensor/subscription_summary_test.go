@@ -0,0 +1,65 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+func TestSubscriptionSummary(t *testing.T) {
+	s := newSubscriptionSummary()
+	s.deliver(&api.TelemetryEvent{
+		Event: &api.TelemetryEvent_Syscall{},
+	})
+	s.deliver(&api.TelemetryEvent{
+		Event: &api.TelemetryEvent_Syscall{},
+	})
+	s.deliver(&api.TelemetryEvent{
+		Event: &api.TelemetryEvent_Process{},
+	})
+	s.drop()
+
+	subscr := &subscription{shedEvents: 2, drops: s.drops}
+	subscr.drops.add(api.DropReason_DROP_REASON_DECODE_ERROR, 5)
+	summary := s.summarize(subscr, 4, "event limit reached")
+
+	if summary.Reason != "event limit reached" {
+		t.Errorf("Unexpected reason %q", summary.Reason)
+	}
+	if summary.EventsDelivered != 3 || summary.EventsDropped != 3 ||
+		summary.EventsLost != 4 {
+		t.Errorf("Unexpected counts: %+v", summary)
+	}
+	if len(summary.Drops) != 3 ||
+		summary.Drops[0].Reason != api.DropReason_DROP_REASON_BUFFER_FULL ||
+		summary.Drops[0].Count != 1 ||
+		summary.Drops[1].Reason != api.DropReason_DROP_REASON_OVERLOAD ||
+		summary.Drops[1].Count != 2 ||
+		summary.Drops[2].Reason != api.DropReason_DROP_REASON_DECODE_ERROR ||
+		summary.Drops[2].Count != 5 {
+		t.Errorf("Unexpected drops: %v", summary.Drops)
+	}
+	if summary.EventTypeCounts["syscall"] != 2 ||
+		summary.EventTypeCounts["process"] != 1 {
+		t.Errorf("Unexpected event type counts: %v",
+			summary.EventTypeCounts)
+	}
+	if summary.DurationNanos <= 0 {
+		t.Errorf("Expected a positive duration, got %d",
+			summary.DurationNanos)
+	}
+}
@@ -0,0 +1,367 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/config"
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"google.golang.org/genproto/googleapis/rpc/code"
+)
+
+func TestLazyEnrichment(t *testing.T) {
+	ssm := newSafeSubscriptionMap()
+
+	s1 := newSubscription(nil, 1, nil)
+	es, err := s1.addEventSink(1, expression.GreaterThan(
+		expression.Identifier("num_threads"),
+		expression.Value(uint32(100))), syscallExitEventTypes)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if es.lazyEnrichment != lazyEnrichmentStatus {
+		t.Errorf("Expected status enrichment, got %#x", es.lazyEnrichment)
+	}
+
+	s2 := newSubscription(nil, 2, nil)
+	if _, err = s2.addEventSink(1, expression.Equal(
+		expression.Identifier("mnt_ns"),
+		expression.Value(uint64(4026531840))),
+		syscallExitEventTypes); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if e := ssm.lazyEnrichment(); e != 0 {
+		t.Errorf("Expected no lazy enrichment, got %#x", e)
+	}
+	ssm.subscribe(s2)
+	if e := ssm.lazyEnrichment(); e != lazyEnrichmentMountNamespace {
+		t.Errorf("Expected mount namespace enrichment, got %#x", e)
+	}
+	ssm.subscribe(s1)
+	ssm.subscribe(s1)
+	expected := lazyEnrichmentStatus | lazyEnrichmentMountNamespace
+	if e := ssm.lazyEnrichment(); e != expected {
+		t.Errorf("Expected status and mount namespace enrichment, got %#x", e)
+	}
+	ssm.unsubscribe(s1, nil)
+	if e := ssm.lazyEnrichment(); e != lazyEnrichmentMountNamespace {
+		t.Errorf("Expected mount namespace enrichment, got %#x", e)
+	}
+}
+
+func TestAddEventSinkEnrichmentFilter(t *testing.T) {
+	s := newSubscription(nil, 1, nil)
+	filter := expression.LogicalAnd(
+		expression.Equal(
+			expression.Identifier("id"),
+			expression.Value(int64(59))),
+		expression.Equal(
+			expression.Identifier("mnt_ns"),
+			expression.Value(uint64(4026531840))))
+
+	es, err := s.addEventSink(1, filter, syscallExitEventTypes)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if es.filter == nil {
+		t.Fatal("Expected filter to be evaluated in userspace")
+	}
+	if _, ok := es.filterTypes["mnt_ns"]; !ok {
+		t.Error("Expected filter types to include mnt_ns")
+	}
+	if _, ok := syscallExitEventTypes["mnt_ns"]; ok {
+		t.Error("Event type map was modified")
+	}
+
+	values := expression.FieldValueMap{
+		"id":     int64(59),
+		"ret":    int64(0),
+		"mnt_ns": uint64(4026531840),
+	}
+	v, err := es.filter.Evaluate(es.filterTypes, values)
+	if err != nil {
+		t.Fatalf("Unexpected evaluation error: %v", err)
+	}
+	if !expression.IsValueTrue(v) {
+		t.Error("Expected filter to match")
+	}
+}
+
+func TestAddDerivedEventSinkFilter(t *testing.T) {
+	s := newSubscription(nil, 1, nil)
+	filter := expression.LogicalAnd(
+		expression.Equal(
+			expression.Identifier("id"),
+			expression.Value(int64(0))),
+		expression.GreaterThan(
+			expression.Identifier("bytes"),
+			expression.Value(uint64(4096))))
+
+	es, err := s.addDerivedEventSink(1, filter, syscallExitEventTypes,
+		syscallExitDerivedEventTypes)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if es.filter == nil {
+		t.Fatal("Expected filter to be evaluated in userspace")
+	}
+
+	for bytes, match := range map[uint64]bool{512: false, 8192: true} {
+		values := expression.FieldValueMap{
+			"id":    int64(0),
+			"ret":   int64(bytes),
+			"bytes": bytes,
+		}
+		v, err := es.filter.Evaluate(es.filterTypes, values)
+		if err != nil {
+			t.Fatalf("Unexpected evaluation error: %v", err)
+		}
+		if expression.IsValueTrue(v) != match {
+			t.Errorf("Expected match %v for bytes %d", match, bytes)
+		}
+	}
+
+	if _, err = s.addEventSink(2, filter, syscallExitEventTypes); err == nil {
+		t.Error("Expected error for filter on underived field")
+	}
+}
+
+func TestAddEventSinkEnvironmentFilter(t *testing.T) {
+	saveEnvironmentVariables := config.Sensor.EnvironmentVariables
+	defer func() {
+		config.Sensor.EnvironmentVariables = saveEnvironmentVariables
+	}()
+	config.Sensor.EnvironmentVariables = []string{"LD_PRELOAD"}
+
+	s := newSubscription(nil, 1, nil)
+	filter := expression.LogicalAnd(
+		expression.Equal(
+			expression.Identifier("id"),
+			expression.Value(int64(59))),
+		expression.IsNotNull(expression.Identifier("env.LD_PRELOAD")))
+
+	es, err := s.addEventSink(1, filter, syscallExitEventTypes)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if es.filter == nil {
+		t.Fatal("Expected filter to be evaluated in userspace")
+	}
+
+	values := expression.FieldValueMap{
+		"id":  int64(59),
+		"ret": int64(0),
+	}
+	for _, preload := range []string{"", "/tmp/evil.so"} {
+		if preload != "" {
+			values["env.LD_PRELOAD"] = preload
+		}
+		v, err := es.filter.Evaluate(es.filterTypes, values)
+		if err != nil {
+			t.Fatalf("Unexpected evaluation error: %v", err)
+		}
+		if expression.IsValueTrue(v) != (preload != "") {
+			t.Errorf("Unexpected match for LD_PRELOAD=%q", preload)
+		}
+	}
+
+	filter = expression.IsNotNull(expression.Identifier("env.HOME"))
+	if _, err = s.addEventSink(2, filter, syscallExitEventTypes); err == nil {
+		t.Error("Expected error for filter on unconfigured variable")
+	}
+}
+
+func TestAddEventSinkFilterLoweringPolicy(t *testing.T) {
+	defer func(policy string) {
+		config.Sensor.FilterLoweringPolicy = policy
+	}(config.Sensor.FilterLoweringPolicy)
+
+	// Valid, but the kernel can only compare fields with values
+	filter := expression.Equal(
+		expression.Identifier("id"),
+		expression.Identifier("ret"))
+
+	config.Sensor.FilterLoweringPolicy = filterLoweringLenient
+	s := newSubscription(nil, 1, nil)
+	es, err := s.addEventSink(1, filter, syscallExitEventTypes)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if es.filter == nil || !es.filterFallback {
+		t.Error("Expected filter to fall back to userspace evaluation")
+	}
+	status := s.takeStatus()
+	if len(status) != 1 || status[0].Code != int32(code.Code_UNIMPLEMENTED) {
+		t.Errorf("Expected a fallback status, got %v", status)
+	}
+
+	config.Sensor.FilterLoweringPolicy = filterLoweringStrict
+	s = newSubscription(nil, 1, nil)
+	if _, err = s.addEventSink(1, filter, syscallExitEventTypes); err == nil {
+		t.Error("Expected error for filter that cannot be lowered")
+	}
+	if len(s.eventSinks) != 0 {
+		t.Error("Expected event sink to be rejected")
+	}
+}
+
+func TestAddEventSinkUnsupportedFieldPolicy(t *testing.T) {
+	filter := expression.LogicalAnd(
+		expression.LogicalAnd(
+			expression.Equal(
+				expression.Identifier("id"),
+				expression.Value(int64(59))),
+			expression.Equal(
+				expression.Identifier("mnt_ns"),
+				expression.Value(uint64(4026531840)))),
+		expression.LogicalOr(
+			expression.Equal(
+				expression.Identifier("ret"),
+				expression.Value(int64(0))),
+			expression.Equal(
+				expression.Identifier("cgroup_inode"),
+				expression.Value(uint64(5)))))
+
+	s := newSubscription(nil, 1, nil)
+	if _, err := s.addEventSink(1, filter, syscallExitEventTypes); err == nil {
+		t.Error("Expected error for filter on unsupported field")
+	}
+	if len(s.eventSinks) != 0 {
+		t.Error("Expected event sink to be rejected")
+	}
+
+	s = newSubscription(nil, 1, nil)
+	s.unsupportedFieldPolicy = api.UnsupportedFieldPolicy_UNSUPPORTED_FIELD_POLICY_LENIENT
+	es, err := s.addEventSink(1, filter, syscallExitEventTypes)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if es.filter == nil {
+		t.Fatal("Expected remaining filter to be evaluated in userspace")
+	}
+	status := s.takeStatus()
+	expected := "Dropped filter predicates that refer to unsupported " +
+		"fields (cgroup_inode): ret = 0 OR cgroup_inode = 5"
+	if len(status) != 1 ||
+		status[0].Code != int32(code.Code_FAILED_PRECONDITION) ||
+		status[0].Message != expected {
+		t.Errorf("Expected status %q, got %v", expected, status)
+	}
+
+	// The dropped predicate no longer restricts the events.
+	values := expression.FieldValueMap{
+		"id":     int64(59),
+		"ret":    int64(-1),
+		"mnt_ns": uint64(4026531840),
+	}
+	v, err := es.filter.Evaluate(es.filterTypes, values)
+	if err != nil {
+		t.Fatalf("Unexpected evaluation error: %v", err)
+	}
+	if !expression.IsValueTrue(v) {
+		t.Error("Expected filter to match")
+	}
+
+	// Nothing remains of a filter made only of unsupported predicates.
+	filter = expression.IsNotNull(expression.Identifier("cgroup_inode"))
+	es, err = s.addEventSink(2, filter, syscallExitEventTypes)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if es.filter != nil || es.kernelFilter != nil {
+		t.Error("Expected no filter to remain")
+	}
+}
+
+func TestLogStatusCoalescing(t *testing.T) {
+	s := newSubscription(nil, 1, nil)
+	for i := 0; i < 5; i++ {
+		s.logStatus(code.Code_INVALID_ARGUMENT,
+			"Wildcard syscall filter ignored")
+	}
+	s.logStatus(code.Code_UNKNOWN, "Could not register kprobe")
+	s.logStatus(code.Code_UNKNOWN, "Wildcard syscall filter ignored")
+
+	status := s.takeStatus()
+	expected := []string{
+		"Wildcard syscall filter ignored (repeated 5 times)",
+		"Could not register kprobe",
+		"Wildcard syscall filter ignored",
+	}
+	if len(status) != len(expected) {
+		t.Fatalf("Expected %d status messages, got %d",
+			len(expected), len(status))
+	}
+	for i, m := range expected {
+		if status[i].Message != m {
+			t.Errorf("Expected status %q, got %q", m, status[i].Message)
+		}
+	}
+
+	if status = s.takeStatus(); len(status) != 0 {
+		t.Errorf("Expected no status messages, got %d", len(status))
+	}
+}
+
+func TestSetSampleFields(t *testing.T) {
+	s := newSubscription(nil, 1, nil)
+	s.setSampleFields(nil)
+	if s.eventAttr != nil {
+		t.Errorf("Expected default EventAttr, got %+v", s.eventAttr)
+	}
+
+	s.setSampleFields([]api.SampleField{
+		api.SampleField_SAMPLE_FIELD_TID,
+		api.SampleField_SAMPLE_FIELD_CALLCHAIN,
+		api.SampleField(99),
+	})
+	expected := perf.PERF_SAMPLE_RAW | perf.PERF_SAMPLE_TID |
+		perf.PERF_SAMPLE_CALLCHAIN
+	if s.eventAttr == nil || s.eventAttr.SampleType != expected {
+		t.Errorf("Expected sample type %#x, got %+v", expected, s.eventAttr)
+	}
+	if status := s.takeStatus(); len(status) != 1 {
+		t.Errorf("Expected 1 status for invalid field, got %v", status)
+	}
+
+	// The data source is only requested for hardware performance events
+	s.setSampleFields([]api.SampleField{
+		api.SampleField_SAMPLE_FIELD_DATA_SRC,
+	})
+	if s.eventAttr != nil || !s.sampleDataSrc {
+		t.Errorf("Expected default EventAttr with data source, got %+v",
+			s.eventAttr)
+	}
+	s.setSampleFields([]api.SampleField{
+		api.SampleField_SAMPLE_FIELD_CPU,
+	})
+	if s.sampleDataSrc {
+		t.Error("Expected data source to be cleared")
+	}
+}
+
+func TestNumSubscriptionPriorities(t *testing.T) {
+	for v, name := range api.SubscriptionPriority_name {
+		if v < 0 || v >= int32(numSubscriptionPriorities) {
+			t.Errorf("%s is not counted by DroppedEvents", name)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/golang/protobuf/proto"
+
+	"google.golang.org/genproto/googleapis/rpc/code"
+)
+
+// The maximum rate at which a SyntheticEventFilter may generate events
+const maxSyntheticEventsPerSecond = 100000
+
+var syntheticEventTypes = expression.FieldTypeMap{
+	"index": expression.ValueTypeUnsignedInt64,
+}
+
+type syntheticFilter struct {
+	sensor *Sensor
+}
+
+func (f *syntheticFilter) decodeSyntheticEvent(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	e := proto.Clone(data["template"].(*api.TelemetryEvent)).(*api.TelemetryEvent)
+	base := f.sensor.newEvent()
+	e.Id = base.Id
+	e.SensorId = base.SensorId
+	e.SensorMonotimeNanos = base.SensorMonotimeNanos
+	e.SensorSequenceNumber = base.SensorSequenceNumber
+	e.Synthetic = true
+
+	atomic.AddUint64(&f.sensor.Metrics.SyntheticEvents, 1)
+	return e, nil
+}
+
+// syntheticEventInterval validates a SyntheticEventFilter and returns the
+// interval between the events that it generates.
+func syntheticEventInterval(e *api.SyntheticEventFilter) (time.Duration, error) {
+	if e.Template == nil || e.Template.Event == nil {
+		return 0, fmt.Errorf("Synthetic event template has no payload")
+	}
+	if !(e.EventsPerSecond > 0 &&
+		e.EventsPerSecond <= maxSyntheticEventsPerSecond) {
+		return 0, fmt.Errorf("Synthetic event rate out of range (%v)",
+			e.EventsPerSecond)
+	}
+	return time.Duration(float64(time.Second) / e.EventsPerSecond), nil
+}
+
+func registerSyntheticEvents(
+	sensor *Sensor,
+	subscr *subscription,
+	events []*api.SyntheticEventFilter,
+) {
+	if len(events) == 0 {
+		return
+	}
+
+	f := syntheticFilter{sensor: sensor}
+	eventID, err := sensor.Monitor.RegisterExternalEvent("synthetic",
+		f.decodeSyntheticEvent)
+	if err != nil {
+		subscr.logStatus(
+			code.Code_UNKNOWN,
+			fmt.Sprintf("Could not register synthetic event: %v", err))
+		return
+	}
+
+	done := make(chan struct{})
+	ngenerators := 0
+	for _, e := range events {
+		interval, err := syntheticEventInterval(e)
+		if err != nil {
+			subscr.logStatus(code.Code_INVALID_ARGUMENT, err.Error())
+			continue
+		}
+		ngenerators++
+
+		go func(template *api.TelemetryEvent, count uint64) {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for index := uint64(0); count == 0 || index < count; index++ {
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+					sampleID := perf.SampleID{
+						Time: uint64(sys.CurrentMonotonicRaw()),
+					}
+					data := perf.TraceEventSampleData{
+						"index":    index,
+						"template": template,
+					}
+					sensor.Monitor.EnqueueExternalSample(
+						eventID, sampleID, data)
+				}
+			}
+		}(e.Template, e.Count)
+	}
+	if ngenerators == 0 {
+		sensor.Monitor.UnregisterEvent(eventID)
+	} else {
+		es, _ := subscr.addEventSink(eventID, nil, syntheticEventTypes)
+		es.unregister = func(es *eventSink) {
+			sensor.Monitor.UnregisterEvent(es.eventID)
+			close(done)
+		}
+	}
+}
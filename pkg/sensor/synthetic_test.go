@@ -0,0 +1,86 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func TestSyntheticEventInterval(t *testing.T) {
+	template := &api.TelemetryEvent{
+		Event: &api.TelemetryEvent_Ticker{Ticker: &api.TickerEvent{}},
+	}
+	interval, err := syntheticEventInterval(&api.SyntheticEventFilter{
+		Template:        template,
+		EventsPerSecond: 4,
+	})
+	if err != nil || interval != 250*time.Millisecond {
+		t.Errorf("Unexpected interval %v, %v", interval, err)
+	}
+
+	invalid := []*api.SyntheticEventFilter{
+		{EventsPerSecond: 1},
+		{Template: &api.TelemetryEvent{}, EventsPerSecond: 1},
+		{Template: template},
+		{Template: template, EventsPerSecond: -1},
+		{Template: template, EventsPerSecond: maxSyntheticEventsPerSecond + 1},
+	}
+	for _, e := range invalid {
+		if _, err = syntheticEventInterval(e); err == nil {
+			t.Errorf("Expected error for %v", e)
+		}
+	}
+}
+
+func TestDecodeSyntheticEvent(t *testing.T) {
+	sensor := &Sensor{ID: "sensor"}
+	f := syntheticFilter{sensor: sensor}
+	template := &api.TelemetryEvent{
+		Id:          "template",
+		ProcessTgid: 42,
+		ContainerId: "c1",
+		Event: &api.TelemetryEvent_Syscall{
+			Syscall: &api.SyscallEvent{Id: 59},
+		},
+	}
+
+	i, err := f.decodeSyntheticEvent(nil, perf.TraceEventSampleData{
+		"index":    uint64(0),
+		"template": template,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := i.(*api.TelemetryEvent)
+	if !e.Synthetic || e.Id == "template" || e.SensorId != "sensor" ||
+		e.SensorSequenceNumber != 1 || e.ProcessTgid != 42 ||
+		e.ContainerId != "c1" || e.GetSyscall().GetId() != 59 {
+		t.Errorf("Unexpected synthetic event %v", e)
+	}
+	if e.GetSyscall() == template.GetSyscall() || template.Synthetic {
+		t.Error("Expected the template to be copied")
+	}
+	if sensor.Metrics.Events != 0 || sensor.Metrics.SyntheticEvents != 1 {
+		t.Errorf("Unexpected metrics %+v", sensor.Metrics)
+	}
+	if telemetryEventType(e) != "synthetic" {
+		t.Errorf("Unexpected event type %q", telemetryEventType(e))
+	}
+}
@@ -16,14 +16,21 @@ package sensor
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"sync/atomic"
+	"time"
 
 	api "github.com/capsule8/capsule8/api/v0"
 
+	"github.com/capsule8/capsule8/pkg/config"
 	"github.com/capsule8/capsule8/pkg/expression"
 	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/btf"
 	"github.com/capsule8/capsule8/pkg/sys/perf"
 
+	"github.com/golang/glog"
+
 	"google.golang.org/genproto/googleapis/rpc/code"
 )
 
@@ -37,56 +44,277 @@ var syscallEnterEventTypes = expression.FieldTypeMap{
 	"arg5": expression.ValueTypeUnsignedInt64,
 }
 
+// syscallEnterDerivedEventTypes are the types of fields that
+// decodeSyscallTraceEnter derives from the raw syscall enter data.
+var syscallEnterDerivedEventTypes = expression.FieldTypeMap{
+	"ptrace_request":    expression.ValueTypeString,
+	"ptrace_target_pid": expression.ValueTypeSignedInt32,
+
+	"prot":                    expression.ValueTypeString,
+	"writable_and_executable": expression.ValueTypeBool,
+	"mmap_flags":              expression.ValueTypeString,
+
+	"path":              expression.ValueTypeString,
+	"new_path":          expression.ValueTypeString,
+	"path_relative":     expression.ValueTypeBool,
+	"new_path_relative": expression.ValueTypeBool,
+
+	"bpf_command":           expression.ValueTypeString,
+	"perf_event_type":       expression.ValueTypeString,
+	"perf_event_config":     expression.ValueTypeUnsignedInt64,
+	"perf_event_target_pid": expression.ValueTypeSignedInt32,
+	"perf_event_cpu":        expression.ValueTypeSignedInt32,
+
+	"memfd_name": expression.ValueTypeString,
+
+	"namespace_types": expression.ValueTypeString,
+
+	"keyctl_operation": expression.ValueTypeString,
+	"key_type":         expression.ValueTypeString,
+	"key_description":  expression.ValueTypeString,
+}
+
 var syscallExitEventTypes = expression.FieldTypeMap{
 	"id":  expression.ValueTypeSignedInt64,
 	"ret": expression.ValueTypeSignedInt64,
 }
 
+// syscallExitDerivedEventTypes are the types of fields that decodeSysExit
+// derives from the raw syscall exit data.
+var syscallExitDerivedEventTypes = expression.FieldTypeMap{
+	"bytes": expression.ValueTypeUnsignedInt64,
+
+	"namespace_types": expression.ValueTypeString,
+}
+
+// The machine architecture whose system call numbers are used by the sensor
+// to recognize particular system calls, such as those in syscallBytesIDs and
+// syscallPtraceID. On other architectures, system call events report only
+// their raw fields; see Sensor.syscallTablesSupported.
+const syscallTableArch = "x86_64"
+
+// syscallTablesSupported returns whether the system call numbers of the
+// running kernel are those that the sensor uses to recognize particular
+// system calls, so that the fields derived from them may be reported.
+func (s *Sensor) syscallTablesSupported() bool {
+	return s.machineArch == syscallTableArch
+}
+
+// syscallDerivedFieldsReferenced returns whether an expression refers to any
+// of the fields derived from particular system calls.
+func syscallDerivedFieldsReferenced(expr *api.Expression) (referenced bool) {
+	walkExpressionIdentifiers(expr, func(ident string) {
+		if _, ok := syscallEnterDerivedEventTypes[ident]; ok {
+			referenced = true
+		} else if _, ok = syscallExitDerivedEventTypes[ident]; ok {
+			referenced = true
+		} else if _, ok = syscallCloneLinkageDerivedEventTypes[ident]; ok {
+			referenced = true
+		}
+	})
+	return
+}
+
+// syscallBytesIDs is the set of x86_64 system call numbers that return the
+// number of bytes transferred when they succeed.
+var syscallBytesIDs = map[int64]bool{
+	0:   true, // read
+	1:   true, // write
+	17:  true, // pread64
+	18:  true, // pwrite64
+	19:  true, // readv
+	20:  true, // writev
+	40:  true, // sendfile
+	44:  true, // sendto
+	45:  true, // recvfrom
+	46:  true, // sendmsg
+	47:  true, // recvmsg
+	275: true, // splice
+	276: true, // tee
+	278: true, // vmsplice
+	295: true, // preadv
+	296: true, // pwritev
+	326: true, // copy_file_range
+	327: true, // preadv2
+	328: true, // pwritev2
+}
+
+// syscallSetIDIDs is the set of x86_64 system call numbers that set the user
+// or group IDs of the calling task. They return 0 when they succeed.
+var syscallSetIDIDs = map[int64]bool{
+	105: true, // setuid
+	106: true, // setgid
+	113: true, // setreuid
+	114: true, // setregid
+	117: true, // setresuid
+	119: true, // setresgid
+}
+
+// syscallCloneIDs is the set of x86_64 system call numbers that create a new
+// task. They return the pid of the new task in the calling task and 0 in the
+// new task.
+var syscallCloneIDs = map[int64]bool{
+	56:  true, // clone
+	57:  true, // fork
+	58:  true, // vfork
+	435: true, // clone3
+}
+
 type syscallFilter struct {
 	sensor *Sensor
 }
 
+// syscallArgStatus returns the capture status of each of the arguments of a
+// system call enter event. The arguments are read from the registers saved on
+// entry to the kernel, which cannot fault, so they are either captured or not.
+func syscallArgStatus(data perf.TraceEventSampleData) []api.SyscallArgStatus {
+	status := make([]api.SyscallArgStatus, 6)
+	for i := range status {
+		if _, ok := data["arg"+strconv.Itoa(i)].(uint64); ok {
+			status[i] = api.SyscallArgStatus_SYSCALL_ARG_STATUS_CAPTURED
+		}
+	}
+	return status
+}
+
 func (f *syscallFilter) decodeDummySysEnter(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
 	return nil, nil
 }
 
 func (f *syscallFilter) decodeSyscallTraceEnter(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	start := time.Now()
 	ev := f.sensor.NewEventFromSample(sample, data)
 	if ev == nil {
 		return nil, nil
 	}
+	syscall := &api.SyscallEvent{
+		Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER,
+		Id:   data["id"].(int64),
+	}
+
+	// Sensitive arguments are limited before anything is derived from
+	// them or they are seen by userspace filters.
+	if p := f.sensor.syscallArgPolicy; p != nil {
+		syscall.PolicyArgMask = uint32(p.apply(syscall.Id, data))
+	}
+
+	// Arguments that were not requested by the subscription are not
+	// present in the sample data and are left as zero.
+	syscall.Arg0, _ = data["arg0"].(uint64)
+	syscall.Arg1, _ = data["arg1"].(uint64)
+	syscall.Arg2, _ = data["arg2"].(uint64)
+	syscall.Arg3, _ = data["arg3"].(uint64)
+	syscall.Arg4, _ = data["arg4"].(uint64)
+	syscall.Arg5, _ = data["arg5"].(uint64)
+	syscall.ArgStatus = syscallArgStatus(data)
+	if f.sensor.syscallTablesSupported() {
+		f.setSyscallEnterFields(ev, syscall, data)
+	}
 	ev.Event = &api.TelemetryEvent_Syscall{
-		Syscall: &api.SyscallEvent{
-			Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER,
-			Id:   data["id"].(int64),
-			Arg0: data["arg0"].(uint64),
-			Arg1: data["arg1"].(uint64),
-			Arg2: data["arg2"].(uint64),
-			Arg3: data["arg3"].(uint64),
-			Arg4: data["arg4"].(uint64),
-			Arg5: data["arg5"].(uint64),
-		},
+		Syscall: syscall,
 	}
+	f.sensor.syscallCosts.addDecode(syscall.Id, time.Since(start))
 
 	return ev, nil
 }
 
+// setSyscallEnterFields sets the fields of a system call enter event that are
+// specific to the system call that was made.
+func (f *syscallFilter) setSyscallEnterFields(
+	ev *api.TelemetryEvent,
+	syscall *api.SyscallEvent,
+	data perf.TraceEventSampleData,
+) {
+	switch syscall.Id {
+	case syscallPtraceID:
+		f.setPtraceTarget(ev, syscall, data)
+	case syscallMmapID, syscallMprotectID:
+		setMemoryProtection(syscall, data)
+	case syscallBPFID:
+		setBPFCommand(syscall, data)
+		f.setSyscallCaller(ev, syscall)
+	case syscallPerfEventOpenID:
+		f.setPerfEventOpen(ev, syscall, data)
+		f.setSyscallCaller(ev, syscall)
+	case syscallMemfdCreateID:
+		setMemfdName(syscall, data)
+	case syscallUnshareID, syscallSetnsID:
+		f.setNamespaceTypes(ev, syscall, data)
+	case syscallKeyctlID:
+		setKeyctlOperation(syscall, data)
+	case syscallAddKeyID, syscallRequestKeyID:
+		f.setKeyStrings(syscall, data)
+	}
+	if _, ok := syscallPathIDs[syscall.Id]; ok {
+		f.setSyscallPaths(ev, syscall, data)
+	}
+}
+
+// setCredentialsTransition records the credentials of the task that made a
+// set*id system call before and after the call. By the time the exit event is
+// decoded, the process cache has already seen the call's commit_creds(), so
+// the credentials before the call are the ones that it replaced. If the call
+// failed, no credentials were committed.
+func (f *syscallFilter) setCredentialsTransition(
+	ev *api.TelemetryEvent,
+	syscall *api.SyscallEvent,
+) {
+	if ev.Credentials == nil {
+		return
+	}
+	syscall.CredentialsAfter = ev.Credentials
+	syscall.CredentialsBefore = ev.Credentials
+	if syscall.Ret != 0 {
+		return
+	}
+
+	t := f.sensor.ProcessCache.LookupTask(int(ev.ProcessPid))
+	if c := t.PreviousCreds; c != nil {
+		syscall.CredentialsBefore = c.toAPI()
+	}
+}
+
 func (f *syscallFilter) decodeSysExit(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	start := time.Now()
 	ev := f.sensor.NewEventFromSample(sample, data)
 	if ev == nil {
 		return nil, nil
 	}
+	syscall := &api.SyscallEvent{
+		Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT,
+		Id:   data["id"].(int64),
+		Ret:  data["ret"].(int64),
+	}
+	if f.sensor.syscallTablesSupported() {
+		f.setSyscallExitFields(ev, syscall, data)
+	}
 	ev.Event = &api.TelemetryEvent_Syscall{
-		Syscall: &api.SyscallEvent{
-			Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT,
-			Id:   data["id"].(int64),
-			Ret:  data["ret"].(int64),
-		},
+		Syscall: syscall,
 	}
+	f.sensor.syscallCosts.addDecode(syscall.Id, time.Since(start))
 
 	return ev, nil
 }
 
+// setSyscallExitFields sets the fields of a system call exit event that are
+// specific to the system call that was made.
+func (f *syscallFilter) setSyscallExitFields(
+	ev *api.TelemetryEvent,
+	syscall *api.SyscallEvent,
+	data perf.TraceEventSampleData,
+) {
+	if syscallBytesIDs[syscall.Id] && syscall.Ret >= 0 {
+		syscall.Bytes = uint64(syscall.Ret)
+		data["bytes"] = syscall.Bytes
+	}
+	if syscallSetIDIDs[syscall.Id] {
+		f.setCredentialsTransition(ev, syscall)
+	}
+	if syscall.Id == syscallUnshareID || syscall.Id == syscallSetnsID {
+		f.setNamespaceTransitions(ev, syscall, data)
+	}
+}
+
 func containsIDFilter(expr *api.Expression) bool {
 	if expr == nil {
 		return false
@@ -114,107 +342,522 @@ func containsIDFilter(expr *api.Expression) bool {
 	return false
 }
 
-func rewriteSyscallEventFilter(sef *api.SyscallEventFilter) {
-	if sef.Id != nil {
+// deprecatedSyscallField is a deprecated field of a SyscallEventFilter that
+// was translated into an expression.
+type deprecatedSyscallField struct {
+	name string
+	expr *api.Expression
+}
+
+// rewriteSyscallEventFilter translates the deprecated fields of a syscall
+// event filter into its filter expression, returning the fields that were
+// set.
+func rewriteSyscallEventFilter(
+	sef *api.SyscallEventFilter,
+) []deprecatedSyscallField {
+	var deprecated []deprecatedSyscallField
+	rewrite := func(name, identifier string, value interface{}) {
 		newExpr := expression.Equal(
-			expression.Identifier("id"),
-			expression.Value(sef.Id.Value))
+			expression.Identifier(identifier),
+			expression.Value(value))
 		sef.FilterExpression = expression.LogicalAnd(
 			newExpr, sef.FilterExpression)
+		deprecated = append(deprecated,
+			deprecatedSyscallField{name: name, expr: newExpr})
+	}
+
+	if sef.Id != nil {
+		rewrite("Id", "id", sef.Id.Value)
 		sef.Id = nil
 	}
 
 	if sef.Type == api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER {
 		if sef.Arg0 != nil {
-			newExpr := expression.Equal(
-				expression.Identifier("arg0"),
-				expression.Value(sef.Arg0.Value))
-			sef.FilterExpression = expression.LogicalAnd(
-				newExpr, sef.FilterExpression)
+			rewrite("Arg0", "arg0", sef.Arg0.Value)
 			sef.Arg0 = nil
 		}
 
 		if sef.Arg1 != nil {
-			newExpr := expression.Equal(
-				expression.Identifier("arg1"),
-				expression.Value(sef.Arg1.Value))
-			sef.FilterExpression = expression.LogicalAnd(
-				newExpr, sef.FilterExpression)
+			rewrite("Arg1", "arg1", sef.Arg1.Value)
 			sef.Arg1 = nil
 		}
 
 		if sef.Arg2 != nil {
-			newExpr := expression.Equal(
-				expression.Identifier("arg2"),
-				expression.Value(sef.Arg2.Value))
-			sef.FilterExpression = expression.LogicalAnd(
-				newExpr, sef.FilterExpression)
+			rewrite("Arg2", "arg2", sef.Arg2.Value)
 			sef.Arg2 = nil
 		}
 
 		if sef.Arg3 != nil {
-			newExpr := expression.Equal(
-				expression.Identifier("arg3"),
-				expression.Value(sef.Arg3.Value))
-			sef.FilterExpression = expression.LogicalAnd(
-				newExpr, sef.FilterExpression)
+			rewrite("Arg3", "arg3", sef.Arg3.Value)
 			sef.Arg3 = nil
 		}
 
 		if sef.Arg4 != nil {
-			newExpr := expression.Equal(
-				expression.Identifier("arg4"),
-				expression.Value(sef.Arg4.Value))
-			sef.FilterExpression = expression.LogicalAnd(
-				newExpr, sef.FilterExpression)
+			rewrite("Arg4", "arg4", sef.Arg4.Value)
 			sef.Arg4 = nil
 		}
 
 		if sef.Arg5 != nil {
-			newExpr := expression.Equal(
-				expression.Identifier("arg5"),
-				expression.Value(sef.Arg5.Value))
-			sef.FilterExpression = expression.LogicalAnd(
-				newExpr, sef.FilterExpression)
+			rewrite("Arg5", "arg5", sef.Arg5.Value)
 			sef.Arg5 = nil
 		}
 	} else if sef.Type == api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT {
 		if sef.Ret != nil {
-			newExpr := expression.Equal(
-				expression.Identifier("ret"),
-				expression.Value(sef.Ret.Value))
-			sef.FilterExpression = expression.LogicalAnd(
-				newExpr, sef.FilterExpression)
+			rewrite("Ret", "ret", sef.Ret.Value)
 			sef.Ret = nil
 		}
 	}
+
+	return deprecated
+}
+
+// warnDeprecatedSyscallFields reports a status for each deprecated field of
+// a syscall event filter, naming the equivalent filter expression.
+func warnDeprecatedSyscallFields(
+	subscr *subscription,
+	deprecated []deprecatedSyscallField,
+) {
+	for _, d := range deprecated {
+		var form string
+		if expr, err := expression.NewExpression(d.expr); err == nil {
+			form = expr.String()
+		}
+		subscr.logStatus(
+			code.Code_OK,
+			fmt.Sprintf("SyscallEventFilter field %s is deprecated; use the filter expression %s instead",
+				d.name, form))
+	}
 }
 
 const (
 	syscallNewEnterKprobeAddress string = "syscall_trace_enter_phase1"
 	syscallOldEnterKprobeAddress string = "syscall_trace_enter"
 
-	// These offsets index into the x86_64 version of struct pt_regs
-	// in the kernel. This is a stable structure.
-	syscallEnterKprobeFetchargs string = "id=+120(%di):s64 " + // orig_ax
-		"arg0=+112(%di):u64 " + // di
-		"arg1=+104(%di):u64 " + // si
-		"arg2=+96(%di):u64 " + // dx
-		"arg3=+56(%di):u64 " + // r10
-		"arg4=+72(%di):u64 " + // r8
-		"arg5=+64(%di):u64" // r9
+	// syscallArgMaskAll selects all six system call arguments.
+	syscallArgMaskAll uint8 = 0x3f
 )
 
+// syscallEnterLayout describes where the system call number and arguments
+// are found in struct pt_regs, a pointer to which is passed to the syscall
+// enter kprobe in a register.
+type syscallEnterLayout struct {
+	// The register holding the struct pt_regs pointer
+	register string
+
+	// Byte offsets into struct pt_regs
+	id   uint32
+	args [6]uint32
+
+	// The names of the struct pt_regs members at the offsets, used to
+	// derive the offsets from kernel BTF type information. Empty if the
+	// offsets cannot be derived.
+	idMember   string
+	argMembers [6]string
+
+	// true if the offsets were derived from kernel BTF type information
+	fromBTF bool
+}
+
+// These offsets index into the x86_64 version of struct pt_regs in the
+// kernel. This is a stable structure.
+var syscallEnterLayoutX86_64 = syscallEnterLayout{
+	register: "%di",
+	id:       120, // orig_ax
+	args: [6]uint32{
+		112, // di
+		104, // si
+		96,  // dx
+		56,  // r10
+		72,  // r8
+		64,  // r9
+	},
+	idMember: "orig_ax",
+	argMembers: [6]string{
+		"di", "si", "dx", "r10", "r8", "r9",
+	},
+}
+
+// These offsets index into the arm64 version of struct pt_regs in the kernel,
+// which begins with the general purpose registers x0-x30. The system call
+// number is taken from x8, where userspace passes it, rather than syscallno,
+// which is only 32 bits wide on newer kernels. The registers are an array in
+// an anonymous union, so their offsets are not derived from BTF.
+var syscallEnterLayoutArm64 = syscallEnterLayout{
+	register: "%x0",
+	id:       64, // regs[8]
+	args: [6]uint32{
+		0,  // regs[0]
+		8,  // regs[1]
+		16, // regs[2]
+		24, // regs[3]
+		32, // regs[4]
+		40, // regs[5]
+	},
+}
+
+// newSyscallEnterLayoutFromBTF derives the struct pt_regs offsets of the
+// members named by a built-in layout from BTF type information. The return
+// is nil if the layout does not name its members or any of them cannot be
+// found.
+func newSyscallEnterLayoutFromBTF(
+	builtin *syscallEnterLayout,
+	spec *btf.Spec,
+) *syscallEnterLayout {
+	if builtin.idMember == "" {
+		return nil
+	}
+	layout := *builtin
+	layout.fromBTF = true
+
+	var ok bool
+	layout.id, ok = spec.StructMemberOffset("pt_regs", layout.idMember)
+	if !ok {
+		return nil
+	}
+	for i, member := range layout.argMembers {
+		layout.args[i], ok = spec.StructMemberOffset("pt_regs", member)
+		if !ok {
+			return nil
+		}
+	}
+	return &layout
+}
+
+// builtinSyscallEnterLayout returns the built-in struct pt_regs layout for the
+// specified machine architecture as reported by uname(2), or nil if the
+// architecture is not supported.
+func builtinSyscallEnterLayout(arch string) *syscallEnterLayout {
+	switch arch {
+	case "x86_64":
+		return &syscallEnterLayoutX86_64
+	case "aarch64", "arm64":
+		return &syscallEnterLayoutArm64
+	}
+	return nil
+}
+
+// newSyscallEnterLayout returns the struct pt_regs layout to use for the
+// syscall enter kprobe on the specified machine architecture, or nil if the
+// architecture is not supported. For architectures whose built-in layout
+// names its members, offsets are derived from the running kernel's BTF type
+// information when it is available (spec is not nil); otherwise, the
+// built-in offsets are used.
+func newSyscallEnterLayout(arch string, spec *btf.Spec) *syscallEnterLayout {
+	builtin := builtinSyscallEnterLayout(arch)
+	if builtin == nil || builtin.idMember == "" || spec == nil {
+		return builtin
+	}
+
+	if layout := newSyscallEnterLayoutFromBTF(builtin, spec); layout != nil {
+		glog.V(1).Info("Using pt_regs offsets derived from kernel BTF")
+		return layout
+	}
+	glog.V(1).Info("Kernel BTF does not describe pt_regs, using built-in offsets")
+	return builtin
+}
+
+// fetchargs returns the fetchargs string for the syscall enter kprobe that
+// captures the system call number and only those arguments selected by
+// argMask.
+func (l *syscallEnterLayout) fetchargs(argMask uint8) string {
+	fetchargs := []string{
+		fmt.Sprintf("id=+%d(%s):s64", l.id, l.register),
+	}
+	for i, offset := range l.args {
+		if argMask&(1<<uint(i)) != 0 {
+			fetchargs = append(fetchargs,
+				fmt.Sprintf("arg%d=+%d(%s):u64", i, offset, l.register))
+		}
+	}
+	return strings.Join(fetchargs, " ")
+}
+
+// syscallArgMaskFromExpression returns a mask of the system call arguments
+// referenced by an expression, including those that derived fields are
+// decoded from.
+func syscallArgMaskFromExpression(expr *api.Expression) (mask uint8) {
+	walkExpressionIdentifiers(expr, func(ident string) {
+		if len(ident) == 4 && strings.HasPrefix(ident, "arg") &&
+			ident[3] >= '0' && ident[3] <= '5' {
+			mask |= 1 << (ident[3] - '0')
+		} else if ident == "ptrace_request" {
+			mask |= 1 << 0
+		} else if ident == "ptrace_target_pid" {
+			mask |= 1<<0 | 1<<1
+		} else if ident == "prot" || ident == "writable_and_executable" {
+			mask |= 1 << 2
+		} else if ident == "mmap_flags" {
+			mask |= 1 << 3
+		} else if ident == "bpf_command" ||
+			ident == "perf_event_type" || ident == "perf_event_config" {
+			mask |= 1 << 0
+		} else if ident == "perf_event_target_pid" {
+			mask |= 1 << 1
+		} else if ident == "perf_event_cpu" {
+			mask |= 1 << 2
+		} else if ident == "namespace_types" {
+			// unshare's flags or setns's nstype
+			mask |= 1<<0 | 1<<1
+		} else if ident == "keyctl_operation" {
+			mask |= 1 << 0
+		}
+	})
+	return
+}
+
+// syscallIDFilterFromExpression returns an expression that matches any of the
+// system call numbers compared for equality by the specified expression. As
+// long as containsIDFilter is true for the expression, the resulting filter
+// will match at least everything that the original expression matches.
+func syscallIDFilterFromExpression(expr *api.Expression) *api.Expression {
+	var idFilter *api.Expression
+	for _, id := range syscallIDsFromExpression(expr) {
+		idFilter = expression.LogicalOr(idFilter,
+			expression.Equal(
+				expression.Identifier("id"),
+				expression.Value(id)))
+	}
+	return idFilter
+}
+
+// syscallIDsFromExpression returns the system call numbers compared for
+// equality by the specified expression, in the order that they appear.
+func syscallIDsFromExpression(expr *api.Expression) []int64 {
+	var (
+		ids  []int64
+		seen = make(map[int64]bool)
+	)
+
+	var walk func(*api.Expression)
+	walk = func(expr *api.Expression) {
+		switch expr.GetType() {
+		case api.Expression_LOGICAL_AND, api.Expression_LOGICAL_OR:
+			operands := expr.GetBinaryOp()
+			walk(operands.Lhs)
+			walk(operands.Rhs)
+		case api.Expression_EQ:
+			operands := expr.GetBinaryOp()
+			if operands.Lhs.GetIdentifier() != "id" {
+				break
+			}
+			v := operands.Rhs.GetValue()
+			if v == nil || v.GetType() != api.ValueType_SINT64 {
+				break
+			}
+			id := v.GetSignedValue()
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	walk(expr)
+
+	return ids
+}
+
+func (f *syscallFilter) registerEnterKprobe(
+	subscr *subscription,
+	filter *api.Expression,
+	argMask, pathMask uint8,
+) *eventSink {
+	sensor := f.sensor
+	if sensor.syscallEnterLayout == nil {
+		subscr.logStatus(
+			code.Code_UNIMPLEMENTED,
+			fmt.Sprintf("System call enter events are not supported on %s",
+				sensor.machineArch))
+		return nil
+	}
+
+	// Create the dummy syscall event. This event is needed to put the
+	// kernel into a mode where it'll make the function calls needed to
+	// make the kprobe we'll add fire. Add the tracepoint, but make sure it
+	// never adds events into the ringbuffer by using a filter that will
+	// never evaluate true. It also never gets enabled, but just creating
+	// it is enough.
+	//
+	// For kernels older than 3.x, create this dummy event in all event
+	// groups, because we cannot remove it when we don't need it anymore
+	// due to bugs in CentOS 6.x kernels (2.6.32).
+	var (
+		err     error
+		eventID uint64
+	)
+	eventName := "raw_syscalls/sys_enter"
+	major, _, _ := sys.KernelVersion()
+	if major < 3 {
+		eventID, err = sensor.Monitor.RegisterTracepoint(
+			eventName, f.decodeDummySysEnter,
+			perf.WithEventAttr(subscr.eventAttr),
+			perf.WithEventGroup(subscr.eventGroupID),
+			perf.WithFilter("id == 0x7fffffff"))
+		if err != nil {
+			eventName = "syscalls/sys_enter"
+			eventID, err = sensor.Monitor.RegisterTracepoint(
+				eventName, f.decodeDummySysEnter,
+				perf.WithEventAttr(subscr.eventAttr),
+				perf.WithEventGroup(subscr.eventGroupID),
+				perf.WithFilter("id == 0x7fffffff"))
+		}
+		if err != nil {
+			subscr.logStatus(
+				code.Code_UNKNOWN,
+				fmt.Sprintf("Could not register dummy syscall event %s: %v", eventName, err))
+		}
+	} else if atomic.AddInt64(&sensor.dummySyscallEventCount, 1) == 1 {
+		eventID, err = sensor.Monitor.RegisterTracepoint(
+			eventName, f.decodeDummySysEnter,
+			perf.WithEventGroup(0),
+			perf.WithFilter("id == 0x7fffffff"))
+		if err != nil {
+			subscr.logStatus(
+				code.Code_UNKNOWN,
+				fmt.Sprintf("Could not register dummy syscall event %s: %v", eventName, err))
+			atomic.AddInt64(&sensor.dummySyscallEventCount, -1)
+		} else {
+			sensor.dummySyscallEventID = eventID
+		}
+	}
+
+	// There are two possible kprobes. Newer kernels (>= 4.1) have
+	// refactored syscall entry code, so syscall_trace_enter_phase1 is the
+	// right one, but for older kernels syscall_trace_enter is the right
+	// one. Both have the same signature, so the fetchargs doesn't have to
+	// change. Try the new probe first, because the old probe will also set
+	// in the newer kernels, but it won't fire.
+	fetchargs := sensor.syscallEnterLayout.fetchargs(argMask)
+	if pathMask != 0 {
+		fetchargs += " " +
+			sensor.syscallEnterLayout.pathFetchargs(pathMask)
+	}
+	if argMask&1 != 0 && sensor.syscallTablesSupported() &&
+		perfEventAttrsReferenced(filter) {
+		fetchargs += " " +
+			sensor.syscallEnterLayout.perfEventAttrFetchargs()
+	}
+	kprobeSymbol := syscallNewEnterKprobeAddress
+	eventID, err = sensor.RegisterKprobe(
+		kprobeSymbol, false,
+		fetchargs,
+		f.decodeSyscallTraceEnter,
+		perf.WithEventAttr(subscr.eventAttr),
+		perf.WithEventGroup(subscr.eventGroupID))
+	if err != nil {
+		kprobeSymbol = syscallOldEnterKprobeAddress
+		eventID, err = sensor.RegisterKprobe(
+			kprobeSymbol, false,
+			fetchargs,
+			f.decodeSyscallTraceEnter,
+			perf.WithEventAttr(subscr.eventAttr),
+			perf.WithEventGroup(subscr.eventGroupID))
+	}
+	if err != nil {
+		subscr.logStatus(
+			code.Code_UNKNOWN,
+			fmt.Sprintf("Could not register syscall enter kprobe %s: %v", kprobeSymbol, err))
+		return nil
+	}
+
+	filterTypes, derivedTypes := sensor.syscallArgPolicy.enterEventTypes()
+	es, err := subscr.addDerivedEventSink(eventID, filter,
+		filterTypes, derivedTypes)
+	if err != nil {
+		subscr.logStatus(
+			code.Code_UNKNOWN,
+			fmt.Sprintf("Invalid filter expression for syscall enter filter: %v", err))
+		sensor.Monitor.UnregisterEvent(eventID)
+		if major >= 3 {
+			if atomic.AddInt64(&sensor.dummySyscallEventCount, -1) == 0 {
+				sensor.Monitor.UnregisterEvent(sensor.dummySyscallEventID)
+			}
+		}
+		return nil
+	}
+	if major >= 3 {
+		es.unregister = func(*eventSink) {
+			eventID := sensor.dummySyscallEventID
+			if atomic.AddInt64(&sensor.dummySyscallEventCount, -1) == 0 {
+				sensor.Monitor.UnregisterEvent(eventID)
+			}
+		}
+	}
+	return es
+}
+
+func (f *syscallFilter) registerExitTracepoint(
+	subscr *subscription,
+	filter *api.Expression,
+) *eventSink {
+	return f.registerSysExitTracepoint(subscr, filter, f.decodeSysExit,
+		syscallExitDerivedEventTypes, "syscall exit")
+}
+
+// registerSysExitTracepoint registers the syscall exit tracepoint with the
+// specified decoder, which derives fields of the specified types. kind names
+// the filter in status messages.
+func (f *syscallFilter) registerSysExitTracepoint(
+	subscr *subscription,
+	filter *api.Expression,
+	decoder perf.TraceEventDecoderFn,
+	derivedTypes expression.FieldTypeMap,
+	kind string,
+) *eventSink {
+	sensor := f.sensor
+
+	eventName := "raw_syscalls/sys_exit"
+	eventID, err := sensor.Monitor.RegisterTracepoint(eventName,
+		decoder,
+		perf.WithEventAttr(subscr.eventAttr),
+		perf.WithEventGroup(subscr.eventGroupID))
+	if err != nil {
+		eventName = "syscalls/sys_exit"
+		eventID, err = sensor.Monitor.RegisterTracepoint(eventName,
+			decoder,
+			perf.WithEventAttr(subscr.eventAttr),
+			perf.WithEventGroup(subscr.eventGroupID))
+	}
+	if err != nil {
+		subscr.logStatus(
+			code.Code_UNKNOWN,
+			fmt.Sprintf("Could not register tracepoint %s: %v", eventName, err))
+		return nil
+	}
+
+	es, err := subscr.addDerivedEventSink(eventID, filter,
+		syscallExitEventTypes, derivedTypes)
+	if err != nil {
+		subscr.logStatus(
+			code.Code_UNKNOWN,
+			fmt.Sprintf("Invalid filter expression for %s filter: %v",
+				kind, err))
+		sensor.Monitor.UnregisterEvent(eventID)
+		return nil
+	}
+	return es
+}
+
 func registerSyscallEvents(
 	sensor *Sensor,
 	subscr *subscription,
 	events []*api.SyscallEventFilter,
 ) {
-	var enterFilter, exitFilter *api.Expression
+	var (
+		enterFilter, exitFilter, completeFilter *api.Expression
+		linkageFilter                           *api.Expression
+		enterArgMask, completeArgMask           uint8
+		completePathMask                        uint8
+		enterDeepIDs                            = make(map[int64]uint8)
+		orphanAction                            api.SyscallOrphanAction
+		distributions                           []*api.SyscallEventFilter
+	)
 
 	for _, sef := range events {
 		// Translate deprecated fields into an expression
-		rewriteSyscallEventFilter(sef)
+		deprecated := rewriteSyscallEventFilter(sef)
+		if config.Sensor.WarnDeprecatedFilterFields {
+			warnDeprecatedSyscallFields(subscr, deprecated)
+		}
 
 		if !containsIDFilter(sef.FilterExpression) {
 			// No wildcard filters for now
@@ -224,13 +867,70 @@ func registerSyscallEvents(
 			continue
 		}
 
+		argMask := syscallArgMaskAll
+		if sef.ArgMask != 0 {
+			argMask = uint8(sef.ArgMask) |
+				syscallArgMaskFromExpression(sef.FilterExpression)
+		}
+
+		// Paths are only captured when requested, because fetching
+		// strings is much more expensive than fetching integers.
+		var pathMask uint8
+		if !sensor.syscallTablesSupported() {
+			if sef.CapturePaths ||
+				syscallDerivedFieldsReferenced(sef.FilterExpression) {
+				subscr.logStatus(
+					code.Code_UNIMPLEMENTED,
+					fmt.Sprintf("System call specific fields are not supported on %s",
+						sensor.machineArch))
+			}
+		} else {
+			if sef.CapturePaths ||
+				syscallPathsReferenced(sef.FilterExpression) {
+				var dirfdMask uint8
+				dirfdMask, pathMask = syscallPathMasks(
+					syscallIDsFromExpression(sef.FilterExpression))
+				argMask |= dirfdMask
+			}
+			pathMask |= memfdNamePathMask(
+				syscallIDsFromExpression(sef.FilterExpression)) |
+				keyStringPathMask(
+					syscallIDsFromExpression(sef.FilterExpression))
+		}
+
 		switch sef.Type {
 		case api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER:
 			enterFilter = expression.LogicalOr(enterFilter,
 				sef.FilterExpression)
+			enterArgMask |= argMask
+			if sensor.syscallTablesSupported() {
+				syscallDeepCaptureIDs(enterDeepIDs,
+					syscallIDsFromExpression(sef.FilterExpression),
+					argMask, sef.CapturePaths ||
+						syscallPathsReferenced(sef.FilterExpression))
+			}
 		case api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT:
 			exitFilter = expression.LogicalOr(exitFilter,
 				sef.FilterExpression)
+		case api.SyscallEventType_SYSCALL_EVENT_TYPE_COMPLETE:
+			completeFilter = expression.LogicalOr(completeFilter,
+				sef.FilterExpression)
+			completeArgMask |= argMask
+			completePathMask |= pathMask
+			if sef.OrphanAction == api.SyscallOrphanAction_SYSCALL_ORPHAN_ACTION_EMIT_PARTIAL {
+				orphanAction = sef.OrphanAction
+			}
+		case api.SyscallEventType_SYSCALL_EVENT_TYPE_CLONE_LINKAGE:
+			linkageFilter = expression.LogicalOr(linkageFilter,
+				sef.FilterExpression)
+		case api.SyscallEventType_SYSCALL_EVENT_TYPE_ARG_DISTRIBUTION:
+			if sef.ArgDistribution == nil || sef.ArgDistribution.ArgIndex > 5 {
+				subscr.logStatus(
+					code.Code_INVALID_ARGUMENT,
+					"Syscall argument distribution filter requires an arg_index from 0 to 5")
+				continue
+			}
+			distributions = append(distributions, sef)
 		default:
 			subscr.logStatus(
 				code.Code_INVALID_ARGUMENT,
@@ -244,128 +944,39 @@ func registerSyscallEvents(
 	}
 
 	if enterFilter != nil {
-		// Create the dummy syscall event. This event is needed to put
-		// the kernel into a mode where it'll make the function calls
-		// needed to make the kprobe we'll add fire. Add the tracepoint,
-		// but make sure it never adds events into the ringbuffer by
-		// using a filter that will never evaluate true. It also never
-		// gets enabled, but just creating it is enough.
-		//
-		// For kernels older than 3.x, create this dummy event in all
-		// event groups, because we cannot remove it when we don't need
-		// it anymore due to bugs in CentOS 6.x kernels (2.6.32).
-		var (
-			err     error
-			eventID uint64
-		)
-		eventName := "raw_syscalls/sys_enter"
-		major, _, _ := sys.KernelVersion()
-		if major < 3 {
-			eventID, err = sensor.Monitor.RegisterTracepoint(
-				eventName, f.decodeDummySysEnter,
-				perf.WithEventGroup(subscr.eventGroupID),
-				perf.WithFilter("id == 0x7fffffff"))
-			if err != nil {
-				eventName = "syscalls/sys_enter"
-				eventID, err = sensor.Monitor.RegisterTracepoint(
-					eventName, f.decodeDummySysEnter,
-					perf.WithEventGroup(subscr.eventGroupID),
-					perf.WithFilter("id == 0x7fffffff"))
-			}
-			if err != nil {
-				subscr.logStatus(
-					code.Code_UNKNOWN,
-					fmt.Sprintf("Could not register dummy syscall event %s: %v", eventName, err))
-			}
-		} else if atomic.AddInt64(&sensor.dummySyscallEventCount, 1) == 1 {
-			eventID, err = sensor.Monitor.RegisterTracepoint(
-				eventName, f.decodeDummySysEnter,
-				perf.WithEventGroup(0),
-				perf.WithFilter("id == 0x7fffffff"))
-			if err != nil {
-				subscr.logStatus(
-					code.Code_UNKNOWN,
-					fmt.Sprintf("Could not register dummy syscall event %s: %v", eventName, err))
-				atomic.AddInt64(&sensor.dummySyscallEventCount, -1)
-			} else {
-				sensor.dummySyscallEventID = eventID
-			}
-		}
-
-		// There are two possible kprobes. Newer kernels (>= 4.1) have
-		// refactored syscall entry code, so syscall_trace_enter_phase1
-		// is the right one, but for older kernels syscall_trace_enter
-		// is the right one. Both have the same signature, so the
-		// fetchargs doesn't have to change. Try the new probe first,
-		// because the old probe will also set in the newer kernels,
-		// but it won't fire.
-		kprobeSymbol := syscallNewEnterKprobeAddress
-		eventID, err = sensor.RegisterKprobe(
-			kprobeSymbol, false,
-			syscallEnterKprobeFetchargs,
-			f.decodeSyscallTraceEnter,
-			perf.WithEventGroup(subscr.eventGroupID))
-		if err != nil {
-			kprobeSymbol = syscallOldEnterKprobeAddress
-			eventID, err = sensor.RegisterKprobe(
-				kprobeSymbol, false,
-				syscallEnterKprobeFetchargs,
-				f.decodeSyscallTraceEnter,
-				perf.WithEventGroup(subscr.eventGroupID))
-		}
-		if err != nil {
-			subscr.logStatus(
-				code.Code_UNKNOWN,
-				fmt.Sprintf("Could not register syscall enter kprobe %s: %v", kprobeSymbol, err))
-		} else {
-			es, err := subscr.addEventSink(eventID, enterFilter,
-				syscallEnterEventTypes)
-			if err != nil {
-				subscr.logStatus(
-					code.Code_UNKNOWN,
-					fmt.Sprintf("Invalid filter expression for syscall enter filter: %v", err))
-				sensor.Monitor.UnregisterEvent(eventID)
-				if major >= 3 {
-					eventID = sensor.dummySyscallEventID
-					if atomic.AddInt64(&sensor.dummySyscallEventCount, -1) == 0 {
-						sensor.Monitor.UnregisterEvent(sensor.dummySyscallEventID)
-					}
-				}
-			} else if major >= 3 {
-				es.unregister = func(*eventSink) {
-					eventID := sensor.dummySyscallEventID
-					if atomic.AddInt64(&sensor.dummySyscallEventCount, -1) == 0 {
-						sensor.Monitor.UnregisterEvent(eventID)
-					}
-				}
-			}
-		}
+		enterFilter = sensor.applyDefaultFilter(DefaultFilterSyscall,
+			enterFilter)
+		f.registerSyscallEnterEvents(subscr, enterFilter, enterArgMask,
+			enterDeepIDs)
 	}
 
 	if exitFilter != nil {
-		eventName := "raw_syscalls/sys_exit"
-		eventID, err := sensor.Monitor.RegisterTracepoint(eventName,
-			f.decodeSysExit,
-			perf.WithEventGroup(subscr.eventGroupID))
-		if err != nil {
-			eventName = "syscalls/sys_exit"
-			eventID, err = sensor.Monitor.RegisterTracepoint(eventName,
-				f.decodeSysExit,
-				perf.WithEventGroup(subscr.eventGroupID))
-		}
-		if err != nil {
-			subscr.logStatus(
-				code.Code_UNKNOWN,
-				fmt.Sprintf("Could not register tracepoint %s: %v", eventName, err))
-		} else {
-			_, err = subscr.addEventSink(eventID, exitFilter,
-				syscallExitEventTypes)
-			if err != nil {
-				subscr.logStatus(
-					code.Code_UNKNOWN,
-					fmt.Sprintf("Invalid filter expression for syscall exit filter: %v", err))
-				sensor.Monitor.UnregisterEvent(eventID)
-			}
-		}
+		exitFilter = sensor.applyDefaultFilter(DefaultFilterSyscall,
+			exitFilter)
+		f.registerExitTracepoint(subscr, exitFilter)
+	}
+
+	if completeFilter != nil {
+		completeFilter = sensor.applyDefaultFilter(DefaultFilterSyscall,
+			completeFilter)
+		registerSyscallCompleteEvents(&f, subscr, completeFilter,
+			completeArgMask, completePathMask, orphanAction)
+	}
+
+	if linkageFilter != nil {
+		linkageFilter = sensor.applyDefaultFilter(DefaultFilterSyscall,
+			linkageFilter)
+		registerSyscallCloneLinkageEvents(&f, subscr, linkageFilter)
+	}
+
+	// Each argument distribution counts its own argument, so they cannot
+	// share a kprobe the way that the other filters do.
+	for _, sef := range distributions {
+		argMask := uint8(1<<sef.ArgDistribution.ArgIndex) |
+			syscallArgMaskFromExpression(sef.FilterExpression)
+		filter := sensor.applyDefaultFilter(DefaultFilterSyscall,
+			sef.FilterExpression)
+		registerSyscallArgDistribution(&f, subscr, filter, argMask,
+			sef.ArgDistribution)
 	}
 }
@@ -0,0 +1,250 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+// The number of low-order bits of an argument kept by the truncate action if
+// not specified
+const defaultSyscallArgTruncateBits = 16
+
+type syscallArgAction int
+
+const (
+	syscallArgCapture syscallArgAction = iota
+	syscallArgDisable
+	syscallArgHash
+	syscallArgTruncate
+)
+
+type syscallArgRule struct {
+	action syscallArgAction
+	bits   uint
+}
+
+// syscallArgPolicy limits the capture of sensitive system call arguments
+// regardless of what subscriptions request. Each argument of a system call
+// may be disabled (removed from the event), hashed with a key that is
+// random for each run of the sensor, so that equal values can still be
+// correlated, or truncated to its low-order bits.
+type syscallArgPolicy struct {
+	rules map[int64]*[6]syscallArgRule
+	key   []byte
+}
+
+// parseSyscallArgPolicy parses a policy of comma separated rules of the form
+// <id>[:arg<n>]=<action>, where the action is "disable", "hash", or
+// "truncate[:<bits>]". A rule without an argument applies to all of the
+// system call's arguments. For example, "1:arg1=disable,250=hash" removes
+// the buffer from write and hashes all of the arguments of keyctl.
+func parseSyscallArgPolicy(spec string) (*syscallArgPolicy, error) {
+	p := &syscallArgPolicy{
+		rules: make(map[int64]*[6]syscallArgRule),
+		key:   make([]byte, sha256.Size),
+	}
+	if _, err := rand.Read(p.key); err != nil {
+		return nil, err
+	}
+
+	for _, text := range strings.Split(spec, ",") {
+		text = strings.TrimSpace(text)
+		if len(text) == 0 {
+			continue
+		}
+		parts := strings.SplitN(text, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Invalid syscall argument policy rule %q", text)
+		}
+
+		target := strings.SplitN(parts[0], ":", 2)
+		id, err := strconv.ParseInt(target[0], 10, 64)
+		if err != nil || id < 0 {
+			return nil, fmt.Errorf("Invalid syscall id in syscall argument policy rule %q", text)
+		}
+		first, last := 0, 5
+		if len(target) == 2 {
+			arg := target[1]
+			if len(arg) != 4 || !strings.HasPrefix(arg, "arg") ||
+				arg[3] < '0' || arg[3] > '5' {
+				return nil, fmt.Errorf("Invalid argument in syscall argument policy rule %q", text)
+			}
+			first = int(arg[3] - '0')
+			last = first
+		}
+
+		rule, err := parseSyscallArgRule(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("%v in syscall argument policy rule %q", err, text)
+		}
+
+		rules, ok := p.rules[id]
+		if !ok {
+			rules = &[6]syscallArgRule{}
+			p.rules[id] = rules
+		}
+		for i := first; i <= last; i++ {
+			rules[i] = rule
+		}
+	}
+	return p, nil
+}
+
+func parseSyscallArgRule(text string) (syscallArgRule, error) {
+	parts := strings.SplitN(text, ":", 2)
+	switch parts[0] {
+	case "disable":
+		if len(parts) == 1 {
+			return syscallArgRule{action: syscallArgDisable}, nil
+		}
+	case "hash":
+		if len(parts) == 1 {
+			return syscallArgRule{action: syscallArgHash}, nil
+		}
+	case "truncate":
+		rule := syscallArgRule{
+			action: syscallArgTruncate,
+			bits:   defaultSyscallArgTruncateBits,
+		}
+		if len(parts) == 1 {
+			return rule, nil
+		}
+		bits, err := strconv.ParseUint(parts[1], 10, 8)
+		if err == nil && bits < 64 {
+			rule.bits = uint(bits)
+			return rule, nil
+		}
+	}
+	return syscallArgRule{}, fmt.Errorf("Invalid action %q", text)
+}
+
+func (p *syscallArgPolicy) hash(value uint64) uint64 {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], value)
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write(b[:])
+	return binary.LittleEndian.Uint64(mac.Sum(nil))
+}
+
+// applyString applies the policy to a string pointed to by an argument of a
+// system call. Strings cannot be truncated, so they are removed by both the
+// disable and truncate actions, and hashed strings are replaced by the hex
+// encoding of the first 8 bytes of their keyed hash. The second return value
+// is false if the string is removed.
+func (p *syscallArgPolicy) applyString(
+	id int64,
+	arg int,
+	s string,
+) (string, bool) {
+	if p == nil {
+		return s, true
+	}
+	rules, ok := p.rules[id]
+	if !ok {
+		return s, true
+	}
+	switch rules[arg].action {
+	case syscallArgCapture:
+		return s, true
+	case syscallArgHash:
+		mac := hmac.New(sha256.New, p.key)
+		mac.Write([]byte(s))
+		return hex.EncodeToString(mac.Sum(nil)[:8]), true
+	}
+	return "", false
+}
+
+// apply applies the policy to the captured arguments of a system call in
+// its sample data, returning a mask of the arguments that were changed.
+func (p *syscallArgPolicy) apply(
+	id int64,
+	data perf.TraceEventSampleData,
+) (mask uint8) {
+	rules, ok := p.rules[id]
+	if !ok {
+		return
+	}
+	for i, rule := range rules {
+		if rule.action == syscallArgCapture {
+			continue
+		}
+		name := "arg" + strconv.Itoa(i)
+		value, ok := data[name].(uint64)
+		if !ok {
+			continue
+		}
+		switch rule.action {
+		case syscallArgDisable:
+			delete(data, name)
+		case syscallArgHash:
+			data[name] = p.hash(value)
+		case syscallArgTruncate:
+			data[name] = value & (1<<rule.bits - 1)
+		}
+		mask |= 1 << uint(i)
+	}
+	return
+}
+
+// enterEventTypes returns the types of the fields of system call enter events
+// split into those that filters may compare in the kernel and those that
+// they may only compare in userspace. The kernel sees arguments before the
+// policy is applied, so arguments that any rule limits are moved to the
+// latter, and filters on them compare the values that the events report.
+func (p *syscallArgPolicy) enterEventTypes() (
+	filterTypes, derivedTypes expression.FieldTypeMap,
+) {
+	if p == nil {
+		return syscallEnterEventTypes, syscallEnterDerivedEventTypes
+	}
+
+	var limited [6]bool
+	for _, rules := range p.rules {
+		for i, rule := range rules {
+			if rule.action != syscallArgCapture {
+				limited[i] = true
+			}
+		}
+	}
+
+	filterTypes = make(expression.FieldTypeMap, len(syscallEnterEventTypes))
+	derivedTypes = make(expression.FieldTypeMap,
+		len(syscallEnterDerivedEventTypes)+len(limited))
+	for k, v := range syscallEnterEventTypes {
+		filterTypes[k] = v
+	}
+	for k, v := range syscallEnterDerivedEventTypes {
+		derivedTypes[k] = v
+	}
+	for i, l := range limited {
+		if l {
+			name := "arg" + strconv.Itoa(i)
+			derivedTypes[name] = filterTypes[name]
+			delete(filterTypes, name)
+		}
+	}
+	return
+}
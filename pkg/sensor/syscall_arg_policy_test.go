@@ -0,0 +1,107 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func TestParseSyscallArgPolicyErrors(t *testing.T) {
+	for _, spec := range []string{
+		"1",
+		"x=disable",
+		"1:arg6=disable",
+		"1:fd=disable",
+		"1=redact",
+		"1=hash:8",
+		"1=truncate:64",
+	} {
+		if _, err := parseSyscallArgPolicy(spec); err == nil {
+			t.Errorf("Expected error for policy %q", spec)
+		}
+	}
+}
+
+func TestSyscallArgPolicy(t *testing.T) {
+	p, err := parseSyscallArgPolicy("1:arg1=disable, 1:arg2=truncate:8,250=hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := perf.TraceEventSampleData{
+		"arg0": uint64(1),
+		"arg1": uint64(0x7ffe1234),
+		"arg2": uint64(0x1234),
+	}
+	if mask := p.apply(1, data); mask != 1<<1|1<<2 {
+		t.Errorf("Expected mask %#x, got %#x", 1<<1|1<<2, mask)
+	}
+	if _, ok := data["arg1"]; ok {
+		t.Error("Expected arg1 to be removed")
+	}
+	if data["arg0"] != uint64(1) || data["arg2"] != uint64(0x34) {
+		t.Errorf("Unexpected arguments %v", data)
+	}
+
+	// Hashing is consistent, and only captured arguments are changed
+	data = perf.TraceEventSampleData{
+		"arg0": uint64(42),
+	}
+	if mask := p.apply(250, data); mask != 1<<0 {
+		t.Errorf("Expected mask %#x, got %#x", 1<<0, mask)
+	}
+	if data["arg0"] == uint64(42) || data["arg0"] != p.hash(42) {
+		t.Errorf("Expected hashed arg0, got %v", data["arg0"])
+	}
+
+	if mask := p.apply(2, data); mask != 0 {
+		t.Errorf("Expected no arguments changed, got %#x", mask)
+	}
+}
+
+func TestSyscallArgPolicyEnterEventTypes(t *testing.T) {
+	var p *syscallArgPolicy
+	filterTypes, derivedTypes := p.enterEventTypes()
+	if _, ok := filterTypes["arg1"]; !ok {
+		t.Error("Expected arg1 to be a kernel field without a policy")
+	}
+
+	p, err := parseSyscallArgPolicy("1:arg1=disable,250:arg4=hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filterTypes, derivedTypes = p.enterEventTypes()
+	for _, name := range []string{"arg1", "arg4"} {
+		if _, ok := filterTypes[name]; ok {
+			t.Errorf("Expected %s not to be a kernel field", name)
+		}
+		if _, ok := derivedTypes[name]; !ok {
+			t.Errorf("Expected %s to be a userspace field", name)
+		}
+	}
+	for _, name := range []string{"id", "arg0", "arg2", "arg3", "arg5"} {
+		if _, ok := filterTypes[name]; !ok {
+			t.Errorf("Expected %s to be a kernel field", name)
+		}
+	}
+	if _, ok := syscallEnterEventTypes["arg1"]; !ok {
+		t.Error("Syscall enter event types were modified")
+	}
+	if _, ok := syscallEnterDerivedEventTypes["arg1"]; ok {
+		t.Error("Syscall enter derived event types were modified")
+	}
+}
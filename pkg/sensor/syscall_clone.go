@@ -0,0 +1,109 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"sort"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"google.golang.org/genproto/googleapis/rpc/code"
+)
+
+var syscallCloneLinkageDerivedEventTypes = expression.FieldTypeMap{
+	"parent_pid": expression.ValueTypeSignedInt32,
+	"child_pid":  expression.ValueTypeSignedInt32,
+}
+
+// decodeCloneLinkage decodes the return of a clone, fork, or vfork system call
+// in the calling task into an event linking it to the task that it created.
+// The pid returned to the caller is in the caller's pid namespace, so the
+// created task is found in the process cache, which knows its host pid from
+// the kernel's own fork tracepoint.
+func (f *syscallFilter) decodeCloneLinkage(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	id := data["id"].(int64)
+	ret := data["ret"].(int64)
+	if ret <= 0 || !syscallCloneIDs[id] {
+		return nil, nil
+	}
+
+	ev := f.sensor.NewEventFromSample(sample, data)
+	if ev == nil {
+		return nil, nil
+	}
+	child := f.sensor.ProcessCache.LookupClonedTask(int(ev.ProcessTgid),
+		int(ev.ProcessPid), int(ret))
+	if child == nil {
+		return nil, nil
+	}
+
+	syscall := &api.SyscallEvent{
+		Type:      api.SyscallEventType_SYSCALL_EVENT_TYPE_CLONE_LINKAGE,
+		Id:        id,
+		Ret:       ret,
+		ParentPid: ev.ProcessPid,
+		ChildPid:  int32(child.PID),
+	}
+	data["parent_pid"] = syscall.ParentPid
+	data["child_pid"] = syscall.ChildPid
+	ev.Event = &api.TelemetryEvent_Syscall{
+		Syscall: syscall,
+	}
+
+	return ev, nil
+}
+
+// syscallCloneLinkageExpression returns an expression that is true for the
+// successful returns of the clone, fork, and vfork system calls in the
+// calling task.
+func syscallCloneLinkageExpression() *api.Expression {
+	ids := make([]int64, 0, len(syscallCloneIDs))
+	for id := range syscallCloneIDs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return expression.LogicalAnd(syscallIDsExpression(ids...),
+		expression.GreaterThan(
+			expression.Identifier("ret"),
+			expression.Value(int64(0))))
+}
+
+func registerSyscallCloneLinkageEvents(
+	f *syscallFilter,
+	subscr *subscription,
+	filter *api.Expression,
+) {
+	sensor := f.sensor
+
+	if !sensor.syscallTablesSupported() {
+		subscr.logStatus(
+			code.Code_UNIMPLEMENTED,
+			fmt.Sprintf("Clone linkage events are not supported on %s",
+				sensor.machineArch))
+		return
+	}
+
+	filter = expression.LogicalAnd(filter, syscallCloneLinkageExpression())
+	f.registerSysExitTracepoint(subscr, filter, f.decodeCloneLinkage,
+		syscallCloneLinkageDerivedEventTypes, "clone linkage")
+}
@@ -0,0 +1,338 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/config"
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+
+	"github.com/golang/glog"
+
+	"google.golang.org/genproto/googleapis/rpc/code"
+)
+
+// syscallCompleteTracker correlates syscall enter and exit events for a
+// subscription, combining each pair into a single complete event.
+type syscallCompleteTracker struct {
+	sync.Mutex
+
+	dispatchFn  eventSinkDispatchFn
+	emitPartial bool
+
+	// If true, complete events carry the sequence numbers of the enter
+	// and exit events that they were combined from.
+	causedBy bool
+
+	// If true, every exit that passes the exit filter is expected to have
+	// a matching enter. This is not the case when the enter filter
+	// references system call arguments, because the exit filter can only
+	// match on the system call number.
+	strictExits bool
+
+	// The predicates of the filter that refer to fields of the exit,
+	// evaluated when the exit is seen. Nil if there are none.
+	exitFilter *expression.Expression
+
+	// Whether the exit fields derived from the system call tables, such
+	// as bytes, are reported
+	syscallTables bool
+
+	// Enter events awaiting their exit, keyed by tid
+	pending map[int32]*api.TelemetryEvent
+
+	stopChan chan struct{}
+}
+
+func newSyscallCompleteTracker(
+	dispatchFn eventSinkDispatchFn,
+	emitPartial, strictExits bool,
+) *syscallCompleteTracker {
+	return &syscallCompleteTracker{
+		dispatchFn:  dispatchFn,
+		emitPartial: emitPartial,
+		strictExits: strictExits,
+		pending:     make(map[int32]*api.TelemetryEvent),
+	}
+}
+
+func (t *syscallCompleteTracker) orphan(e *api.TelemetryEvent) {
+	if t.emitPartial {
+		syscall := e.GetSyscall()
+		syscall.Type = api.SyscallEventType_SYSCALL_EVENT_TYPE_COMPLETE
+		syscall.Partial = true
+		if t.causedBy {
+			e.CausedBy = []uint64{e.SensorSequenceNumber}
+		}
+		t.dispatchFn(e)
+	}
+}
+
+// exitFieldValues returns the values of the fields of an exit event that
+// the exit filter may refer to.
+func (t *syscallCompleteTracker) exitFieldValues(
+	exit *api.SyscallEvent,
+) expression.FieldValueMap {
+	values := expression.FieldValueMap{
+		"id":  exit.Id,
+		"ret": exit.Ret,
+	}
+	if t.syscallTables && syscallBytesIDs[exit.Id] && exit.Ret >= 0 {
+		values["bytes"] = exit.Bytes
+	}
+	return values
+}
+
+// exitMatches returns whether an exit event passes the exit filter.
+func (t *syscallCompleteTracker) exitMatches(exit *api.SyscallEvent) bool {
+	if t.exitFilter == nil {
+		return true
+	}
+	v, err := t.exitFilter.Evaluate(syscallCompleteExitTypes,
+		t.exitFieldValues(exit))
+	if err != nil {
+		glog.V(1).Infof("Expression evaluation error: %s", err)
+		return false
+	}
+	return expression.IsValueTrue(v)
+}
+
+func (t *syscallCompleteTracker) enter(e *api.TelemetryEvent) {
+	// The decoded event may be seen by other event sinks, so make a
+	// private copy that can be modified when the exit is seen.
+	e = copyTelemetryEvent(e)
+
+	// ProcessPid is the kernel's pid of the task, which is its tid. It
+	// is zero if the task is unknown, in which case the enter cannot be
+	// matched with its exit.
+	tid := e.ProcessPid
+	if tid == 0 {
+		t.orphan(e)
+		return
+	}
+
+	t.Lock()
+	old := t.pending[tid]
+	t.pending[tid] = e
+	t.Unlock()
+
+	// A task can only be in one system call at a time, so a pending enter
+	// for the same task means that its exit was missed.
+	if old != nil {
+		t.orphan(old)
+	}
+}
+
+func (t *syscallCompleteTracker) exit(e *api.TelemetryEvent) {
+	exit := e.GetSyscall()
+
+	var ce *api.TelemetryEvent
+	if tid := e.ProcessPid; tid != 0 {
+		t.Lock()
+		ce = t.pending[tid]
+		if ce != nil && ce.GetSyscall().Id == exit.Id {
+			delete(t.pending, tid)
+		} else {
+			ce = nil
+		}
+		t.Unlock()
+	}
+
+	if !t.exitMatches(exit) {
+		return
+	}
+	if ce == nil {
+		if t.strictExits {
+			t.orphan(copyTelemetryEvent(e))
+		}
+		return
+	}
+
+	syscall := ce.GetSyscall()
+	syscall.Type = api.SyscallEventType_SYSCALL_EVENT_TYPE_COMPLETE
+	syscall.Ret = exit.Ret
+	syscall.Bytes = exit.Bytes
+	syscall.CredentialsBefore = exit.CredentialsBefore
+	syscall.CredentialsAfter = exit.CredentialsAfter
+	syscall.NamespaceTransitions = exit.NamespaceTransitions
+	syscall.NamespacesUnresolved = exit.NamespacesUnresolved
+	syscall.DurationNanos = e.SensorMonotimeNanos - ce.SensorMonotimeNanos
+	if t.causedBy {
+		ce.CausedBy = []uint64{
+			ce.SensorSequenceNumber,
+			e.SensorSequenceNumber,
+		}
+	}
+	t.dispatchFn(ce)
+}
+
+// expire handles enter events that have been waiting for their exit since
+// before the specified monotime.
+func (t *syscallCompleteTracker) expire(before int64) {
+	var orphans []*api.TelemetryEvent
+
+	t.Lock()
+	for tid, e := range t.pending {
+		if e.SensorMonotimeNanos < before {
+			orphans = append(orphans, e)
+			delete(t.pending, tid)
+		}
+	}
+	t.Unlock()
+
+	for _, e := range orphans {
+		t.orphan(e)
+	}
+}
+
+func (t *syscallCompleteTracker) start(sensor *Sensor, timeout time.Duration) {
+	if timeout <= 0 {
+		// Orphaned enters are only detected by a subsequent enter
+		return
+	}
+	t.stopChan = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(timeout)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-t.stopChan:
+				return
+			case <-ticker.C:
+				now := sys.CurrentMonotonicRaw() - sensor.bootMonotimeNanos
+				t.expire(now - int64(timeout))
+			}
+		}
+	}()
+}
+
+func (t *syscallCompleteTracker) stop() {
+	if t.stopChan != nil {
+		close(t.stopChan)
+	}
+}
+
+// syscallCompleteExitTypes are the types of the fields that the exit part of
+// a complete filter may refer to.
+var syscallCompleteExitTypes = expression.FieldTypeMap{
+	"id":    expression.ValueTypeSignedInt64,
+	"ret":   expression.ValueTypeSignedInt64,
+	"bytes": expression.ValueTypeUnsignedInt64,
+}
+
+// isSyscallExitField returns whether an identifier refers to a field that
+// only the exit of a system call has.
+func isSyscallExitField(ident string) bool {
+	return ident == "ret" || ident == "bytes"
+}
+
+// splitSyscallCompleteFilter splits a complete filter into the predicates
+// that are evaluated on the enter of a system call and those that are
+// evaluated on its exit. The predicates that refer to exit fields must be
+// combined with the rest of the filter by logical and, and must not also
+// refer to enter fields.
+func splitSyscallCompleteFilter(
+	filter *api.Expression,
+) (enterFilter, exitFilter *api.Expression, err error) {
+	enterFilter, exitPredicates := dropExpressionPredicates(filter,
+		isSyscallExitField)
+	for _, p := range exitPredicates {
+		var mixed string
+		walkExpressionIdentifiers(p, func(ident string) {
+			if mixed == "" && ident != "id" && !isSyscallExitField(ident) {
+				mixed = ident
+			}
+		})
+		if mixed != "" {
+			return nil, nil, fmt.Errorf("Syscall complete filter predicates that refer to ret or bytes cannot also refer to %s", mixed)
+		}
+		exitFilter = expression.LogicalAnd(exitFilter, p)
+	}
+	return
+}
+
+func registerSyscallCompleteEvents(
+	f *syscallFilter,
+	subscr *subscription,
+	filter *api.Expression,
+	argMask, pathMask uint8,
+	orphanAction api.SyscallOrphanAction,
+) {
+	idFilter := syscallIDFilterFromExpression(filter)
+	if idFilter == nil {
+		subscr.logStatus(
+			code.Code_INVALID_ARGUMENT,
+			"Syscall complete filter must compare id to a signed integer")
+		return
+	}
+
+	enterFilter, exitFilter, err := splitSyscallCompleteFilter(filter)
+	if err != nil {
+		subscr.logStatus(code.Code_INVALID_ARGUMENT, err.Error())
+		return
+	}
+	if enterFilter == nil {
+		enterFilter = idFilter
+	}
+
+	emitPartial := orphanAction ==
+		api.SyscallOrphanAction_SYSCALL_ORPHAN_ACTION_EMIT_PARTIAL
+	strictExits := syscallArgMaskFromExpression(filter) == 0
+	t := newSyscallCompleteTracker(subscr.dispatchFn, emitPartial,
+		strictExits)
+	t.causedBy = subscr.includeCausedBy
+	t.syscallTables = f.sensor.syscallTablesSupported()
+	if exitFilter != nil {
+		t.exitFilter, err = expression.NewExpression(exitFilter)
+		if err == nil {
+			err = t.exitFilter.Validate(syscallCompleteExitTypes)
+		}
+		if err != nil {
+			subscr.logStatus(
+				code.Code_INVALID_ARGUMENT,
+				fmt.Sprintf("Invalid filter expression for syscall complete exit filter: %v", err))
+			return
+		}
+	}
+
+	enterSink := f.registerEnterKprobe(subscr, enterFilter, argMask,
+		pathMask)
+	if enterSink == nil {
+		return
+	}
+	exitSink := f.registerExitTracepoint(subscr,
+		f.sensor.applyDefaultFilter(DefaultFilterSyscall, idFilter))
+	if exitSink == nil {
+		subscr.removeEventSink(enterSink)
+		f.sensor.Monitor.UnregisterEvent(enterSink.eventID)
+		if enterSink.unregister != nil {
+			enterSink.unregister(enterSink)
+		}
+		return
+	}
+
+	enterSink.dispatchFn = t.enter
+	exitSink.dispatchFn = t.exit
+	exitSink.unregister = func(*eventSink) {
+		t.stop()
+	}
+	t.start(f.sensor, config.Sensor.SyscallCompleteTimeout)
+}
@@ -0,0 +1,110 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"sort"
+	"sync/atomic"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+// The number of system call ids for which costs are tracked. Events for
+// system calls with ids outside of [0, maxSyscallCostID) are not tracked.
+const maxSyscallCostID = 512
+
+type syscallCost struct {
+	events        uint64
+	decodeNanos   uint64
+	deliveryNanos uint64
+}
+
+// syscallCostAccounting tracks the time the Sensor spends on the events of
+// each system call, across all subscriptions, so that operators can see
+// which system calls are the most expensive to monitor. Decoding happens on
+// the event monitor's goroutine and delivery on the dispatch goroutines, so
+// the costs are updated atomically. The zero value is ready to use.
+type syscallCostAccounting struct {
+	costs [maxSyscallCostID]syscallCost
+}
+
+func (a *syscallCostAccounting) get(id int64) *syscallCost {
+	if id < 0 || id >= maxSyscallCostID {
+		return nil
+	}
+	return &a.costs[id]
+}
+
+// addDecode records the time taken to decode an event for a system call.
+func (a *syscallCostAccounting) addDecode(id int64, d time.Duration) {
+	if c := a.get(id); c != nil {
+		atomic.AddUint64(&c.events, 1)
+		atomic.AddUint64(&c.decodeNanos, uint64(d))
+	}
+}
+
+// addDelivery records the time taken to filter an event for a system call
+// and deliver it to subscriptions.
+func (a *syscallCostAccounting) addDelivery(id int64, d time.Duration) {
+	if c := a.get(id); c != nil {
+		atomic.AddUint64(&c.deliveryNanos, uint64(d))
+	}
+}
+
+// statistics returns the costs of each system call that has had events, most
+// expensive first.
+func (a *syscallCostAccounting) statistics() []*api.SyscallCost {
+	var (
+		totalDecode uint64
+		stats       []*api.SyscallCost
+	)
+	for id := range a.costs {
+		c := &a.costs[id]
+		sc := &api.SyscallCost{
+			Id:            int64(id),
+			Events:        atomic.LoadUint64(&c.events),
+			DecodeNanos:   atomic.LoadUint64(&c.decodeNanos),
+			DeliveryNanos: atomic.LoadUint64(&c.deliveryNanos),
+		}
+		if sc.Events == 0 && sc.DeliveryNanos == 0 {
+			continue
+		}
+		totalDecode += sc.DecodeNanos
+		stats = append(stats, sc)
+	}
+
+	for _, sc := range stats {
+		if totalDecode > 0 {
+			sc.DecodeFraction = float64(sc.DecodeNanos) /
+				float64(totalDecode)
+		}
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		ci := stats[i].DecodeNanos + stats[i].DeliveryNanos
+		cj := stats[j].DecodeNanos + stats[j].DeliveryNanos
+		if ci != cj {
+			return ci > cj
+		}
+		return stats[i].Id < stats[j].Id
+	})
+	return stats
+}
+
+// SyscallCosts returns the time spent decoding and delivering the events of
+// each system call since the Sensor started, most expensive first.
+func (s *Sensor) SyscallCosts() []*api.SyscallCost {
+	return s.syscallCosts.statistics()
+}
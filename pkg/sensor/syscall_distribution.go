@@ -0,0 +1,226 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys"
+)
+
+const (
+	defaultSyscallDistributionTopK     = 10
+	defaultSyscallDistributionInterval = 60 * time.Second
+
+	// The maximum number of distinct values counted per system call in
+	// an interval. Values first seen after the limit is reached are
+	// included in the total, but are not counted individually.
+	maxSyscallDistributionValues = 4096
+)
+
+// syscallArgDistribution counts the values passed to a system call argument
+// and periodically emits the most frequent ones in place of the individual
+// syscall enter events.
+type syscallArgDistribution struct {
+	sync.Mutex
+
+	newEvent   func() *api.TelemetryEvent
+	dispatchFn eventSinkDispatchFn
+	argIndex   uint32
+	topK       int
+
+	// Counts of each argument value, keyed by syscall number
+	counts map[int64]map[uint64]uint64
+	totals map[int64]uint64
+
+	intervalStart int64
+
+	stopChan chan struct{}
+}
+
+func newSyscallArgDistribution(
+	newEvent func() *api.TelemetryEvent,
+	dispatchFn eventSinkDispatchFn,
+	argIndex uint32,
+	topK int,
+) *syscallArgDistribution {
+	if topK <= 0 {
+		topK = defaultSyscallDistributionTopK
+	}
+	return &syscallArgDistribution{
+		newEvent:   newEvent,
+		dispatchFn: dispatchFn,
+		argIndex:   argIndex,
+		topK:       topK,
+		counts:     make(map[int64]map[uint64]uint64),
+		totals:     make(map[int64]uint64),
+	}
+}
+
+func syscallEventArg(ev *api.SyscallEvent, index uint32) uint64 {
+	switch index {
+	case 0:
+		return ev.Arg0
+	case 1:
+		return ev.Arg1
+	case 2:
+		return ev.Arg2
+	case 3:
+		return ev.Arg3
+	case 4:
+		return ev.Arg4
+	case 5:
+		return ev.Arg5
+	}
+	return 0
+}
+
+// add counts the argument value of a syscall enter event. The event itself is
+// not dispatched.
+func (d *syscallArgDistribution) add(e *api.TelemetryEvent) {
+	ev := e.GetSyscall()
+	if ev == nil {
+		return
+	}
+	value := syscallEventArg(ev, d.argIndex)
+
+	d.Lock()
+	counts, ok := d.counts[ev.Id]
+	if !ok {
+		counts = make(map[uint64]uint64)
+		d.counts[ev.Id] = counts
+	}
+	if _, ok = counts[value]; ok || len(counts) < maxSyscallDistributionValues {
+		counts[value]++
+	}
+	d.totals[ev.Id]++
+	d.Unlock()
+}
+
+// flush dispatches an argument distribution event for each syscall seen since
+// the last flush and resets the counts. now is the sensor monotime at which
+// the interval ends.
+func (d *syscallArgDistribution) flush(now int64) {
+	d.Lock()
+	counts, totals := d.counts, d.totals
+	d.counts = make(map[int64]map[uint64]uint64)
+	d.totals = make(map[int64]uint64)
+	start := d.intervalStart
+	d.intervalStart = now
+	d.Unlock()
+
+	ids := make([]int64, 0, len(counts))
+	for id := range counts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		e := d.newEvent()
+		e.Event = &api.TelemetryEvent_Syscall{
+			Syscall: &api.SyscallEvent{
+				Type:              api.SyscallEventType_SYSCALL_EVENT_TYPE_ARG_DISTRIBUTION,
+				Id:                id,
+				DurationNanos:     now - start,
+				DistributionArg:   d.argIndex,
+				DistributionTotal: totals[id],
+				Distribution:      topSyscallArgValues(counts[id], d.topK),
+			},
+		}
+		d.dispatchFn(e)
+	}
+}
+
+// topSyscallArgValues returns up to k of the most frequent values, ordered by
+// descending count and then by ascending value.
+func topSyscallArgValues(counts map[uint64]uint64, k int) []*api.SyscallArgValueCount {
+	values := make([]*api.SyscallArgValueCount, 0, len(counts))
+	for value, count := range counts {
+		values = append(values, &api.SyscallArgValueCount{
+			Value: value,
+			Count: count,
+		})
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if values[i].Count != values[j].Count {
+			return values[i].Count > values[j].Count
+		}
+		return values[i].Value < values[j].Value
+	})
+	if len(values) > k {
+		values = values[:k]
+	}
+	return values
+}
+
+func (d *syscallArgDistribution) start(sensor *Sensor, interval time.Duration) {
+	d.intervalStart = sys.CurrentMonotonicRaw() - sensor.bootMonotimeNanos
+	d.stopChan = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-d.stopChan:
+				return
+			case <-ticker.C:
+				now := sys.CurrentMonotonicRaw() - sensor.bootMonotimeNanos
+				d.flush(now)
+			}
+		}
+	}()
+}
+
+func (d *syscallArgDistribution) stop() {
+	if d.stopChan != nil {
+		close(d.stopChan)
+	}
+}
+
+func registerSyscallArgDistribution(
+	f *syscallFilter,
+	subscr *subscription,
+	filter *api.Expression,
+	argMask uint8,
+	dist *api.SyscallArgDistribution,
+) {
+	sensor := f.sensor
+
+	es := f.registerEnterKprobe(subscr, filter, argMask, 0)
+	if es == nil {
+		return
+	}
+
+	interval := defaultSyscallDistributionInterval
+	if dist.IntervalSeconds != 0 {
+		interval = time.Duration(dist.IntervalSeconds) * time.Second
+	}
+	d := newSyscallArgDistribution(sensor.NewEvent, subscr.dispatchFn,
+		dist.ArgIndex, int(dist.TopK))
+
+	es.dispatchFn = d.add
+	unregister := es.unregister
+	es.unregister = func(es *eventSink) {
+		d.stop()
+		if unregister != nil {
+			unregister(es)
+		}
+	}
+	d.start(sensor, interval)
+}
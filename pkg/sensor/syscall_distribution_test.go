@@ -0,0 +1,85 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+func TestSyscallArgDistribution(t *testing.T) {
+	var events []*api.TelemetryEvent
+	d := newSyscallArgDistribution(
+		func() *api.TelemetryEvent { return &api.TelemetryEvent{} },
+		func(e *api.TelemetryEvent) { events = append(events, e) },
+		1, 2)
+
+	add := func(id int64, arg1 uint64) {
+		d.add(&api.TelemetryEvent{
+			Event: &api.TelemetryEvent_Syscall{
+				Syscall: &api.SyscallEvent{
+					Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER,
+					Id:   id,
+					Arg1: arg1,
+				},
+			},
+		})
+	}
+	for _, v := range []uint64{7, 3, 7, 5, 3, 9} {
+		add(2, v)
+	}
+	add(0, 42)
+
+	d.flush(1000)
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 distribution events, got %d", len(events))
+	}
+
+	ev := events[0].GetSyscall()
+	if ev.Id != 0 || ev.DistributionTotal != 1 || len(ev.Distribution) != 1 {
+		t.Errorf("Unexpected distribution for syscall 0: %+v", ev)
+	}
+
+	ev = events[1].GetSyscall()
+	if ev.Type != api.SyscallEventType_SYSCALL_EVENT_TYPE_ARG_DISTRIBUTION {
+		t.Errorf("Unexpected event type %s", ev.Type)
+	}
+	if ev.DistributionArg != 1 || ev.DistributionTotal != 6 ||
+		ev.DurationNanos != 1000 {
+		t.Errorf("Unexpected distribution for syscall 2: %+v", ev)
+	}
+	// Ties are broken by value and only the top 2 are kept
+	expected := []api.SyscallArgValueCount{
+		{Value: 3, Count: 2},
+		{Value: 7, Count: 2},
+	}
+	if len(ev.Distribution) != len(expected) {
+		t.Fatalf("Expected %d values, got %d", len(expected),
+			len(ev.Distribution))
+	}
+	for i, vc := range ev.Distribution {
+		if *vc != expected[i] {
+			t.Errorf("Expected %+v at %d, got %+v", expected[i], i, *vc)
+		}
+	}
+
+	// Counts are reset after each flush
+	events = nil
+	d.flush(2000)
+	if len(events) != 0 {
+		t.Errorf("Expected no events after reset, got %d", len(events))
+	}
+}
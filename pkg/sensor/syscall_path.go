@@ -0,0 +1,205 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+// The value of a dirfd argument that refers to the current working directory
+const atFDCWD = -100
+
+// syscallPathArgs gives the indices of the arguments of a system call that
+// hold paths, and of the directory file descriptors that relative paths are
+// resolved against. An index of -1 means that there is no such argument.
+type syscallPathArgs struct {
+	path, dirfd       int
+	newPath, newDirfd int
+}
+
+// syscallPathIDs maps the x86_64 numbers of the system calls that open,
+// execute, or modify files by path to the arguments holding their paths.
+var syscallPathIDs = map[int64]syscallPathArgs{
+	59:  {0, -1, -1, -1}, // execve
+	82:  {0, -1, 1, -1},  // rename
+	86:  {0, -1, 1, -1},  // link
+	87:  {0, -1, -1, -1}, // unlink
+	90:  {0, -1, -1, -1}, // chmod
+	92:  {0, -1, -1, -1}, // chown
+	94:  {0, -1, -1, -1}, // lchown
+	257: {1, 0, -1, -1},  // openat
+	258: {1, 0, -1, -1},  // mkdirat
+	259: {1, 0, -1, -1},  // mknodat
+	260: {1, 0, -1, -1},  // fchownat
+	263: {1, 0, -1, -1},  // unlinkat
+	264: {1, 0, 3, 2},    // renameat
+	265: {1, 0, 3, 2},    // linkat
+	266: {2, 1, -1, -1},  // symlinkat
+	268: {1, 0, -1, -1},  // fchmodat
+	316: {1, 0, 3, 2},    // renameat2
+	322: {1, 0, -1, -1},  // execveat
+}
+
+// syscallPathMasks returns the masks of the arguments that must be captured
+// as integers (directory file descriptors) and as strings (paths) in order to
+// report the paths of the specified system calls.
+func syscallPathMasks(ids []int64) (argMask, pathMask uint8) {
+	for _, id := range ids {
+		args, ok := syscallPathIDs[id]
+		if !ok {
+			continue
+		}
+		for _, i := range []int{args.path, args.newPath} {
+			if i >= 0 {
+				pathMask |= 1 << uint(i)
+			}
+		}
+		for _, i := range []int{args.dirfd, args.newDirfd} {
+			if i >= 0 {
+				argMask |= 1 << uint(i)
+			}
+		}
+	}
+	return
+}
+
+// syscallPathsReferenced returns whether an expression refers to the paths
+// derived for file-modifying system calls.
+func syscallPathsReferenced(expr *api.Expression) (referenced bool) {
+	walkExpressionIdentifiers(expr, func(ident string) {
+		switch ident {
+		case "path", "new_path", "path_relative", "new_path_relative":
+			referenced = true
+		}
+	})
+	return
+}
+
+// pathFetchargs returns the fetchargs that capture the strings pointed to by
+// the system call arguments selected by pathMask. The arguments are user
+// pointers, so they are read as user memory.
+func (l *syscallEnterLayout) pathFetchargs(pathMask uint8) string {
+	var fetchargs []string
+	for i, offset := range l.args {
+		if pathMask&(1<<uint(i)) != 0 {
+			fetchargs = append(fetchargs,
+				fmt.Sprintf("path%d=+0(+%d(%s)):ustring", i, offset,
+					l.register))
+		}
+	}
+	return strings.Join(fetchargs, " ")
+}
+
+// resolveSyscallPath returns a path argument made absolute if possible, and
+// whether it is. A relative path is resolved against the working directory of
+// the task if it is known and dirfd is AT_FDCWD or absent, or against the
+// directory that dirfd refers to, which is found with fdPath.
+func resolveSyscallPath(
+	path string,
+	dirfd int,
+	data perf.TraceEventSampleData,
+	cwd string,
+	fdPath func(fd int) (string, error),
+) (string, bool) {
+	if path == "" || filepath.IsAbs(path) {
+		return path, true
+	}
+	dir := cwd
+	if dirfd >= 0 {
+		fd, ok := data[fmt.Sprintf("arg%d", dirfd)].(uint64)
+		if !ok {
+			return path, false
+		}
+		if int32(fd) != atFDCWD {
+			// The descriptor is looked up after the system call
+			// was made, and so may since have been closed or
+			// reused.
+			var err error
+			dir, err = fdPath(int(int32(fd)))
+			if err != nil {
+				return path, false
+			}
+		}
+	}
+	if !filepath.IsAbs(dir) {
+		// Descriptors that aren't files, e.g. sockets, have links
+		// such as socket:[1234]
+		return path, false
+	}
+	return filepath.Join(dir, path), true
+}
+
+// syscallPathStatus returns the capture status of a captured path.
+func syscallPathStatus(
+	data perf.TraceEventSampleData,
+	name string,
+) api.SyscallArgStatus {
+	if data.Faulted(name) {
+		return api.SyscallArgStatus_SYSCALL_ARG_STATUS_FAULTED
+	}
+	return api.SyscallArgStatus_SYSCALL_ARG_STATUS_CAPTURED
+}
+
+// setSyscallPaths decodes the paths of a file-modifying system call, if they
+// were captured. Relative paths are resolved against the working directory of
+// the task that the event was decoded for.
+func (f *syscallFilter) setSyscallPaths(
+	ev *api.TelemetryEvent,
+	syscall *api.SyscallEvent,
+	data perf.TraceEventSampleData,
+) {
+	args := syscallPathIDs[syscall.Id]
+	name := fmt.Sprintf("path%d", args.path)
+	path, ok := data[name].(string)
+	if !ok {
+		return
+	}
+	syscall.PathStatus = syscallPathStatus(data, name)
+
+	var cwd string
+	if t, ok := data["__task__"].(*Task); ok {
+		cwd = t.CWD
+	}
+	fdPath := func(fd int) (string, error) {
+		return procFS.ProcessFileDescriptorPath(int(ev.ProcessTgid), fd)
+	}
+
+	var resolved bool
+	syscall.Path, resolved = resolveSyscallPath(path, args.dirfd, data,
+		cwd, fdPath)
+	syscall.PathRelative = !resolved
+	data["path"] = syscall.Path
+	data["path_relative"] = syscall.PathRelative
+	if args.newPath < 0 {
+		return
+	}
+	name = fmt.Sprintf("path%d", args.newPath)
+	newPath, ok := data[name].(string)
+	if !ok {
+		return
+	}
+	syscall.NewPathStatus = syscallPathStatus(data, name)
+	syscall.NewPath, resolved = resolveSyscallPath(newPath, args.newDirfd,
+		data, cwd, fdPath)
+	syscall.NewPathRelative = !resolved
+	data["new_path"] = syscall.NewPath
+	data["new_path_relative"] = syscall.NewPathRelative
+}
@@ -0,0 +1,123 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"errors"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+func TestSyscallPathMasks(t *testing.T) {
+	// unlink, renameat, and read
+	argMask, pathMask := syscallPathMasks([]int64{87, 264, 0})
+	if argMask != 1<<0|1<<2 || pathMask != 1<<0|1<<1|1<<3 {
+		t.Errorf("Unexpected masks %#x %#x", argMask, pathMask)
+	}
+
+	expected := "path0=+0(+112(%di)):ustring path3=+0(+56(%di)):ustring"
+	if got := syscallEnterLayoutX86_64.pathFetchargs(1<<0 | 1<<3); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	expr := expression.LogicalAnd(
+		expression.Equal(
+			expression.Identifier("id"),
+			expression.Value(int64(87))),
+		expression.Like(
+			expression.Identifier("path"),
+			expression.Value("/etc/*")))
+	if !syscallPathsReferenced(expr) {
+		t.Error("Expected path to be referenced")
+	}
+	if syscallPathsReferenced(expr.GetBinaryOp().Lhs) {
+		t.Error("Expected path not to be referenced")
+	}
+}
+
+func TestResolveSyscallPath(t *testing.T) {
+	data := perf.TraceEventSampleData{
+		"arg0": uint64(0xffffffffffffff9c), // AT_FDCWD
+		"arg2": uint64(3),
+		"arg3": uint64(4),
+		"arg4": uint64(5),
+	}
+	fdPath := func(fd int) (string, error) {
+		switch fd {
+		case 3:
+			return "/var/lib", nil
+		case 4:
+			return "socket:[1234]", nil
+		}
+		return "", errors.New("no such file descriptor")
+	}
+	testCases := []struct {
+		path     string
+		dirfd    int
+		cwd      string
+		expected string
+		resolved bool
+	}{
+		{"/etc/passwd", -1, "/root", "/etc/passwd", true},
+		{"/etc/passwd", 4, "/root", "/etc/passwd", true},
+		{"passwd", -1, "/etc", "/etc/passwd", true},
+		{"../passwd", 0, "/etc/ssh", "/etc/passwd", true},
+		{"docker/../dpkg", 2, "/etc", "/var/lib/dpkg", true},
+		{"passwd", 3, "/etc", "passwd", false},
+		{"passwd", 4, "/etc", "passwd", false},
+		{"passwd", 1, "/etc", "passwd", false},
+		{"passwd", -1, "", "passwd", false},
+		{"passwd", 0, "", "passwd", false},
+	}
+	for _, tc := range testCases {
+		got, resolved := resolveSyscallPath(tc.path, tc.dirfd, data,
+			tc.cwd, fdPath)
+		if got != tc.expected || resolved != tc.resolved {
+			t.Errorf("%q relative to arg%d in %q: expected %q %v, got %q %v",
+				tc.path, tc.dirfd, tc.cwd, tc.expected, tc.resolved,
+				got, resolved)
+		}
+	}
+}
+
+func TestSetSyscallPaths(t *testing.T) {
+	f := &syscallFilter{}
+	ev := &api.TelemetryEvent{}
+
+	// The working directory comes from the task that the event was
+	// decoded for.
+	syscall := &api.SyscallEvent{Id: 82} // rename
+	data := perf.TraceEventSampleData{
+		"path0":    "passwd",
+		"path1":    "/tmp/passwd",
+		"__task__": &Task{CWD: "/etc"},
+	}
+	f.setSyscallPaths(ev, syscall, data)
+	if syscall.Path != "/etc/passwd" || syscall.PathRelative ||
+		syscall.NewPath != "/tmp/passwd" || syscall.NewPathRelative {
+		t.Errorf("Unexpected paths %+v", syscall)
+	}
+
+	syscall = &api.SyscallEvent{Id: 87} // unlink
+	data = perf.TraceEventSampleData{"path0": "passwd"}
+	f.setSyscallPaths(ev, syscall, data)
+	if syscall.Path != "passwd" || !syscall.PathRelative {
+		t.Errorf("Expected unresolved relative path, got %+v", syscall)
+	}
+}
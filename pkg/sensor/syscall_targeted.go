@@ -0,0 +1,186 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"sort"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+	"github.com/golang/glog"
+)
+
+// The prefix of the symbols of the system call entry points on
+// syscallTableArch, the only architecture on which targeted kprobes are
+// registered. Since Linux 4.17, each of these takes only a pointer to the
+// struct pt_regs saved on entry to the kernel, so the layout used for the
+// syscall enter kprobe also applies to them.
+const syscallTargetedKprobePrefix = "__x64_sys_"
+
+// Expensive arguments, strings and the struct perf_event_attr passed to
+// perf_event_open, are fetched by the kernel every time that a kprobe fires,
+// before its filter is evaluated. When they are captured by the syscall enter
+// kprobe, every system call made on the system pays for them. Instead, the
+// system calls that need them are reported by targeted kprobes on their own
+// entry points, and the syscall enter kprobe, which captures only integers,
+// reports the rest. If a targeted kprobe cannot be registered, which is the
+// case on kernels older than 4.17, its system call falls back to being
+// reported by the syscall enter kprobe with the expensive arguments.
+
+// syscallDeepCaptureIDs adds the numbers of the system calls in ids that need
+// expensive arguments captured to deep, along with the masks of the arguments
+// that are captured as strings. argMask and capturePaths are those of the
+// filter that ids are taken from.
+func syscallDeepCaptureIDs(
+	deep map[int64]uint8,
+	ids []int64,
+	argMask uint8,
+	capturePaths bool,
+) {
+	for _, id := range ids {
+		var pathMask uint8
+		if capturePaths {
+			_, pathMask = syscallPathMasks([]int64{id})
+		}
+		pathMask |= memfdNamePathMask([]int64{id}) |
+			keyStringPathMask([]int64{id})
+		if pathMask != 0 ||
+			(id == syscallPerfEventOpenID && argMask&1 != 0) {
+			deep[id] |= pathMask
+		}
+	}
+}
+
+// excludeSyscallIDs returns an expression that matches what the specified
+// expression does, except for the specified system calls.
+func excludeSyscallIDs(expr *api.Expression, ids []int64) *api.Expression {
+	for _, id := range ids {
+		expr = expression.LogicalAnd(expr,
+			expression.NotEqual(
+				expression.Identifier("id"),
+				expression.Value(id)))
+	}
+	return expr
+}
+
+// syscallIDsExcludedByExpression returns the system call numbers excluded
+// from an expression by excludeSyscallIDs.
+func syscallIDsExcludedByExpression(expr *api.Expression) map[int64]bool {
+	excluded := make(map[int64]bool)
+	for expr.GetType() == api.Expression_LOGICAL_AND {
+		operands := expr.GetBinaryOp()
+		if operands.Rhs.GetType() == api.Expression_NE {
+			ne := operands.Rhs.GetBinaryOp()
+			v := ne.Rhs.GetValue()
+			if ne.Lhs.GetIdentifier() == "id" &&
+				v.GetType() == api.ValueType_SINT64 {
+				excluded[v.GetSignedValue()] = true
+			}
+		}
+		expr = operands.Lhs
+	}
+	return excluded
+}
+
+// registerSyscallEnterEvents registers the kprobes reporting the system call
+// enter events of a subscription. deep holds the system calls that need
+// expensive arguments captured; see syscallDeepCaptureIDs.
+func (f *syscallFilter) registerSyscallEnterEvents(
+	subscr *subscription,
+	filter *api.Expression,
+	argMask uint8,
+	deep map[int64]uint8,
+) {
+	ids := make([]int64, 0, len(deep))
+	for id := range deep {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var (
+		targeted []int64
+		pathMask uint8
+	)
+	for _, id := range ids {
+		if f.registerTargetedEnterKprobe(subscr, filter, id, argMask,
+			deep[id]) != nil {
+			targeted = append(targeted, id)
+		} else {
+			pathMask |= deep[id]
+		}
+	}
+
+	f.registerEnterKprobe(subscr, excludeSyscallIDs(filter, targeted),
+		argMask, pathMask)
+}
+
+// registerTargetedEnterKprobe registers a kprobe reporting the enter events of
+// a single system call, including its expensive arguments. Failure is not
+// reported to the subscription, because the caller falls back to reporting
+// the system call with the syscall enter kprobe.
+func (f *syscallFilter) registerTargetedEnterKprobe(
+	subscr *subscription,
+	filter *api.Expression,
+	id int64,
+	argMask, pathMask uint8,
+) *eventSink {
+	sensor := f.sensor
+	layout := sensor.syscallEnterLayout
+	if layout == nil || !sensor.syscallTablesSupported() {
+		return nil
+	}
+
+	name, ok := sys.SyscallName(sensor.machineArch, id)
+	if !ok {
+		return nil
+	}
+
+	fetchargs := layout.fetchargs(argMask)
+	if pathMask != 0 {
+		fetchargs += " " + layout.pathFetchargs(pathMask)
+	}
+	if id == syscallPerfEventOpenID && argMask&1 != 0 {
+		fetchargs += " " + layout.perfEventAttrFetchargs()
+	}
+
+	kprobeSymbol := syscallTargetedKprobePrefix + name
+	eventID, err := sensor.RegisterKprobe(
+		kprobeSymbol, false,
+		fetchargs,
+		f.decodeSyscallTraceEnter,
+		perf.WithEventAttr(subscr.eventAttr),
+		perf.WithEventGroup(subscr.eventGroupID))
+	if err != nil {
+		glog.V(1).Infof("Could not register targeted syscall enter kprobe %s, capturing its arguments with the syscall enter kprobe: %v",
+			kprobeSymbol, err)
+		return nil
+	}
+
+	filterTypes, derivedTypes := sensor.syscallArgPolicy.enterEventTypes()
+	es, err := subscr.addDerivedEventSink(eventID, filter,
+		filterTypes, derivedTypes)
+	if err != nil {
+		glog.V(1).Infof("Invalid filter expression for targeted syscall enter kprobe %s: %v",
+			kprobeSymbol, err)
+		sensor.Monitor.UnregisterEvent(eventID)
+		return nil
+	}
+	glog.V(2).Infof("Capturing %s arguments with targeted kprobe %s",
+		name, kprobeSymbol)
+	return es
+}
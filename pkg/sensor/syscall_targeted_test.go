@@ -0,0 +1,69 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+)
+
+func TestSyscallDeepCaptureIDs(t *testing.T) {
+	// read, renameat, memfd_create, and perf_event_open
+	ids := []int64{0, 264, syscallMemfdCreateID, syscallPerfEventOpenID}
+
+	deep := make(map[int64]uint8)
+	syscallDeepCaptureIDs(deep, ids, syscallArgMaskAll, false)
+	if len(deep) != 2 || deep[syscallMemfdCreateID] != 1<<0 ||
+		deep[syscallPerfEventOpenID] != 0 {
+		t.Errorf("Unexpected deep capture IDs %v", deep)
+	}
+
+	deep = make(map[int64]uint8)
+	syscallDeepCaptureIDs(deep, ids, 1<<1, true)
+	if len(deep) != 2 || deep[264] != 1<<1|1<<3 ||
+		deep[syscallMemfdCreateID] != 1<<0 {
+		t.Errorf("Unexpected deep capture IDs %v", deep)
+	}
+}
+
+func TestExcludeSyscallIDs(t *testing.T) {
+	filter := syscallIDFilterFromExpression(
+		expression.LogicalOr(
+			expression.Equal(
+				expression.Identifier("id"),
+				expression.Value(int64(0))),
+			expression.Equal(
+				expression.Identifier("id"),
+				expression.Value(int64(syscallPerfEventOpenID)))))
+	if !perfEventAttrsReferenced(filter) {
+		t.Error("Expected perf_event_open to be referenced")
+	}
+
+	if got := excludeSyscallIDs(filter, nil); got != filter {
+		t.Errorf("Expected filter to be unchanged, got %v", got)
+	}
+
+	filter = excludeSyscallIDs(filter,
+		[]int64{264, syscallPerfEventOpenID})
+	excluded := syscallIDsExcludedByExpression(filter)
+	if len(excluded) != 2 || !excluded[264] ||
+		!excluded[syscallPerfEventOpenID] {
+		t.Errorf("Unexpected excluded IDs %v", excluded)
+	}
+	if perfEventAttrsReferenced(filter) {
+		t.Error("Expected excluded perf_event_open not to be referenced")
+	}
+}
@@ -0,0 +1,735 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/btf"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+	"github.com/capsule8/capsule8/pkg/sys/proc"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	"google.golang.org/genproto/googleapis/rpc/code"
+)
+
+func TestSyscallEnterLayoutFetchargs(t *testing.T) {
+	type testCase struct {
+		argMask   uint8
+		fetchargs string
+	}
+	testCases := []testCase{
+		{0, "id=+120(%di):s64"},
+		{1 << 0, "id=+120(%di):s64 arg0=+112(%di):u64"},
+		{1<<1 | 1<<5, "id=+120(%di):s64 arg1=+104(%di):u64 arg5=+64(%di):u64"},
+		{syscallArgMaskAll, "id=+120(%di):s64 arg0=+112(%di):u64 " +
+			"arg1=+104(%di):u64 arg2=+96(%di):u64 arg3=+56(%di):u64 " +
+			"arg4=+72(%di):u64 arg5=+64(%di):u64"},
+	}
+	for _, tc := range testCases {
+		got := syscallEnterLayoutX86_64.fetchargs(tc.argMask)
+		if got != tc.fetchargs {
+			t.Errorf("argMask %#x: expected %q, got %q",
+				tc.argMask, tc.fetchargs, got)
+		}
+	}
+}
+
+func TestSyscallEnterLayoutArch(t *testing.T) {
+	testCases := []struct {
+		arch      string
+		fetchargs string
+	}{
+		{"x86_64", "id=+120(%di):s64 arg0=+112(%di):u64 " +
+			"arg1=+104(%di):u64 arg2=+96(%di):u64 arg3=+56(%di):u64 " +
+			"arg4=+72(%di):u64 arg5=+64(%di):u64"},
+		{"aarch64", "id=+64(%x0):s64 arg0=+0(%x0):u64 " +
+			"arg1=+8(%x0):u64 arg2=+16(%x0):u64 arg3=+24(%x0):u64 " +
+			"arg4=+32(%x0):u64 arg5=+40(%x0):u64"},
+		{"arm64", "id=+64(%x0):s64 arg0=+0(%x0):u64 " +
+			"arg1=+8(%x0):u64 arg2=+16(%x0):u64 arg3=+24(%x0):u64 " +
+			"arg4=+32(%x0):u64 arg5=+40(%x0):u64"},
+	}
+	for _, tc := range testCases {
+		layout := builtinSyscallEnterLayout(tc.arch)
+		if layout == nil {
+			t.Errorf("%s: expected a layout", tc.arch)
+			continue
+		}
+		got := layout.fetchargs(syscallArgMaskAll)
+		if got != tc.fetchargs {
+			t.Errorf("%s: expected %q, got %q", tc.arch, tc.fetchargs, got)
+		}
+	}
+
+	for _, arch := range []string{"", "i686", "armv7l", "ppc64le", "s390x"} {
+		if layout := newSyscallEnterLayout(arch, nil); layout != nil {
+			t.Errorf("%q: expected no layout, got %+v", arch, layout)
+		}
+	}
+	if layout := newSyscallEnterLayout("aarch64", &btf.Spec{}); layout != &syscallEnterLayoutArm64 {
+		t.Errorf("Expected built-in arm64 layout, got %+v", layout)
+	}
+	if layout := newSyscallEnterLayout("x86_64", nil); layout != &syscallEnterLayoutX86_64 {
+		t.Errorf("Expected built-in x86_64 layout without BTF, got %+v", layout)
+	}
+
+	// Offsets are only derived from BTF for layouts that name their
+	// members, and only if all of the members are found
+	if layout := newSyscallEnterLayoutFromBTF(&syscallEnterLayoutArm64,
+		&btf.Spec{}); layout != nil {
+		t.Errorf("Expected no arm64 layout from BTF, got %+v", layout)
+	}
+	if layout := newSyscallEnterLayoutFromBTF(&syscallEnterLayoutX86_64,
+		&btf.Spec{}); layout != nil {
+		t.Errorf("Expected no layout from empty BTF, got %+v", layout)
+	}
+
+	// Unsupported architectures report the enter kprobe as unimplemented
+	// rather than registering it
+	f := syscallFilter{sensor: &Sensor{machineArch: "s390x"}}
+	s := newSubscription(f.sensor, 1, nil)
+	if es := f.registerEnterKprobe(s, nil, syscallArgMaskAll, 0); es != nil {
+		t.Error("Expected no event sink")
+	}
+	status := s.takeStatus()
+	if len(status) != 1 || status[0].Code != int32(code.Code_UNIMPLEMENTED) {
+		t.Errorf("Expected UNIMPLEMENTED status, got %v", status)
+	}
+}
+
+func TestSyscallTablesUnsupported(t *testing.T) {
+	// System call numbers differ between architectures, so the fields
+	// derived from particular system calls are only reported on the
+	// architecture whose numbers the sensor knows.
+	sensor := &Sensor{machineArch: "aarch64"}
+	if sensor.syscallTablesSupported() {
+		t.Error("Expected aarch64 system call tables to be unsupported")
+	}
+	if !(&Sensor{machineArch: "x86_64"}).syscallTablesSupported() {
+		t.Error("Expected x86_64 system call tables to be supported")
+	}
+
+	if !syscallDerivedFieldsReferenced(expression.Equal(
+		expression.Identifier("child_pid"), expression.Value(int32(1)))) {
+		t.Error("Expected child_pid to be a derived field")
+	}
+	if syscallDerivedFieldsReferenced(expression.Equal(
+		expression.Identifier("ret"), expression.Value(int64(0)))) {
+		t.Error("Expected ret not to be a derived field")
+	}
+
+	// Filters referring to derived fields are reported as unimplemented,
+	// as is fileless execution detection, which relies on them.
+	sensor = &Sensor{machineArch: "s390x"}
+	s := newSubscription(sensor, 1, nil)
+	registerSyscallEvents(sensor, s, []*api.SyscallEventFilter{
+		{
+			Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER,
+			FilterExpression: expression.LogicalAnd(
+				expression.Equal(
+					expression.Identifier("id"),
+					expression.Value(int64(59))),
+				expression.Equal(
+					expression.Identifier("path"),
+					expression.Value("/bin/sh"))),
+		},
+	})
+	expected := "System call specific fields are not supported on s390x"
+	status := s.takeStatus()
+	if len(status) == 0 || status[0].Message != expected ||
+		status[0].Code != int32(code.Code_UNIMPLEMENTED) {
+		t.Errorf("Expected %q, got %v", expected, status)
+	}
+
+	registerFilelessExecutionEvents(sensor, s,
+		[]*api.FilelessExecutionFilter{{}})
+	expected = "Fileless execution events are not supported on s390x"
+	status = s.takeStatus()
+	if len(status) != 1 || status[0].Message != expected {
+		t.Errorf("Expected %q, got %v", expected, status)
+	}
+}
+
+func TestSyscallArgMaskFromExpression(t *testing.T) {
+	expr := expression.LogicalAnd(
+		expression.Equal(
+			expression.Identifier("id"),
+			expression.Value(int64(2))),
+		expression.LogicalOr(
+			expression.Equal(
+				expression.Identifier("arg1"),
+				expression.Value(uint64(0))),
+			expression.IsNull(expression.Identifier("arg4"))))
+
+	if mask := syscallArgMaskFromExpression(expr); mask != 1<<1|1<<4 {
+		t.Errorf("Expected arg mask %#x, got %#x", 1<<1|1<<4, mask)
+	}
+	if mask := syscallArgMaskFromExpression(nil); mask != 0 {
+		t.Errorf("Expected arg mask 0 for nil expression, got %#x", mask)
+	}
+}
+
+func TestPtraceArgMaskAndRequestName(t *testing.T) {
+	expr := expression.Equal(
+		expression.Identifier("ptrace_request"),
+		expression.Value("PTRACE_ATTACH"))
+	if mask := syscallArgMaskFromExpression(expr); mask != 1<<0 {
+		t.Errorf("Expected arg mask %#x, got %#x", 1<<0, mask)
+	}
+	expr = expression.Equal(
+		expression.Identifier("ptrace_target_pid"),
+		expression.Value(int32(1)))
+	if mask := syscallArgMaskFromExpression(expr); mask != 1<<0|1<<1 {
+		t.Errorf("Expected arg mask %#x, got %#x", 1<<0|1<<1, mask)
+	}
+
+	for request, name := range map[uint64]string{
+		16:     "PTRACE_ATTACH",
+		0x4206: "PTRACE_SEIZE",
+		1000:   "1000",
+	} {
+		if got := ptraceRequestName(request); got != name {
+			t.Errorf("Expected %q for request %d, got %q", name,
+				request, got)
+		}
+	}
+}
+
+// pidNamespaceTestFS is a proc.FileSystem that only knows the pids and pid
+// namespaces of tasks, which are all thread group leaders.
+type pidNamespaceTestFS struct {
+	proc.FileSystem
+	nspids     map[int][]int
+	namespaces map[int]uint64
+}
+
+func (fs *pidNamespaceTestFS) ReadTaskStatus(tgid, pid int, i interface{}) error {
+	nspids, ok := fs.nspids[pid]
+	if !ok {
+		return errors.New("task exited")
+	}
+	reflect.ValueOf(i).Elem().FieldByName("NSpid").Set(
+		reflect.ValueOf(nspids))
+	return nil
+}
+
+func (fs *pidNamespaceTestFS) TaskNamespaces(tgid, pid int) (map[string]uint64, error) {
+	return map[string]uint64{"pid": fs.namespaces[pid]}, nil
+}
+
+func (fs *pidNamespaceTestFS) WalkTasks(walkFunc proc.TaskWalkFunc) error {
+	for pid := range fs.nspids {
+		if !walkFunc(pid, pid) {
+			break
+		}
+	}
+	return nil
+}
+
+func TestPtraceTarget(t *testing.T) {
+	oldProcFS := procFS
+	procFS = &pidNamespaceTestFS{
+		nspids: map[int][]int{
+			10: {10},
+			11: {11, 1},
+			12: {12, 2},
+			13: {13, 2},
+		},
+		namespaces: map[int]uint64{10: 1, 11: 2, 12: 2, 13: 3},
+	}
+	defer func() { procFS = oldProcFS }()
+
+	pc := &ProcessInfoCache{
+		cache:  newArrayTaskCache(32),
+		maxPID: 32,
+	}
+	for pid, command := range map[int]string{
+		10: "gdb", 11: "strace", 12: "target", 13: "other",
+	} {
+		pc.LookupTask(pid).Command = command
+	}
+	f := &syscallFilter{sensor: &Sensor{ProcessCache: pc}}
+
+	type testCase struct {
+		callerPid int32
+		pid       uint64
+		command   string
+	}
+	testCases := []testCase{
+		{10, 0xffffffffffffffff, ""},
+		{10, 0, ""},
+		{10, 33, ""},
+		{0, 12, ""},
+		{10, 12, "target"},
+		{11, 2, "target"},
+		{11, 3, ""},
+	}
+	for _, tc := range testCases {
+		ev := &api.TelemetryEvent{ProcessPid: tc.callerPid}
+		syscall := &api.SyscallEvent{Id: syscallPtraceID}
+		data := perf.TraceEventSampleData{
+			"arg0": uint64(16),
+			"arg1": tc.pid,
+		}
+		f.setPtraceTarget(ev, syscall, data)
+		if syscall.PtraceRequest != "PTRACE_ATTACH" ||
+			syscall.PtraceTargetPid != int32(tc.pid) ||
+			syscall.PtraceTargetCommand != tc.command {
+			t.Errorf("Caller %d, pid %d: unexpected ptrace decoding %+v",
+				tc.callerPid, int32(tc.pid), syscall)
+		}
+	}
+}
+
+func newTestSyscallEvent(
+	pid int32,
+	monotime int64,
+	syscall *api.SyscallEvent,
+) *api.TelemetryEvent {
+	return &api.TelemetryEvent{
+		ProcessPid:          pid,
+		SensorMonotimeNanos: monotime,
+		Event: &api.TelemetryEvent_Syscall{
+			Syscall: syscall,
+		},
+	}
+}
+
+func TestSyscallCompleteTracker(t *testing.T) {
+	var events []*api.TelemetryEvent
+	dispatchFn := func(e *api.TelemetryEvent) {
+		events = append(events, e)
+	}
+
+	tracker := newSyscallCompleteTracker(dispatchFn, false, true)
+	tracker.enter(newTestSyscallEvent(100, 1000, &api.SyscallEvent{
+		Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER,
+		Id:   0,
+		Arg0: 3,
+	}))
+	tracker.exit(newTestSyscallEvent(100, 1500, &api.SyscallEvent{
+		Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT,
+		Id:   0,
+		Ret:  42,
+	}))
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+	syscall := events[0].GetSyscall()
+	if syscall.Type != api.SyscallEventType_SYSCALL_EVENT_TYPE_COMPLETE ||
+		syscall.Arg0 != 3 || syscall.Ret != 42 ||
+		syscall.DurationNanos != 500 || syscall.Partial {
+		t.Errorf("Unexpected complete event: %+v", syscall)
+	}
+
+	// Orphans are dropped
+	events = nil
+	tracker.exit(newTestSyscallEvent(100, 2000, &api.SyscallEvent{
+		Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT,
+		Id:   0,
+	}))
+	tracker.enter(newTestSyscallEvent(100, 3000, &api.SyscallEvent{
+		Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER,
+		Id:   0,
+	}))
+	tracker.expire(4000)
+	if len(events) != 0 {
+		t.Errorf("Expected orphans to be dropped, got %d events", len(events))
+	}
+}
+
+func TestSyscallCompleteTrackerPartial(t *testing.T) {
+	var events []*api.TelemetryEvent
+	dispatchFn := func(e *api.TelemetryEvent) {
+		events = append(events, e)
+	}
+
+	tracker := newSyscallCompleteTracker(dispatchFn, true, true)
+
+	// Unmatched exit
+	tracker.exit(newTestSyscallEvent(100, 1000, &api.SyscallEvent{
+		Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT,
+		Id:   1,
+		Ret:  -1,
+	}))
+
+	// Enter superseded by another enter from the same task
+	tracker.enter(newTestSyscallEvent(100, 2000, &api.SyscallEvent{
+		Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER,
+		Id:   60,
+	}))
+	tracker.enter(newTestSyscallEvent(100, 3000, &api.SyscallEvent{
+		Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER,
+		Id:   1,
+	}))
+
+	// Enter that times out
+	tracker.expire(3001)
+
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(events))
+	}
+	for i, id := range []int64{1, 60, 1} {
+		syscall := events[i].GetSyscall()
+		if syscall.Type != api.SyscallEventType_SYSCALL_EVENT_TYPE_COMPLETE ||
+			!syscall.Partial || syscall.Id != id {
+			t.Errorf("Unexpected partial event %d: %+v", i, syscall)
+		}
+	}
+}
+
+func TestSyscallCompleteTrackerCausedBy(t *testing.T) {
+	var events []*api.TelemetryEvent
+	dispatchFn := func(e *api.TelemetryEvent) {
+		events = append(events, e)
+	}
+
+	tracker := newSyscallCompleteTracker(dispatchFn, true, true)
+	tracker.causedBy = true
+
+	enter := newTestSyscallEvent(100, 1000, &api.SyscallEvent{
+		Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER,
+		Id:   0,
+	})
+	enter.SensorSequenceNumber = 7
+	exit := newTestSyscallEvent(100, 1500, &api.SyscallEvent{
+		Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT,
+		Id:   0,
+	})
+	exit.SensorSequenceNumber = 9
+	tracker.enter(enter)
+	tracker.exit(exit)
+
+	// Unmatched exit
+	exit.SensorSequenceNumber = 12
+	tracker.exit(exit)
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	for i, expected := range [][]uint64{{7, 9}, {12}} {
+		if !reflect.DeepEqual(events[i].CausedBy, expected) {
+			t.Errorf("Event %d: expected caused_by %v, got %v", i,
+				expected, events[i].CausedBy)
+		}
+	}
+	if enter.CausedBy != nil || exit.CausedBy != nil {
+		t.Error("Contributing events were modified")
+	}
+}
+
+func TestSyscallCompleteTrackerExitFilter(t *testing.T) {
+	var events []*api.TelemetryEvent
+	dispatchFn := func(e *api.TelemetryEvent) {
+		events = append(events, e)
+	}
+
+	_, exitFilter, err := splitSyscallCompleteFilter(expression.LogicalAnd(
+		expression.Equal(
+			expression.Identifier("id"), expression.Value(int64(0))),
+		expression.GreaterThan(
+			expression.Identifier("bytes"), expression.Value(uint64(10)))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracker := newSyscallCompleteTracker(dispatchFn, true, true)
+	tracker.syscallTables = true
+	if tracker.exitFilter, err = expression.NewExpression(exitFilter); err != nil {
+		t.Fatal(err)
+	}
+
+	// Exits that do not pass the exit filter drop their enter, rather
+	// than leaving it to be orphaned
+	for _, ret := range []int64{5, 50} {
+		tracker.enter(newTestSyscallEvent(100, 1000, &api.SyscallEvent{
+			Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER,
+			Id:   0,
+		}))
+		tracker.exit(newTestSyscallEvent(100, 1500, &api.SyscallEvent{
+			Type:  api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT,
+			Id:    0,
+			Ret:   ret,
+			Bytes: uint64(ret),
+		}))
+	}
+	tracker.expire(2000)
+	if len(events) != 1 || events[0].GetSyscall().Ret != 50 ||
+		events[0].GetSyscall().Partial {
+		t.Fatalf("Expected 1 complete event for 50 bytes, got %+v", events)
+	}
+
+	// Tasks are matched by tid, and enters of unknown tasks cannot be
+	// matched at all
+	events = nil
+	tracker.enter(newTestSyscallEvent(0, 3000, &api.SyscallEvent{
+		Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER,
+		Id:   0,
+	}))
+	if len(events) != 1 || !events[0].GetSyscall().Partial {
+		t.Fatalf("Expected a partial event for an unknown task, got %+v",
+			events)
+	}
+	if len(tracker.pending) != 0 {
+		t.Errorf("Expected no pending enters, got %v", tracker.pending)
+	}
+}
+
+func TestSplitSyscallCompleteFilter(t *testing.T) {
+	id := expression.Equal(
+		expression.Identifier("id"), expression.Value(int64(0)))
+	arg := expression.Equal(
+		expression.Identifier("arg0"), expression.Value(uint64(3)))
+	ret := expression.LessThan(
+		expression.Identifier("ret"), expression.Value(int64(0)))
+
+	enter, exit, err := splitSyscallCompleteFilter(
+		expression.LogicalAnd(expression.LogicalAnd(id, ret), arg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(enter, expression.LogicalAnd(id, arg)) {
+		t.Errorf("Unexpected enter filter %v", enter)
+	}
+	if !reflect.DeepEqual(exit, ret) {
+		t.Errorf("Unexpected exit filter %v", exit)
+	}
+
+	// Exit predicates may refer to id, but not to enter fields
+	idRet := expression.LogicalOr(id, ret)
+	if _, exit, err = splitSyscallCompleteFilter(idRet); err != nil ||
+		!reflect.DeepEqual(exit, idRet) {
+		t.Errorf("Unexpected exit filter %v: %v", exit, err)
+	}
+	if _, _, err = splitSyscallCompleteFilter(
+		expression.LogicalOr(arg, ret)); err == nil {
+		t.Error("Expected error for predicate on both arg0 and ret")
+	}
+}
+
+func TestSyscallIDFilterFromExpression(t *testing.T) {
+	expr := expression.LogicalOr(
+		expression.LogicalAnd(
+			expression.Equal(
+				expression.Identifier("id"),
+				expression.Value(int64(0))),
+			expression.Equal(
+				expression.Identifier("arg0"),
+				expression.Value(uint64(3)))),
+		expression.LogicalOr(
+			expression.Equal(
+				expression.Identifier("id"),
+				expression.Value(int64(1))),
+			expression.Equal(
+				expression.Identifier("id"),
+				expression.Value(int64(0)))))
+
+	idFilter := syscallIDFilterFromExpression(expr)
+	e, err := expression.NewExpression(idFilter)
+	if err != nil {
+		t.Fatalf("Invalid id filter: %v", err)
+	}
+	if s := e.KernelFilterString(); s != "id == 0 || id == 1" {
+		t.Errorf("Unexpected id filter %q", s)
+	}
+}
+
+func TestLookupClonedTask(t *testing.T) {
+	oldProcFS := procFS
+	procFS = &pidNamespaceTestFS{
+		nspids: map[int][]int{
+			10: {10},
+			11: {11, 1},
+			12: {12, 2},
+			13: {13, 3},
+		},
+		namespaces: map[int]uint64{10: 1, 11: 2, 12: 2, 13: 2},
+	}
+	defer func() { procFS = oldProcFS }()
+
+	pc := &ProcessInfoCache{
+		cache:  newArrayTaskCache(32),
+		maxPID: 32,
+	}
+	for _, pid := range []int{10, 11, 12, 13} {
+		pc.LookupTask(pid).TGID = pid
+	}
+
+	// A task in the initial pid namespace sees the host pid.
+	pc.LookupTask(10).clonedChild = pc.LookupTask(11)
+	if c := pc.LookupClonedTask(10, 10, 11); c != pc.LookupTask(11) {
+		t.Errorf("Expected task 11, got %+v", c)
+	}
+
+	// A task in a child pid namespace sees its own pid for the child,
+	// which must not be mistaken for the task with that host pid.
+	pc.LookupTask(11).clonedChild = pc.LookupTask(12)
+	if c := pc.LookupClonedTask(11, 11, 2); c != pc.LookupTask(12) {
+		t.Errorf("Expected task 12, got %+v", c)
+	}
+
+	// A child that was not seen to be cloned is resolved through procfs.
+	pc.LookupTask(11).clonedChild = nil
+	if c := pc.LookupClonedTask(11, 11, 3); c != pc.LookupTask(13) {
+		t.Errorf("Expected task 13, got %+v", c)
+	}
+	if c := pc.LookupClonedTask(11, 11, 4); c != nil {
+		t.Errorf("Expected no task, got %+v", c)
+	}
+}
+
+func TestSyscallCloneLinkageExpression(t *testing.T) {
+	types := expression.FieldTypeMap{
+		"id":  expression.ValueTypeSignedInt64,
+		"ret": expression.ValueTypeSignedInt64,
+	}
+	expr, err := expression.NewExpression(syscallCloneLinkageExpression())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = expr.Validate(types); err != nil {
+		t.Fatal(err)
+	}
+
+	type testCase struct {
+		id, ret int64
+		match   bool
+	}
+	testCases := []testCase{
+		{56, 1234, true},
+		{57, 1234, true},
+		{58, 1234, true},
+		{435, 1234, true},
+		{56, 0, false},
+		{56, -11, false},
+		{59, 1234, false},
+	}
+	for _, tc := range testCases {
+		v, err := expr.Evaluate(types, expression.FieldValueMap{
+			"id": tc.id, "ret": tc.ret,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expression.IsValueTrue(v) != tc.match {
+			t.Errorf("Expected %v for id %d ret %d", tc.match, tc.id, tc.ret)
+		}
+	}
+}
+
+func TestRewriteSyscallEventFilterDeprecatedFields(t *testing.T) {
+	sef := &api.SyscallEventFilter{
+		Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER,
+		Id:   &wrappers.Int64Value{Value: 59},
+		Arg1: &wrappers.UInt64Value{Value: 2},
+		Ret:  &wrappers.Int64Value{Value: 0},
+	}
+	deprecated := rewriteSyscallEventFilter(sef)
+	if sef.Id != nil || sef.Arg1 != nil {
+		t.Errorf("Expected deprecated fields to be cleared, got %+v", sef)
+	}
+	if len(deprecated) != 2 ||
+		deprecated[0].name != "Id" || deprecated[1].name != "Arg1" {
+		t.Fatalf("Expected Id and Arg1 to be reported, got %+v", deprecated)
+	}
+
+	s := newSubscription(nil, 1, nil)
+	warnDeprecatedSyscallFields(s, deprecated)
+	status := s.takeStatus()
+	if len(status) != 2 {
+		t.Fatalf("Expected 2 status messages, got %v", status)
+	}
+	expected := "SyscallEventFilter field Arg1 is deprecated; use the filter expression arg1 = 2 instead"
+	if status[1].Message != expected {
+		t.Errorf("Expected status %q, got %q", expected, status[1].Message)
+	}
+
+	if deprecated = rewriteSyscallEventFilter(sef); len(deprecated) != 0 {
+		t.Errorf("Expected no deprecated fields, got %+v", deprecated)
+	}
+}
+
+func TestSetMemoryProtection(t *testing.T) {
+	syscall := &api.SyscallEvent{Id: syscallMmapID}
+	data := perf.TraceEventSampleData{
+		"arg2": uint64(protRead | protWrite | protExec),
+		"arg3": uint64(0x22),
+	}
+	setMemoryProtection(syscall, data)
+	if syscall.Prot != "PROT_READ|PROT_WRITE|PROT_EXEC" ||
+		!syscall.WritableAndExecutable ||
+		syscall.MmapFlags != "MAP_PRIVATE|MAP_ANONYMOUS" {
+		t.Errorf("Unexpected mmap decoding %+v", syscall)
+	}
+	if data["writable_and_executable"] != true {
+		t.Errorf("Expected writable_and_executable in data, got %v", data)
+	}
+
+	// mprotect has no flags argument
+	syscall = &api.SyscallEvent{Id: syscallMprotectID}
+	data = perf.TraceEventSampleData{
+		"arg2": uint64(protRead | 0x100),
+		"arg3": uint64(0x22),
+	}
+	setMemoryProtection(syscall, data)
+	if syscall.Prot != "PROT_READ|0x100" || syscall.WritableAndExecutable ||
+		syscall.MmapFlags != "" {
+		t.Errorf("Unexpected mprotect decoding %+v", syscall)
+	}
+
+	if s := protString(0); s != "PROT_NONE" {
+		t.Errorf("Expected PROT_NONE, got %q", s)
+	}
+
+	expr := expression.Equal(
+		expression.Identifier("writable_and_executable"),
+		expression.Value(true))
+	if mask := syscallArgMaskFromExpression(expr); mask != 1<<2 {
+		t.Errorf("Expected arg mask %#x, got %#x", 1<<2, mask)
+	}
+}
+
+func TestSyscallArgStatus(t *testing.T) {
+	data := perf.TraceEventSampleData{
+		"arg0":  uint64(0),
+		"arg2":  uint64(4096),
+		"path1": "",
+	}
+	status := syscallArgStatus(data)
+	captured := api.SyscallArgStatus_SYSCALL_ARG_STATUS_CAPTURED
+	notRequested := api.SyscallArgStatus_SYSCALL_ARG_STATUS_NOT_REQUESTED
+	expected := []api.SyscallArgStatus{
+		captured, notRequested, captured,
+		notRequested, notRequested, notRequested,
+	}
+	if !reflect.DeepEqual(status, expected) {
+		t.Errorf("Expected %v, got %v", expected, status)
+	}
+
+	if s := syscallPathStatus(data, "path1"); s != captured {
+		t.Errorf("Expected path1 to be captured, got %v", s)
+	}
+	data[perf.TraceEventFaultedFields] = map[string]bool{"path1": true}
+	if s := syscallPathStatus(data, "path1"); s !=
+		api.SyscallArgStatus_SYSCALL_ARG_STATUS_FAULTED {
+		t.Errorf("Expected path1 to be faulted, got %v", s)
+	}
+}
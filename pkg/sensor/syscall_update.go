@@ -0,0 +1,243 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+
+	"github.com/golang/glog"
+)
+
+// maxSyscallID is one more than the largest system call id that may be
+// added to or removed from a running subscription.
+const maxSyscallID = 4096
+
+// syscallIDUpdate is the state of the kernel filter of a syscall event sink
+// whose system call ids have been updated by UpdateSyscallIDs. The filter is
+// rebuilt from it on each update, so that it does not grow with the number
+// of updates and the ids added never bypass the rest of the filter.
+type syscallIDUpdate struct {
+	// base is the conjunction of the predicates of the original filter
+	// that refer to fields other than the system call id, including the
+	// default filter. It is ANDed with the ids of every update.
+	base *api.Expression
+
+	// ids is the conjunction of the predicates of the original filter that
+	// refer only to the system call id; nil if it matched all ids.
+	ids *api.Expression
+
+	// The system call ids added to or removed from ids so far.
+	added   map[int64]bool
+	removed map[int64]bool
+}
+
+// conjunctionTerms appends the operands of the top-level logical ANDs of an
+// expression to terms.
+func conjunctionTerms(expr *api.Expression, terms []*api.Expression) []*api.Expression {
+	if expr.GetType() == api.Expression_LOGICAL_AND {
+		operands := expr.GetBinaryOp()
+		terms = conjunctionTerms(operands.Lhs, terms)
+		return conjunctionTerms(operands.Rhs, terms)
+	}
+	return append(terms, expr)
+}
+
+// newSyscallIDUpdate splits the kernel filter of a syscall event sink into
+// the predicates on the system call id and the rest. It fails if the filter
+// is not a conjunction with at least one predicate on the id alone.
+func newSyscallIDUpdate(filter *api.Expression) (*syscallIDUpdate, error) {
+	u := &syscallIDUpdate{
+		added:   make(map[int64]bool),
+		removed: make(map[int64]bool),
+	}
+	if filter == nil {
+		return u, nil
+	}
+	for _, term := range conjunctionTerms(filter, nil) {
+		idOnly := true
+		walkExpressionIdentifiers(term, func(ident string) {
+			if ident != "id" {
+				idOnly = false
+			}
+		})
+		if idOnly {
+			u.ids = expression.LogicalAnd(u.ids, term)
+		} else {
+			u.base = expression.LogicalAnd(u.base, term)
+		}
+	}
+	if u.ids == nil {
+		return nil, errors.New("Syscall filter has no range of ids")
+	}
+	return u, nil
+}
+
+func sortedSyscallIDs(ids map[int64]bool) []int64 {
+	sorted := make([]int64, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+// update returns the state with the system calls in add also matched and
+// those in remove never matched, in addition to the changes of previous
+// updates.
+func (u *syscallIDUpdate) update(add, remove []int64) *syscallIDUpdate {
+	next := &syscallIDUpdate{
+		base:    u.base,
+		ids:     u.ids,
+		added:   make(map[int64]bool, len(u.added)+len(add)),
+		removed: make(map[int64]bool, len(u.removed)+len(remove)),
+	}
+	for id := range u.added {
+		next.added[id] = true
+	}
+	for id := range u.removed {
+		next.removed[id] = true
+	}
+	for _, id := range add {
+		delete(next.removed, id)
+		next.added[id] = true
+	}
+	for _, id := range remove {
+		delete(next.added, id)
+		next.removed[id] = true
+	}
+	return next
+}
+
+// filter returns the kernel filter for the state. Changes to the ids never
+// change the predicates on other fields. A nil filter matches all system
+// calls.
+func (u *syscallIDUpdate) filter() *api.Expression {
+	ids := u.ids
+	if ids != nil {
+		for _, id := range sortedSyscallIDs(u.added) {
+			ids = expression.LogicalOr(ids,
+				expression.Equal(
+					expression.Identifier("id"),
+					expression.Value(id)))
+		}
+	}
+	for _, id := range sortedSyscallIDs(u.removed) {
+		ids = expression.LogicalAnd(ids,
+			expression.NotEqual(
+				expression.Identifier("id"),
+				expression.Value(id)))
+	}
+	return expression.LogicalAnd(u.base, ids)
+}
+
+func validateSyscallIDs(add, remove []int64) error {
+	added := make(map[int64]bool, len(add))
+	for _, id := range add {
+		if id < 0 || id >= maxSyscallID {
+			return fmt.Errorf("Invalid syscall id %d", id)
+		}
+		added[id] = true
+	}
+	for _, id := range remove {
+		if id < 0 || id >= maxSyscallID {
+			return fmt.Errorf("Invalid syscall id %d", id)
+		}
+		if added[id] {
+			return fmt.Errorf("Syscall id %d both added and removed", id)
+		}
+	}
+	return nil
+}
+
+// UpdateSyscallIDs adds and removes system call ids from the syscall events
+// of a running subscription by setting new kernel filters on the events in
+// place, so that no events are missed while the events are recreated. The
+// subscription's syscall events must be filtered by the kernel. The new
+// kernel filters are returned.
+func (s *Sensor) UpdateSyscallIDs(
+	subscriptionID int32,
+	add, remove []int64,
+) ([]string, error) {
+	if err := validateSyscallIDs(add, remove); err != nil {
+		return nil, err
+	}
+
+	s.syscallUpdateMutex.Lock()
+	defer s.syscallUpdateMutex.Unlock()
+
+	var sinks []*eventSink
+	for _, m := range s.eventMap.getMap() {
+		es, ok := m[subscriptionID]
+		if !ok || es.eventType != "syscall" {
+			continue
+		}
+		if _, ok = es.filterTypes["id"]; !ok {
+			continue
+		}
+		if es.filter != nil {
+			return nil, fmt.Errorf("Subscription %d syscall filter requires userspace evaluation",
+				subscriptionID)
+		}
+		sinks = append(sinks, es)
+	}
+	if len(sinks) == 0 {
+		return nil, fmt.Errorf("Subscription %d has no syscall events",
+			subscriptionID)
+	}
+
+	updates := make([]*syscallIDUpdate, len(sinks))
+	for i, es := range sinks {
+		u := es.syscallIDs
+		if u == nil {
+			var err error
+			if u, err = newSyscallIDUpdate(es.kernelFilter); err != nil {
+				return nil, fmt.Errorf("Subscription %d: %v",
+					subscriptionID, err)
+			}
+		}
+		updates[i] = u.update(add, remove)
+	}
+
+	filters := make([]string, len(sinks))
+	for i, es := range sinks {
+		filter := updates[i].filter()
+		if filter == nil {
+			es.syscallIDs = updates[i]
+			continue
+		}
+		expr, err := expression.NewExpression(filter)
+		if err == nil {
+			err = expr.ValidateKernelFilter()
+		}
+		if err == nil {
+			filters[i] = expr.KernelFilterString()
+			err = s.Monitor.SetFilter(es.eventID, filters[i])
+		}
+		if err != nil {
+			return nil, err
+		}
+		es.kernelFilter = filter
+		es.syscallIDs = updates[i]
+		glog.V(1).Infof("Subscription %d: syscall filter updated to %s",
+			subscriptionID, filters[i])
+	}
+	return filters, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+)
+
+func TestUpdateSyscallIDFilter(t *testing.T) {
+	// The user's filter ANDed with a default filter
+	filter := expression.LogicalAnd(
+		expression.LogicalAnd(
+			expression.LogicalOr(
+				expression.Equal(
+					expression.Identifier("id"),
+					expression.Value(int64(59))),
+				expression.Equal(
+					expression.Identifier("id"),
+					expression.Value(int64(322)))),
+			expression.Equal(
+				expression.Identifier("arg0"),
+				expression.Value(uint64(3)))),
+		expression.NotEqual(
+			expression.Identifier("common_pid"),
+			expression.Value(int32(100))))
+
+	u, err := newSyscallIDUpdate(filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each update builds on the previous ones without growing the filter
+	// for ids that are already added or removed.
+	testCases := []struct {
+		add, remove []int64
+		expected    string
+	}{
+		{nil, nil,
+			"arg0 == 3 && common_pid != 100 && (id == 59 || id == 322)"},
+		{[]int64{2}, nil,
+			"arg0 == 3 && common_pid != 100 && (id == 59 || id == 322 || id == 2)"},
+		{[]int64{2}, nil,
+			"arg0 == 3 && common_pid != 100 && (id == 59 || id == 322 || id == 2)"},
+		{nil, []int64{59},
+			"arg0 == 3 && common_pid != 100 && ((id == 59 || id == 322 || id == 2) && id != 59)"},
+		{[]int64{59}, []int64{2},
+			"arg0 == 3 && common_pid != 100 && ((id == 59 || id == 322 || id == 59) && id != 2)"},
+	}
+	for _, tc := range testCases {
+		u = u.update(tc.add, tc.remove)
+		expr, err := expression.NewExpression(u.filter())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := expr.KernelFilterString(); s != tc.expected {
+			t.Errorf("Expected %q, got %q", tc.expected, s)
+		}
+	}
+
+	// Ids are not added to a filter that matches all of them
+	u, err = newSyscallIDUpdate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := u.update([]int64{2}, nil).filter(); f != nil {
+		t.Errorf("Expected nil filter to remain nil, got %v", f)
+	}
+
+	// A filter on other fields for some ids only has no range of ids
+	filter = expression.LogicalOr(
+		expression.LogicalAnd(
+			expression.Equal(
+				expression.Identifier("id"),
+				expression.Value(int64(59))),
+			expression.Equal(
+				expression.Identifier("arg0"),
+				expression.Value(uint64(3)))),
+		expression.Equal(
+			expression.Identifier("id"),
+			expression.Value(int64(2))))
+	if _, err = newSyscallIDUpdate(filter); err == nil {
+		t.Error("Expected filter without a range of ids to be rejected")
+	}
+}
+
+func TestValidateSyscallIDs(t *testing.T) {
+	if err := validateSyscallIDs([]int64{0, 59}, []int64{1}); err != nil {
+		t.Error(err)
+	}
+	if err := validateSyscallIDs([]int64{-1}, nil); err == nil {
+		t.Error("Expected error for negative syscall id")
+	}
+	if err := validateSyscallIDs(nil, []int64{maxSyscallID}); err == nil {
+		t.Error("Expected error for out of range syscall id")
+	}
+	if err := validateSyscallIDs([]int64{2}, []int64{2}); err == nil {
+		t.Error("Expected error for syscall id added and removed")
+	}
+}
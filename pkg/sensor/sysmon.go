@@ -0,0 +1,201 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+// The ids of the Sysmon events that Linux telemetry is mapped to
+const (
+	sysmonProcessCreate      = 1
+	sysmonNetworkConnect     = 3
+	sysmonProcessTerminate   = 5
+	sysmonProcessAccess      = 10
+	sysmonFileCreate         = 11
+	sysmonFileDeleteDetected = 26
+)
+
+var sysmonEventNames = map[int]string{
+	sysmonProcessCreate:      "Process Create",
+	sysmonNetworkConnect:     "Network connection detected",
+	sysmonProcessTerminate:   "Process terminated",
+	sysmonProcessAccess:      "Process accessed",
+	sysmonFileCreate:         "File created",
+	sysmonFileDeleteDetected: "File Delete logged",
+}
+
+// The format of Sysmon's UtcTime fields
+const sysmonTimeFormat = "2006-01-02 15:04:05.000"
+
+// The x86_64 system call numbers of unlink and unlinkat
+const (
+	syscallUnlinkID   = 87
+	syscallUnlinkatID = 263
+)
+
+// sysmonEvent is the JSON form of a Sysmon event, modeled on the System and
+// EventData sections of the events that Sysmon writes to the Windows event
+// log.
+type sysmonEvent struct {
+	EventID   int               `json:"EventID"`
+	EventName string            `json:"EventName"`
+	Computer  string            `json:"Computer,omitempty"`
+	EventData map[string]string `json:"EventData"`
+}
+
+// sysmonOutputEncoder encodes the events that have a close Sysmon
+// equivalent as single lines of Sysmon-like JSON, so that existing
+// Sysmon-based detection content can be applied to them. Other events are
+// encoded as nothing. See docs/Sysmon.md for the mapping and its
+// limitations.
+type sysmonOutputEncoder struct {
+	// now returns the time reported in each event's UtcTime, since
+	// telemetry events carry only monotonic timestamps.
+	now func() time.Time
+
+	// syscallTables is true if system call ids are those of
+	// syscallTableArch, so that unlink and unlinkat can be recognized.
+	syscallTables bool
+}
+
+func (enc sysmonOutputEncoder) Encode(event *api.TelemetryEvent) ([]byte, error) {
+	id, data := sysmonEventData(event, enc.syscallTables)
+	if id == 0 {
+		return nil, nil
+	}
+
+	data["RuleName"] = "-"
+	data["UtcTime"] = enc.now().UTC().Format(sysmonTimeFormat)
+	if _, ok := data["ProcessId"]; ok {
+		data["ProcessGuid"] = event.ProcessId
+		if len(event.ProcessLineage) > 0 {
+			if _, ok = data["Image"]; !ok {
+				data["Image"] = event.ProcessLineage[0].Command
+			}
+		}
+	}
+
+	b, err := json.Marshal(sysmonEvent{
+		EventID:   id,
+		EventName: sysmonEventNames[id],
+		Computer:  event.SensorId,
+		EventData: data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+func (sysmonOutputEncoder) Extension() string {
+	return "json"
+}
+
+// sysmonEventData returns the id of the Sysmon event that a telemetry event
+// maps to and the fields specific to it, or 0 if there is none. syscallTables
+// is as for sysmonOutputEncoder.
+func sysmonEventData(
+	event *api.TelemetryEvent,
+	syscallTables bool,
+) (int, map[string]string) {
+	pid := event.ProcessTgid
+	if pid == 0 {
+		pid = event.ProcessPid
+	}
+	data := map[string]string{
+		"ProcessId": strconv.FormatInt(int64(pid), 10),
+	}
+	if event.Credentials != nil {
+		data["User"] = strconv.FormatUint(uint64(event.Credentials.Uid), 10)
+	}
+
+	switch e := event.Event.(type) {
+	case *api.TelemetryEvent_Process:
+		switch e.Process.Type {
+		case api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC:
+			data["Image"] = e.Process.ExecFilename
+			data["CommandLine"] = strings.Join(e.Process.ExecCommandLine, " ")
+			if len(event.ProcessLineage) > 1 {
+				data["ParentProcessId"] = strconv.FormatInt(
+					int64(event.ProcessLineage[1].Pid), 10)
+				data["ParentImage"] = event.ProcessLineage[1].Command
+			}
+			if event.ParentExe != "" {
+				data["ParentImage"] = event.ParentExe
+			}
+			return sysmonProcessCreate, data
+		case api.ProcessEventType_PROCESS_EVENT_TYPE_EXIT:
+			return sysmonProcessTerminate, data
+		}
+
+	case *api.TelemetryEvent_Network:
+		if e.Network.Type != api.NetworkEventType_NETWORK_EVENT_TYPE_CONNECT_ATTEMPT {
+			break
+		}
+		ip, port, ok := inetAddress(e.Network.Address)
+		if !ok {
+			break
+		}
+		data["Initiated"] = "true"
+		if ip != nil {
+			data["DestinationIp"] = ip.String()
+		}
+		data["DestinationPort"] = port
+		data["DestinationIsIpv6"] = strconv.FormatBool(ip.To4() == nil)
+		return sysmonNetworkConnect, data
+
+	case *api.TelemetryEvent_File:
+		// O_CREAT
+		if e.File.Type != api.FileEventType_FILE_EVENT_TYPE_OPEN ||
+			e.File.OpenFlags&0100 == 0 {
+			break
+		}
+		data["TargetFilename"] = e.File.Filename
+		return sysmonFileCreate, data
+
+	case *api.TelemetryEvent_Syscall:
+		// Only complete events have both the arguments and the result.
+		s := e.Syscall
+		if s.Type != api.SyscallEventType_SYSCALL_EVENT_TYPE_COMPLETE ||
+			s.Ret < 0 {
+			break
+		}
+		switch {
+		case s.PtraceTargetPid != 0:
+			data["SourceProcessId"] = data["ProcessId"]
+			data["SourceProcessGUID"] = event.ProcessId
+			delete(data, "ProcessId")
+			if len(event.ProcessLineage) > 0 {
+				data["SourceImage"] = event.ProcessLineage[0].Command
+			}
+			data["TargetProcessId"] = strconv.FormatInt(
+				int64(s.PtraceTargetPid), 10)
+			data["TargetImage"] = s.PtraceTargetCommand
+			data["GrantedAccess"] = s.PtraceRequest
+			return sysmonProcessAccess, data
+		case syscallTables &&
+			(s.Id == syscallUnlinkID || s.Id == syscallUnlinkatID):
+			data["TargetFilename"] = s.Path
+			return sysmonFileDeleteDetected, data
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+func TestSysmonOutputEncoder(t *testing.T) {
+	enc := sysmonOutputEncoder{
+		now: func() time.Time {
+			return time.Date(2018, 3, 1, 12, 30, 15, 250e6, time.UTC)
+		},
+		syscallTables: true,
+	}
+
+	tests := []struct {
+		event *api.TelemetryEvent
+		id    int
+		data  map[string]string
+	}{
+		{
+			&api.TelemetryEvent{
+				ProcessId:   "p1",
+				ProcessTgid: 42,
+				ProcessLineage: []*api.Process{
+					{Pid: 42, Command: "ls"},
+					{Pid: 7, Command: "bash"},
+				},
+				ParentExe:   "/bin/bash",
+				Credentials: &api.Credentials{Uid: 1000},
+				Event: &api.TelemetryEvent_Process{
+					Process: &api.ProcessEvent{
+						Type:            api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC,
+						ExecFilename:    "/bin/ls",
+						ExecCommandLine: []string{"ls", "-l"},
+					},
+				},
+			},
+			sysmonProcessCreate,
+			map[string]string{
+				"RuleName":        "-",
+				"UtcTime":         "2018-03-01 12:30:15.250",
+				"ProcessGuid":     "p1",
+				"ProcessId":       "42",
+				"Image":           "/bin/ls",
+				"CommandLine":     "ls -l",
+				"ParentProcessId": "7",
+				"ParentImage":     "/bin/bash",
+				"User":            "1000",
+			},
+		},
+		{
+			&api.TelemetryEvent{
+				ProcessPid: 9,
+				Event: &api.TelemetryEvent_Network{
+					Network: &api.NetworkEvent{
+						Type: api.NetworkEventType_NETWORK_EVENT_TYPE_CONNECT_ATTEMPT,
+						Address: &api.NetworkAddress{
+							Address: &api.NetworkAddress_Ipv4Address{
+								Ipv4Address: &api.IPv4AddressAndPort{
+									Address: &api.IPv4Address{Address: 0x0100000a},
+									Port:    0xbb01,
+								},
+							},
+						},
+					},
+				},
+			},
+			sysmonNetworkConnect,
+			map[string]string{
+				"RuleName":          "-",
+				"UtcTime":           "2018-03-01 12:30:15.250",
+				"ProcessGuid":       "",
+				"ProcessId":         "9",
+				"Initiated":         "true",
+				"DestinationIp":     "10.0.0.1",
+				"DestinationPort":   "443",
+				"DestinationIsIpv6": "false",
+			},
+		},
+		{
+			&api.TelemetryEvent{
+				ProcessTgid:    3,
+				ProcessLineage: []*api.Process{{Pid: 3, Command: "gdb"}},
+				Event: &api.TelemetryEvent_Syscall{
+					Syscall: &api.SyscallEvent{
+						Type:                api.SyscallEventType_SYSCALL_EVENT_TYPE_COMPLETE,
+						Id:                  101,
+						PtraceRequest:       "PTRACE_ATTACH",
+						PtraceTargetPid:     4,
+						PtraceTargetCommand: "sshd",
+					},
+				},
+			},
+			sysmonProcessAccess,
+			map[string]string{
+				"RuleName":          "-",
+				"UtcTime":           "2018-03-01 12:30:15.250",
+				"SourceProcessGUID": "",
+				"SourceProcessId":   "3",
+				"SourceImage":       "gdb",
+				"TargetProcessId":   "4",
+				"TargetImage":       "sshd",
+				"GrantedAccess":     "PTRACE_ATTACH",
+			},
+		},
+		{
+			&api.TelemetryEvent{
+				ProcessTgid: 5,
+				Event: &api.TelemetryEvent_File{
+					File: &api.FileEvent{
+						Type:      api.FileEventType_FILE_EVENT_TYPE_OPEN,
+						Filename:  "/tmp/x",
+						OpenFlags: 0101,
+					},
+				},
+			},
+			sysmonFileCreate,
+			map[string]string{
+				"RuleName":       "-",
+				"UtcTime":        "2018-03-01 12:30:15.250",
+				"ProcessGuid":    "",
+				"ProcessId":      "5",
+				"TargetFilename": "/tmp/x",
+			},
+		},
+	}
+	for _, tc := range tests {
+		b, err := enc.Encode(tc.event)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if len(b) == 0 || b[len(b)-1] != '\n' {
+			t.Errorf("Expected a line of JSON, got %q", b)
+			continue
+		}
+		var e sysmonEvent
+		if err = json.Unmarshal(b, &e); err != nil {
+			t.Error(err)
+			continue
+		}
+		if e.EventID != tc.id || e.EventName != sysmonEventNames[tc.id] {
+			t.Errorf("Expected event %d, got %d (%s)", tc.id, e.EventID,
+				e.EventName)
+		}
+		if !reflect.DeepEqual(e.EventData, tc.data) {
+			t.Errorf("Expected %v, got %v", tc.data, e.EventData)
+		}
+	}
+
+	// Events without a Sysmon equivalent are encoded as nothing.
+	unmapped := []*api.TelemetryEvent{
+		{},
+		{
+			Event: &api.TelemetryEvent_File{
+				File: &api.FileEvent{
+					Type:     api.FileEventType_FILE_EVENT_TYPE_OPEN,
+					Filename: "/etc/passwd",
+				},
+			},
+		},
+		{
+			Event: &api.TelemetryEvent_Syscall{
+				Syscall: &api.SyscallEvent{
+					Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_COMPLETE,
+					Id:   syscallUnlinkID,
+					Ret:  -2,
+				},
+			},
+		},
+	}
+	for _, e := range unmapped {
+		if b, err := enc.Encode(e); err != nil || b != nil {
+			t.Errorf("Expected no encoding for %v, got %q, %v", e, b, err)
+		}
+	}
+
+	if enc, err := NewOutputEncoder("sysmon"); err != nil || enc.Extension() != "json" {
+		t.Errorf("Unexpected Sysmon encoder %v, %v", enc, err)
+	}
+}
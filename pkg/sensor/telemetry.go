@@ -16,24 +16,34 @@ package sensor
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	api "github.com/capsule8/capsule8/api/v0"
 	"github.com/capsule8/capsule8/pkg/config"
+	"github.com/capsule8/capsule8/pkg/dictionary"
 	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
 
 	"golang.org/x/sys/unix"
 
+	"google.golang.org/genproto/googleapis/rpc/code"
+	google_rpc "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 // TelemetryServiceGetEventsRequestFunc is a function called when a new
@@ -221,8 +231,9 @@ func (ts *TelemetryService) Serve() error {
 	}
 
 	t := &telemetryServiceServer{
-		sensor:  ts.sensor,
-		service: ts,
+		sensor:      ts.sensor,
+		service:     ts,
+		idempotency: newIdempotencyKeys(config.Sensor.IdempotencyKeyTTL),
 	}
 	api.RegisterTelemetryServiceServer(ts.server, t)
 
@@ -242,8 +253,14 @@ func (ts *TelemetryService) Stop() {
 }
 
 type telemetryServiceServer struct {
-	sensor  *Sensor
-	service *TelemetryService
+	sensor      *Sensor
+	service     *TelemetryService
+	idempotency *idempotencyKeys
+
+	// The sessions being served, by subscription ID, so that requests
+	// to modify a subscription can be checked against its session
+	sessionsLock sync.Mutex
+	sessions     map[int32]*getEventsSession
 }
 
 func (t *telemetryServiceServer) getEventsError(err error) error {
@@ -253,6 +270,122 @@ func (t *telemetryServiceServer) getEventsError(err error) error {
 	return err
 }
 
+// getEventsSession is the state of a subscription being served over a
+// GetEvents stream. The session of a request with an idempotency key may be
+// taken over by a new stream when the request is retried.
+type getEventsSession struct {
+	subscr  *subscription
+	summary *subscriptionSummary
+	events  chan *api.TelemetryEvent
+	cancel  context.CancelFunc
+
+	// A secret sent to the client on the session's streams, which it
+	// presents to modify the subscription
+	token string
+
+	maxEvents        int64
+	nEvents          int64
+	throttleDuration time.Duration
+	nextEventTime    time.Time
+}
+
+// newGetEventsSession creates the subscription for a GetEvents request. The
+// subscription is canceled when the stream ends, unless the request has an
+// idempotency key, in which case it is only canceled when the session ends.
+func (t *telemetryServiceServer) newGetEventsSession(
+	ctx context.Context,
+	req *api.GetEventsRequest,
+) (*getEventsSession, []*google_rpc.Status, error) {
+	sub := req.Subscription
+
+	// Higher priority subscriptions get deeper buffers so that they
+	// drop fewer events when the client cannot keep up.
+	bufferLength := int(t.sensor.Limits().ChannelBufferLength)
+	switch sub.Priority {
+	case api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_LOW:
+		if bufferLength > 1 {
+			bufferLength /= 2
+		}
+	case api.SubscriptionPriority_SUBSCRIPTION_PRIORITY_HIGH:
+		bufferLength *= 4
+	}
+
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return nil, nil, err
+	}
+
+	sess := &getEventsSession{
+		summary:       newSubscriptionSummary(),
+		events:        make(chan *api.TelemetryEvent, bufferLength),
+		token:         hex.EncodeToString(token),
+		nextEventTime: time.Now(),
+	}
+	f := func(e *api.TelemetryEvent) {
+		// Send the event to the data channel, but drop the event if
+		// the channel is full. Do not block the sensor from delivering
+		// telemetry to other subscribers.
+		select {
+		case sess.events <- e:
+		default:
+			sess.summary.drop()
+			t.sensor.DropEvent(sub.Priority)
+		}
+	}
+
+	if req.IdempotencyKey != "" {
+		ctx = context.Background()
+	}
+	ctx, sess.cancel = context.WithCancel(ctx)
+
+	subscr, status, err := t.sensor.createSubscription(ctx, sub, f,
+		sess.summary.drops)
+	if err != nil {
+		sess.cancel()
+		return nil, nil, err
+	}
+	sess.subscr = subscr
+	return sess, status, nil
+}
+
+// addSession records the session serving a subscription.
+func (t *telemetryServiceServer) addSession(sess *getEventsSession) {
+	t.sessionsLock.Lock()
+	if t.sessions == nil {
+		t.sessions = make(map[int32]*getEventsSession)
+	}
+	t.sessions[sess.subscr.eventGroupID] = sess
+	t.sessionsLock.Unlock()
+}
+
+// removeSession forgets the session serving a subscription.
+func (t *telemetryServiceServer) removeSession(sess *getEventsSession) {
+	t.sessionsLock.Lock()
+	delete(t.sessions, sess.subscr.eventGroupID)
+	t.sessionsLock.Unlock()
+}
+
+// authorizeSession returns an error unless the token is that of the session
+// serving the subscription.
+func (t *telemetryServiceServer) authorizeSession(
+	subscriptionID int32,
+	token string,
+) error {
+	t.sessionsLock.Lock()
+	sess := t.sessions[subscriptionID]
+	t.sessionsLock.Unlock()
+
+	if sess == nil {
+		return status.Errorf(codes.NotFound,
+			"Subscription %d does not exist", subscriptionID)
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(sess.token)) != 1 {
+		return status.Errorf(codes.PermissionDenied,
+			"Subscription %d belongs to another session", subscriptionID)
+	}
+	return nil
+}
+
 func (t *telemetryServiceServer) GetEvents(
 	req *api.GetEventsRequest,
 	stream api.TelemetryService_GetEventsServer,
@@ -304,63 +437,156 @@ func (t *telemetryServiceServer) GetEvents(
 		}
 	}
 
-	events := make(chan *api.TelemetryEvent,
-		config.Sensor.ChannelBufferLength)
-	f := func(e *api.TelemetryEvent) {
-		// Send the event to the data channel, but drop the event if
-		// the channel is full. Do not block the sensor from delivering
-		// telemetry to other subscribers.
-		select {
-		case events <- e:
-		default:
+	var (
+		idem *idempotentSubscription
+		sess *getEventsSession
+	)
+	r := &api.GetEventsResponse{}
+	if req.IdempotencyKey != "" {
+		idem, sess, err = t.idempotency.acquire(stream.Context(),
+			peerClient(stream.Context()), req)
+		if err != nil {
+			return t.getEventsError(err)
 		}
 	}
-
-	ctx, cancel := context.WithCancel(stream.Context())
-	defer cancel()
-
-	r := &api.GetEventsResponse{}
-	if r.Statuses, err = t.sensor.NewSubscription(ctx, sub, f); err != nil {
-		glog.Errorf("Failed to get events for subscription %+v: %v",
-			sub, err)
-		return t.getEventsError(err)
+	if sess != nil {
+		msg := fmt.Sprintf("Resumed subscription %d for idempotency key %q",
+			sess.subscr.eventGroupID, req.IdempotencyKey)
+		glog.V(1).Info(msg)
+		r.Statuses = []*google_rpc.Status{{
+			Code:    int32(code.Code_OK),
+			Message: msg,
+		}}
+	} else {
+		sess, r.Statuses, err = t.newGetEventsSession(stream.Context(),
+			req)
+		if err != nil {
+			if idem != nil {
+				t.idempotency.abandon(idem)
+			}
+			glog.Errorf("Failed to get events for subscription %+v: %v",
+				sub, err)
+			return t.getEventsError(err)
+		}
+		sess.maxEvents = maxEvents
+		sess.throttleDuration = throttleDuration
+		if idem != nil {
+			idem.session = sess
+		}
+		t.addSession(sess)
 	}
+	subscr := sess.subscr
+	r.SubscriptionId = subscr.eventGroupID
+	r.SubscriptionToken = sess.token
+	r.SubscriptionTags = sub.Tags
+	r.PerfClock = subscr.perfClock
+
+	// Report the subscription's events when it ends, whatever the
+	// reason. If the stream has already failed, the summary is only
+	// logged.
+	finish := func(reason string, err error) error {
+		sess.cancel()
+		t.removeSession(sess)
+		if idem != nil {
+			t.idempotency.release(idem)
+		}
+		r := &api.GetEventsResponse{
+			Summary: sess.summary.summarize(subscr, len(sess.events),
+				reason),
+		}
+		glog.V(1).Infof("Subscription %d ended (%s): %+v",
+			subscr.eventGroupID, reason, r.Summary)
+		stream.Send(r)
+		return err
+	}
+
 	if err = stream.Send(r); err != nil {
+		finish("stream error", err)
 		return t.getEventsError(err)
 	}
 	if t.service.options.getEventsResponse != nil {
 		t.service.options.getEventsResponse(r, nil)
 	}
 
-	var nEvents int64
-	nextEventTime := time.Now()
+	var encoder *dictionary.Encoder
+	if req.DictionaryEncoding {
+		encoder = dictionary.NewEncoder(dictionary.DefaultMaxEntries)
+	}
+
+	var takeover chan chan struct{}
+	if idem != nil {
+		takeover = idem.takeover
+	}
+	ctx := stream.Context()
 	for {
 		select {
 		case <-ctx.Done():
 			glog.V(1).Infof("Client disconnected, closing stream")
-			return ctx.Err()
-		case e := <-events:
-			if throttleDuration != 0 {
+			return finish("client disconnected", ctx.Err())
+		case stopped := <-takeover:
+			// The retried request continues the session, including
+			// its summary, on its own stream.
+			glog.V(1).Infof("Subscription %d taken over by a retried request",
+				subscr.eventGroupID)
+			stream.Send(&api.GetEventsResponse{
+				Statuses: []*google_rpc.Status{{
+					Code:    int32(code.Code_ABORTED),
+					Message: "Subscription taken over by a retried request with the same idempotency key",
+				}},
+			})
+			close(stopped)
+			return fmt.Errorf("Subscription %d taken over by a retried request",
+				subscr.eventGroupID)
+		case st := <-subscr.lateStatus:
+			r = &api.GetEventsResponse{
+				Statuses: []*google_rpc.Status{st},
+			}
+			if err = stream.Send(r); err != nil {
+				return finish("stream error", err)
+			}
+		case e := <-sess.events:
+			if sess.throttleDuration != 0 {
 				now := time.Now()
-				if now.Before(nextEventTime) {
+				if now.Before(sess.nextEventTime) {
+					sess.summary.drops.add(
+						api.DropReason_DROP_REASON_THROTTLE_MODIFIER, 1)
 					break
 				}
-				nextEventTime = now
-				nextEventTime.Add(throttleDuration)
+				sess.nextEventTime = now
+				sess.nextEventTime.Add(sess.throttleDuration)
 			}
 			r = &api.GetEventsResponse{
 				Events: []*api.ReceivedTelemetryEvent{
-					&api.ReceivedTelemetryEvent{Event: e},
+					&api.ReceivedTelemetryEvent{
+						Event:            e,
+						SubscriptionTags: sub.Tags,
+					},
 				},
 			}
+			// Events are measured before they are encoded,
+			// since the encoder's dictionary must not refer to
+			// events that are shed.
+			if !subscr.egressShare.admit(proto.Size(r)) {
+				t.sensor.DropEvent(sub.Priority)
+				break
+			}
+			if encoder != nil {
+				encoder.Encode(r)
+			}
+			sendStart := time.Now()
 			if err = stream.Send(r); err != nil {
-				return err
+				return finish("stream error", err)
+			}
+			if subscr.ackThrottle != nil {
+				subscr.ackThrottle.observe(time.Since(sendStart))
 			}
-			if maxEvents > 0 {
-				nEvents++
-				if nEvents == maxEvents {
-					return fmt.Errorf("Event limit reached (%d)",
-						maxEvents)
+			sess.summary.deliver(e)
+			if sess.maxEvents > 0 {
+				sess.nEvents++
+				if sess.nEvents == sess.maxEvents {
+					return finish("event limit reached",
+						fmt.Errorf("Event limit reached (%d)",
+							sess.maxEvents))
 				}
 			}
 		}
@@ -369,3 +595,84 @@ func (t *telemetryServiceServer) GetEvents(
 	// unreachable
 	return nil
 }
+
+func (t *telemetryServiceServer) GetCapabilities(
+	ctx context.Context,
+	req *api.GetCapabilitiesRequest,
+) (*api.GetCapabilitiesResponse, error) {
+	r := &api.GetCapabilitiesResponse{
+		DictionaryEncoding: true,
+		TracingAvailable:   t.sensor.tracingAvailable,
+	}
+	if layout := t.sensor.syscallEnterLayout; layout != nil {
+		r.BtfSyscallArgOffsets = layout.fromBTF
+	}
+	return r, nil
+}
+
+func (t *telemetryServiceServer) GetStatistics(
+	ctx context.Context,
+	req *api.GetStatisticsRequest,
+) (*api.GetStatisticsResponse, error) {
+	r := &api.GetStatisticsResponse{
+		Filters:           t.sensor.FilterStatistics(),
+		SyscallCosts:      t.sensor.SyscallCosts(),
+		AckThrottles:      t.sensor.AckThrottleStatistics(),
+		DispatchWorkers:   t.sensor.DispatchWorkerStatistics(),
+		Egress:            t.sensor.EgressStatistics(),
+		RingBuffers:       t.sensor.RingBufferStatistics(),
+		SubscriptionDrops: t.sensor.DropStatistics(),
+		Cpu:               t.sensor.CPUStatistics(),
+		Decoding:          t.sensor.DecodeStatistics(),
+	}
+	r.PendingLazySubscriptions, r.ActiveLazySubscriptions =
+		t.sensor.lazySubscriptionCounts()
+	return r, nil
+}
+
+func (t *telemetryServiceServer) GetCounts(
+	ctx context.Context,
+	req *api.GetCountsRequest,
+) (*api.GetCountsResponse, error) {
+	return t.sensor.EventCounts(
+		time.Duration(req.DurationSeconds) * time.Second)
+}
+
+func (t *telemetryServiceServer) GetLimits(
+	ctx context.Context,
+	req *api.GetLimitsRequest,
+) (*api.SensorLimits, error) {
+	return t.sensor.Limits(), nil
+}
+
+func (t *telemetryServiceServer) UpdateLimits(
+	ctx context.Context,
+	req *api.UpdateLimitsRequest,
+) (*api.UpdateLimitsResponse, error) {
+	limits, status, err := t.sensor.UpdateLimits(req)
+	if err != nil {
+		return nil, err
+	}
+	return &api.UpdateLimitsResponse{
+		Limits:   limits,
+		Statuses: status,
+	}, nil
+}
+
+func (t *telemetryServiceServer) UpdateSyscallIds(
+	ctx context.Context,
+	req *api.UpdateSyscallIdsRequest,
+) (*api.UpdateSyscallIdsResponse, error) {
+	err := t.authorizeSession(req.SubscriptionId, req.SubscriptionToken)
+	if err != nil {
+		return nil, err
+	}
+	filters, err := t.sensor.UpdateSyscallIDs(req.SubscriptionId,
+		req.AddIds, req.RemoveIds)
+	if err != nil {
+		return nil, err
+	}
+	return &api.UpdateSyscallIdsResponse{
+		KernelFilters: filters,
+	}, nil
+}
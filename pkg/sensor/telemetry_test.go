@@ -0,0 +1,68 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUpdateSyscallIdsSession(t *testing.T) {
+	s := &Sensor{eventMap: newSafeSubscriptionMap()}
+	ts := &telemetryServiceServer{sensor: s}
+	sess := &getEventsSession{
+		subscr: newSubscription(s, 7, nil),
+		token:  "secret",
+	}
+	ts.addSession(sess)
+
+	update := func(id int32, token string) error {
+		_, err := ts.UpdateSyscallIds(context.Background(),
+			&api.UpdateSyscallIdsRequest{
+				SubscriptionId:    id,
+				SubscriptionToken: token,
+				AddIds:            []int64{59},
+			})
+		return err
+	}
+
+	if c := status.Code(update(8, "secret")); c != codes.NotFound {
+		t.Errorf("Expected NotFound for another subscription, got %s", c)
+	}
+	if c := status.Code(update(7, "")); c != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied without a token, got %s", c)
+	}
+	if c := status.Code(update(7, "guess")); c != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for another token, got %s", c)
+	}
+
+	// The session's own token reaches the subscription, which has no
+	// syscall events to update
+	err := update(7, "secret")
+	if c := status.Code(err); err == nil ||
+		c == codes.NotFound || c == codes.PermissionDenied {
+		t.Errorf("Expected the update to be attempted, got %v", err)
+	}
+
+	ts.removeSession(sess)
+	if c := status.Code(update(7, "secret")); c != codes.NotFound {
+		t.Errorf("Expected NotFound after the session ended, got %s", c)
+	}
+}
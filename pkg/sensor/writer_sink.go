@@ -0,0 +1,234 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/capsule8/capsule8/pkg/config"
+
+	"github.com/golang/glog"
+
+	google_rpc "google.golang.org/genproto/googleapis/rpc/status"
+)
+
+// WriteErrorPolicy determines what a WriterSink does when an event cannot be
+// encoded or written.
+type WriteErrorPolicy int
+
+const (
+	// WriteErrorDrop drops the event and continues.
+	WriteErrorDrop WriteErrorPolicy = iota
+
+	// WriteErrorLog drops the event and continues, logging the first of
+	// each run of consecutive failures.
+	WriteErrorLog
+
+	// WriteErrorStop ends the subscription. The error is returned by
+	// Err.
+	WriteErrorStop
+)
+
+// WriterSink delivers the events of a subscription to an io.Writer, encoded
+// with an OutputEncoder. It allows programs that embed the Sensor to send
+// events anywhere that an io.Writer can, such as a pipe, a buffer, or a
+// network connection.
+//
+// Events are written by a single goroutine owned by the WriterSink, one
+// encoded event per Write call, so the writer need not be safe for
+// concurrent use unless it is also used elsewhere. The Sensor never blocks
+// on the writer; events that arrive while the writer is busy are queued up
+// to config.Sensor.ChannelBufferLength, and events beyond that are dropped
+// and counted.
+type WriterSink struct {
+	// Updated atomically. It is the first field so that it is 64-bit
+	// aligned.
+	dropped uint64
+
+	w       io.Writer
+	encoder OutputEncoder
+	policy  WriteErrorPolicy
+
+	events chan *api.TelemetryEvent
+	egress *egressShare
+	done   chan struct{}
+
+	// Protects the state of the WriterSink, so that Stop may be called
+	// at any time, even before Subscribe. cancel is set before the writing
+	// goroutine starts.
+	mutex      sync.Mutex
+	cancel     context.CancelFunc
+	subscribed bool
+	stopped    bool
+
+	errMutex sync.Mutex
+	err      error
+}
+
+// NewWriterSink creates a new WriterSink that writes events to w with the
+// specified encoder and write error policy.
+func NewWriterSink(
+	w io.Writer,
+	encoder OutputEncoder,
+	policy WriteErrorPolicy,
+) (*WriterSink, error) {
+	switch policy {
+	case WriteErrorDrop, WriteErrorLog, WriteErrorStop:
+	default:
+		return nil, fmt.Errorf("Invalid write error policy %d", policy)
+	}
+	return &WriterSink{
+		w:       w,
+		encoder: encoder,
+		policy:  policy,
+		events: make(chan *api.TelemetryEvent,
+			config.Sensor.ChannelBufferLength),
+		done: make(chan struct{}),
+	}, nil
+}
+
+// Subscribe creates a subscription whose events are written by the
+// WriterSink until ctx is cancelled or Stop is called. A WriterSink may only
+// be subscribed once, and not after it has been stopped.
+func (ws *WriterSink) Subscribe(
+	ctx context.Context,
+	sensor *Sensor,
+	sub *api.Subscription,
+) ([]*google_rpc.Status, error) {
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
+	if ws.stopped {
+		return nil, errors.New("WriterSink is stopped")
+	}
+	if ws.subscribed {
+		return nil, errors.New("WriterSink is already subscribed")
+	}
+	ws.subscribed = true
+
+	ctx, ws.cancel = context.WithCancel(ctx)
+	subscr, status, err := sensor.createSubscription(ctx, sub, ws.dispatch, nil)
+	if err != nil {
+		ws.cancel()
+		close(ws.done)
+		return nil, err
+	}
+	ws.egress = subscr.egressShare
+	go ws.run(ctx)
+	return status, nil
+}
+
+// Stop ends the subscription and waits for the WriterSink to stop writing.
+// Events still queued are discarded. If the WriterSink has not been
+// subscribed, it never will be.
+func (ws *WriterSink) Stop() {
+	ws.mutex.Lock()
+	if !ws.subscribed && !ws.stopped {
+		close(ws.done)
+	}
+	ws.stopped = true
+	cancel := ws.cancel
+	ws.mutex.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	<-ws.done
+}
+
+// Done returns a channel that is closed once the WriterSink has stopped
+// writing, either because it was stopped or because a write failed under
+// WriteErrorStop.
+func (ws *WriterSink) Done() <-chan struct{} {
+	return ws.done
+}
+
+// Err returns the error that stopped the WriterSink under WriteErrorStop, or
+// nil.
+func (ws *WriterSink) Err() error {
+	ws.errMutex.Lock()
+	defer ws.errMutex.Unlock()
+	return ws.err
+}
+
+// Dropped returns the number of events that were dropped, either because
+// the queue was full, because the Sensor's egress limit was reached, or
+// because they could not be encoded or written.
+func (ws *WriterSink) Dropped() uint64 {
+	return atomic.LoadUint64(&ws.dropped)
+}
+
+func (ws *WriterSink) dispatch(e *api.TelemetryEvent) {
+	select {
+	case ws.events <- e:
+	default:
+		atomic.AddUint64(&ws.dropped, 1)
+	}
+}
+
+func (ws *WriterSink) write(e *api.TelemetryEvent) error {
+	b, err := ws.encoder.Encode(e)
+	if err != nil || len(b) == 0 {
+		return err
+	}
+	if !ws.egress.admit(len(b)) {
+		atomic.AddUint64(&ws.dropped, 1)
+		return nil
+	}
+	_, err = ws.w.Write(b)
+	return err
+}
+
+func (ws *WriterSink) run(ctx context.Context) {
+	defer close(ws.done)
+
+	failing := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-ws.events:
+			err := ws.write(e)
+			if err == nil {
+				if failing && ws.policy == WriteErrorLog {
+					glog.Infof("Resumed writing events")
+				}
+				failing = false
+				continue
+			}
+
+			atomic.AddUint64(&ws.dropped, 1)
+			switch ws.policy {
+			case WriteErrorLog:
+				if !failing {
+					glog.Warningf("Couldn't write event: %v", err)
+				}
+			case WriteErrorStop:
+				ws.errMutex.Lock()
+				ws.err = err
+				ws.errMutex.Unlock()
+				ws.cancel()
+				return
+			}
+			failing = true
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+// signalingWriter signals each write after appending it to a buffer.
+type signalingWriter struct {
+	buf     bytes.Buffer
+	written chan struct{}
+}
+
+func (w *signalingWriter) Write(b []byte) (int, error) {
+	n, err := w.buf.Write(b)
+	w.written <- struct{}{}
+	return n, err
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(b []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func startTestWriterSink(t *testing.T, ws *WriterSink) {
+	var ctx context.Context
+	ctx, ws.cancel = context.WithCancel(context.Background())
+	ws.subscribed = true
+	go ws.run(ctx)
+}
+
+func TestWriterSink(t *testing.T) {
+	if _, err := NewWriterSink(nil, nil, WriteErrorPolicy(99)); err == nil {
+		t.Error("Expected error for invalid write error policy")
+	}
+
+	w := &signalingWriter{written: make(chan struct{}, 2)}
+	ws, err := NewWriterSink(w, jsonOutputEncoder{}, WriteErrorDrop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := &api.TelemetryEvent{Id: "event"}
+	ws.dispatch(e)
+	startTestWriterSink(t, ws)
+	ws.dispatch(e)
+	<-w.written
+	<-w.written
+	ws.Stop()
+
+	expected, _ := jsonOutputEncoder{}.Encode(e)
+	expected = append(expected, expected...)
+	if !bytes.Equal(w.buf.Bytes(), expected) {
+		t.Errorf("Expected %q written, got %q", expected, w.buf.Bytes())
+	}
+	if ws.Err() != nil || ws.Dropped() != 0 {
+		t.Errorf("Expected no errors or drops, got %v, %d", ws.Err(),
+			ws.Dropped())
+	}
+}
+
+func TestWriterSinkStopOnError(t *testing.T) {
+	ws, err := NewWriterSink(failingWriter{}, jsonOutputEncoder{},
+		WriteErrorStop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	startTestWriterSink(t, ws)
+	ws.dispatch(&api.TelemetryEvent{Id: "event"})
+	<-ws.Done()
+
+	if ws.Err() == nil || ws.Dropped() != 1 {
+		t.Errorf("Expected write error and 1 drop, got %v, %d",
+			ws.Err(), ws.Dropped())
+	}
+}
+
+func TestWriterSinkStopBeforeSubscribe(t *testing.T) {
+	ws, err := NewWriterSink(failingWriter{}, jsonOutputEncoder{},
+		WriteErrorDrop)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Stop must not wait for a subscription that was never made, and
+	// may be called again.
+	ws.Stop()
+	ws.Stop()
+	<-ws.Done()
+
+	if _, err = ws.Subscribe(context.Background(), nil, nil); err == nil {
+		t.Error("Expected error subscribing a stopped WriterSink")
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package btf implements a minimal reader for the kernel's BPF Type Format
+// (BTF) type information. It is only concerned with struct layouts.
+package btf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// KernelBTFPath is the location of the running kernel's BTF type information.
+const KernelBTFPath = "/sys/kernel/btf/vmlinux"
+
+const (
+	btfMagic = 0xeb9f
+
+	kindInt       = 1
+	kindPtr       = 2
+	kindArray     = 3
+	kindStruct    = 4
+	kindUnion     = 5
+	kindEnum      = 6
+	kindFwd       = 7
+	kindTypedef   = 8
+	kindVolatile  = 9
+	kindConst     = 10
+	kindRestrict  = 11
+	kindFunc      = 12
+	kindFuncProto = 13
+	kindVar       = 14
+	kindDatasec   = 15
+	kindFloat     = 16
+	kindDeclTag   = 17
+	kindTypeTag   = 18
+	kindEnum64    = 19
+)
+
+type btfHeader struct {
+	Magic   uint16
+	Version uint8
+	Flags   uint8
+	HdrLen  uint32
+	TypeOff uint32
+	TypeLen uint32
+	StrOff  uint32
+	StrLen  uint32
+}
+
+type btfType struct {
+	NameOff  uint32
+	Info     uint32
+	SizeType uint32
+}
+
+type btfMember struct {
+	NameOff uint32
+	Type    uint32
+	Offset  uint32
+}
+
+// compositeMember is a member of a struct or union, with its byte offset.
+type compositeMember struct {
+	name   string
+	typeID uint32
+	offset uint32
+}
+
+// Spec holds the struct layouts read from BTF type information.
+type Spec struct {
+	// Mapping of struct name to a mapping of member name to byte offset
+	structs map[string]map[string]uint32
+}
+
+// LoadKernelSpec reads the BTF type information for the running kernel.
+func LoadKernelSpec() (*Spec, error) {
+	data, err := ioutil.ReadFile(KernelBTFPath)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse parses raw BTF type information.
+func Parse(data []byte) (*Spec, error) {
+	var (
+		hdr   btfHeader
+		order binary.ByteOrder = binary.LittleEndian
+	)
+	if len(data) < binary.Size(hdr) {
+		return nil, errors.New("BTF data is truncated")
+	}
+	if binary.BigEndian.Uint16(data) == btfMagic {
+		order = binary.BigEndian
+	} else if binary.LittleEndian.Uint16(data) != btfMagic {
+		return nil, errors.New("BTF magic number not found")
+	}
+	binary.Read(bytes.NewReader(data), order, &hdr)
+
+	typeStart := uint64(hdr.HdrLen) + uint64(hdr.TypeOff)
+	typeEnd := typeStart + uint64(hdr.TypeLen)
+	strStart := uint64(hdr.HdrLen) + uint64(hdr.StrOff)
+	strEnd := strStart + uint64(hdr.StrLen)
+	if typeEnd > uint64(len(data)) || strEnd > uint64(len(data)) {
+		return nil, errors.New("BTF data is truncated")
+	}
+	strs := data[strStart:strEnd]
+
+	// The members of all structs and unions by type ID, so that the
+	// members of anonymous ones can be found through the named structs
+	// that contain them.
+	composites := make(map[uint32][]compositeMember)
+	named := make(map[string]uint32)
+
+	r := bytes.NewReader(data[typeStart:typeEnd])
+	for typeID := uint32(1); r.Len() > 0; typeID++ {
+		var t btfType
+		if err := binary.Read(r, order, &t); err != nil {
+			return nil, fmt.Errorf("BTF type data is truncated: %v", err)
+		}
+		kind := (t.Info >> 24) & 0x1f
+		vlen := int64(t.Info & 0xffff)
+		kindFlag := t.Info&(1<<31) != 0
+
+		var skip int64
+		switch kind {
+		case kindInt, kindVar, kindDeclTag:
+			skip = 4
+		case kindArray:
+			skip = 12
+		case kindEnum, kindFuncProto:
+			skip = vlen * 8
+		case kindDatasec, kindEnum64:
+			skip = vlen * 12
+		case kindStruct, kindUnion:
+			members := make([]compositeMember, vlen)
+			for i := range members {
+				var m btfMember
+				if err := binary.Read(r, order, &m); err != nil {
+					return nil, fmt.Errorf("BTF member data is truncated: %v", err)
+				}
+				bitOffset := m.Offset
+				if kindFlag {
+					bitOffset &= 0xffffff
+				}
+				members[i] = compositeMember{
+					name:   btfString(strs, m.NameOff),
+					typeID: m.Type,
+					offset: bitOffset / 8,
+				}
+			}
+			composites[typeID] = members
+			name := btfString(strs, t.NameOff)
+			if kind == kindStruct && name != "" {
+				named[name] = typeID
+			}
+		case kindPtr, kindFwd, kindTypedef, kindVolatile, kindConst,
+			kindRestrict, kindFunc, kindFloat, kindTypeTag:
+			// No additional data
+		default:
+			return nil, fmt.Errorf("Unknown BTF type kind %d", kind)
+		}
+		if skip > 0 {
+			if _, err := r.Seek(skip, io.SeekCurrent); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	spec := &Spec{
+		structs: make(map[string]map[string]uint32, len(named)),
+	}
+	for name, typeID := range named {
+		members := make(map[string]uint32)
+		addMembers(members, composites, typeID, 0, 0)
+		spec.structs[name] = members
+	}
+	return spec, nil
+}
+
+// maxAnonymousDepth bounds the nesting of anonymous structs and unions that
+// is followed, so that malformed type information cannot recurse forever.
+const maxAnonymousDepth = 8
+
+// addMembers adds the members of a struct or union at the specified byte
+// offset, including the members of anonymous structs and unions within it.
+func addMembers(
+	members map[string]uint32,
+	composites map[uint32][]compositeMember,
+	typeID, offset uint32,
+	depth int,
+) {
+	for _, m := range composites[typeID] {
+		if m.name != "" {
+			members[m.name] = offset + m.offset
+		} else if depth < maxAnonymousDepth {
+			addMembers(members, composites, m.typeID,
+				offset+m.offset, depth+1)
+		}
+	}
+}
+
+func btfString(strs []byte, off uint32) string {
+	if uint64(off) >= uint64(len(strs)) {
+		return ""
+	}
+	s := strs[off:]
+	if i := bytes.IndexByte(s, 0); i >= 0 {
+		s = s[:i]
+	}
+	return string(s)
+}
+
+// StructMemberOffset returns the byte offset of a member within a struct. The
+// members of anonymous structs and unions within it are found as well.
+func (s *Spec) StructMemberOffset(structName, memberName string) (uint32, bool) {
+	members, ok := s.structs[structName]
+	if !ok {
+		return 0, false
+	}
+	offset, ok := members[memberName]
+	return offset, ok
+}
@@ -0,0 +1,108 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package btf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func buildTestBTF() []byte {
+	strs := []byte("\x00int\x00pt_regs\x00di\x00si\x00" +
+		"sock_common\x00skc_daddr\x00skc_family\x00")
+
+	var types bytes.Buffer
+	w := func(v interface{}) {
+		binary.Write(&types, binary.LittleEndian, v)
+	}
+
+	// [1] int: name "int", kind INT, size 4, encoding data
+	w(btfType{NameOff: 1, Info: kindInt << 24, SizeType: 4})
+	w(uint32(32))
+
+	// [2] const int
+	w(btfType{Info: kindConst << 24, SizeType: 1})
+
+	// [3] struct pt_regs { int di; int si; } with bitfield-style offsets
+	w(btfType{NameOff: 5, Info: 1<<31 | kindStruct<<24 | 2, SizeType: 16})
+	w(btfMember{NameOff: 13, Type: 1, Offset: 0})
+	w(btfMember{NameOff: 16, Type: 1, Offset: 64})
+
+	// [4] struct { int skc_daddr; } at offset 4 of
+	// [5] union { struct [4]; }, itself at offset 8 of
+	// [6] struct sock_common { int skc_family; union [5]; }
+	w(btfType{Info: kindStruct<<24 | 1, SizeType: 8})
+	w(btfMember{NameOff: 31, Type: 1, Offset: 32})
+	w(btfType{Info: kindUnion<<24 | 1, SizeType: 8})
+	w(btfMember{Type: 4, Offset: 0})
+	w(btfType{NameOff: 19, Info: kindStruct<<24 | 2, SizeType: 16})
+	w(btfMember{NameOff: 41, Type: 1, Offset: 0})
+	w(btfMember{Type: 5, Offset: 64})
+
+	hdr := btfHeader{
+		Magic:   btfMagic,
+		Version: 1,
+		HdrLen:  uint32(binary.Size(btfHeader{})),
+		TypeOff: 0,
+		TypeLen: uint32(types.Len()),
+		StrOff:  uint32(types.Len()),
+		StrLen:  uint32(len(strs)),
+	}
+
+	var data bytes.Buffer
+	binary.Write(&data, binary.LittleEndian, hdr)
+	data.Write(types.Bytes())
+	data.Write(strs)
+	return data.Bytes()
+}
+
+func TestParse(t *testing.T) {
+	spec, err := Parse(buildTestBTF())
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if offset, ok := spec.StructMemberOffset("pt_regs", "di"); !ok || offset != 0 {
+		t.Errorf("Expected pt_regs.di at offset 0, got %d (%v)", offset, ok)
+	}
+	if offset, ok := spec.StructMemberOffset("pt_regs", "si"); !ok || offset != 8 {
+		t.Errorf("Expected pt_regs.si at offset 8, got %d (%v)", offset, ok)
+	}
+	if _, ok := spec.StructMemberOffset("pt_regs", "dx"); ok {
+		t.Error("Unexpected member pt_regs.dx found")
+	}
+	if offset, ok := spec.StructMemberOffset("sock_common", "skc_daddr"); !ok || offset != 12 {
+		t.Errorf("Expected sock_common.skc_daddr at offset 12, got %d (%v)", offset, ok)
+	}
+	if offset, ok := spec.StructMemberOffset("sock_common", "skc_family"); !ok || offset != 0 {
+		t.Errorf("Expected sock_common.skc_family at offset 0, got %d (%v)", offset, ok)
+	}
+	if _, ok := spec.StructMemberOffset("task_struct", "pid"); ok {
+		t.Error("Unexpected struct task_struct found")
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	if _, err := Parse([]byte{0x01, 0x02}); err == nil {
+		t.Error("Expected error for truncated data")
+	}
+
+	data := buildTestBTF()
+	data[0], data[1] = 0, 0
+	if _, err := Parse(data); err == nil {
+		t.Error("Expected error for bad magic number")
+	}
+}
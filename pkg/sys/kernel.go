@@ -44,3 +44,23 @@ func KernelVersion() (int, int, int) {
 
 	return bits[0], bits[1], bits[2]
 }
+
+// MachineArch returns the hardware architecture of the currently running
+// kernel as reported by uname(2), e.g. "x86_64" or "aarch64". The return is
+// empty if it cannot be determined.
+func MachineArch() string {
+	var buf syscall.Utsname
+	err := syscall.Uname(&buf)
+	if err != nil {
+		return ""
+	}
+
+	b := make([]byte, 0, len(buf.Machine))
+	for _, c := range buf.Machine {
+		if c == 0 {
+			break
+		}
+		b = append(b, byte(c))
+	}
+	return string(b)
+}
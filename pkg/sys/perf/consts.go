@@ -173,6 +173,55 @@ const (
 	PERF_SAMPLE_MAX
 )
 
+// Fields of the data source reported with PERF_SAMPLE_DATA_SRC, from union
+// perf_mem_data_src in include/uapi/linux/perf_event.h. Each field is a
+// bitmask at the specified shift.
+const (
+	PERF_MEM_OP_SHIFT    = 0
+	PERF_MEM_LVL_SHIFT   = 5
+	PERF_MEM_SNOOP_SHIFT = 19
+	PERF_MEM_LOCK_SHIFT  = 24
+	PERF_MEM_TLB_SHIFT   = 26
+
+	PERF_MEM_OP_NA     = 0x01
+	PERF_MEM_OP_LOAD   = 0x02
+	PERF_MEM_OP_STORE  = 0x04
+	PERF_MEM_OP_PFETCH = 0x08
+	PERF_MEM_OP_EXEC   = 0x10
+
+	PERF_MEM_LVL_NA       = 0x01
+	PERF_MEM_LVL_HIT      = 0x02
+	PERF_MEM_LVL_MISS     = 0x04
+	PERF_MEM_LVL_L1       = 0x08
+	PERF_MEM_LVL_LFB      = 0x10
+	PERF_MEM_LVL_L2       = 0x20
+	PERF_MEM_LVL_L3       = 0x40
+	PERF_MEM_LVL_LOC_RAM  = 0x80
+	PERF_MEM_LVL_REM_RAM1 = 0x100
+	PERF_MEM_LVL_REM_RAM2 = 0x200
+	PERF_MEM_LVL_REM_CCE1 = 0x400
+	PERF_MEM_LVL_REM_CCE2 = 0x800
+	PERF_MEM_LVL_IO       = 0x1000
+	PERF_MEM_LVL_UNC      = 0x2000
+
+	PERF_MEM_SNOOP_NA   = 0x01
+	PERF_MEM_SNOOP_NONE = 0x02
+	PERF_MEM_SNOOP_HIT  = 0x04
+	PERF_MEM_SNOOP_MISS = 0x08
+	PERF_MEM_SNOOP_HITM = 0x10
+
+	PERF_MEM_LOCK_NA     = 0x01
+	PERF_MEM_LOCK_LOCKED = 0x02
+
+	PERF_MEM_TLB_NA   = 0x01
+	PERF_MEM_TLB_HIT  = 0x02
+	PERF_MEM_TLB_MISS = 0x04
+	PERF_MEM_TLB_L1   = 0x08
+	PERF_MEM_TLB_L2   = 0x10
+	PERF_MEM_TLB_WK   = 0x20
+	PERF_MEM_TLB_OS   = 0x40
+)
+
 // Bitmasks for bitfield in EventAttr
 const (
 	eaDisabled = 1 << iota
@@ -0,0 +1,269 @@
+// Copyright 2017 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perf
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// The number of batches of samples that may be queued for an event group's
+// isolated decoding goroutine. Samples are dropped rather than queued
+// beyond this, so that a group that cannot keep up does not delay the
+// decoding of other groups.
+const isolatedDecoderQueueLength = 64
+
+// WithIsolatedDecoding is used to register an event group whose samples are
+// decoded by a goroutine of its own rather than the EventMonitor's shared
+// decoding goroutine, so that a backlog of other groups' samples does not
+// delay them, and vice versa. Decoders never run concurrently, since they
+// may share state, so the group's samples are decoded one at a time between
+// those of other groups; it is their queueing and dispatch that are
+// isolated. The group's samples are still decoded in order, and external
+// samples are dispatched by the group's goroutine as well, in order with
+// them, but both may be dispatched out of order with the samples of other
+// groups. Samples are dropped if the group's goroutine falls too far behind.
+func WithIsolatedDecoding() EventGroupOption {
+	return func(o *eventGroupOptions) {
+		o.isolated = true
+	}
+}
+
+// groupDecodeStats counts the samples decoded for an event group. Updated
+// atomically.
+type groupDecodeStats struct {
+	samples         uint64
+	decodeNanos     uint64
+	latencyNanos    uint64
+	maxLatencyNanos uint64
+	droppedSamples  uint64
+}
+
+func (s *groupDecodeStats) record(decode, latency time.Duration) {
+	atomic.AddUint64(&s.samples, 1)
+	atomic.AddUint64(&s.decodeNanos, uint64(decode))
+	atomic.AddUint64(&s.latencyNanos, uint64(latency))
+	for {
+		max := atomic.LoadUint64(&s.maxLatencyNanos)
+		if uint64(latency) <= max ||
+			atomic.CompareAndSwapUint64(&s.maxLatencyNanos, max,
+				uint64(latency)) {
+			break
+		}
+	}
+}
+
+// decodeGroupSample decodes a sample of an event read from the ringbuffers
+// at readTime, and records the time taken in the statistics of the event's
+// group.
+func (monitor *EventMonitor) decodeGroupSample(
+	event *registeredEvent,
+	esm *EventMonitorSample,
+	readTime time.Time,
+) {
+	monitor.decodeMutex.Lock()
+	start := time.Now()
+	event.decodeSample(esm, monitor)
+	end := time.Now()
+	monitor.decodeMutex.Unlock()
+	if event.group != nil {
+		event.group.decodeStats.record(end.Sub(start), end.Sub(readTime))
+	}
+}
+
+// IsolatedDecodeDrop describes samples of an event group with isolated
+// decoding that were dropped because the group's goroutine fell behind.
+type IsolatedDecodeDrop struct {
+	GroupID int32
+	Dropped uint64
+}
+
+// IsolatedDecodeDropFn is the signature of a function called when samples of
+// an event group with isolated decoding were dropped. It is called once the
+// group's goroutine has caught up enough for samples to be queued again.
+type IsolatedDecodeDropFn func(IsolatedDecodeDrop)
+
+type isolatedBatch struct {
+	samples  []EventMonitorSample
+	readTime time.Time
+
+	// Set if the samples are external samples, which were decoded by
+	// the shared decoding goroutine.
+	decoded bool
+}
+
+// isolatedDecoder is the goroutine that decodes and dispatches the samples
+// of an event group registered with WithIsolatedDecoding.
+type isolatedDecoder struct {
+	monitor  *EventMonitor
+	group    *eventMonitorGroup
+	batches  chan isolatedBatch
+	done     chan struct{}
+	stopOnce sync.Once
+
+	// The number of samples dropped since samples were last queued.
+	// Only used by the EventMonitor's shared decoding goroutine.
+	dropped uint64
+}
+
+func newIsolatedDecoder(
+	monitor *EventMonitor,
+	group *eventMonitorGroup,
+) *isolatedDecoder {
+	d := &isolatedDecoder{
+		monitor: monitor,
+		group:   group,
+		batches: make(chan isolatedBatch, isolatedDecoderQueueLength),
+		done:    make(chan struct{}),
+	}
+	go d.run()
+	return d
+}
+
+// enqueue queues undecoded samples of the group, which were read from the
+// ringbuffers at readTime, for decoding. It never blocks; samples are
+// dropped if the queue is full, and reported once samples are queued again.
+func (d *isolatedDecoder) enqueue(
+	samples []EventMonitorSample,
+	readTime time.Time,
+) {
+	d.enqueueBatch(isolatedBatch{samples: samples, readTime: readTime})
+}
+
+// enqueueDecoded queues external samples, which have already been decoded,
+// for dispatch in order with the group's samples.
+func (d *isolatedDecoder) enqueueDecoded(samples []EventMonitorSample) {
+	d.enqueueBatch(isolatedBatch{samples: samples, decoded: true})
+}
+
+func (d *isolatedDecoder) enqueueBatch(b isolatedBatch) {
+	samples := b.samples
+	select {
+	case <-d.done:
+		return
+	default:
+	}
+	select {
+	case d.batches <- b:
+		if d.dropped > 0 {
+			if fn := d.monitor.decodeDropFn; fn != nil {
+				fn(IsolatedDecodeDrop{
+					GroupID: d.group.groupID,
+					Dropped: d.dropped,
+				})
+			}
+			d.dropped = 0
+		}
+	default:
+		d.dropped += uint64(len(samples))
+		atomic.AddUint64(&d.group.decodeStats.droppedSamples,
+			uint64(len(samples)))
+	}
+}
+
+func (d *isolatedDecoder) stop() {
+	d.stopOnce.Do(func() {
+		close(d.done)
+	})
+}
+
+func (d *isolatedDecoder) run() {
+	for {
+		select {
+		case <-d.done:
+			return
+		case b := <-d.batches:
+			if b.decoded {
+				d.monitor.dispatchFn(b.samples)
+				continue
+			}
+			eventMap := d.monitor.events.getMap()
+			batch := b.samples[:0]
+			for _, esm := range b.samples {
+				event, ok := eventMap[esm.EventID]
+				if !ok {
+					continue
+				}
+				if esm.Err == nil {
+					d.monitor.decodeGroupSample(event, &esm,
+						b.readTime)
+					if esm.Err == nil && esm.DecodedSample == nil {
+						continue
+					}
+				}
+				batch = append(batch, esm)
+			}
+			if len(batch) > 0 {
+				d.monitor.dispatchFn(batch)
+			}
+		}
+	}
+}
+
+// GroupDecodeStatistics describes the decoding of an event group's samples
+// since it was registered.
+type GroupDecodeStatistics struct {
+	GroupID int32
+
+	// Whether the group's samples are decoded by a goroutine of its own
+	Isolated bool
+
+	// The number of samples decoded, and the total time spent decoding
+	// them
+	Samples     uint64
+	DecodeNanos uint64
+
+	// The total and maximum time from when samples were read from the
+	// ringbuffers until they were decoded
+	LatencyNanos    uint64
+	MaxLatencyNanos uint64
+
+	// The number of batches of samples waiting for an isolated group's
+	// goroutine, and the number of samples dropped because it fell too
+	// far behind
+	QueuedBatches  int
+	DroppedSamples uint64
+}
+
+// DecodeStatistics returns the decoding statistics of each registered event
+// group, ordered by group ID.
+func (monitor *EventMonitor) DecodeStatistics() []GroupDecodeStatistics {
+	monitor.lock.Lock()
+	stats := make([]GroupDecodeStatistics, 0, len(monitor.groups))
+	for _, group := range monitor.groups {
+		s := group.decodeStats
+		gs := GroupDecodeStatistics{
+			GroupID:         group.groupID,
+			Isolated:        group.decoder != nil,
+			Samples:         atomic.LoadUint64(&s.samples),
+			DecodeNanos:     atomic.LoadUint64(&s.decodeNanos),
+			LatencyNanos:    atomic.LoadUint64(&s.latencyNanos),
+			MaxLatencyNanos: atomic.LoadUint64(&s.maxLatencyNanos),
+			DroppedSamples:  atomic.LoadUint64(&s.droppedSamples),
+		}
+		if group.decoder != nil {
+			gs.QueuedBatches = len(group.decoder.batches)
+		}
+		stats = append(stats, gs)
+	}
+	monitor.lock.Unlock()
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].GroupID < stats[j].GroupID
+	})
+	return stats
+}
@@ -27,6 +27,28 @@ import (
 // the representation of sample data parsed from a Linux kernel sample.
 type TraceEventSampleData map[string]interface{}
 
+// TraceEventFaultedFields is the key in TraceEventSampleData of the names of
+// the string fields that the kernel could not fetch, e.g. because a kprobe
+// fetcharg referred to memory that could not be read. Its value is a
+// map[string]bool, and it is only present if a field faulted.
+const TraceEventFaultedFields = "__faulted__"
+
+// Faulted returns whether the kernel could not fetch the value of the named
+// field. The field's value is then empty.
+func (data TraceEventSampleData) Faulted(name string) bool {
+	faulted, _ := data[TraceEventFaultedFields].(map[string]bool)
+	return faulted[name]
+}
+
+func (data TraceEventSampleData) setFaulted(name string) {
+	faulted, ok := data[TraceEventFaultedFields].(map[string]bool)
+	if !ok {
+		faulted = make(map[string]bool)
+		data[TraceEventFaultedFields] = faulted
+	}
+	faulted[name] = true
+}
+
 // TraceEventDecoderFn is the signature of a function to call to decode a
 // sample. The first argument is the sample to be decoded, and the second
 // is the parsed sample data.
@@ -94,6 +116,12 @@ func (d *traceEventDecoder) decodeRawData(rawData []byte) (TraceEventSampleData,
 			}
 
 			if field.dataType == TraceEventFieldTypeString {
+				// Fetched strings include their terminating
+				// NUL, so an empty field means the fetch
+				// faulted.
+				if dataLength == 0 {
+					data.setFaulted(field.FieldName)
+				}
 				if dataLength > 0 && rawData[dataOffset+dataLength-1] == 0 {
 					dataLength--
 				}
@@ -25,6 +25,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"runtime"
 	"sort"
 	"strings"
 	"sync"
@@ -55,6 +56,8 @@ type eventMonitorOptions struct {
 	ringBufferNumPages int
 	cgroups            []string
 	pids               []int
+	overflowFn         RingBufferOverflowFn
+	decodeDropFn       IsolatedDecodeDropFn
 }
 
 // EventMonitorOption is used to implement optional arguments for
@@ -104,6 +107,23 @@ func WithRingBufferNumPages(numPages int) EventMonitorOption {
 	}
 }
 
+// WithRingBufferOverflowFn is used to set a function to call when records
+// are lost because a ringbuffer was full.
+func WithRingBufferOverflowFn(fn RingBufferOverflowFn) EventMonitorOption {
+	return func(o *eventMonitorOptions) {
+		o.overflowFn = fn
+	}
+}
+
+// WithIsolatedDecodeDropFn is used to set a function to call when samples of
+// an event group with isolated decoding were dropped because its goroutine
+// fell behind.
+func WithIsolatedDecodeDropFn(fn IsolatedDecodeDropFn) EventMonitorOption {
+	return func(o *eventMonitorOptions) {
+		o.decodeDropFn = fn
+	}
+}
+
 // WithCgroup is used to add a cgroup to the set of sources to monitor.
 func WithCgroup(cgroup string) EventMonitorOption {
 	return func(o *eventMonitorOptions) {
@@ -194,6 +214,48 @@ func WithEventGroup(groupID int32) RegisterEventOption {
 	}
 }
 
+type eventGroupOptions struct {
+	clockID            int32
+	useClockID         bool
+	isolated           bool
+	ringBufferNumPages int
+}
+
+// EventGroupOption is used to implement optional arguments for
+// RegisterEventGroup. It must be exported, but it is not typically used
+// directly.
+type EventGroupOption func(*eventGroupOptions)
+
+// WithClockID is used to register an event group whose events are
+// timestamped by the kernel using the specified clock (e.g.,
+// unix.CLOCK_BOOTTIME) rather than the EventMonitor's clock. The time of each
+// sample is still normalized to the EventMonitor's clock so that samples are
+// ordered consistently; the time read from the group's clock is reported in
+// SampleRecord.ClockTime. Kernels that do not support use_clockid can not
+// register such groups (see HaveClockID).
+func WithClockID(clockID int32) EventGroupOption {
+	return func(o *eventGroupOptions) {
+		o.clockID = clockID
+		o.useClockID = true
+	}
+}
+
+// WithGroupRingBufferNumPages is used to register an event group whose
+// ringbuffers have a different size than the EventMonitor's default. The
+// number of pages must be a power of 2.
+func WithGroupRingBufferNumPages(numPages int) EventGroupOption {
+	return func(o *eventGroupOptions) {
+		o.ringBufferNumPages = numPages
+	}
+}
+
+// HaveClockID returns true if the running kernel supports selecting the
+// clock used to timestamp samples. It is only valid once an EventMonitor has
+// been created.
+func HaveClockID() bool {
+	return haveClockID
+}
+
 // EventType represents the type of an event (tracepoint, external, etc.)
 type EventType int
 
@@ -416,6 +478,18 @@ func (d traceEventSampleDecoder) decodeSample(
 	esm.DecodedSample = s
 }
 
+// DecoderPanicError is the error for a sample whose decoder panicked because
+// the sample was malformed. The panic is recovered so that one bad sample
+// does not take down the monitor.
+type DecoderPanicError struct {
+	// Value is the value passed to panic
+	Value interface{}
+}
+
+func (e DecoderPanicError) Error() string {
+	return fmt.Sprintf("Decoder panic: %v", e.Value)
+}
+
 type registeredEvent struct {
 	id        uint64
 	name      string
@@ -425,6 +499,43 @@ type registeredEvent struct {
 	eventType EventType
 	group     *eventMonitorGroup
 	leader    bool
+
+	// Whether the event is meant to be enabled. Protected by the
+	// monitor's lock.
+	enabled bool
+}
+
+// isSafeDecoderPanic returns whether a decoder panic is known to be caused by
+// a malformed sample rather than by a bug that may have left shared state
+// inconsistent: an index or slice of the sample's data that is out of range,
+// or a failed type assertion on one of its fields.
+func isSafeDecoderPanic(r interface{}) bool {
+	switch e := r.(type) {
+	case *runtime.TypeAssertionError:
+		return true
+	case runtime.Error:
+		return strings.Contains(e.Error(), "out of range")
+	}
+	return false
+}
+
+// decodeSample decodes a sample with the event's decoder, recovering from
+// panics in the decoder that are caused by malformed samples. Any other
+// panic is not recovered.
+func (event *registeredEvent) decodeSample(
+	esm *EventMonitorSample,
+	monitor *EventMonitor,
+) {
+	defer func() {
+		if r := recover(); r != nil {
+			if !isSafeDecoderPanic(r) {
+				panic(r)
+			}
+			esm.DecodedSample = nil
+			esm.Err = DecoderPanicError{Value: r}
+		}
+	}()
+	event.decoder.decodeSample(esm, monitor)
 }
 
 const (
@@ -448,9 +559,16 @@ type perfGroupLeader struct {
 	// any point in the future.
 	state int32
 
+	// The group that the leader belongs to and its index in the group's
+	// leaders, which is also the index of its fd in each of the group's
+	// events. Immutable once the group is created.
+	group *eventMonitorGroup
+	index int
+
 	// Mutable only by the monitor goroutine while running. No
 	// synchronization is required.
 	pendingSamples []EventMonitorSample
+	lastSampleTime uint64
 }
 
 func (pgl *perfGroupLeader) cleanup() {
@@ -471,9 +589,30 @@ type eventMonitorGroup struct {
 	events  map[uint64]*registeredEvent
 	monitor *EventMonitor
 	groupID int32
+
+	// If useClockID is true, the group's events are timestamped using
+	// clockID rather than the EventMonitor's clock.
+	clockID    int32
+	useClockID bool
+
+	// The statistics of the decoding of the group's samples, and the
+	// goroutine that decodes them if the group was registered with
+	// WithIsolatedDecoding. Immutable once the group is created.
+	decodeStats *groupDecodeStats
+	decoder     *isolatedDecoder
+}
+
+// clockOffset returns the current difference between the group's clock and
+// the EventMonitor's clock.
+func (group *eventMonitorGroup) clockOffset() int64 {
+	return sys.CurrentClockTime(group.clockID) - sys.CurrentMonotonicRaw()
 }
 
 func (group *eventMonitorGroup) cleanup() {
+	if group.decoder != nil {
+		group.decoder.stop()
+	}
+
 	// First we disable all events. This is necessary because of CentOS 6's
 	// kernel bugs that could cause a kernel panic if we don't do this.
 	group.disable()
@@ -495,6 +634,7 @@ func (group *eventMonitorGroup) disable() {
 		for _, fd := range event.fds {
 			disable(fd)
 		}
+		event.enabled = false
 	}
 }
 
@@ -503,6 +643,7 @@ func (group *eventMonitorGroup) enable() {
 		for _, fd := range event.fds {
 			enable(fd)
 		}
+		event.enabled = true
 	}
 }
 
@@ -574,6 +715,19 @@ type EventMonitor struct {
 	// required.
 	hasPendingSamples bool
 
+	// Immutable. Called by the monitor goroutine when records are lost.
+	overflowFn RingBufferOverflowFn
+
+	// Immutable. Called by the decoding goroutine when samples of an
+	// isolated group were dropped.
+	decodeDropFn IsolatedDecodeDropFn
+
+	// Held while any decoder runs. Decoders share state, such as the
+	// process information maintained by the decoders of external
+	// samples, so those of isolated groups never run concurrently with
+	// the others; only their queues and dispatch are isolated.
+	decodeMutex sync.Mutex
+
 	// Immutable once set. Only used by the dispatchSampleLoop goroutine.
 	// Load once there and cache locally to avoid cache misses on this
 	// struct.
@@ -610,6 +764,10 @@ type EventMonitor struct {
 	externalSamples        externalSampleList
 	nextExternalSampleTime uint64
 
+	// The decoders of the groups in groups with isolated decoding. The
+	// slice is replaced rather than modified when groups change.
+	isolatedDecoders []*isolatedDecoder
+
 	// Immutable, used only when adding new tracepoints/probes
 	defaultAttr EventAttr
 	tracingDir  string
@@ -625,8 +783,9 @@ type EventMonitor struct {
 }
 
 type queuedSamples struct {
-	next    *queuedSamples
-	samples [][]EventMonitorSample
+	next     *queuedSamples
+	samples  [][]EventMonitorSample
+	readTime time.Time
 }
 
 func fixupEventAttr(eventAttr *EventAttr) {
@@ -794,6 +953,7 @@ func (monitor *EventMonitor) newRegisteredEvent(
 		eventType: eventType,
 		group:     group,
 		leader:    leader,
+		enabled:   attr != nil && !attr.Disabled,
 	}
 	group.events[eventid] = event
 
@@ -850,6 +1010,12 @@ func (monitor *EventMonitor) newRegisteredPerfEvent(
 	if !ok {
 		return 0, fmt.Errorf("Group ID %d does not exist", opts.groupID)
 	}
+	if group.useClockID {
+		// The kernel requires that all events in a group use the
+		// group leader's clock.
+		attr.UseClockID = true
+		attr.ClockID = group.clockID
+	}
 
 	newfds, err := group.perfEventOpen(name, &attr, opts.filter, flags)
 	if err != nil {
@@ -986,7 +1152,7 @@ func (monitor *EventMonitor) RegisterCounterEventGroup(
 	leaderAttr.Type = perfTypeFromEventType(counters[0].EventType)
 	leaderAttr.Config = counters[0].Config
 	leaderAttr.Pinned = true
-	group, err := monitor.newEventGroup(&leaderAttr)
+	group, err := monitor.newEventGroup(&leaderAttr, eventGroupOptions{})
 	if err != nil {
 		return 0, 0, err
 	}
@@ -1348,6 +1514,7 @@ func (monitor *EventMonitor) Disable(eventid uint64) {
 		for _, fd := range event.fds {
 			disable(fd)
 		}
+		event.enabled = false
 	}
 }
 
@@ -1360,6 +1527,7 @@ func (monitor *EventMonitor) DisableAll() {
 	for fd := range monitor.eventfds {
 		disable(fd)
 	}
+	monitor.setAllEnabled(false)
 }
 
 // DisableGroup disables all events for an event group.
@@ -1389,6 +1557,7 @@ func (monitor *EventMonitor) Enable(eventid uint64) {
 		for _, fd := range event.fds {
 			enable(fd)
 		}
+		event.enabled = true
 	}
 }
 
@@ -1401,6 +1570,16 @@ func (monitor *EventMonitor) EnableAll() {
 	for fd := range monitor.eventfds {
 		enable(fd)
 	}
+	monitor.setAllEnabled(true)
+}
+
+func (monitor *EventMonitor) setAllEnabled(enabled bool) {
+	// This should be called with monitor.lock held.
+	for _, group := range monitor.groups {
+		for _, event := range group.events {
+			event.enabled = enabled
+		}
+	}
 }
 
 // EnableGroup enables all events for an event group.
@@ -1464,6 +1643,15 @@ type EventMonitorSample struct {
 
 	// Err will be non-nil if any occurred during processing of RawSample.
 	Err error
+
+	// External is set for samples enqueued by EnqueueExternalSample.
+	// They are dispatched by the shared decoding goroutine and, so that
+	// they are in order with the group's own samples, again by the
+	// goroutine of each event group with isolated decoding, with Isolated
+	// set and IsolatedGroupID set to the ID of the group.
+	External        bool
+	Isolated        bool
+	IsolatedGroupID int32
 }
 
 // externalSampleList implements sort.Interface and is used for sorting a list
@@ -1505,6 +1693,7 @@ func (monitor *EventMonitor) EnqueueExternalSample(
 	esm := EventMonitorSample{
 		EventID:     eventID,
 		DecodedData: decodedData,
+		External:    true,
 	}
 	esm.RawSample.Type = PERF_RECORD_SAMPLE
 	esm.RawSample.Record = &SampleRecord{
@@ -1570,7 +1759,9 @@ func (monitor *EventMonitor) processExternalSamples(timeLimit uint64) bool {
 			continue
 		}
 		if esm.Err == nil {
-			event.decoder.decodeSample(&esm, monitor)
+			monitor.decodeMutex.Lock()
+			event.decodeSample(&esm, monitor)
+			monitor.decodeMutex.Unlock()
 			if esm.Err != nil || esm.DecodedSample != nil {
 				batch = append(batch, esm)
 			}
@@ -1594,6 +1785,18 @@ func (monitor *EventMonitor) processExternalSamples(timeLimit uint64) bool {
 	}
 
 	if len(batch) > 0 {
+		monitor.lock.Lock()
+		decoders := monitor.isolatedDecoders
+		monitor.lock.Unlock()
+		for _, d := range decoders {
+			copied := make([]EventMonitorSample, len(batch))
+			for i := range batch {
+				copied[i] = batch[i]
+				copied[i].Isolated = true
+				copied[i].IsolatedGroupID = d.group.groupID
+			}
+			d.enqueueDecoded(copied)
+		}
 		monitor.dispatchFn(batch)
 		return true
 	}
@@ -1654,7 +1857,13 @@ func newSampleMerger(samples [][]EventMonitorSample) sampleMerger {
 	}
 }
 
-func (monitor *EventMonitor) dispatchSamples(samples [][]EventMonitorSample) {
+// dispatchSamples decodes samples that were read from the ringbuffers at
+// readTime and dispatches them in time order. The samples of groups with
+// isolated decoding are handed to their own goroutines undecoded.
+func (monitor *EventMonitor) dispatchSamples(
+	samples [][]EventMonitorSample,
+	readTime time.Time,
+) {
 	dispatchFn := monitor.dispatchFn
 	eventIDMap := monitor.eventIDMap.getMap()
 	eventMap := monitor.events.getMap()
@@ -1664,6 +1873,7 @@ func (monitor *EventMonitor) dispatchSamples(samples [][]EventMonitorSample) {
 		nsamples += len(s)
 	}
 	batch := make([]EventMonitorSample, 0, nsamples)
+	var isolated map[*isolatedDecoder][]EventMonitorSample
 
 	m := newSampleMerger(samples)
 	for {
@@ -1679,6 +1889,12 @@ func (monitor *EventMonitor) dispatchSamples(samples [][]EventMonitorSample) {
 				batch = make([]EventMonitorSample, 0,
 					nsamples-len(batch))
 			}
+			// Isolated groups are given the samples before
+			// the external samples that follow them.
+			for d, s := range isolated {
+				d.enqueue(s, readTime)
+			}
+			isolated = nil
 			monitor.processExternalSamples(esm.RawSample.Time)
 		}
 
@@ -1704,19 +1920,29 @@ func (monitor *EventMonitor) dispatchSamples(samples [][]EventMonitorSample) {
 			// matches the normalized timestamp.
 			record.Time = esm.RawSample.Time
 		}
-		if esm.Err == nil {
-			event.decoder.decodeSample(&esm, monitor)
+		if esm.Err != nil {
+			batch = append(batch, esm)
+		} else if g := event.group; g != nil && g.decoder != nil {
+			if isolated == nil {
+				isolated = make(map[*isolatedDecoder][]EventMonitorSample)
+			}
+			isolated[g.decoder] = append(isolated[g.decoder], esm)
+		} else {
+			monitor.decodeGroupSample(event, &esm, readTime)
 			if esm.Err != nil || esm.DecodedSample != nil {
 				batch = append(batch, esm)
 			}
-		} else {
-			batch = append(batch, esm)
 		}
 		if esm.RawSample.Time > monitor.lastSampleTimeDispatched {
 			monitor.lastSampleTimeDispatched = esm.RawSample.Time
 		}
 	}
 
+	// Isolated groups are queued first so that they are not delayed by
+	// waiting for the dispatch of the shared batch.
+	for d, s := range isolated {
+		d.enqueue(s, readTime)
+	}
 	if len(batch) > 0 {
 		dispatchFn(batch)
 	}
@@ -1727,7 +1953,10 @@ func (monitor *EventMonitor) dispatchSampleLoop() {
 	defer monitor.wg.Done()
 
 	for monitor.isRunning {
-		var samples [][]EventMonitorSample
+		var (
+			samples  [][]EventMonitorSample
+			readTime time.Time
+		)
 
 		monitor.lock.Lock()
 		if !monitor.isRunning {
@@ -1737,6 +1966,7 @@ func (monitor *EventMonitor) dispatchSampleLoop() {
 		qs := monitor.dispatchQueueHead
 		if qs != nil {
 			samples = qs.samples
+			readTime = qs.readTime
 			monitor.dispatchQueueHead = qs.next
 			if monitor.dispatchQueueHead == nil {
 				monitor.dispatchQueueTail = nil
@@ -1752,7 +1982,7 @@ func (monitor *EventMonitor) dispatchSampleLoop() {
 		monitor.lock.Unlock()
 
 		if len(samples) > 0 {
-			monitor.dispatchSamples(samples)
+			monitor.dispatchSamples(samples, readTime)
 		} else {
 			now := sys.CurrentMonotonicRaw()
 			monitor.processExternalSamples(uint64(now))
@@ -1775,6 +2005,7 @@ func (monitor *EventMonitor) enqueueSamples(samples [][]EventMonitorSample) {
 		qs.next = nil
 	}
 	qs.samples = samples
+	qs.readTime = time.Now()
 
 	if monitor.dispatchQueueTail == nil {
 		monitor.dispatchQueueHead = qs
@@ -1793,7 +2024,10 @@ func (monitor *EventMonitor) readRingBuffers() {
 	// samples may be added and so this flag will be updated later.
 	monitor.hasPendingSamples = false
 
-	var lastTimestamp uint64
+	var (
+		lastTimestamp uint64
+		overflows     []*ringBufferOverflow
+	)
 	fds := make(map[int]bool)
 	groupLeaders := monitor.groupLeaders.getMap()
 	samples := make([][]EventMonitorSample, 0, len(groupLeaders))
@@ -1815,19 +2049,56 @@ func (monitor *EventMonitor) readRingBuffers() {
 			continue
 		}
 
+		var (
+			overflow    *ringBufferOverflow
+			clockOffset int64
+		)
+		useClockID := pgl.group != nil && pgl.group.useClockID
+		if useClockID {
+			clockOffset = pgl.group.clockOffset()
+		}
 		pgl.rb.read(func(data []byte) {
 			attrMap := monitor.eventAttrMap.getMap()
 			r := bytes.NewReader(data)
 			for r.Len() > 0 {
 				ems := EventMonitorSample{}
 				ems.Err = ems.RawSample.read(r, nil, attrMap)
+				if useClockID {
+					if sr, ok := ems.RawSample.Record.(*SampleRecord); ok {
+						sr.ClockTime = ems.RawSample.Time
+					}
+					ems.RawSample.Time = uint64(
+						int64(ems.RawSample.Time) -
+							clockOffset)
+				}
 				ems.RawSample.Time =
 					uint64(int64(ems.RawSample.Time) -
 						timeOffsets[pgl.cpu] +
 						timeBase)
+
+				// Lost records are not samples of any
+				// event, so they are collected here
+				// rather than dispatched.
+				if lr, ok := ems.RawSample.Record.(*LostRecord); ok && ems.Err == nil {
+					if overflow == nil {
+						overflow = &ringBufferOverflow{
+							pgl: pgl,
+							RingBufferOverflow: RingBufferOverflow{
+								StartTime: pgl.lastSampleTime,
+							},
+						}
+					}
+					overflow.Lost += lr.Lost
+					overflow.EndTime = ems.RawSample.Time
+					continue
+				}
+				pgl.lastSampleTime = ems.RawSample.Time
 				groupSamples = append(groupSamples, ems)
 			}
 		})
+		if overflow != nil {
+			overflows = append(overflows, overflow)
+		}
 
 		if len(groupSamples) == 0 {
 			if len(pgl.pendingSamples) > 0 {
@@ -1870,6 +2141,71 @@ func (monitor *EventMonitor) readRingBuffers() {
 	}
 
 	monitor.enqueueSamples(samples)
+
+	for _, o := range overflows {
+		monitor.recoverRingBuffer(o)
+	}
+}
+
+// RingBufferOverflow describes records lost because a ringbuffer was full.
+type RingBufferOverflow struct {
+	// The event group and CPU of the ringbuffer
+	GroupID int32
+	CPU     int
+
+	// The number of records that the kernel reported lost
+	Lost uint64
+
+	// The window in which records were lost, normalized like sample
+	// times. It begins with the last record read from the ringbuffer
+	// before the loss, or is 0 if there was none, and ends when the
+	// kernel reported the loss.
+	StartTime uint64
+	EndTime   uint64
+
+	// The number of events on the CPU that were enabled again once the
+	// ringbuffer was drained
+	Reenabled int
+}
+
+// RingBufferOverflowFn is the signature of a function called when records
+// are lost because a ringbuffer was full.
+type RingBufferOverflowFn func(RingBufferOverflow)
+
+type ringBufferOverflow struct {
+	RingBufferOverflow
+	pgl *perfGroupLeader
+}
+
+// recoverRingBuffer enables the events that write to a ringbuffer that has
+// overflowed and been drained. Some kernels disable an event when its
+// ringbuffer is full and never enable it again. The kernel does not report
+// that it has done so, but enabling an event that is already enabled has no
+// effect, so each event that is meant to be enabled is enabled again.
+func (monitor *EventMonitor) recoverRingBuffer(o *ringBufferOverflow) {
+	monitor.lock.Lock()
+	group := o.pgl.group
+	if group == nil || atomic.LoadInt32(&o.pgl.state) != perfGroupLeaderStateActive {
+		monitor.lock.Unlock()
+		return
+	}
+	o.GroupID = group.groupID
+	o.CPU = o.pgl.cpu
+	for _, event := range group.events {
+		if !event.enabled || o.pgl.index >= len(event.fds) {
+			continue
+		}
+		if enable(event.fds[o.pgl.index]) == nil {
+			o.Reenabled++
+		}
+	}
+	monitor.lock.Unlock()
+
+	glog.V(1).Infof("Ringbuffer for group %d on CPU %d lost %d records; enabled %d events",
+		o.GroupID, o.CPU, o.Lost, o.Reenabled)
+	if monitor.overflowFn != nil {
+		monitor.overflowFn(o.RingBufferOverflow)
+	}
 }
 
 func (monitor *EventMonitor) flushPendingSamples() {
@@ -2187,6 +2523,7 @@ func (monitor *EventMonitor) initializeGroupLeaders(
 	pid int,
 	flags uintptr,
 	attr *EventAttr,
+	numPages int,
 ) ([]*perfGroupLeader, error) {
 	if attr == nil {
 		attr = &groupEventAttr
@@ -2210,7 +2547,7 @@ func (monitor *EventMonitor) initializeGroupLeaders(
 			state: perfGroupLeaderStateActive,
 		}
 
-		pgls[cpu].rb, err = newRingBuffer(fd, monitor.ringBufferNumPages)
+		pgls[cpu].rb, err = newRingBuffer(fd, numPages)
 		if err != nil {
 			break
 		}
@@ -2241,7 +2578,26 @@ func (monitor *EventMonitor) initializeGroupLeaders(
 
 func (monitor *EventMonitor) newEventGroup(
 	attr *EventAttr,
+	opts eventGroupOptions,
 ) (*eventMonitorGroup, error) {
+	if opts.useClockID {
+		if !haveClockID {
+			return nil, errors.New("Kernel does not support use_clockid")
+		}
+		if attr == nil {
+			attr = &groupEventAttr
+		}
+		a := *attr
+		a.UseClockID = true
+		a.ClockID = opts.clockID
+		attr = &a
+	}
+
+	numPages := opts.ringBufferNumPages
+	if numPages <= 0 {
+		numPages = monitor.ringBufferNumPages
+	}
+
 	ncpu := sys.HostProcFS().NumCPU()
 	nleaders := (len(monitor.cgroups) + len(monitor.pids)) * ncpu
 	leaders := make([]*perfGroupLeader, 0, nleaders)
@@ -2250,7 +2606,7 @@ func (monitor *EventMonitor) newEventGroup(
 		flags := monitor.perfEventOpenFlags | PERF_FLAG_PID_CGROUP
 		for _, fd := range monitor.cgroups {
 			pgls, err := monitor.initializeGroupLeaders(fd, flags,
-				attr)
+				attr, numPages)
 			if err != nil {
 				for _, pgl := range leaders {
 					pgl.cleanup()
@@ -2265,7 +2621,7 @@ func (monitor *EventMonitor) newEventGroup(
 		flags := monitor.perfEventOpenFlags
 		for _, pid := range monitor.pids {
 			pgls, err := monitor.initializeGroupLeaders(pid, flags,
-				attr)
+				attr, numPages)
 			if err != nil {
 				for _, pgl := range leaders {
 					pgl.cleanup()
@@ -2276,11 +2632,22 @@ func (monitor *EventMonitor) newEventGroup(
 		}
 	}
 
-	return &eventMonitorGroup{
-		leaders: leaders,
-		events:  make(map[uint64]*registeredEvent),
-		monitor: monitor,
-	}, nil
+	group := &eventMonitorGroup{
+		leaders:     leaders,
+		events:      make(map[uint64]*registeredEvent),
+		monitor:     monitor,
+		clockID:     opts.clockID,
+		useClockID:  opts.useClockID,
+		decodeStats: &groupDecodeStats{},
+	}
+	for i, pgl := range leaders {
+		pgl.group = group
+		pgl.index = i
+	}
+	if opts.isolated {
+		group.decoder = newIsolatedDecoder(monitor, group)
+	}
+	return group, nil
 }
 
 func (monitor *EventMonitor) registerNewEventGroup(group *eventMonitorGroup) {
@@ -2289,6 +2656,12 @@ func (monitor *EventMonitor) registerNewEventGroup(group *eventMonitorGroup) {
 	group.groupID = monitor.nextGroupID
 	monitor.nextGroupID++
 	monitor.groups[group.groupID] = group
+	if group.decoder != nil {
+		decoders := make([]*isolatedDecoder, 0,
+			len(monitor.isolatedDecoders)+1)
+		decoders = append(decoders, monitor.isolatedDecoders...)
+		monitor.isolatedDecoders = append(decoders, group.decoder)
+	}
 
 	if monitor.isRunning {
 		monitor.groupLeaders.update(group.leaders)
@@ -2299,8 +2672,16 @@ func (monitor *EventMonitor) registerNewEventGroup(group *eventMonitorGroup) {
 
 // RegisterEventGroup creates a new event group that can be used for grouping
 // events.
-func (monitor *EventMonitor) RegisterEventGroup(name string) (int32, error) {
-	group, err := monitor.newEventGroup(nil)
+func (monitor *EventMonitor) RegisterEventGroup(
+	name string,
+	options ...EventGroupOption,
+) (int32, error) {
+	var opts eventGroupOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
+	group, err := monitor.newEventGroup(nil, opts)
 	if err != nil {
 		return -1, err
 	}
@@ -2321,6 +2702,16 @@ func (monitor *EventMonitor) unregisterEventGroup(group *eventMonitorGroup) {
 	// This should be called with monitor.lock LOCKED!!
 
 	delete(monitor.groups, group.groupID)
+	if group.decoder != nil {
+		decoders := make([]*isolatedDecoder, 0,
+			len(monitor.isolatedDecoders))
+		for _, d := range monitor.isolatedDecoders {
+			if d != group.decoder {
+				decoders = append(decoders, d)
+			}
+		}
+		monitor.isolatedDecoders = decoders
+	}
 
 	group.cleanup()
 
@@ -2507,6 +2898,8 @@ func NewEventMonitor(options ...EventMonitorOption) (*EventMonitor, error) {
 		tracingDir:         opts.tracingDir,
 		ringBufferNumPages: opts.ringBufferNumPages,
 		perfEventOpenFlags: opts.flags,
+		overflowFn:         opts.overflowFn,
+		decodeDropFn:       opts.decodeDropFn,
 	}
 	monitor.cond = sync.Cond{L: &monitor.lock}
 
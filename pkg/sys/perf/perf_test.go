@@ -17,7 +17,11 @@ package perf
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
+	"sync"
+	"syscall"
 	"testing"
+	"time"
 )
 
 //
@@ -110,3 +114,316 @@ func TestPack(t *testing.T) {
 	}
 
 }
+
+func TestDecodeSamplePanic(t *testing.T) {
+	event := &registeredEvent{
+		decoder: externalEventSampleDecoder{
+			decoderFn: func(sr *SampleRecord, data TraceEventSampleData) (interface{}, error) {
+				return sr.RawData[4], nil
+			},
+		},
+	}
+	esm := EventMonitorSample{}
+	esm.RawSample.Record = &SampleRecord{}
+	event.decodeSample(&esm, nil)
+	if _, ok := esm.Err.(DecoderPanicError); !ok {
+		t.Fatalf("Expected DecoderPanicError, got %v", esm.Err)
+	}
+	if esm.DecodedSample != nil {
+		t.Errorf("Expected no decoded sample, got %v", esm.DecodedSample)
+	}
+
+	event.decoder = externalEventSampleDecoder{
+		decoderFn: func(sr *SampleRecord, data TraceEventSampleData) (interface{}, error) {
+			return data["pid"].(int32), nil
+		},
+	}
+	esm.Err = nil
+	event.decodeSample(&esm, nil)
+	if _, ok := esm.Err.(DecoderPanicError); !ok {
+		t.Fatalf("Expected DecoderPanicError, got %v", esm.Err)
+	}
+
+	// Panics that are not caused by malformed samples are not recovered
+	event.decoder = externalEventSampleDecoder{
+		decoderFn: func(*SampleRecord, TraceEventSampleData) (interface{}, error) {
+			panic("bad state")
+		},
+	}
+	func() {
+		defer func() {
+			if r := recover(); r != "bad state" {
+				t.Errorf("Expected panic to propagate, got %v", r)
+			}
+		}()
+		event.decodeSample(&esm, nil)
+	}()
+
+	decodeErr := errors.New("short sample")
+	event.decoder = externalEventSampleDecoder{
+		decoderFn: func(*SampleRecord, TraceEventSampleData) (interface{}, error) {
+			return nil, decodeErr
+		},
+	}
+	esm.Err = nil
+	event.decodeSample(&esm, nil)
+	if esm.Err != decodeErr {
+		t.Errorf("Expected %v, got %v", decodeErr, esm.Err)
+	}
+}
+
+func TestDecodeRawDataFaultedString(t *testing.T) {
+	d := &traceEventDecoder{
+		fields: map[string]traceEventField{
+			"path0": {
+				FieldName:   "path0",
+				Offset:      0,
+				dataType:    TraceEventFieldTypeString,
+				dataLocSize: 4,
+			},
+			"path1": {
+				FieldName:   "path1",
+				Offset:      4,
+				dataType:    TraceEventFieldTypeString,
+				dataLocSize: 4,
+			},
+		},
+	}
+
+	// path0 is "/tmp" and path1 faulted, leaving an empty data_loc
+	rawData := []byte{8, 0, 5, 0, 13, 0, 0, 0, '/', 't', 'm', 'p', 0}
+	data, err := d.decodeRawData(rawData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data["path0"] != "/tmp" || data.Faulted("path0") {
+		t.Errorf("Expected path0 to be captured, got %q", data["path0"])
+	}
+	if data["path1"] != "" || !data.Faulted("path1") {
+		t.Errorf("Expected path1 to be faulted, got %q", data["path1"])
+	}
+}
+
+func TestRecoverRingBuffer(t *testing.T) {
+	var overflows []RingBufferOverflow
+	monitor := &EventMonitor{
+		overflowFn: func(o RingBufferOverflow) {
+			overflows = append(overflows, o)
+		},
+	}
+	group := &eventMonitorGroup{
+		groupID: 3,
+		events: map[uint64]*registeredEvent{
+			1: {id: 1, fds: []int{-1, -1}, enabled: true},
+			2: {id: 2, fds: []int{-1, -1}},
+		},
+		monitor: monitor,
+	}
+	pgl := &perfGroupLeader{
+		cpu:   1,
+		group: group,
+		index: 1,
+		state: perfGroupLeaderStateActive,
+	}
+
+	monitor.recoverRingBuffer(&ringBufferOverflow{
+		RingBufferOverflow: RingBufferOverflow{
+			Lost:      5,
+			StartTime: 100,
+			EndTime:   200,
+		},
+		pgl: pgl,
+	})
+	if len(overflows) != 1 {
+		t.Fatalf("Expected 1 overflow reported, got %d", len(overflows))
+	}
+	o := overflows[0]
+	if o.GroupID != 3 || o.CPU != 1 || o.Lost != 5 ||
+		o.StartTime != 100 || o.EndTime != 200 {
+		t.Errorf("Unexpected overflow %+v", o)
+	}
+
+	// Overflows of leaders that are being removed are not reported
+	pgl.state = perfGroupLeaderStateClosing
+	monitor.recoverRingBuffer(&ringBufferOverflow{pgl: pgl})
+	if len(overflows) != 1 {
+		t.Errorf("Expected no overflow reported for closing leader")
+	}
+}
+
+func TestGroupDecodeStats(t *testing.T) {
+	var s groupDecodeStats
+	s.record(2*time.Microsecond, 30*time.Microsecond)
+	s.record(4*time.Microsecond, 10*time.Microsecond)
+
+	if s.samples != 2 {
+		t.Errorf("Expected 2 samples, got %d", s.samples)
+	}
+	if s.decodeNanos != 6000 {
+		t.Errorf("Expected 6000 decode nanos, got %d", s.decodeNanos)
+	}
+	if s.latencyNanos != 40000 {
+		t.Errorf("Expected 40000 latency nanos, got %d", s.latencyNanos)
+	}
+	if s.maxLatencyNanos != 30000 {
+		t.Errorf("Expected 30000 max latency nanos, got %d",
+			s.maxLatencyNanos)
+	}
+}
+
+func TestIsolatedDecoderDrops(t *testing.T) {
+	var drops []IsolatedDecodeDrop
+	d := &isolatedDecoder{
+		monitor: &EventMonitor{
+			decodeDropFn: func(drop IsolatedDecodeDrop) {
+				drops = append(drops, drop)
+			},
+		},
+		group: &eventMonitorGroup{
+			groupID:     7,
+			decodeStats: new(groupDecodeStats),
+		},
+		batches: make(chan isolatedBatch, 1),
+		done:    make(chan struct{}),
+	}
+
+	now := time.Now()
+	d.enqueue(make([]EventMonitorSample, 3), now)
+	d.enqueue(make([]EventMonitorSample, 2), now)
+	d.enqueue(make([]EventMonitorSample, 4), now)
+	if len(drops) != 0 {
+		t.Fatalf("Expected no drops reported while full, got %v", drops)
+	}
+	if n := d.group.decodeStats.droppedSamples; n != 6 {
+		t.Errorf("Expected 6 dropped samples, got %d", n)
+	}
+
+	<-d.batches
+	d.enqueue(make([]EventMonitorSample, 1), now)
+	if len(drops) != 1 || drops[0].GroupID != 7 || drops[0].Dropped != 6 {
+		t.Fatalf("Expected 6 drops reported for group 7, got %v", drops)
+	}
+
+	d.stop()
+	d.enqueue(make([]EventMonitorSample, 1), now)
+	if n := d.group.decodeStats.droppedSamples; n != 6 {
+		t.Errorf("Expected no drops after stop, got %d", n)
+	}
+}
+
+func newTestSample(eventID, time uint64) EventMonitorSample {
+	esm := EventMonitorSample{
+		EventID:     eventID,
+		DecodedData: TraceEventSampleData{},
+	}
+	esm.RawSample.Time = time
+	esm.RawSample.Record = &SampleRecord{Time: time}
+	return esm
+}
+
+// TestIsolatedDecoding checks that the decoders of an isolated group do not
+// race with those of external samples, which update shared state as the
+// sensor's process information is updated when processes clone, and that
+// the group's goroutine dispatches external samples in order with its own.
+// Run with -race.
+func TestIsolatedDecoding(t *testing.T) {
+	const (
+		cloneEventID    = 1
+		isolatedEventID = 2
+		iterations      = 20
+	)
+
+	parents := make(map[int]int)
+	monitor := &EventMonitor{
+		events:     newSafeRegisteredEventMap(),
+		eventIDMap: newSafeUInt64Map(),
+	}
+	if err := syscall.Pipe(monitor.pipe[:]); err != nil {
+		t.Fatal(err)
+	}
+	defer syscall.Close(monitor.pipe[0])
+	defer syscall.Close(monitor.pipe[1])
+
+	var (
+		lock  sync.Mutex
+		times []uint64
+	)
+	monitor.dispatchFn = func(samples []EventMonitorSample) {
+		lock.Lock()
+		for _, esm := range samples {
+			if esm.EventID == isolatedEventID || esm.Isolated {
+				times = append(times, esm.RawSample.Time)
+			}
+		}
+		lock.Unlock()
+	}
+
+	group := &eventMonitorGroup{
+		groupID:     1,
+		decodeStats: new(groupDecodeStats),
+	}
+	monitor.events.insertInPlace(cloneEventID, &registeredEvent{
+		id:        cloneEventID,
+		eventType: EventTypeExternal,
+		decoder: externalEventSampleDecoder{
+			decoderFn: func(sample *SampleRecord, data TraceEventSampleData) (interface{}, error) {
+				parents[int(sample.Time)] = 1
+				return sample.Time, nil
+			},
+		},
+	})
+	monitor.events.insertInPlace(isolatedEventID, &registeredEvent{
+		id:    isolatedEventID,
+		group: group,
+		decoder: externalEventSampleDecoder{
+			decoderFn: func(sample *SampleRecord, data TraceEventSampleData) (interface{}, error) {
+				return parents[int(sample.Time)-1], nil
+			},
+		},
+	})
+	group.decoder = newIsolatedDecoder(monitor, group)
+	defer group.decoder.stop()
+	monitor.isolatedDecoders = []*isolatedDecoder{group.decoder}
+
+	// Each iteration queues three batches for the group, all of which fit
+	// in its queue.
+	var expected []uint64
+	for i := uint64(0); i < iterations; i++ {
+		base := i * 3
+		clone := newTestSample(cloneEventID, base+2)
+		clone.External = true
+		monitor.lock.Lock()
+		monitor.externalSamples = append(monitor.externalSamples, clone)
+		monitor.lock.Unlock()
+
+		monitor.dispatchSamples([][]EventMonitorSample{{
+			newTestSample(isolatedEventID, base+1),
+			newTestSample(isolatedEventID, base+3),
+		}}, time.Now())
+		expected = append(expected, base+1, base+2, base+3)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		lock.Lock()
+		n := len(times)
+		lock.Unlock()
+		if n >= len(expected) || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if len(times) != len(expected) {
+		t.Fatalf("Expected %d samples dispatched for the isolated group, got %d",
+			len(expected), len(times))
+	}
+	for i := range expected {
+		if times[i] != expected[i] {
+			t.Fatalf("Expected sample at time %d, got %d (%v)",
+				expected[i], times[i], times)
+		}
+	}
+}
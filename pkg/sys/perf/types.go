@@ -636,6 +636,11 @@ type SampleRecord struct {
 	Transaction uint64
 	IntrABI     uint64
 	IntrRegs    []uint64
+
+	// ClockTime is the time of the sample as read from the clock of its
+	// event group, if the group was registered WithClockID. Time is
+	// always normalized to the EventMonitor's clock.
+	ClockTime uint64
 }
 
 func (s *SampleRecord) read(reader *bytes.Reader, eventAttr *EventAttr, formatMap map[uint64]*EventAttr) error {
@@ -750,10 +755,20 @@ func (s *SampleRecord) read(reader *bytes.Reader, eventAttr *EventAttr, formatMa
 	}
 
 	if (eventAttr.SampleType&PERF_SAMPLE_REGS_USER) != 0 ||
-		(eventAttr.SampleType&PERF_SAMPLE_STACK_USER) != 0 ||
-		(eventAttr.SampleType&PERF_SAMPLE_WEIGHT) != 0 ||
-		(eventAttr.SampleType&PERF_SAMPLE_DATA_SRC) != 0 ||
-		(eventAttr.SampleType&PERF_SAMPLE_TRANSACTION) != 0 ||
+		(eventAttr.SampleType&PERF_SAMPLE_STACK_USER) != 0 {
+
+		panic("PERF_RECORD_SAMPLE field parsing not implemented")
+	}
+
+	if (eventAttr.SampleType & PERF_SAMPLE_WEIGHT) != 0 {
+		binary.Read(reader, binary.LittleEndian, &s.Weight)
+	}
+
+	if (eventAttr.SampleType & PERF_SAMPLE_DATA_SRC) != 0 {
+		binary.Read(reader, binary.LittleEndian, &s.DataSrc)
+	}
+
+	if (eventAttr.SampleType&PERF_SAMPLE_TRANSACTION) != 0 ||
 		(eventAttr.SampleType&PERF_SAMPLE_REGS_INTR) != 0 {
 
 		panic("PERF_RECORD_SAMPLE field parsing not implemented")
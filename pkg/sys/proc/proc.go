@@ -14,6 +14,8 @@
 
 package proc
 
+import "time"
+
 // FileSystem is an interface for obtaining system information from the Linux
 // proc filesystem.
 type FileSystem interface {
@@ -37,6 +39,10 @@ type FileSystem interface {
 	// be used for things like kprobes.
 	KernelTextSymbolNames() (map[string]string, error)
 
+	// KernelModuleNames returns the names of the currently loaded kernel
+	// modules in the order that the kernel lists them.
+	KernelModuleNames() ([]string, error)
+
 	// ProcessContainerID returns the container ID running the specified
 	// process. If the process is not running inside of a container, the
 	// return will be the empty string.
@@ -46,6 +52,24 @@ type FileSystem interface {
 	// specified process.
 	ProcessCommandLine(pid int) ([]string, error)
 
+	// ProcessExecutable returns the path of the executable that the
+	// specified process is running.
+	ProcessExecutable(pid int) (string, error)
+
+	// ProcessEnvironment returns the named environment variables of the
+	// specified process as they were when it exec'd. Variables that are
+	// not named are never copied out of the environment.
+	ProcessEnvironment(pid int, names []string) (map[string]string, error)
+
+	// ProcessFileDescriptorPath returns the path of the file that the
+	// specified file descriptor of the specified process refers to.
+	ProcessFileDescriptorPath(pid, fd int) (string, error)
+
+	// ProcessFileDescriptors returns up to max of the open file
+	// descriptors of the specified process, lowest first, and whether it
+	// had more.
+	ProcessFileDescriptors(pid, max int) ([]FileDescriptor, bool, error)
+
 	// TaskControlGroups returns the cgroup membership of the specified task.
 	TaskControlGroups(tgid, pid int) ([]ControlGroup, error)
 
@@ -53,9 +77,25 @@ type FileSystem interface {
 	// task.
 	TaskCWD(tgid, pid int) (string, error)
 
+	// TaskMountNamespace returns the inode number identifying the mount
+	// namespace of the specified task.
+	TaskMountNamespace(tgid, pid int) (uint64, error)
+
+	// TaskNamespaces returns the inode numbers identifying the namespaces
+	// of the specified task, by type, e.g. "mnt" or "net".
+	TaskNamespaces(tgid, pid int) (map[string]uint64, error)
+
 	// TaskStartTime returns the time at which the specified task started.
 	TaskStartTime(tgid, pid int) (int64, error)
 
+	// TaskResourceUsage returns the resources used by the specified
+	// task.
+	TaskResourceUsage(tgid, pid int) (ResourceUsage, error)
+
+	// TaskFlags returns the kernel flags (PF_*) of the specified task,
+	// such as PF_KTHREAD.
+	TaskFlags(tgid, pid int) (uint64, error)
+
 	// TaskUniqueID returns a unique task ID for the specified task.
 	TaskUniqueID(tgid, pid int, startTime int64) (string, error)
 
@@ -88,6 +128,29 @@ type Mount struct {
 	SuperOptions   map[string]string
 }
 
+// FileDescriptor describes an open file descriptor of a process
+type FileDescriptor struct {
+	// The file descriptor number
+	FD int
+
+	// Path is the path of the file that the descriptor refers to, as
+	// shown by its /proc/[pid]/fd link, e.g. "socket:[12345]" for
+	// sockets.
+	Path string
+}
+
+// ResourceUsage describes the resources used by a task
+type ResourceUsage struct {
+	// The CPU time that the task has spent in user and kernel mode
+	UserTime   time.Duration
+	SystemTime time.Duration
+
+	// The number of page faults that the task has made that did not
+	// and did require loading a page from disk
+	MinorFaults uint64
+	MajorFaults uint64
+}
+
 // ControlGroup describes the cgroup membership of a process
 type ControlGroup struct {
 	// Unique hierarchy ID
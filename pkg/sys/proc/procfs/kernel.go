@@ -16,6 +16,7 @@ package procfs
 
 import (
 	"bufio"
+	"bytes"
 	"os"
 	"path/filepath"
 	"strings"
@@ -54,3 +55,22 @@ func (fs *FileSystem) KernelTextSymbolNames() (map[string]string, error) {
 
 	return symbols, nil
 }
+
+// KernelModuleNames returns the names of the currently loaded kernel modules
+// in the order that the kernel lists them.
+func (fs *FileSystem) KernelModuleNames() ([]string, error) {
+	data, err := fs.ReadFile("modules")
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 0 {
+			names = append(names, fields[0])
+		}
+	}
+	return names, nil
+}
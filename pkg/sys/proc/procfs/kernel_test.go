@@ -33,3 +33,12 @@ func TestKernelTextSymbolNames(t *testing.T) {
 	ok(t, err)
 	equals(t, expectedSymbols, actualSymbols)
 }
+
+func TestKernelModuleNames(t *testing.T) {
+	fs, err := NewFileSystem("testdata")
+	ok(t, err)
+
+	actualNames, err := fs.KernelModuleNames()
+	ok(t, err)
+	equals(t, []string{"xt_conntrack", "nf_nat", "overlay"}, actualNames)
+}
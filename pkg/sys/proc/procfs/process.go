@@ -22,12 +22,15 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/capsule8/capsule8/pkg/sys/proc"
 
@@ -89,6 +92,95 @@ func (fs *FileSystem) ProcessCommandLine(pid int) ([]string, error) {
 	return commandLine, nil
 }
 
+// ProcessExecutable returns the path of the executable that the process
+// indicated by the given PID is running.
+func (fs *FileSystem) ProcessExecutable(pid int) (string, error) {
+	return os.Readlink(fmt.Sprintf("%s/%d/exe", fs.MountPoint, pid))
+}
+
+// ProcessEnvironment returns the named environment variables of the process
+// indicated by the given PID as they were when it exec'd. Only the values of
+// the named variables are copied out of the environment.
+func (fs *FileSystem) ProcessEnvironment(
+	pid int,
+	names []string,
+) (map[string]string, error) {
+	filename := fmt.Sprintf("%d/environ", pid)
+	data, err := fs.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	env := make(map[string]string)
+	for _, s := range bytes.Split(data, []byte{0}) {
+		i := bytes.IndexByte(s, '=')
+		if i <= 0 {
+			continue
+		}
+		for _, name := range names {
+			if string(s[:i]) == name {
+				env[name] = string(s[i+1:])
+				break
+			}
+		}
+	}
+
+	return env, nil
+}
+
+// ProcessFileDescriptorPath returns the path of the file that the specified
+// file descriptor of the process indicated by the given PID refers to.
+func (fs *FileSystem) ProcessFileDescriptorPath(pid, fd int) (string, error) {
+	return os.Readlink(fmt.Sprintf("%s/%d/fd/%d", fs.MountPoint, pid, fd))
+}
+
+// ProcessFileDescriptors returns up to max of the open file descriptors of the
+// specified process, lowest first, and whether it had more. Only max+1
+// directory entries are read, so the cost is bounded for processes with large
+// file descriptor tables.
+func (fs *FileSystem) ProcessFileDescriptors(
+	pid, max int,
+) ([]proc.FileDescriptor, bool, error) {
+	if max <= 0 {
+		return nil, false, fmt.Errorf("Invalid maximum %d", max)
+	}
+	dirname := fmt.Sprintf("%s/%d/fd", fs.MountPoint, pid)
+	d, err := os.Open(dirname)
+	if err != nil {
+		return nil, false, err
+	}
+	names, err := d.Readdirnames(max + 1)
+	d.Close()
+	if err != nil && err != io.EOF {
+		return nil, false, err
+	}
+
+	truncated := len(names) > max
+	if truncated {
+		names = names[:max]
+	}
+	fds := make([]proc.FileDescriptor, 0, len(names))
+	for _, name := range names {
+		fd, err := strconv.Atoi(name)
+		if err != nil {
+			continue
+		}
+		path, err := os.Readlink(filepath.Join(dirname, name))
+		if err != nil {
+			// The descriptor was closed after it was listed
+			continue
+		}
+		fds = append(fds, proc.FileDescriptor{
+			FD:   fd,
+			Path: path,
+		})
+	}
+	sort.Slice(fds, func(i, j int) bool {
+		return fds[i].FD < fds[j].FD
+	})
+	return fds, truncated, nil
+}
+
 // TaskControlGroups returns the cgroup membership of the specified task.
 func (fs *FileSystem) TaskControlGroups(tgid, pid int) ([]proc.ControlGroup, error) {
 	filename := fmt.Sprintf("%d/task/%d/cgroup", tgid, pid)
@@ -127,6 +219,62 @@ func (fs *FileSystem) TaskCWD(tgid, pid int) (string, error) {
 		fs.MountPoint, tgid, pid))
 }
 
+// TaskMountNamespace returns the inode number identifying the mount namespace
+// of the specified task.
+func (fs *FileSystem) TaskMountNamespace(tgid, pid int) (uint64, error) {
+	link, err := os.Readlink(fmt.Sprintf("%s/%d/task/%d/ns/mnt",
+		fs.MountPoint, tgid, pid))
+	if err != nil {
+		return 0, err
+	}
+	return parseNamespaceLink("mnt", link)
+}
+
+// parseNamespaceLink returns the inode number in the target of a namespace
+// link, which is of the form "mnt:[4026531840]".
+func parseNamespaceLink(nsType, link string) (uint64, error) {
+	prefix := nsType + ":["
+	if !strings.HasPrefix(link, prefix) || !strings.HasSuffix(link, "]") {
+		return 0, fmt.Errorf("Unrecognized %s namespace link %q",
+			nsType, link)
+	}
+	return strconv.ParseUint(link[len(prefix):len(link)-1], 10, 64)
+}
+
+// TaskNamespaces returns the inode numbers identifying the namespaces of the
+// specified task, by type, e.g. "mnt" or "net". The namespaces that the task's
+// children will be created in, such as "pid_for_children", are not included.
+func (fs *FileSystem) TaskNamespaces(tgid, pid int) (map[string]uint64, error) {
+	dir := fmt.Sprintf("%s/%d/task/%d/ns", fs.MountPoint, tgid, pid)
+	d, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	names, err := d.Readdirnames(-1)
+	d.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make(map[string]uint64, len(names))
+	for _, name := range names {
+		if strings.HasSuffix(name, "_for_children") {
+			continue
+		}
+		link, err := os.Readlink(filepath.Join(dir, name))
+		if err != nil {
+			// The task has exited
+			return nil, err
+		}
+		ino, err := parseNamespaceLink(name, link)
+		if err != nil {
+			return nil, err
+		}
+		namespaces[name] = ino
+	}
+	return namespaces, nil
+}
+
 // TaskStartTime returns the time at which the specified task started.
 func (fs *FileSystem) TaskStartTime(tgid, pid int) (int64, error) {
 	filename := fmt.Sprintf("%d/task/%d/stat", tgid, pid)
@@ -169,6 +317,68 @@ func (fs *FileSystem) TaskStartTime(tgid, pid int) (int64, error) {
 	return i, nil
 }
 
+// The kernel reports CPU times in /proc in units of USER_HZ, which is 100 on
+// all architectures that Linux supports.
+const userHZ = 100
+
+// TaskResourceUsage returns the resources used by the specified task.
+func (fs *FileSystem) TaskResourceUsage(tgid, pid int) (proc.ResourceUsage, error) {
+	filename := fmt.Sprintf("%d/task/%d/stat", tgid, pid)
+	b, err := fs.ReadFile(filename)
+	if err != nil {
+		return proc.ResourceUsage{}, err
+	}
+	data := string(b)
+
+	// The fields following the command, which is between the first '('
+	// and the last ')', start with the state, which is field 3 as
+	// numbered by proc(5).
+	lastRParen := strings.LastIndexByte(data, ')')
+	if lastRParen < 0 {
+		return proc.ResourceUsage{}, fmt.Errorf("Malformed %s", filename)
+	}
+	fields := strings.Fields(data[lastRParen+1:])
+	if len(fields) < 13 {
+		return proc.ResourceUsage{}, fmt.Errorf("Malformed %s", filename)
+	}
+
+	var values [4]uint64
+	for i, f := range []int{10, 12, 14, 15} {
+		values[i], err = strconv.ParseUint(fields[f-3], 10, 64)
+		if err != nil {
+			return proc.ResourceUsage{}, err
+		}
+	}
+	return proc.ResourceUsage{
+		MinorFaults: values[0],
+		MajorFaults: values[1],
+		UserTime:    time.Duration(values[2]) * time.Second / userHZ,
+		SystemTime:  time.Duration(values[3]) * time.Second / userHZ,
+	}, nil
+}
+
+// TaskFlags returns the kernel flags (PF_*) of the specified task.
+func (fs *FileSystem) TaskFlags(tgid, pid int) (uint64, error) {
+	filename := fmt.Sprintf("%d/task/%d/stat", tgid, pid)
+	b, err := fs.ReadFile(filename)
+	if err != nil {
+		return 0, err
+	}
+	data := string(b)
+
+	// The flags are field 9 as numbered by proc(5), counting from the
+	// state following the command.
+	lastRParen := strings.LastIndexByte(data, ')')
+	if lastRParen < 0 {
+		return 0, fmt.Errorf("Malformed %s", filename)
+	}
+	fields := strings.Fields(data[lastRParen+1:])
+	if len(fields) < 7 {
+		return 0, fmt.Errorf("Malformed %s", filename)
+	}
+	return strconv.ParseUint(fields[9-3], 10, 64)
+}
+
 // TaskUniqueID returns a unique task ID for a PID.
 func (fs *FileSystem) TaskUniqueID(tgid, pid int, startTime int64) (string, error) {
 	// Do not use tgid here, because the TGID for a PID can change. The
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"github.com/capsule8/capsule8/pkg/sys/proc"
 	"testing"
+	"time"
 )
 
 func TestProcessContainerID(t *testing.T) {
@@ -129,6 +130,73 @@ func TestTaskControlGroups(t *testing.T) {
 	assert(t, err != nil, "Expected non-nil error return")
 }
 
+func TestProcessExecutable(t *testing.T) {
+	fs, err := NewFileSystem("testdata")
+	ok(t, err)
+
+	exe, err := fs.ProcessExecutable(1)
+	ok(t, err)
+	equals(t, "/sbin/init", exe)
+
+	_, err = fs.ProcessExecutable(322)
+	assert(t, err != nil, "Expected non-nil error return")
+}
+
+func TestProcessEnvironment(t *testing.T) {
+	fs, err := NewFileSystem("testdata")
+	ok(t, err)
+
+	expectedEnv := map[string]string{
+		"HOME":       "/",
+		"LD_PRELOAD": "",
+		"BOOT_IMAGE": "/vmlinuz=x",
+	}
+	env, err := fs.ProcessEnvironment(1,
+		[]string{"HOME", "LD_PRELOAD", "BOOT_IMAGE", "PATH"})
+	ok(t, err)
+	equals(t, expectedEnv, env)
+
+	env, err = fs.ProcessEnvironment(1, nil)
+	ok(t, err)
+	equals(t, map[string]string{}, env)
+
+	_, err = fs.ProcessEnvironment(322, []string{"HOME"})
+	assert(t, err != nil, "Expected non-nil error return")
+}
+
+func TestProcessFileDescriptorPath(t *testing.T) {
+	fs, err := NewFileSystem("testdata")
+	ok(t, err)
+
+	path, err := fs.ProcessFileDescriptorPath(1, 3)
+	ok(t, err)
+	equals(t, "/etc", path)
+
+	_, err = fs.ProcessFileDescriptorPath(1, 4)
+	assert(t, err != nil, "Expected non-nil error return")
+}
+
+func TestProcessFileDescriptors(t *testing.T) {
+	fs, err := NewFileSystem("testdata")
+	ok(t, err)
+
+	fds, truncated, err := fs.ProcessFileDescriptors(1, 10)
+	ok(t, err)
+	equals(t, []proc.FileDescriptor{
+		{FD: 3, Path: "/etc"},
+		{FD: 5, Path: "/dev/null"},
+	}, fds)
+	assert(t, !truncated, "Expected complete file descriptors")
+
+	fds, truncated, err = fs.ProcessFileDescriptors(1, 1)
+	ok(t, err)
+	equals(t, 1, len(fds))
+	assert(t, truncated, "Expected truncated file descriptors")
+
+	_, _, err = fs.ProcessFileDescriptors(322, 10)
+	assert(t, err != nil, "Expected non-nil error return")
+}
+
 func TestTaskCWD(t *testing.T) {
 	fs, err := NewFileSystem("testdata")
 	ok(t, err)
@@ -145,6 +213,33 @@ func TestTaskCWD(t *testing.T) {
 	assert(t, err != nil, "Expected non-nil error return")
 }
 
+func TestTaskMountNamespace(t *testing.T) {
+	fs, err := NewFileSystem("testdata")
+	ok(t, err)
+
+	ns, err := fs.TaskMountNamespace(1, 1)
+	ok(t, err)
+	equals(t, uint64(4026531840), ns)
+
+	_, err = fs.TaskMountNamespace(322, 223)
+	assert(t, err != nil, "Expected non-nil error return")
+}
+
+func TestTaskNamespaces(t *testing.T) {
+	fs, err := NewFileSystem("testdata")
+	ok(t, err)
+
+	namespaces, err := fs.TaskNamespaces(1, 1)
+	ok(t, err)
+	equals(t, map[string]uint64{
+		"mnt": 4026531840,
+		"net": 4026531993,
+	}, namespaces)
+
+	_, err = fs.TaskNamespaces(322, 223)
+	assert(t, err != nil, "Expected non-nil error return")
+}
+
 func TestStartTime(t *testing.T) {
 	fs, err := NewFileSystem("testdata")
 	ok(t, err)
@@ -157,6 +252,35 @@ func TestStartTime(t *testing.T) {
 	assert(t, err != nil, "Expected non-nil error return")
 }
 
+func TestTaskResourceUsage(t *testing.T) {
+	fs, err := NewFileSystem("testdata")
+	ok(t, err)
+
+	usage, err := fs.TaskResourceUsage(405, 405)
+	ok(t, err)
+	equals(t, proc.ResourceUsage{
+		UserTime:    1500 * time.Millisecond,
+		SystemTime:  250 * time.Millisecond,
+		MinorFaults: 34,
+		MajorFaults: 2,
+	}, usage)
+
+	_, err = fs.TaskResourceUsage(322, 223)
+	assert(t, err != nil, "Expected non-nil error return")
+}
+
+func TestTaskFlags(t *testing.T) {
+	fs, err := NewFileSystem("testdata")
+	ok(t, err)
+
+	flags, err := fs.TaskFlags(405, 405)
+	ok(t, err)
+	equals(t, uint64(1077936192), flags)
+
+	_, err = fs.TaskFlags(322, 223)
+	assert(t, err != nil, "Expected non-nil error return")
+}
+
 func TestTaskUniqueID(t *testing.T) {
 	fs, err := NewFileSystem("testdata")
 	ok(t, err)
@@ -25,3 +25,11 @@ func CurrentMonotonicRaw() int64 {
 	unix.ClockGettime(unix.CLOCK_MONOTONIC_RAW, &ts)
 	return ts.Nano()
 }
+
+// CurrentClockTime returns the current time of the specified clock (e.g.,
+// unix.CLOCK_BOOTTIME) as an integer.
+func CurrentClockTime(clockID int32) int64 {
+	var ts unix.Timespec
+	unix.ClockGettime(clockID, &ts)
+	return ts.Nano()
+}